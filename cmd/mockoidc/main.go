@@ -0,0 +1,40 @@
+// Package main chạy mock OIDC provider cho môi trường phát triển
+//
+// Cách dùng:
+//
+//	go run ./cmd/mockoidc -addr :9000 -issuer http://localhost:9000
+//
+// Sau đó cấu hình API: OIDC_GOOGLE_ENABLED=true, OIDC_GOOGLE_ISSUER_URL=http://localhost:9000,
+// OIDC_GOOGLE_CLIENT_ID/OIDC_GOOGLE_CLIENT_SECRET tùy ý
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"restaurant_project/pkg/mockoidc"
+)
+
+func main() {
+	addr := flag.String("addr", ":9000", "Địa chỉ lắng nghe")
+	issuer := flag.String("issuer", "http://localhost:9000", "Issuer URL (URL gốc mà client truy cập server)")
+	flag.Parse()
+
+	server, err := mockoidc.NewServer(*issuer)
+	if err != nil {
+		log.Fatalf("Failed to create mock OIDC server: %v", err)
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           server,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("Mock OIDC provider listening on %s (issuer %s)", *addr, *issuer)
+	if err := httpServer.ListenAndServe(); err != nil {
+		log.Fatalf("Mock OIDC server stopped: %v", err)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"go.uber.org/zap"
 
 	"restaurant_project/internal/di"
+	"restaurant_project/internal/infrastructure/middleware"
 	"restaurant_project/pkg/logger"
 )
 
@@ -34,6 +35,18 @@ func NewRunner(app *di.App) *Runner {
 // Run khởi chạy application
 func (r *Runner) Run() error {
 	r.setupServer()
+
+	// Khởi chạy worker pool của job queue (gửi email nền,...)
+	if err := r.app.JobQueue.Start(context.Background()); err != nil {
+		return fmt.Errorf("job queue start error: %w", err)
+	}
+
+	// Khởi chạy outbox relay (phát domain event tới các consumer nội bộ)
+	r.app.OutboxRelay.Start()
+
+	// Khởi chạy scheduler (tác vụ định kỳ, khóa phân tán trên Redis)
+	r.app.Scheduler.Start()
+
 	return r.runWithGracefulShutdown()
 }
 
@@ -111,6 +124,10 @@ func (r *Runner) registerRoutes(router *gin.Engine) {
 	// Swagger endpoints - đăng ký trực tiếp trên router
 	r.app.SwaggerHandler.RegisterRoutesOnEngine(router)
 
+	// JWKS endpoint (không có prefix /api) - service khác verify JWT bằng public key
+	jwksGroup := router.Group(r.app.JWKSHandler.BasePath())
+	r.app.JWKSHandler.RegisterRoutes(jwksGroup)
+
 	// API routes
 	api := router.Group("/api")
 	{
@@ -131,14 +148,81 @@ func (r *Runner) registerRoutes(router *gin.Engine) {
 		r.app.MonAnHandler.RegisterRoutes(monAnGroup)
 
 		// MonAn protected routes (PROTECTED - thêm/sửa/xóa món)
+		// Idempotency-Key chống gửi trùng khi thêm món (mạng chập chờn, bấm lại)
 		monAnProtectedGroup := api.Group(r.app.MonAnHandler.BasePath())
 		monAnProtectedGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		monAnProtectedGroup.Use(r.app.Middlewares.Idempotency.Middleware())
 		r.app.MonAnHandler.RegisterProtectedRoutes(monAnProtectedGroup)
 
 		// User routes (PROTECTED - cần JWT)
 		userGroup := api.Group(r.app.UserHandler.BasePath())
 		userGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
 		r.app.UserHandler.RegisterRoutes(userGroup)
+
+		// Kho & mua hàng routes (PROTECTED - inventory:manage, purchasing:manage)
+		ingredientGroup := api.Group(r.app.NguyenLieuHandler.BasePath())
+		ingredientGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.NguyenLieuHandler.RegisterRoutes(ingredientGroup)
+
+		supplierGroup := api.Group(r.app.NhaCungCapHandler.BasePath())
+		supplierGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.NhaCungCapHandler.RegisterRoutes(supplierGroup)
+
+		purchaseOrderGroup := api.Group(r.app.DonDatHangHandler.BasePath())
+		purchaseOrderGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.DonDatHangHandler.RegisterRoutes(purchaseOrderGroup)
+
+		// Order routes (PROTECTED - đặt món cần đăng nhập)
+		// Tạo đơn bắt buộc Idempotency-Key: gửi lại khi mất kết nối không được tạo (và tính tiền) hai đơn
+		orderGroup := api.Group(r.app.OrderHandler.BasePath())
+		orderGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		orderGroup.Use(r.app.Middlewares.Idempotency.Middleware(middleware.IdempotencyKeyRequired()))
+		r.app.OrderHandler.RegisterRoutes(orderGroup)
+
+		// Khuyến mãi routes (PROTECTED - promotion:manage)
+		promotionGroup := api.Group(r.app.KhuyenMaiHandler.BasePath())
+		promotionGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.KhuyenMaiHandler.RegisterRoutes(promotionGroup)
+
+		// Mã giảm giá routes (PROTECTED - promotion:manage)
+		voucherGroup := api.Group(r.app.MaGiamGiaHandler.BasePath())
+		voucherGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.MaGiamGiaHandler.RegisterRoutes(voucherGroup)
+
+		// Job queue routes (PROTECTED - job:manage)
+		jobGroup := api.Group(r.app.JobHandler.BasePath())
+		jobGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.JobHandler.RegisterRoutes(jobGroup)
+
+		// Scheduler routes (PROTECTED - scheduler:manage)
+		schedulerGroup := api.Group(r.app.SchedulerHandler.BasePath())
+		schedulerGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.SchedulerHandler.RegisterRoutes(schedulerGroup)
+
+		// Báo cáo routes (PROTECTED - report:read)
+		reportGroup := api.Group(r.app.BaoCaoHandler.BasePath())
+		reportGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.BaoCaoHandler.RegisterRoutes(reportGroup)
+
+		// Permission routes (PROTECTED - permission:manage)
+		permissionGroup := api.Group(r.app.PermissionHandler.BasePath())
+		permissionGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.PermissionHandler.RegisterRoutes(permissionGroup)
+
+		// Audit log routes (PROTECTED - audit:read)
+		auditLogGroup := api.Group(r.app.AuditLogHandler.BasePath())
+		auditLogGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.AuditLogHandler.RegisterRoutes(auditLogGroup)
+
+		// Bản ghi đã xóa mềm routes (PROTECTED - deleted:restore)
+		deletedGroup := api.Group(r.app.XoaMemHandler.BasePath())
+		deletedGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.XoaMemHandler.RegisterRoutes(deletedGroup)
+
+		// Webhook routes (PROTECTED - webhook:manage)
+		webhookGroup := api.Group(r.app.WebhookHandler.BasePath())
+		webhookGroup.Use(r.app.Middlewares.JWTAuth.Middleware())
+		r.app.WebhookHandler.RegisterRoutes(webhookGroup)
 	}
 
 	logger.Debug("Routes registered successfully")
@@ -152,29 +236,111 @@ func (r *Runner) handleRoot(c *gin.Context) {
 		"di":      "Google Wire",
 		"logger":  "Uber Zap",
 		"endpoints": gin.H{
-			"GET /swagger/index.html":       "Swagger UI",
-			"GET /health":                   "Full health check",
-			"GET /health/live":              "Liveness probe",
-			"GET /health/ready":             "Readiness probe",
-			"GET /api/mon-an":               "List all dishes",
-			"GET /api/mon-an?con_hang=true": "List available dishes",
-			"GET /api/mon-an/:id":           "Get dish by ID",
-			"POST /api/mon-an":              "Create new dish",
-			"PUT /api/mon-an/:id/gia":       "Update price",
-			"PUT /api/mon-an/:id/giam-gia":  "Apply discount",
-			"PUT /api/mon-an/:id/het-hang":  "Mark as out of stock",
-			"DELETE /api/mon-an/:id":        "Delete dish",
-			"POST /api/auth/register":       "Register new customer",
-			"POST /api/auth/login":          "Login",
-			"POST /api/auth/refresh":        "Refresh access token",
-			"POST /api/auth/logout":         "Logout (revoke token) [Auth]",
-			"GET /api/users/me":             "Get current user [Auth]",
-			"PUT /api/users/me/password":    "Change password [Auth]",
-			"GET /api/users":                "List all users [Manager+]",
-			"POST /api/users":               "Create user [Manager+]",
-			"GET /api/users/:id":            "Get user by ID [Manager+]",
-			"PUT /api/users/:id":            "Update user [Manager+]",
-			"DELETE /api/users/:id":         "Deactivate user [Admin]",
+			"GET /swagger/index.html":                                       "Swagger UI",
+			"GET /health":                                                   "Full health check",
+			"GET /health/live":                                              "Liveness probe",
+			"GET /health/ready":                                             "Readiness probe",
+			"GET /api/mon-an":                                               "List all dishes",
+			"GET /api/mon-an?con_hang=true":                                 "List available dishes",
+			"GET /api/mon-an/:id":                                           "Get dish by ID",
+			"POST /api/mon-an":                                              "Create new dish [menu:write]",
+			"PUT /api/mon-an/:id/gia":                                       "Update price [menu:write]",
+			"PUT /api/mon-an/:id/giam-gia":                                  "Apply discount [menu:write]",
+			"PUT /api/mon-an/:id/het-hang":                                  "Mark as out of stock [menu:availability:write]",
+			"PUT /api/mon-an/:id/lich-ban":                                  "Set serving schedule [menu:write]",
+			"PUT /api/mon-an/:id/tuy-chon":                                  "Set modifier groups (size, toppings,...) [menu:write]",
+			"DELETE /api/mon-an/:id":                                        "Soft-delete dish (restorable until purged) [menu:write]",
+			"GET /api/mon-an/:id/lich-su-gia":                               "Price history (?tu=&den= RFC3339) [report:read]",
+			"GET /api/mon-an/:id/lich-doi-gia":                              "List scheduled price changes [menu:write]",
+			"POST /api/mon-an/:id/lich-doi-gia":                             "Schedule a future price change [menu:write]",
+			"PUT /api/mon-an/:id/lich-doi-gia/:lichId/huy":                  "Cancel a pending price change [menu:write]",
+			"GET /.well-known/jwks.json":                                    "Public keys for verifying JWTs",
+			"POST /api/auth/register":                                       "Register new customer",
+			"POST /api/auth/login":                                          "Login",
+			"POST /api/auth/refresh":                                        "Refresh access token",
+			"POST /api/auth/forgot-password":                                "Request password reset email",
+			"POST /api/auth/reset-password":                                 "Reset password with emailed token",
+			"GET /api/auth/sessions":                                        "List active sessions [Auth]",
+			"DELETE /api/auth/sessions/:id":                                 "Revoke one session [Auth]",
+			"POST /api/auth/logout":                                         "Logout (revoke token) [Auth]",
+			"POST /api/auth/mfa/verify":                                     "Complete login with TOTP or recovery code",
+			"POST /api/auth/mfa/setup":                                      "Enroll TOTP during login when 2FA is required",
+			"GET /api/auth/mfa":                                             "Get 2FA status [Auth]",
+			"POST /api/auth/mfa/enroll":                                     "Start TOTP enrollment [Auth]",
+			"POST /api/auth/mfa/confirm":                                    "Confirm TOTP enrollment [Auth]",
+			"POST /api/auth/mfa/disable":                                    "Disable 2FA [Auth]",
+			"GET /api/auth/oidc/providers":                                  "List enabled external login providers",
+			"POST /api/auth/oidc/:provider/authorize":                       "Start external login (OIDC + PKCE)",
+			"POST /api/auth/oidc/callback":                                  "Complete external login with code and state",
+			"GET /api/auth/identities":                                      "List linked external identities [Auth]",
+			"POST /api/auth/identities/:provider/authorize":                 "Start linking an external identity [Auth]",
+			"POST /api/auth/identities/link":                                "Complete linking an external identity [Auth]",
+			"DELETE /api/auth/identities/:provider":                         "Unlink an external identity [Auth]",
+			"GET /api/users/me":                                             "Get current user [Auth]",
+			"PUT /api/users/me/password":                                    "Change password [Auth]",
+			"GET /api/users":                                                "List all users [user:read]",
+			"POST /api/users":                                               "Create user [user:write]",
+			"POST /api/users/staff":                                         "Create staff user + NhanVien profile atomically [user:write, salary needs staff:salary:write]",
+			"GET /api/users/:id":                                            "Get user by ID [user:read]",
+			"PUT /api/users/:id":                                            "Update user [user:write]",
+			"DELETE /api/users/:id":                                         "Deactivate user [user:deactivate]",
+			"GET /api/users/:id/sessions":                                   "List user's sessions [user:session:manage]",
+			"DELETE /api/users/:id/sessions":                                "Revoke all user's sessions [user:session:manage]",
+			"DELETE /api/users/:id/sessions/:sessionId":                     "Revoke one user's session [user:session:manage]",
+			"GET /api/ingredients":                                          "List ingredients with stock [inventory:manage]",
+			"POST /api/ingredients":                                         "Create ingredient [inventory:manage]",
+			"POST /api/ingredients/:id/consume":                             "Record ingredient consumption [inventory:manage]",
+			"GET /api/suppliers":                                            "List suppliers [purchasing:manage]",
+			"POST /api/suppliers":                                           "Create supplier [purchasing:manage]",
+			"GET /api/suppliers/:id":                                        "Get supplier by ID [purchasing:manage]",
+			"PUT /api/suppliers/:id":                                        "Update supplier [purchasing:manage]",
+			"GET /api/purchase-orders":                                      "List purchase orders [purchasing:manage]",
+			"POST /api/purchase-orders":                                     "Create draft purchase order [purchasing:manage]",
+			"GET /api/purchase-orders/suggestions":                          "Suggested reorder report [purchasing:manage]",
+			"GET /api/purchase-orders/:id":                                  "Get purchase order [purchasing:manage]",
+			"POST /api/purchase-orders/:id/items":                           "Add item to draft [purchasing:manage]",
+			"PUT /api/purchase-orders/:id/send":                             "Send to supplier [purchasing:manage]",
+			"POST /api/purchase-orders/:id/receive":                         "Receive goods [purchasing:manage]",
+			"PUT /api/purchase-orders/:id/cancel":                           "Cancel purchase order [purchasing:manage]",
+			"POST /api/orders":                                              "Place order (scheduled dishes, best promotions applied) [Auth, Idempotency-Key required]",
+			"GET /api/orders/pending":                                       "List pending orders [order:read]",
+			"GET /api/orders/mine":                                          "List my orders [Auth]",
+			"GET /api/orders/:id":                                           "Get order by ID [order:read or own order]",
+			"PUT /api/orders/:id/status":                                    "Advance order status [order:status:write or assigned chef]",
+			"PUT /api/orders/:id/chef":                                      "Assign chef to order [order:assign]",
+			"GET /api/orders/:id/kitchen-ticket":                            "Kitchen ticket with selected options [order:read]",
+			"PUT /api/orders/:id/cancel":                                    "Cancel order, release voucher [order:cancel]",
+			"GET /api/promotions":                                           "List promotions [promotion:manage]",
+			"POST /api/promotions":                                          "Create promotion [promotion:manage]",
+			"GET /api/promotions/:id":                                       "Get promotion by ID [promotion:manage]",
+			"PUT /api/promotions/:id/deactivate":                            "Deactivate promotion [promotion:manage]",
+			"GET /api/vouchers?chien_dich=":                                 "List vouchers of a campaign [promotion:manage]",
+			"POST /api/vouchers/generate":                                   "Bulk-generate voucher codes [promotion:manage]",
+			"GET /api/vouchers/stats?chien_dich=":                           "Voucher redemption stats [promotion:manage]",
+			"GET /api/vouchers/:ma":                                         "Get voucher by code [promotion:manage]",
+			"GET /api/admin/jobs/stats":                                     "Job queue stats [job:manage]",
+			"GET /api/admin/jobs/dead-letter":                               "List failed jobs [job:manage]",
+			"POST /api/admin/jobs/dead-letter/:id/replay":                   "Replay failed job [job:manage]",
+			"GET /api/admin/scheduler/tasks":                                "Scheduled tasks with last run and last error [scheduler:manage]",
+			"GET /api/admin/scheduler/tasks/:ten/runs":                      "Scheduled task run history [scheduler:manage]",
+			"GET /api/reports/daily-revenue?tu_ngay=&den_ngay=":             "Daily revenue snapshots [report:read]",
+			"GET /api/admin/permissions":                                    "Permission catalog [permission:manage]",
+			"GET /api/admin/permissions/roles":                              "Permissions of every role [permission:manage]",
+			"PUT /api/admin/permissions/roles/:role":                        "Replace a role's permissions [permission:manage]",
+			"GET /api/admin/audit-logs":                                     "Audit log of privileged actions, filterable and paginated [audit:read]",
+			"GET /api/admin/deleted/dishes":                                 "List soft-deleted dishes [deleted:restore]",
+			"POST /api/admin/deleted/dishes/:id/restore":                    "Restore a soft-deleted dish [deleted:restore]",
+			"GET /api/admin/deleted/customers":                              "List soft-deleted customers [deleted:restore]",
+			"POST /api/admin/deleted/customers/:id/restore":                 "Restore a soft-deleted customer [deleted:restore]",
+			"GET /api/admin/deleted/staff":                                  "List soft-deleted staff [deleted:restore]",
+			"POST /api/admin/deleted/staff/:id/restore":                     "Restore a soft-deleted staff member [deleted:restore]",
+			"GET /api/admin/webhooks":                                       "List registered webhooks [webhook:manage]",
+			"POST /api/admin/webhooks":                                      "Register a webhook with event filter and signing secret [webhook:manage]",
+			"GET /api/admin/webhooks/:id":                                   "Webhook details [webhook:manage]",
+			"PUT /api/admin/webhooks/:id":                                   "Update, pause or resume a webhook [webhook:manage]",
+			"DELETE /api/admin/webhooks/:id":                                "Delete a webhook [webhook:manage]",
+			"GET /api/admin/webhooks/:id/deliveries":                        "Delivery log of a webhook, filterable and paginated [webhook:manage]",
+			"POST /api/admin/webhooks/:id/deliveries/:deliveryId/redeliver": "Redeliver an event to a webhook [webhook:manage]",
 		},
 	})
 }
@@ -236,6 +402,26 @@ func (r *Runner) shutdown() error {
 		logger.Info("HTTP server stopped")
 	}
 
+	// Dừng scheduler trước job queue (tác vụ đang chạy có thể còn enqueue email)
+	logger.Info("Stopping scheduler...")
+	if err := r.app.Scheduler.Stop(ctx); err != nil {
+		logger.Error("Scheduler shutdown error", zap.Error(err))
+	}
+
+	// Dừng outbox relay trước job queue (consumer có thể còn enqueue job);
+	// sự kiện chưa phát vẫn nằm trong outbox và được phát ở lần khởi động sau
+	logger.Info("Stopping outbox relay...")
+	if err := r.app.OutboxRelay.Stop(ctx); err != nil {
+		logger.Error("Outbox relay shutdown error", zap.Error(err))
+	}
+
+	// Shutdown job queue sau HTTP server (request cuối vẫn enqueue được),
+	// trước databases (job đang chạy vẫn cần Redis)
+	logger.Info("Stopping job queue...")
+	if err := r.app.JobQueue.Stop(ctx); err != nil {
+		logger.Error("Job queue shutdown error", zap.Error(err))
+	}
+
 	// Shutdown databases
 	logger.Info("Closing database connections...")
 	if err := r.app.DBManager.Shutdown(ctx); err != nil {
@@ -0,0 +1,67 @@
+// Package scheduler chạy các tác vụ định kỳ (cron-like) với khóa phân tán trên Redis
+package scheduler
+
+import (
+	"fmt"
+	"time"
+)
+
+// LichChay xác định các thời điểm chạy của một tác vụ
+// Mọi instance phải tính ra cùng một thời điểm cho cùng một lần chạy (slot),
+// vì slot được dùng làm khóa chống chạy trùng
+type LichChay interface {
+	// Tiep trả về thời điểm chạy đầu tiên sau thời điểm sau
+	Tiep(sau time.Time) time.Time
+
+	// String mô tả lịch (hiển thị trên trang admin)
+	String() string
+}
+
+// moiKhoang chạy theo chu kỳ cố định, căn theo mốc Unix để các instance cùng slot
+type moiKhoang struct {
+	chuKy time.Duration
+}
+
+// MoiKhoang tạo lịch chạy mỗi chuKy (VD: mỗi giờ chạy vào phút 00)
+func MoiKhoang(chuKy time.Duration) (LichChay, error) {
+	if chuKy < time.Second {
+		return nil, fmt.Errorf("chu kỳ chạy phải từ 1 giây trở lên, nhận %s", chuKy)
+	}
+	return moiKhoang{chuKy: chuKy}, nil
+}
+
+func (l moiKhoang) Tiep(sau time.Time) time.Time {
+	return sau.Truncate(l.chuKy).Add(l.chuKy)
+}
+
+func (l moiKhoang) String() string {
+	return "mỗi " + l.chuKy.String()
+}
+
+// hangNgay chạy mỗi ngày vào một giờ cố định theo múi giờ nhà hàng
+type hangNgay struct {
+	gio, phut int
+	loc       *time.Location
+}
+
+// HangNgay tạo lịch chạy mỗi ngày lúc gioPhut ("HH:MM") theo múi giờ loc
+func HangNgay(gioPhut string, loc *time.Location) (LichChay, error) {
+	t, err := time.Parse("15:04", gioPhut)
+	if err != nil {
+		return nil, fmt.Errorf("giờ chạy %q không hợp lệ (định dạng HH:MM): %w", gioPhut, err)
+	}
+	return hangNgay{gio: t.Hour(), phut: t.Minute(), loc: loc}, nil
+}
+
+func (l hangNgay) Tiep(sau time.Time) time.Time {
+	sau = sau.In(l.loc)
+	next := time.Date(sau.Year(), sau.Month(), sau.Day(), l.gio, l.phut, 0, 0, l.loc)
+	if !next.After(sau) {
+		next = time.Date(sau.Year(), sau.Month(), sau.Day()+1, l.gio, l.phut, 0, 0, l.loc)
+	}
+	return next
+}
+
+func (l hangNgay) String() string {
+	return fmt.Sprintf("hằng ngày %02d:%02d (%s)", l.gio, l.phut, l.loc)
+}
@@ -0,0 +1,197 @@
+// Package scheduler chạy các tác vụ định kỳ (cron-like) với khóa phân tán trên Redis
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+)
+
+const (
+	// timeoutMacDinh là timeout của tác vụ không khai báo Timeout
+	timeoutMacDinh = 5 * time.Minute
+	// khoaDuPhong cộng thêm vào TTL khóa để khóa không hết hạn ngay khi tác vụ chạm timeout
+	khoaDuPhong = time.Minute
+	// storeTimeout giới hạn thời gian mỗi lệnh ghi trạng thái lên Redis
+	storeTimeout = 5 * time.Second
+)
+
+// TacVu là một tác vụ chạy định kỳ
+type TacVu struct {
+	Ten     string        // Tên duy nhất, dùng làm khóa Redis và hiển thị trên trang admin
+	Lich    LichChay      // Lịch chạy
+	Timeout time.Duration // Thời gian chạy tối đa của một lần (0 = 5 phút)
+
+	// Chay thực thi tác vụ, chuỗi trả về là tóm tắt kết quả lưu vào lịch sử
+	Chay func(ctx context.Context) (string, error)
+}
+
+// Scheduler chạy các tác vụ định kỳ
+// Mỗi instance đều chạy vòng lặp của mọi tác vụ, nhưng khóa trên Redis đảm bảo
+// mỗi slot (thời điểm theo lịch) chỉ được một instance thực thi.
+// Lần chạy bị lỡ khi không instance nào hoạt động sẽ không được chạy bù.
+type Scheduler struct {
+	store    service.ScheduledTaskStore
+	instance string
+	tacVu    []TacVu
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New tạo mới Scheduler
+func New(store service.ScheduledTaskStore) *Scheduler {
+	hostname, _ := os.Hostname()
+	return &Scheduler{
+		store:    store,
+		instance: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+}
+
+// Them đăng ký một tác vụ, phải gọi trước Start
+func (s *Scheduler) Them(t TacVu) {
+	if t.Timeout <= 0 {
+		t.Timeout = timeoutMacDinh
+	}
+	s.tacVu = append(s.tacVu, t)
+}
+
+// Start khởi chạy vòng lặp cho từng tác vụ
+func (s *Scheduler) Start() {
+	if len(s.tacVu) == 0 {
+		logger.Info("Scheduler has no tasks, not started")
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for _, t := range s.tacVu {
+		s.wg.Add(1)
+		go s.vongLap(ctx, t)
+	}
+
+	logger.Info("Scheduler started",
+		zap.Int("tasks", len(s.tacVu)),
+		zap.String("instance", s.instance),
+	)
+}
+
+// Stop dừng lên lịch và chờ các tác vụ đang chạy kết thúc
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logger.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler stop: %w", ctx.Err())
+	}
+}
+
+// vongLap chờ tới slot kế tiếp của tác vụ rồi chạy, lặp tới khi scheduler dừng
+func (s *Scheduler) vongLap(ctx context.Context, t TacVu) {
+	defer s.wg.Done()
+
+	for {
+		slot := t.Lich.Tiep(time.Now())
+		s.dangKy(t, slot)
+
+		timer := time.NewTimer(time.Until(slot))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.chayMotLan(t, slot)
+	}
+}
+
+// dangKy ghi lịch và lần chạy kế tiếp lên Redis cho trang admin
+func (s *Scheduler) dangKy(t TacVu, slot time.Time) {
+	ctx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	if err := s.store.RegisterTask(ctx, t.Ten, t.Lich.String(), slot); err != nil {
+		logger.Warn("Failed to register scheduled task", zap.String("tac_vu", t.Ten), zap.Error(err))
+	}
+}
+
+// chayMotLan giành khóa cho slot và chạy tác vụ nếu giành được
+// Dùng context riêng: tác vụ đang chạy được hoàn thành kể cả khi scheduler đang dừng
+func (s *Scheduler) chayMotLan(t TacVu, slot time.Time) {
+	lockCtx, lockCancel := context.WithTimeout(context.Background(), storeTimeout)
+	unlock, acquired, err := s.store.TryLock(lockCtx, t.Ten, slot, t.Timeout+khoaDuPhong)
+	lockCancel()
+	if err != nil {
+		logger.Error("Failed to acquire scheduler lock", zap.String("tac_vu", t.Ten), zap.Error(err))
+		return
+	}
+	if !acquired {
+		logger.Debug("Scheduled task taken by another instance", zap.String("tac_vu", t.Ten), zap.Time("slot", slot))
+		return
+	}
+	defer unlock()
+
+	taskLogger := logger.With(zap.String("tac_vu", t.Ten), zap.String("instance", s.instance))
+	ctx, cancel := context.WithTimeout(logger.WithContext(context.Background(), taskLogger), t.Timeout)
+	defer cancel()
+
+	run := service.TaskRun{
+		TacVu:    t.Ten,
+		LichChay: slot,
+		BatDau:   time.Now(),
+		Instance: s.instance,
+	}
+	ketQua, err := chayAnToan(ctx, t)
+	run.KetThuc = time.Now()
+	run.KetQua = ketQua
+	if err != nil {
+		run.Loi = err.Error()
+		taskLogger.Error("Scheduled task failed",
+			zap.Duration("duration", run.KetThuc.Sub(run.BatDau)),
+			zap.Error(err),
+		)
+	} else {
+		taskLogger.Info("Scheduled task completed",
+			zap.Duration("duration", run.KetThuc.Sub(run.BatDau)),
+			zap.String("ket_qua", ketQua),
+		)
+	}
+
+	recordCtx, recordCancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer recordCancel()
+	if err := s.store.RecordRun(recordCtx, run); err != nil {
+		taskLogger.Warn("Failed to record scheduled task run", zap.Error(err))
+	}
+}
+
+// chayAnToan chạy tác vụ, panic được chuyển thành error để ghi vào lịch sử
+func chayAnToan(ctx context.Context, t TacVu) (ketQua string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panic: %v", r)
+		}
+	}()
+
+	return t.Chay(ctx)
+}
@@ -0,0 +1,246 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/pkg/logger"
+)
+
+// Audit log use case errors
+var (
+	ErrKhoangThoiGianKhongHopLe = errors.New("thời điểm kết thúc phải sau thời điểm bắt đầu")
+)
+
+// AuditLogUseCase xử lý tra cứu audit log (Admin)
+// Audit log được ghi từ các use case thực hiện thao tác đặc quyền qua ghiAuditLog
+type AuditLogUseCase struct {
+	repo repository.IAuditLogRepository
+}
+
+// NewAuditLogUseCase tạo mới AuditLogUseCase
+func NewAuditLogUseCase(repo repository.IAuditLogRepository) *AuditLogUseCase {
+	return &AuditLogUseCase{repo: repo}
+}
+
+// XemAuditLogInput là điều kiện tra cứu audit log (trường rỗng/nil thì bỏ qua)
+type XemAuditLogInput struct {
+	ActorID    string
+	HanhDong   string
+	DoiTuong   string
+	DoiTuongID string
+	Tu         *time.Time
+	Den        *time.Time
+	Offset     int
+	Limit      int
+}
+
+// XemAuditLog tra cứu audit log theo điều kiện lọc có phân trang, mới nhất trước
+func (uc *AuditLogUseCase) XemAuditLog(ctx context.Context, input XemAuditLogInput) ([]*entity.AuditLog, int64, error) {
+	if input.Tu != nil && input.Den != nil && input.Den.Before(*input.Tu) {
+		return nil, 0, ErrKhoangThoiGianKhongHopLe
+	}
+
+	filter := repository.AuditLogFilter{
+		ActorID:    input.ActorID,
+		HanhDong:   input.HanhDong,
+		DoiTuong:   input.DoiTuong,
+		DoiTuongID: input.DoiTuongID,
+		Tu:         input.Tu,
+		Den:        input.Den,
+	}
+	return uc.repo.Find(ctx, filter, input.Offset, input.Limit)
+}
+
+// ghiAuditLog ghi một thao tác đặc quyền vào audit log
+// Actor, request ID và IP lấy từ request context (JWT middleware gắn vào);
+// không có actor (scheduler, background job) thì ghi là AuditActorHeThong
+//
+// Ghi audit log là best-effort: lỗi chỉ được log, không làm thất bại thao tác đã thực hiện
+func ghiAuditLog(
+	ctx context.Context,
+	repo repository.IAuditLogRepository,
+	hanhDong, doiTuong, doiTuongID string,
+	truoc, sau map[string]any,
+) {
+	if repo == nil {
+		return
+	}
+
+	log := entity.NewAuditLog(uuid.New().String(), hanhDong, doiTuong, doiTuongID, truoc, sau)
+	if actor, ok := middleware.RequestActorFromContext(ctx); ok {
+		log.ActorID = actor.UserID
+		log.ActorRole = actor.Role
+		log.RequestID = actor.RequestID
+		log.IP = actor.IP
+	}
+
+	if err := repo.Append(ctx, log); err != nil {
+		logger.CtxError(ctx, "failed to append audit log",
+			zap.String("action", hanhDong),
+			zap.String("target", doiTuong),
+			zap.String("target_id", doiTuongID),
+			zap.Error(err),
+		)
+	}
+}
+
+// actorIDTuContext trả về user thực hiện request (rỗng nếu không đến từ request đã xác thực)
+func actorIDTuContext(ctx context.Context) string {
+	if actor, ok := middleware.RequestActorFromContext(ctx); ok {
+		return actor.UserID
+	}
+	return ""
+}
+
+// ============================================
+// SNAPSHOT CHO AUDIT LOG
+// Chỉ gồm các trường nghiệp vụ có thể bị thay đổi, giá trị kiểu đơn giản
+// để so sánh trước/sau và lưu trữ được
+// ============================================
+
+// snapshotMonAn chụp trạng thái món ăn
+func snapshotMonAn(m *entity.MonAn) map[string]any {
+	lichBan := make([]string, len(m.LichBan))
+	for i, k := range m.LichBan {
+		lichBan[i] = fmt.Sprintf("%v %02d:%02d-%02d:%02d", k.CacNgay, k.TuPhut/60, k.TuPhut%60, k.DenPhut/60, k.DenPhut%60)
+	}
+	nhomTuyChon := make([]string, len(m.NhomTuyChon))
+	for i, n := range m.NhomTuyChon {
+		nhomTuyChon[i] = fmt.Sprintf("%s(%d-%d, %d lựa chọn)", n.ID, n.ChonToiThieu, n.ChonToiDa, len(n.LuaChon))
+	}
+
+	return map[string]any{
+		"ten":           m.Ten,
+		"gia":           m.Gia,
+		"mo_ta":         m.MoTa,
+		"con_hang":      m.ConHang,
+		"giam_gia":      m.GiamGia,
+		"lich_ban":      lichBan,
+		"nhom_tuy_chon": nhomTuyChon,
+	}
+}
+
+// snapshotUser chụp trạng thái user (không gồm password hash)
+func snapshotUser(u *entity.User) map[string]any {
+	return map[string]any{
+		"username":  u.Username,
+		"email":     u.Email,
+		"role":      string(u.Role),
+		"is_active": u.IsActive,
+	}
+}
+
+// snapshotKhachHang chụp trạng thái khách hàng
+func snapshotKhachHang(kh *entity.KhachHang) map[string]any {
+	return map[string]any{
+		"ho_ten":         kh.HoTen,
+		"so_dien_thoai":  kh.SoDienThoai,
+		"email":          kh.Email,
+		"diem_tich_luy":  kh.DiemTichLuy,
+		"cap_thanh_vien": kh.CapThanhVien,
+	}
+}
+
+// snapshotNhanVien chụp trạng thái nhân viên
+func snapshotNhanVien(nv *entity.NhanVien) map[string]any {
+	return map[string]any{
+		"user_id":       nv.UserID,
+		"ho_ten":        nv.HoTen,
+		"chuc_vu":       string(nv.ChucVu),
+		"so_dien_thoai": nv.SoDienThoai,
+		"trang_thai":    string(nv.TrangThai),
+	}
+}
+
+// snapshotOrder chụp trạng thái đơn hàng
+func snapshotOrder(o *entity.Order) map[string]any {
+	return map[string]any{
+		"trang_thai":      string(o.TrangThai),
+		"dau_bep_id":      o.DauBepID,
+		"tien_thanh_toan": o.TienThanhToan,
+	}
+}
+
+// snapshotKhuyenMai chụp trạng thái chương trình khuyến mãi
+func snapshotKhuyenMai(km *entity.KhuyenMai) map[string]any {
+	return map[string]any{
+		"ten":            km.Ten,
+		"loai":           string(km.Loai),
+		"gia_tri":        km.GiaTri,
+		"mon_an_ids":     slices.Clone(km.MonAnIDs),
+		"tu_ngay":        thoiGianAudit(km.TuNgay),
+		"den_ngay":       thoiGianAudit(km.DenNgay),
+		"dang_hoat_dong": km.DangHoatDong,
+	}
+}
+
+// snapshotNguyenLieu chụp trạng thái nguyên liệu
+func snapshotNguyenLieu(nl *entity.NguyenLieu) map[string]any {
+	return map[string]any{
+		"ten":           nl.Ten,
+		"don_vi_tinh":   nl.DonViTinh,
+		"so_luong_ton":  nl.SoLuongTon,
+		"muc_toi_thieu": nl.MucToiThieu,
+	}
+}
+
+// snapshotNhaCungCap chụp trạng thái nhà cung cấp
+func snapshotNhaCungCap(ncc *entity.NhaCungCap) map[string]any {
+	return map[string]any{
+		"ten":           ncc.Ten,
+		"nguoi_lien_he": ncc.NguoiLienHe,
+		"so_dien_thoai": ncc.SoDienThoai,
+		"email":         ncc.Email,
+		"dia_chi":       ncc.DiaChi,
+		"so_ngay_giao":  ncc.SoNgayGiao,
+		"dang_hop_tac":  ncc.DangHopTac,
+	}
+}
+
+// snapshotDonDatHang chụp trạng thái đơn đặt hàng
+func snapshotDonDatHang(don *entity.DonDatHang) map[string]any {
+	items := make([]string, len(don.Items))
+	for i, item := range don.Items {
+		items[i] = fmt.Sprintf("%s: đặt %g, nhận %g", item.NguyenLieuID, item.SoLuongDat, item.SoLuongDaNhan)
+	}
+
+	return map[string]any{
+		"nha_cung_cap_id": don.NhaCungCapID,
+		"trang_thai":      string(don.TrangThai),
+		"items":           items,
+	}
+}
+
+// snapshotWebhook chụp trạng thái webhook (không gồm secret)
+func snapshotWebhook(wh *entity.Webhook) map[string]any {
+	suKien := make([]string, len(wh.SuKien))
+	for i, loai := range wh.SuKien {
+		suKien[i] = string(loai)
+	}
+
+	return map[string]any{
+		"ten":            wh.Ten,
+		"url":            wh.URL,
+		"su_kien":        suKien,
+		"dang_hoat_dong": wh.DangHoatDong,
+	}
+}
+
+// thoiGianAudit định dạng thời gian cho snapshot (nil = chuỗi rỗng)
+func thoiGianAudit(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
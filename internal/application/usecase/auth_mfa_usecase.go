@@ -0,0 +1,400 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/pkg/logger"
+	"restaurant_project/pkg/password"
+	"restaurant_project/pkg/totp"
+)
+
+const (
+	// mfaSkew là số bước TOTP lệch cho phép mỗi phía (±30 giây)
+	mfaSkew = 1
+	// kyTuMaKhoiPhuc là bảng ký tự của mã khôi phục (base32 chữ thường, không có 0/1/8/9 dễ nhầm)
+	kyTuMaKhoiPhuc = "abcdefghijklmnopqrstuvwxyz234567"
+)
+
+// MFAEnrollmentResult là thông tin đăng ký TOTP, chỉ hiển thị một lần
+type MFAEnrollmentResult struct {
+	Secret     string   // Secret base32 (nhập tay nếu không quét được QR)
+	URI        string   // otpauth:// URI để tạo QR code
+	MaKhoiPhuc []string // Mã khôi phục dạng rõ, mỗi mã dùng được một lần
+}
+
+// MFAStatus là trạng thái xác thực hai lớp của user
+type MFAStatus struct {
+	DaBat           bool
+	BatBuoc         bool
+	SoMaKhoiPhucCon int
+	NgayKichHoat    *time.Time
+}
+
+// isMFARequired kiểm tra role có bị chính sách bắt buộc 2FA không
+func (uc *AuthUseCase) isMFARequired(role entity.UserRole) bool {
+	return uc.mfaPolicy.BatBuocTuRole != "" && middleware.HasMinRole(string(role), uc.mfaPolicy.BatBuocTuRole)
+}
+
+// createMFAChallenge tạo challenge nếu user đã bật 2FA hoặc bị bắt buộc 2FA
+// Trả về nil nếu user được đăng nhập thẳng
+func (uc *AuthUseCase) createMFAChallenge(ctx context.Context, user *entity.User) (*MFAChallengeResult, error) {
+	if uc.mfaRepo == nil || uc.mfaChallengeService == nil {
+		return nil, nil
+	}
+
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	enabled := mfa != nil && mfa.DaKichHoat
+	if !enabled && !uc.isMFARequired(user.Role) {
+		return nil, nil
+	}
+
+	token, err := uc.mfaChallengeService.CreateChallenge(ctx, service.MFAChallenge{
+		UserID:       user.ID,
+		YeuCauDangKy: !enabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MFAChallengeResult{
+		Token:        token,
+		YeuCauDangKy: !enabled,
+		ExpiresIn:    int64(uc.mfaPolicy.ChallengeTTL.Seconds()),
+	}, nil
+}
+
+// getChallengeUser lấy challenge và user của challenge
+func (uc *AuthUseCase) getChallengeUser(ctx context.Context, challengeToken string) (*service.MFAChallenge, *entity.User, error) {
+	if uc.mfaRepo == nil || uc.mfaChallengeService == nil {
+		return nil, nil, ErrInvalidMFAChallenge
+	}
+
+	challenge, err := uc.mfaChallengeService.GetChallenge(ctx, challengeToken)
+	if err != nil {
+		if errors.Is(err, service.ErrMFAChallengeNotFound) {
+			return nil, nil, ErrInvalidMFAChallenge
+		}
+		return nil, nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil {
+		return nil, nil, ErrInvalidMFAChallenge
+	}
+	if !user.IsActive {
+		return nil, nil, ErrUserInactive
+	}
+
+	return challenge, user, nil
+}
+
+// SetupMFAWithChallenge đăng ký TOTP trong lúc đăng nhập (user bị bắt buộc 2FA nhưng chưa đăng ký)
+func (uc *AuthUseCase) SetupMFAWithChallenge(ctx context.Context, challengeToken string) (*MFAEnrollmentResult, error) {
+	challenge, user, err := uc.getChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.YeuCauDangKy {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	return uc.enrollMFA(ctx, user)
+}
+
+// VerifyMFA hoàn tất đăng nhập bằng mã TOTP hoặc mã khôi phục
+// Challenge đang đăng ký (YeuCauDangKy) chỉ nhận mã TOTP và kích hoạt 2FA khi mã đúng
+func (uc *AuthUseCase) VerifyMFA(ctx context.Context, challengeToken, code string, client ClientInfo) (*AuthResult, error) {
+	_, user, err := uc.getChallengeUser(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tài khoản bị khóa trong lúc chờ mã (đoán mã sai qua nhiều challenge)
+	if uc.loginAttemptService != nil {
+		isLocked, err := uc.loginAttemptService.IsLocked(ctx, user.Username)
+		if err == nil && isLocked {
+			_, _ = uc.mfaChallengeService.DeleteChallenge(ctx, challengeToken)
+			remainingTime, _ := uc.loginAttemptService.GetRemainingLockTime(ctx, user.Username)
+			return nil, &AccountLockedError{RemainingSeconds: remainingTime}
+		}
+	}
+
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa == nil {
+		return nil, ErrMFANotEnrolled
+	}
+
+	ok, err := uc.checkMFACode(ctx, mfa, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Mỗi mã sai tính như một lần đăng nhập sai để account lockout chặn đoán mã
+		_, _ = uc.mfaChallengeService.RecordFailure(ctx, challengeToken)
+		if uc.loginAttemptService != nil {
+			_, _ = uc.loginAttemptService.IncrementAttempts(ctx, user.Username)
+		}
+		return nil, ErrInvalidMFACode
+	}
+
+	// Challenge chỉ dùng một lần
+	deleted, err := uc.mfaChallengeService.DeleteChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+	if !deleted {
+		return nil, ErrInvalidMFAChallenge
+	}
+
+	if !mfa.DaKichHoat {
+		mfa.KichHoat(mfa.BuocCuoi)
+		if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+			return nil, err
+		}
+		logger.CtxInfo(ctx, "MFA enabled during login", zap.String("user_id", user.ID))
+	}
+
+	if uc.loginAttemptService != nil {
+		_ = uc.loginAttemptService.ResetAttempts(ctx, user.Username)
+	}
+
+	return uc.generateAuthResult(ctx, user, client)
+}
+
+// EnrollMFA bắt đầu đăng ký TOTP cho user đã đăng nhập
+// Gọi lại khi chưa xác nhận sẽ tạo secret và mã khôi phục mới
+func (uc *AuthUseCase) EnrollMFA(ctx context.Context, userID string) (*MFAEnrollmentResult, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return uc.enrollMFA(ctx, user)
+}
+
+// enrollMFA tạo secret và mã khôi phục mới, lưu ở trạng thái chờ xác nhận
+func (uc *AuthUseCase) enrollMFA(ctx context.Context, user *entity.User) (*MFAEnrollmentResult, error) {
+	if uc.mfaRepo == nil {
+		return nil, errors.New("mfa service không khả dụng")
+	}
+
+	existing, err := uc.mfaRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.DaKichHoat {
+		return nil, ErrMFAAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes(uc.mfaPolicy.SoMaKhoiPhuc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.mfaRepo.Save(ctx, entity.NewUserMFA(user.ID, secret, hashes)); err != nil {
+		return nil, err
+	}
+
+	logger.CtxInfo(ctx, "MFA enrollment started", zap.String("user_id", user.ID))
+
+	return &MFAEnrollmentResult{
+		Secret:     secret,
+		URI:        totp.URI(uc.mfaPolicy.Issuer, user.Username, secret),
+		MaKhoiPhuc: codes,
+	}, nil
+}
+
+// ConfirmMFA kích hoạt 2FA bằng mã TOTP đầu tiên từ app authenticator
+func (uc *AuthUseCase) ConfirmMFA(ctx context.Context, userID, code string) error {
+	if uc.mfaRepo == nil {
+		return ErrMFANotEnrolled
+	}
+
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil {
+		return ErrMFANotEnrolled
+	}
+	if mfa.DaKichHoat {
+		return ErrMFAAlreadyEnabled
+	}
+
+	ok, err := uc.checkMFACode(ctx, mfa, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	mfa.KichHoat(mfa.BuocCuoi)
+	if err := uc.mfaRepo.Save(ctx, mfa); err != nil {
+		return err
+	}
+
+	logger.CtxInfo(ctx, "MFA enabled", zap.String("user_id", userID))
+	return nil
+}
+
+// DisableMFA tắt 2FA, yêu cầu cả mật khẩu và mã (TOTP hoặc mã khôi phục)
+// Role bị chính sách bắt buộc 2FA không được tắt
+func (uc *AuthUseCase) DisableMFA(ctx context.Context, userID, currentPassword, code string) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+	if !password.Verify(currentPassword, user.PasswordHash) {
+		return ErrInvalidCredentials
+	}
+	if uc.isMFARequired(user.Role) {
+		return ErrMFARequired
+	}
+	if uc.mfaRepo == nil {
+		return ErrMFANotEnrolled
+	}
+
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil || !mfa.DaKichHoat {
+		return ErrMFANotEnrolled
+	}
+
+	ok, err := uc.checkMFACode(ctx, mfa, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidMFACode
+	}
+
+	if err := uc.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	logger.CtxInfo(ctx, "MFA disabled", zap.String("user_id", userID))
+	return nil
+}
+
+// GetMFAStatus lấy trạng thái 2FA của user
+func (uc *AuthUseCase) GetMFAStatus(ctx context.Context, userID string) (*MFAStatus, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	status := &MFAStatus{BatBuoc: uc.isMFARequired(user.Role)}
+	if uc.mfaRepo == nil {
+		return status, nil
+	}
+
+	mfa, err := uc.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if mfa != nil && mfa.DaKichHoat {
+		status.DaBat = true
+		status.SoMaKhoiPhucCon = len(mfa.MaKhoiPhuc)
+		status.NgayKichHoat = mfa.NgayKichHoat
+	}
+
+	return status, nil
+}
+
+// checkMFACode kiểm tra mã TOTP (mỗi bước chỉ dùng một lần) hoặc mã khôi phục (chỉ khi 2FA đã bật)
+// Mã TOTP đúng cập nhật mfa.BuocCuoi để lần Save sau không ghi đè bước cũ hơn
+func (uc *AuthUseCase) checkMFACode(ctx context.Context, mfa *entity.UserMFA, code string) (bool, error) {
+	if counter, ok := totp.Validate(mfa.Secret, code, time.Now(), mfaSkew); ok {
+		recorded, err := uc.mfaRepo.GhiNhanBuoc(ctx, mfa.UserID, counter)
+		if err != nil || !recorded {
+			return false, err
+		}
+		mfa.BuocCuoi = counter
+		return true, nil
+	}
+
+	if !mfa.DaKichHoat {
+		return false, nil
+	}
+
+	used, err := uc.mfaRepo.DungMaKhoiPhuc(ctx, mfa.UserID, hashRecoveryCode(code))
+	if err != nil {
+		return false, err
+	}
+	if used {
+		logger.CtxWarn(ctx, "MFA recovery code used",
+			zap.String("user_id", mfa.UserID),
+			zap.Int("remaining", len(mfa.MaKhoiPhuc)-1),
+		)
+	}
+	return used, nil
+}
+
+// generateRecoveryCodes tạo n mã khôi phục dạng "xxxxx-xxxxx" và SHA-256 của chúng
+func generateRecoveryCodes(n int) (codes, hashes []string, err error) {
+	codes = make([]string, n)
+	hashes = make([]string, n)
+
+	buf := make([]byte, 10)
+	for i := range n {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		var sb strings.Builder
+		for j, b := range buf {
+			if j == 5 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(kyTuMaKhoiPhuc[int(b)%len(kyTuMaKhoiPhuc)])
+		}
+		codes[i] = sb.String()
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode chuẩn hóa (bỏ gạch nối, khoảng trắng, chữ hoa) rồi băm SHA-256
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/pkg/totp"
+)
+
+// fakeMFARepo lưu cấu hình TOTP trong bộ nhớ, cùng ngữ nghĩa với MySQL repo
+type fakeMFARepo struct {
+	data map[string]*entity.UserMFA
+}
+
+func newFakeMFARepo(mfas ...*entity.UserMFA) *fakeMFARepo {
+	r := &fakeMFARepo{data: make(map[string]*entity.UserMFA)}
+	for _, m := range mfas {
+		r.data[m.UserID] = m
+	}
+	return r
+}
+
+func (r *fakeMFARepo) FindByUserID(_ context.Context, userID string) (*entity.UserMFA, error) {
+	m, ok := r.data[userID]
+	if !ok {
+		return nil, nil
+	}
+	cp := *m
+	cp.MaKhoiPhuc = slices.Clone(m.MaKhoiPhuc)
+	return &cp, nil
+}
+
+func (r *fakeMFARepo) Save(_ context.Context, mfa *entity.UserMFA) error {
+	r.data[mfa.UserID] = mfa
+	return nil
+}
+
+func (r *fakeMFARepo) Delete(_ context.Context, userID string) error {
+	delete(r.data, userID)
+	return nil
+}
+
+func (r *fakeMFARepo) GhiNhanBuoc(_ context.Context, userID string, buoc int64) (bool, error) {
+	m, ok := r.data[userID]
+	if !ok || buoc <= m.BuocCuoi {
+		return false, nil
+	}
+	m.BuocCuoi = buoc
+	return true, nil
+}
+
+func (r *fakeMFARepo) DungMaKhoiPhuc(_ context.Context, userID, maHash string) (bool, error) {
+	m, ok := r.data[userID]
+	if !ok {
+		return false, nil
+	}
+	i := slices.Index(m.MaKhoiPhuc, maHash)
+	if i < 0 {
+		return false, nil
+	}
+	m.MaKhoiPhuc = slices.Delete(m.MaKhoiPhuc, i, i+1)
+	return true, nil
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("generateRecoveryCodes: %v", err)
+	}
+	if len(codes) != 10 || len(hashes) != 10 {
+		t.Fatalf("len = %d/%d, want 10/10", len(codes), len(hashes))
+	}
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		// Dạng "xxxxx-xxxxx" chỉ gồm ký tự trong bảng
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("code %q has wrong format", code)
+		}
+		for _, c := range strings.ReplaceAll(code, "-", "") {
+			if !strings.ContainsRune(kyTuMaKhoiPhuc, c) {
+				t.Errorf("code %q contains %q outside alphabet", code, c)
+			}
+		}
+		if hashes[i] != hashRecoveryCode(code) {
+			t.Errorf("hash of %q does not match", code)
+		}
+		if strings.Contains(hashes[i], code) {
+			t.Errorf("hash contains plaintext code")
+		}
+		if seen[code] {
+			t.Errorf("duplicate code %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode_Normalize(t *testing.T) {
+	want := hashRecoveryCode("abcde-fghij")
+
+	tests := []string{"abcdefghij", "ABCDE-FGHIJ", " abcde fghij ", "abc-de-fghij"}
+	for _, code := range tests {
+		if got := hashRecoveryCode(code); got != want {
+			t.Errorf("hashRecoveryCode(%q) differs from canonical form", code)
+		}
+	}
+	if hashRecoveryCode("abcde-fghik") == want {
+		t.Error("different codes have the same hash")
+	}
+}
+
+func TestCheckMFACode(t *testing.T) {
+	ctx := context.Background()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	now := totp.Counter(time.Now())
+	code := func(c int64) string {
+		s, err := totp.Code(secret, c)
+		if err != nil {
+			t.Fatalf("Code: %v", err)
+		}
+		return s
+	}
+	const recovery = "abcde-fghij"
+
+	tests := []struct {
+		name         string
+		daKichHoat   bool
+		buocCuoi     int64
+		code         string
+		want         bool
+		wantBuocCuoi int64
+		wantConMa    int
+	}{
+		{
+			name:         "mã TOTP đúng",
+			daKichHoat:   true,
+			buocCuoi:     now - 5,
+			code:         code(now),
+			want:         true,
+			wantBuocCuoi: now,
+			wantConMa:    1,
+		},
+		{
+			name:         "mã TOTP của bước đã dùng bị từ chối",
+			daKichHoat:   true,
+			buocCuoi:     now,
+			code:         code(now),
+			wantBuocCuoi: now,
+			wantConMa:    1,
+		},
+		{
+			name:         "mã TOTP cũ hơn bước cuối bị từ chối",
+			daKichHoat:   true,
+			buocCuoi:     now,
+			code:         code(now - 1),
+			wantBuocCuoi: now,
+			wantConMa:    1,
+		},
+		{
+			name:       "mã khôi phục dùng được khi đã kích hoạt",
+			daKichHoat: true,
+			code:       "ABCDE FGHIJ",
+			want:       true,
+			wantConMa:  0,
+		},
+		{
+			name:      "mã khôi phục không dùng được khi đang đăng ký",
+			code:      recovery,
+			wantConMa: 1,
+		},
+		{
+			name:       "mã sai",
+			daKichHoat: true,
+			code:       "xyz",
+			wantConMa:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mfa := &entity.UserMFA{
+				UserID:     "u1",
+				Secret:     secret,
+				DaKichHoat: tt.daKichHoat,
+				BuocCuoi:   tt.buocCuoi,
+				MaKhoiPhuc: []string{hashRecoveryCode(recovery)},
+			}
+			repo := newFakeMFARepo(mfa)
+			uc := &AuthUseCase{mfaRepo: repo}
+
+			loaded, _ := repo.FindByUserID(ctx, "u1")
+			got, err := uc.checkMFACode(ctx, loaded, tt.code)
+			if err != nil {
+				t.Fatalf("checkMFACode: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("checkMFACode = %v, want %v", got, tt.want)
+			}
+
+			stored := repo.data["u1"]
+			if stored.BuocCuoi != tt.wantBuocCuoi {
+				t.Errorf("BuocCuoi = %d, want %d", stored.BuocCuoi, tt.wantBuocCuoi)
+			}
+			if len(stored.MaKhoiPhuc) != tt.wantConMa {
+				t.Errorf("remaining recovery codes = %d, want %d", len(stored.MaKhoiPhuc), tt.wantConMa)
+			}
+		})
+	}
+}
+
+func TestCheckMFACode_RecoveryCodeSingleUse(t *testing.T) {
+	ctx := context.Background()
+	const recovery = "abcde-fghij"
+	repo := newFakeMFARepo(&entity.UserMFA{
+		UserID:     "u1",
+		Secret:     "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		DaKichHoat: true,
+		MaKhoiPhuc: []string{hashRecoveryCode(recovery)},
+	})
+	uc := &AuthUseCase{mfaRepo: repo}
+
+	for i, want := range []bool{true, false} {
+		mfa, _ := repo.FindByUserID(ctx, "u1")
+		got, err := uc.checkMFACode(ctx, mfa, recovery)
+		if err != nil {
+			t.Fatalf("attempt %d: %v", i+1, err)
+		}
+		if got != want {
+			t.Fatalf("attempt %d = %v, want %v", i+1, got, want)
+		}
+	}
+}
@@ -0,0 +1,450 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+)
+
+const (
+	// pkceVerifierBytes: 32 byte ngẫu nhiên → verifier 43 ký tự (RFC 7636 yêu cầu 43-128)
+	pkceVerifierBytes = 32
+	oidcNonceBytes    = 16
+	// maxUsernameLength khớp users.username VARCHAR(50)
+	maxUsernameLength = 50
+	minUsernameLength = 3
+	// soLanThuUsername là số lần thử thêm hậu tố ngẫu nhiên khi username sinh từ email đã tồn tại
+	soLanThuUsername = 5
+)
+
+// OIDCAuthorization là URL chuyển người dùng tới provider để đăng nhập/liên kết
+type OIDCAuthorization struct {
+	URL   string
+	State string // Frontend giữ lại để đối chiếu với state provider trả về
+}
+
+// LinkedIdentities là các phương thức đăng nhập của user
+type LinkedIdentities struct {
+	HasPassword bool
+	Identities  []*entity.ExternalIdentity
+}
+
+// OIDCProviders trả về tên các provider đang bật
+func (uc *AuthUseCase) OIDCProviders() []string {
+	names := make([]string, 0, len(uc.oidcProviders))
+	for name := range uc.oidcProviders {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// StartOIDCLogin bắt đầu đăng nhập bằng provider: tạo state, nonce, PKCE verifier và URL đăng nhập
+func (uc *AuthUseCase) StartOIDCLogin(ctx context.Context, providerName string) (*OIDCAuthorization, error) {
+	return uc.startOIDC(ctx, providerName, "")
+}
+
+// StartOIDCLink bắt đầu liên kết tài khoản bên ngoài cho user đang đăng nhập
+func (uc *AuthUseCase) StartOIDCLink(ctx context.Context, userID, providerName string) (*OIDCAuthorization, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	identities, err := uc.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range identities {
+		if identity.Provider == providerName {
+			return nil, ErrIdentityAlreadyLinked
+		}
+	}
+
+	return uc.startOIDC(ctx, providerName, userID)
+}
+
+// startOIDC lưu trạng thái đăng nhập (verifier chỉ nằm ở server) và tạo URL đăng nhập tại provider
+func (uc *AuthUseCase) startOIDC(ctx context.Context, providerName, linkUserID string) (*OIDCAuthorization, error) {
+	provider, ok := uc.oidcProviders[providerName]
+	if !ok || uc.oidcStateService == nil {
+		return nil, ErrOIDCProviderNotFound
+	}
+
+	verifier, err := randomURLToken(pkceVerifierBytes)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomURLToken(oidcNonceBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := uc.oidcStateService.CreateState(ctx, service.OIDCAuthRequest{
+		Provider:     providerName,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		LinkUserID:   linkUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := sha256.Sum256([]byte(verifier))
+	authURL, err := provider.AuthorizationURL(ctx, state, nonce, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCAuthorization{URL: authURL, State: state}, nil
+}
+
+// CompleteOIDCLogin hoàn tất đăng nhập sau khi provider redirect về với code và state
+// Tìm user theo tài khoản đã liên kết; chưa liên kết thì theo email đã xác minh:
+// có user cùng email (đã xác thực) → tự liên kết, chưa có → tạo tài khoản Customer mới
+func (uc *AuthUseCase) CompleteOIDCLogin(ctx context.Context, code, state string, client ClientInfo) (*AuthResult, error) {
+	request, identity, err := uc.exchangeOIDC(ctx, code, state)
+	if err != nil {
+		return nil, err
+	}
+	// State của luồng liên kết không dùng để đăng nhập
+	if request.LinkUserID != "" {
+		return nil, ErrInvalidOIDCState
+	}
+
+	user, link, err := uc.resolveOIDCUser(ctx, request.Provider, identity)
+	if err != nil {
+		return nil, err
+	}
+	if !user.IsActive {
+		return nil, ErrUserInactive
+	}
+
+	if err := uc.identityRepo.GhiNhanDangNhap(ctx, link.ID, time.Now()); err != nil {
+		logger.CtxWarn(ctx, "Failed to record external identity login",
+			zap.String("user_id", user.ID),
+			zap.String("provider", link.Provider),
+			zap.Error(err),
+		)
+	}
+
+	// Provider chỉ thay thế bước mật khẩu, user đã bật 2FA vẫn phải nhập mã
+	challenge, err := uc.createMFAChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &AuthResult{User: user, MFAChallenge: challenge}, nil
+	}
+
+	return uc.generateAuthResult(ctx, user, client)
+}
+
+// LinkIdentity hoàn tất liên kết tài khoản bên ngoài cho user đang đăng nhập
+func (uc *AuthUseCase) LinkIdentity(ctx context.Context, userID, code, state string) (*entity.ExternalIdentity, error) {
+	request, identity, err := uc.exchangeOIDC(ctx, code, state)
+	if err != nil {
+		return nil, err
+	}
+	// State phải được tạo bởi chính user này (StartOIDCLink)
+	if request.LinkUserID == "" || request.LinkUserID != userID {
+		return nil, ErrInvalidOIDCState
+	}
+
+	existing, err := uc.identityRepo.FindByProviderSubject(ctx, request.Provider, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.UserID == userID {
+			return existing, nil
+		}
+		return nil, ErrIdentityAlreadyLinked
+	}
+
+	link := entity.NewExternalIdentity(uuid.New().String(), userID, request.Provider, identity.Subject, identity.Email)
+	if err := uc.identityRepo.Create(ctx, link); err != nil {
+		if errors.Is(err, repository.ErrDuplicateEntry) {
+			return nil, ErrIdentityAlreadyLinked
+		}
+		return nil, err
+	}
+
+	logger.CtxInfo(ctx, "External identity linked",
+		zap.String("user_id", userID),
+		zap.String("provider", link.Provider),
+	)
+
+	return link, nil
+}
+
+// ListIdentities lấy các tài khoản bên ngoài đã liên kết của user
+func (uc *AuthUseCase) ListIdentities(ctx context.Context, userID string) (*LinkedIdentities, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	identities, err := uc.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LinkedIdentities{
+		HasPassword: user.HasPassword(),
+		Identities:  identities,
+	}, nil
+}
+
+// UnlinkIdentity hủy liên kết provider của user
+// Không cho hủy phương thức đăng nhập cuối cùng (user chưa có mật khẩu và chỉ còn một liên kết)
+func (uc *AuthUseCase) UnlinkIdentity(ctx context.Context, userID, providerName string) error {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	identities, err := uc.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	linked := slices.ContainsFunc(identities, func(identity *entity.ExternalIdentity) bool {
+		return identity.Provider == providerName
+	})
+	if !linked {
+		return ErrIdentityNotFound
+	}
+	if !user.HasPassword() && len(identities) == 1 {
+		return ErrLastLoginMethod
+	}
+
+	deleted, err := uc.identityRepo.Delete(ctx, userID, providerName)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrIdentityNotFound
+	}
+
+	logger.CtxInfo(ctx, "External identity unlinked",
+		zap.String("user_id", userID),
+		zap.String("provider", providerName),
+	)
+
+	return nil
+}
+
+// exchangeOIDC dùng state (một lần) để lấy verifier và đổi code lấy danh tính đã xác thực
+func (uc *AuthUseCase) exchangeOIDC(ctx context.Context, code, state string) (*service.OIDCAuthRequest, *service.OIDCIdentity, error) {
+	if uc.oidcStateService == nil {
+		return nil, nil, ErrInvalidOIDCState
+	}
+
+	request, err := uc.oidcStateService.ConsumeState(ctx, state)
+	if errors.Is(err, service.ErrOIDCStateNotFound) {
+		return nil, nil, ErrInvalidOIDCState
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	provider, ok := uc.oidcProviders[request.Provider]
+	if !ok {
+		return nil, nil, ErrOIDCProviderNotFound
+	}
+
+	identity, err := provider.Exchange(ctx, code, request.CodeVerifier)
+	if errors.Is(err, service.ErrOIDCExchangeFailed) {
+		logger.CtxWarn(ctx, "OIDC code exchange rejected",
+			zap.String("provider", request.Provider),
+			zap.Error(err),
+		)
+		return nil, nil, ErrOIDCLoginFailed
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Nonce chống replay ID token của một lần đăng nhập khác
+	if subtle.ConstantTimeCompare([]byte(identity.Nonce), []byte(request.Nonce)) != 1 {
+		logger.CtxWarn(ctx, "OIDC id token nonce mismatch",
+			zap.String("provider", request.Provider),
+		)
+		return nil, nil, ErrOIDCLoginFailed
+	}
+
+	return request, identity, nil
+}
+
+// resolveOIDCUser tìm hoặc tạo user cho danh tính bên ngoài, trả về cả liên kết
+func (uc *AuthUseCase) resolveOIDCUser(ctx context.Context, providerName string, identity *service.OIDCIdentity) (*entity.User, *entity.ExternalIdentity, error) {
+	link, err := uc.identityRepo.FindByProviderSubject(ctx, providerName, identity.Subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	if link != nil {
+		user, err := uc.userRepo.FindByID(ctx, link.UserID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if user == nil {
+			return nil, nil, ErrOIDCLoginFailed
+		}
+		return user, link, nil
+	}
+
+	// Chưa liên kết: chỉ tin email khi provider đã xác minh, nếu không ai cũng chiếm được tài khoản theo email
+	email := strings.TrimSpace(identity.Email)
+	if email == "" || !identity.EmailVerified {
+		return nil, nil, ErrOIDCEmailNotVerified
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, nil, err
+	}
+	created := false
+	if user != nil {
+		// Tài khoản chưa xác thực email có thể do người khác đăng ký bằng email này
+		if !user.IsEmailVerified {
+			return nil, nil, ErrOIDCAccountNotVerified
+		}
+	} else {
+		user, err = uc.createExternalUser(ctx, email)
+		if err != nil {
+			return nil, nil, err
+		}
+		created = true
+	}
+
+	link = entity.NewExternalIdentity(uuid.New().String(), user.ID, providerName, identity.Subject, email)
+	if err := uc.identityRepo.Create(ctx, link); err != nil {
+		if created {
+			if delErr := uc.userRepo.Delete(ctx, user.ID); delErr != nil {
+				logger.CtxWarn(ctx, "Failed to remove user after identity link failed",
+					zap.String("user_id", user.ID),
+					zap.Error(delErr),
+				)
+			}
+		}
+		if !errors.Is(err, repository.ErrDuplicateEntry) {
+			return nil, nil, err
+		}
+
+		// Callback đồng thời của cùng tài khoản đã liên kết trước
+		existing, findErr := uc.identityRepo.FindByProviderSubject(ctx, providerName, identity.Subject)
+		if findErr != nil {
+			return nil, nil, findErr
+		}
+		if existing == nil {
+			return nil, nil, ErrIdentityAlreadyLinked
+		}
+		existingUser, findErr := uc.userRepo.FindByID(ctx, existing.UserID)
+		if findErr != nil {
+			return nil, nil, findErr
+		}
+		if existingUser == nil {
+			return nil, nil, ErrOIDCLoginFailed
+		}
+		return existingUser, existing, nil
+	}
+
+	logger.CtxInfo(ctx, "External identity linked",
+		zap.String("user_id", user.ID),
+		zap.String("provider", providerName),
+		zap.Bool("new_user", created),
+	)
+
+	return user, link, nil
+}
+
+// createExternalUser tạo Customer cho email chưa có tài khoản
+// Username sinh từ phần trước @ của email, thêm hậu tố ngẫu nhiên nếu đã tồn tại
+func (uc *AuthUseCase) createExternalUser(ctx context.Context, email string) (*entity.User, error) {
+	base := usernameFromEmail(email)
+	username := base
+
+	for range soLanThuUsername {
+		user, err := entity.NewExternalUser(uuid.New().String(), username, email, entity.RoleCustomer)
+		if err != nil {
+			return nil, err
+		}
+
+		err = uc.userRepo.Create(ctx, user)
+		if err == nil {
+			return user, nil
+		}
+		if !errors.Is(err, repository.ErrDuplicateEntry) {
+			return nil, err
+		}
+
+		// Trùng do email (request đồng thời) thì không thử lại
+		exists, err := uc.userRepo.ExistsByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrEmailExists
+		}
+
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return nil, err
+		}
+		username = base[:min(len(base), maxUsernameLength-7)] + "_" + hex.EncodeToString(suffix)
+	}
+
+	return nil, ErrUsernameExists
+}
+
+// usernameFromEmail lấy phần trước @ của email, chỉ giữ chữ thường, số, '.', '_', '-'
+func usernameFromEmail(email string) string {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+
+	var b strings.Builder
+	for _, r := range local {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '.' || r == '_' || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+
+	username := b.String()
+	if len(username) < minUsernameLength {
+		username = "user_" + username
+	}
+	return username[:min(len(username), maxUsernameLength)]
+}
+
+// randomURLToken tạo chuỗi ngẫu nhiên base64url (không padding)
+func randomURLToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,503 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/internal/infrastructure/middleware"
+	infraservice "restaurant_project/internal/infrastructure/service"
+	"restaurant_project/pkg/mockoidc"
+)
+
+const (
+	testOIDCProvider    = "mock"
+	testOIDCRedirectURL = "http://app.example.com/auth/oidc/callback"
+)
+
+// fakeIdentityRepo lưu liên kết tài khoản bên ngoài, unique theo (provider, subject) và (user, provider)
+type fakeIdentityRepo struct {
+	mu         sync.Mutex
+	identities []*entity.ExternalIdentity
+}
+
+func (r *fakeIdentityRepo) FindByProviderSubject(_ context.Context, provider, subject string) (*entity.ExternalIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, i := range r.identities {
+		if i.Provider == provider && i.Subject == subject {
+			return i, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeIdentityRepo) FindByUserID(_ context.Context, userID string) ([]*entity.ExternalIdentity, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*entity.ExternalIdentity
+	for _, i := range r.identities {
+		if i.UserID == userID {
+			result = append(result, i)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeIdentityRepo) Create(_ context.Context, identity *entity.ExternalIdentity) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, i := range r.identities {
+		if (i.Provider == identity.Provider && i.Subject == identity.Subject) ||
+			(i.UserID == identity.UserID && i.Provider == identity.Provider) {
+			return repository.ErrDuplicateEntry
+		}
+	}
+	r.identities = append(r.identities, identity)
+	return nil
+}
+
+func (r *fakeIdentityRepo) Delete(_ context.Context, userID, provider string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for idx, i := range r.identities {
+		if i.UserID == userID && i.Provider == provider {
+			r.identities = append(r.identities[:idx], r.identities[idx+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeIdentityRepo) GhiNhanDangNhap(_ context.Context, id string, thoiDiem time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, i := range r.identities {
+		if i.ID == id {
+			i.LanDangNhapCuoi = &thoiDiem
+		}
+	}
+	return nil
+}
+
+// fakeOIDCStateService giữ state trong bộ nhớ, mỗi state dùng một lần
+type fakeOIDCStateService struct {
+	mu     sync.Mutex
+	states map[string]service.OIDCAuthRequest
+}
+
+func (s *fakeOIDCStateService) CreateState(_ context.Context, request service.OIDCAuthRequest) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := uuid.NewString()
+	s.states[state] = request
+	return state, nil
+}
+
+func (s *fakeOIDCStateService) ConsumeState(_ context.Context, state string) (*service.OIDCAuthRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	request, ok := s.states[state]
+	if !ok {
+		return nil, service.ErrOIDCStateNotFound
+	}
+	delete(s.states, state)
+	return &request, nil
+}
+
+// sua đổi trạng thái đã lưu (giả lập state bị tráo giữa lúc bắt đầu và callback)
+func (s *fakeOIDCStateService) sua(state string, fn func(*service.OIDCAuthRequest)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	request := s.states[state]
+	fn(&request)
+	s.states[state] = request
+}
+
+// oidcTestEnv là AuthUseCase nối với mock OIDC provider chạy bằng httptest
+type oidcTestEnv struct {
+	uc         *AuthUseCase
+	users      *fakeUserRepo
+	identities *fakeIdentityRepo
+	states     *fakeOIDCStateService
+}
+
+func newOIDCTestEnv(t *testing.T) *oidcTestEnv {
+	t.Helper()
+
+	var handler http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := mockoidc.NewServer(server.URL)
+	if err != nil {
+		t.Fatalf("mockoidc.NewServer: %v", err)
+	}
+	handler = provider
+
+	jwtAuth, err := middleware.NewJWTAuth(config.JWTConfig{
+		Enabled:   true,
+		SecretKey: "test-secret-key-for-oidc-usecase-tests",
+	}, config.AuthCookieConfig{}, nil)
+	if err != nil {
+		t.Fatalf("NewJWTAuth: %v", err)
+	}
+
+	env := &oidcTestEnv{
+		users:      &fakeUserRepo{users: make(map[string]*entity.User)},
+		identities: &fakeIdentityRepo{},
+		states:     &fakeOIDCStateService{states: make(map[string]service.OIDCAuthRequest)},
+	}
+	client := infraservice.NewOIDCClient(testOIDCProvider, config.OIDCProviderConfig{
+		Enabled:   true,
+		IssuerURL: server.URL,
+		ClientID:  "restaurant",
+	}, testOIDCRedirectURL)
+
+	env.uc = &AuthUseCase{
+		userRepo:         env.users,
+		jwtAuth:          jwtAuth,
+		identityRepo:     env.identities,
+		oidcProviders:    map[string]service.OIDCProvider{testOIDCProvider: client},
+		oidcStateService: env.states,
+		permissionRepo:   fakePermissionRepo{},
+	}
+	return env
+}
+
+// authorize đăng nhập tại mock provider bằng login_hint, trả về code và state trong redirect
+func authorize(t *testing.T, authURL, email string, emailVerified bool) (code, state string) {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse authorization URL: %v", err)
+	}
+	q := u.Query()
+	q.Set("login_hint", email)
+	if !emailVerified {
+		q.Set("email_verified", "false")
+	}
+	u.RawQuery = q.Encode()
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := client.Get(u.String())
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize status = %d, want 302", resp.StatusCode)
+	}
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatalf("parse redirect: %v", err)
+	}
+	if !strings.HasPrefix(location.String(), testOIDCRedirectURL) {
+		t.Fatalf("redirect = %s, want %s", location, testOIDCRedirectURL)
+	}
+	return location.Query().Get("code"), location.Query().Get("state")
+}
+
+func (env *oidcTestEnv) themUser(t *testing.T, email string, emailVerified, hasPassword bool) *entity.User {
+	t.Helper()
+
+	user, err := entity.NewExternalUser(uuid.NewString(), strings.Split(email, "@")[0], email, entity.RoleCustomer)
+	if err != nil {
+		t.Fatalf("NewExternalUser: %v", err)
+	}
+	user.IsEmailVerified = emailVerified
+	if hasPassword {
+		user.PasswordHash = "$2a$10$hash"
+	}
+	env.users.users[user.ID] = user
+	return user
+}
+
+func TestCompleteOIDCLogin(t *testing.T) {
+	const email = "khach@example.com"
+
+	tests := []struct {
+		name          string
+		existing      func(t *testing.T, env *oidcTestEnv) *entity.User
+		emailVerified bool
+		wantErr       error
+		wantNewUser   bool
+	}{
+		{
+			name:          "email mới tạo tài khoản Customer",
+			emailVerified: true,
+			wantNewUser:   true,
+		},
+		{
+			name: "liên kết với user có cùng email đã xác thực",
+			existing: func(t *testing.T, env *oidcTestEnv) *entity.User {
+				return env.themUser(t, email, true, true)
+			},
+			emailVerified: true,
+		},
+		{
+			name:          "provider chưa xác minh email",
+			emailVerified: false,
+			wantErr:       ErrOIDCEmailNotVerified,
+		},
+		{
+			name: "user cùng email chưa xác thực không bị chiếm",
+			existing: func(t *testing.T, env *oidcTestEnv) *entity.User {
+				return env.themUser(t, email, false, true)
+			},
+			emailVerified: true,
+			wantErr:       ErrOIDCAccountNotVerified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newOIDCTestEnv(t)
+			var existing *entity.User
+			if tt.existing != nil {
+				existing = tt.existing(t, env)
+			}
+
+			start, err := env.uc.StartOIDCLogin(ctx, testOIDCProvider)
+			if err != nil {
+				t.Fatalf("StartOIDCLogin: %v", err)
+			}
+			code, state := authorize(t, start.URL, email, tt.emailVerified)
+			if state != start.State {
+				t.Fatalf("state = %q, want %q", state, start.State)
+			}
+
+			result, err := env.uc.CompleteOIDCLogin(ctx, code, state, ClientInfo{})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if len(env.identities.identities) != 0 {
+					t.Errorf("identity linked on failed login")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CompleteOIDCLogin: %v", err)
+			}
+			if result.AccessToken == "" || result.RefreshToken == "" {
+				t.Errorf("missing tokens in result")
+			}
+
+			if existing != nil && result.User.ID != existing.ID {
+				t.Errorf("logged in as %s, want existing user %s", result.User.ID, existing.ID)
+			}
+			if tt.wantNewUser && (result.User.HasPassword() || !result.User.IsEmailVerified) {
+				t.Errorf("new user = %+v, want verified user without password", result.User)
+			}
+
+			link, _ := env.identities.FindByProviderSubject(ctx, testOIDCProvider, mockoidc.Subject(email))
+			if link == nil || link.UserID != result.User.ID {
+				t.Fatalf("identity = %+v, want linked to %s", link, result.User.ID)
+			}
+			if link.LanDangNhapCuoi == nil {
+				t.Errorf("login time not recorded")
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLogin_LinkedIdentityLogsInAgain(t *testing.T) {
+	ctx := context.Background()
+	env := newOIDCTestEnv(t)
+
+	var userID string
+	for i := range 2 {
+		start, err := env.uc.StartOIDCLogin(ctx, testOIDCProvider)
+		if err != nil {
+			t.Fatalf("StartOIDCLogin: %v", err)
+		}
+		code, state := authorize(t, start.URL, "khach@example.com", true)
+		result, err := env.uc.CompleteOIDCLogin(ctx, code, state, ClientInfo{})
+		if err != nil {
+			t.Fatalf("login %d: %v", i+1, err)
+		}
+		if userID != "" && result.User.ID != userID {
+			t.Fatalf("login %d user = %s, want %s", i+1, result.User.ID, userID)
+		}
+		userID = result.User.ID
+	}
+	if len(env.users.users) != 1 || len(env.identities.identities) != 1 {
+		t.Errorf("users = %d, identities = %d, want 1/1", len(env.users.users), len(env.identities.identities))
+	}
+}
+
+func TestCompleteOIDCLogin_InvalidCallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		tamper  func(env *oidcTestEnv, code, state string) (string, string)
+		wantErr error
+	}{
+		{
+			name: "state không khớp",
+			tamper: func(_ *oidcTestEnv, code, _ string) (string, string) {
+				return code, "state-khac"
+			},
+			wantErr: ErrInvalidOIDCState,
+		},
+		{
+			name: "nonce không khớp",
+			tamper: func(env *oidcTestEnv, code, state string) (string, string) {
+				env.states.sua(state, func(r *service.OIDCAuthRequest) { r.Nonce = "nonce-khac" })
+				return code, state
+			},
+			wantErr: ErrOIDCLoginFailed,
+		},
+		{
+			name: "PKCE verifier sai",
+			tamper: func(env *oidcTestEnv, code, state string) (string, string) {
+				env.states.sua(state, func(r *service.OIDCAuthRequest) { r.CodeVerifier = "verifier-khac" })
+				return code, state
+			},
+			wantErr: ErrOIDCLoginFailed,
+		},
+		{
+			name: "code không hợp lệ",
+			tamper: func(_ *oidcTestEnv, _, state string) (string, string) {
+				return "code-khac", state
+			},
+			wantErr: ErrOIDCLoginFailed,
+		},
+		{
+			name: "state của luồng liên kết",
+			tamper: func(env *oidcTestEnv, code, state string) (string, string) {
+				env.states.sua(state, func(r *service.OIDCAuthRequest) { r.LinkUserID = "u1" })
+				return code, state
+			},
+			wantErr: ErrInvalidOIDCState,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			env := newOIDCTestEnv(t)
+
+			start, err := env.uc.StartOIDCLogin(ctx, testOIDCProvider)
+			if err != nil {
+				t.Fatalf("StartOIDCLogin: %v", err)
+			}
+			code, state := authorize(t, start.URL, "khach@example.com", true)
+			code, state = tt.tamper(env, code, state)
+
+			if _, err := env.uc.CompleteOIDCLogin(ctx, code, state, ClientInfo{}); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(env.users.users) != 0 || len(env.identities.identities) != 0 {
+				t.Errorf("user or identity created on rejected callback")
+			}
+		})
+	}
+}
+
+func TestCompleteOIDCLogin_StateUsedOnce(t *testing.T) {
+	ctx := context.Background()
+	env := newOIDCTestEnv(t)
+
+	start, err := env.uc.StartOIDCLogin(ctx, testOIDCProvider)
+	if err != nil {
+		t.Fatalf("StartOIDCLogin: %v", err)
+	}
+	code, state := authorize(t, start.URL, "khach@example.com", true)
+
+	if _, err := env.uc.CompleteOIDCLogin(ctx, code, state, ClientInfo{}); err != nil {
+		t.Fatalf("first callback: %v", err)
+	}
+	if _, err := env.uc.CompleteOIDCLogin(ctx, code, state, ClientInfo{}); !errors.Is(err, ErrInvalidOIDCState) {
+		t.Fatalf("replayed callback err = %v, want %v", err, ErrInvalidOIDCState)
+	}
+}
+
+func TestLinkAndUnlinkIdentity(t *testing.T) {
+	ctx := context.Background()
+	env := newOIDCTestEnv(t)
+	user := env.themUser(t, "nhanvien@example.com", true, true)
+	other := env.themUser(t, "khac@example.com", true, true)
+
+	start, err := env.uc.StartOIDCLink(ctx, user.ID, testOIDCProvider)
+	if err != nil {
+		t.Fatalf("StartOIDCLink: %v", err)
+	}
+	// Email tại provider không cần trùng email của user khi liên kết chủ động
+	code, state := authorize(t, start.URL, "canhan@gmail.com", true)
+
+	// State của user này không dùng được cho user khác
+	if _, err := env.uc.LinkIdentity(ctx, other.ID, code, state); !errors.Is(err, ErrInvalidOIDCState) {
+		t.Fatalf("link by other user err = %v, want %v", err, ErrInvalidOIDCState)
+	}
+
+	start, _ = env.uc.StartOIDCLink(ctx, user.ID, testOIDCProvider)
+	code, state = authorize(t, start.URL, "canhan@gmail.com", true)
+	link, err := env.uc.LinkIdentity(ctx, user.ID, code, state)
+	if err != nil {
+		t.Fatalf("LinkIdentity: %v", err)
+	}
+	if link.UserID != user.ID || link.Subject != mockoidc.Subject("canhan@gmail.com") {
+		t.Fatalf("link = %+v", link)
+	}
+
+	if _, err := env.uc.StartOIDCLink(ctx, user.ID, testOIDCProvider); !errors.Is(err, ErrIdentityAlreadyLinked) {
+		t.Fatalf("second StartOIDCLink err = %v, want %v", err, ErrIdentityAlreadyLinked)
+	}
+
+	// Tài khoản bên ngoài đã thuộc user khác
+	start, _ = env.uc.StartOIDCLink(ctx, other.ID, testOIDCProvider)
+	code, state = authorize(t, start.URL, "canhan@gmail.com", true)
+	if _, err := env.uc.LinkIdentity(ctx, other.ID, code, state); !errors.Is(err, ErrIdentityAlreadyLinked) {
+		t.Fatalf("link taken identity err = %v, want %v", err, ErrIdentityAlreadyLinked)
+	}
+
+	if err := env.uc.UnlinkIdentity(ctx, user.ID, testOIDCProvider); err != nil {
+		t.Fatalf("UnlinkIdentity: %v", err)
+	}
+	if err := env.uc.UnlinkIdentity(ctx, user.ID, testOIDCProvider); !errors.Is(err, ErrIdentityNotFound) {
+		t.Fatalf("second UnlinkIdentity err = %v, want %v", err, ErrIdentityNotFound)
+	}
+}
+
+func TestUnlinkIdentity_LastLoginMethod(t *testing.T) {
+	ctx := context.Background()
+	env := newOIDCTestEnv(t)
+
+	start, err := env.uc.StartOIDCLogin(ctx, testOIDCProvider)
+	if err != nil {
+		t.Fatalf("StartOIDCLogin: %v", err)
+	}
+	code, state := authorize(t, start.URL, "khach@example.com", true)
+	result, err := env.uc.CompleteOIDCLogin(ctx, code, state, ClientInfo{})
+	if err != nil {
+		t.Fatalf("CompleteOIDCLogin: %v", err)
+	}
+
+	// User tạo qua OIDC chưa có mật khẩu: liên kết duy nhất là cách đăng nhập cuối cùng
+	if err := env.uc.UnlinkIdentity(ctx, result.User.ID, testOIDCProvider); !errors.Is(err, ErrLastLoginMethod) {
+		t.Fatalf("err = %v, want %v", err, ErrLastLoginMethod)
+	}
+	if len(env.identities.identities) != 1 {
+		t.Errorf("identity removed despite error")
+	}
+}
@@ -0,0 +1,210 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/pkg/logger"
+)
+
+// maxUserAgentLength giới hạn độ dài User-Agent lưu cùng session
+const maxUserAgentLength = 512
+
+// ClientInfo là thông tin thiết bị gửi request đăng nhập/refresh
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+// normalize cắt User-Agent quá dài trước khi lưu
+func (c ClientInfo) normalize() ClientInfo {
+	c.UserAgent = strings.TrimSpace(c.UserAgent)
+	if len(c.UserAgent) > maxUserAgentLength {
+		cut := maxUserAgentLength
+		for cut > 0 && !utf8.RuneStart(c.UserAgent[cut]) {
+			cut--
+		}
+		c.UserAgent = c.UserAgent[:cut]
+	}
+	return c
+}
+
+// rotateSession cấp token mới cho session đang có khi refresh
+// Session đã hết hạn hoặc bị thu hồi thì không cấp token.
+// Refresh token không còn là token hiện hành của session (đã xoay vòng) → thu hồi cả họ token
+func (uc *AuthUseCase) rotateSession(
+	ctx context.Context,
+	user *entity.User,
+	claims *middleware.UserClaims,
+	client ClientInfo,
+	revokedTokens []*middleware.UserClaims,
+) (*AuthResult, error) {
+	accessTokenInfo, refreshTokenInfo, err := uc.generateTokens(ctx, user, claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if uc.sessionService != nil {
+		client = client.normalize()
+		removeJTIs := make([]string, 0, len(revokedTokens))
+		for _, token := range revokedTokens {
+			if token.ID != "" {
+				removeJTIs = append(removeJTIs, token.ID)
+			}
+		}
+
+		err := uc.sessionService.RotateSession(ctx, service.SessionRotation{
+			SessionID:    claims.SessionID,
+			UserID:       user.ID,
+			IP:           client.IP,
+			UserAgent:    client.UserAgent,
+			PresentedJTI: claims.ID,
+			RefreshJTI:   refreshTokenInfo.JTI,
+			AddJTIs:      []string{accessTokenInfo.JTI, refreshTokenInfo.JTI},
+			RemoveJTIs:   removeJTIs,
+			TTL:          refreshTokenInfo.TTL,
+		})
+		if errors.Is(err, service.ErrSessionNotFound) {
+			return nil, ErrInvalidToken
+		}
+		if errors.Is(err, service.ErrRefreshTokenReused) {
+			uc.revokeTokenFamily(ctx, user.ID, claims, client)
+			return nil, ErrInvalidToken
+		}
+		if err != nil {
+			// Fail-open: metadata session lỗi không chặn refresh (thu hồi vẫn dựa vào blacklist)
+			logger.CtxWarn(ctx, "failed to rotate session",
+				zap.String("user_id", user.ID),
+				zap.String("session_id", claims.SessionID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	// Chỉ thu hồi token cũ sau khi xoay vòng thành công
+	uc.revokeTokens(ctx, user.ID, revokedTokens)
+
+	return uc.trackTokens(ctx, user, accessTokenInfo, refreshTokenInfo), nil
+}
+
+// revokeTokenFamily xử lý refresh token đã xoay vòng bị dùng lại: thu hồi cả session
+// (mọi access/refresh token cấp từ cùng lần đăng nhập) và ghi log sự cố bảo mật.
+// Session đã đăng xuất/bị thu hồi thì token cũ đơn thuần bị từ chối, không phải sự cố
+func (uc *AuthUseCase) revokeTokenFamily(ctx context.Context, userID string, claims *middleware.UserClaims, client ClientInfo) {
+	if claims.SessionID == "" {
+		return
+	}
+
+	client = client.normalize()
+	fields := []zap.Field{
+		zap.String("event", "refresh_token_reuse"),
+		zap.String("user_id", userID),
+		zap.String("session_id", claims.SessionID),
+		zap.String("jti", claims.ID),
+		zap.String("ip", client.IP),
+		zap.String("user_agent", client.UserAgent),
+	}
+
+	err := uc.RevokeSession(ctx, userID, claims.SessionID)
+	if errors.Is(err, ErrSessionNotFound) {
+		return
+	}
+	if err != nil {
+		logger.CtxError(ctx, "security incident: refresh token reuse detected, failed to revoke token family",
+			append(fields, zap.Error(err))...,
+		)
+		return
+	}
+
+	logger.CtxError(ctx, "security incident: refresh token reuse detected, token family revoked", fields...)
+}
+
+// ListSessions lấy các phiên đăng nhập còn hiệu lực của user
+func (uc *AuthUseCase) ListSessions(ctx context.Context, userID string) ([]service.Session, error) {
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	if uc.sessionService == nil {
+		return []service.Session{}, nil
+	}
+
+	return uc.sessionService.ListUserSessions(ctx, userID)
+}
+
+// RevokeSession thu hồi một phiên đăng nhập của user: blacklist mọi token còn thuộc phiên
+// Trả về ErrSessionNotFound nếu phiên không tồn tại hoặc thuộc user khác
+func (uc *AuthUseCase) RevokeSession(ctx context.Context, userID, sessionID string) error {
+	if uc.sessionService == nil || sessionID == "" {
+		return ErrSessionNotFound
+	}
+
+	session, err := uc.sessionService.GetSession(ctx, sessionID)
+	if errors.Is(err, service.ErrSessionNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+
+	jtis, err := uc.sessionService.DeleteSession(ctx, sessionID)
+	if errors.Is(err, service.ErrSessionNotFound) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	// Token trong session sống tối đa bằng refresh token TTL
+	if blacklistService := uc.jwtAuth.GetBlacklistService(); blacklistService != nil {
+		ttl := uc.jwtAuth.RefreshTokenTTL()
+		for _, jti := range jtis {
+			if err := blacklistService.Blacklist(ctx, jti, ttl); err != nil {
+				return err
+			}
+			_ = blacklistService.UntrackUserToken(ctx, userID, jti)
+		}
+	}
+
+	logger.CtxInfo(ctx, "session revoked",
+		zap.String("user_id", userID),
+		zap.String("session_id", sessionID),
+		zap.Int("revoked_tokens", len(jtis)),
+	)
+	uc.ghiAuditThuHoiPhien(ctx, "session.thu_hoi", userID, sessionID)
+
+	return nil
+}
+
+// ghiAuditThuHoiPhien ghi audit log khi phiên của user bị người khác (admin) thu hồi
+// User tự đăng xuất / thu hồi phiên của mình không phải thao tác đặc quyền nên không ghi
+func (uc *AuthUseCase) ghiAuditThuHoiPhien(ctx context.Context, hanhDong, userID, sessionID string) {
+	actor, ok := middleware.RequestActorFromContext(ctx)
+	if !ok || actor.UserID == userID {
+		return
+	}
+
+	// Phiên bị thu hồi coi như bị xóa: chỉ có snapshot trước
+	truoc := map[string]any{"user_id": userID}
+	doiTuongID := userID
+	if sessionID != "" {
+		truoc["session_id"] = sessionID
+		doiTuongID = sessionID
+	}
+	ghiAuditLog(ctx, uc.auditRepo, hanhDong, entity.AuditDoiTuongSession, doiTuongID, truoc, nil)
+}
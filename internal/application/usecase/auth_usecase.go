@@ -4,13 +4,17 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 
 	"restaurant_project/internal/domain/entity"
 	"restaurant_project/internal/domain/repository"
 	"restaurant_project/internal/domain/service"
 	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/pkg/logger"
 	"restaurant_project/pkg/password"
 )
 
@@ -23,6 +27,21 @@ var (
 	ErrInvalidVerificationToken  = errors.New("token xác thực không hợp lệ hoặc đã hết hạn")
 	ErrEmailAlreadyVerified      = errors.New("email đã được xác thực")
 	ErrResendCooldown            = errors.New("vui lòng đợi trước khi gửi lại email xác thực")
+	ErrInvalidResetToken         = errors.New("token đặt lại mật khẩu không hợp lệ hoặc đã hết hạn")
+	ErrInvalidMFAChallenge       = errors.New("phiên xác thực hai lớp không hợp lệ hoặc đã hết hạn")
+	ErrInvalidMFACode            = errors.New("mã xác thực không đúng")
+	ErrMFAAlreadyEnabled         = errors.New("xác thực hai lớp đã được bật")
+	ErrMFANotEnrolled            = errors.New("chưa đăng ký xác thực hai lớp")
+	ErrMFARequired               = errors.New("vai trò của bạn bắt buộc xác thực hai lớp")
+	ErrSessionNotFound           = errors.New("không tìm thấy phiên đăng nhập")
+	ErrOIDCProviderNotFound      = errors.New("nhà cung cấp đăng nhập không được hỗ trợ")
+	ErrInvalidOIDCState          = errors.New("phiên đăng nhập bên ngoài không hợp lệ hoặc đã hết hạn")
+	ErrOIDCLoginFailed           = errors.New("đăng nhập bằng tài khoản bên ngoài thất bại")
+	ErrOIDCEmailNotVerified      = errors.New("email của tài khoản bên ngoài chưa được xác minh")
+	ErrOIDCAccountNotVerified    = errors.New("email đã được dùng cho tài khoản chưa xác thực, vui lòng đăng nhập bằng mật khẩu và liên kết tài khoản")
+	ErrIdentityAlreadyLinked     = errors.New("tài khoản bên ngoài đã được liên kết với người dùng khác hoặc đã liên kết nhà cung cấp này")
+	ErrIdentityNotFound          = errors.New("chưa liên kết tài khoản với nhà cung cấp này")
+	ErrLastLoginMethod           = errors.New("không thể hủy liên kết phương thức đăng nhập duy nhất, vui lòng đặt mật khẩu trước")
 )
 
 // RegisterInput là input để đăng ký tài khoản mới
@@ -30,20 +49,39 @@ type RegisterInput struct {
 	Username string
 	Email    string
 	Password string
+	Client   ClientInfo
 }
 
 // LoginInput là input để đăng nhập
 type LoginInput struct {
 	Username string
 	Password string
+	Client   ClientInfo
 }
 
 // AuthResult là kết quả sau khi đăng nhập/đăng ký thành công
+// MFAChallenge khác nil: mới qua bước mật khẩu, chưa cấp token (AccessToken/RefreshToken rỗng)
 type AuthResult struct {
 	User         *entity.User
 	AccessToken  string
 	RefreshToken string
 	ExpiresIn    int64 // seconds
+	MFAChallenge *MFAChallengeResult
+}
+
+// MFAChallengeResult là challenge cần gửi kèm mã TOTP ở bước đăng nhập thứ hai
+type MFAChallengeResult struct {
+	Token        string
+	YeuCauDangKy bool  // true = phải đăng ký TOTP (POST /auth/mfa/setup) trước khi nhập mã
+	ExpiresIn    int64 // seconds
+}
+
+// MFAPolicy là chính sách xác thực hai lớp
+type MFAPolicy struct {
+	Issuer        string        // Tên hiển thị trong app authenticator
+	BatBuocTuRole string        // Bắt buộc 2FA cho role từ mức này trở lên (rỗng = không bắt buộc)
+	ChallengeTTL  time.Duration // Thời gian sống của challenge
+	SoMaKhoiPhuc  int           // Số mã khôi phục cấp khi đăng ký
 }
 
 // AuthUseCase xử lý business logic liên quan đến Authentication
@@ -53,6 +91,16 @@ type AuthUseCase struct {
 	loginAttemptService      service.LoginAttemptService
 	emailVerificationService service.EmailVerificationService
 	emailService             service.EmailService
+	passwordResetService     service.PasswordResetService
+	mfaRepo                  repository.IUserMFARepository
+	mfaChallengeService      service.MFAChallengeService
+	mfaPolicy                MFAPolicy
+	sessionService           service.SessionService
+	identityRepo             repository.IExternalIdentityRepository
+	oidcProviders            map[string]service.OIDCProvider
+	oidcStateService         service.OIDCStateService
+	permissionRepo           repository.IPermissionRepository
+	auditRepo                repository.IAuditLogRepository // Ghi audit log khi admin thu hồi phiên của user khác
 }
 
 // NewAuthUseCase tạo mới AuthUseCase
@@ -62,13 +110,38 @@ func NewAuthUseCase(
 	loginAttemptService service.LoginAttemptService,
 	emailVerificationService service.EmailVerificationService,
 	emailService service.EmailService,
+	passwordResetService service.PasswordResetService,
+	mfaRepo repository.IUserMFARepository,
+	mfaChallengeService service.MFAChallengeService,
+	mfaPolicy MFAPolicy,
+	sessionService service.SessionService,
+	identityRepo repository.IExternalIdentityRepository,
+	oidcProviders []service.OIDCProvider,
+	oidcStateService service.OIDCStateService,
+	permissionRepo repository.IPermissionRepository,
+	auditRepo repository.IAuditLogRepository,
 ) *AuthUseCase {
+	providers := make(map[string]service.OIDCProvider, len(oidcProviders))
+	for _, provider := range oidcProviders {
+		providers[provider.Name()] = provider
+	}
+
 	return &AuthUseCase{
 		userRepo:                 userRepo,
 		jwtAuth:                  jwtAuth,
 		loginAttemptService:      loginAttemptService,
 		emailVerificationService: emailVerificationService,
 		emailService:             emailService,
+		passwordResetService:     passwordResetService,
+		mfaRepo:                  mfaRepo,
+		mfaChallengeService:      mfaChallengeService,
+		mfaPolicy:                mfaPolicy,
+		sessionService:           sessionService,
+		identityRepo:             identityRepo,
+		oidcProviders:            providers,
+		oidcStateService:         oidcStateService,
+		permissionRepo:           permissionRepo,
+		auditRepo:                auditRepo,
 	}
 }
 
@@ -124,13 +197,18 @@ func (uc *AuthUseCase) Register(ctx context.Context, input RegisterInput) (*Auth
 		if err == nil && token != "" {
 			// Set cooldown trước khi gửi email
 			_ = uc.emailVerificationService.SetResendCooldown(ctx, user.ID)
-			// Gửi email (hoặc log ra console trong dev mode)
-			_ = uc.emailService.SendVerificationEmail(ctx, user.Email, token)
+			// Email được đưa vào job queue, worker gửi ở nền và tự thử lại khi SMTP lỗi
+			if err := uc.emailService.SendVerificationEmail(ctx, user.Email, token); err != nil {
+				logger.CtxWarn(ctx, "Failed to queue verification email",
+					zap.String("user_id", user.ID),
+					zap.Error(err),
+				)
+			}
 		}
 	}
 
 	// Generate tokens
-	return uc.generateAuthResult(ctx, user)
+	return uc.generateAuthResult(ctx, user, input.Client)
 }
 
 // Login đăng nhập và trả về tokens
@@ -173,13 +251,23 @@ func (uc *AuthUseCase) Login(ctx context.Context, input LoginInput) (*AuthResult
 		return nil, ErrInvalidCredentials
 	}
 
+	// Xác thực hai lớp: trả challenge thay vì token thật
+	// Chưa reset failed attempts - mã TOTP sai vẫn tính vào account lockout
+	challenge, err := uc.createMFAChallenge(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil {
+		return &AuthResult{User: user, MFAChallenge: challenge}, nil
+	}
+
 	// Login success - reset failed attempts
 	if uc.loginAttemptService != nil {
 		_ = uc.loginAttemptService.ResetAttempts(ctx, input.Username)
 	}
 
 	// Generate tokens
-	return uc.generateAuthResult(ctx, user)
+	return uc.generateAuthResult(ctx, user, input.Client)
 }
 
 // AccountLockedError chứa thông tin về tài khoản bị khóa
@@ -193,13 +281,21 @@ func (e *AccountLockedError) Error() string {
 
 // RefreshToken làm mới access token từ refresh token
 // oldAccessToken (optional): access token cũ để cleanup JTI khỏi Redis
-func (uc *AuthUseCase) RefreshToken(ctx context.Context, refreshToken string, oldAccessToken string) (*AuthResult, error) {
+// Token mới thuộc cùng session với refresh token cũ
+func (uc *AuthUseCase) RefreshToken(ctx context.Context, refreshToken string, oldAccessToken string, client ClientInfo) (*AuthResult, error) {
 	// Validate refresh token và lấy user ID
 	claims, err := uc.jwtAuth.ValidateToken(refreshToken)
 	if err != nil {
 		return nil, ErrInvalidToken
 	}
 
+	// Chỉ refresh token được đổi lấy cặp token mới: refresh token có Subject là userID,
+	// access token thì không (và có typ access). Token cấp trước khi có claim typ chỉ dựa vào Subject
+	if claims.Subject == "" || (claims.TokenType != "" && claims.TokenType != middleware.TokenTypeRefresh) {
+		return nil, ErrInvalidToken
+	}
+	userID := claims.Subject
+
 	// Check refresh token đã bị blacklist chưa (chống reuse attack)
 	// Fail-closed: nếu Redis lỗi → reject token (giống JWT middleware)
 	if claims.ID != "" {
@@ -209,79 +305,125 @@ func (uc *AuthUseCase) RefreshToken(ctx context.Context, refreshToken string, ol
 				return nil, ErrInvalidToken
 			}
 			if isBlacklisted {
+				// Refresh token đã xoay vòng bị dùng lại → có thể đã bị đánh cắp
+				uc.revokeTokenFamily(ctx, userID, claims, client)
 				return nil, ErrInvalidToken
 			}
 		}
 	}
 
-	// Lấy user từ claims.Subject (refresh token chỉ có Subject là userID)
-	userID := claims.Subject
-	if userID == "" {
-		// Thử lấy từ UserID field nếu Subject rỗng
-		userID = claims.UserID
+	// Find user
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
-
-	if userID == "" {
-		return nil, ErrInvalidToken
+	if user == nil {
+		return nil, ErrUserNotFound
 	}
 
-	// Revoke refresh token cũ: blacklist + untrack
-	if claims.ID != "" {
-		if blacklistService := uc.jwtAuth.GetBlacklistService(); blacklistService != nil {
-			remainingTTL := uc.jwtAuth.GetTokenRemainingTime(claims)
-			if remainingTTL > 0 {
-				_ = blacklistService.Blacklist(ctx, claims.ID, remainingTTL)
-			}
-			_ = blacklistService.UntrackUserToken(ctx, userID, claims.ID)
-		}
+	// Check if user is still active
+	if !user.IsActive {
+		return nil, ErrUserInactive
 	}
 
-	// Blacklist + untrack access token cũ để không thể sử dụng lại
+	// Refresh token cũ + access token cũ sẽ bị thu hồi
+	revokedTokens := []*middleware.UserClaims{claims}
 	if oldAccessToken != "" {
 		if oldClaims, err := uc.jwtAuth.ParseTokenIgnoreExpiry(oldAccessToken); err == nil && oldClaims.ID != "" {
-			if blacklistService := uc.jwtAuth.GetBlacklistService(); blacklistService != nil {
-				remainingTTL := uc.jwtAuth.GetTokenRemainingTime(oldClaims)
-				if remainingTTL > 0 {
-					_ = blacklistService.Blacklist(ctx, oldClaims.ID, remainingTTL)
-				}
-				_ = blacklistService.UntrackUserToken(ctx, userID, oldClaims.ID)
+			revokedTokens = append(revokedTokens, oldClaims)
+		}
+	}
+
+	// Token phát hành trước khi có session: mở session mới
+	if claims.SessionID == "" {
+		uc.revokeTokens(ctx, userID, revokedTokens)
+		return uc.generateAuthResult(ctx, user, client)
+	}
+
+	// Generate new tokens (cả access + refresh mới) trong session hiện tại
+	return uc.rotateSession(ctx, user, claims, client, revokedTokens)
+}
+
+// revokeTokens blacklist + untrack các token để không thể sử dụng lại
+// Trả về jti của các token đã thu hồi
+func (uc *AuthUseCase) revokeTokens(ctx context.Context, userID string, tokens []*middleware.UserClaims) []string {
+	jtis := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if token.ID == "" {
+			continue
+		}
+		if blacklistService := uc.jwtAuth.GetBlacklistService(); blacklistService != nil {
+			remainingTTL := uc.jwtAuth.GetTokenRemainingTime(token)
+			if remainingTTL > 0 {
+				_ = blacklistService.Blacklist(ctx, token.ID, remainingTTL)
 			}
+			_ = blacklistService.UntrackUserToken(ctx, userID, token.ID)
 		}
+		jtis = append(jtis, token.ID)
 	}
+	return jtis
+}
 
-	// Find user
-	user, err := uc.userRepo.FindByID(ctx, userID)
+// generateAuthResult mở session mới, tạo AuthResult với tokens và track tokens cho user
+func (uc *AuthUseCase) generateAuthResult(ctx context.Context, user *entity.User, client ClientInfo) (*AuthResult, error) {
+	sessionID := uuid.New().String()
+
+	accessTokenInfo, refreshTokenInfo, err := uc.generateTokens(ctx, user, sessionID)
 	if err != nil {
 		return nil, err
 	}
-	if user == nil {
-		return nil, ErrUserNotFound
-	}
 
-	// Check if user is still active
-	if !user.IsActive {
-		return nil, ErrUserInactive
+	// Lưu session (fail-open: lỗi chỉ làm session không hiện trong danh sách)
+	if uc.sessionService != nil {
+		now := time.Now()
+		client = client.normalize()
+		session := service.Session{
+			ID:            sessionID,
+			UserID:        user.ID,
+			UserAgent:     client.UserAgent,
+			IP:            client.IP,
+			CreatedAt:     now,
+			LastRefreshAt: now,
+			ExpiresAt:     now.Add(refreshTokenInfo.TTL),
+			RefreshJTI:    refreshTokenInfo.JTI,
+		}
+		jtis := []string{accessTokenInfo.JTI, refreshTokenInfo.JTI}
+		if err := uc.sessionService.CreateSession(ctx, session, jtis, refreshTokenInfo.TTL); err != nil {
+			logger.CtxWarn(ctx, "failed to create session",
+				zap.String("user_id", user.ID),
+				zap.Error(err),
+			)
+		}
 	}
 
-	// Generate new tokens (cả access + refresh mới)
-	return uc.generateAuthResult(ctx, user)
+	return uc.trackTokens(ctx, user, accessTokenInfo, refreshTokenInfo), nil
 }
 
-// generateAuthResult tạo AuthResult với tokens và track tokens cho user
-func (uc *AuthUseCase) generateAuthResult(ctx context.Context, user *entity.User) (*AuthResult, error) {
+// generateTokens tạo cặp access + refresh token thuộc session
+// Access token mang permission hiện tại của role (đọc lại mỗi lần login/refresh)
+func (uc *AuthUseCase) generateTokens(ctx context.Context, user *entity.User, sessionID string) (*middleware.TokenInfo, *middleware.TokenInfo, error) {
+	permissions, err := uc.permissionRepo.FindByRole(ctx, user.Role)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load role permissions: %w", err)
+	}
+
 	// Generate access token with info
-	accessTokenInfo, err := uc.jwtAuth.GenerateAccessTokenWithInfo(user.ID, string(user.Role), user.Email)
+	accessTokenInfo, err := uc.jwtAuth.GenerateAccessTokenWithInfo(user.ID, string(user.Role), user.Email, sessionID, permissions)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Generate refresh token with info
-	refreshTokenInfo, err := uc.jwtAuth.GenerateRefreshTokenWithInfo(user.ID)
+	refreshTokenInfo, err := uc.jwtAuth.GenerateRefreshTokenWithInfo(user.ID, sessionID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	// Track tokens cho user (để support logout all devices)
+	return accessTokenInfo, refreshTokenInfo, nil
+}
+
+// trackTokens track tokens cho user (để support logout all devices) và tạo AuthResult
+func (uc *AuthUseCase) trackTokens(ctx context.Context, user *entity.User, accessTokenInfo, refreshTokenInfo *middleware.TokenInfo) *AuthResult {
 	blacklistService := uc.jwtAuth.GetBlacklistService()
 	if blacklistService != nil {
 		// Track access token
@@ -295,7 +437,7 @@ func (uc *AuthUseCase) generateAuthResult(ctx context.Context, user *entity.User
 		AccessToken:  accessTokenInfo.Token,
 		RefreshToken: refreshTokenInfo.Token,
 		ExpiresIn:    int64(accessTokenInfo.TTL.Seconds()),
-	}, nil
+	}
 }
 
 // GetJWTAuth trả về JWTAuthMiddleware (dùng cho logout)
@@ -311,17 +453,25 @@ func (uc *AuthUseCase) LogoutAllDevices(ctx context.Context, userID string) erro
 		return nil // Blacklist không được bật
 	}
 
-	return blacklistService.RevokeAllUserTokens(ctx, userID)
+	if err := blacklistService.RevokeAllUserTokens(ctx, userID); err != nil {
+		return err
+	}
+
+	uc.ghiAuditThuHoiPhien(ctx, "session.thu_hoi_tat_ca", userID, "")
+	return nil
 }
 
 // GetActiveSessionCount lấy số lượng session active của user
 func (uc *AuthUseCase) GetActiveSessionCount(ctx context.Context, userID string) (int64, error) {
-	blacklistService := uc.jwtAuth.GetBlacklistService()
-	if blacklistService == nil {
+	if uc.sessionService == nil {
 		return 0, nil
 	}
 
-	return blacklistService.GetUserActiveTokenCount(ctx, userID)
+	sessions, err := uc.sessionService.ListUserSessions(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return int64(len(sessions)), nil
 }
 
 // VerifyEmail xác thực email của user với token
@@ -415,3 +565,136 @@ func (uc *AuthUseCase) ResendVerificationEmail(ctx context.Context, userID strin
 	// Send email
 	return uc.emailService.SendVerificationEmail(ctx, user.Email, token)
 }
+
+// ForgotPassword gửi email chứa link đặt lại mật khẩu
+// Luôn trả về nil với email không tồn tại, tài khoản bị khóa hoặc đang trong cooldown
+// để không lộ thông tin email nào đã đăng ký (chống email enumeration)
+func (uc *AuthUseCase) ForgotPassword(ctx context.Context, email string) error {
+	if uc.passwordResetService == nil || uc.emailService == nil {
+		return errors.New("password reset service không khả dụng")
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive {
+		return nil
+	}
+
+	// Check cooldown (im lặng bỏ qua, không báo cho client)
+	canResend, _, err := uc.passwordResetService.CanResend(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if !canResend {
+		return nil
+	}
+
+	// Mỗi thời điểm chỉ một link còn hiệu lực
+	_ = uc.passwordResetService.InvalidateAllUserTokens(ctx, user.ID)
+
+	token, err := uc.passwordResetService.GenerateToken(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	_ = uc.passwordResetService.SetResendCooldown(ctx, user.ID)
+
+	if err := uc.emailService.SendPasswordResetEmail(ctx, user.Email, token); err != nil {
+		logger.CtxError(ctx, "failed to queue password reset email",
+			zap.String("user_id", user.ID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	logger.CtxInfo(ctx, "password reset requested",
+		zap.String("user_id", user.ID),
+	)
+
+	return nil
+}
+
+// ResetPassword đặt lại mật khẩu bằng token đã gửi qua email
+// Token chỉ dùng một lần; sau khi thành công mọi phiên đăng nhập của user bị thu hồi
+func (uc *AuthUseCase) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if uc.passwordResetService == nil {
+		return ErrInvalidResetToken
+	}
+
+	userID, err := uc.passwordResetService.ConsumeToken(ctx, token)
+	if err != nil {
+		return ErrInvalidResetToken
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil || !user.IsActive {
+		return ErrInvalidResetToken
+	}
+
+	newHash, err := password.Hash(newPassword)
+	if err != nil {
+		return err
+	}
+	if err := user.UpdatePassword(newHash); err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Save(ctx, user); err != nil {
+		logger.CtxError(ctx, "failed to save password reset",
+			zap.String("user_id", userID),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	// Vô hiệu các link còn lại và mở khóa đăng nhập (user vừa chứng minh sở hữu email)
+	_ = uc.passwordResetService.InvalidateAllUserTokens(ctx, userID)
+	if uc.loginAttemptService != nil {
+		_ = uc.loginAttemptService.ResetAttempts(ctx, user.Username)
+	}
+
+	// Revoke tất cả tokens sau khi đặt lại password (fail-open)
+	if blacklistService := uc.jwtAuth.GetBlacklistService(); blacklistService != nil {
+		if err := blacklistService.RevokeAllUserTokens(ctx, userID); err != nil {
+			logger.CtxWarn(ctx, "failed to revoke tokens after password reset",
+				zap.String("user_id", userID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	logger.CtxInfo(ctx, "password reset",
+		zap.String("user_id", userID),
+	)
+
+	return nil
+}
+
+// DonTokenHetHan dọn các token xác thực email và đặt lại mật khẩu đã hết hạn
+// Được scheduler gọi định kỳ; trả về tổng số token đã dọn
+func (uc *AuthUseCase) DonTokenHetHan(ctx context.Context) (int64, error) {
+	var tong int64
+
+	if uc.emailVerificationService != nil {
+		n, err := uc.emailVerificationService.CleanupExpiredTokens(ctx)
+		if err != nil {
+			return tong, fmt.Errorf("không thể dọn token xác thực email: %w", err)
+		}
+		tong += n
+	}
+
+	if uc.passwordResetService != nil {
+		n, err := uc.passwordResetService.CleanupExpiredTokens(ctx)
+		if err != nil {
+			return tong, fmt.Errorf("không thể dọn token đặt lại mật khẩu: %w", err)
+		}
+		tong += n
+	}
+
+	return tong, nil
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/internal/infrastructure/middleware"
+)
+
+// fakeSessionService lưu session trong bộ nhớ, xoay vòng theo refresh_jti giống RedisSessionService
+type fakeSessionService struct {
+	mu       sync.Mutex
+	sessions map[string]*service.Session
+}
+
+func (s *fakeSessionService) CreateSession(_ context.Context, session service.Session, _ []string, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sessions[session.ID] = &session
+	return nil
+}
+
+func (s *fakeSessionService) RotateSession(_ context.Context, rotation service.SessionRotation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[rotation.SessionID]
+	if !ok || session.UserID != rotation.UserID {
+		return service.ErrSessionNotFound
+	}
+	if session.RefreshJTI != rotation.PresentedJTI {
+		return service.ErrRefreshTokenReused
+	}
+	session.RefreshJTI = rotation.RefreshJTI
+	return nil
+}
+
+func (s *fakeSessionService) GetSession(_ context.Context, sessionID string) (*service.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	session, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, service.ErrSessionNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (s *fakeSessionService) ListUserSessions(_ context.Context, userID string) ([]service.Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []service.Session
+	for _, session := range s.sessions {
+		if session.UserID == userID {
+			result = append(result, *session)
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeSessionService) DeleteSession(_ context.Context, sessionID string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.sessions[sessionID]; !ok {
+		return nil, service.ErrSessionNotFound
+	}
+	delete(s.sessions, sessionID)
+	return nil, nil
+}
+
+// newRefreshTestUseCase tạo AuthUseCase có một user đang hoạt động (không bật blacklist)
+func newRefreshTestUseCase(t *testing.T) (*AuthUseCase, *fakeSessionService, *entity.User) {
+	t.Helper()
+
+	jwtAuth, err := middleware.NewJWTAuth(config.JWTConfig{
+		Enabled:   true,
+		SecretKey: "test-secret-key-for-refresh-usecase-tests",
+	}, config.AuthCookieConfig{}, nil)
+	if err != nil {
+		t.Fatalf("NewJWTAuth: %v", err)
+	}
+
+	user, err := entity.NewUser("user-1", "khach1", "khach1@example.com", "hash", entity.RoleCustomer)
+	if err != nil {
+		t.Fatalf("NewUser: %v", err)
+	}
+
+	sessions := &fakeSessionService{sessions: make(map[string]*service.Session)}
+	uc := &AuthUseCase{
+		userRepo:       &fakeUserRepo{users: map[string]*entity.User{user.ID: user}},
+		jwtAuth:        jwtAuth,
+		sessionService: sessions,
+		permissionRepo: fakePermissionRepo{},
+	}
+	return uc, sessions, user
+}
+
+func TestRefreshToken_XoayVong(t *testing.T) {
+	ctx := context.Background()
+	uc, sessions, user := newRefreshTestUseCase(t)
+
+	login, err := uc.generateAuthResult(ctx, user, ClientInfo{})
+	if err != nil {
+		t.Fatalf("generateAuthResult error = %v", err)
+	}
+
+	refreshed, err := uc.RefreshToken(ctx, login.RefreshToken, "", ClientInfo{})
+	if err != nil {
+		t.Fatalf("RefreshToken error = %v", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == login.RefreshToken {
+		t.Fatalf("RefreshToken did not issue a new refresh token")
+	}
+
+	// Refresh token cũ dùng lại → thu hồi cả session, refresh token mới cũng mất hiệu lực
+	if _, err := uc.RefreshToken(ctx, login.RefreshToken, "", ClientInfo{}); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("reused refresh token error = %v, want %v", err, ErrInvalidToken)
+	}
+	if list, _ := sessions.ListUserSessions(ctx, user.ID); len(list) != 0 {
+		t.Errorf("sessions after reuse = %d, want 0", len(list))
+	}
+	if _, err := uc.RefreshToken(ctx, refreshed.RefreshToken, "", ClientInfo{}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("refresh after family revoked error = %v, want %v", err, ErrInvalidToken)
+	}
+}
+
+func TestRefreshToken_TuChoiAccessToken(t *testing.T) {
+	ctx := context.Background()
+	uc, sessions, user := newRefreshTestUseCase(t)
+
+	login, err := uc.generateAuthResult(ctx, user, ClientInfo{})
+	if err != nil {
+		t.Fatalf("generateAuthResult error = %v", err)
+	}
+
+	// Access token có sid và jti thuộc session nhưng không phải refresh token
+	if _, err := uc.RefreshToken(ctx, login.AccessToken, "", ClientInfo{}); !errors.Is(err, ErrInvalidToken) {
+		t.Fatalf("RefreshToken(access token) error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	// Bị từ chối trước khi chạm session: không coi là dùng lại, refresh token thật vẫn dùng được
+	if list, _ := sessions.ListUserSessions(ctx, user.ID); len(list) != 1 {
+		t.Fatalf("sessions = %d, want 1", len(list))
+	}
+	if _, err := uc.RefreshToken(ctx, login.RefreshToken, "", ClientInfo{}); err != nil {
+		t.Errorf("RefreshToken(refresh token) error = %v", err)
+	}
+}
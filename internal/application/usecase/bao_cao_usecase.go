@@ -0,0 +1,98 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/pkg/logger"
+)
+
+// Báo cáo use case errors
+var (
+	ErrKhoangNgayKhongHopLe = errors.New("khoảng ngày không hợp lệ (định dạng YYYY-MM-DD, tối đa 366 ngày)")
+)
+
+const (
+	// dinhDangNgay là định dạng ngày của snapshot doanh thu
+	dinhDangNgay = "2006-01-02"
+	// soNgayBaoCaoToiDa giới hạn khoảng ngày của một lần xem báo cáo
+	soNgayBaoCaoToiDa = 366
+)
+
+// BaoCaoUseCase xử lý snapshot và báo cáo doanh thu
+type BaoCaoUseCase struct {
+	orderRepo    repository.IOrderRepository
+	doanhThuRepo repository.IDoanhThuRepository
+	loc          *time.Location // Múi giờ nhà hàng - ranh giới ngày của báo cáo
+}
+
+// NewBaoCaoUseCase tạo mới BaoCaoUseCase
+func NewBaoCaoUseCase(
+	orderRepo repository.IOrderRepository,
+	doanhThuRepo repository.IDoanhThuRepository,
+	loc *time.Location,
+) *BaoCaoUseCase {
+	return &BaoCaoUseCase{
+		orderRepo:    orderRepo,
+		doanhThuRepo: doanhThuRepo,
+		loc:          loc,
+	}
+}
+
+// ChupDoanhThuNgay tổng hợp và lưu snapshot doanh thu của ngày chứa thời điểm ngay
+// Ranh giới ngày tính theo múi giờ nhà hàng. Được scheduler gọi mỗi đêm cho ngày hôm trước
+func (uc *BaoCaoUseCase) ChupDoanhThuNgay(ctx context.Context, ngay time.Time) (*entity.DoanhThuNgay, error) {
+	ngay = ngay.In(uc.loc)
+	from := time.Date(ngay.Year(), ngay.Month(), ngay.Day(), 0, 0, 0, 0, uc.loc)
+	to := from.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+	orders, err := uc.orderRepo.FindByThoiGian(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy đơn hàng trong ngày: %w", err)
+	}
+
+	dt := entity.TinhDoanhThuNgay(from.Format(dinhDangNgay), orders)
+	if err := uc.doanhThuRepo.Save(ctx, dt); err != nil {
+		return nil, fmt.Errorf("không thể lưu snapshot doanh thu: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Daily revenue snapshot saved",
+		zap.String("ngay", dt.Ngay),
+		zap.Int("so_don", dt.SoDon),
+		zap.Int64("doanh_thu", dt.DoanhThu),
+	)
+
+	return dt, nil
+}
+
+// XemDoanhThuNgay lấy các snapshot doanh thu từ tuNgay đến denNgay (YYYY-MM-DD)
+func (uc *BaoCaoUseCase) XemDoanhThuNgay(ctx context.Context, tuNgay, denNgay string) ([]*entity.DoanhThuNgay, error) {
+	from, err := time.ParseInLocation(dinhDangNgay, tuNgay, uc.loc)
+	if err != nil {
+		return nil, ErrKhoangNgayKhongHopLe
+	}
+	to, err := time.ParseInLocation(dinhDangNgay, denNgay, uc.loc)
+	if err != nil {
+		return nil, ErrKhoangNgayKhongHopLe
+	}
+	if to.Before(from) || to.Sub(from) > soNgayBaoCaoToiDa*24*time.Hour {
+		return nil, ErrKhoangNgayKhongHopLe
+	}
+
+	list, err := uc.doanhThuRepo.FindByKhoang(ctx, tuNgay, denNgay)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy báo cáo doanh thu: %w", err)
+	}
+	if list == nil {
+		list = []*entity.DoanhThuNgay{}
+	}
+
+	return list, nil
+}
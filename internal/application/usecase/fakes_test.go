@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// Fake repository dùng chung cho các test trong package
+
+// fakeUserRepo lưu user trong bộ nhớ, chỉ cài các method các test trong package dùng tới
+type fakeUserRepo struct {
+	repository.IUserRepository
+
+	mu    sync.Mutex
+	users map[string]*entity.User
+}
+
+func (r *fakeUserRepo) FindByID(_ context.Context, id string) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.users[id], nil
+}
+
+func (r *fakeUserRepo) FindByEmail(_ context.Context, email string) (*entity.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if strings.EqualFold(u.Email, email) {
+			return u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepo) ExistsByUsername(_ context.Context, username string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Username == username {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeUserRepo) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	u, err := r.FindByEmail(ctx, email)
+	return u != nil, err
+}
+
+func (r *fakeUserRepo) Create(_ context.Context, user *entity.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.Username == user.Username || strings.EqualFold(u.Email, user.Email) {
+			return repository.ErrDuplicateEntry
+		}
+	}
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *fakeUserRepo) Delete(_ context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, id)
+	return nil
+}
+
+// fakePermissionRepo trả về danh sách quyền rỗng cho mọi role
+type fakePermissionRepo struct {
+	repository.IPermissionRepository
+}
+
+func (fakePermissionRepo) FindByRole(context.Context, entity.UserRole) ([]string, error) {
+	return []string{}, nil
+}
@@ -0,0 +1,53 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+)
+
+// Job use case errors
+var (
+	ErrJobNotFound = errors.New("không tìm thấy job trong dead-letter")
+)
+
+// JobUseCase xử lý nghiệp vụ quản trị hàng đợi công việc nền (Admin)
+type JobUseCase struct {
+	jobQueue  service.JobQueue
+	auditRepo repository.IAuditLogRepository
+}
+
+// NewJobUseCase tạo mới JobUseCase
+func NewJobUseCase(jobQueue service.JobQueue, auditRepo repository.IAuditLogRepository) *JobUseCase {
+	return &JobUseCase{
+		jobQueue:  jobQueue,
+		auditRepo: auditRepo,
+	}
+}
+
+// ThongKe lấy số job đang chờ, chờ thử lại và thất bại
+func (uc *JobUseCase) ThongKe(ctx context.Context) (service.JobQueueStats, error) {
+	return uc.jobQueue.Stats(ctx)
+}
+
+// XemJobThatBai liệt kê job trong dead-letter list, mới nhất trước
+func (uc *JobUseCase) XemJobThatBai(ctx context.Context, offset, limit int) ([]service.Job, int64, error) {
+	return uc.jobQueue.ListDeadLetter(ctx, int64(offset), int64(limit))
+}
+
+// ChayLaiJob đưa một job thất bại trở lại hàng đợi
+func (uc *JobUseCase) ChayLaiJob(ctx context.Context, id string) error {
+	if err := uc.jobQueue.ReplayDeadLetter(ctx, id); err != nil {
+		if errors.Is(err, service.ErrJobNotFound) {
+			return ErrJobNotFound
+		}
+		return err
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "job.chay_lai", entity.AuditDoiTuongJob, id, nil, nil)
+	return nil
+}
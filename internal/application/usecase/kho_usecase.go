@@ -0,0 +1,174 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+)
+
+// Kho use case errors
+var (
+	ErrNguyenLieuNotFound = errors.New("không tìm thấy nguyên liệu")
+)
+
+// ThemNguyenLieuInput là input để thêm nguyên liệu mới vào kho
+type ThemNguyenLieuInput struct {
+	Ten         string
+	DonViTinh   string
+	MucToiThieu float64
+}
+
+// GhiTieuHaoInput là input để ghi nhận tiêu hao nguyên liệu
+type GhiTieuHaoInput struct {
+	NguyenLieuID string
+	SoLuong      float64
+	ThamChieu    string
+}
+
+// KhoUseCase xử lý các use case liên quan đến tồn kho nguyên liệu
+type KhoUseCase struct {
+	repo         repository.INguyenLieuRepository
+	uow          repository.IUnitOfWork // Transaction MySQL: tồn kho + biến động kho
+	emailService service.EmailService
+	auditRepo    repository.IAuditLogRepository
+}
+
+// NewKhoUseCase tạo mới KhoUseCase
+func NewKhoUseCase(repo repository.INguyenLieuRepository, uow repository.IUnitOfWork, emailService service.EmailService, auditRepo repository.IAuditLogRepository) *KhoUseCase {
+	return &KhoUseCase{
+		repo:         repo,
+		uow:          uow,
+		emailService: emailService,
+		auditRepo:    auditRepo,
+	}
+}
+
+// ThemNguyenLieu thêm nguyên liệu mới (tồn kho ban đầu = 0)
+func (uc *KhoUseCase) ThemNguyenLieu(ctx context.Context, input ThemNguyenLieuInput) (*entity.NguyenLieu, error) {
+	nl, err := entity.NewNguyenLieu(uuid.New().String(), input.Ten, input.DonViTinh, input.MucToiThieu)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tạo nguyên liệu: %w", err)
+	}
+
+	if err := uc.repo.Save(ctx, nl); err != nil {
+		return nil, fmt.Errorf("không thể lưu nguyên liệu: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "nguyen_lieu.them", entity.AuditDoiTuongNguyenLieu, nl.ID, nil, snapshotNguyenLieu(nl))
+
+	return nl, nil
+}
+
+// XemKho lấy danh sách tất cả nguyên liệu
+func (uc *KhoUseCase) XemKho(ctx context.Context) ([]*entity.NguyenLieu, error) {
+	list, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách nguyên liệu: %w", err)
+	}
+
+	return list, nil
+}
+
+// TimNguyenLieu tìm nguyên liệu theo ID
+func (uc *KhoUseCase) TimNguyenLieu(ctx context.Context, id string) (*entity.NguyenLieu, error) {
+	nl, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm nguyên liệu: %w", err)
+	}
+	if nl == nil {
+		return nil, ErrNguyenLieuNotFound
+	}
+
+	return nl, nil
+}
+
+// GhiTieuHao trừ tồn kho và ghi biến động tiêu hao
+// Lịch sử tiêu hao được dùng để tính gợi ý đặt hàng
+func (uc *KhoUseCase) GhiTieuHao(ctx context.Context, input GhiTieuHaoInput) (*entity.NguyenLieu, error) {
+	var nl *entity.NguyenLieu
+	var truoc map[string]any
+
+	// Tồn kho và biến động kho ghi trong cùng một transaction, dòng nguyên liệu bị khóa
+	// để tiêu hao và nhận hàng đồng thời không ghi đè số lượng tồn của nhau
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		nl, err = uc.repo.FindByIDForUpdate(ctx, input.NguyenLieuID)
+		if err != nil {
+			return fmt.Errorf("không thể tìm nguyên liệu: %w", err)
+		}
+		if nl == nil {
+			return ErrNguyenLieuNotFound
+		}
+
+		truoc = snapshotNguyenLieu(nl)
+		if err := nl.TieuHao(input.SoLuong); err != nil {
+			return fmt.Errorf("không thể ghi tiêu hao: %w", err)
+		}
+
+		if err := uc.repo.Save(ctx, nl); err != nil {
+			return fmt.Errorf("không thể lưu nguyên liệu: %w", err)
+		}
+
+		bienDong := &entity.BienDongKho{
+			ID:           uuid.New().String(),
+			NguyenLieuID: nl.ID,
+			Loai:         entity.BienDongTieuHao,
+			SoLuong:      -input.SoLuong,
+			DonGia:       nl.GiaVonBinhQuan,
+			ThamChieu:    input.ThamChieu,
+			NgayTao:      time.Now(),
+		}
+		if err := uc.repo.ThemBienDong(ctx, bienDong); err != nil {
+			return fmt.Errorf("không thể ghi biến động kho: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "nguyen_lieu.tieu_hao", entity.AuditDoiTuongNguyenLieu, nl.ID, truoc, snapshotNguyenLieu(nl))
+
+	return nl, nil
+}
+
+// KiemTraTonKho tìm các nguyên liệu dưới mức tồn tối thiểu và gửi cảnh báo tới emailNhan
+// emailNhan rỗng thì chỉ ghi log. Được scheduler gọi định kỳ
+func (uc *KhoUseCase) KiemTraTonKho(ctx context.Context, emailNhan string) ([]*entity.NguyenLieu, error) {
+	list, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách nguyên liệu: %w", err)
+	}
+
+	thieu := make([]*entity.NguyenLieu, 0)
+	for _, nl := range list {
+		if nl.DuoiMucToiThieu() {
+			thieu = append(thieu, nl)
+		}
+	}
+	if len(thieu) == 0 {
+		return thieu, nil
+	}
+
+	logger.CtxWarn(ctx, "Ingredients below minimum stock",
+		zap.Int("so_nguyen_lieu", len(thieu)),
+	)
+
+	if emailNhan != "" && uc.emailService != nil {
+		if err := uc.emailService.SendLowStockAlert(ctx, emailNhan, thieu); err != nil {
+			return thieu, fmt.Errorf("không thể gửi cảnh báo tồn kho: %w", err)
+		}
+	}
+
+	return thieu, nil
+}
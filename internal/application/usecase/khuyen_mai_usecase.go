@@ -0,0 +1,153 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/pkg/logger"
+)
+
+// Khuyến mãi use case errors
+var (
+	ErrKhuyenMaiNotFound = errors.New("không tìm thấy khuyến mãi")
+)
+
+// TaoKhuyenMaiInput là input để tạo khuyến mãi
+type TaoKhuyenMaiInput struct {
+	Ten         string
+	MoTa        string
+	Loai        entity.LoaiKhuyenMai
+	GiaTri      int64
+	GiamToiDa   int64
+	MonAnIDs    []string
+	SoLuongMua  int
+	SoLuongTang int
+	GiaCombo    int64
+	DonToiThieu int64
+	TuNgay      *time.Time
+	DenNgay     *time.Time
+	KhungGio    []entity.KhungGioBan
+	CongDon     bool
+	UuTien      int
+}
+
+// KhuyenMaiUseCase xử lý các use case quản lý khuyến mãi
+// Việc áp dụng khuyến mãi vào đơn hàng nằm trong OrderUseCase
+type KhuyenMaiUseCase struct {
+	repo      repository.IKhuyenMaiRepository
+	monAnRepo repository.IMonAnRepository
+	auditRepo repository.IAuditLogRepository
+}
+
+// NewKhuyenMaiUseCase tạo mới KhuyenMaiUseCase
+func NewKhuyenMaiUseCase(
+	repo repository.IKhuyenMaiRepository,
+	monAnRepo repository.IMonAnRepository,
+	auditRepo repository.IAuditLogRepository,
+) *KhuyenMaiUseCase {
+	return &KhuyenMaiUseCase{
+		repo:      repo,
+		monAnRepo: monAnRepo,
+		auditRepo: auditRepo,
+	}
+}
+
+// TaoKhuyenMai tạo chương trình khuyến mãi mới
+// Các món được tham chiếu phải tồn tại trong menu
+func (uc *KhuyenMaiUseCase) TaoKhuyenMai(ctx context.Context, input TaoKhuyenMaiInput) (*entity.KhuyenMai, error) {
+	km, err := entity.NewKhuyenMai(uuid.New().String(), input.Ten, input.Loai)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tạo khuyến mãi: %w", err)
+	}
+	km.MoTa = input.MoTa
+	km.GiaTri = input.GiaTri
+	km.GiamToiDa = input.GiamToiDa
+	km.MonAnIDs = input.MonAnIDs
+	km.SoLuongMua = input.SoLuongMua
+	km.SoLuongTang = input.SoLuongTang
+	km.GiaCombo = input.GiaCombo
+	km.DonToiThieu = input.DonToiThieu
+	km.TuNgay = input.TuNgay
+	km.DenNgay = input.DenNgay
+	km.KhungGio = input.KhungGio
+	km.CongDon = input.CongDon
+	km.UuTien = input.UuTien
+
+	if err := km.KiemTra(); err != nil {
+		return nil, fmt.Errorf("không thể tạo khuyến mãi: %w", err)
+	}
+
+	for _, monAnID := range km.MonAnIDs {
+		mon, err := uc.monAnRepo.FindByID(ctx, monAnID)
+		if err != nil {
+			return nil, fmt.Errorf("không thể tìm món: %w", err)
+		}
+		if mon == nil {
+			return nil, fmt.Errorf("%w: %s", ErrMonAnNotFound, monAnID)
+		}
+	}
+
+	if err := uc.repo.Save(ctx, km); err != nil {
+		return nil, fmt.Errorf("không thể lưu khuyến mãi: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Promotion created",
+		zap.String("khuyen_mai_id", km.ID),
+		zap.String("loai", string(km.Loai)),
+		zap.Bool("cong_don", km.CongDon),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "khuyen_mai.tao", entity.AuditDoiTuongKhuyenMai, km.ID, nil, snapshotKhuyenMai(km))
+
+	return km, nil
+}
+
+// XemKhuyenMai lấy danh sách tất cả khuyến mãi
+func (uc *KhuyenMaiUseCase) XemKhuyenMai(ctx context.Context) ([]*entity.KhuyenMai, error) {
+	list, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách khuyến mãi: %w", err)
+	}
+
+	return list, nil
+}
+
+// TimKhuyenMai lấy khuyến mãi theo ID
+func (uc *KhuyenMaiUseCase) TimKhuyenMai(ctx context.Context, id string) (*entity.KhuyenMai, error) {
+	km, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm khuyến mãi: %w", err)
+	}
+	if km == nil {
+		return nil, ErrKhuyenMaiNotFound
+	}
+
+	return km, nil
+}
+
+// NgungKhuyenMai tắt khuyến mãi (giữ lại để đối soát các đơn đã áp dụng)
+func (uc *KhuyenMaiUseCase) NgungKhuyenMai(ctx context.Context, id string) (*entity.KhuyenMai, error) {
+	km, err := uc.TimKhuyenMai(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotKhuyenMai(km)
+	km.NgungHoatDong()
+
+	if err := uc.repo.Save(ctx, km); err != nil {
+		return nil, fmt.Errorf("không thể lưu khuyến mãi: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Promotion deactivated", zap.String("khuyen_mai_id", km.ID))
+	ghiAuditLog(ctx, uc.auditRepo, "khuyen_mai.ngung", entity.AuditDoiTuongKhuyenMai, km.ID, truoc, snapshotKhuyenMai(km))
+
+	return km, nil
+}
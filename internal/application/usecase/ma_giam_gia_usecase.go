@@ -0,0 +1,189 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/pkg/logger"
+)
+
+// Mã giảm giá use case errors
+var (
+	ErrMaGiamGiaNotFound   = errors.New("không tìm thấy mã giảm giá")
+	ErrMaGiamGiaKhongHopLe = errors.New("mã giảm giá không dùng được")
+	ErrMaGiamGiaDaTonTai   = errors.New("mã giảm giá đã tồn tại")
+	ErrSoLuongMaKhongHopLe = errors.New("số lượng mã phải từ 1 đến 1000")
+	ErrMaCoDinhChiMotMa    = errors.New("khi chỉ định mã cụ thể thì chỉ được phát hành 1 mã")
+)
+
+// Cấu hình sinh mã ngẫu nhiên
+const (
+	// bangKyTuMa bỏ các ký tự dễ nhầm (0/O, 1/I/L)
+	bangKyTuMa       = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+	doDaiMaNgauNhien = 8
+	soLuongMaToiDa   = 1000
+)
+
+// PhatHanhMaInput là input để phát hành mã giảm giá hàng loạt
+type PhatHanhMaInput struct {
+	ChienDich     string
+	Ma            string // Mã cụ thể (VD: "SUMMER10"), rỗng = sinh ngẫu nhiên
+	TienTo        string // Tiền tố cho mã sinh ngẫu nhiên (VD: "TET-")
+	SoLuong       int
+	Loai          entity.LoaiMaGiamGia
+	GiaTri        int64
+	GiamToiDa     int64
+	DonToiThieu   int64
+	SoLanToiDa    int
+	SoLanMoiKhach int
+	HetHan        *time.Time
+}
+
+// MaGiamGiaUseCase xử lý phát hành và thống kê mã giảm giá
+// Việc dùng / hoàn mã khi đặt và hủy đơn nằm trong OrderUseCase
+type MaGiamGiaUseCase struct {
+	repo      repository.IMaGiamGiaRepository
+	auditRepo repository.IAuditLogRepository
+}
+
+// NewMaGiamGiaUseCase tạo mới MaGiamGiaUseCase
+func NewMaGiamGiaUseCase(repo repository.IMaGiamGiaRepository, auditRepo repository.IAuditLogRepository) *MaGiamGiaUseCase {
+	return &MaGiamGiaUseCase{
+		repo:      repo,
+		auditRepo: auditRepo,
+	}
+}
+
+// PhatHanhMa phát hành mã giảm giá hàng loạt cho một chiến dịch
+// Tất cả mã được lưu trong một transaction: hoặc đủ cả lô, hoặc không mã nào
+func (uc *MaGiamGiaUseCase) PhatHanhMa(ctx context.Context, input PhatHanhMaInput) ([]*entity.MaGiamGia, error) {
+	if input.SoLuong <= 0 || input.SoLuong > soLuongMaToiDa {
+		return nil, ErrSoLuongMaKhongHopLe
+	}
+	if input.Ma != "" && input.SoLuong != 1 {
+		return nil, ErrMaCoDinhChiMotMa
+	}
+
+	cacMa, err := uc.sinhMa(input)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*entity.MaGiamGia, 0, len(cacMa))
+	for _, ma := range cacMa {
+		m, err := entity.NewMaGiamGia(uuid.New().String(), ma, input.ChienDich, input.Loai, input.GiaTri)
+		if err != nil {
+			return nil, fmt.Errorf("không thể tạo mã giảm giá: %w", err)
+		}
+		m.GiamToiDa = input.GiamToiDa
+		m.DonToiThieu = input.DonToiThieu
+		m.SoLanToiDa = input.SoLanToiDa
+		m.SoLanMoiKhach = input.SoLanMoiKhach
+		m.HetHan = input.HetHan
+		list = append(list, m)
+	}
+
+	if err := uc.repo.SaveBatch(ctx, list); err != nil {
+		if errors.Is(err, repository.ErrDuplicateEntry) {
+			return nil, ErrMaGiamGiaDaTonTai
+		}
+		return nil, fmt.Errorf("không thể lưu mã giảm giá: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Vouchers issued",
+		zap.String("chien_dich", input.ChienDich),
+		zap.Int("so_luong", len(list)),
+		zap.String("loai", string(input.Loai)),
+	)
+	// Một bản ghi cho cả lô, đối tượng là chiến dịch
+	ghiAuditLog(ctx, uc.auditRepo, "ma_giam_gia.phat_hanh", entity.AuditDoiTuongMaGiamGia, input.ChienDich, nil, map[string]any{
+		"loai":     string(input.Loai),
+		"gia_tri":  input.GiaTri,
+		"so_luong": len(list),
+		"cac_ma":   cacMa,
+		"het_han":  thoiGianAudit(input.HetHan),
+	})
+
+	return list, nil
+}
+
+// sinhMa tạo danh sách mã (mã cụ thể hoặc mã ngẫu nhiên không trùng nhau trong lô)
+func (uc *MaGiamGiaUseCase) sinhMa(input PhatHanhMaInput) ([]string, error) {
+	if input.Ma != "" {
+		return []string{input.Ma}, nil
+	}
+
+	tienTo := entity.ChuanHoaMa(input.TienTo)
+	daCo := make(map[string]bool, input.SoLuong)
+	result := make([]string, 0, input.SoLuong)
+	for len(result) < input.SoLuong {
+		ngauNhien, err := chuoiNgauNhien(doDaiMaNgauNhien)
+		if err != nil {
+			return nil, fmt.Errorf("không thể sinh mã: %w", err)
+		}
+		ma := tienTo + ngauNhien
+		if daCo[ma] {
+			continue
+		}
+		daCo[ma] = true
+		result = append(result, ma)
+	}
+	return result, nil
+}
+
+// chuoiNgauNhien sinh chuỗi ngẫu nhiên an toàn (crypto/rand) từ bangKyTuMa
+func chuoiNgauNhien(doDai int) (string, error) {
+	b := make([]byte, doDai)
+	soKyTu := big.NewInt(int64(len(bangKyTuMa)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, soKyTu)
+		if err != nil {
+			return "", err
+		}
+		b[i] = bangKyTuMa[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// XemMaTheoChienDich lấy các mã của một chiến dịch
+func (uc *MaGiamGiaUseCase) XemMaTheoChienDich(ctx context.Context, chienDich string) ([]*entity.MaGiamGia, error) {
+	list, err := uc.repo.FindByChienDich(ctx, chienDich)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách mã: %w", err)
+	}
+
+	return list, nil
+}
+
+// TimMa lấy mã giảm giá theo mã
+func (uc *MaGiamGiaUseCase) TimMa(ctx context.Context, ma string) (*entity.MaGiamGia, error) {
+	m, err := uc.repo.FindByMa(ctx, entity.ChuanHoaMa(ma))
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm mã giảm giá: %w", err)
+	}
+	if m == nil {
+		return nil, ErrMaGiamGiaNotFound
+	}
+
+	return m, nil
+}
+
+// ThongKe lấy số liệu sử dụng mã của một chiến dịch
+func (uc *MaGiamGiaUseCase) ThongKe(ctx context.Context, chienDich string) (*entity.ThongKeMaGiamGia, error) {
+	tk, err := uc.repo.ThongKe(ctx, chienDich)
+	if err != nil {
+		return nil, fmt.Errorf("không thể thống kê mã giảm giá: %w", err)
+	}
+
+	return tk, nil
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"os"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	// Use case log qua global logger, test không cần output
+	logger.Log = zap.NewNop()
+	os.Exit(m.Run())
+}
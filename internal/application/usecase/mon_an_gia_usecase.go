@@ -0,0 +1,278 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/pkg/logger"
+)
+
+// Giá món ăn use case errors
+var (
+	ErrLichDoiGiaNotFound = errors.New("không tìm thấy lịch đổi giá")
+	ErrLichDoiGiaDaXuLy   = errors.New("lịch đổi giá đã được áp dụng hoặc hủy")
+)
+
+// doiGia đổi giá món, lưu món và ghi lịch sử giá + audit log
+// lichDoiGiaID khác rỗng khi đổi giá theo lịch
+func (uc *MonAnUseCase) doiGia(ctx context.Context, mon *entity.MonAn, giaMoi int64, nguoiThayDoi, lichDoiGiaID string) error {
+	return uc.doiGiaKem(ctx, mon, giaMoi, nguoiThayDoi, lichDoiGiaID, nil)
+}
+
+// doiGiaKem như doiGia, luuThem (nếu có) chạy trong cùng transaction với giá mới và lịch sử giá
+// Entity được đổi trước transaction: transaction có thể chạy lại khi gặp lỗi tạm thời.
+// Save tăng mon.Version khi thành công nên mỗi lần chạy lại đặt lại version đã đọc
+func (uc *MonAnUseCase) doiGiaKem(ctx context.Context, mon *entity.MonAn, giaMoi int64, nguoiThayDoi, lichDoiGiaID string, luuThem func(ctx context.Context) error) error {
+	truoc := snapshotMonAn(mon)
+	giaCu := mon.Gia
+
+	if err := mon.CapNhatGia(giaMoi); err != nil {
+		return fmt.Errorf("không thể cập nhật giá: %w", err)
+	}
+
+	lichSu := entity.NewLichSuGia(uuid.New().String(), mon.ID, giaCu, mon.Gia, mon.NgayCapNhat, nguoiThayDoi)
+	lichSu.LichDoiGiaID = lichDoiGiaID
+
+	// Giá mới và lịch sử giá lưu cùng transaction: không có giá nào thiếu mục lịch sử
+	version := mon.Version
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		mon.Version = version
+		if err := uc.repo.Save(ctx, mon); err != nil {
+			return fmt.Errorf("không thể lưu món ăn: %w", err)
+		}
+		if err := uc.ghiLichSuGia(ctx, lichSu); err != nil {
+			return err
+		}
+		if luuThem != nil {
+			return luuThem(ctx)
+		}
+		return nil
+	})
+	if err != nil {
+		mon.Version = version
+		return err
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.cap_nhat_gia", entity.AuditDoiTuongMonAn, mon.ID, truoc, snapshotMonAn(mon))
+
+	return nil
+}
+
+// ghiLichSuGia ghi một lần đổi giá vào lịch sử (gọi trong transaction lưu giá)
+func (uc *MonAnUseCase) ghiLichSuGia(ctx context.Context, lichSu *entity.LichSuGia) error {
+	if uc.lichSuGiaRepo == nil {
+		return nil
+	}
+	if err := uc.lichSuGiaRepo.Append(ctx, lichSu); err != nil {
+		return fmt.Errorf("không thể ghi lịch sử giá: %w", err)
+	}
+	return nil
+}
+
+// XemLichSuGia lấy lịch sử giá của món theo thứ tự thời gian
+// tu/den lọc theo thời điểm giá có hiệu lực (nil = không giới hạn)
+// Món đã xóa vẫn xem được lịch sử để đối soát báo cáo
+func (uc *MonAnUseCase) XemLichSuGia(ctx context.Context, monAnID string, tu, den *time.Time) ([]*entity.LichSuGia, error) {
+	if monAnID == "" {
+		return nil, errors.New("ID không được để trống")
+	}
+	if tu != nil && den != nil && den.Before(*tu) {
+		return nil, ErrKhoangThoiGianKhongHopLe
+	}
+
+	list, err := uc.lichSuGiaRepo.FindByMonAnID(ctx, monAnID, tu, den)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy lịch sử giá: %w", err)
+	}
+
+	return list, nil
+}
+
+// DatLichDoiGiaInput là dữ liệu đầu vào để đặt lịch đổi giá
+type DatLichDoiGiaInput struct {
+	MonAnID   string
+	GiaMoi    int64
+	ApDungLuc time.Time
+}
+
+// DatLichDoiGia đặt trước một lần đổi giá cho món, scheduler áp dụng khi tới thời điểm
+func (uc *MonAnUseCase) DatLichDoiGia(ctx context.Context, input DatLichDoiGiaInput) (*entity.LichDoiGia, error) {
+	mon, err := uc.TimMon(ctx, input.MonAnID)
+	if err != nil {
+		return nil, err
+	}
+
+	lich, err := entity.NewLichDoiGia(uuid.New().String(), mon.ID, input.GiaMoi, input.ApDungLuc,
+		actorIDTuContext(ctx), uc.bayGio())
+	if err != nil {
+		return nil, fmt.Errorf("không thể đặt lịch đổi giá: %w", err)
+	}
+
+	if err := uc.lichDoiGiaRepo.Save(ctx, lich); err != nil {
+		return nil, fmt.Errorf("không thể lưu lịch đổi giá: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Price change scheduled",
+		zap.String("mon_an_id", mon.ID),
+		zap.String("lich_doi_gia_id", lich.ID),
+		zap.Int64("gia_moi", lich.GiaMoi),
+		zap.Time("ap_dung_luc", lich.ApDungLuc),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.dat_lich_doi_gia", entity.AuditDoiTuongMonAn, mon.ID, nil, map[string]any{
+		"lich_doi_gia_id": lich.ID,
+		"gia_moi":         lich.GiaMoi,
+		"ap_dung_luc":     lich.ApDungLuc.Format(time.RFC3339),
+	})
+
+	return lich, nil
+}
+
+// XemLichDoiGia lấy các lịch đổi giá của món (mọi trạng thái), theo thời điểm áp dụng
+func (uc *MonAnUseCase) XemLichDoiGia(ctx context.Context, monAnID string) ([]*entity.LichDoiGia, error) {
+	if monAnID == "" {
+		return nil, errors.New("ID không được để trống")
+	}
+
+	list, err := uc.lichDoiGiaRepo.FindByMonAnID(ctx, monAnID)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy lịch đổi giá: %w", err)
+	}
+
+	return list, nil
+}
+
+// HuyLichDoiGia hủy lịch đổi giá chưa áp dụng của món
+func (uc *MonAnUseCase) HuyLichDoiGia(ctx context.Context, monAnID, lichID string) (*entity.LichDoiGia, error) {
+	lich, err := uc.lichDoiGiaRepo.FindByID(ctx, lichID)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm lịch đổi giá: %w", err)
+	}
+	if lich == nil || lich.MonAnID != monAnID {
+		return nil, ErrLichDoiGiaNotFound
+	}
+
+	if err := lich.Huy(); err != nil {
+		return nil, err
+	}
+
+	// Scheduler có thể đang áp dụng đúng lịch này: chỉ hủy được nếu lịch vẫn còn chờ
+	if err := uc.luuTrangThaiLich(ctx, lich); err != nil {
+		return nil, err
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.huy_lich_doi_gia", entity.AuditDoiTuongMonAn, monAnID,
+		map[string]any{"lich_doi_gia_id": lich.ID, "trang_thai": string(entity.LichDoiGiaChoApDung)},
+		map[string]any{"lich_doi_gia_id": lich.ID, "trang_thai": string(lich.TrangThai)},
+	)
+
+	return lich, nil
+}
+
+// ApDungLichDoiGia áp dụng các lịch đổi giá đã tới hạn, trả về số lịch đã áp dụng
+// Được scheduler gọi định kỳ. Lịch của món đã bị xóa được hủy.
+// Lỗi ở một lịch không chặn các lịch khác; lịch lỗi vẫn chờ và được thử lại ở lần chạy sau
+func (uc *MonAnUseCase) ApDungLichDoiGia(ctx context.Context) (int, error) {
+	now := uc.bayGio()
+	list, err := uc.lichDoiGiaRepo.FindDenHan(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("không thể lấy lịch đổi giá tới hạn: %w", err)
+	}
+
+	soDaApDung := 0
+	var errs []error
+	for _, lich := range list {
+		daApDung, err := uc.apDungLich(ctx, lich, now)
+		if err != nil {
+			logger.CtxError(ctx, "failed to apply scheduled price change",
+				zap.String("lich_doi_gia_id", lich.ID),
+				zap.String("mon_an_id", lich.MonAnID),
+				zap.Error(err),
+			)
+			errs = append(errs, fmt.Errorf("lịch %s: %w", lich.ID, err))
+			continue
+		}
+		if daApDung {
+			soDaApDung++
+		}
+	}
+
+	return soDaApDung, errors.Join(errs...)
+}
+
+// apDungLich áp dụng một lịch đổi giá, false nếu lịch bị hủy vì món không còn
+func (uc *MonAnUseCase) apDungLich(ctx context.Context, lich *entity.LichDoiGia, now time.Time) (bool, error) {
+	mon, err := uc.repo.FindByID(ctx, lich.MonAnID)
+	if err != nil {
+		return false, fmt.Errorf("không thể tìm món: %w", err)
+	}
+
+	if mon == nil {
+		if err := lich.Huy(); err != nil {
+			return false, err
+		}
+		// Lịch đã được hủy/áp dụng ở nơi khác thì không cần thử lại
+		if err := uc.luuTrangThaiLich(ctx, lich); err != nil && !errors.Is(err, ErrLichDoiGiaDaXuLy) {
+			return false, err
+		}
+		logger.CtxWarn(ctx, "Scheduled price change cancelled, dish no longer exists",
+			zap.String("lich_doi_gia_id", lich.ID),
+			zap.String("mon_an_id", lich.MonAnID),
+		)
+		return false, nil
+	}
+
+	// Đổi giá và đánh dấu lịch trong cùng transaction: lỗi ở bất kỳ bước nào thì lịch vẫn chờ
+	// và lần sau thử lại. Giá đã bằng giá mới (đổi thủ công trùng giá của lịch) thì chỉ đánh dấu lịch
+	// để không ghi lịch sử giá không có thay đổi
+	if err := lich.DanhDauDaApDung(now); err != nil {
+		return false, err
+	}
+	// Lịch bị hủy trong lúc áp dụng thì đánh dấu thất bại và transaction rollback cả giá mới
+	luuLich := func(ctx context.Context) error {
+		return uc.luuTrangThaiLich(ctx, lich)
+	}
+
+	if mon.Gia != lich.GiaMoi {
+		err = uc.doiGiaKem(ctx, mon, lich.GiaMoi, lich.NguoiTao, lich.ID, luuLich)
+	} else {
+		err = luuLich(ctx)
+	}
+	if errors.Is(err, ErrLichDoiGiaDaXuLy) {
+		logger.CtxInfo(ctx, "Scheduled price change skipped, already cancelled or applied",
+			zap.String("lich_doi_gia_id", lich.ID),
+			zap.String("mon_an_id", lich.MonAnID),
+		)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	logger.CtxInfo(ctx, "Scheduled price change applied",
+		zap.String("lich_doi_gia_id", lich.ID),
+		zap.String("mon_an_id", mon.ID),
+		zap.Int64("gia_moi", mon.Gia),
+	)
+
+	return true, nil
+}
+
+// luuTrangThaiLich lưu trạng thái mới của lịch, ErrLichDoiGiaDaXuLy nếu lịch không còn chờ áp dụng
+func (uc *MonAnUseCase) luuTrangThaiLich(ctx context.Context, lich *entity.LichDoiGia) error {
+	err := uc.lichDoiGiaRepo.ChuyenTrangThai(ctx, lich)
+	if errors.Is(err, repository.ErrConcurrentModification) {
+		return ErrLichDoiGiaDaXuLy
+	}
+	if err != nil {
+		return fmt.Errorf("không thể lưu lịch đổi giá: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// errTamThoi giả lập lỗi tạm thời khiến transaction MongoDB được chạy lại
+var errTamThoi = errors.New("TransientTransactionError")
+
+// fakeMonAnRepo lưu bản sao món trong bộ nhớ, Save kiểm tra Version như MonAnMongoRepo
+type fakeMonAnRepo struct {
+	repository.IMonAnRepository
+
+	mu   sync.Mutex
+	mons map[string]entity.MonAn
+}
+
+func (r *fakeMonAnRepo) FindByID(_ context.Context, id string) (*entity.MonAn, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	mon, ok := r.mons[id]
+	if !ok {
+		return nil, nil
+	}
+	return &mon, nil
+}
+
+func (r *fakeMonAnRepo) Save(_ context.Context, mon *entity.MonAn) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mons[mon.ID].Version != mon.Version {
+		return repository.ErrConcurrentModification
+	}
+	mon.Version++
+	r.mons[mon.ID] = *mon
+	return nil
+}
+
+// fakeTxUnitOfWork rollback món về trạng thái trước transaction khi fn lỗi,
+// gặp errTamThoi thì chạy lại fn một lần (như WithTransaction của driver)
+type fakeTxUnitOfWork struct {
+	monRepo *fakeMonAnRepo
+	soLan   int
+}
+
+func (u *fakeTxUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	for {
+		u.soLan++
+		u.monRepo.mu.Lock()
+		truoc := maps.Clone(u.monRepo.mons)
+		u.monRepo.mu.Unlock()
+
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		u.monRepo.mu.Lock()
+		u.monRepo.mons = truoc
+		u.monRepo.mu.Unlock()
+		if !errors.Is(err, errTamThoi) || u.soLan > 1 {
+			return err
+		}
+	}
+}
+
+// fakeLichSuGiaRepo ghi lịch sử giá, loiLanDau != nil thì lần Append đầu tiên trả lỗi đó
+type fakeLichSuGiaRepo struct {
+	repository.ILichSuGiaRepository
+
+	loiLanDau error
+	list      []*entity.LichSuGia
+}
+
+func (r *fakeLichSuGiaRepo) Append(_ context.Context, lichSu *entity.LichSuGia) error {
+	if err := r.loiLanDau; err != nil {
+		r.loiLanDau = nil
+		return err
+	}
+	r.list = append(r.list, lichSu)
+	return nil
+}
+
+// fakeLichDoiGiaRepo lưu lịch đổi giá, ChuyenTrangThai chỉ khớp lịch còn chờ như LichDoiGiaMongoRepo
+// truocKhiChuyen (nếu có) chạy trước khi chuyển trạng thái: giả lập request/scheduler khác xử lý cùng lịch
+type fakeLichDoiGiaRepo struct {
+	repository.ILichDoiGiaRepository
+
+	mu             sync.Mutex
+	lichs          map[string]entity.LichDoiGia
+	truocKhiChuyen func(r *fakeLichDoiGiaRepo)
+}
+
+func (r *fakeLichDoiGiaRepo) FindByID(_ context.Context, id string) (*entity.LichDoiGia, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lich, ok := r.lichs[id]
+	if !ok {
+		return nil, nil
+	}
+	return &lich, nil
+}
+
+func (r *fakeLichDoiGiaRepo) FindDenHan(_ context.Context, now time.Time) ([]*entity.LichDoiGia, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var list []*entity.LichDoiGia
+	for _, lich := range r.lichs {
+		if lich.DenHan(now) {
+			list = append(list, &lich)
+		}
+	}
+	return list, nil
+}
+
+func (r *fakeLichDoiGiaRepo) ChuyenTrangThai(_ context.Context, lich *entity.LichDoiGia) error {
+	if hook := r.truocKhiChuyen; hook != nil {
+		r.truocKhiChuyen = nil
+		hook(r)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.lichs[lich.ID].TrangThai != entity.LichDoiGiaChoApDung {
+		return repository.ErrConcurrentModification
+	}
+	r.lichs[lich.ID] = *lich
+	return nil
+}
+
+// datTrangThai đổi trạng thái lịch đang lưu (gọi từ truocKhiChuyen)
+func (r *fakeLichDoiGiaRepo) datTrangThai(id string, trangThai entity.TrangThaiLichDoiGia) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lich := r.lichs[id]
+	lich.TrangThai = trangThai
+	r.lichs[id] = lich
+}
+
+// giaTestEnv là MonAnUseCase với một món giá 50000 (Version 1) và một lịch đổi giá đã tới hạn
+type giaTestEnv struct {
+	uc      *MonAnUseCase
+	mons    *fakeMonAnRepo
+	lichSu  *fakeLichSuGiaRepo
+	lichs   *fakeLichDoiGiaRepo
+	uow     *fakeTxUnitOfWork
+	lichDoi entity.LichDoiGia
+}
+
+func newGiaTestEnv(t *testing.T) *giaTestEnv {
+	t.Helper()
+
+	mon, err := entity.NewMonAn("mon-1", "Phở bò", 50000, "")
+	if err != nil {
+		t.Fatalf("NewMonAn: %v", err)
+	}
+	mon.Version = 1
+
+	lich := entity.LichDoiGia{
+		ID:        "lich-1",
+		MonAnID:   mon.ID,
+		GiaMoi:    60000,
+		ApDungLuc: time.Now().Add(-time.Minute),
+		TrangThai: entity.LichDoiGiaChoApDung,
+	}
+
+	env := &giaTestEnv{
+		mons:    &fakeMonAnRepo{mons: map[string]entity.MonAn{mon.ID: *mon}},
+		lichSu:  &fakeLichSuGiaRepo{},
+		lichs:   &fakeLichDoiGiaRepo{lichs: map[string]entity.LichDoiGia{lich.ID: lich}},
+		lichDoi: lich,
+	}
+	env.uow = &fakeTxUnitOfWork{monRepo: env.mons}
+	env.uc = NewMonAnUseCase(env.mons, env.lichSu, env.lichs, nil, env.uow, nil, time.UTC)
+	return env
+}
+
+func (env *giaTestEnv) giaHienTai(t *testing.T) entity.MonAn {
+	t.Helper()
+	mon, _ := env.mons.FindByID(context.Background(), "mon-1")
+	return *mon
+}
+
+func TestDoiGia_TransactionChayLai(t *testing.T) {
+	env := newGiaTestEnv(t)
+	env.lichSu.loiLanDau = errTamThoi
+
+	mon, _ := env.mons.FindByID(context.Background(), "mon-1")
+	if err := env.uc.doiGia(context.Background(), mon, 55000, "admin", ""); err != nil {
+		t.Fatalf("doiGia error = %v", err)
+	}
+
+	if env.uow.soLan != 2 {
+		t.Errorf("transaction attempts = %d, want 2", env.uow.soLan)
+	}
+	luu := env.giaHienTai(t)
+	if luu.Gia != 55000 || luu.Version != 2 {
+		t.Errorf("stored dish = gia %d version %d, want gia 55000 version 2", luu.Gia, luu.Version)
+	}
+	if mon.Version != 2 {
+		t.Errorf("entity version = %d, want 2", mon.Version)
+	}
+	if len(env.lichSu.list) != 1 {
+		t.Errorf("price history entries = %d, want 1", len(env.lichSu.list))
+	}
+}
+
+func TestHuyLichDoiGia_LichVuaDuocApDung(t *testing.T) {
+	env := newGiaTestEnv(t)
+	env.lichs.truocKhiChuyen = func(r *fakeLichDoiGiaRepo) {
+		r.datTrangThai(env.lichDoi.ID, entity.LichDoiGiaDaApDung)
+	}
+
+	_, err := env.uc.HuyLichDoiGia(context.Background(), env.lichDoi.MonAnID, env.lichDoi.ID)
+	if !errors.Is(err, ErrLichDoiGiaDaXuLy) {
+		t.Fatalf("HuyLichDoiGia error = %v, want %v", err, ErrLichDoiGiaDaXuLy)
+	}
+
+	lich, _ := env.lichs.FindByID(context.Background(), env.lichDoi.ID)
+	if lich.TrangThai != entity.LichDoiGiaDaApDung {
+		t.Errorf("schedule status = %s, want %s", lich.TrangThai, entity.LichDoiGiaDaApDung)
+	}
+}
+
+func TestApDungLichDoiGia_LichVuaBiHuy(t *testing.T) {
+	env := newGiaTestEnv(t)
+	env.lichs.truocKhiChuyen = func(r *fakeLichDoiGiaRepo) {
+		r.datTrangThai(env.lichDoi.ID, entity.LichDoiGiaDaHuy)
+	}
+
+	soDaApDung, err := env.uc.ApDungLichDoiGia(context.Background())
+	if err != nil || soDaApDung != 0 {
+		t.Fatalf("ApDungLichDoiGia = (%d, %v), want (0, nil)", soDaApDung, err)
+	}
+
+	// Transaction rollback: giá món giữ nguyên, lịch vẫn là đã hủy
+	if luu := env.giaHienTai(t); luu.Gia != 50000 || luu.Version != 1 {
+		t.Errorf("stored dish = gia %d version %d, want gia 50000 version 1", luu.Gia, luu.Version)
+	}
+	lich, _ := env.lichs.FindByID(context.Background(), env.lichDoi.ID)
+	if lich.TrangThai != entity.LichDoiGiaDaHuy {
+		t.Errorf("schedule status = %s, want %s", lich.TrangThai, entity.LichDoiGiaDaHuy)
+	}
+}
+
+func TestApDungLichDoiGia_ApDung(t *testing.T) {
+	env := newGiaTestEnv(t)
+
+	soDaApDung, err := env.uc.ApDungLichDoiGia(context.Background())
+	if err != nil || soDaApDung != 1 {
+		t.Fatalf("ApDungLichDoiGia = (%d, %v), want (1, nil)", soDaApDung, err)
+	}
+
+	if luu := env.giaHienTai(t); luu.Gia != 60000 {
+		t.Errorf("stored price = %d, want 60000", luu.Gia)
+	}
+	lich, _ := env.lichs.FindByID(context.Background(), env.lichDoi.ID)
+	if lich.TrangThai != entity.LichDoiGiaDaApDung || lich.DaApDungLuc == nil {
+		t.Errorf("schedule = %s applied at %v, want %s with time", lich.TrangThai, lich.DaApDungLuc, entity.LichDoiGiaDaApDung)
+	}
+}
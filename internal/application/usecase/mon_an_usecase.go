@@ -6,6 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
+	"github.com/google/uuid"
 
 	"restaurant_project/internal/domain/entity"
 	"restaurant_project/internal/domain/repository"
@@ -20,7 +23,13 @@ import (
 // - Không chứa business logic cốt lõi (đã có trong Entity)
 // - Chỉ điều phối workflow
 type MonAnUseCase struct {
-	repo repository.IMonAnRepository
+	repo           repository.IMonAnRepository
+	lichSuGiaRepo  repository.ILichSuGiaRepository  // Lịch sử giá từng món
+	lichDoiGiaRepo repository.ILichDoiGiaRepository // Lịch đổi giá đặt trước
+	auditRepo      repository.IAuditLogRepository   // Ghi audit log thao tác sửa menu
+	uow            repository.IUnitOfWork           // Transaction MongoDB: lưu món + ghi outbox
+	outboxRepo     repository.IOutboxRepository     // Outbox MongoDB (mon_an.availability_changed)
+	loc            *time.Location                   // Múi giờ nhà hàng - dùng cho lịch phục vụ món
 }
 
 // NewMonAnUseCase tạo mới MonAnUseCase với dependency injection
@@ -28,12 +37,31 @@ type MonAnUseCase struct {
 // - UseCase KHÔNG tự tạo repository
 // - Repository được "inject" (tiêm) từ bên ngoài
 // - Điều này giúp dễ dàng thay đổi implementation (VD: từ Memory → MySQL)
-func NewMonAnUseCase(repo repository.IMonAnRepository) *MonAnUseCase {
+func NewMonAnUseCase(
+	repo repository.IMonAnRepository,
+	lichSuGiaRepo repository.ILichSuGiaRepository,
+	lichDoiGiaRepo repository.ILichDoiGiaRepository,
+	auditRepo repository.IAuditLogRepository,
+	uow repository.IUnitOfWork,
+	outboxRepo repository.IOutboxRepository,
+	loc *time.Location,
+) *MonAnUseCase {
 	return &MonAnUseCase{
-		repo: repo,
+		repo:           repo,
+		lichSuGiaRepo:  lichSuGiaRepo,
+		lichDoiGiaRepo: lichDoiGiaRepo,
+		auditRepo:      auditRepo,
+		uow:            uow,
+		outboxRepo:     outboxRepo,
+		loc:            loc,
 	}
 }
 
+// bayGio trả về thời điểm hiện tại theo múi giờ nhà hàng
+func (uc *MonAnUseCase) bayGio() time.Time {
+	return time.Now().In(uc.loc)
+}
+
 // ThemMonInput là dữ liệu đầu vào để thêm món mới
 type ThemMonInput struct {
 	ID    string
@@ -59,6 +87,10 @@ func (uc *MonAnUseCase) ThemMon(ctx context.Context, input ThemMonInput) (*entit
 		return nil, fmt.Errorf("không thể lưu món ăn: %w", err)
 	}
 
+	// Giá khởi tạo là mốc đầu tiên của lịch sử giá
+	uc.ghiLichSuGia(ctx, entity.NewLichSuGia(uuid.New().String(), mon.ID, 0, mon.Gia, mon.NgayTao, actorIDTuContext(ctx)))
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.them", entity.AuditDoiTuongMonAn, mon.ID, nil, snapshotMonAn(mon))
+
 	return mon, nil
 }
 
@@ -72,14 +104,24 @@ func (uc *MonAnUseCase) XemMenu(ctx context.Context) ([]*entity.MonAn, error) {
 	return menu, nil
 }
 
-// XemMenuConHang lấy danh sách món còn bán
+// XemMenuConHang lấy danh sách món còn bán tại thời điểm hiện tại
+// Lọc theo lịch phục vụ ở đây (không ở repository) để danh sách được cache
+// không phụ thuộc thời gian và không bị stale khi qua ranh giới khung giờ
 func (uc *MonAnUseCase) XemMenuConHang(ctx context.Context) ([]*entity.MonAn, error) {
 	menu, err := uc.repo.FindByConHang(ctx, true)
 	if err != nil {
 		return nil, fmt.Errorf("không thể lấy menu còn hàng: %w", err)
 	}
 
-	return menu, nil
+	now := uc.bayGio()
+	dangBan := make([]*entity.MonAn, 0, len(menu))
+	for _, mon := range menu {
+		if mon.CoTheBanLuc(now) {
+			dangBan = append(dangBan, mon)
+		}
+	}
+
+	return dangBan, nil
 }
 
 // TimMon tìm món theo ID
@@ -102,26 +144,25 @@ func (uc *MonAnUseCase) TimMon(ctx context.Context, id string) (*entity.MonAn, e
 
 // CapNhatGiaInput là dữ liệu đầu vào để cập nhật giá
 type CapNhatGiaInput struct {
-	ID      string
-	GiaMoi  int64
+	ID       string
+	GiaMoi   int64
+	PhienBan int64 // Version client đã đọc (If-Match), 0 = không kiểm tra
 }
 
-// CapNhatGia cập nhật giá cho món ăn
+// CapNhatGia cập nhật giá cho món ăn (có hiệu lực ngay, ghi lịch sử giá)
 func (uc *MonAnUseCase) CapNhatGia(ctx context.Context, input CapNhatGiaInput) (*entity.MonAn, error) {
 	// Bước 1: Tìm món
 	mon, err := uc.TimMon(ctx, input.ID)
 	if err != nil {
 		return nil, err
 	}
-
-	// Bước 2: Cập nhật giá (business logic trong Entity)
-	if err := mon.CapNhatGia(input.GiaMoi); err != nil {
-		return nil, fmt.Errorf("không thể cập nhật giá: %w", err)
+	if err := kiemTraPhienBan(mon.Version, input.PhienBan); err != nil {
+		return nil, err
 	}
 
-	// Bước 3: Lưu lại
-	if err := uc.repo.Save(ctx, mon); err != nil {
-		return nil, fmt.Errorf("không thể lưu món ăn: %w", err)
+	// Bước 2: Đổi giá, lưu và ghi lịch sử
+	if err := uc.doiGia(ctx, mon, input.GiaMoi, actorIDTuContext(ctx), ""); err != nil {
+		return nil, err
 	}
 
 	return mon, nil
@@ -131,6 +172,7 @@ func (uc *MonAnUseCase) CapNhatGia(ctx context.Context, input CapNhatGiaInput) (
 type ApDungGiamGiaInput struct {
 	ID        string
 	PhanTram  int
+	PhienBan  int64 // Version client đã đọc (If-Match), 0 = không kiểm tra
 }
 
 // ApDungGiamGia áp dụng giảm giá cho món ăn
@@ -141,6 +183,12 @@ func (uc *MonAnUseCase) ApDungGiamGia(ctx context.Context, input ApDungGiamGiaIn
 		return nil, err
 	}
 
+	if err := kiemTraPhienBan(mon.Version, input.PhienBan); err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotMonAn(mon)
+
 	// Bước 2: Áp dụng giảm giá (business logic trong Entity)
 	if err := mon.ApDungGiamGia(input.PhanTram); err != nil {
 		return nil, fmt.Errorf("không thể áp dụng giảm giá: %w", err)
@@ -151,37 +199,136 @@ func (uc *MonAnUseCase) ApDungGiamGia(ctx context.Context, input ApDungGiamGiaIn
 		return nil, fmt.Errorf("không thể lưu món ăn: %w", err)
 	}
 
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.ap_dung_giam_gia", entity.AuditDoiTuongMonAn, mon.ID, truoc, snapshotMonAn(mon))
+
 	return mon, nil
 }
 
-// XoaMon xóa món khỏi menu
-func (uc *MonAnUseCase) XoaMon(ctx context.Context, id string) error {
-	if id == "" {
-		return errors.New("ID không được để trống")
+// DatLichBanInput là dữ liệu đầu vào để đặt lịch phục vụ
+type DatLichBanInput struct {
+	ID       string
+	LichBan  []entity.KhungGioBan
+	PhienBan int64 // Version client đã đọc (If-Match), 0 = không kiểm tra
+}
+
+// DatLichBan đặt lịch phục vụ cho món ăn (rỗng = phục vụ mọi lúc)
+func (uc *MonAnUseCase) DatLichBan(ctx context.Context, input DatLichBanInput) (*entity.MonAn, error) {
+	// Bước 1: Tìm món
+	mon, err := uc.TimMon(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := kiemTraPhienBan(mon.Version, input.PhienBan); err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotMonAn(mon)
+
+	// Bước 2: Đặt lịch (validation đã thực hiện khi tạo KhungGioBan)
+	mon.DatLichBan(input.LichBan)
+
+	// Bước 3: Lưu lại
+	if err := uc.repo.Save(ctx, mon); err != nil {
+		return nil, fmt.Errorf("không thể lưu món ăn: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.dat_lich_ban", entity.AuditDoiTuongMonAn, mon.ID, truoc, snapshotMonAn(mon))
+
+	return mon, nil
+}
+
+// DatTuyChonInput là dữ liệu đầu vào để đặt các nhóm tùy chọn của món
+type DatTuyChonInput struct {
+	ID          string
+	NhomTuyChon []entity.NhomTuyChon
+	PhienBan    int64 // Version client đã đọc (If-Match), 0 = không kiểm tra
+}
+
+// DatTuyChon thay toàn bộ nhóm tùy chọn của món (rỗng = món không có tùy chọn)
+func (uc *MonAnUseCase) DatTuyChon(ctx context.Context, input DatTuyChonInput) (*entity.MonAn, error) {
+	// Bước 1: Tìm món
+	mon, err := uc.TimMon(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := kiemTraPhienBan(mon.Version, input.PhienBan); err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotMonAn(mon)
+
+	// Bước 2: Đặt tùy chọn (validation từng nhóm đã thực hiện khi tạo NhomTuyChon)
+	if err := mon.DatNhomTuyChon(input.NhomTuyChon); err != nil {
+		return nil, err
+	}
+
+	// Bước 3: Lưu lại
+	if err := uc.repo.Save(ctx, mon); err != nil {
+		return nil, fmt.Errorf("không thể lưu món ăn: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.dat_tuy_chon", entity.AuditDoiTuongMonAn, mon.ID, truoc, snapshotMonAn(mon))
+
+	return mon, nil
+}
+
+// XoaMon xóa (mềm) món khỏi menu, admin khôi phục được trong thời gian lưu giữ
+// phienBan là Version client đã đọc (If-Match), 0 = không kiểm tra
+func (uc *MonAnUseCase) XoaMon(ctx context.Context, id string, phienBan int64) error {
+	mon, err := uc.TimMon(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := kiemTraPhienBan(mon.Version, phienBan); err != nil {
+		return err
 	}
 
 	if err := uc.repo.Delete(ctx, id); err != nil {
 		return fmt.Errorf("không thể xóa món: %w", err)
 	}
 
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.xoa", entity.AuditDoiTuongMonAn, id, snapshotMonAn(mon), nil)
+
 	return nil
 }
 
 // DanhDauHetHang đánh dấu món hết hàng
-func (uc *MonAnUseCase) DanhDauHetHang(ctx context.Context, id string) (*entity.MonAn, error) {
+// phienBan là Version client đã đọc (If-Match), 0 = không kiểm tra
+func (uc *MonAnUseCase) DanhDauHetHang(ctx context.Context, id string, phienBan int64) (*entity.MonAn, error) {
 	// Bước 1: Tìm món
 	mon, err := uc.TimMon(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if err := kiemTraPhienBan(mon.Version, phienBan); err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotMonAn(mon)
+	dangConHang := mon.ConHang
 
 	// Bước 2: Đánh dấu hết hàng
 	mon.HetHang()
 
-	// Bước 3: Lưu lại
-	if err := uc.repo.Save(ctx, mon); err != nil {
+	// Bước 3: Lưu lại (kèm sự kiện mon_an.availability_changed nếu món vừa chuyển sang hết hàng)
+	if dangConHang {
+		err = luuKemSuKien(ctx, uc.uow, uc.outboxRepo, func(ctx context.Context) error {
+			return uc.repo.Save(ctx, mon)
+		}, entity.SuKienMonAnAvailabilityChanged, mon.ID, entity.MonAnAvailabilityChangedPayload{
+			MonAnID: mon.ID,
+			Ten:     mon.Ten,
+			ConHang: mon.ConHang,
+		})
+	} else {
+		err = uc.repo.Save(ctx, mon)
+	}
+	if err != nil {
 		return nil, fmt.Errorf("không thể lưu món ăn: %w", err)
 	}
 
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.het_hang", entity.AuditDoiTuongMonAn, mon.ID, truoc, snapshotMonAn(mon))
+
 	return mon, nil
 }
@@ -0,0 +1,550 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/pkg/logger"
+)
+
+// Mua hàng use case errors
+var (
+	ErrNhaCungCapNotFound    = errors.New("không tìm thấy nhà cung cấp")
+	ErrNhaCungCapNgungHopTac = errors.New("nhà cung cấp đã ngừng hợp tác")
+	ErrDonDatHangNotFound    = errors.New("không tìm thấy đơn đặt hàng")
+)
+
+// Giá trị mặc định cho gợi ý đặt hàng
+const (
+	defaultSoNgayTieuHao = 14 // Số ngày tiêu hao gần đây dùng để tính trung bình
+	defaultSoNgayDuTru   = 7  // Số ngày dự trữ sau khi hàng về
+)
+
+// TaoNhaCungCapInput là input để tạo nhà cung cấp
+type TaoNhaCungCapInput struct {
+	Ten         string
+	NguoiLienHe string
+	SoDienThoai string
+	Email       string
+	DiaChi      string
+	SoNgayGiao  int
+}
+
+// CapNhatNhaCungCapInput là input để cập nhật nhà cung cấp
+type CapNhatNhaCungCapInput struct {
+	ID          string
+	Ten         *string
+	NguoiLienHe *string
+	SoDienThoai *string
+	Email       *string
+	DiaChi      *string
+	SoNgayGiao  *int
+	DangHopTac  *bool
+}
+
+// TaoDonDatHangItemInput là một dòng nguyên liệu khi tạo đơn
+type TaoDonDatHangItemInput struct {
+	NguyenLieuID string
+	SoLuong      float64
+	DonGia       int64
+}
+
+// TaoDonDatHangInput là input để tạo đơn đặt hàng (bản nháp)
+type TaoDonDatHangInput struct {
+	NhaCungCapID string
+	NguoiTaoID   string
+	GhiChu       string
+	Items        []TaoDonDatHangItemInput
+}
+
+// NhanHangItemInput là một dòng nguyên liệu nhận được
+type NhanHangItemInput struct {
+	NguyenLieuID string
+	SoLuong      float64
+	DonGia       *int64 // Đơn giá thực tế (nil = dùng đơn giá trên đơn)
+}
+
+// NhanHangInput là input để ghi nhận hàng về
+type NhanHangInput struct {
+	DonDatHangID string
+	Items        []NhanHangItemInput
+}
+
+// GoiYDatHangInput là input cho báo cáo gợi ý đặt hàng
+type GoiYDatHangInput struct {
+	SoNgayTieuHao int    // Số ngày tiêu hao gần đây (mặc định 14)
+	SoNgayDuTru   int    // Số ngày dự trữ mong muốn (mặc định 7)
+	NhaCungCapID  string // Nhà cung cấp dự kiến (dùng thời gian giao hàng), optional
+}
+
+// GoiYDatHang là một dòng trong báo cáo gợi ý đặt hàng
+type GoiYDatHang struct {
+	NguyenLieu           *entity.NguyenLieu
+	TieuHaoTrungBinhNgay float64 // Tiêu hao trung bình mỗi ngày
+	DangVe               float64 // Số lượng đang về từ các đơn chưa nhận đủ
+	SoLuongGoiY          float64 // Số lượng nên đặt thêm
+}
+
+// MuaHangUseCase xử lý nhà cung cấp, đơn đặt hàng và nhập kho
+type MuaHangUseCase struct {
+	nhaCungCapRepo repository.INhaCungCapRepository
+	donDatHangRepo repository.IDonDatHangRepository
+	nguyenLieuRepo repository.INguyenLieuRepository
+	uow            repository.IUnitOfWork // Transaction MySQL: đơn đặt hàng + tồn kho + biến động kho
+	auditRepo      repository.IAuditLogRepository
+}
+
+// NewMuaHangUseCase tạo mới MuaHangUseCase
+func NewMuaHangUseCase(
+	nhaCungCapRepo repository.INhaCungCapRepository,
+	donDatHangRepo repository.IDonDatHangRepository,
+	nguyenLieuRepo repository.INguyenLieuRepository,
+	uow repository.IUnitOfWork,
+	auditRepo repository.IAuditLogRepository,
+) *MuaHangUseCase {
+	return &MuaHangUseCase{
+		nhaCungCapRepo: nhaCungCapRepo,
+		donDatHangRepo: donDatHangRepo,
+		nguyenLieuRepo: nguyenLieuRepo,
+		uow:            uow,
+		auditRepo:      auditRepo,
+	}
+}
+
+// ============================================================
+// Nhà cung cấp
+// ============================================================
+
+// TaoNhaCungCap tạo nhà cung cấp mới
+func (uc *MuaHangUseCase) TaoNhaCungCap(ctx context.Context, input TaoNhaCungCapInput) (*entity.NhaCungCap, error) {
+	ncc, err := entity.NewNhaCungCap(uuid.New().String(), input.Ten, input.SoDienThoai, input.SoNgayGiao)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tạo nhà cung cấp: %w", err)
+	}
+	ncc.NguoiLienHe = input.NguoiLienHe
+	ncc.Email = input.Email
+	ncc.DiaChi = input.DiaChi
+
+	if err := uc.nhaCungCapRepo.Save(ctx, ncc); err != nil {
+		return nil, fmt.Errorf("không thể lưu nhà cung cấp: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "nha_cung_cap.tao", entity.AuditDoiTuongNhaCungCap, ncc.ID, nil, snapshotNhaCungCap(ncc))
+
+	return ncc, nil
+}
+
+// XemNhaCungCap lấy danh sách nhà cung cấp
+func (uc *MuaHangUseCase) XemNhaCungCap(ctx context.Context) ([]*entity.NhaCungCap, error) {
+	list, err := uc.nhaCungCapRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách nhà cung cấp: %w", err)
+	}
+
+	return list, nil
+}
+
+// TimNhaCungCap tìm nhà cung cấp theo ID
+func (uc *MuaHangUseCase) TimNhaCungCap(ctx context.Context, id string) (*entity.NhaCungCap, error) {
+	ncc, err := uc.nhaCungCapRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm nhà cung cấp: %w", err)
+	}
+	if ncc == nil {
+		return nil, ErrNhaCungCapNotFound
+	}
+
+	return ncc, nil
+}
+
+// CapNhatNhaCungCap cập nhật thông tin nhà cung cấp
+func (uc *MuaHangUseCase) CapNhatNhaCungCap(ctx context.Context, input CapNhatNhaCungCapInput) (*entity.NhaCungCap, error) {
+	ncc, err := uc.TimNhaCungCap(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	truoc := snapshotNhaCungCap(ncc)
+
+	if input.Ten != nil {
+		if *input.Ten == "" {
+			return nil, errors.New("tên nhà cung cấp không được để trống")
+		}
+		ncc.Ten = *input.Ten
+	}
+	if input.SoDienThoai != nil {
+		if *input.SoDienThoai == "" {
+			return nil, errors.New("số điện thoại không được để trống")
+		}
+		ncc.SoDienThoai = *input.SoDienThoai
+	}
+	if input.SoNgayGiao != nil {
+		if *input.SoNgayGiao < 0 {
+			return nil, errors.New("số ngày giao hàng không được âm")
+		}
+		ncc.SoNgayGiao = *input.SoNgayGiao
+	}
+	if input.NguoiLienHe != nil {
+		ncc.NguoiLienHe = *input.NguoiLienHe
+	}
+	if input.Email != nil {
+		ncc.Email = *input.Email
+	}
+	if input.DiaChi != nil {
+		ncc.DiaChi = *input.DiaChi
+	}
+	if input.DangHopTac != nil {
+		if *input.DangHopTac {
+			ncc.HopTacLai()
+		} else {
+			ncc.NgungHopTac()
+		}
+	}
+	ncc.NgayCapNhat = time.Now()
+
+	if err := uc.nhaCungCapRepo.Save(ctx, ncc); err != nil {
+		return nil, fmt.Errorf("không thể lưu nhà cung cấp: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "nha_cung_cap.cap_nhat", entity.AuditDoiTuongNhaCungCap, ncc.ID, truoc, snapshotNhaCungCap(ncc))
+
+	return ncc, nil
+}
+
+// ============================================================
+// Đơn đặt hàng
+// ============================================================
+
+// TaoDonDatHang tạo đơn đặt hàng ở trạng thái nháp
+func (uc *MuaHangUseCase) TaoDonDatHang(ctx context.Context, input TaoDonDatHangInput) (*entity.DonDatHang, error) {
+	ncc, err := uc.TimNhaCungCap(ctx, input.NhaCungCapID)
+	if err != nil {
+		return nil, err
+	}
+	if !ncc.DangHopTac {
+		return nil, ErrNhaCungCapNgungHopTac
+	}
+
+	don, err := entity.NewDonDatHang(uuid.New().String(), ncc.ID, input.NguoiTaoID)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tạo đơn đặt hàng: %w", err)
+	}
+	don.GhiChu = input.GhiChu
+
+	for _, item := range input.Items {
+		if err := uc.themNguyenLieuVaoDon(ctx, don, item); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.donDatHangRepo.Save(ctx, don); err != nil {
+		return nil, fmt.Errorf("không thể lưu đơn đặt hàng: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "don_dat_hang.tao", entity.AuditDoiTuongDonDatHang, don.ID, nil, snapshotDonDatHang(don))
+
+	return don, nil
+}
+
+// ThemNguyenLieuVaoDon thêm một dòng nguyên liệu vào đơn nháp
+func (uc *MuaHangUseCase) ThemNguyenLieuVaoDon(ctx context.Context, donID string, item TaoDonDatHangItemInput) (*entity.DonDatHang, error) {
+	don, err := uc.TimDonDatHang(ctx, donID)
+	if err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotDonDatHang(don)
+	if err := uc.themNguyenLieuVaoDon(ctx, don, item); err != nil {
+		return nil, err
+	}
+
+	if err := uc.donDatHangRepo.Save(ctx, don); err != nil {
+		return nil, fmt.Errorf("không thể lưu đơn đặt hàng: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "don_dat_hang.them_nguyen_lieu", entity.AuditDoiTuongDonDatHang, don.ID, truoc, snapshotDonDatHang(don))
+
+	return don, nil
+}
+
+// themNguyenLieuVaoDon kiểm tra nguyên liệu tồn tại rồi thêm vào đơn
+func (uc *MuaHangUseCase) themNguyenLieuVaoDon(ctx context.Context, don *entity.DonDatHang, item TaoDonDatHangItemInput) error {
+	nl, err := uc.nguyenLieuRepo.FindByID(ctx, item.NguyenLieuID)
+	if err != nil {
+		return fmt.Errorf("không thể tìm nguyên liệu: %w", err)
+	}
+	if nl == nil {
+		return ErrNguyenLieuNotFound
+	}
+
+	if err := don.ThemNguyenLieu(nl.ID, nl.Ten, item.SoLuong, item.DonGia); err != nil {
+		return fmt.Errorf("không thể thêm nguyên liệu: %w", err)
+	}
+
+	return nil
+}
+
+// XemDonDatHang lấy danh sách đơn đặt hàng, lọc theo trạng thái nếu có
+func (uc *MuaHangUseCase) XemDonDatHang(ctx context.Context, trangThai entity.TrangThaiDonDatHang) ([]*entity.DonDatHang, error) {
+	var list []*entity.DonDatHang
+	var err error
+
+	if trangThai != "" {
+		list, err = uc.donDatHangRepo.FindByTrangThai(ctx, trangThai)
+	} else {
+		list, err = uc.donDatHangRepo.FindAll(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách đơn đặt hàng: %w", err)
+	}
+
+	return list, nil
+}
+
+// TimDonDatHang tìm đơn đặt hàng theo ID
+func (uc *MuaHangUseCase) TimDonDatHang(ctx context.Context, id string) (*entity.DonDatHang, error) {
+	don, err := uc.donDatHangRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm đơn đặt hàng: %w", err)
+	}
+	if don == nil {
+		return nil, ErrDonDatHangNotFound
+	}
+
+	return don, nil
+}
+
+// GuiDonDatHang gửi đơn cho nhà cung cấp
+func (uc *MuaHangUseCase) GuiDonDatHang(ctx context.Context, id string) (*entity.DonDatHang, error) {
+	don, err := uc.TimDonDatHang(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotDonDatHang(don)
+	if err := don.Gui(); err != nil {
+		return nil, fmt.Errorf("không thể gửi đơn đặt hàng: %w", err)
+	}
+
+	if err := uc.donDatHangRepo.Save(ctx, don); err != nil {
+		return nil, fmt.Errorf("không thể lưu đơn đặt hàng: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "don_dat_hang.gui", entity.AuditDoiTuongDonDatHang, don.ID, truoc, snapshotDonDatHang(don))
+
+	return don, nil
+}
+
+// HuyDonDatHang hủy đơn đặt hàng
+func (uc *MuaHangUseCase) HuyDonDatHang(ctx context.Context, id string) (*entity.DonDatHang, error) {
+	don, err := uc.TimDonDatHang(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	truoc := snapshotDonDatHang(don)
+	if err := don.Huy(); err != nil {
+		return nil, fmt.Errorf("không thể hủy đơn đặt hàng: %w", err)
+	}
+
+	if err := uc.donDatHangRepo.Save(ctx, don); err != nil {
+		return nil, fmt.Errorf("không thể lưu đơn đặt hàng: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "don_dat_hang.huy", entity.AuditDoiTuongDonDatHang, don.ID, truoc, snapshotDonDatHang(don))
+
+	return don, nil
+}
+
+// NhanHang ghi nhận hàng về cho đơn đặt hàng
+// Workflow cho mỗi dòng:
+// 1. Cập nhật số lượng đã nhận trên đơn (entity tự chuyển trạng thái)
+// 2. Tăng tồn kho và tính lại giá vốn bình quân (COGS)
+// 3. Ghi biến động kho nhập hàng với đơn giá thực tế
+func (uc *MuaHangUseCase) NhanHang(ctx context.Context, input NhanHangInput) (*entity.DonDatHang, error) {
+	if len(input.Items) == 0 {
+		return nil, errors.New("danh sách nhận hàng không được để trống")
+	}
+
+	var don *entity.DonDatHang
+	var truoc map[string]any
+
+	// Trạng thái đơn, tồn kho và biến động kho ghi trong cùng một transaction:
+	// lỗi ở bất kỳ dòng nào thì không dòng nào được nhập kho
+	err := uc.uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+		// Khóa đơn: hai lần nhận hàng cho cùng đơn chạy tuần tự, không nhận vượt số lượng đặt
+		don, err = uc.donDatHangRepo.FindByIDForUpdate(ctx, input.DonDatHangID)
+		if err != nil {
+			return fmt.Errorf("không thể tìm đơn đặt hàng: %w", err)
+		}
+		if don == nil {
+			return ErrDonDatHangNotFound
+		}
+		truoc = snapshotDonDatHang(don)
+
+		// Khóa nguyên liệu theo thứ tự ID để các phiếu nhận đồng thời không deadlock;
+		// nguyên liệu xuất hiện trên nhiều dòng chỉ được nạp và lưu một lần
+		nguyenLieus := make(map[string]*entity.NguyenLieu, len(input.Items))
+		nguyenLieuIDs := make([]string, 0, len(input.Items))
+		for _, item := range input.Items {
+			if _, ok := nguyenLieus[item.NguyenLieuID]; !ok {
+				nguyenLieus[item.NguyenLieuID] = nil
+				nguyenLieuIDs = append(nguyenLieuIDs, item.NguyenLieuID)
+			}
+		}
+		sort.Strings(nguyenLieuIDs)
+
+		for _, id := range nguyenLieuIDs {
+			nl, err := uc.nguyenLieuRepo.FindByIDForUpdate(ctx, id)
+			if err != nil {
+				return fmt.Errorf("không thể tìm nguyên liệu: %w", err)
+			}
+			if nl == nil {
+				return ErrNguyenLieuNotFound
+			}
+			nguyenLieus[id] = nl
+		}
+
+		now := time.Now()
+		bienDongs := make([]*entity.BienDongKho, 0, len(input.Items))
+		for _, item := range input.Items {
+			donItem, err := don.NhanHang(item.NguyenLieuID, item.SoLuong)
+			if err != nil {
+				return fmt.Errorf("không thể nhận hàng: %w", err)
+			}
+
+			donGia := donItem.DonGia
+			if item.DonGia != nil {
+				donGia = *item.DonGia
+			}
+
+			// Nhập lần lượt từng dòng vào cùng entity: giá vốn bình quân tính đúng khi một
+			// nguyên liệu có nhiều dòng với đơn giá khác nhau
+			if err := nguyenLieus[item.NguyenLieuID].NhapKho(item.SoLuong, donGia); err != nil {
+				return fmt.Errorf("không thể nhập kho: %w", err)
+			}
+
+			bienDongs = append(bienDongs, &entity.BienDongKho{
+				ID:           uuid.New().String(),
+				NguyenLieuID: item.NguyenLieuID,
+				Loai:         entity.BienDongNhapHang,
+				SoLuong:      item.SoLuong,
+				DonGia:       donGia,
+				ThamChieu:    don.ID,
+				NgayTao:      now,
+			})
+		}
+
+		if err := uc.donDatHangRepo.Save(ctx, don); err != nil {
+			return fmt.Errorf("không thể lưu đơn đặt hàng: %w", err)
+		}
+		for _, id := range nguyenLieuIDs {
+			if err := uc.nguyenLieuRepo.Save(ctx, nguyenLieus[id]); err != nil {
+				return fmt.Errorf("không thể cập nhật tồn kho: %w", err)
+			}
+		}
+		for _, bienDong := range bienDongs {
+			if err := uc.nguyenLieuRepo.ThemBienDong(ctx, bienDong); err != nil {
+				return fmt.Errorf("không thể ghi biến động kho: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.CtxInfo(ctx, "Purchase order received",
+		zap.String("don_dat_hang_id", don.ID),
+		zap.String("trang_thai", string(don.TrangThai)),
+		zap.Int("so_dong", len(input.Items)),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "don_dat_hang.nhan_hang", entity.AuditDoiTuongDonDatHang, don.ID, truoc, snapshotDonDatHang(don))
+
+	return don, nil
+}
+
+// ============================================================
+// Gợi ý đặt hàng
+// ============================================================
+
+// GoiYDatHang tính số lượng nên đặt thêm cho từng nguyên liệu dựa trên tiêu hao gần đây
+// Công thức: cần = tiêu hao TB/ngày * (thời gian giao + số ngày dự trữ) + mức tối thiểu
+//
+//	gợi ý = cần - tồn kho - đang về
+func (uc *MuaHangUseCase) GoiYDatHang(ctx context.Context, input GoiYDatHangInput) ([]GoiYDatHang, error) {
+	soNgayTieuHao := input.SoNgayTieuHao
+	if soNgayTieuHao <= 0 {
+		soNgayTieuHao = defaultSoNgayTieuHao
+	}
+	soNgayDuTru := input.SoNgayDuTru
+	if soNgayDuTru < 0 {
+		soNgayDuTru = defaultSoNgayDuTru
+	}
+
+	soNgayGiao := 0
+	if input.NhaCungCapID != "" {
+		ncc, err := uc.TimNhaCungCap(ctx, input.NhaCungCapID)
+		if err != nil {
+			return nil, err
+		}
+		soNgayGiao = ncc.SoNgayGiao
+	}
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -soNgayTieuHao)
+	tieuHao, err := uc.nguyenLieuRepo.TongTieuHao(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tính tiêu hao: %w", err)
+	}
+
+	dangCho, err := uc.donDatHangRepo.FindDangCho(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy đơn đang chờ: %w", err)
+	}
+	dangVe := make(map[string]float64)
+	for _, don := range dangCho {
+		for _, item := range don.Items {
+			dangVe[item.NguyenLieuID] += item.ConThieu()
+		}
+	}
+
+	nguyenLieuList, err := uc.nguyenLieuRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách nguyên liệu: %w", err)
+	}
+
+	result := make([]GoiYDatHang, 0)
+	for _, nl := range nguyenLieuList {
+		trungBinh := tieuHao[nl.ID] / float64(soNgayTieuHao)
+		can := trungBinh*float64(soNgayGiao+soNgayDuTru) + nl.MucToiThieu
+		goiY := can - nl.SoLuongTon - dangVe[nl.ID]
+		if goiY <= 0 {
+			continue
+		}
+
+		result = append(result, GoiYDatHang{
+			NguyenLieu:           nl,
+			TieuHaoTrungBinhNgay: math.Round(trungBinh*1000) / 1000,
+			DangVe:               dangVe[nl.ID],
+			SoLuongGoiY:          math.Ceil(goiY*1000) / 1000,
+		})
+	}
+
+	// Ưu tiên nguyên liệu thiếu nhiều nhất
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].SoLuongGoiY > result[j].SoLuongGoiY
+	})
+
+	return result, nil
+}
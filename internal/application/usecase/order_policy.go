@@ -0,0 +1,108 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// Order policy errors
+var (
+	ErrOrderForbidden = errors.New("không có quyền thực hiện thao tác này trên đơn hàng")
+)
+
+// Actor là người thực hiện thao tác, lấy từ access token
+type Actor struct {
+	UserID      string
+	Role        entity.UserRole
+	Permissions []string
+}
+
+// HasPermission kiểm tra actor có permission không
+func (a Actor) HasPermission(permission string) bool {
+	return slices.Contains(a.Permissions, permission)
+}
+
+// kiemTraXemOrder trả về ErrOrderNotFound nếu actor không được xem đơn
+//
+// Chính sách truy cập đơn hàng, đánh giá trên đơn đã load:
+//   - Xem: có order:read, hoặc là khách hàng của đơn (KhachHangID thuộc user)
+//   - Đổi trạng thái: có order:status:write, hoặc có order:status:write:assigned
+//     và là đầu bếp được gán (DauBepID là NhanVien của user)
+//
+// Actor không được xem đơn luôn nhận ErrOrderNotFound như khi đơn không tồn tại,
+// để không lộ đơn có tồn tại hay không; được xem nhưng không được thao tác nhận ErrOrderForbidden
+func (uc *OrderUseCase) kiemTraXemOrder(ctx context.Context, actor Actor, order *entity.Order) error {
+	if actor.HasPermission(entity.PermissionOrderRead) {
+		return nil
+	}
+
+	laKhach, err := uc.laKhachHangCuaOrder(ctx, actor, order)
+	if err != nil {
+		return err
+	}
+	if !laKhach {
+		return ErrOrderNotFound
+	}
+	return nil
+}
+
+// kiemTraThaoTac kiểm tra actor được thao tác trên đơn:
+// duocPhep = false thì trả ErrOrderForbidden nếu actor xem được đơn, ngược lại ErrOrderNotFound
+func (uc *OrderUseCase) kiemTraThaoTac(ctx context.Context, actor Actor, order *entity.Order, duocPhep bool) error {
+	if duocPhep {
+		return nil
+	}
+	if err := uc.kiemTraXemOrder(ctx, actor, order); err != nil {
+		return err
+	}
+	return ErrOrderForbidden
+}
+
+// kiemTraDoiTrangThai kiểm tra actor được đổi trạng thái đơn
+func (uc *OrderUseCase) kiemTraDoiTrangThai(ctx context.Context, actor Actor, order *entity.Order) error {
+	duocPhep := actor.HasPermission(entity.PermissionOrderStatusWrite)
+	if !duocPhep && actor.HasPermission(entity.PermissionOrderStatusAssigned) {
+		laDauBep, err := uc.laDauBepCuaOrder(ctx, actor, order)
+		if err != nil {
+			return err
+		}
+		duocPhep = laDauBep
+	}
+
+	return uc.kiemTraThaoTac(ctx, actor, order, duocPhep)
+}
+
+// laKhachHangCuaOrder kiểm tra đơn có phải của khách hàng ứng với user không
+// Đơn của khách có hồ sơ lưu KhachHang.ID; khách chưa có hồ sơ thì đơn lưu User.ID
+func (uc *OrderUseCase) laKhachHangCuaOrder(ctx context.Context, actor Actor, order *entity.Order) (bool, error) {
+	if order.KhachHangID == "" || actor.UserID == "" {
+		return false, nil
+	}
+	if order.KhachHangID == actor.UserID {
+		return true, nil
+	}
+
+	kh, err := uc.khachHangRepo.FindByUserID(ctx, actor.UserID)
+	if err != nil {
+		return false, fmt.Errorf("không thể tìm khách hàng của user: %w", err)
+	}
+	return kh != nil && kh.ID == order.KhachHangID, nil
+}
+
+// laDauBepCuaOrder kiểm tra đơn có được gán cho nhân viên ứng với user không
+func (uc *OrderUseCase) laDauBepCuaOrder(ctx context.Context, actor Actor, order *entity.Order) (bool, error) {
+	if order.DauBepID == "" || actor.UserID == "" {
+		return false, nil
+	}
+
+	nv, err := uc.nhanVienRepo.FindByUserID(ctx, actor.UserID)
+	if err != nil {
+		return false, fmt.Errorf("không thể tìm nhân viên của user: %w", err)
+	}
+	return nv != nil && nv.ID == order.DauBepID, nil
+}
@@ -0,0 +1,473 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+)
+
+// Order use case errors
+var (
+	ErrOrderNotFound            = errors.New("không tìm thấy đơn hàng")
+	ErrOrderEmpty               = errors.New("đơn hàng phải có ít nhất một món")
+	ErrMonAnNotFound            = errors.New("không tìm thấy món ăn")
+	ErrMonKhongPhucVu           = errors.New("món không phục vụ vào thời điểm này")
+	ErrInvalidLoaiOrder         = errors.New("loại đơn hàng không hợp lệ")
+	ErrThieuSoBan               = errors.New("đơn tại chỗ phải có số bàn")
+	ErrThieuDiaChiGiao          = errors.New("đơn giao hàng phải có địa chỉ giao")
+	ErrTuyChonKhongHopLe        = errors.New("tùy chọn món không hợp lệ")
+	ErrTrangThaiOrderKhongHopLe = errors.New("chuyển trạng thái đơn hàng không hợp lệ")
+	ErrHuyQuaDoiTrangThai       = errors.New("dùng chức năng hủy đơn để hủy (hoàn mã giảm giá đã dùng)")
+	ErrNhanVienNotFound         = errors.New("không tìm thấy nhân viên")
+	ErrKhongPhaiDauBep          = errors.New("nhân viên không phải đầu bếp")
+)
+
+// TaoOrderItemInput là một món trong đơn hàng mới
+type TaoOrderItemInput struct {
+	MonAnID string
+	SoLuong int
+	TuyChon map[string][]string // Mã nhóm tùy chọn → các mã lựa chọn
+	GhiChu  string
+}
+
+// TaoOrderInput là input để tạo đơn hàng
+type TaoOrderInput struct {
+	LoaiOrder       entity.LoaiOrder
+	SoBan           int
+	KhachHangUserID string // User của khách tự đặt (rỗng = nhân viên đặt hộ)
+	KhachHangEmail  string // Email nhận hóa đơn (rỗng = không gửi)
+	NhanVienID      string
+	GhiChu          string
+	DiaChiGiao      string
+	MaGiamGia       string // Mã giảm giá khách nhập (optional)
+	Items           []TaoOrderItemInput
+}
+
+// OrderUseCase xử lý các use case liên quan đến đơn hàng
+type OrderUseCase struct {
+	orderRepo     repository.IOrderRepository
+	monAnRepo     repository.IMonAnRepository
+	khuyenMaiRepo repository.IKhuyenMaiRepository
+	maGiamGiaRepo repository.IMaGiamGiaRepository
+	khachHangRepo repository.IKhachHangRepository // Xác định khách hàng của đơn (chính sách truy cập)
+	nhanVienRepo  repository.INhanVienRepository  // Xác định đầu bếp của đơn (chính sách truy cập)
+	emailService  service.EmailService
+	auditRepo     repository.IAuditLogRepository // Ghi audit log hủy đơn, đổi trạng thái, phân công
+	uow           repository.IUnitOfWork         // Transaction MongoDB: lưu đơn + ghi outbox
+	outboxRepo    repository.IOutboxRepository   // Outbox MongoDB (order.created, order.status_changed)
+	loc           *time.Location                 // Múi giờ nhà hàng - dùng cho lịch phục vụ món và khung giờ khuyến mãi
+}
+
+// NewOrderUseCase tạo mới OrderUseCase
+func NewOrderUseCase(
+	orderRepo repository.IOrderRepository,
+	monAnRepo repository.IMonAnRepository,
+	khuyenMaiRepo repository.IKhuyenMaiRepository,
+	maGiamGiaRepo repository.IMaGiamGiaRepository,
+	khachHangRepo repository.IKhachHangRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	emailService service.EmailService,
+	auditRepo repository.IAuditLogRepository,
+	uow repository.IUnitOfWork,
+	outboxRepo repository.IOutboxRepository,
+	loc *time.Location,
+) *OrderUseCase {
+	return &OrderUseCase{
+		orderRepo:     orderRepo,
+		monAnRepo:     monAnRepo,
+		khuyenMaiRepo: khuyenMaiRepo,
+		maGiamGiaRepo: maGiamGiaRepo,
+		khachHangRepo: khachHangRepo,
+		nhanVienRepo:  nhanVienRepo,
+		emailService:  emailService,
+		auditRepo:     auditRepo,
+		uow:           uow,
+		outboxRepo:    outboxRepo,
+		loc:           loc,
+	}
+}
+
+// TaoOrder tạo đơn hàng mới
+// Workflow:
+// 1. Validate loại đơn và thông tin bắt buộc
+// 2. Với mỗi món: kiểm tra còn hàng VÀ nằm trong lịch phục vụ hiện tại (giờ nhà hàng)
+// 3. Snapshot tên + giá (đã giảm) vào OrderItem
+// 4. Đánh giá các khuyến mãi đang hiệu lực, chọn phương án giảm nhiều nhất và ghi lại
+// 5. Dùng mã giảm giá (nếu có) trên số tiền còn lại - trừ lượt trong transaction có khóa dòng
+// 6. Lưu đơn kèm sự kiện order.created (lưu thất bại thì hoàn lượt mã đã trừ)
+// 7. Gửi hóa đơn qua email cho khách (nếu có email, lỗi gửi không làm hỏng đơn)
+func (uc *OrderUseCase) TaoOrder(ctx context.Context, input TaoOrderInput) (*entity.Order, error) {
+	if err := validateLoaiOrder(input); err != nil {
+		return nil, err
+	}
+	if len(input.Items) == 0 {
+		return nil, ErrOrderEmpty
+	}
+
+	order, err := entity.NewOrder(uuid.New().String(), input.LoaiOrder)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tạo đơn hàng: %w", err)
+	}
+	order.SoBan = input.SoBan
+	if input.KhachHangUserID != "" {
+		khachHangID, err := uc.khachHangCuaUser(ctx, input.KhachHangUserID)
+		if err != nil {
+			return nil, err
+		}
+		order.KhachHangID = khachHangID
+	}
+	order.NhanVienID = input.NhanVienID
+	order.GhiChu = input.GhiChu
+	order.DiaChiGiao = input.DiaChiGiao
+
+	now := time.Now().In(uc.loc)
+	for _, item := range input.Items {
+		mon, err := uc.monAnRepo.FindByID(ctx, item.MonAnID)
+		if err != nil {
+			return nil, fmt.Errorf("không thể tìm món: %w", err)
+		}
+		if mon == nil {
+			return nil, fmt.Errorf("%w: %s", ErrMonAnNotFound, item.MonAnID)
+		}
+		if !mon.CoTheBanLuc(now) {
+			return nil, fmt.Errorf("%w: %s", ErrMonKhongPhucVu, mon.Ten)
+		}
+
+		tuyChon, err := mon.ChonTuyChon(item.TuyChon)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrTuyChonKhongHopLe, err)
+		}
+
+		if err := order.ThemMonCoTuyChon(mon.ID, mon.Ten, item.SoLuong, mon.TinhGia(), tuyChon, item.GhiChu); err != nil {
+			return nil, fmt.Errorf("không thể thêm món %s: %w", mon.Ten, err)
+		}
+	}
+
+	dsKhuyenMai, err := uc.khuyenMaiRepo.FindDangHoatDong(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy khuyến mãi: %w", err)
+	}
+	if err := order.ApDungKhuyenMai(entity.ChonKhuyenMaiTotNhat(order.Items, dsKhuyenMai, now)); err != nil {
+		return nil, fmt.Errorf("không thể áp dụng khuyến mãi: %w", err)
+	}
+
+	if input.MaGiamGia != "" {
+		if err := uc.dungMaGiamGia(ctx, order, input.MaGiamGia, now); err != nil {
+			return nil, err
+		}
+	}
+
+	err = luuKemSuKien(ctx, uc.uow, uc.outboxRepo, func(ctx context.Context) error {
+		return uc.orderRepo.Save(ctx, order)
+	}, entity.SuKienOrderCreated, order.ID, entity.OrderCreatedPayload{
+		OrderID:       order.ID,
+		KhachHangID:   order.KhachHangID,
+		LoaiOrder:     order.LoaiOrder,
+		TienThanhToan: order.TienThanhToan,
+	})
+	if err != nil {
+		if order.MaGiamGia != "" {
+			uc.hoanMaGiamGia(ctx, order.ID)
+		}
+		return nil, fmt.Errorf("không thể lưu đơn hàng: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Order created",
+		zap.String("order_id", order.ID),
+		zap.String("loai_order", string(order.LoaiOrder)),
+		zap.Int("so_mon", len(order.Items)),
+		zap.Int("so_khuyen_mai", len(order.KhuyenMai)),
+		zap.Int64("giam_gia", order.GiamGia),
+		zap.String("ma_giam_gia", order.MaGiamGia),
+		zap.Int64("tien_thanh_toan", order.TienThanhToan),
+	)
+
+	if input.KhachHangEmail != "" && uc.emailService != nil {
+		if err := uc.emailService.SendOrderReceipt(ctx, input.KhachHangEmail, order); err != nil {
+			logger.CtxWarn(ctx, "Failed to queue order receipt",
+				zap.String("order_id", order.ID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return order, nil
+}
+
+// khachHangCuaUser trả về mã khách hàng ghi vào đơn khách tự đặt:
+// KhachHang.ID nếu user đã có hồ sơ khách hàng, chưa có thì dùng User.ID
+func (uc *OrderUseCase) khachHangCuaUser(ctx context.Context, userID string) (string, error) {
+	kh, err := uc.khachHangRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("không thể tìm khách hàng của user: %w", err)
+	}
+	if kh == nil {
+		return userID, nil
+	}
+	return kh.ID, nil
+}
+
+// dungMaGiamGia trừ một lượt mã giảm giá cho đơn và cộng khoản giảm vào đơn
+// Điều kiện dùng mã được kiểm tra khi mã đang bị khóa, nên các đơn đồng thời
+// không thể vượt quá giới hạn lượt
+func (uc *OrderUseCase) dungMaGiamGia(ctx context.Context, order *entity.Order, ma string, now time.Time) error {
+	ma = entity.ChuanHoaMa(ma)
+	tienConLai := order.TienThanhToan
+
+	suDung, err := uc.maGiamGiaRepo.DungMa(ctx, ma, order.KhachHangID,
+		func(m *entity.MaGiamGia, soLanKhachDaDung int) (*entity.SuDungMaGiamGia, error) {
+			if err := m.KiemTraSuDung(tienConLai, order.KhachHangID, soLanKhachDaDung, now); err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrMaGiamGiaKhongHopLe, err)
+			}
+			return &entity.SuDungMaGiamGia{
+				ID:          uuid.New().String(),
+				MaGiamGiaID: m.ID,
+				OrderID:     order.ID,
+				KhachHangID: order.KhachHangID,
+				SoTienGiam:  m.TinhGiam(tienConLai),
+				TrangThai:   entity.SuDungMaDaDung,
+				NgayDung:    time.Now(),
+			}, nil
+		})
+	if err != nil {
+		if errors.Is(err, ErrMaGiamGiaKhongHopLe) {
+			return err
+		}
+		return fmt.Errorf("không thể dùng mã giảm giá: %w", err)
+	}
+	if suDung == nil {
+		return ErrMaGiamGiaNotFound
+	}
+
+	if err := order.ApDungMaGiamGia(ma, suDung.SoTienGiam); err != nil {
+		uc.hoanMaGiamGia(ctx, order.ID)
+		return fmt.Errorf("không thể áp dụng mã giảm giá: %w", err)
+	}
+
+	return nil
+}
+
+// hoanMaGiamGia hoàn lượt mã của đơn (best-effort, chỉ ghi log khi lỗi)
+func (uc *OrderUseCase) hoanMaGiamGia(ctx context.Context, orderID string) {
+	if _, err := uc.maGiamGiaRepo.HoanMaTheoOrder(ctx, orderID); err != nil {
+		logger.CtxError(ctx, "Failed to release voucher",
+			zap.String("order_id", orderID),
+			zap.Error(err),
+		)
+	}
+}
+
+// validateLoaiOrder kiểm tra loại đơn và các thông tin bắt buộc theo loại
+func validateLoaiOrder(input TaoOrderInput) error {
+	switch input.LoaiOrder {
+	case entity.OrderTaiCho:
+		if input.SoBan <= 0 {
+			return ErrThieuSoBan
+		}
+	case entity.OrderMangVe:
+	case entity.OrderGiaoHang:
+		if input.DiaChiGiao == "" {
+			return ErrThieuDiaChiGiao
+		}
+	default:
+		return ErrInvalidLoaiOrder
+	}
+	return nil
+}
+
+// XemOrder lấy đơn hàng theo ID (theo chính sách xem đơn của actor)
+func (uc *OrderUseCase) XemOrder(ctx context.Context, actor Actor, id string) (*entity.Order, error) {
+	order, err := uc.timOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.kiemTraXemOrder(ctx, actor, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// XemOrderCuaToi lấy các đơn khách hàng của actor đã đặt, mới nhất trước
+func (uc *OrderUseCase) XemOrderCuaToi(ctx context.Context, actor Actor) ([]*entity.Order, error) {
+	orders, err := uc.orderRepo.FindByKhachHangID(ctx, actor.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy đơn hàng: %w", err)
+	}
+
+	// Đơn đặt sau khi có hồ sơ khách hàng lưu KhachHang.ID
+	khachHangID, err := uc.khachHangCuaUser(ctx, actor.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if khachHangID != actor.UserID {
+		theoHoSo, err := uc.orderRepo.FindByKhachHangID(ctx, khachHangID)
+		if err != nil {
+			return nil, fmt.Errorf("không thể lấy đơn hàng: %w", err)
+		}
+		orders = append(orders, theoHoSo...)
+		sort.Slice(orders, func(i, j int) bool {
+			return orders[i].ThoiGianDat.After(orders[j].ThoiGianDat)
+		})
+	}
+
+	return orders, nil
+}
+
+// timOrder load đơn hàng theo ID, không kiểm tra quyền
+func (uc *OrderUseCase) timOrder(ctx context.Context, id string) (*entity.Order, error) {
+	order, err := uc.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm đơn hàng: %w", err)
+	}
+	if order == nil {
+		return nil, ErrOrderNotFound
+	}
+
+	return order, nil
+}
+
+// XemOrderDangCho lấy các đơn đang chờ xử lý (mới, đã xác nhận, đang nấu)
+func (uc *OrderUseCase) XemOrderDangCho(ctx context.Context) ([]*entity.Order, error) {
+	orders, err := uc.orderRepo.FindPending(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy đơn đang chờ: %w", err)
+	}
+
+	return orders, nil
+}
+
+// HuyOrder hủy đơn hàng và hoàn lượt mã giảm giá đã dùng (cần order:cancel)
+// Gọi lại trên đơn đã hủy sẽ chỉ thử hoàn mã (an toàn khi lần trước hoàn mã thất bại)
+func (uc *OrderUseCase) HuyOrder(ctx context.Context, actor Actor, id string) (*entity.Order, error) {
+	order, err := uc.timOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.kiemTraThaoTac(ctx, actor, order, actor.HasPermission(entity.PermissionOrderCancel)); err != nil {
+		return nil, err
+	}
+
+	if !order.DaBiHuy() {
+		truoc := snapshotOrder(order)
+		trangThaiCu := order.TrangThai
+		if err := order.ChuyenTrangThai(entity.OrderDaHuy); err != nil {
+			return nil, fmt.Errorf("không thể hủy đơn hàng: %w", err)
+		}
+		if err := uc.luuKemDoiTrangThai(ctx, order, trangThaiCu); err != nil {
+			return nil, fmt.Errorf("không thể lưu đơn hàng: %w", err)
+		}
+		ghiAuditLog(ctx, uc.auditRepo, "order.huy", entity.AuditDoiTuongOrder, order.ID, truoc, snapshotOrder(order))
+	}
+
+	if order.MaGiamGia != "" {
+		soLuot, err := uc.maGiamGiaRepo.HoanMaTheoOrder(ctx, order.ID)
+		if err != nil {
+			return nil, fmt.Errorf("không thể hoàn mã giảm giá: %w", err)
+		}
+		if soLuot > 0 {
+			logger.CtxInfo(ctx, "Voucher released",
+				zap.String("order_id", order.ID),
+				zap.String("ma_giam_gia", order.MaGiamGia),
+			)
+		}
+	}
+
+	logger.CtxInfo(ctx, "Order cancelled",
+		zap.String("order_id", order.ID),
+		zap.String("actor_id", actor.UserID),
+	)
+
+	return order, nil
+}
+
+// CapNhatTrangThai chuyển đơn sang trạng thái mới (theo chính sách đổi trạng thái của actor)
+// Hủy đơn không đi qua đây mà qua HuyOrder để hoàn mã giảm giá
+func (uc *OrderUseCase) CapNhatTrangThai(ctx context.Context, actor Actor, id string, trangThai entity.TrangThaiOrder) (*entity.Order, error) {
+	if trangThai == entity.OrderDaHuy {
+		return nil, ErrHuyQuaDoiTrangThai
+	}
+
+	order, err := uc.timOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.kiemTraDoiTrangThai(ctx, actor, order); err != nil {
+		return nil, err
+	}
+
+	trangThaiCu := order.TrangThai
+	truoc := snapshotOrder(order)
+	if err := order.ChuyenTrangThai(trangThai); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTrangThaiOrderKhongHopLe, err)
+	}
+	if err := uc.luuKemDoiTrangThai(ctx, order, trangThaiCu); err != nil {
+		return nil, fmt.Errorf("không thể lưu đơn hàng: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Order status changed",
+		zap.String("order_id", order.ID),
+		zap.String("tu", string(trangThaiCu)),
+		zap.String("sang", string(order.TrangThai)),
+		zap.String("actor_id", actor.UserID),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "order.doi_trang_thai", entity.AuditDoiTuongOrder, order.ID, truoc, snapshotOrder(order))
+
+	return order, nil
+}
+
+// luuKemDoiTrangThai lưu đơn kèm sự kiện order.status_changed trong cùng transaction
+func (uc *OrderUseCase) luuKemDoiTrangThai(ctx context.Context, order *entity.Order, trangThaiCu entity.TrangThaiOrder) error {
+	return luuKemSuKien(ctx, uc.uow, uc.outboxRepo, func(ctx context.Context) error {
+		return uc.orderRepo.Save(ctx, order)
+	}, entity.SuKienOrderStatusChanged, order.ID, payloadOrderStatusChanged(order, trangThaiCu))
+}
+
+// PhanCongDauBep gán đầu bếp (NhanVien có chức vụ bếp) cho đơn (cần order:assign)
+// Đầu bếp được gán đổi được trạng thái đơn với quyền order:status:write:assigned
+func (uc *OrderUseCase) PhanCongDauBep(ctx context.Context, actor Actor, id string, nhanVienID string) (*entity.Order, error) {
+	order, err := uc.timOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.kiemTraThaoTac(ctx, actor, order, actor.HasPermission(entity.PermissionOrderAssign)); err != nil {
+		return nil, err
+	}
+
+	nv, err := uc.nhanVienRepo.FindByID(ctx, nhanVienID)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm nhân viên: %w", err)
+	}
+	if nv == nil {
+		return nil, ErrNhanVienNotFound
+	}
+	if !nv.LaDauBep() {
+		return nil, ErrKhongPhaiDauBep
+	}
+
+	truoc := snapshotOrder(order)
+	order.GanDauBep(nv.ID)
+	if err := uc.orderRepo.Save(ctx, order); err != nil {
+		return nil, fmt.Errorf("không thể lưu đơn hàng: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Chef assigned to order",
+		zap.String("order_id", order.ID),
+		zap.String("dau_bep_id", nv.ID),
+		zap.String("actor_id", actor.UserID),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "order.phan_cong_dau_bep", entity.AuditDoiTuongOrder, order.ID, truoc, snapshotOrder(order))
+
+	return order, nil
+}
@@ -0,0 +1,120 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/pkg/logger"
+)
+
+// Permission use case errors
+var (
+	ErrPermissionNotFound      = errors.New("permission không tồn tại")
+	ErrCannotRevokeAdminManage = errors.New("không thể bỏ quyền permission:manage của admin")
+)
+
+// RolePermissions là quyền hiện tại của một role
+type RolePermissions struct {
+	Role        entity.UserRole
+	Permissions []string
+}
+
+// SetRolePermissionsInput là input để thay quyền của một role
+type SetRolePermissionsInput struct {
+	Role        entity.UserRole
+	Permissions []string
+	ActorID     string // Admin thực hiện thay đổi (ghi log)
+}
+
+// PermissionUseCase quản lý danh mục permission và quyền của từng role
+// Thay đổi có hiệu lực với access token cấp sau đó (token đang dùng giữ quyền cũ tới khi refresh)
+type PermissionUseCase struct {
+	repo      repository.IPermissionRepository
+	auditRepo repository.IAuditLogRepository
+}
+
+// NewPermissionUseCase tạo mới PermissionUseCase
+func NewPermissionUseCase(repo repository.IPermissionRepository, auditRepo repository.IAuditLogRepository) *PermissionUseCase {
+	return &PermissionUseCase{repo: repo, auditRepo: auditRepo}
+}
+
+// ListPermissions lấy danh mục permission
+func (uc *PermissionUseCase) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	return uc.repo.FindAll(ctx)
+}
+
+// ListRolePermissions lấy quyền của tất cả role (kể cả role chưa có quyền nào)
+func (uc *PermissionUseCase) ListRolePermissions(ctx context.Context) ([]RolePermissions, error) {
+	mapping, err := uc.repo.FindAllRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]RolePermissions, 0, len(entity.AllRoles))
+	for _, role := range entity.AllRoles {
+		permissions := mapping[role]
+		if permissions == nil {
+			permissions = []string{}
+		}
+		result = append(result, RolePermissions{Role: role, Permissions: permissions})
+	}
+	return result, nil
+}
+
+// SetRolePermissions thay toàn bộ quyền của role
+func (uc *PermissionUseCase) SetRolePermissions(ctx context.Context, input SetRolePermissionsInput) (*RolePermissions, error) {
+	if !input.Role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	catalog, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(catalog))
+	for _, p := range catalog {
+		known[p.Ma] = true
+	}
+
+	permissions := make([]string, 0, len(input.Permissions))
+	for _, p := range input.Permissions {
+		if !known[p] {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionNotFound, p)
+		}
+		if !slices.Contains(permissions, p) {
+			permissions = append(permissions, p)
+		}
+	}
+	slices.Sort(permissions)
+
+	// Admin luôn giữ quyền quản lý permission, tránh tự khóa mình khỏi hệ thống
+	if input.Role == entity.RoleAdmin && !slices.Contains(permissions, entity.PermissionPermissionManage) {
+		return nil, ErrCannotRevokeAdminManage
+	}
+
+	truoc, err := uc.repo.FindByRole(ctx, input.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.SetRolePermissions(ctx, input.Role, permissions); err != nil {
+		return nil, err
+	}
+
+	logger.CtxInfo(ctx, "Role permissions updated",
+		zap.String("role", string(input.Role)),
+		zap.Strings("permissions", permissions),
+		zap.String("actor_id", input.ActorID),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "role.dat_quyen", entity.AuditDoiTuongRole, string(input.Role),
+		map[string]any{"permissions": truoc}, map[string]any{"permissions": permissions})
+
+	return &RolePermissions{Role: input.Role, Permissions: permissions}, nil
+}
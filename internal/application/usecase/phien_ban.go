@@ -0,0 +1,21 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import "restaurant_project/internal/domain/repository"
+
+// Optimistic locking errors
+var (
+	// ErrConcurrentModification là lỗi khi dữ liệu đã bị request khác thay đổi:
+	// If-Match không khớp phiên bản hiện tại hoặc cập nhật có điều kiện ở repository thất bại
+	ErrConcurrentModification = repository.ErrConcurrentModification
+)
+
+// kiemTraPhienBan so phiên bản client đã đọc (If-Match) với phiên bản hiện tại
+// mongDoi = 0 nghĩa là client không gửi If-Match: bỏ qua kiểm tra, repository
+// vẫn cập nhật có điều kiện theo phiên bản vừa đọc
+func kiemTraPhienBan(hienTai, mongDoi int64) error {
+	if mongDoi != 0 && mongDoi != hienTai {
+		return ErrConcurrentModification
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/service"
+)
+
+// SchedulerUseCase xử lý nghiệp vụ xem trạng thái tác vụ định kỳ (Admin)
+type SchedulerUseCase struct {
+	store service.ScheduledTaskStore
+}
+
+// NewSchedulerUseCase tạo mới SchedulerUseCase
+func NewSchedulerUseCase(store service.ScheduledTaskStore) *SchedulerUseCase {
+	return &SchedulerUseCase{
+		store: store,
+	}
+}
+
+// XemTacVu liệt kê các tác vụ với lần chạy kế tiếp, lần chạy gần nhất và lỗi gần nhất
+func (uc *SchedulerUseCase) XemTacVu(ctx context.Context) ([]service.TaskStatus, error) {
+	return uc.store.ListTasks(ctx)
+}
+
+// XemLichSuChay lấy lịch sử chạy của một tác vụ, mới nhất trước
+func (uc *SchedulerUseCase) XemLichSuChay(ctx context.Context, tacVu string, limit int) ([]service.TaskRun, error) {
+	return uc.store.ListRuns(ctx, tacVu, int64(limit))
+}
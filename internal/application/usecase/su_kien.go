@@ -0,0 +1,47 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// luuKemSuKien chạy thay đổi trạng thái và ghi sự kiện vào outbox trong cùng transaction
+// uow và outboxRepo phải cùng một store với repository mà luu ghi vào
+func luuKemSuKien(
+	ctx context.Context,
+	uow repository.IUnitOfWork,
+	outboxRepo repository.IOutboxRepository,
+	luu func(ctx context.Context) error,
+	loai entity.LoaiSuKien,
+	doiTuongID string,
+	payload any,
+) error {
+	suKien, err := entity.NewSuKien(uuid.New().String(), loai, doiTuongID, payload)
+	if err != nil {
+		return err
+	}
+
+	return uow.Do(ctx, func(ctx context.Context) error {
+		if err := luu(ctx); err != nil {
+			return err
+		}
+		return outboxRepo.Add(ctx, suKien)
+	})
+}
+
+// payloadOrderStatusChanged tạo payload sự kiện đổi trạng thái đơn
+func payloadOrderStatusChanged(order *entity.Order, trangThaiCu entity.TrangThaiOrder) entity.OrderStatusChangedPayload {
+	return entity.OrderStatusChangedPayload{
+		OrderID:       order.ID,
+		KhachHangID:   order.KhachHangID,
+		DauBepID:      order.DauBepID,
+		TrangThaiCu:   trangThaiCu,
+		TrangThaiMoi:  order.TrangThai,
+		TienThanhToan: order.TienThanhToan,
+	}
+}
@@ -0,0 +1,152 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+)
+
+// SuKienConsumer chứa các consumer domain event cho tác dụng phụ xuyên store
+// Đơn hàng nằm ở MongoDB còn khách hàng / nhân viên ở MySQL nên không thể cập nhật cùng transaction:
+// thay đổi đơn ghi sự kiện vào outbox, relay phát lại tới đây cho tới khi consumer thành công
+type SuKienConsumer struct {
+	orderRepo      repository.IOrderRepository
+	khachHangRepo  repository.IKhachHangRepository
+	nhanVienRepo   repository.INhanVienRepository
+	tokenBlacklist service.TokenBlacklistService
+}
+
+// NewSuKienConsumer tạo mới SuKienConsumer
+func NewSuKienConsumer(
+	orderRepo repository.IOrderRepository,
+	khachHangRepo repository.IKhachHangRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	tokenBlacklist service.TokenBlacklistService,
+) *SuKienConsumer {
+	return &SuKienConsumer{
+		orderRepo:      orderRepo,
+		khachHangRepo:  khachHangRepo,
+		nhanVienRepo:   nhanVienRepo,
+		tokenBlacklist: tokenBlacklist,
+	}
+}
+
+// DangKy đăng ký các consumer vào dispatcher
+// Tên consumer là một phần khóa idempotency: không đổi tên consumer đang chạy
+func (c *SuKienConsumer) DangKy(d service.EventDispatcher) {
+	d.Subscribe(entity.SuKienOrderStatusChanged, "loyalty.cong_diem", c.CongDiemTichLuy)
+	d.Subscribe(entity.SuKienOrderStatusChanged, "nhan_vien.trang_thai_dau_bep", c.CapNhatTrangThaiDauBep)
+	d.Subscribe(entity.SuKienUserDeactivated, "auth.thu_hoi_token", c.ThuHoiToken)
+}
+
+// CongDiemTichLuy cộng điểm cho khách hàng khi đơn hoàn thành
+// Không idempotent tự thân: dispatcher chạy consumer trong transaction MySQL cùng với khóa idempotency,
+// nên lần cộng điểm và khóa cùng commit hoặc cùng rollback; phát lại sau khi commit không cộng hai lần
+func (c *SuKienConsumer) CongDiemTichLuy(ctx context.Context, sk *entity.SuKien) error {
+	var p entity.OrderStatusChangedPayload
+	if err := sk.DocDuLieu(&p); err != nil {
+		return err
+	}
+	if p.TrangThaiMoi != entity.OrderHoanThanh || p.KhachHangID == "" {
+		return nil
+	}
+
+	diem := entity.DiemTuTienThanhToan(p.TienThanhToan)
+	if diem == 0 {
+		return nil
+	}
+
+	// Đơn của khách chưa có hồ sơ lưu User.ID: không có tài khoản tích điểm
+	kh, err := c.khachHangRepo.CongDiemTichLuy(ctx, p.KhachHangID, diem)
+	if err != nil {
+		return fmt.Errorf("không thể cộng điểm tích lũy: %w", err)
+	}
+	if kh == nil {
+		return nil
+	}
+
+	logger.CtxInfo(ctx, "Loyalty points added",
+		zap.String("order_id", p.OrderID),
+		zap.String("khach_hang_id", kh.ID),
+		zap.Int64("diem", diem),
+		zap.Int64("diem_tich_luy", kh.DiemTichLuy),
+	)
+	return nil
+}
+
+// CapNhatTrangThaiDauBep đồng bộ trạng thái đầu bếp theo các đơn được gán
+// Tính lại từ trạng thái hiện tại của các đơn (không dựa vào thứ tự sự kiện), nên phát lại an toàn:
+// còn đơn đang nấu → bận; hết đơn đang nấu mà đang bận → rảnh; nghỉ / offline giữ nguyên
+func (c *SuKienConsumer) CapNhatTrangThaiDauBep(ctx context.Context, sk *entity.SuKien) error {
+	var p entity.OrderStatusChangedPayload
+	if err := sk.DocDuLieu(&p); err != nil {
+		return err
+	}
+	if p.DauBepID == "" {
+		return nil
+	}
+
+	nv, err := c.nhanVienRepo.FindByID(ctx, p.DauBepID)
+	if err != nil {
+		return fmt.Errorf("không thể tìm đầu bếp: %w", err)
+	}
+	if nv == nil {
+		return nil
+	}
+
+	orders, err := c.orderRepo.FindByDauBepID(ctx, nv.ID)
+	if err != nil {
+		return fmt.Errorf("không thể lấy đơn của đầu bếp: %w", err)
+	}
+	dangNau := false
+	for _, o := range orders {
+		if o.TrangThai == entity.OrderDangNau {
+			dangNau = true
+			break
+		}
+	}
+
+	trangThai := nv.TrangThai
+	switch {
+	case dangNau:
+		trangThai = entity.TrangThaiBan
+	case nv.TrangThai == entity.TrangThaiBan:
+		trangThai = entity.TrangThaiRanh
+	}
+	if trangThai == nv.TrangThai {
+		return nil
+	}
+
+	if err := c.nhanVienRepo.UpdateTrangThai(ctx, nv.ID, trangThai); err != nil {
+		return fmt.Errorf("không thể cập nhật trạng thái đầu bếp: %w", err)
+	}
+
+	logger.CtxInfo(ctx, "Chef status updated",
+		zap.String("order_id", p.OrderID),
+		zap.String("dau_bep_id", nv.ID),
+		zap.String("tu", string(nv.TrangThai)),
+		zap.String("sang", string(trangThai)),
+	)
+	return nil
+}
+
+// ThuHoiToken thu hồi mọi token của user bị vô hiệu hóa
+// DeactivateUser đã thu hồi ngay (fail-open); consumer bảo đảm việc thu hồi không bị mất khi bước đó lỗi
+func (c *SuKienConsumer) ThuHoiToken(ctx context.Context, sk *entity.SuKien) error {
+	var p entity.UserDeactivatedPayload
+	if err := sk.DocDuLieu(&p); err != nil {
+		return err
+	}
+
+	if err := c.tokenBlacklist.RevokeAllUserTokens(ctx, p.UserID); err != nil {
+		return fmt.Errorf("không thể thu hồi token: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,8 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -23,10 +25,14 @@ var (
 	ErrEmailExists        = errors.New("email đã tồn tại")
 	ErrInvalidRole        = errors.New("role không hợp lệ")
 	ErrPermissionDenied   = errors.New("không có quyền thực hiện thao tác này")
-	ErrCannotCreateAdmin  = errors.New("chỉ Admin mới có quyền tạo Admin")
-	ErrCannotCreateManager = errors.New("chỉ Admin mới có quyền tạo Manager")
+	ErrCannotAssignRole   = errors.New("không có quyền tạo hoặc quản lý user thuộc role này")
+	ErrCannotWriteSalary  = errors.New("không có quyền đặt lương nhân viên")
 	ErrWrongPassword      = errors.New("mật khẩu cũ không đúng")
 	ErrCannotDeactivateSelf = errors.New("không thể tự vô hiệu hóa tài khoản")
+	ErrInvalidChucVu      = errors.New("chức vụ không hợp lệ")
+	ErrInvalidUserInfo    = errors.New("thông tin user không hợp lệ")
+	ErrInvalidStaffInfo   = errors.New("thông tin nhân viên không hợp lệ")
+	ErrPhoneExists        = errors.New("số điện thoại đã được nhân viên khác sử dụng")
 )
 
 // CreateUserInput là input để tạo user mới
@@ -35,7 +41,18 @@ type CreateUserInput struct {
 	Email       string
 	Password    string
 	Role        entity.UserRole
-	CreatorRole entity.UserRole // Role của người tạo
+
+	CreatorPermissions []string // Permission trong access token của người tạo
+}
+
+// CreateStaffInput là input để tạo tài khoản nhân viên (User + hồ sơ NhanVien)
+type CreateStaffInput struct {
+	CreateUserInput
+
+	HoTen       string
+	ChucVu      entity.ChucVu
+	SoDienThoai string
+	LuongCoBan  int64
 }
 
 // UpdateUserInput là input để cập nhật user
@@ -43,27 +60,146 @@ type UpdateUserInput struct {
 	ID         string
 	Email      *string
 	IsActive   *bool
-	CallerRole entity.UserRole // Role của người thực hiện update
+	PhienBan   int64 // Version client đã đọc (If-Match), 0 = không kiểm tra
+
+	CallerPermissions []string // Permission trong access token của người thực hiện update
 }
 
 // UserUseCase xử lý business logic liên quan đến User
 type UserUseCase struct {
 	repo           repository.IUserRepository
+	nhanVienRepo   repository.INhanVienRepository
+	uow            repository.IUnitOfWork       // Transaction MySQL (users + nhan_vien + outbox)
+	outboxRepo     repository.IOutboxRepository // Outbox MySQL (user.deactivated)
 	tokenBlacklist service.TokenBlacklistService
+	auditRepo      repository.IAuditLogRepository
 }
 
 // NewUserUseCase tạo mới UserUseCase
-func NewUserUseCase(repo repository.IUserRepository, tokenBlacklist service.TokenBlacklistService) *UserUseCase {
+func NewUserUseCase(
+	repo repository.IUserRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	uow repository.IUnitOfWork,
+	outboxRepo repository.IOutboxRepository,
+	tokenBlacklist service.TokenBlacklistService,
+	auditRepo repository.IAuditLogRepository,
+) *UserUseCase {
 	return &UserUseCase{
 		repo:           repo,
+		nhanVienRepo:   nhanVienRepo,
+		uow:            uow,
+		outboxRepo:     outboxRepo,
 		tokenBlacklist: tokenBlacklist,
+		auditRepo:      auditRepo,
 	}
 }
 
 // CreateUser tạo user mới với kiểm tra quyền
 func (uc *UserUseCase) CreateUser(ctx context.Context, input CreateUserInput) (*entity.User, error) {
+	user, err := uc.chuanBiUserMoi(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create in repository (INSERT thuần, không ON DUPLICATE KEY UPDATE)
+	if err := uc.repo.Create(ctx, user); err != nil {
+		// Map duplicate entry error (race condition: 2 request đồng thời pass ExistsByUsername/Email)
+		if errors.Is(err, repository.ErrDuplicateEntry) {
+			return nil, ErrUsernameExists
+		}
+		logger.CtxError(ctx, "failed to create new user",
+			zap.String("target_username", input.Username),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	logger.CtxInfo(ctx, "user created",
+		zap.String("target_user_id", user.ID),
+		zap.String("target_username", user.Username),
+		zap.String("target_role", string(user.Role)),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "user.tao", entity.AuditDoiTuongUser, user.ID, nil, snapshotUser(user))
+
+	return user, nil
+}
+
+// CreateStaff tạo tài khoản nhân viên: User và hồ sơ NhanVien trong cùng một transaction
+// Một trong hai thao tác lỗi thì không bản ghi nào được lưu (không còn User mồ côi thiếu hồ sơ)
+func (uc *UserUseCase) CreateStaff(ctx context.Context, input CreateStaffInput) (*entity.User, *entity.NhanVien, error) {
+	if !input.ChucVu.IsValid() {
+		return nil, nil, ErrInvalidChucVu
+	}
+	// Lương là dữ liệu nhạy cảm: người có user:write nhưng không có staff:salary:write chỉ tạo được hồ sơ lương 0
+	if input.LuongCoBan > 0 && !slices.Contains(input.CreatorPermissions, entity.PermissionStaffSalaryWrite) {
+		return nil, nil, ErrCannotWriteSalary
+	}
+
+	user, err := uc.chuanBiUserMoi(ctx, input.CreateUserInput)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nv, err := entity.NewNhanVien(uuid.New().String(), user.ID, input.HoTen, input.ChucVu, input.SoDienThoai)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidStaffInfo, err)
+	}
+	nv.Email = user.Email
+	if err := nv.CapNhatLuong(input.LuongCoBan); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidStaffInfo, err)
+	}
+
+	exists, err := uc.nhanVienRepo.ExistsBySoDienThoai(ctx, nv.SoDienThoai)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exists {
+		return nil, nil, ErrPhoneExists
+	}
+
+	err = uc.uow.Do(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Create(ctx, user); err != nil {
+			return err
+		}
+		// Trùng ở bảng nhan_vien chỉ có thể là số điện thoại (request đồng thời qua được kiểm tra ở trên)
+		if err := uc.nhanVienRepo.Create(ctx, nv); err != nil {
+			if errors.Is(err, repository.ErrDuplicateEntry) {
+				return ErrPhoneExists
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrPhoneExists) {
+			return nil, nil, err
+		}
+		if errors.Is(err, repository.ErrDuplicateEntry) {
+			return nil, nil, uc.loiTrungUser(ctx, user.Email)
+		}
+		logger.CtxError(ctx, "failed to create staff account",
+			zap.String("target_username", input.Username),
+			zap.Error(err),
+		)
+		return nil, nil, err
+	}
+
+	logger.CtxInfo(ctx, "staff account created",
+		zap.String("target_user_id", user.ID),
+		zap.String("target_username", user.Username),
+		zap.String("nhan_vien_id", nv.ID),
+		zap.String("chuc_vu", string(nv.ChucVu)),
+	)
+	ghiAuditLog(ctx, uc.auditRepo, "user.tao", entity.AuditDoiTuongUser, user.ID, nil, snapshotUser(user))
+	ghiAuditLog(ctx, uc.auditRepo, "nhan_vien.tao", entity.AuditDoiTuongNhanVien, nv.ID, nil, snapshotNhanVien(nv))
+
+	return user, nv, nil
+}
+
+// chuanBiUserMoi kiểm tra quyền, trùng username/email và dựng entity User (chưa lưu)
+func (uc *UserUseCase) chuanBiUserMoi(ctx context.Context, input CreateUserInput) (*entity.User, error) {
 	// Validate role permission
-	if err := uc.validateCreatePermission(input.CreatorRole, input.Role); err != nil {
+	if err := checkAssignRole(input.CreatorPermissions, input.Role); err != nil {
 		return nil, err
 	}
 
@@ -88,10 +224,12 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, input CreateUserInput) (*
 	// Hash password
 	passwordHash, err := password.Hash(input.Password)
 	if err != nil {
+		if errors.Is(err, password.ErrTooLong) {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidUserInfo, err)
+		}
 		return nil, err
 	}
 
-	// Create user entity
 	user, err := entity.NewUser(
 		uuid.New().String(),
 		input.Username,
@@ -100,61 +238,30 @@ func (uc *UserUseCase) CreateUser(ctx context.Context, input CreateUserInput) (*
 		input.Role,
 	)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUserInfo, err)
 	}
+	return user, nil
+}
 
-	// Create in repository (INSERT thuần, không ON DUPLICATE KEY UPDATE)
-	if err := uc.repo.Create(ctx, user); err != nil {
-		// Map duplicate entry error (race condition: 2 request đồng thời pass ExistsByUsername/Email)
-		if errors.Is(err, repository.ErrDuplicateEntry) {
-			return nil, ErrUsernameExists
-		}
-		logger.CtxError(ctx, "failed to create new user",
-			zap.String("target_username", input.Username),
-			zap.Error(err),
-		)
-		return nil, err
+// loiTrungUser xác định user bị trùng username hay email khi insert báo trùng khóa
+// (request đồng thời cùng qua được kiểm tra ExistsByUsername/ExistsByEmail)
+func (uc *UserUseCase) loiTrungUser(ctx context.Context, email string) error {
+	exists, err := uc.repo.ExistsByEmail(ctx, email)
+	if err == nil && exists {
+		return ErrEmailExists
 	}
-
-	logger.CtxInfo(ctx, "user created",
-		zap.String("target_user_id", user.ID),
-		zap.String("target_username", user.Username),
-		zap.String("target_role", string(user.Role)),
-		zap.String("creator_role", string(input.CreatorRole)),
-	)
-
-	return user, nil
+	return ErrUsernameExists
 }
 
-// validateCreatePermission kiểm tra quyền tạo user theo role
-// Permission Matrix:
-// | Creator     | Admin | Manager | Staff | Customer |
-// |-------------|-------|---------|-------|----------|
-// | Admin       | YES   | YES     | YES   | YES      |
-// | Manager     | NO    | NO      | YES   | YES      |
-// | Staff       | NO    | NO      | NO    | NO       |
-// | Customer    | NO    | NO      | NO    | NO       |
-func (uc *UserUseCase) validateCreatePermission(creatorRole, targetRole entity.UserRole) error {
-	switch targetRole {
-	case entity.RoleAdmin:
-		if creatorRole != entity.RoleAdmin {
-			return ErrCannotCreateAdmin
-		}
-	case entity.RoleManager:
-		if creatorRole != entity.RoleAdmin {
-			return ErrCannotCreateManager
-		}
-	case entity.RoleStaff:
-		if creatorRole != entity.RoleAdmin && creatorRole != entity.RoleManager {
-			return ErrPermissionDenied
-		}
-	case entity.RoleCustomer:
-		if creatorRole == entity.RoleCustomer || creatorRole == entity.RoleStaff {
-			return ErrPermissionDenied
-		}
-	default:
+// checkAssignRole kiểm tra người gọi được tạo/quản lý user thuộc role không
+// Cần permission user:assign:<role> (vd: manager mặc định được gán staff, cashier, chef, customer)
+func checkAssignRole(permissions []string, role entity.UserRole) error {
+	if !role.IsValid() {
 		return ErrInvalidRole
 	}
+	if !slices.Contains(permissions, entity.PermissionAssignRole(role)) {
+		return ErrCannotAssignRole
+	}
 	return nil
 }
 
@@ -200,13 +307,17 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, input UpdateUserInput) (*
 		return nil, ErrUserNotFound
 	}
 
-	// Authorization check: Manager chỉ được update staff/customer
-	if input.CallerRole != entity.RoleAdmin {
-		if user.Role == entity.RoleAdmin || user.Role == entity.RoleManager {
-			return nil, ErrPermissionDenied
-		}
+	// Chỉ được sửa user thuộc role mà người gọi được gán
+	if err := checkAssignRole(input.CallerPermissions, user.Role); err != nil {
+		return nil, err
+	}
+	if err := kiemTraPhienBan(user.Version, input.PhienBan); err != nil {
+		return nil, err
 	}
 
+	truoc := snapshotUser(user)
+	dangHoatDong := user.IsActive
+
 	// Update email if provided
 	if input.Email != nil {
 		// Check if email already used by another user
@@ -227,7 +338,7 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, input UpdateUserInput) (*
 
 	user.NgayCapNhat = time.Now()
 
-	if err := uc.repo.Save(ctx, user); err != nil {
+	if err := uc.luuUser(ctx, user, dangHoatDong && !user.IsActive); err != nil {
 		logger.CtxError(ctx, "failed to update user",
 			zap.String("target_user_id", input.ID),
 			zap.Error(err),
@@ -240,12 +351,15 @@ func (uc *UserUseCase) UpdateUser(ctx context.Context, input UpdateUserInput) (*
 		zap.Bool("email_changed", input.Email != nil),
 		zap.Bool("active_changed", input.IsActive != nil),
 	)
+	ghiAuditLog(ctx, uc.auditRepo, "user.cap_nhat", entity.AuditDoiTuongUser, user.ID, truoc, snapshotUser(user))
 
 	return user, nil
 }
 
 // DeactivateUser vô hiệu hóa user (soft delete)
-func (uc *UserUseCase) DeactivateUser(ctx context.Context, id string, requestorID string) (*entity.User, error) {
+// requestorPermissions: chỉ vô hiệu hóa được user thuộc role mà người gọi được gán
+// phienBan: Version client đã đọc (If-Match), 0 = không kiểm tra
+func (uc *UserUseCase) DeactivateUser(ctx context.Context, id string, requestorID string, requestorPermissions []string, phienBan int64) (*entity.User, error) {
 	// Không cho phép tự vô hiệu hóa chính mình
 	if id == requestorID {
 		return nil, ErrCannotDeactivateSelf
@@ -258,10 +372,18 @@ func (uc *UserUseCase) DeactivateUser(ctx context.Context, id string, requestorI
 	if user == nil {
 		return nil, ErrUserNotFound
 	}
+	if err := checkAssignRole(requestorPermissions, user.Role); err != nil {
+		return nil, err
+	}
+	if err := kiemTraPhienBan(user.Version, phienBan); err != nil {
+		return nil, err
+	}
 
+	truoc := snapshotUser(user)
+	dangHoatDong := user.IsActive
 	user.Deactivate()
 
-	if err := uc.repo.Save(ctx, user); err != nil {
+	if err := uc.luuUser(ctx, user, dangHoatDong); err != nil {
 		logger.CtxError(ctx, "failed to deactivate user",
 			zap.String("target_user_id", id),
 			zap.Error(err),
@@ -270,6 +392,7 @@ func (uc *UserUseCase) DeactivateUser(ctx context.Context, id string, requestorI
 	}
 
 	// Revoke tất cả tokens sau khi deactivate thành công (fail-open)
+	// Consumer của sự kiện user.deactivated thu hồi lại nếu bước này lỗi
 	if err := uc.tokenBlacklist.RevokeAllUserTokens(ctx, id); err != nil {
 		logger.CtxWarn(ctx, "failed to revoke tokens after deactivation",
 			zap.String("target_user_id", id),
@@ -282,10 +405,27 @@ func (uc *UserUseCase) DeactivateUser(ctx context.Context, id string, requestorI
 		zap.String("target_username", user.Username),
 		zap.String("requestor_id", requestorID),
 	)
+	ghiAuditLog(ctx, uc.auditRepo, "user.vo_hieu_hoa", entity.AuditDoiTuongUser, user.ID, truoc, snapshotUser(user))
 
 	return user, nil
 }
 
+// luuUser lưu user đã tồn tại
+// vuaVoHieuHoa = true: ghi kèm sự kiện user.deactivated vào outbox trong cùng transaction
+func (uc *UserUseCase) luuUser(ctx context.Context, user *entity.User, vuaVoHieuHoa bool) error {
+	if !vuaVoHieuHoa {
+		return uc.repo.Save(ctx, user)
+	}
+
+	return luuKemSuKien(ctx, uc.uow, uc.outboxRepo, func(ctx context.Context) error {
+		return uc.repo.Save(ctx, user)
+	}, entity.SuKienUserDeactivated, user.ID, entity.UserDeactivatedPayload{
+		UserID:   user.ID,
+		Username: user.Username,
+		Role:     user.Role,
+	})
+}
+
 // ChangePassword đổi mật khẩu user
 func (uc *UserUseCase) ChangePassword(ctx context.Context, userID, oldPassword, newPassword string) error {
 	user, err := uc.repo.FindByID(ctx, userID)
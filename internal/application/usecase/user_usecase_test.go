@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// fakeNhanVienRepo lưu nhân viên trong bộ nhớ, số điện thoại unique như ràng buộc MySQL
+type fakeNhanVienRepo struct {
+	repository.INhanVienRepository
+
+	data    map[string]*entity.NhanVien
+	loiDoc  error // Lỗi storage giả lập cho ExistsBySoDienThoai
+	boQuaKT bool  // Bỏ qua kiểm tra trước để mô phỏng request đồng thời
+}
+
+func (r *fakeNhanVienRepo) ExistsBySoDienThoai(_ context.Context, soDienThoai string) (bool, error) {
+	if r.loiDoc != nil {
+		return false, r.loiDoc
+	}
+	if r.boQuaKT {
+		return false, nil
+	}
+	for _, nv := range r.data {
+		if nv.SoDienThoai == soDienThoai {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeNhanVienRepo) Create(_ context.Context, nhanVien *entity.NhanVien) error {
+	for _, nv := range r.data {
+		if nv.SoDienThoai == nhanVien.SoDienThoai {
+			return repository.ErrDuplicateEntry
+		}
+	}
+	r.data[nhanVien.ID] = nhanVien
+	return nil
+}
+
+// passUnitOfWork chạy fn trực tiếp (repository bộ nhớ không có transaction)
+type passUnitOfWork struct{}
+
+func (passUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestUserUseCase_CreateStaff(t *testing.T) {
+	loiDB := errors.New("mysql: connection refused")
+	quyenDayDu := []string{entity.PermissionAssignRole(entity.RoleStaff), entity.PermissionStaffSalaryWrite}
+
+	staffInput := func(username, email, soDienThoai string) CreateStaffInput {
+		return CreateStaffInput{
+			CreateUserInput: CreateUserInput{
+				Username:           username,
+				Email:              email,
+				Password:           "password123",
+				Role:               entity.RoleStaff,
+				CreatorPermissions: quyenDayDu,
+			},
+			HoTen:       "Nguyễn Văn B",
+			ChucVu:      entity.ChucVuPhucVu,
+			SoDienThoai: soDienThoai,
+			LuongCoBan:  8000000,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		input   CreateStaffInput
+		loiDoc  error
+		boQuaKT bool
+		wantErr error
+	}{
+		{
+			name:  "tạo thành công",
+			input: staffInput("nv_moi", "nv_moi@example.com", "0900000002"),
+		},
+		{
+			name:    "trùng username",
+			input:   staffInput("nv_cu", "khac@example.com", "0900000002"),
+			wantErr: ErrUsernameExists,
+		},
+		{
+			name:    "trùng email",
+			input:   staffInput("nv_moi", "nv_cu@example.com", "0900000002"),
+			wantErr: ErrEmailExists,
+		},
+		{
+			name:    "trùng số điện thoại",
+			input:   staffInput("nv_moi", "nv_moi@example.com", "0900000001"),
+			wantErr: ErrPhoneExists,
+		},
+		{
+			name:    "trùng số điện thoại do request đồng thời",
+			input:   staffInput("nv_moi", "nv_moi@example.com", "0900000001"),
+			boQuaKT: true,
+			wantErr: ErrPhoneExists,
+		},
+		{
+			name: "thiếu họ tên",
+			input: func() CreateStaffInput {
+				in := staffInput("nv_moi", "nv_moi@example.com", "0900000002")
+				in.HoTen = ""
+				return in
+			}(),
+			wantErr: ErrInvalidStaffInfo,
+		},
+		{
+			name: "đặt lương khi thiếu staff:salary:write",
+			input: func() CreateStaffInput {
+				in := staffInput("nv_moi", "nv_moi@example.com", "0900000002")
+				in.CreatorPermissions = []string{entity.PermissionAssignRole(entity.RoleStaff)}
+				return in
+			}(),
+			wantErr: ErrCannotWriteSalary,
+		},
+		{
+			name:    "lỗi storage giữ nguyên, không đổi thành lỗi nghiệp vụ",
+			input:   staffInput("nv_moi", "nv_moi@example.com", "0900000002"),
+			loiDoc:  loiDB,
+			wantErr: loiDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			userCu, _ := entity.NewUser("u-1", "nv_cu", "nv_cu@example.com", "hash", entity.RoleStaff)
+			nvCu, _ := entity.NewNhanVien("nv-1", userCu.ID, "Nguyễn Văn A", entity.ChucVuPhucVu, "0900000001")
+
+			userRepo := &fakeUserRepo{users: map[string]*entity.User{userCu.ID: userCu}}
+			nvRepo := &fakeNhanVienRepo{
+				data:    map[string]*entity.NhanVien{nvCu.ID: nvCu},
+				loiDoc:  tt.loiDoc,
+				boQuaKT: tt.boQuaKT,
+			}
+			uc := NewUserUseCase(userRepo, nvRepo, passUnitOfWork{}, nil, nil, nil)
+
+			user, nv, err := uc.CreateStaff(ctx, tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateStaff() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if nv.UserID != user.ID || nv.Email != user.Email {
+				t.Errorf("staff profile not linked to user: %+v", nv)
+			}
+			if nv.LuongCoBan != tt.input.LuongCoBan {
+				t.Errorf("LuongCoBan = %v, want %v", nv.LuongCoBan, tt.input.LuongCoBan)
+			}
+			if nvRepo.data[nv.ID] == nil {
+				t.Errorf("staff profile %s not stored", nv.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,358 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+	"restaurant_project/pkg/netguard"
+)
+
+// Webhook use case errors
+var (
+	ErrWebhookNotFound             = errors.New("không tìm thấy webhook")
+	ErrWebhookDeliveryNotFound     = errors.New("không tìm thấy lần gửi webhook")
+	ErrWebhookDangTamDung          = errors.New("webhook đang tạm dừng")
+	ErrTrangThaiDeliveryKhongHopLe = errors.New("trạng thái delivery không hợp lệ")
+)
+
+// TaoWebhookInput là input để đăng ký webhook
+type TaoWebhookInput struct {
+	Ten    string
+	URL    string
+	SuKien []string
+	Secret string
+}
+
+// CapNhatWebhookInput là input để cập nhật webhook (nil = giữ nguyên)
+type CapNhatWebhookInput struct {
+	ID           string
+	Ten          *string
+	URL          *string
+	SuKien       []string
+	Secret       *string
+	DangHoatDong *bool
+}
+
+// XemDeliveryInput là điều kiện tra cứu nhật ký gửi của một webhook
+type XemDeliveryInput struct {
+	WebhookID string
+	SuKienID  string
+	TrangThai string
+	Offset    int
+	Limit     int
+}
+
+// WebhookUseCase quản lý webhook của bên thứ ba (Admin) và phát sự kiện tới các webhook
+//
+// Luồng gửi: outbox → dispatcher → PhatSuKien tạo một delivery cho mỗi webhook đăng ký loại sự kiện
+// → WebhookSender gửi ở nền, thử lại với backoff và ghi kết quả vào nhật ký delivery
+type WebhookUseCase struct {
+	webhookRepo  repository.IWebhookRepository
+	deliveryRepo repository.IWebhookDeliveryRepository
+	sender       service.WebhookSender
+	urlGuard     *netguard.Guard
+	auditRepo    repository.IAuditLogRepository
+}
+
+// NewWebhookUseCase tạo mới WebhookUseCase
+// urlGuard từ chối URL trỏ vào mạng nội bộ ngay khi đăng ký (sender kiểm tra lại lúc kết nối)
+func NewWebhookUseCase(
+	webhookRepo repository.IWebhookRepository,
+	deliveryRepo repository.IWebhookDeliveryRepository,
+	sender service.WebhookSender,
+	urlGuard *netguard.Guard,
+	auditRepo repository.IAuditLogRepository,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		sender:       sender,
+		urlGuard:     urlGuard,
+		auditRepo:    auditRepo,
+	}
+}
+
+// ============================================================
+// Quản lý webhook
+// ============================================================
+
+// TaoWebhook đăng ký webhook mới
+func (uc *WebhookUseCase) TaoWebhook(ctx context.Context, input TaoWebhookInput) (*entity.Webhook, error) {
+	wh, err := entity.NewWebhook(uuid.New().String(), input.Ten, input.URL, loaiSuKienTu(input.SuKien), input.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tạo webhook: %w", err)
+	}
+	if err := uc.kiemTraURL(ctx, wh.URL); err != nil {
+		return nil, err
+	}
+
+	if err := uc.webhookRepo.Save(ctx, wh); err != nil {
+		return nil, fmt.Errorf("không thể lưu webhook: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "webhook.tao", entity.AuditDoiTuongWebhook, wh.ID, nil, snapshotWebhook(wh))
+
+	return wh, nil
+}
+
+// XemWebhook lấy danh sách webhook
+func (uc *WebhookUseCase) XemWebhook(ctx context.Context) ([]*entity.Webhook, error) {
+	list, err := uc.webhookRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("không thể lấy danh sách webhook: %w", err)
+	}
+
+	return list, nil
+}
+
+// TimWebhook tìm webhook theo ID
+func (uc *WebhookUseCase) TimWebhook(ctx context.Context, id string) (*entity.Webhook, error) {
+	wh, err := uc.webhookRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm webhook: %w", err)
+	}
+	if wh == nil {
+		return nil, ErrWebhookNotFound
+	}
+
+	return wh, nil
+}
+
+// CapNhatWebhook cập nhật webhook (URL, bộ lọc sự kiện, secret, tạm dừng / kích hoạt)
+func (uc *WebhookUseCase) CapNhatWebhook(ctx context.Context, input CapNhatWebhookInput) (*entity.Webhook, error) {
+	wh, err := uc.TimWebhook(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	truoc := snapshotWebhook(wh)
+	secretCu := wh.Secret
+
+	if input.Ten != nil {
+		if *input.Ten == "" {
+			return nil, errors.New("tên webhook không được để trống")
+		}
+		wh.Ten = *input.Ten
+	}
+	if input.URL != nil {
+		if err := wh.DatURL(*input.URL); err != nil {
+			return nil, err
+		}
+		if err := uc.kiemTraURL(ctx, wh.URL); err != nil {
+			return nil, err
+		}
+	}
+	if input.SuKien != nil {
+		if err := wh.DatSuKien(loaiSuKienTu(input.SuKien)); err != nil {
+			return nil, err
+		}
+	}
+	if input.Secret != nil {
+		if err := wh.DatSecret(*input.Secret); err != nil {
+			return nil, err
+		}
+	}
+	if input.DangHoatDong != nil {
+		if *input.DangHoatDong {
+			wh.KichHoat()
+		} else {
+			wh.TamDung()
+		}
+	}
+
+	if err := uc.webhookRepo.Save(ctx, wh); err != nil {
+		return nil, fmt.Errorf("không thể lưu webhook: %w", err)
+	}
+
+	// Không ghi secret vào audit log, chỉ ghi nhận là đã đổi
+	sau := snapshotWebhook(wh)
+	if wh.Secret != secretCu {
+		sau["doi_secret"] = true
+	}
+	ghiAuditLog(ctx, uc.auditRepo, "webhook.cap_nhat", entity.AuditDoiTuongWebhook, wh.ID, truoc, sau)
+
+	return wh, nil
+}
+
+// kiemTraURL từ chối endpoint có host phân giải ra địa chỉ nội bộ (chống SSRF)
+func (uc *WebhookUseCase) kiemTraURL(ctx context.Context, diaChi string) error {
+	u, err := url.Parse(diaChi)
+	if err != nil {
+		return fmt.Errorf("URL webhook không hợp lệ: %w", err)
+	}
+	if err := uc.urlGuard.KiemTraHost(ctx, u.Hostname()); err != nil {
+		return fmt.Errorf("URL webhook không hợp lệ: %w", err)
+	}
+	return nil
+}
+
+// XoaWebhook xóa webhook; delivery đang chờ gửi sẽ bị bỏ qua (ghi thất bại)
+// Nhật ký delivery của webhook được giữ lại
+func (uc *WebhookUseCase) XoaWebhook(ctx context.Context, id string) error {
+	wh, err := uc.TimWebhook(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.webhookRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("không thể xóa webhook: %w", err)
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "webhook.xoa", entity.AuditDoiTuongWebhook, id, snapshotWebhook(wh), nil)
+
+	return nil
+}
+
+// ============================================================
+// Nhật ký gửi
+// ============================================================
+
+// XemDelivery tra cứu nhật ký gửi của một webhook có phân trang, mới nhất trước
+func (uc *WebhookUseCase) XemDelivery(ctx context.Context, input XemDeliveryInput) ([]*entity.WebhookDelivery, int64, error) {
+	if _, err := uc.TimWebhook(ctx, input.WebhookID); err != nil {
+		return nil, 0, err
+	}
+
+	trangThai := entity.TrangThaiDelivery(input.TrangThai)
+	if trangThai != "" && !trangThai.IsValid() {
+		return nil, 0, ErrTrangThaiDeliveryKhongHopLe
+	}
+
+	filter := repository.WebhookDeliveryFilter{
+		WebhookID: input.WebhookID,
+		SuKienID:  input.SuKienID,
+		TrangThai: trangThai,
+	}
+	list, total, err := uc.deliveryRepo.Find(ctx, filter, input.Offset, input.Limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("không thể tra cứu nhật ký gửi webhook: %w", err)
+	}
+
+	return list, total, nil
+}
+
+// GuiLaiDelivery gửi lại thủ công một delivery (thất bại hoặc cả đã thành công) với cùng body và ID
+// Gửi lại là job mới nên lượt thử lại tự động của queue tính lại từ đầu;
+// SoLanThu của delivery vẫn cộng dồn mọi lần gửi
+func (uc *WebhookUseCase) GuiLaiDelivery(ctx context.Context, webhookID, deliveryID string) (*entity.WebhookDelivery, error) {
+	wh, err := uc.TimWebhook(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if !wh.DangHoatDong {
+		return nil, ErrWebhookDangTamDung
+	}
+
+	d, err := uc.deliveryRepo.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm lần gửi webhook: %w", err)
+	}
+	if d == nil || d.WebhookID != wh.ID {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+
+	truoc := map[string]any{"trang_thai": string(d.TrangThai)}
+	d.GuiLai()
+	if err := uc.deliveryRepo.Save(ctx, d); err != nil {
+		return nil, fmt.Errorf("không thể lưu lần gửi webhook: %w", err)
+	}
+	if err := uc.sender.GuiNen(ctx, d.ID); err != nil {
+		return nil, err
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "webhook.gui_lai", entity.AuditDoiTuongWebhook, wh.ID,
+		truoc, map[string]any{"trang_thai": string(d.TrangThai), "delivery_id": d.ID})
+
+	return d, nil
+}
+
+// ============================================================
+// Consumer domain event
+// ============================================================
+
+// DangKy đăng ký consumer phát sự kiện tới webhook cho mọi loại sự kiện hỗ trợ webhook
+// Tên consumer là một phần khóa idempotency: không đổi tên consumer đang chạy
+func (uc *WebhookUseCase) DangKy(d service.EventDispatcher) {
+	for _, loai := range entity.SuKienWebhook {
+		d.Subscribe(loai, "webhook.phat", uc.PhatSuKien)
+	}
+}
+
+// PhatSuKien tạo delivery cho mỗi webhook đang hoạt động đăng ký loại sự kiện và đưa vào hàng đợi gửi
+// ID delivery xác định từ webhook + sự kiện nên phát lại sau lỗi giữa chừng không tạo delivery trùng;
+// delivery đã tạo ở lần trước nhưng chưa gửi lần nào được đưa lại vào hàng đợi
+func (uc *WebhookUseCase) PhatSuKien(ctx context.Context, sk *entity.SuKien) error {
+	webhooks, err := uc.webhookRepo.FindDangHoatDong(ctx)
+	if err != nil {
+		return fmt.Errorf("không thể lấy danh sách webhook: %w", err)
+	}
+
+	var errs []error
+	for _, wh := range webhooks {
+		if !wh.NhanSuKien(sk.Loai) {
+			continue
+		}
+		if err := uc.taoVaGuiDelivery(ctx, wh, sk); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", wh.ID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// taoVaGuiDelivery tạo delivery của sự kiện cho webhook (nếu chưa có) và đưa vào hàng đợi gửi
+func (uc *WebhookUseCase) taoVaGuiDelivery(ctx context.Context, wh *entity.Webhook, sk *entity.SuKien) error {
+	id := uuid.NewSHA1(uuid.NameSpaceOID, []byte(wh.ID+"/"+sk.ID)).String()
+	d, err := entity.NewWebhookDelivery(id, wh.ID, sk)
+	if err != nil {
+		return err
+	}
+
+	moi, err := uc.deliveryRepo.TaoNeuChuaCo(ctx, d)
+	if err != nil {
+		return fmt.Errorf("không thể ghi nhật ký gửi webhook: %w", err)
+	}
+	if !moi {
+		daCo, err := uc.deliveryRepo.FindByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("không thể tìm lần gửi webhook: %w", err)
+		}
+		// Đã gửi ít nhất một lần: job của delivery đang được queue thử lại
+		if daCo == nil || daCo.SoLanThu > 0 || daCo.TrangThai != entity.DeliveryDangCho {
+			return nil
+		}
+	}
+
+	if err := uc.sender.GuiNen(ctx, id); err != nil {
+		return err
+	}
+
+	logger.CtxDebug(ctx, "Webhook delivery created",
+		zap.String("delivery_id", id),
+		zap.String("webhook_id", wh.ID),
+		zap.String("su_kien_id", sk.ID),
+		zap.String("loai", string(sk.Loai)),
+	)
+	return nil
+}
+
+// loaiSuKienTu chuyển danh sách loại sự kiện từ input (nil giữ nguyên nil)
+func loaiSuKienTu(list []string) []entity.LoaiSuKien {
+	if list == nil {
+		return nil
+	}
+	result := make([]entity.LoaiSuKien, len(list))
+	for i, s := range list {
+		result[i] = entity.LoaiSuKien(s)
+	}
+	return result
+}
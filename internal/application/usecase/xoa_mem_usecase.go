@@ -0,0 +1,177 @@
+// Package usecase chứa Application Use Cases
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/pkg/logger"
+)
+
+// Soft delete use case errors
+var (
+	ErrBanGhiDaXoaNotFound = errors.New("không tìm thấy bản ghi đã xóa")
+	ErrKhoiPhucTrungSoDT   = errors.New("không thể khôi phục: số điện thoại đã được bản ghi khác sử dụng")
+)
+
+// XoaMemUseCase xử lý nghiệp vụ với bản ghi đã xóa mềm (Admin)
+// Món ăn, khách hàng, nhân viên bị xóa chỉ được đánh dấu NgayXoa để báo cáo cũ vẫn tham chiếu được;
+// admin xem và khôi phục được cho tới khi hết thời gian lưu giữ và bị xóa vĩnh viễn
+type XoaMemUseCase struct {
+	monAnRepo     repository.IMonAnRepository
+	khachHangRepo repository.IKhachHangRepository
+	nhanVienRepo  repository.INhanVienRepository
+	auditRepo     repository.IAuditLogRepository
+}
+
+// NewXoaMemUseCase tạo mới XoaMemUseCase
+func NewXoaMemUseCase(
+	monAnRepo repository.IMonAnRepository,
+	khachHangRepo repository.IKhachHangRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	auditRepo repository.IAuditLogRepository,
+) *XoaMemUseCase {
+	return &XoaMemUseCase{
+		monAnRepo:     monAnRepo,
+		khachHangRepo: khachHangRepo,
+		nhanVienRepo:  nhanVienRepo,
+		auditRepo:     auditRepo,
+	}
+}
+
+// XemMonDaXoa liệt kê các món đã xóa mềm, mới xóa trước
+func (uc *XoaMemUseCase) XemMonDaXoa(ctx context.Context) ([]*entity.MonAn, error) {
+	return uc.monAnRepo.FindDeleted(ctx)
+}
+
+// KhoiPhucMon khôi phục món đã xóa mềm về menu
+func (uc *XoaMemUseCase) KhoiPhucMon(ctx context.Context, id string) (*entity.MonAn, error) {
+	ok, err := uc.monAnRepo.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể khôi phục món: %w", err)
+	}
+	if !ok {
+		return nil, ErrBanGhiDaXoaNotFound
+	}
+
+	mon, err := uc.monAnRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm món: %w", err)
+	}
+	if mon == nil {
+		return nil, ErrBanGhiDaXoaNotFound
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "mon_an.khoi_phuc", entity.AuditDoiTuongMonAn, id, nil, snapshotMonAn(mon))
+
+	return mon, nil
+}
+
+// XemKhachHangDaXoa liệt kê các khách hàng đã xóa mềm, mới xóa trước
+func (uc *XoaMemUseCase) XemKhachHangDaXoa(ctx context.Context) ([]*entity.KhachHang, error) {
+	return uc.khachHangRepo.FindDeleted(ctx)
+}
+
+// KhoiPhucKhachHang khôi phục khách hàng đã xóa mềm
+func (uc *XoaMemUseCase) KhoiPhucKhachHang(ctx context.Context, id string) (*entity.KhachHang, error) {
+	ok, err := uc.khachHangRepo.Restore(ctx, id)
+	if errors.Is(err, repository.ErrDuplicateEntry) {
+		return nil, ErrKhoiPhucTrungSoDT
+	}
+	if err != nil {
+		return nil, fmt.Errorf("không thể khôi phục khách hàng: %w", err)
+	}
+	if !ok {
+		return nil, ErrBanGhiDaXoaNotFound
+	}
+
+	kh, err := uc.khachHangRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm khách hàng: %w", err)
+	}
+	if kh == nil {
+		return nil, ErrBanGhiDaXoaNotFound
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "khach_hang.khoi_phuc", entity.AuditDoiTuongKhachHang, id, nil, snapshotKhachHang(kh))
+
+	return kh, nil
+}
+
+// XemNhanVienDaXoa liệt kê các nhân viên đã xóa mềm, mới xóa trước
+func (uc *XoaMemUseCase) XemNhanVienDaXoa(ctx context.Context) ([]*entity.NhanVien, error) {
+	return uc.nhanVienRepo.FindDeleted(ctx)
+}
+
+// KhoiPhucNhanVien khôi phục nhân viên đã xóa mềm
+func (uc *XoaMemUseCase) KhoiPhucNhanVien(ctx context.Context, id string) (*entity.NhanVien, error) {
+	ok, err := uc.nhanVienRepo.Restore(ctx, id)
+	if errors.Is(err, repository.ErrDuplicateEntry) {
+		return nil, ErrKhoiPhucTrungSoDT
+	}
+	if err != nil {
+		return nil, fmt.Errorf("không thể khôi phục nhân viên: %w", err)
+	}
+	if !ok {
+		return nil, ErrBanGhiDaXoaNotFound
+	}
+
+	nv, err := uc.nhanVienRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("không thể tìm nhân viên: %w", err)
+	}
+	if nv == nil {
+		return nil, ErrBanGhiDaXoaNotFound
+	}
+
+	ghiAuditLog(ctx, uc.auditRepo, "nhan_vien.khoi_phuc", entity.AuditDoiTuongNhanVien, id, nil, snapshotNhanVien(nv))
+
+	return nv, nil
+}
+
+// KetQuaDonXoaMem là số bản ghi đã xóa vĩnh viễn theo từng loại
+type KetQuaDonXoaMem struct {
+	MonAn     int64
+	KhachHang int64
+	NhanVien  int64
+}
+
+// DonBanGhiDaXoa xóa vĩnh viễn các bản ghi đã xóa mềm trước thời điểm truoc (scheduler gọi)
+// Lỗi một loại không chặn các loại còn lại: trả về số đã xóa kèm lỗi của các loại thất bại
+func (uc *XoaMemUseCase) DonBanGhiDaXoa(ctx context.Context, truoc time.Time) (KetQuaDonXoaMem, error) {
+	var kq KetQuaDonXoaMem
+	var errs []error
+
+	n, err := uc.monAnRepo.PurgeDeleted(ctx, truoc)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("món ăn: %w", err))
+	}
+	kq.MonAn = n
+
+	n, err = uc.khachHangRepo.PurgeDeleted(ctx, truoc)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("khách hàng: %w", err))
+	}
+	kq.KhachHang = n
+
+	n, err = uc.nhanVienRepo.PurgeDeleted(ctx, truoc)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("nhân viên: %w", err))
+	}
+	kq.NhanVien = n
+
+	logger.CtxInfo(ctx, "soft-deleted records purged",
+		zap.Time("deleted_before", truoc),
+		zap.Int64("mon_an", kq.MonAn),
+		zap.Int64("khach_hang", kq.KhachHang),
+		zap.Int64("nhan_vien", kq.NhanVien),
+	)
+
+	return kq, errors.Join(errs...)
+}
@@ -2,6 +2,10 @@
 package providers
 
 import (
+	"fmt"
+	"time"
+	_ "time/tzdata" // Nhúng tz database để LoadLocation chạy được trên image tối giản
+
 	"restaurant_project/internal/infrastructure/config"
 )
 
@@ -29,3 +33,13 @@ func ProvideServerConfig(cfg *config.Config) *config.ServerConfig {
 func ProvideMySQLConfig(cfg *config.Config) *config.MySQLConfig {
 	return &cfg.MySQL
 }
+
+// ProvideRestaurantLocation load múi giờ của nhà hàng từ RestaurantConfig
+// Dùng để xác định lịch phục vụ món theo giờ địa phương, không phụ thuộc múi giờ server
+func ProvideRestaurantLocation(cfg *config.Config) (*time.Location, error) {
+	loc, err := time.LoadLocation(cfg.Restaurant.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid RESTAURANT_TIMEZONE %q: %w", cfg.Restaurant.Timezone, err)
+	}
+	return loc, nil
+}
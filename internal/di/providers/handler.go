@@ -25,6 +25,11 @@ func ProvideSwaggerHandler() *handler.SwaggerHandler {
 	return handler.NewSwaggerHandler()
 }
 
+// ProvideJWKSHandler tạo JWKS HTTP handler
+func ProvideJWKSHandler(jwtAuth *middleware.JWTAuthMiddleware) *handler.JWKSHandler {
+	return handler.NewJWKSHandler(jwtAuth)
+}
+
 // ProvideHandlers trả về thông báo khi handlers đã sẵn sàng
 func ProvideHandlers(
 	monAnHandler *handler.MonAnHandler,
@@ -37,12 +42,78 @@ func ProvideHandlers(
 // ProvideUserHandler tạo User HTTP handler
 func ProvideUserHandler(
 	uc *usecase.UserUseCase,
+	authUC *usecase.AuthUseCase,
 	jwtAuth *middleware.JWTAuthMiddleware,
 ) *handler.UserHandler {
-	return handler.NewUserHandler(uc, jwtAuth)
+	return handler.NewUserHandler(uc, authUC, jwtAuth)
 }
 
 // ProvideAuthHandler tạo Auth HTTP handler
 func ProvideAuthHandler(uc *usecase.AuthUseCase) *handler.AuthHandler {
 	return handler.NewAuthHandler(uc)
 }
+
+// ProvideNguyenLieuHandler tạo NguyenLieu HTTP handler
+func ProvideNguyenLieuHandler(uc *usecase.KhoUseCase) *handler.NguyenLieuHandler {
+	return handler.NewNguyenLieuHandler(uc)
+}
+
+// ProvideNhaCungCapHandler tạo NhaCungCap HTTP handler
+func ProvideNhaCungCapHandler(uc *usecase.MuaHangUseCase) *handler.NhaCungCapHandler {
+	return handler.NewNhaCungCapHandler(uc)
+}
+
+// ProvideDonDatHangHandler tạo DonDatHang HTTP handler
+func ProvideDonDatHangHandler(uc *usecase.MuaHangUseCase) *handler.DonDatHangHandler {
+	return handler.NewDonDatHangHandler(uc)
+}
+
+// ProvideOrderHandler tạo Order HTTP handler
+func ProvideOrderHandler(uc *usecase.OrderUseCase) *handler.OrderHandler {
+	return handler.NewOrderHandler(uc)
+}
+
+// ProvideKhuyenMaiHandler tạo KhuyenMai HTTP handler
+func ProvideKhuyenMaiHandler(uc *usecase.KhuyenMaiUseCase) *handler.KhuyenMaiHandler {
+	return handler.NewKhuyenMaiHandler(uc)
+}
+
+// ProvideMaGiamGiaHandler tạo MaGiamGia HTTP handler
+func ProvideMaGiamGiaHandler(uc *usecase.MaGiamGiaUseCase) *handler.MaGiamGiaHandler {
+	return handler.NewMaGiamGiaHandler(uc)
+}
+
+// ProvideJobHandler tạo Job HTTP handler (Admin)
+func ProvideJobHandler(uc *usecase.JobUseCase) *handler.JobHandler {
+	return handler.NewJobHandler(uc)
+}
+
+// ProvideBaoCaoHandler tạo BaoCao (báo cáo) HTTP handler
+func ProvideBaoCaoHandler(uc *usecase.BaoCaoUseCase) *handler.BaoCaoHandler {
+	return handler.NewBaoCaoHandler(uc)
+}
+
+// ProvideSchedulerHandler tạo Scheduler HTTP handler
+func ProvideSchedulerHandler(uc *usecase.SchedulerUseCase) *handler.SchedulerHandler {
+	return handler.NewSchedulerHandler(uc)
+}
+
+// ProvidePermissionHandler tạo Permission HTTP handler
+func ProvidePermissionHandler(uc *usecase.PermissionUseCase) *handler.PermissionHandler {
+	return handler.NewPermissionHandler(uc)
+}
+
+// ProvideAuditLogHandler tạo AuditLog HTTP handler (Admin)
+func ProvideAuditLogHandler(uc *usecase.AuditLogUseCase) *handler.AuditLogHandler {
+	return handler.NewAuditLogHandler(uc)
+}
+
+// ProvideXoaMemHandler tạo XoaMem HTTP handler (Admin)
+func ProvideXoaMemHandler(uc *usecase.XoaMemUseCase) *handler.XoaMemHandler {
+	return handler.NewXoaMemHandler(uc)
+}
+
+// ProvideWebhookHandler tạo Webhook HTTP handler (Admin)
+func ProvideWebhookHandler(uc *usecase.WebhookUseCase) *handler.WebhookHandler {
+	return handler.NewWebhookHandler(uc)
+}
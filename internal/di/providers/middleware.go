@@ -2,11 +2,16 @@
 package providers
 
 import (
+	"errors"
+	"fmt"
+
 	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
 
 	"restaurant_project/internal/domain/service"
 	"restaurant_project/internal/infrastructure/config"
 	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/pkg/logger"
 )
 
 // MiddlewareCollection chứa tất cả middleware đã được khởi tạo
@@ -15,6 +20,7 @@ type MiddlewareCollection struct {
 	RateLimit       gin.HandlerFunc
 	AuthRateLimit   gin.HandlerFunc
 	JWTAuth         *middleware.JWTAuthMiddleware
+	Idempotency     *middleware.IdempotencyMiddleware
 	Timeout         gin.HandlerFunc
 	Gzip            gin.HandlerFunc
 	SecurityHeaders gin.HandlerFunc
@@ -22,18 +28,69 @@ type MiddlewareCollection struct {
 	ErrorHandler    gin.HandlerFunc
 }
 
+// defaultJWTSecret là secret mặc định trong config, không được dùng ở production
+const defaultJWTSecret = "change-this-in-production"
+
+// minJWTSecretLen là độ dài tối thiểu (byte) của secret HS256 ở production (RFC 7518: key >= kích thước hash)
+const minJWTSecretLen = 32
+
 // ProvideJWTAuth tạo JWTAuthMiddleware từ config
-func ProvideJWTAuth(cfg *config.Config, blacklistService service.TokenBlacklistService) *middleware.JWTAuthMiddleware {
-	return middleware.NewJWTAuth(cfg.Middleware.JWT, blacklistService)
+func ProvideJWTAuth(cfg *config.Config, blacklistService service.TokenBlacklistService) (*middleware.JWTAuthMiddleware, error) {
+	jwtCfg := cfg.Middleware.JWT
+	jwtAuth, err := middleware.NewJWTAuth(jwtCfg, cfg.Middleware.AuthCookie, blacklistService)
+	if err != nil {
+		return nil, err
+	}
+
+	if jwtAuth.Algorithm() == middleware.AlgorithmHS256 {
+		if err := kiemTraJWTSecret(jwtCfg.SecretKey, cfg.Log.Environment); err != nil {
+			return nil, err
+		}
+	}
+
+	if jwtAuth.CookieAuthEnabled() && !cfg.Middleware.AuthCookie.Secure {
+		if cfg.Log.Environment == "production" {
+			return nil, errors.New("AUTH_COOKIE_SECURE must be true in production")
+		}
+		logger.Warn("Auth cookies are sent without Secure flag, do not use in production")
+	}
+
+	logger.Info("JWT signing configured", zap.String("algorithm", jwtAuth.Algorithm()))
+	return jwtAuth, nil
+}
+
+// kiemTraJWTSecret từ chối secret HS256 mặc định hoặc ngắn hơn minJWTSecretLen ở production,
+// môi trường khác chỉ cảnh báo
+func kiemTraJWTSecret(secret, environment string) error {
+	var loi string
+	switch {
+	case secret == defaultJWTSecret:
+		loi = "JWT_SECRET_KEY must be changed in production (or use JWT_ALGORITHM=RS256/EdDSA)"
+	case len(secret) < minJWTSecretLen:
+		loi = fmt.Sprintf("JWT_SECRET_KEY must be at least %d bytes in production (or use JWT_ALGORITHM=RS256/EdDSA)", minJWTSecretLen)
+	default:
+		return nil
+	}
+
+	if environment == "production" {
+		return errors.New(loi)
+	}
+	logger.Warn("JWT HS256 secret is weak, do not use in production", zap.String("reason", loi))
+	return nil
 }
 
 // ProvideMiddlewareCollection tạo MiddlewareCollection từ config
-func ProvideMiddlewareCollection(cfg *config.Config, jwtAuth *middleware.JWTAuthMiddleware) *MiddlewareCollection {
+func ProvideMiddlewareCollection(
+	cfg *config.Config,
+	jwtAuth *middleware.JWTAuthMiddleware,
+	idempotencyStore service.IdempotencyStore,
+) *MiddlewareCollection {
 	return &MiddlewareCollection{
 		CORS:            middleware.CORS(cfg.Middleware.CORS),
 		RateLimit:       middleware.RateLimit(cfg.Middleware.RateLimit),
 		AuthRateLimit:   middleware.AuthRateLimit(cfg.Middleware.AuthRateLimit),
 		JWTAuth:         jwtAuth,
+		Idempotency:     middleware.NewIdempotency(cfg.Middleware.Idempotency, idempotencyStore),
 		Timeout:         middleware.Timeout(cfg.Middleware.Timeout),
 		Gzip:            middleware.Gzip(cfg.Middleware.Gzip),
 		SecurityHeaders: middleware.SecurityHeaders(cfg.Middleware.Security),
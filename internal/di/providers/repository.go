@@ -7,6 +7,7 @@ import (
 
 	"restaurant_project/internal/domain/cache"
 	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/infrastructure/database"
 	persistenceCache "restaurant_project/internal/infrastructure/persistence/cache"
 	"restaurant_project/internal/infrastructure/persistence/mongodb"
 	"restaurant_project/internal/infrastructure/persistence/mysql"
@@ -39,6 +40,26 @@ func ProvideMonAnRepository(cached *persistenceCache.CachedMonAnRepository) repo
 	return cached
 }
 
+// ProvideOrderMongoRepo tạo Order MongoDB repository
+func ProvideOrderMongoRepo(db *mongo.Database) *mongodb.OrderMongoRepo {
+	return mongodb.NewOrderMongoRepo(db)
+}
+
+// ProvideOrderRepository binds OrderMongoRepo to IOrderRepository interface
+func ProvideOrderRepository(repo *mongodb.OrderMongoRepo) repository.IOrderRepository {
+	return repo
+}
+
+// ProvideKhuyenMaiMongoRepo tạo KhuyenMai MongoDB repository
+func ProvideKhuyenMaiMongoRepo(db *mongo.Database) *mongodb.KhuyenMaiMongoRepo {
+	return mongodb.NewKhuyenMaiMongoRepo(db)
+}
+
+// ProvideKhuyenMaiRepository binds KhuyenMaiMongoRepo to IKhuyenMaiRepository interface
+func ProvideKhuyenMaiRepository(repo *mongodb.KhuyenMaiMongoRepo) repository.IKhuyenMaiRepository {
+	return repo
+}
+
 // ProvideUserMySQLRepo tạo User MySQL repository
 func ProvideUserMySQLRepo(db *sql.DB) *mysql.UserMySQLRepo {
 	return mysql.NewUserMySQLRepo(db)
@@ -48,3 +69,183 @@ func ProvideUserMySQLRepo(db *sql.DB) *mysql.UserMySQLRepo {
 func ProvideUserRepository(repo *mysql.UserMySQLRepo) repository.IUserRepository {
 	return repo
 }
+
+// ProvideNguyenLieuMySQLRepo tạo NguyenLieu MySQL repository
+func ProvideNguyenLieuMySQLRepo(db *sql.DB) *mysql.NguyenLieuMySQLRepo {
+	return mysql.NewNguyenLieuMySQLRepo(db)
+}
+
+// ProvideNguyenLieuRepository binds NguyenLieuMySQLRepo to INguyenLieuRepository interface
+func ProvideNguyenLieuRepository(repo *mysql.NguyenLieuMySQLRepo) repository.INguyenLieuRepository {
+	return repo
+}
+
+// ProvideNhaCungCapMySQLRepo tạo NhaCungCap MySQL repository
+func ProvideNhaCungCapMySQLRepo(db *sql.DB) *mysql.NhaCungCapMySQLRepo {
+	return mysql.NewNhaCungCapMySQLRepo(db)
+}
+
+// ProvideNhaCungCapRepository binds NhaCungCapMySQLRepo to INhaCungCapRepository interface
+func ProvideNhaCungCapRepository(repo *mysql.NhaCungCapMySQLRepo) repository.INhaCungCapRepository {
+	return repo
+}
+
+// ProvideDonDatHangMySQLRepo tạo DonDatHang MySQL repository
+func ProvideDonDatHangMySQLRepo(db *sql.DB) *mysql.DonDatHangMySQLRepo {
+	return mysql.NewDonDatHangMySQLRepo(db)
+}
+
+// ProvideDonDatHangRepository binds DonDatHangMySQLRepo to IDonDatHangRepository interface
+func ProvideDonDatHangRepository(repo *mysql.DonDatHangMySQLRepo) repository.IDonDatHangRepository {
+	return repo
+}
+
+// ProvideMaGiamGiaMySQLRepo tạo MaGiamGia MySQL repository
+func ProvideMaGiamGiaMySQLRepo(db *sql.DB) *mysql.MaGiamGiaMySQLRepo {
+	return mysql.NewMaGiamGiaMySQLRepo(db)
+}
+
+// ProvideMaGiamGiaRepository binds MaGiamGiaMySQLRepo to IMaGiamGiaRepository interface
+func ProvideMaGiamGiaRepository(repo *mysql.MaGiamGiaMySQLRepo) repository.IMaGiamGiaRepository {
+	return repo
+}
+
+// ProvideUserMFAMySQLRepo tạo UserMFA (xác thực hai lớp) MySQL repository
+func ProvideUserMFAMySQLRepo(db *sql.DB) *mysql.UserMFAMySQLRepo {
+	return mysql.NewUserMFAMySQLRepo(db)
+}
+
+// ProvideUserMFARepository binds UserMFAMySQLRepo to IUserMFARepository interface
+func ProvideUserMFARepository(repo *mysql.UserMFAMySQLRepo) repository.IUserMFARepository {
+	return repo
+}
+
+// ProvideExternalIdentityMySQLRepo tạo ExternalIdentity (tài khoản bên ngoài đã liên kết) MySQL repository
+func ProvideExternalIdentityMySQLRepo(db *sql.DB) *mysql.ExternalIdentityMySQLRepo {
+	return mysql.NewExternalIdentityMySQLRepo(db)
+}
+
+// ProvideExternalIdentityRepository binds ExternalIdentityMySQLRepo to IExternalIdentityRepository interface
+func ProvideExternalIdentityRepository(repo *mysql.ExternalIdentityMySQLRepo) repository.IExternalIdentityRepository {
+	return repo
+}
+
+// ProvideKhachHangMySQLRepo tạo KhachHang MySQL repository
+func ProvideKhachHangMySQLRepo(db *sql.DB) *mysql.KhachHangMySQLRepo {
+	return mysql.NewKhachHangMySQLRepo(db)
+}
+
+// ProvideKhachHangRepository binds KhachHangMySQLRepo to IKhachHangRepository interface
+func ProvideKhachHangRepository(repo *mysql.KhachHangMySQLRepo) repository.IKhachHangRepository {
+	return repo
+}
+
+// ProvideNhanVienMySQLRepo tạo NhanVien MySQL repository
+func ProvideNhanVienMySQLRepo(db *sql.DB) *mysql.NhanVienMySQLRepo {
+	return mysql.NewNhanVienMySQLRepo(db)
+}
+
+// ProvideNhanVienRepository binds NhanVienMySQLRepo to INhanVienRepository interface
+func ProvideNhanVienRepository(repo *mysql.NhanVienMySQLRepo) repository.INhanVienRepository {
+	return repo
+}
+
+// ProvidePermissionMySQLRepo tạo Permission (danh mục quyền + quyền của role) MySQL repository
+func ProvidePermissionMySQLRepo(db *sql.DB) *mysql.PermissionMySQLRepo {
+	return mysql.NewPermissionMySQLRepo(db)
+}
+
+// ProvidePermissionRepository binds PermissionMySQLRepo to IPermissionRepository interface
+func ProvidePermissionRepository(repo *mysql.PermissionMySQLRepo) repository.IPermissionRepository {
+	return repo
+}
+
+// ProvideDoanhThuMongoRepo tạo DoanhThu (snapshot doanh thu ngày) MongoDB repository
+func ProvideDoanhThuMongoRepo(db *mongo.Database) *mongodb.DoanhThuMongoRepo {
+	return mongodb.NewDoanhThuMongoRepo(db)
+}
+
+// ProvideDoanhThuRepository binds DoanhThuMongoRepo to IDoanhThuRepository interface
+func ProvideDoanhThuRepository(repo *mongodb.DoanhThuMongoRepo) repository.IDoanhThuRepository {
+	return repo
+}
+
+// ProvideAuditLogMongoRepo tạo AuditLog (audit trail thao tác đặc quyền) MongoDB repository
+func ProvideAuditLogMongoRepo(db *mongo.Database) *mongodb.AuditLogMongoRepo {
+	return mongodb.NewAuditLogMongoRepo(db)
+}
+
+// ProvideAuditLogRepository binds AuditLogMongoRepo to IAuditLogRepository interface
+func ProvideAuditLogRepository(repo *mongodb.AuditLogMongoRepo) repository.IAuditLogRepository {
+	return repo
+}
+
+// ProvideLichSuGiaMongoRepo tạo LichSuGia (lịch sử giá món) MongoDB repository
+func ProvideLichSuGiaMongoRepo(db *mongo.Database) *mongodb.LichSuGiaMongoRepo {
+	return mongodb.NewLichSuGiaMongoRepo(db)
+}
+
+// ProvideLichSuGiaRepository binds LichSuGiaMongoRepo to ILichSuGiaRepository interface
+func ProvideLichSuGiaRepository(repo *mongodb.LichSuGiaMongoRepo) repository.ILichSuGiaRepository {
+	return repo
+}
+
+// ProvideLichDoiGiaMongoRepo tạo LichDoiGia (lịch đổi giá đặt trước) MongoDB repository
+func ProvideLichDoiGiaMongoRepo(db *mongo.Database) *mongodb.LichDoiGiaMongoRepo {
+	return mongodb.NewLichDoiGiaMongoRepo(db)
+}
+
+// ProvideLichDoiGiaRepository binds LichDoiGiaMongoRepo to ILichDoiGiaRepository interface
+func ProvideLichDoiGiaRepository(repo *mongodb.LichDoiGiaMongoRepo) repository.ILichDoiGiaRepository {
+	return repo
+}
+
+// ProvideWebhookMySQLRepo tạo Webhook (endpoint của bên thứ ba) MySQL repository
+func ProvideWebhookMySQLRepo(db *sql.DB) *mysql.WebhookMySQLRepo {
+	return mysql.NewWebhookMySQLRepo(db)
+}
+
+// ProvideWebhookRepository binds WebhookMySQLRepo to IWebhookRepository interface
+func ProvideWebhookRepository(repo *mysql.WebhookMySQLRepo) repository.IWebhookRepository {
+	return repo
+}
+
+// ProvideWebhookDeliveryMongoRepo tạo WebhookDelivery (nhật ký gửi webhook) MongoDB repository
+func ProvideWebhookDeliveryMongoRepo(db *mongo.Database) *mongodb.WebhookDeliveryMongoRepo {
+	return mongodb.NewWebhookDeliveryMongoRepo(db)
+}
+
+// ProvideWebhookDeliveryRepository binds WebhookDeliveryMongoRepo to IWebhookDeliveryRepository interface
+func ProvideWebhookDeliveryRepository(repo *mongodb.WebhookDeliveryMongoRepo) repository.IWebhookDeliveryRepository {
+	return repo
+}
+
+// ProvideOutboxMySQLRepo tạo Outbox sự kiện MySQL repository (sự kiện của user, nhân viên...)
+func ProvideOutboxMySQLRepo(db *sql.DB) *mysql.OutboxMySQLRepo {
+	return mysql.NewOutboxMySQLRepo(db)
+}
+
+// ProvideOutboxMongoRepo tạo Outbox sự kiện MongoDB repository (sự kiện của order, món ăn...)
+func ProvideOutboxMongoRepo(db *mongo.Database) *mongodb.OutboxMongoRepo {
+	return mongodb.NewOutboxMongoRepo(db)
+}
+
+// ProvideProcessedEventMySQLRepo tạo ProcessedEvent MySQL repository (khóa idempotency của consumer)
+func ProvideProcessedEventMySQLRepo(db *sql.DB) *mysql.ProcessedEventMySQLRepo {
+	return mysql.NewProcessedEventMySQLRepo(db)
+}
+
+// ProvideProcessedEventRepository binds ProcessedEventMySQLRepo to IProcessedEventRepository interface
+func ProvideProcessedEventRepository(repo *mysql.ProcessedEventMySQLRepo) repository.IProcessedEventRepository {
+	return repo
+}
+
+// ProvideMySQLUnitOfWork tạo UnitOfWork cho transaction MySQL
+func ProvideMySQLUnitOfWork(conn *database.MySQLConnection) *mysql.MySQLUnitOfWork {
+	return mysql.NewMySQLUnitOfWork(conn)
+}
+
+// ProvideMongoUnitOfWork tạo UnitOfWork cho transaction MongoDB (cần replica set)
+func ProvideMongoUnitOfWork(conn *database.MongoDBConnection) *mongodb.MongoUnitOfWork {
+	return mongodb.NewMongoUnitOfWork(conn)
+}
@@ -0,0 +1,141 @@
+// Package providers chứa các provider functions cho Wire DI
+package providers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant_project/internal/app/scheduler"
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	infraservice "restaurant_project/internal/infrastructure/service"
+)
+
+// ProvideScheduler tạo Scheduler và đăng ký các tác vụ định kỳ
+// Scheduler.Enabled = false → không đăng ký tác vụ nào (instance chỉ phục vụ HTTP)
+func ProvideScheduler(
+	cfg *config.Config,
+	loc *time.Location,
+	store service.ScheduledTaskStore,
+	baoCaoUC *usecase.BaoCaoUseCase,
+	khoUC *usecase.KhoUseCase,
+	authUC *usecase.AuthUseCase,
+	monAnUC *usecase.MonAnUseCase,
+	xoaMemUC *usecase.XoaMemUseCase,
+	outboxRelay *infraservice.OutboxRelay,
+	processedEventRepo repository.IProcessedEventRepository,
+) (*scheduler.Scheduler, error) {
+	s := scheduler.New(store)
+	if !cfg.Scheduler.Enabled {
+		return s, nil
+	}
+
+	lichDoanhThu, err := scheduler.HangNgay(cfg.Scheduler.RevenueSnapshotAt, loc)
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_REVENUE_SNAPSHOT_AT: %w", err)
+	}
+	lichDonToken, err := scheduler.MoiKhoang(cfg.Scheduler.TokenCleanupInterval)
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_TOKEN_CLEANUP_INTERVAL: %w", err)
+	}
+	lichTonKho, err := scheduler.HangNgay(cfg.Scheduler.LowStockCheckAt, loc)
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_LOW_STOCK_CHECK_AT: %w", err)
+	}
+	lichDoiGia, err := scheduler.MoiKhoang(cfg.Scheduler.PriceChangeInterval)
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_PRICE_CHANGE_INTERVAL: %w", err)
+	}
+	lichDonXoaMem, err := scheduler.HangNgay(cfg.Scheduler.PurgeDeletedAt, loc)
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_PURGE_DELETED_AT: %w", err)
+	}
+	lichDonOutbox, err := scheduler.HangNgay(cfg.Scheduler.OutboxCleanupAt, loc)
+	if err != nil {
+		return nil, fmt.Errorf("SCHEDULER_OUTBOX_CLEANUP_AT: %w", err)
+	}
+
+	// Snapshot doanh thu ngày hôm trước
+	s.Them(scheduler.TacVu{
+		Ten:     "doanh_thu_ngay",
+		Lich:    lichDoanhThu,
+		Timeout: 10 * time.Minute,
+		Chay: func(ctx context.Context) (string, error) {
+			dt, err := baoCaoUC.ChupDoanhThuNgay(ctx, time.Now().In(loc).AddDate(0, 0, -1))
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("ngày %s: %d đơn, doanh thu %d", dt.Ngay, dt.SoDon, dt.DoanhThu), nil
+		},
+	})
+
+	// Dọn token xác thực email / đặt lại mật khẩu đã hết hạn
+	s.Them(scheduler.TacVu{
+		Ten:  "don_token_het_han",
+		Lich: lichDonToken,
+		Chay: func(ctx context.Context) (string, error) {
+			n, err := authUC.DonTokenHetHan(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("dọn %d token", n), nil
+		},
+	})
+
+	// Kiểm tra nguyên liệu dưới mức tồn tối thiểu
+	emailNhan := cfg.Scheduler.LowStockAlertEmail
+	s.Them(scheduler.TacVu{
+		Ten:  "kiem_tra_ton_kho",
+		Lich: lichTonKho,
+		Chay: func(ctx context.Context) (string, error) {
+			list, err := khoUC.KiemTraTonKho(ctx, emailNhan)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d nguyên liệu dưới mức tối thiểu", len(list)), nil
+		},
+	})
+
+	// Áp dụng các lịch đổi giá món đã tới hạn
+	s.Them(scheduler.TacVu{
+		Ten:  "ap_dung_lich_doi_gia",
+		Lich: lichDoiGia,
+		Chay: func(ctx context.Context) (string, error) {
+			// Lỗi một lịch không chặn lịch khác: vẫn ghi nhận số lịch đã áp dụng
+			n, err := monAnUC.ApDungLichDoiGia(ctx)
+			return fmt.Sprintf("áp dụng %d lịch đổi giá", n), err
+		},
+	})
+
+	// Xóa vĩnh viễn món ăn, khách hàng, nhân viên đã xóa mềm quá thời gian lưu giữ
+	thoiGianLuuGiu := cfg.Scheduler.SoftDeleteRetention
+	s.Them(scheduler.TacVu{
+		Ten:  "don_ban_ghi_da_xoa",
+		Lich: lichDonXoaMem,
+		Chay: func(ctx context.Context) (string, error) {
+			kq, err := xoaMemUC.DonBanGhiDaXoa(ctx, time.Now().Add(-thoiGianLuuGiu))
+			return fmt.Sprintf("xóa vĩnh viễn %d món, %d khách hàng, %d nhân viên", kq.MonAn, kq.KhachHang, kq.NhanVien), err
+		},
+	})
+
+	// Xóa sự kiện outbox đã phát và khóa idempotency của consumer quá thời gian lưu giữ
+	thoiGianGiuSuKien := cfg.Outbox.Retention
+	thoiGianGiuKhoa := cfg.Outbox.ProcessedTTL
+	s.Them(scheduler.TacVu{
+		Ten:  "don_outbox",
+		Lich: lichDonOutbox,
+		Chay: func(ctx context.Context) (string, error) {
+			n, err := outboxRelay.PurgeDaPhat(ctx, time.Now().Add(-thoiGianGiuSuKien))
+			if err != nil {
+				return fmt.Sprintf("xóa %d sự kiện đã phát", n), err
+			}
+			k, err := processedEventRepo.PurgeTruoc(ctx, time.Now().Add(-thoiGianGiuKhoa))
+			return fmt.Sprintf("xóa %d sự kiện đã phát, %d khóa idempotency", n, k), err
+		},
+	})
+
+	return s, nil
+}
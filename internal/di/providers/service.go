@@ -2,9 +2,18 @@
 package providers
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/repository"
 	"restaurant_project/internal/domain/service"
 	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/internal/infrastructure/persistence/mongodb"
+	"restaurant_project/internal/infrastructure/persistence/mysql"
 	infraservice "restaurant_project/internal/infrastructure/service"
+	"restaurant_project/pkg/netguard"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -33,9 +42,165 @@ func ProvideEmailVerificationService(
 	return infraservice.NewRedisEmailVerificationService(client, cfg.Middleware.EmailVerification)
 }
 
-// ProvideEmailService tạo EmailService (Console mode cho development)
+// ProvidePasswordResetService tạo PasswordResetService từ Redis client
+func ProvidePasswordResetService(
+	client *redis.Client,
+	cfg *config.Config,
+) service.PasswordResetService {
+	return infraservice.NewRedisPasswordResetService(client, cfg.Middleware.PasswordReset)
+}
+
+// ProvideSessionService tạo SessionService từ Redis client
+func ProvideSessionService(
+	client *redis.Client,
+	cfg *config.Config,
+) service.SessionService {
+	return infraservice.NewRedisSessionService(client, cfg.Middleware.TokenBlacklist)
+}
+
+// ProvideMFAChallengeService tạo MFAChallengeService từ Redis client
+func ProvideMFAChallengeService(
+	client *redis.Client,
+	cfg *config.Config,
+) service.MFAChallengeService {
+	return infraservice.NewRedisMFAChallengeService(client, cfg.Middleware.MFA)
+}
+
+// ProvideOIDCStateService tạo OIDCStateService từ Redis client
+func ProvideOIDCStateService(
+	client *redis.Client,
+	cfg *config.Config,
+) service.OIDCStateService {
+	return infraservice.NewRedisOIDCStateService(client, cfg.Middleware.OIDC)
+}
+
+// ProvideOIDCProviders tạo các OIDC provider đang bật
+func ProvideOIDCProviders(cfg *config.Config) ([]service.OIDCProvider, error) {
+	oidcCfg := cfg.Middleware.OIDC
+	var providers []service.OIDCProvider
+
+	if oidcCfg.Google.Enabled {
+		if oidcCfg.Google.ClientID == "" || oidcCfg.Google.ClientSecret == "" {
+			return nil, errors.New("OIDC_GOOGLE_ENABLED requires OIDC_GOOGLE_CLIENT_ID and OIDC_GOOGLE_CLIENT_SECRET")
+		}
+		providers = append(providers, infraservice.NewOIDCClient("google", oidcCfg.Google, oidcCfg.RedirectURL))
+	}
+
+	return providers, nil
+}
+
+// ProvideRedisJobQueue tạo job queue dùng Redis Streams
+// Runner gọi Start/Stop của queue cùng vòng đời HTTP server
+// Job phải kết thúc (timeout) trước khi bị coi là mồ côi, nếu không job đang chạy bị worker khác nhận lại và chạy hai lần
+func ProvideRedisJobQueue(
+	client *redis.Client,
+	cfg *config.Config,
+) (*infraservice.RedisJobQueue, error) {
+	if cfg.JobQueue.JobTimeout <= 0 || cfg.JobQueue.JobTimeout >= cfg.JobQueue.VisibilityTimeout {
+		return nil, fmt.Errorf("JOB_QUEUE_JOB_TIMEOUT (%s) must be positive and less than JOB_QUEUE_VISIBILITY_TIMEOUT (%s)",
+			cfg.JobQueue.JobTimeout, cfg.JobQueue.VisibilityTimeout)
+	}
+	return infraservice.NewRedisJobQueue(client, cfg.JobQueue), nil
+}
+
+// ProvideJobQueue binds RedisJobQueue to JobQueue interface
+func ProvideJobQueue(queue *infraservice.RedisJobQueue) service.JobQueue {
+	return queue
+}
+
+// ProvideEmailService tạo EmailService theo config, gửi qua job queue
+// Email.Enabled = true  → SMTPEmailService (gửi thật, template HTML + text)
+// Email.Enabled = false → ConsoleEmailService (log ra console cho development)
 func ProvideEmailService(
 	cfg *config.Config,
-) service.EmailService {
-	return infraservice.NewConsoleEmailService(cfg.Middleware.Email)
+	loc *time.Location,
+	client *redis.Client,
+	queue *infraservice.RedisJobQueue,
+) (service.EmailService, error) {
+	var sender service.EmailService
+	if !cfg.Middleware.Email.Enabled {
+		sender = infraservice.NewConsoleEmailService(cfg.Middleware.Email)
+	} else {
+		smtpSender, err := infraservice.NewSMTPEmailService(cfg.Middleware.Email, cfg.Middleware.PasswordReset.TokenTTL, loc)
+		if err != nil {
+			return nil, err
+		}
+		sender = smtpSender
+	}
+
+	return infraservice.NewQueuedEmailService(
+		client,
+		queue,
+		sender,
+		cfg.Middleware.EmailVerification.TokenTTL,
+		cfg.Middleware.PasswordReset.TokenTTL,
+	), nil
+}
+
+// ProvideQueuedWebhookSender tạo WebhookSender gửi webhook qua job queue (thử lại với backoff)
+func ProvideQueuedWebhookSender(
+	queue *infraservice.RedisJobQueue,
+	webhookRepo repository.IWebhookRepository,
+	deliveryRepo repository.IWebhookDeliveryRepository,
+	guard *netguard.Guard,
+	cfg *config.Config,
+) *infraservice.QueuedWebhookSender {
+	return infraservice.NewQueuedWebhookSender(queue, webhookRepo, deliveryRepo, guard, cfg.Webhook)
+}
+
+// ProvideWebhookURLGuard tạo guard chặn gửi webhook tới địa chỉ nội bộ (allow-list WEBHOOK_ALLOWED_NETWORKS)
+func ProvideWebhookURLGuard(cfg *config.Config) (*netguard.Guard, error) {
+	guard, err := netguard.New(cfg.Webhook.AllowedNetworks)
+	if err != nil {
+		return nil, fmt.Errorf("WEBHOOK_ALLOWED_NETWORKS: %w", err)
+	}
+	return guard, nil
+}
+
+// ProvideWebhookSender binds QueuedWebhookSender to WebhookSender interface
+func ProvideWebhookSender(sender *infraservice.QueuedWebhookSender) service.WebhookSender {
+	return sender
+}
+
+// ProvideScheduledTaskStore tạo store khóa phân tán và lịch sử chạy của scheduler
+func ProvideScheduledTaskStore(client *redis.Client, cfg *config.Config) service.ScheduledTaskStore {
+	return infraservice.NewRedisScheduledTaskStore(client, cfg.Scheduler)
+}
+
+// ProvideIdempotencyStore tạo store lưu response theo Idempotency-Key từ Redis client
+func ProvideIdempotencyStore(client *redis.Client) service.IdempotencyStore {
+	return infraservice.NewRedisIdempotencyStore(client)
+}
+
+// ProvideLocalEventDispatcher tạo dispatcher domain event trong process và đăng ký các consumer
+// Khóa idempotency nằm ở MySQL nên consumer chạy trong transaction của UnitOfWork MySQL
+func ProvideLocalEventDispatcher(
+	processed repository.IProcessedEventRepository,
+	uow *mysql.MySQLUnitOfWork,
+	consumer *usecase.SuKienConsumer,
+	webhookUC *usecase.WebhookUseCase,
+) *infraservice.LocalEventDispatcher {
+	dispatcher := infraservice.NewLocalEventDispatcher(processed, uow)
+	consumer.DangKy(dispatcher)
+	webhookUC.DangKy(dispatcher)
+	return dispatcher
+}
+
+// ProvideEventDispatcher binds LocalEventDispatcher to EventDispatcher interface
+func ProvideEventDispatcher(dispatcher *infraservice.LocalEventDispatcher) service.EventDispatcher {
+	return dispatcher
+}
+
+// ProvideOutboxRelay tạo relay phát sự kiện từ outbox MySQL và MongoDB
+// Runner gọi Start/Stop của relay cùng vòng đời HTTP server
+func ProvideOutboxRelay(
+	dispatcher service.EventDispatcher,
+	cfg *config.Config,
+	mysqlOutbox *mysql.OutboxMySQLRepo,
+	mongoOutbox *mongodb.OutboxMongoRepo,
+) *infraservice.OutboxRelay {
+	return infraservice.NewOutboxRelay(dispatcher, cfg.Outbox,
+		infraservice.OutboxStore{Ten: "mysql", Repo: mysqlOutbox},
+		infraservice.OutboxStore{Ten: "mongodb", Repo: mongoOutbox},
+	)
 }
@@ -2,20 +2,43 @@
 package providers
 
 import (
+	"fmt"
+	"time"
+
 	"restaurant_project/internal/application/usecase"
 	"restaurant_project/internal/domain/repository"
 	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
 	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/infrastructure/persistence/mongodb"
+	"restaurant_project/internal/infrastructure/persistence/mysql"
+	"restaurant_project/pkg/netguard"
 )
 
 // ProvideMonAnUseCase tạo MonAn use case
-func ProvideMonAnUseCase(repo repository.IMonAnRepository) *usecase.MonAnUseCase {
-	return usecase.NewMonAnUseCase(repo)
+func ProvideMonAnUseCase(
+	repo repository.IMonAnRepository,
+	lichSuGiaRepo repository.ILichSuGiaRepository,
+	lichDoiGiaRepo repository.ILichDoiGiaRepository,
+	auditRepo repository.IAuditLogRepository,
+	uow *mongodb.MongoUnitOfWork,
+	outboxRepo *mongodb.OutboxMongoRepo,
+	loc *time.Location,
+) *usecase.MonAnUseCase {
+	return usecase.NewMonAnUseCase(repo, lichSuGiaRepo, lichDoiGiaRepo, auditRepo, uow, outboxRepo, loc)
 }
 
 // ProvideUserUseCase tạo User use case
-func ProvideUserUseCase(repo repository.IUserRepository, tokenBlacklist service.TokenBlacklistService) *usecase.UserUseCase {
-	return usecase.NewUserUseCase(repo, tokenBlacklist)
+// User, hồ sơ NhanVien và outbox sự kiện user cùng nằm trong MySQL nên dùng UnitOfWork MySQL
+func ProvideUserUseCase(
+	repo repository.IUserRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	uow *mysql.MySQLUnitOfWork,
+	outboxRepo *mysql.OutboxMySQLRepo,
+	tokenBlacklist service.TokenBlacklistService,
+	auditRepo repository.IAuditLogRepository,
+) *usecase.UserUseCase {
+	return usecase.NewUserUseCase(repo, nhanVienRepo, uow, outboxRepo, tokenBlacklist, auditRepo)
 }
 
 // ProvideAuthUseCase tạo Auth use case
@@ -25,6 +48,144 @@ func ProvideAuthUseCase(
 	loginAttemptService service.LoginAttemptService,
 	emailVerificationService service.EmailVerificationService,
 	emailService service.EmailService,
-) *usecase.AuthUseCase {
-	return usecase.NewAuthUseCase(repo, jwtAuth, loginAttemptService, emailVerificationService, emailService)
+	passwordResetService service.PasswordResetService,
+	mfaRepo repository.IUserMFARepository,
+	mfaChallengeService service.MFAChallengeService,
+	sessionService service.SessionService,
+	identityRepo repository.IExternalIdentityRepository,
+	oidcProviders []service.OIDCProvider,
+	oidcStateService service.OIDCStateService,
+	permissionRepo repository.IPermissionRepository,
+	auditRepo repository.IAuditLogRepository,
+	cfg *config.Config,
+) (*usecase.AuthUseCase, error) {
+	mfaCfg := cfg.Middleware.MFA
+	if mfaCfg.RequiredMinRole != "" && !middleware.IsValidRole(mfaCfg.RequiredMinRole) {
+		return nil, fmt.Errorf("MFA_REQUIRED_MIN_ROLE: role %q không hợp lệ", mfaCfg.RequiredMinRole)
+	}
+
+	mfaPolicy := usecase.MFAPolicy{
+		Issuer:        mfaCfg.Issuer,
+		BatBuocTuRole: mfaCfg.RequiredMinRole,
+		ChallengeTTL:  mfaCfg.ChallengeTTL,
+		SoMaKhoiPhuc:  mfaCfg.RecoveryCodes,
+	}
+
+	return usecase.NewAuthUseCase(repo, jwtAuth, loginAttemptService, emailVerificationService, emailService, passwordResetService,
+		mfaRepo, mfaChallengeService, mfaPolicy, sessionService, identityRepo, oidcProviders, oidcStateService, permissionRepo, auditRepo), nil
+}
+
+// ProvideKhoUseCase tạo Kho (tồn kho nguyên liệu) use case
+// Tiêu hao khóa dòng nguyên liệu như nhận hàng nên dùng chung UnitOfWork MySQL
+func ProvideKhoUseCase(
+	repo repository.INguyenLieuRepository,
+	uow *mysql.MySQLUnitOfWork,
+	emailService service.EmailService,
+	auditRepo repository.IAuditLogRepository,
+) *usecase.KhoUseCase {
+	return usecase.NewKhoUseCase(repo, uow, emailService, auditRepo)
+}
+
+// ProvideMuaHangUseCase tạo MuaHang (nhà cung cấp + đơn đặt hàng) use case
+// Đơn đặt hàng và tồn kho cùng nằm trong MySQL nên nhận hàng dùng UnitOfWork MySQL
+func ProvideMuaHangUseCase(
+	nhaCungCapRepo repository.INhaCungCapRepository,
+	donDatHangRepo repository.IDonDatHangRepository,
+	nguyenLieuRepo repository.INguyenLieuRepository,
+	uow *mysql.MySQLUnitOfWork,
+	auditRepo repository.IAuditLogRepository,
+) *usecase.MuaHangUseCase {
+	return usecase.NewMuaHangUseCase(nhaCungCapRepo, donDatHangRepo, nguyenLieuRepo, uow, auditRepo)
+}
+
+// ProvideOrderUseCase tạo Order use case
+func ProvideOrderUseCase(
+	orderRepo repository.IOrderRepository,
+	monAnRepo repository.IMonAnRepository,
+	khuyenMaiRepo repository.IKhuyenMaiRepository,
+	maGiamGiaRepo repository.IMaGiamGiaRepository,
+	khachHangRepo repository.IKhachHangRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	emailService service.EmailService,
+	auditRepo repository.IAuditLogRepository,
+	uow *mongodb.MongoUnitOfWork,
+	outboxRepo *mongodb.OutboxMongoRepo,
+	loc *time.Location,
+) *usecase.OrderUseCase {
+	// Đơn hàng nằm trong MongoDB: sự kiện ghi vào outbox MongoDB cùng transaction
+	return usecase.NewOrderUseCase(orderRepo, monAnRepo, khuyenMaiRepo, maGiamGiaRepo, khachHangRepo, nhanVienRepo, emailService, auditRepo, uow, outboxRepo, loc)
+}
+
+// ProvideKhuyenMaiUseCase tạo KhuyenMai use case
+func ProvideKhuyenMaiUseCase(
+	repo repository.IKhuyenMaiRepository,
+	monAnRepo repository.IMonAnRepository,
+	auditRepo repository.IAuditLogRepository,
+) *usecase.KhuyenMaiUseCase {
+	return usecase.NewKhuyenMaiUseCase(repo, monAnRepo, auditRepo)
+}
+
+// ProvideMaGiamGiaUseCase tạo MaGiamGia (voucher) use case
+func ProvideMaGiamGiaUseCase(repo repository.IMaGiamGiaRepository, auditRepo repository.IAuditLogRepository) *usecase.MaGiamGiaUseCase {
+	return usecase.NewMaGiamGiaUseCase(repo, auditRepo)
+}
+
+// ProvideJobUseCase tạo Job use case (quản trị hàng đợi)
+func ProvideJobUseCase(jobQueue service.JobQueue, auditRepo repository.IAuditLogRepository) *usecase.JobUseCase {
+	return usecase.NewJobUseCase(jobQueue, auditRepo)
+}
+
+// ProvideBaoCaoUseCase tạo BaoCao (báo cáo doanh thu) use case
+func ProvideBaoCaoUseCase(
+	orderRepo repository.IOrderRepository,
+	doanhThuRepo repository.IDoanhThuRepository,
+	loc *time.Location,
+) *usecase.BaoCaoUseCase {
+	return usecase.NewBaoCaoUseCase(orderRepo, doanhThuRepo, loc)
+}
+
+// ProvideSchedulerUseCase tạo Scheduler use case (xem trạng thái tác vụ định kỳ)
+func ProvideSchedulerUseCase(store service.ScheduledTaskStore) *usecase.SchedulerUseCase {
+	return usecase.NewSchedulerUseCase(store)
+}
+
+// ProvidePermissionUseCase tạo Permission use case (quản lý quyền của role)
+func ProvidePermissionUseCase(repo repository.IPermissionRepository, auditRepo repository.IAuditLogRepository) *usecase.PermissionUseCase {
+	return usecase.NewPermissionUseCase(repo, auditRepo)
+}
+
+// ProvideAuditLogUseCase tạo AuditLog use case (tra cứu audit log)
+func ProvideAuditLogUseCase(repo repository.IAuditLogRepository) *usecase.AuditLogUseCase {
+	return usecase.NewAuditLogUseCase(repo)
+}
+
+// ProvideXoaMemUseCase tạo XoaMem use case (bản ghi đã xóa mềm)
+func ProvideXoaMemUseCase(
+	monAnRepo repository.IMonAnRepository,
+	khachHangRepo repository.IKhachHangRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	auditRepo repository.IAuditLogRepository,
+) *usecase.XoaMemUseCase {
+	return usecase.NewXoaMemUseCase(monAnRepo, khachHangRepo, nhanVienRepo, auditRepo)
+}
+
+// ProvideSuKienConsumer tạo các consumer domain event (điểm tích lũy, trạng thái đầu bếp, thu hồi token)
+func ProvideSuKienConsumer(
+	orderRepo repository.IOrderRepository,
+	khachHangRepo repository.IKhachHangRepository,
+	nhanVienRepo repository.INhanVienRepository,
+	tokenBlacklist service.TokenBlacklistService,
+) *usecase.SuKienConsumer {
+	return usecase.NewSuKienConsumer(orderRepo, khachHangRepo, nhanVienRepo, tokenBlacklist)
+}
+
+// ProvideWebhookUseCase tạo Webhook use case (quản lý webhook, nhật ký gửi, phát sự kiện tới webhook)
+func ProvideWebhookUseCase(
+	webhookRepo repository.IWebhookRepository,
+	deliveryRepo repository.IWebhookDeliveryRepository,
+	sender service.WebhookSender,
+	urlGuard *netguard.Guard,
+	auditRepo repository.IAuditLogRepository,
+) *usecase.WebhookUseCase {
+	return usecase.NewWebhookUseCase(webhookRepo, deliveryRepo, sender, urlGuard, auditRepo)
 }
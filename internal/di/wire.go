@@ -7,10 +7,12 @@ package di
 import (
 	"github.com/google/wire"
 
+	"restaurant_project/internal/app/scheduler"
 	"restaurant_project/internal/di/providers"
 	"restaurant_project/internal/infrastructure/config"
 	"restaurant_project/internal/infrastructure/database"
 	"restaurant_project/internal/infrastructure/migration"
+	infraservice "restaurant_project/internal/infrastructure/service"
 	"restaurant_project/internal/presentation/http/handler"
 )
 
@@ -23,7 +25,23 @@ var ServiceSet = wire.NewSet(
 	providers.ProvideLoginAttemptService,
 	providers.ProvideTokenBlacklistService,
 	providers.ProvideEmailVerificationService,
+	providers.ProvidePasswordResetService,
+	providers.ProvideMFAChallengeService,
+	providers.ProvideSessionService,
+	providers.ProvideOIDCStateService,
+	providers.ProvideOIDCProviders,
+	providers.ProvideRedisJobQueue,
+	providers.ProvideJobQueue,
 	providers.ProvideEmailService,
+	providers.ProvideScheduledTaskStore,
+	providers.ProvideScheduler,
+	providers.ProvideLocalEventDispatcher,
+	providers.ProvideEventDispatcher,
+	providers.ProvideOutboxRelay,
+	providers.ProvideQueuedWebhookSender,
+	providers.ProvideWebhookURLGuard,
+	providers.ProvideWebhookSender,
+	providers.ProvideIdempotencyStore,
 )
 
 // ============================================================
@@ -53,6 +71,7 @@ var ConfigSet = wire.NewSet(
 	providers.ProvideRedisConfig,
 	providers.ProvideServerConfig,
 	providers.ProvideMySQLConfig,
+	providers.ProvideRestaurantLocation,
 )
 
 // DatabaseSet chứa các providers cho Database layer
@@ -74,6 +93,46 @@ var RepositorySet = wire.NewSet(
 	providers.ProvideMonAnRepository,
 	providers.ProvideUserMySQLRepo,
 	providers.ProvideUserRepository,
+	providers.ProvideNguyenLieuMySQLRepo,
+	providers.ProvideNguyenLieuRepository,
+	providers.ProvideNhaCungCapMySQLRepo,
+	providers.ProvideNhaCungCapRepository,
+	providers.ProvideDonDatHangMySQLRepo,
+	providers.ProvideDonDatHangRepository,
+	providers.ProvideOrderMongoRepo,
+	providers.ProvideOrderRepository,
+	providers.ProvideKhuyenMaiMongoRepo,
+	providers.ProvideKhuyenMaiRepository,
+	providers.ProvideMaGiamGiaMySQLRepo,
+	providers.ProvideMaGiamGiaRepository,
+	providers.ProvideUserMFAMySQLRepo,
+	providers.ProvideUserMFARepository,
+	providers.ProvideExternalIdentityMySQLRepo,
+	providers.ProvideExternalIdentityRepository,
+	providers.ProvideKhachHangMySQLRepo,
+	providers.ProvideKhachHangRepository,
+	providers.ProvideNhanVienMySQLRepo,
+	providers.ProvideNhanVienRepository,
+	providers.ProvidePermissionMySQLRepo,
+	providers.ProvidePermissionRepository,
+	providers.ProvideDoanhThuMongoRepo,
+	providers.ProvideDoanhThuRepository,
+	providers.ProvideAuditLogMongoRepo,
+	providers.ProvideAuditLogRepository,
+	providers.ProvideLichSuGiaMongoRepo,
+	providers.ProvideLichSuGiaRepository,
+	providers.ProvideLichDoiGiaMongoRepo,
+	providers.ProvideLichDoiGiaRepository,
+	providers.ProvideOutboxMySQLRepo,
+	providers.ProvideOutboxMongoRepo,
+	providers.ProvideProcessedEventMySQLRepo,
+	providers.ProvideProcessedEventRepository,
+	providers.ProvideMySQLUnitOfWork,
+	providers.ProvideMongoUnitOfWork,
+	providers.ProvideWebhookMySQLRepo,
+	providers.ProvideWebhookRepository,
+	providers.ProvideWebhookDeliveryMongoRepo,
+	providers.ProvideWebhookDeliveryRepository,
 )
 
 // UseCaseSet chứa các providers cho UseCase layer
@@ -81,6 +140,19 @@ var UseCaseSet = wire.NewSet(
 	providers.ProvideMonAnUseCase,
 	providers.ProvideUserUseCase,
 	providers.ProvideAuthUseCase,
+	providers.ProvideKhoUseCase,
+	providers.ProvideMuaHangUseCase,
+	providers.ProvideOrderUseCase,
+	providers.ProvideKhuyenMaiUseCase,
+	providers.ProvideMaGiamGiaUseCase,
+	providers.ProvideJobUseCase,
+	providers.ProvideBaoCaoUseCase,
+	providers.ProvideSchedulerUseCase,
+	providers.ProvidePermissionUseCase,
+	providers.ProvideAuditLogUseCase,
+	providers.ProvideXoaMemUseCase,
+	providers.ProvideSuKienConsumer,
+	providers.ProvideWebhookUseCase,
 )
 
 // HandlerSet chứa các providers cho Handler layer
@@ -88,8 +160,22 @@ var HandlerSet = wire.NewSet(
 	providers.ProvideMonAnHandler,
 	providers.ProvideHealthHandler,
 	providers.ProvideSwaggerHandler,
+	providers.ProvideJWKSHandler,
 	providers.ProvideUserHandler,
 	providers.ProvideAuthHandler,
+	providers.ProvideNguyenLieuHandler,
+	providers.ProvideNhaCungCapHandler,
+	providers.ProvideDonDatHangHandler,
+	providers.ProvideOrderHandler,
+	providers.ProvideKhuyenMaiHandler,
+	providers.ProvideMaGiamGiaHandler,
+	providers.ProvideJobHandler,
+	providers.ProvideBaoCaoHandler,
+	providers.ProvideSchedulerHandler,
+	providers.ProvidePermissionHandler,
+	providers.ProvideAuditLogHandler,
+	providers.ProvideXoaMemHandler,
+	providers.ProvideWebhookHandler,
 )
 
 // ============================================================
@@ -98,15 +184,32 @@ var HandlerSet = wire.NewSet(
 
 // App chứa tất cả dependencies đã được inject
 type App struct {
-	Config           *config.Config
-	DBManager        *database.DBManager
-	MigrationManager *migration.MigrationManager
-	MonAnHandler     *handler.MonAnHandler
-	HealthHandler    *handler.HealthHandler
-	SwaggerHandler   *handler.SwaggerHandler
-	UserHandler      *handler.UserHandler
-	AuthHandler      *handler.AuthHandler
-	Middlewares      *providers.MiddlewareCollection
+	Config            *config.Config
+	DBManager         *database.DBManager
+	MigrationManager  *migration.MigrationManager
+	MonAnHandler      *handler.MonAnHandler
+	HealthHandler     *handler.HealthHandler
+	SwaggerHandler    *handler.SwaggerHandler
+	JWKSHandler       *handler.JWKSHandler
+	UserHandler       *handler.UserHandler
+	AuthHandler       *handler.AuthHandler
+	NguyenLieuHandler *handler.NguyenLieuHandler
+	NhaCungCapHandler *handler.NhaCungCapHandler
+	DonDatHangHandler *handler.DonDatHangHandler
+	OrderHandler      *handler.OrderHandler
+	KhuyenMaiHandler  *handler.KhuyenMaiHandler
+	MaGiamGiaHandler  *handler.MaGiamGiaHandler
+	JobHandler        *handler.JobHandler
+	BaoCaoHandler     *handler.BaoCaoHandler
+	SchedulerHandler  *handler.SchedulerHandler
+	PermissionHandler *handler.PermissionHandler
+	AuditLogHandler   *handler.AuditLogHandler
+	XoaMemHandler     *handler.XoaMemHandler
+	WebhookHandler    *handler.WebhookHandler
+	Middlewares       *providers.MiddlewareCollection
+	JobQueue          *infraservice.RedisJobQueue
+	OutboxRelay       *infraservice.OutboxRelay
+	Scheduler         *scheduler.Scheduler
 
 	// Internal connections (để cleanup)
 	MongoConn *database.MongoDBConnection
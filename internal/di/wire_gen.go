@@ -8,10 +8,12 @@ package di
 
 import (
 	"github.com/google/wire"
+	"restaurant_project/internal/app/scheduler"
 	"restaurant_project/internal/di/providers"
 	"restaurant_project/internal/infrastructure/config"
 	"restaurant_project/internal/infrastructure/database"
 	"restaurant_project/internal/infrastructure/migration"
+	infraservice "restaurant_project/internal/infrastructure/service"
 	"restaurant_project/internal/presentation/http/handler"
 )
 
@@ -38,40 +40,165 @@ func InitializeApp() (*App, error) {
 		return nil, err
 	}
 	database := providers.ProvideMongoDB(mongoDBConnection)
+	auditLogMongoRepo := providers.ProvideAuditLogMongoRepo(database)
+	iAuditLogRepository := providers.ProvideAuditLogRepository(auditLogMongoRepo)
+	lichSuGiaMongoRepo := providers.ProvideLichSuGiaMongoRepo(database)
+	iLichSuGiaRepository := providers.ProvideLichSuGiaRepository(lichSuGiaMongoRepo)
+	lichDoiGiaMongoRepo := providers.ProvideLichDoiGiaMongoRepo(database)
+	iLichDoiGiaRepository := providers.ProvideLichDoiGiaRepository(lichDoiGiaMongoRepo)
 	monAnMongoRepo := providers.ProvideMonAnMongoRepo(database)
 	client := providers.ProvideRedisClient(redisConnection)
 	redisCacheRepository := providers.ProvideRedisCacheRepository(client)
 	cachedMonAnRepository := providers.ProvideCachedMonAnRepository(monAnMongoRepo, redisCacheRepository)
 	iMonAnRepository := providers.ProvideMonAnRepository(cachedMonAnRepository)
-	monAnUseCase := providers.ProvideMonAnUseCase(iMonAnRepository)
+	location, err := providers.ProvideRestaurantLocation(config)
+	if err != nil {
+		return nil, err
+	}
+	mongoUnitOfWork := providers.ProvideMongoUnitOfWork(mongoDBConnection)
+	outboxMongoRepo := providers.ProvideOutboxMongoRepo(database)
+	monAnUseCase := providers.ProvideMonAnUseCase(iMonAnRepository, iLichSuGiaRepository, iLichDoiGiaRepository, iAuditLogRepository, mongoUnitOfWork, outboxMongoRepo, location)
 	monAnHandler := providers.ProvideMonAnHandler(monAnUseCase)
 	healthHandler := providers.ProvideHealthHandler(dbManager)
 	swaggerHandler := providers.ProvideSwaggerHandler()
 	userMySQLRepo := providers.ProvideUserMySQLRepo(db)
 	iUserRepository := providers.ProvideUserRepository(userMySQLRepo)
 	tokenBlacklistService := providers.ProvideTokenBlacklistService(client, config)
-	userUseCase := providers.ProvideUserUseCase(iUserRepository, tokenBlacklistService)
-	jwtAuthMiddleware := providers.ProvideJWTAuth(config, tokenBlacklistService)
-	userHandler := providers.ProvideUserHandler(userUseCase, jwtAuthMiddleware)
+	nhanVienMySQLRepo := providers.ProvideNhanVienMySQLRepo(db)
+	iNhanVienRepository := providers.ProvideNhanVienRepository(nhanVienMySQLRepo)
+	mySQLUnitOfWork := providers.ProvideMySQLUnitOfWork(mySQLConnection)
+	outboxMySQLRepo := providers.ProvideOutboxMySQLRepo(db)
+	userUseCase := providers.ProvideUserUseCase(iUserRepository, iNhanVienRepository, mySQLUnitOfWork, outboxMySQLRepo, tokenBlacklistService, iAuditLogRepository)
+	jwtAuthMiddleware, err := providers.ProvideJWTAuth(config, tokenBlacklistService)
+	if err != nil {
+		return nil, err
+	}
+	jwksHandler := providers.ProvideJWKSHandler(jwtAuthMiddleware)
 	loginAttemptService := providers.ProvideLoginAttemptService(client, config)
 	emailVerificationService := providers.ProvideEmailVerificationService(client, config)
-	emailService := providers.ProvideEmailService(config)
-	authUseCase := providers.ProvideAuthUseCase(iUserRepository, jwtAuthMiddleware, loginAttemptService, emailVerificationService, emailService)
+	redisJobQueue, err := providers.ProvideRedisJobQueue(client, config)
+	if err != nil {
+		return nil, err
+	}
+	emailService, err := providers.ProvideEmailService(config, location, client, redisJobQueue)
+	if err != nil {
+		return nil, err
+	}
+	passwordResetService := providers.ProvidePasswordResetService(client, config)
+	userMFAMySQLRepo := providers.ProvideUserMFAMySQLRepo(db)
+	iUserMFARepository := providers.ProvideUserMFARepository(userMFAMySQLRepo)
+	mfaChallengeService := providers.ProvideMFAChallengeService(client, config)
+	sessionService := providers.ProvideSessionService(client, config)
+	externalIdentityMySQLRepo := providers.ProvideExternalIdentityMySQLRepo(db)
+	iExternalIdentityRepository := providers.ProvideExternalIdentityRepository(externalIdentityMySQLRepo)
+	permissionMySQLRepo := providers.ProvidePermissionMySQLRepo(db)
+	iPermissionRepository := providers.ProvidePermissionRepository(permissionMySQLRepo)
+	v, err := providers.ProvideOIDCProviders(config)
+	if err != nil {
+		return nil, err
+	}
+	oidcStateService := providers.ProvideOIDCStateService(client, config)
+	authUseCase, err := providers.ProvideAuthUseCase(iUserRepository, jwtAuthMiddleware, loginAttemptService, emailVerificationService, emailService, passwordResetService, iUserMFARepository, mfaChallengeService, sessionService, iExternalIdentityRepository, v, oidcStateService, iPermissionRepository, iAuditLogRepository, config)
+	if err != nil {
+		return nil, err
+	}
+	userHandler := providers.ProvideUserHandler(userUseCase, authUseCase, jwtAuthMiddleware)
 	authHandler := providers.ProvideAuthHandler(authUseCase)
-	middlewareCollection := providers.ProvideMiddlewareCollection(config, jwtAuthMiddleware)
+	nguyenLieuMySQLRepo := providers.ProvideNguyenLieuMySQLRepo(db)
+	iNguyenLieuRepository := providers.ProvideNguyenLieuRepository(nguyenLieuMySQLRepo)
+	khoUseCase := providers.ProvideKhoUseCase(iNguyenLieuRepository, mySQLUnitOfWork, emailService, iAuditLogRepository)
+	nguyenLieuHandler := providers.ProvideNguyenLieuHandler(khoUseCase)
+	nhaCungCapMySQLRepo := providers.ProvideNhaCungCapMySQLRepo(db)
+	iNhaCungCapRepository := providers.ProvideNhaCungCapRepository(nhaCungCapMySQLRepo)
+	donDatHangMySQLRepo := providers.ProvideDonDatHangMySQLRepo(db)
+	iDonDatHangRepository := providers.ProvideDonDatHangRepository(donDatHangMySQLRepo)
+	muaHangUseCase := providers.ProvideMuaHangUseCase(iNhaCungCapRepository, iDonDatHangRepository, iNguyenLieuRepository, mySQLUnitOfWork, iAuditLogRepository)
+	nhaCungCapHandler := providers.ProvideNhaCungCapHandler(muaHangUseCase)
+	donDatHangHandler := providers.ProvideDonDatHangHandler(muaHangUseCase)
+	orderMongoRepo := providers.ProvideOrderMongoRepo(database)
+	iOrderRepository := providers.ProvideOrderRepository(orderMongoRepo)
+	khuyenMaiMongoRepo := providers.ProvideKhuyenMaiMongoRepo(database)
+	iKhuyenMaiRepository := providers.ProvideKhuyenMaiRepository(khuyenMaiMongoRepo)
+	maGiamGiaMySQLRepo := providers.ProvideMaGiamGiaMySQLRepo(db)
+	iMaGiamGiaRepository := providers.ProvideMaGiamGiaRepository(maGiamGiaMySQLRepo)
+	khachHangMySQLRepo := providers.ProvideKhachHangMySQLRepo(db)
+	iKhachHangRepository := providers.ProvideKhachHangRepository(khachHangMySQLRepo)
+	orderUseCase := providers.ProvideOrderUseCase(iOrderRepository, iMonAnRepository, iKhuyenMaiRepository, iMaGiamGiaRepository, iKhachHangRepository, iNhanVienRepository, emailService, iAuditLogRepository, mongoUnitOfWork, outboxMongoRepo, location)
+	orderHandler := providers.ProvideOrderHandler(orderUseCase)
+	khuyenMaiUseCase := providers.ProvideKhuyenMaiUseCase(iKhuyenMaiRepository, iMonAnRepository, iAuditLogRepository)
+	khuyenMaiHandler := providers.ProvideKhuyenMaiHandler(khuyenMaiUseCase)
+	maGiamGiaUseCase := providers.ProvideMaGiamGiaUseCase(iMaGiamGiaRepository, iAuditLogRepository)
+	maGiamGiaHandler := providers.ProvideMaGiamGiaHandler(maGiamGiaUseCase)
+	jobQueue := providers.ProvideJobQueue(redisJobQueue)
+	jobUseCase := providers.ProvideJobUseCase(jobQueue, iAuditLogRepository)
+	jobHandler := providers.ProvideJobHandler(jobUseCase)
+	doanhThuMongoRepo := providers.ProvideDoanhThuMongoRepo(database)
+	iDoanhThuRepository := providers.ProvideDoanhThuRepository(doanhThuMongoRepo)
+	baoCaoUseCase := providers.ProvideBaoCaoUseCase(iOrderRepository, iDoanhThuRepository, location)
+	baoCaoHandler := providers.ProvideBaoCaoHandler(baoCaoUseCase)
+	scheduledTaskStore := providers.ProvideScheduledTaskStore(client, config)
+	schedulerUseCase := providers.ProvideSchedulerUseCase(scheduledTaskStore)
+	schedulerHandler := providers.ProvideSchedulerHandler(schedulerUseCase)
+	permissionUseCase := providers.ProvidePermissionUseCase(iPermissionRepository, iAuditLogRepository)
+	permissionHandler := providers.ProvidePermissionHandler(permissionUseCase)
+	auditLogUseCase := providers.ProvideAuditLogUseCase(iAuditLogRepository)
+	auditLogHandler := providers.ProvideAuditLogHandler(auditLogUseCase)
+	xoaMemUseCase := providers.ProvideXoaMemUseCase(iMonAnRepository, iKhachHangRepository, iNhanVienRepository, iAuditLogRepository)
+	xoaMemHandler := providers.ProvideXoaMemHandler(xoaMemUseCase)
+	webhookMySQLRepo := providers.ProvideWebhookMySQLRepo(db)
+	iWebhookRepository := providers.ProvideWebhookRepository(webhookMySQLRepo)
+	webhookDeliveryMongoRepo := providers.ProvideWebhookDeliveryMongoRepo(database)
+	iWebhookDeliveryRepository := providers.ProvideWebhookDeliveryRepository(webhookDeliveryMongoRepo)
+	guard, err := providers.ProvideWebhookURLGuard(config)
+	if err != nil {
+		return nil, err
+	}
+	queuedWebhookSender := providers.ProvideQueuedWebhookSender(redisJobQueue, iWebhookRepository, iWebhookDeliveryRepository, guard, config)
+	webhookSender := providers.ProvideWebhookSender(queuedWebhookSender)
+	webhookUseCase := providers.ProvideWebhookUseCase(iWebhookRepository, iWebhookDeliveryRepository, webhookSender, guard, iAuditLogRepository)
+	webhookHandler := providers.ProvideWebhookHandler(webhookUseCase)
+	idempotencyStore := providers.ProvideIdempotencyStore(client)
+	middlewareCollection := providers.ProvideMiddlewareCollection(config, jwtAuthMiddleware, idempotencyStore)
+	suKienConsumer := providers.ProvideSuKienConsumer(iOrderRepository, iKhachHangRepository, iNhanVienRepository, tokenBlacklistService)
+	processedEventMySQLRepo := providers.ProvideProcessedEventMySQLRepo(db)
+	iProcessedEventRepository := providers.ProvideProcessedEventRepository(processedEventMySQLRepo)
+	localEventDispatcher := providers.ProvideLocalEventDispatcher(iProcessedEventRepository, mySQLUnitOfWork, suKienConsumer, webhookUseCase)
+	eventDispatcher := providers.ProvideEventDispatcher(localEventDispatcher)
+	outboxRelay := providers.ProvideOutboxRelay(eventDispatcher, config, outboxMySQLRepo, outboxMongoRepo)
+	schedulerScheduler, err := providers.ProvideScheduler(config, location, scheduledTaskStore, baoCaoUseCase, khoUseCase, authUseCase, monAnUseCase, xoaMemUseCase, outboxRelay, iProcessedEventRepository)
+	if err != nil {
+		return nil, err
+	}
 	app := &App{
-		Config:           config,
-		DBManager:        dbManager,
-		MigrationManager: migrationManager,
-		MonAnHandler:     monAnHandler,
-		HealthHandler:    healthHandler,
-		SwaggerHandler:   swaggerHandler,
-		UserHandler:      userHandler,
-		AuthHandler:      authHandler,
-		Middlewares:      middlewareCollection,
-		MongoConn:        mongoDBConnection,
-		RedisConn:        redisConnection,
-		MySQLConn:        mySQLConnection,
+		Config:            config,
+		DBManager:         dbManager,
+		MigrationManager:  migrationManager,
+		MonAnHandler:      monAnHandler,
+		HealthHandler:     healthHandler,
+		SwaggerHandler:    swaggerHandler,
+		JWKSHandler:       jwksHandler,
+		UserHandler:       userHandler,
+		AuthHandler:       authHandler,
+		NguyenLieuHandler: nguyenLieuHandler,
+		NhaCungCapHandler: nhaCungCapHandler,
+		DonDatHangHandler: donDatHangHandler,
+		OrderHandler:      orderHandler,
+		KhuyenMaiHandler:  khuyenMaiHandler,
+		MaGiamGiaHandler:  maGiamGiaHandler,
+		JobHandler:        jobHandler,
+		BaoCaoHandler:     baoCaoHandler,
+		SchedulerHandler:  schedulerHandler,
+		PermissionHandler: permissionHandler,
+		AuditLogHandler:   auditLogHandler,
+		XoaMemHandler:     xoaMemHandler,
+		WebhookHandler:    webhookHandler,
+		Middlewares:       middlewareCollection,
+		JobQueue:          redisJobQueue,
+		OutboxRelay:       outboxRelay,
+		Scheduler:         schedulerScheduler,
+		MongoConn:         mongoDBConnection,
+		RedisConn:         redisConnection,
+		MySQLConn:         mySQLConnection,
 	}
 	return app, nil
 }
@@ -79,7 +206,7 @@ func InitializeApp() (*App, error) {
 // wire.go:
 
 // ServiceSet chứa các providers cho Domain Service layer
-var ServiceSet = wire.NewSet(providers.ProvideLoginAttemptService, providers.ProvideTokenBlacklistService, providers.ProvideEmailVerificationService, providers.ProvideEmailService)
+var ServiceSet = wire.NewSet(providers.ProvideLoginAttemptService, providers.ProvideTokenBlacklistService, providers.ProvideEmailVerificationService, providers.ProvidePasswordResetService, providers.ProvideMFAChallengeService, providers.ProvideSessionService, providers.ProvideOIDCStateService, providers.ProvideOIDCProviders, providers.ProvideRedisJobQueue, providers.ProvideJobQueue, providers.ProvideEmailService, providers.ProvideScheduledTaskStore, providers.ProvideScheduler, providers.ProvideLocalEventDispatcher, providers.ProvideEventDispatcher, providers.ProvideOutboxRelay, providers.ProvideQueuedWebhookSender, providers.ProvideWebhookURLGuard, providers.ProvideWebhookSender, providers.ProvideIdempotencyStore)
 
 // MiddlewareSet chứa các providers cho Middleware layer
 var MiddlewareSet = wire.NewSet(providers.ProvideJWTAuth, providers.ProvideMiddlewareCollection)
@@ -88,31 +215,48 @@ var MiddlewareSet = wire.NewSet(providers.ProvideJWTAuth, providers.ProvideMiddl
 var MigrationSet = wire.NewSet(providers.ProvideMySQLMigrator, providers.ProvideMigrationManager)
 
 // ConfigSet chứa các providers cho Config layer
-var ConfigSet = wire.NewSet(providers.ProvideConfig, providers.ProvideMongoDBConfig, providers.ProvideRedisConfig, providers.ProvideServerConfig, providers.ProvideMySQLConfig)
+var ConfigSet = wire.NewSet(providers.ProvideConfig, providers.ProvideMongoDBConfig, providers.ProvideRedisConfig, providers.ProvideServerConfig, providers.ProvideMySQLConfig, providers.ProvideRestaurantLocation)
 
 // DatabaseSet chứa các providers cho Database layer
 var DatabaseSet = wire.NewSet(providers.ProvideMongoDBConnection, providers.ProvideRedisConnection, providers.ProvideMySQLConnection, providers.ProvideDBManager, providers.ProvideMongoDB, providers.ProvideRedisClient, providers.ProvideMySQLDB)
 
 // RepositorySet chứa các providers cho Repository layer
-var RepositorySet = wire.NewSet(providers.ProvideMonAnMongoRepo, providers.ProvideRedisCacheRepository, providers.ProvideCachedMonAnRepository, providers.ProvideMonAnRepository, providers.ProvideUserMySQLRepo, providers.ProvideUserRepository)
+var RepositorySet = wire.NewSet(providers.ProvideMonAnMongoRepo, providers.ProvideRedisCacheRepository, providers.ProvideCachedMonAnRepository, providers.ProvideMonAnRepository, providers.ProvideUserMySQLRepo, providers.ProvideUserRepository, providers.ProvideNguyenLieuMySQLRepo, providers.ProvideNguyenLieuRepository, providers.ProvideNhaCungCapMySQLRepo, providers.ProvideNhaCungCapRepository, providers.ProvideDonDatHangMySQLRepo, providers.ProvideDonDatHangRepository, providers.ProvideOrderMongoRepo, providers.ProvideOrderRepository, providers.ProvideKhuyenMaiMongoRepo, providers.ProvideKhuyenMaiRepository, providers.ProvideMaGiamGiaMySQLRepo, providers.ProvideMaGiamGiaRepository, providers.ProvideUserMFAMySQLRepo, providers.ProvideUserMFARepository, providers.ProvideExternalIdentityMySQLRepo, providers.ProvideExternalIdentityRepository, providers.ProvideKhachHangMySQLRepo, providers.ProvideKhachHangRepository, providers.ProvideNhanVienMySQLRepo, providers.ProvideNhanVienRepository, providers.ProvidePermissionMySQLRepo, providers.ProvidePermissionRepository, providers.ProvideDoanhThuMongoRepo, providers.ProvideDoanhThuRepository, providers.ProvideAuditLogMongoRepo, providers.ProvideAuditLogRepository, providers.ProvideOutboxMySQLRepo, providers.ProvideOutboxMongoRepo, providers.ProvideProcessedEventMySQLRepo, providers.ProvideProcessedEventRepository, providers.ProvideMySQLUnitOfWork, providers.ProvideMongoUnitOfWork, providers.ProvideWebhookMySQLRepo, providers.ProvideWebhookRepository, providers.ProvideWebhookDeliveryMongoRepo, providers.ProvideWebhookDeliveryRepository)
 
 // UseCaseSet chứa các providers cho UseCase layer
-var UseCaseSet = wire.NewSet(providers.ProvideMonAnUseCase, providers.ProvideUserUseCase, providers.ProvideAuthUseCase)
+var UseCaseSet = wire.NewSet(providers.ProvideMonAnUseCase, providers.ProvideUserUseCase, providers.ProvideAuthUseCase, providers.ProvideKhoUseCase, providers.ProvideMuaHangUseCase, providers.ProvideOrderUseCase, providers.ProvideKhuyenMaiUseCase, providers.ProvideMaGiamGiaUseCase, providers.ProvideJobUseCase, providers.ProvideBaoCaoUseCase, providers.ProvideSchedulerUseCase, providers.ProvidePermissionUseCase, providers.ProvideAuditLogUseCase, providers.ProvideXoaMemUseCase, providers.ProvideSuKienConsumer, providers.ProvideWebhookUseCase)
 
 // HandlerSet chứa các providers cho Handler layer
-var HandlerSet = wire.NewSet(providers.ProvideMonAnHandler, providers.ProvideHealthHandler, providers.ProvideSwaggerHandler, providers.ProvideUserHandler, providers.ProvideAuthHandler)
+var HandlerSet = wire.NewSet(providers.ProvideMonAnHandler, providers.ProvideHealthHandler, providers.ProvideSwaggerHandler, providers.ProvideJWKSHandler, providers.ProvideUserHandler, providers.ProvideAuthHandler, providers.ProvideNguyenLieuHandler, providers.ProvideNhaCungCapHandler, providers.ProvideDonDatHangHandler, providers.ProvideOrderHandler, providers.ProvideKhuyenMaiHandler, providers.ProvideMaGiamGiaHandler, providers.ProvideJobHandler, providers.ProvideBaoCaoHandler, providers.ProvideSchedulerHandler, providers.ProvidePermissionHandler, providers.ProvideAuditLogHandler, providers.ProvideXoaMemHandler, providers.ProvideWebhookHandler)
 
 // App chứa tất cả dependencies đã được inject
 type App struct {
-	Config           *config.Config
-	DBManager        *database.DBManager
-	MigrationManager *migration.MigrationManager
-	MonAnHandler     *handler.MonAnHandler
-	HealthHandler    *handler.HealthHandler
-	SwaggerHandler   *handler.SwaggerHandler
-	UserHandler      *handler.UserHandler
-	AuthHandler      *handler.AuthHandler
-	Middlewares      *providers.MiddlewareCollection
+	Config            *config.Config
+	DBManager         *database.DBManager
+	MigrationManager  *migration.MigrationManager
+	MonAnHandler      *handler.MonAnHandler
+	HealthHandler     *handler.HealthHandler
+	SwaggerHandler    *handler.SwaggerHandler
+	JWKSHandler       *handler.JWKSHandler
+	UserHandler       *handler.UserHandler
+	AuthHandler       *handler.AuthHandler
+	NguyenLieuHandler *handler.NguyenLieuHandler
+	NhaCungCapHandler *handler.NhaCungCapHandler
+	DonDatHangHandler *handler.DonDatHangHandler
+	OrderHandler      *handler.OrderHandler
+	KhuyenMaiHandler  *handler.KhuyenMaiHandler
+	MaGiamGiaHandler  *handler.MaGiamGiaHandler
+	JobHandler        *handler.JobHandler
+	BaoCaoHandler     *handler.BaoCaoHandler
+	SchedulerHandler  *handler.SchedulerHandler
+	PermissionHandler *handler.PermissionHandler
+	AuditLogHandler   *handler.AuditLogHandler
+	XoaMemHandler     *handler.XoaMemHandler
+	WebhookHandler    *handler.WebhookHandler
+	Middlewares       *providers.MiddlewareCollection
+	JobQueue          *infraservice.RedisJobQueue
+	OutboxRelay       *infraservice.OutboxRelay
+	Scheduler         *scheduler.Scheduler
 
 	// Internal connections (để cleanup)
 	MongoConn *database.MongoDBConnection
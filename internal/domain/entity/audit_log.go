@@ -0,0 +1,84 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"reflect"
+	"time"
+)
+
+// Loại đối tượng bị tác động trong audit log
+const (
+	AuditDoiTuongMonAn      = "mon_an"
+	AuditDoiTuongUser       = "user"
+	AuditDoiTuongSession    = "session"
+	AuditDoiTuongRole       = "role"
+	AuditDoiTuongOrder      = "order"
+	AuditDoiTuongKhuyenMai  = "khuyen_mai"
+	AuditDoiTuongMaGiamGia  = "ma_giam_gia"
+	AuditDoiTuongNguyenLieu = "nguyen_lieu"
+	AuditDoiTuongNhaCungCap = "nha_cung_cap"
+	AuditDoiTuongDonDatHang = "don_dat_hang"
+	AuditDoiTuongJob        = "job"
+	AuditDoiTuongKhachHang  = "khach_hang"
+	AuditDoiTuongNhanVien   = "nhan_vien"
+	AuditDoiTuongWebhook    = "webhook"
+)
+
+// AuditActorHeThong là actor của thao tác không đến từ request đã xác thực (scheduler, background job)
+const AuditActorHeThong = "system"
+
+// AuditLog là một bản ghi thao tác đặc quyền: ai làm gì, trên đối tượng nào, thay đổi ra sao
+// Chỉ ghi thêm, không sửa hay xóa
+type AuditLog struct {
+	ID         string
+	ActorID    string         // User thực hiện (AuditActorHeThong nếu không có)
+	ActorRole  string         // Role của actor lúc thực hiện
+	HanhDong   string         // Thao tác, dạng <đối tượng>.<hành động> (vd: mon_an.cap_nhat_gia)
+	DoiTuong   string         // Loại đối tượng (AuditDoiTuong*)
+	DoiTuongID string         // ID đối tượng
+	TruocKhi   map[string]any // Giá trị trước thao tác của các trường thay đổi (nil = tạo mới)
+	SauKhi     map[string]any // Giá trị sau thao tác của các trường thay đổi (nil = đã xóa)
+	RequestID  string
+	IP         string
+	ThoiGian   time.Time
+}
+
+// NewAuditLog tạo bản ghi audit log, chỉ giữ lại các trường có thay đổi giữa truoc và sau
+// truoc = nil (tạo mới) hoặc sau = nil (xóa) thì giữ nguyên toàn bộ snapshot còn lại
+func NewAuditLog(id, hanhDong, doiTuong, doiTuongID string, truoc, sau map[string]any) *AuditLog {
+	truocKhi, sauKhi := TinhThayDoi(truoc, sau)
+	return &AuditLog{
+		ID:         id,
+		ActorID:    AuditActorHeThong,
+		HanhDong:   hanhDong,
+		DoiTuong:   doiTuong,
+		DoiTuongID: doiTuongID,
+		TruocKhi:   truocKhi,
+		SauKhi:     sauKhi,
+		ThoiGian:   time.Now(),
+	}
+}
+
+// TinhThayDoi so sánh hai snapshot và trả về giá trị trước/sau của các trường khác nhau
+func TinhThayDoi(truoc, sau map[string]any) (map[string]any, map[string]any) {
+	if truoc == nil || sau == nil {
+		return truoc, sau
+	}
+
+	truocKhi := make(map[string]any)
+	sauKhi := make(map[string]any)
+	for k, v := range sau {
+		cu, ok := truoc[k]
+		if !ok || !reflect.DeepEqual(cu, v) {
+			truocKhi[k] = cu
+			sauKhi[k] = v
+		}
+	}
+	for k, cu := range truoc {
+		if _, ok := sau[k]; !ok {
+			truocKhi[k] = cu
+			sauKhi[k] = nil
+		}
+	}
+	return truocKhi, sauKhi
+}
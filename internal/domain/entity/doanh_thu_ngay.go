@@ -0,0 +1,41 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import "time"
+
+// DoanhThuNgay là snapshot doanh thu của một ngày (theo múi giờ nhà hàng)
+// Được chụp mỗi đêm cho ngày hôm trước; chụp lại cùng ngày sẽ ghi đè snapshot cũ
+type DoanhThuNgay struct {
+	Ngay           string    // Ngày theo múi giờ nhà hàng (YYYY-MM-DD)
+	SoDon          int       // Tổng số đơn đặt trong ngày
+	SoDonHoanThanh int       // Số đơn hoàn thành
+	SoDonHuy       int       // Số đơn bị hủy
+	TongTien       int64     // Tổng tiền trước giảm giá của đơn hoàn thành
+	GiamGia        int64     // Tổng giảm giá của đơn hoàn thành
+	DoanhThu       int64     // Tổng tiền thực thu của đơn hoàn thành
+	ChupLuc        time.Time // Thời điểm chụp snapshot
+}
+
+// TinhDoanhThuNgay tổng hợp snapshot từ các đơn đặt trong ngày
+// Chỉ đơn hoàn thành được tính vào doanh thu; đơn đang xử lý chỉ được đếm vào SoDon
+func TinhDoanhThuNgay(ngay string, orders []*Order) *DoanhThuNgay {
+	dt := &DoanhThuNgay{
+		Ngay:    ngay,
+		SoDon:   len(orders),
+		ChupLuc: time.Now(),
+	}
+
+	for _, o := range orders {
+		switch o.TrangThai {
+		case OrderHoanThanh:
+			dt.SoDonHoanThanh++
+			dt.TongTien += o.TongTien
+			dt.GiamGia += o.GiamGia
+			dt.DoanhThu += o.TienThanhToan
+		case OrderDaHuy:
+			dt.SoDonHuy++
+		}
+	}
+
+	return dt
+}
@@ -0,0 +1,184 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// TrangThaiDonDatHang định nghĩa các trạng thái của đơn đặt hàng (purchase order)
+type TrangThaiDonDatHang string
+
+const (
+	DonDatHangNhap        TrangThaiDonDatHang = "nhap"          // Bản nháp (draft)
+	DonDatHangDaGui       TrangThaiDonDatHang = "da_gui"        // Đã gửi nhà cung cấp
+	DonDatHangNhanMotPhan TrangThaiDonDatHang = "nhan_mot_phan" // Đã nhận một phần
+	DonDatHangDaNhan      TrangThaiDonDatHang = "da_nhan"       // Đã nhận đủ
+	DonDatHangDaHuy       TrangThaiDonDatHang = "da_huy"        // Đã hủy
+)
+
+// DonDatHangItem đại diện cho một dòng nguyên liệu trong đơn đặt hàng
+type DonDatHangItem struct {
+	NguyenLieuID  string  // ID nguyên liệu
+	TenNguyenLieu string  // Tên nguyên liệu (snapshot)
+	SoLuongDat    float64 // Số lượng đặt
+	SoLuongDaNhan float64 // Số lượng đã nhận
+	DonGia        int64   // Đơn giá dự kiến (VND/đơn vị)
+}
+
+// ConThieu trả về số lượng còn chưa nhận
+func (i *DonDatHangItem) ConThieu() float64 {
+	conThieu := i.SoLuongDat - i.SoLuongDaNhan
+	if conThieu < 0 {
+		return 0
+	}
+	return conThieu
+}
+
+// DonDatHang là Entity đại diện cho đơn đặt hàng nguyên liệu từ nhà cung cấp
+// Lưu trong MySQL vì nhận hàng phải cập nhật tồn kho trong cùng transaction
+type DonDatHang struct {
+	ID           string              // UUID
+	NhaCungCapID string              // FK -> NhaCungCap.ID
+	NguoiTaoID   string              // User tạo đơn
+	TrangThai    TrangThaiDonDatHang // Trạng thái hiện tại
+	Items        []DonDatHangItem    // Danh sách nguyên liệu
+	GhiChu       string              // Ghi chú
+	NgayTao      time.Time           // Ngày tạo
+	NgayGui      *time.Time          // Ngày gửi cho nhà cung cấp (nullable)
+	NgayNhan     *time.Time          // Ngày nhận đủ hàng (nullable)
+	NgayCapNhat  time.Time           // Ngày cập nhật cuối
+}
+
+// NewDonDatHang tạo một DonDatHang mới ở trạng thái nháp
+func NewDonDatHang(id, nhaCungCapID, nguoiTaoID string) (*DonDatHang, error) {
+	if nhaCungCapID == "" {
+		return nil, errors.New("nhà cung cấp không được để trống")
+	}
+
+	now := time.Now()
+	return &DonDatHang{
+		ID:           id,
+		NhaCungCapID: nhaCungCapID,
+		NguoiTaoID:   nguoiTaoID,
+		TrangThai:    DonDatHangNhap,
+		Items:        make([]DonDatHangItem, 0),
+		NgayTao:      now,
+		NgayCapNhat:  now,
+	}, nil
+}
+
+// ThemNguyenLieu thêm một dòng nguyên liệu (chỉ khi còn là bản nháp)
+func (d *DonDatHang) ThemNguyenLieu(nguyenLieuID, tenNguyenLieu string, soLuong float64, donGia int64) error {
+	if d.TrangThai != DonDatHangNhap {
+		return errors.New("chỉ có thể sửa đơn đặt hàng ở trạng thái nháp")
+	}
+	if soLuong <= 0 {
+		return errors.New("số lượng đặt phải lớn hơn 0")
+	}
+	if donGia < 0 {
+		return errors.New("đơn giá không được âm")
+	}
+
+	for i := range d.Items {
+		if d.Items[i].NguyenLieuID == nguyenLieuID {
+			return errors.New("nguyên liệu đã có trong đơn đặt hàng")
+		}
+	}
+
+	d.Items = append(d.Items, DonDatHangItem{
+		NguyenLieuID:  nguyenLieuID,
+		TenNguyenLieu: tenNguyenLieu,
+		SoLuongDat:    soLuong,
+		DonGia:        donGia,
+	})
+	d.NgayCapNhat = time.Now()
+	return nil
+}
+
+// Gui chuyển đơn từ nháp sang đã gửi
+func (d *DonDatHang) Gui() error {
+	if d.TrangThai != DonDatHangNhap {
+		return errors.New("chỉ có thể gửi đơn đặt hàng ở trạng thái nháp")
+	}
+	if len(d.Items) == 0 {
+		return errors.New("đơn đặt hàng chưa có nguyên liệu")
+	}
+
+	now := time.Now()
+	d.TrangThai = DonDatHangDaGui
+	d.NgayGui = &now
+	d.NgayCapNhat = now
+	return nil
+}
+
+// NhanHang ghi nhận số lượng nhận được cho một nguyên liệu
+// Tự động chuyển trạng thái sang nhận một phần hoặc đã nhận đủ
+func (d *DonDatHang) NhanHang(nguyenLieuID string, soLuong float64) (*DonDatHangItem, error) {
+	if d.TrangThai != DonDatHangDaGui && d.TrangThai != DonDatHangNhanMotPhan {
+		return nil, errors.New("chỉ có thể nhận hàng cho đơn đã gửi")
+	}
+	if soLuong <= 0 {
+		return nil, errors.New("số lượng nhận phải lớn hơn 0")
+	}
+
+	var item *DonDatHangItem
+	for i := range d.Items {
+		if d.Items[i].NguyenLieuID == nguyenLieuID {
+			item = &d.Items[i]
+			break
+		}
+	}
+	if item == nil {
+		return nil, errors.New("nguyên liệu không có trong đơn đặt hàng")
+	}
+
+	item.SoLuongDaNhan += soLuong
+	d.capNhatTrangThaiNhan()
+	return item, nil
+}
+
+// capNhatTrangThaiNhan tính lại trạng thái dựa trên số lượng đã nhận
+func (d *DonDatHang) capNhatTrangThaiNhan() {
+	now := time.Now()
+	daNhanDu := true
+	for _, item := range d.Items {
+		if item.ConThieu() > 0 {
+			daNhanDu = false
+			break
+		}
+	}
+
+	if daNhanDu {
+		d.TrangThai = DonDatHangDaNhan
+		d.NgayNhan = &now
+	} else {
+		d.TrangThai = DonDatHangNhanMotPhan
+	}
+	d.NgayCapNhat = now
+}
+
+// Huy hủy đơn đặt hàng (không thể hủy khi đã nhận hàng)
+func (d *DonDatHang) Huy() error {
+	if d.TrangThai != DonDatHangNhap && d.TrangThai != DonDatHangDaGui {
+		return errors.New("không thể hủy đơn đặt hàng đã nhận hàng")
+	}
+
+	d.TrangThai = DonDatHangDaHuy
+	d.NgayCapNhat = time.Now()
+	return nil
+}
+
+// TinhTongTien tính tổng giá trị đơn theo số lượng đặt
+func (d *DonDatHang) TinhTongTien() int64 {
+	var tong float64
+	for _, item := range d.Items {
+		tong += item.SoLuongDat * float64(item.DonGia)
+	}
+	return int64(tong)
+}
+
+// DangCho kiểm tra đơn còn đang chờ nhận hàng không
+func (d *DonDatHang) DangCho() bool {
+	return d.TrangThai == DonDatHangDaGui || d.TrangThai == DonDatHangNhanMotPhan
+}
@@ -0,0 +1,28 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import "time"
+
+// ExternalIdentity là tài khoản bên ngoài (OpenID Connect) đã liên kết với một user
+// Nhận diện bằng cặp (Provider, Subject), không dựa vào email vì email có thể đổi
+type ExternalIdentity struct {
+	ID              string     // UUID
+	UserID          string     // User sở hữu
+	Provider        string     // Tên provider (google)
+	Subject         string     // Claim sub của provider
+	Email           string     // Email của tài khoản bên ngoài lúc liên kết
+	NgayLienKet     time.Time  // Ngày liên kết
+	LanDangNhapCuoi *time.Time // Lần đăng nhập gần nhất (nil = chưa đăng nhập lần nào)
+}
+
+// NewExternalIdentity tạo liên kết mới giữa user và tài khoản bên ngoài
+func NewExternalIdentity(id, userID, provider, subject, email string) *ExternalIdentity {
+	return &ExternalIdentity{
+		ID:          id,
+		UserID:      userID,
+		Provider:    provider,
+		Subject:     subject,
+		Email:       email,
+		NgayLienKet: time.Now(),
+	}
+}
@@ -0,0 +1,107 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// LichSuGia là một lần đổi giá của món ăn
+// Mỗi lần Gia thay đổi (đổi trực tiếp hoặc theo lịch) ghi một bản ghi, không sửa/xóa
+// Giá tại thời điểm t = GiaMoi của bản ghi gần nhất có HieuLucTu <= t
+type LichSuGia struct {
+	ID           string    // UUID
+	MonAnID      string    // FK -> MonAn.ID
+	GiaCu        int64     // Giá trước khi đổi (0 = giá khởi tạo khi thêm món)
+	GiaMoi       int64     // Giá sau khi đổi
+	HieuLucTu    time.Time // Thời điểm giá mới bắt đầu áp dụng
+	NguoiThayDoi string    // User đổi giá hoặc đặt lịch (rỗng = hệ thống)
+	LichDoiGiaID string    // Lịch đổi giá đã tạo ra lần đổi này (rỗng = đổi trực tiếp)
+}
+
+// NewLichSuGia tạo bản ghi lịch sử giá
+func NewLichSuGia(id, monAnID string, giaCu, giaMoi int64, hieuLucTu time.Time, nguoiThayDoi string) *LichSuGia {
+	return &LichSuGia{
+		ID:           id,
+		MonAnID:      monAnID,
+		GiaCu:        giaCu,
+		GiaMoi:       giaMoi,
+		HieuLucTu:    hieuLucTu,
+		NguoiThayDoi: nguoiThayDoi,
+	}
+}
+
+// TrangThaiLichDoiGia định nghĩa các trạng thái của lịch đổi giá
+type TrangThaiLichDoiGia string
+
+const (
+	LichDoiGiaChoApDung TrangThaiLichDoiGia = "cho_ap_dung" // Chưa tới hạn
+	LichDoiGiaDaApDung  TrangThaiLichDoiGia = "da_ap_dung"  // Đã đổi giá món
+	LichDoiGiaDaHuy     TrangThaiLichDoiGia = "da_huy"      // Đã hủy trước khi áp dụng
+)
+
+// LichDoiGia là một lần đổi giá được đặt trước cho món ăn
+// VD: giá mới từ ngày 1 tháng sau. Scheduler áp dụng khi tới ApDungLuc
+type LichDoiGia struct {
+	ID          string              // UUID
+	MonAnID     string              // FK -> MonAn.ID
+	GiaMoi      int64               // Giá sẽ áp dụng
+	ApDungLuc   time.Time           // Thời điểm áp dụng
+	TrangThai   TrangThaiLichDoiGia // Trạng thái hiện tại
+	NguoiTao    string              // User đặt lịch
+	NgayTao     time.Time           // Ngày tạo
+	DaApDungLuc *time.Time          // Thời điểm scheduler thực sự áp dụng (nullable)
+}
+
+// NewLichDoiGia tạo lịch đổi giá mới với validation
+// now là thời điểm hiện tại: lịch phải ở tương lai
+func NewLichDoiGia(id, monAnID string, giaMoi int64, apDungLuc time.Time, nguoiTao string, now time.Time) (*LichDoiGia, error) {
+	if monAnID == "" {
+		return nil, errors.New("món ăn không được để trống")
+	}
+	if giaMoi <= 0 {
+		return nil, errors.New("giá món ăn phải lớn hơn 0")
+	}
+	if !apDungLuc.After(now) {
+		return nil, errors.New("thời điểm áp dụng phải ở tương lai")
+	}
+
+	return &LichDoiGia{
+		ID:        id,
+		MonAnID:   monAnID,
+		GiaMoi:    giaMoi,
+		ApDungLuc: apDungLuc,
+		TrangThai: LichDoiGiaChoApDung,
+		NguoiTao:  nguoiTao,
+		NgayTao:   now,
+	}, nil
+}
+
+// ChoApDung kiểm tra lịch còn chờ áp dụng không
+func (l *LichDoiGia) ChoApDung() bool {
+	return l.TrangThai == LichDoiGiaChoApDung
+}
+
+// DenHan kiểm tra lịch đã tới thời điểm áp dụng chưa
+func (l *LichDoiGia) DenHan(now time.Time) bool {
+	return l.ChoApDung() && !l.ApDungLuc.After(now)
+}
+
+// Huy hủy lịch chưa áp dụng
+func (l *LichDoiGia) Huy() error {
+	if !l.ChoApDung() {
+		return errors.New("chỉ hủy được lịch đổi giá đang chờ áp dụng")
+	}
+	l.TrangThai = LichDoiGiaDaHuy
+	return nil
+}
+
+// DanhDauDaApDung ghi nhận lịch đã được áp dụng lúc t
+func (l *LichDoiGia) DanhDauDaApDung(t time.Time) error {
+	if !l.ChoApDung() {
+		return errors.New("lịch đổi giá không còn chờ áp dụng")
+	}
+	l.TrangThai = LichDoiGiaDaApDung
+	l.DaApDungLuc = &t
+	return nil
+}
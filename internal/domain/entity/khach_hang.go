@@ -6,22 +6,34 @@ import (
 	"time"
 )
 
+// SoTienMoiDiem là số tiền thanh toán (VND) đổi được 1 điểm tích lũy
+const SoTienMoiDiem int64 = 1000
+
+// DiemTuTienThanhToan tính điểm tích lũy của một đơn hoàn thành
+func DiemTuTienThanhToan(tienThanhToan int64) int64 {
+	if tienThanhToan <= 0 {
+		return 0
+	}
+	return tienThanhToan / SoTienMoiDiem
+}
+
 // KhachHang là Entity đại diện cho khách hàng
 // Lưu trong MySQL vì:
 // - Dữ liệu ổn định, cần tích điểm thành viên
 // - Cần foreign key với User
 // - Cần ACID cho transactions liên quan đến điểm thưởng
 type KhachHang struct {
-	ID           string    // UUID
-	UserID       string    // FK -> User.ID (nullable - khách vãng lai)
-	HoTen        string    // Họ và tên
-	SoDienThoai  string    // Số điện thoại (unique)
-	Email        string    // Email (optional)
-	DiaChi       string    // Địa chỉ giao hàng
-	DiemTichLuy  int64     // Điểm tích lũy (loyalty points)
-	CapThanhVien string    // Cấp: "bronze", "silver", "gold", "platinum"
-	NgayTao      time.Time // Ngày đăng ký
-	NgayCapNhat  time.Time // Ngày cập nhật cuối
+	ID           string     // UUID
+	UserID       string     // FK -> User.ID (nullable - khách vãng lai)
+	HoTen        string     // Họ và tên
+	SoDienThoai  string     // Số điện thoại (unique)
+	Email        string     // Email (optional)
+	DiaChi       string     // Địa chỉ giao hàng
+	DiemTichLuy  int64      // Điểm tích lũy (loyalty points)
+	CapThanhVien string     // Cấp: "bronze", "silver", "gold", "platinum"
+	NgayTao      time.Time  // Ngày đăng ký
+	NgayCapNhat  time.Time  // Ngày cập nhật cuối
+	NgayXoa      *time.Time // Thời điểm xóa mềm (nil = chưa xóa)
 }
 
 // NewKhachHang tạo một KhachHang mới
@@ -45,6 +57,11 @@ func NewKhachHang(id, hoTen, soDienThoai string) (*KhachHang, error) {
 	}, nil
 }
 
+// DaXoa kiểm tra khách hàng đã bị xóa mềm chưa
+func (k *KhachHang) DaXoa() bool {
+	return k.NgayXoa != nil
+}
+
 // ThemDiem thêm điểm tích lũy và tự động nâng cấp
 func (k *KhachHang) ThemDiem(diem int64) {
 	k.DiemTichLuy += diem
@@ -64,15 +81,20 @@ func (k *KhachHang) TruDiem(diem int64) error {
 
 // capNhatCapThanhVien cập nhật cấp dựa trên điểm tích lũy
 func (k *KhachHang) capNhatCapThanhVien() {
+	k.CapThanhVien = CapThanhVienTheoDiem(k.DiemTichLuy)
+}
+
+// CapThanhVienTheoDiem trả về cấp thành viên ứng với tổng điểm tích lũy
+func CapThanhVienTheoDiem(diem int64) string {
 	switch {
-	case k.DiemTichLuy >= 10000:
-		k.CapThanhVien = "platinum"
-	case k.DiemTichLuy >= 5000:
-		k.CapThanhVien = "gold"
-	case k.DiemTichLuy >= 2000:
-		k.CapThanhVien = "silver"
+	case diem >= 10000:
+		return "platinum"
+	case diem >= 5000:
+		return "gold"
+	case diem >= 2000:
+		return "silver"
 	default:
-		k.CapThanhVien = "bronze"
+		return "bronze"
 	}
 }
 
@@ -0,0 +1,78 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// phutMoiNgay là số phút trong một ngày
+const phutMoiNgay = 24 * 60
+
+// KhungGioBan là một khung giờ phục vụ của món ăn (Value Object)
+// VD: Bữa sáng 06:00-10:00 mọi ngày, hoặc chỉ Thứ 7 & Chủ nhật cả ngày
+//
+// Quy ước:
+//   - CacNgay rỗng = áp dụng mọi ngày trong tuần
+//   - TuPhut == DenPhut = cả ngày
+//   - DenPhut < TuPhut = khung giờ qua đêm (VD: 22:00-02:00), phần sau nửa đêm
+//     thuộc về ngày bắt đầu
+//   - Thời điểm kiểm tra phải ở múi giờ của nhà hàng
+type KhungGioBan struct {
+	CacNgay []time.Weekday // Các ngày trong tuần áp dụng (rỗng = mọi ngày)
+	TuPhut  int            // Phút bắt đầu trong ngày (0-1439), VD 06:00 = 360
+	DenPhut int            // Phút kết thúc, không bao gồm (0-1439)
+}
+
+// NewKhungGioBan tạo KhungGioBan mới với validation
+func NewKhungGioBan(cacNgay []time.Weekday, tuPhut, denPhut int) (KhungGioBan, error) {
+	if tuPhut < 0 || tuPhut >= phutMoiNgay || denPhut < 0 || denPhut >= phutMoiNgay {
+		return KhungGioBan{}, errors.New("giờ bán phải nằm trong khoảng 00:00-23:59")
+	}
+	for _, ngay := range cacNgay {
+		if ngay < time.Sunday || ngay > time.Saturday {
+			return KhungGioBan{}, errors.New("ngày trong tuần không hợp lệ")
+		}
+	}
+
+	return KhungGioBan{
+		CacNgay: cacNgay,
+		TuPhut:  tuPhut,
+		DenPhut: denPhut,
+	}, nil
+}
+
+// apDungNgay kiểm tra khung giờ có áp dụng cho ngày trong tuần không
+func (k KhungGioBan) apDungNgay(ngay time.Weekday) bool {
+	if len(k.CacNgay) == 0 {
+		return true
+	}
+	for _, n := range k.CacNgay {
+		if n == ngay {
+			return true
+		}
+	}
+	return false
+}
+
+// BaoGom kiểm tra thời điểm t (đã ở múi giờ nhà hàng) có nằm trong khung giờ không
+func (k KhungGioBan) BaoGom(t time.Time) bool {
+	phut := t.Hour()*60 + t.Minute()
+
+	switch {
+	case k.TuPhut == k.DenPhut:
+		// Cả ngày
+		return k.apDungNgay(t.Weekday())
+	case k.TuPhut < k.DenPhut:
+		return k.apDungNgay(t.Weekday()) && phut >= k.TuPhut && phut < k.DenPhut
+	default:
+		// Qua đêm: phần trước nửa đêm thuộc hôm nay, phần sau thuộc hôm trước
+		if phut >= k.TuPhut {
+			return k.apDungNgay(t.Weekday())
+		}
+		if phut < k.DenPhut {
+			return k.apDungNgay(t.AddDate(0, 0, -1).Weekday())
+		}
+		return false
+	}
+}
@@ -0,0 +1,162 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// LoaiKhuyenMai định nghĩa các loại khuyến mãi
+type LoaiKhuyenMai string
+
+const (
+	KMGiamPhanTramMon LoaiKhuyenMai = "giam_phan_tram_mon" // Giảm % trên các món áp dụng
+	KMGiamTienMon     LoaiKhuyenMai = "giam_tien_mon"      // Giảm số tiền cố định trên mỗi phần món
+	KMMuaXTangY       LoaiKhuyenMai = "mua_x_tang_y"       // Mua X phần tặng Y phần (cùng món)
+	KMCombo           LoaiKhuyenMai = "combo"              // Combo nhiều món với giá cố định
+	KMGiamPhanTramDon LoaiKhuyenMai = "giam_phan_tram_don" // Giảm % trên tổng đơn
+	KMGiamTienDon     LoaiKhuyenMai = "giam_tien_don"      // Giảm số tiền cố định trên tổng đơn
+)
+
+// KhuyenMai là Entity đại diện cho một chương trình khuyến mãi
+// Lưu trong MongoDB vì cấu hình mỗi loại khác nhau (danh sách món, khung giờ, combo)
+//
+// Quy ước các trường theo loại:
+//   - KMGiamPhanTramMon: GiaTri = % (1-100), GiamToiDa = trần số tiền giảm, MonAnIDs rỗng = mọi món
+//   - KMGiamTienMon:     GiaTri = số tiền giảm mỗi phần, MonAnIDs rỗng = mọi món
+//   - KMMuaXTangY:       SoLuongMua = X, SoLuongTang = Y, MonAnIDs rỗng = mọi món
+//   - KMCombo:           MonAnIDs = các món trong combo (lặp ID = nhiều phần), GiaCombo
+//   - KMGiamPhanTramDon: GiaTri = % (1-100), GiamToiDa = trần số tiền giảm (0 = không giới hạn)
+//   - KMGiamTienDon:     GiaTri = số tiền giảm
+//
+// Happy hour = khuyến mãi có KhungGio (tái sử dụng KhungGioBan của lịch phục vụ món)
+type KhuyenMai struct {
+	ID           string        // UUID
+	Ten          string        // Tên chương trình (VD: "Happy hour 17h-19h")
+	MoTa         string        // Mô tả
+	Loai         LoaiKhuyenMai // Loại khuyến mãi
+	GiaTri       int64         // % hoặc số tiền giảm, tùy loại
+	GiamToiDa    int64         // Trần số tiền giảm cho loại % (0 = không giới hạn)
+	MonAnIDs     []string      // Các món áp dụng / các món trong combo
+	SoLuongMua   int           // X trong "mua X tặng Y"
+	SoLuongTang  int           // Y trong "mua X tặng Y"
+	GiaCombo     int64         // Giá trọn gói của combo
+	DonToiThieu  int64         // Tổng đơn tối thiểu để được áp dụng (0 = không yêu cầu)
+	TuNgay       *time.Time    // Bắt đầu hiệu lực (nil = không giới hạn)
+	DenNgay      *time.Time    // Hết hiệu lực, không bao gồm (nil = không giới hạn)
+	KhungGio     []KhungGioBan // Khung giờ áp dụng trong ngày (rỗng = cả ngày)
+	CongDon      bool          // Được cộng dồn với các khuyến mãi cộng dồn khác
+	UuTien       int           // Độ ưu tiên khi đánh giá (lớn hơn xét trước)
+	DangHoatDong bool          // Còn hoạt động không
+	NgayTao      time.Time     // Ngày tạo
+	NgayCapNhat  time.Time     // Ngày cập nhật cuối
+}
+
+// NewKhuyenMai tạo một KhuyenMai mới (đang hoạt động)
+// Các trường theo loại được gán sau đó và kiểm tra bằng KiemTra()
+func NewKhuyenMai(id, ten string, loai LoaiKhuyenMai) (*KhuyenMai, error) {
+	if ten == "" {
+		return nil, errors.New("tên khuyến mãi không được để trống")
+	}
+	switch loai {
+	case KMGiamPhanTramMon, KMGiamTienMon, KMMuaXTangY, KMCombo, KMGiamPhanTramDon, KMGiamTienDon:
+	default:
+		return nil, errors.New("loại khuyến mãi không hợp lệ")
+	}
+
+	now := time.Now()
+	return &KhuyenMai{
+		ID:           id,
+		Ten:          ten,
+		Loai:         loai,
+		DangHoatDong: true,
+		NgayTao:      now,
+		NgayCapNhat:  now,
+	}, nil
+}
+
+// KiemTra kiểm tra cấu hình khuyến mãi có hợp lệ với loại không
+func (k *KhuyenMai) KiemTra() error {
+	if k.DonToiThieu < 0 || k.GiamToiDa < 0 {
+		return errors.New("giá trị tiền không được âm")
+	}
+	if k.TuNgay != nil && k.DenNgay != nil && !k.DenNgay.After(*k.TuNgay) {
+		return errors.New("ngày kết thúc phải sau ngày bắt đầu")
+	}
+
+	switch k.Loai {
+	case KMGiamPhanTramMon, KMGiamPhanTramDon:
+		if k.GiaTri <= 0 || k.GiaTri > 100 {
+			return errors.New("phần trăm giảm phải từ 1 đến 100")
+		}
+	case KMGiamTienMon, KMGiamTienDon:
+		if k.GiaTri <= 0 {
+			return errors.New("số tiền giảm phải lớn hơn 0")
+		}
+	case KMMuaXTangY:
+		if k.SoLuongMua <= 0 || k.SoLuongTang <= 0 {
+			return errors.New("số lượng mua và số lượng tặng phải lớn hơn 0")
+		}
+	case KMCombo:
+		if len(k.MonAnIDs) < 2 {
+			return errors.New("combo phải có ít nhất 2 món")
+		}
+		if k.GiaCombo <= 0 {
+			return errors.New("giá combo phải lớn hơn 0")
+		}
+	}
+
+	return nil
+}
+
+// NgungHoatDong tắt khuyến mãi
+func (k *KhuyenMai) NgungHoatDong() {
+	k.DangHoatDong = false
+	k.NgayCapNhat = time.Now()
+}
+
+// DangHieuLuc kiểm tra khuyến mãi có hiệu lực tại thời điểm t không
+// t phải ở múi giờ của nhà hàng (để so khung giờ happy hour)
+func (k *KhuyenMai) DangHieuLuc(t time.Time) bool {
+	if !k.DangHoatDong {
+		return false
+	}
+	if k.TuNgay != nil && t.Before(*k.TuNgay) {
+		return false
+	}
+	if k.DenNgay != nil && !t.Before(*k.DenNgay) {
+		return false
+	}
+	if len(k.KhungGio) == 0 {
+		return true
+	}
+	for _, khung := range k.KhungGio {
+		if khung.BaoGom(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// apDungChoMon kiểm tra món có thuộc phạm vi khuyến mãi không (MonAnIDs rỗng = mọi món)
+func (k *KhuyenMai) apDungChoMon(monAnID string) bool {
+	if len(k.MonAnIDs) == 0 {
+		return true
+	}
+	for _, id := range k.MonAnIDs {
+		if id == monAnID {
+			return true
+		}
+	}
+	return false
+}
+
+// laKhuyenMaiDon kiểm tra khuyến mãi tính trên tổng đơn (không tiêu thụ phần món)
+func (k *KhuyenMai) laKhuyenMaiDon() bool {
+	return k.Loai == KMGiamPhanTramDon || k.Loai == KMGiamTienDon
+}
+
+// laKhuyenMaiPhanTram kiểm tra khuyến mãi giảm theo % (chỉ loại này dùng GiamToiDa)
+func (k *KhuyenMai) laKhuyenMaiPhanTram() bool {
+	return k.Loai == KMGiamPhanTramMon || k.Loai == KMGiamPhanTramDon
+}
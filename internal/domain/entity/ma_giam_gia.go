@@ -0,0 +1,166 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// LoaiMaGiamGia định nghĩa cách tính giảm của mã
+type LoaiMaGiamGia string
+
+const (
+	MaGiamPhanTram LoaiMaGiamGia = "phan_tram" // Giảm % trên số tiền còn lại của đơn
+	MaGiamSoTien   LoaiMaGiamGia = "so_tien"   // Giảm số tiền cố định
+)
+
+// TrangThaiSuDungMa định nghĩa trạng thái một lượt dùng mã
+type TrangThaiSuDungMa string
+
+const (
+	SuDungMaDaDung TrangThaiSuDungMa = "da_dung" // Đang tính vào lượt đã dùng
+	SuDungMaDaHoan TrangThaiSuDungMa = "da_hoan" // Đã hoàn lượt (đơn bị hủy)
+)
+
+// MaGiamGia là Entity đại diện cho một mã giảm giá (voucher / coupon)
+// Lưu trong MySQL vì việc trừ lượt cần khóa dòng (SELECT ... FOR UPDATE)
+type MaGiamGia struct {
+	ID            string        // UUID
+	Ma            string        // Mã khách nhập (viết hoa)
+	ChienDich     string        // Chiến dịch / đợt phát hành
+	Loai          LoaiMaGiamGia // Giảm theo % hoặc số tiền
+	GiaTri        int64         // % (1-100) hoặc số tiền (VND)
+	GiamToiDa     int64         // Trần số tiền giảm cho loại % (0 = không giới hạn)
+	DonToiThieu   int64         // Giá trị đơn tối thiểu
+	SoLanToiDa    int           // Tổng lượt dùng tối đa (0 = không giới hạn, 1 = dùng một lần)
+	SoLanMoiKhach int           // Lượt dùng tối đa mỗi khách (0 = không giới hạn)
+	SoLanDaDung   int           // Lượt đã dùng (không tính lượt đã hoàn)
+	HetHan        *time.Time    // Hạn dùng (nil = không hết hạn)
+	DangHoatDong  bool          // Còn hoạt động không
+	NgayTao       time.Time     // Ngày tạo
+	NgayCapNhat   time.Time     // Ngày cập nhật cuối
+}
+
+// SuDungMaGiamGia ghi nhận một lượt dùng mã trên một đơn hàng
+type SuDungMaGiamGia struct {
+	ID          string            // UUID
+	MaGiamGiaID string            // FK -> MaGiamGia
+	OrderID     string            // Đơn hàng đã dùng mã
+	KhachHangID string            // Khách dùng mã (rỗng = khách vãng lai)
+	SoTienGiam  int64             // Số tiền đã giảm
+	TrangThai   TrangThaiSuDungMa // Đã dùng / đã hoàn
+	NgayDung    time.Time         // Thời điểm dùng
+	NgayHoan    *time.Time        // Thời điểm hoàn lượt
+}
+
+// ThongKeMaGiamGia là số liệu sử dụng mã của một chiến dịch
+type ThongKeMaGiamGia struct {
+	ChienDich    string // Chiến dịch
+	SoMa         int    // Tổng số mã đã phát hành
+	SoMaDaDung   int    // Số mã đã được dùng ít nhất một lần
+	SoLuotDung   int    // Tổng lượt dùng hiện hành
+	SoLuotHoan   int    // Tổng lượt đã hoàn do hủy đơn
+	TongTienGiam int64  // Tổng tiền đã giảm (không tính lượt đã hoàn)
+}
+
+// NewMaGiamGia tạo một MaGiamGia mới với validation
+func NewMaGiamGia(id, ma, chienDich string, loai LoaiMaGiamGia, giaTri int64) (*MaGiamGia, error) {
+	ma = ChuanHoaMa(ma)
+	if ma == "" {
+		return nil, errors.New("mã giảm giá không được để trống")
+	}
+	if chienDich == "" {
+		return nil, errors.New("chiến dịch không được để trống")
+	}
+
+	switch loai {
+	case MaGiamPhanTram:
+		if giaTri <= 0 || giaTri > 100 {
+			return nil, errors.New("phần trăm giảm phải từ 1 đến 100")
+		}
+	case MaGiamSoTien:
+		if giaTri <= 0 {
+			return nil, errors.New("số tiền giảm phải lớn hơn 0")
+		}
+	default:
+		return nil, errors.New("loại mã giảm giá không hợp lệ")
+	}
+
+	now := time.Now()
+	return &MaGiamGia{
+		ID:           id,
+		Ma:           ma,
+		ChienDich:    chienDich,
+		Loai:         loai,
+		GiaTri:       giaTri,
+		SoLanToiDa:   1, // Mặc định dùng một lần
+		DangHoatDong: true,
+		NgayTao:      now,
+		NgayCapNhat:  now,
+	}, nil
+}
+
+// ChuanHoaMa chuẩn hóa mã khách nhập (bỏ khoảng trắng, viết hoa)
+func ChuanHoaMa(ma string) string {
+	return strings.ToUpper(strings.TrimSpace(ma))
+}
+
+// KiemTraSuDung kiểm tra mã có dùng được cho đơn không
+// tongTien là số tiền đơn còn lại (sau khuyến mãi), soLanKhachDaDung là lượt khách này đã dùng mã
+func (m *MaGiamGia) KiemTraSuDung(tongTien int64, khachHangID string, soLanKhachDaDung int, now time.Time) error {
+	if !m.DangHoatDong {
+		return errors.New("mã giảm giá đã ngừng hoạt động")
+	}
+	if m.HetHan != nil && !now.Before(*m.HetHan) {
+		return errors.New("mã giảm giá đã hết hạn")
+	}
+	if m.SoLanToiDa > 0 && m.SoLanDaDung >= m.SoLanToiDa {
+		return errors.New("mã giảm giá đã hết lượt sử dụng")
+	}
+	if m.SoLanMoiKhach > 0 {
+		if khachHangID == "" {
+			return errors.New("mã giảm giá chỉ dành cho khách hàng đã đăng nhập")
+		}
+		if soLanKhachDaDung >= m.SoLanMoiKhach {
+			return errors.New("bạn đã dùng hết lượt của mã giảm giá này")
+		}
+	}
+	if tongTien < m.DonToiThieu {
+		return errors.New("đơn hàng chưa đạt giá trị tối thiểu để dùng mã")
+	}
+	return nil
+}
+
+// TinhGiam tính số tiền giảm trên số tiền còn lại của đơn (không vượt quá tongTien)
+func (m *MaGiamGia) TinhGiam(tongTien int64) int64 {
+	var giam int64
+	switch m.Loai {
+	case MaGiamPhanTram:
+		giam = tongTien * m.GiaTri / 100
+		if m.GiamToiDa > 0 && giam > m.GiamToiDa {
+			giam = m.GiamToiDa
+		}
+	case MaGiamSoTien:
+		giam = m.GiaTri
+	}
+
+	if giam > tongTien {
+		giam = tongTien
+	}
+	return giam
+}
+
+// GhiNhanLuotDung tăng số lượt đã dùng
+func (m *MaGiamGia) GhiNhanLuotDung() {
+	m.SoLanDaDung++
+	m.NgayCapNhat = time.Now()
+}
+
+// HoanLuotDung trả lại một lượt dùng (khi đơn bị hủy)
+func (m *MaGiamGia) HoanLuotDung() {
+	if m.SoLanDaDung > 0 {
+		m.SoLanDaDung--
+	}
+	m.NgayCapNhat = time.Now()
+}
@@ -4,6 +4,7 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"time"
 )
 
@@ -11,14 +12,18 @@ import (
 // Đây giống như "công thức phở" - quy tắc kinh doanh không thay đổi
 // dù bạn đổi database (MySQL → MongoDB) hay đổi framework
 type MonAn struct {
-	ID          string    // Mã định danh duy nhất
-	Ten         string    // Tên món ăn (VD: "Phở tái")
-	Gia         int64     // Giá gốc (đơn vị: VND)
-	MoTa        string    // Mô tả món ăn
-	ConHang     bool      // Còn bán không?
-	GiamGia     int       // Phần trăm giảm giá (0-100)
-	NgayTao     time.Time // Ngày tạo món
-	NgayCapNhat time.Time // Ngày cập nhật cuối
+	ID          string        // Mã định danh duy nhất
+	Ten         string        // Tên món ăn (VD: "Phở tái")
+	Gia         int64         // Giá gốc (đơn vị: VND)
+	MoTa        string        // Mô tả món ăn
+	ConHang     bool          // Còn bán không?
+	GiamGia     int           // Phần trăm giảm giá (0-100)
+	LichBan     []KhungGioBan // Các khung giờ phục vụ (rỗng = phục vụ mọi lúc)
+	NhomTuyChon []NhomTuyChon // Các nhóm tùy chọn (size, topping, độ cay,...)
+	NgayTao     time.Time     // Ngày tạo món
+	NgayCapNhat time.Time     // Ngày cập nhật cuối
+	Version     int64         // Phiên bản để kiểm soát ghi đồng thời (0 = chưa lưu, tăng mỗi lần lưu)
+	NgayXoa     *time.Time    // Thời điểm xóa mềm (nil = chưa xóa)
 }
 
 // NewMonAn tạo một MonAn mới với validation
@@ -47,6 +52,11 @@ func NewMonAn(id, ten string, gia int64, moTa string) (*MonAn, error) {
 	}, nil
 }
 
+// DaXoa kiểm tra món đã bị xóa mềm chưa
+func (m *MonAn) DaXoa() bool {
+	return m.NgayXoa != nil
+}
+
 // TinhGia tính giá sau khi áp dụng giảm giá
 // Business logic: Giá cuối = Giá gốc - (Giá gốc * GiảmGiá / 100)
 // VD: Phở 50,000đ giảm 10% → 45,000đ
@@ -66,6 +76,81 @@ func (m *MonAn) CoTheBan() bool {
 	return m.ConHang && m.Gia > 0
 }
 
+// CoBanLuc kiểm tra món có nằm trong lịch phục vụ tại thời điểm t không
+// t phải ở múi giờ của nhà hàng (VD: Asia/Ho_Chi_Minh)
+func (m *MonAn) CoBanLuc(t time.Time) bool {
+	if len(m.LichBan) == 0 {
+		return true
+	}
+	for _, k := range m.LichBan {
+		if k.BaoGom(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// CoTheBanLuc kiểm tra món có thể bán tại thời điểm t không
+// Business rule: CoTheBan() VÀ nằm trong lịch phục vụ
+func (m *MonAn) CoTheBanLuc(t time.Time) bool {
+	return m.CoTheBan() && m.CoBanLuc(t)
+}
+
+// DatLichBan thay toàn bộ lịch phục vụ của món
+// Truyền danh sách rỗng để món được phục vụ mọi lúc
+func (m *MonAn) DatLichBan(lichBan []KhungGioBan) {
+	m.LichBan = lichBan
+	m.NgayCapNhat = time.Now()
+}
+
+// DatNhomTuyChon thay toàn bộ nhóm tùy chọn của món
+// Business rule: Mã nhóm không được trùng nhau
+func (m *MonAn) DatNhomTuyChon(nhom []NhomTuyChon) error {
+	daCo := make(map[string]bool, len(nhom))
+	for _, n := range nhom {
+		if daCo[n.ID] {
+			return fmt.Errorf("nhóm tùy chọn %q bị trùng", n.ID)
+		}
+		daCo[n.ID] = true
+	}
+
+	m.NhomTuyChon = nhom
+	m.NgayCapNhat = time.Now()
+	return nil
+}
+
+// ChonTuyChon kiểm tra lựa chọn của khách (mã nhóm → các mã lựa chọn)
+// và trả về snapshot các tùy chọn đã chọn (kèm giá cộng thêm của từng lựa chọn)
+// Business rule: Nhóm bắt buộc phải được chọn, số lựa chọn nằm trong [ChonToiThieu, ChonToiDa]
+func (m *MonAn) ChonTuyChon(chon map[string][]string) ([]TuyChonDaChon, error) {
+	for nhomID := range chon {
+		if !m.coNhomTuyChon(nhomID) {
+			return nil, fmt.Errorf("món %q không có nhóm tùy chọn %q", m.Ten, nhomID)
+		}
+	}
+
+	var result []TuyChonDaChon
+	for _, n := range m.NhomTuyChon {
+		daChon, err := n.chon(chon[n.ID])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, daChon...)
+	}
+
+	return result, nil
+}
+
+// coNhomTuyChon kiểm tra món có nhóm tùy chọn với mã cho trước không
+func (m *MonAn) coNhomTuyChon(nhomID string) bool {
+	for _, n := range m.NhomTuyChon {
+		if n.ID == nhomID {
+			return true
+		}
+	}
+	return false
+}
+
 // ApDungGiamGia áp dụng giảm giá cho món
 // Business rule: Giảm giá phải trong khoảng 0-100%
 func (m *MonAn) ApDungGiamGia(phanTram int) error {
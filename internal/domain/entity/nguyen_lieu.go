@@ -0,0 +1,107 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// NguyenLieu là Entity đại diện cho nguyên liệu trong kho
+// Lưu trong MySQL vì:
+// - Tồn kho cần ACID (nhập hàng + ghi biến động kho cùng lúc)
+// - Giá vốn bình quân dùng để tính COGS
+type NguyenLieu struct {
+	ID             string    // UUID
+	Ten            string    // Tên nguyên liệu (VD: "Thịt bò")
+	DonViTinh      string    // Đơn vị tính (kg, lít, cái,...)
+	SoLuongTon     float64   // Số lượng tồn kho hiện tại
+	MucToiThieu    float64   // Mức tồn tối thiểu (dưới mức này cần đặt thêm)
+	GiaVonBinhQuan int64     // Giá vốn bình quân gia quyền (VND/đơn vị) - dùng cho COGS
+	NgayTao        time.Time // Ngày tạo
+	NgayCapNhat    time.Time // Ngày cập nhật cuối
+}
+
+// NewNguyenLieu tạo một NguyenLieu mới
+func NewNguyenLieu(id, ten, donViTinh string, mucToiThieu float64) (*NguyenLieu, error) {
+	if ten == "" {
+		return nil, errors.New("tên nguyên liệu không được để trống")
+	}
+	if donViTinh == "" {
+		return nil, errors.New("đơn vị tính không được để trống")
+	}
+	if mucToiThieu < 0 {
+		return nil, errors.New("mức tồn tối thiểu không được âm")
+	}
+
+	now := time.Now()
+	return &NguyenLieu{
+		ID:          id,
+		Ten:         ten,
+		DonViTinh:   donViTinh,
+		SoLuongTon:  0,
+		MucToiThieu: mucToiThieu,
+		NgayTao:     now,
+		NgayCapNhat: now,
+	}, nil
+}
+
+// NhapKho tăng tồn kho và cập nhật giá vốn bình quân gia quyền
+// Business logic: GiáVốnMới = (Tồn * GiáVốnCũ + SốLượngNhập * ĐơnGiá) / (Tồn + SốLượngNhập)
+func (n *NguyenLieu) NhapKho(soLuong float64, donGia int64) error {
+	if soLuong <= 0 {
+		return errors.New("số lượng nhập phải lớn hơn 0")
+	}
+	if donGia < 0 {
+		return errors.New("đơn giá không được âm")
+	}
+
+	tonCu := n.SoLuongTon
+	if tonCu < 0 {
+		tonCu = 0
+	}
+	tongGiaTri := tonCu*float64(n.GiaVonBinhQuan) + soLuong*float64(donGia)
+	n.SoLuongTon += soLuong
+	n.GiaVonBinhQuan = int64(tongGiaTri / (tonCu + soLuong))
+	n.NgayCapNhat = time.Now()
+	return nil
+}
+
+// TieuHao trừ tồn kho khi nguyên liệu được sử dụng
+func (n *NguyenLieu) TieuHao(soLuong float64) error {
+	if soLuong <= 0 {
+		return errors.New("số lượng tiêu hao phải lớn hơn 0")
+	}
+	if soLuong > n.SoLuongTon {
+		return errors.New("không đủ tồn kho")
+	}
+
+	n.SoLuongTon -= soLuong
+	n.NgayCapNhat = time.Now()
+	return nil
+}
+
+// DuoiMucToiThieu kiểm tra tồn kho có dưới mức tối thiểu không
+func (n *NguyenLieu) DuoiMucToiThieu() bool {
+	return n.SoLuongTon < n.MucToiThieu
+}
+
+// LoaiBienDongKho định nghĩa loại biến động kho
+type LoaiBienDongKho string
+
+const (
+	BienDongNhapHang  LoaiBienDongKho = "nhap_hang"  // Nhập từ đơn đặt hàng
+	BienDongTieuHao   LoaiBienDongKho = "tieu_hao"   // Tiêu hao khi chế biến
+	BienDongDieuChinh LoaiBienDongKho = "dieu_chinh" // Điều chỉnh sau kiểm kê
+)
+
+// BienDongKho ghi lại một lần thay đổi tồn kho
+// Dùng để tính tiêu hao gần đây (gợi ý đặt hàng) và truy vết giá vốn
+type BienDongKho struct {
+	ID           string          // UUID
+	NguyenLieuID string          // FK -> NguyenLieu.ID
+	Loai         LoaiBienDongKho // Loại biến động
+	SoLuong      float64         // Số lượng (dương = tăng, âm = giảm)
+	DonGia       int64           // Đơn giá tại thời điểm biến động (VND/đơn vị)
+	ThamChieu    string          // Mã tham chiếu (VD: ID đơn đặt hàng)
+	NgayTao      time.Time       // Thời điểm biến động
+}
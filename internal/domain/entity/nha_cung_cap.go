@@ -0,0 +1,58 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// NhaCungCap là Entity đại diện cho nhà cung cấp nguyên liệu
+// Lưu trong MySQL vì cần foreign key với đơn đặt hàng
+type NhaCungCap struct {
+	ID          string    // UUID
+	Ten         string    // Tên nhà cung cấp
+	NguoiLienHe string    // Người liên hệ
+	SoDienThoai string    // Số điện thoại
+	Email       string    // Email (optional)
+	DiaChi      string    // Địa chỉ
+	SoNgayGiao  int       // Thời gian giao hàng dự kiến (ngày) - dùng cho gợi ý đặt hàng
+	DangHopTac  bool      // Còn hợp tác không
+	NgayTao     time.Time // Ngày tạo
+	NgayCapNhat time.Time // Ngày cập nhật cuối
+}
+
+// NewNhaCungCap tạo một NhaCungCap mới
+func NewNhaCungCap(id, ten, soDienThoai string, soNgayGiao int) (*NhaCungCap, error) {
+	if ten == "" {
+		return nil, errors.New("tên nhà cung cấp không được để trống")
+	}
+	if soDienThoai == "" {
+		return nil, errors.New("số điện thoại không được để trống")
+	}
+	if soNgayGiao < 0 {
+		return nil, errors.New("số ngày giao hàng không được âm")
+	}
+
+	now := time.Now()
+	return &NhaCungCap{
+		ID:          id,
+		Ten:         ten,
+		SoDienThoai: soDienThoai,
+		SoNgayGiao:  soNgayGiao,
+		DangHopTac:  true,
+		NgayTao:     now,
+		NgayCapNhat: now,
+	}, nil
+}
+
+// NgungHopTac đánh dấu ngừng hợp tác với nhà cung cấp
+func (n *NhaCungCap) NgungHopTac() {
+	n.DangHopTac = false
+	n.NgayCapNhat = time.Now()
+}
+
+// HopTacLai đánh dấu hợp tác trở lại
+func (n *NhaCungCap) HopTacLai() {
+	n.DangHopTac = true
+	n.NgayCapNhat = time.Now()
+}
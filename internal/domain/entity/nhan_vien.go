@@ -17,6 +17,15 @@ const (
 	ChucVuGiaoHang  ChucVu = "giao_hang" // Giao hàng
 )
 
+// IsValid kiểm tra chức vụ có hợp lệ không
+func (c ChucVu) IsValid() bool {
+	switch c {
+	case ChucVuBep, ChucVuPhucVu, ChucVuThuNgan, ChucVuQuanLy, ChucVuGiaoHang:
+		return true
+	}
+	return false
+}
+
 // TrangThaiLamViec định nghĩa trạng thái làm việc
 type TrangThaiLamViec string
 
@@ -44,6 +53,7 @@ type NhanVien struct {
 	NgayVaoLam  time.Time        // Ngày bắt đầu làm việc
 	NgayTao     time.Time        // Ngày tạo record
 	NgayCapNhat time.Time        // Ngày cập nhật cuối
+	NgayXoa     *time.Time       // Thời điểm xóa mềm (nil = chưa xóa)
 }
 
 // NewNhanVien tạo một NhanVien mới
@@ -70,6 +80,11 @@ func NewNhanVien(id, userID, hoTen string, chucVu ChucVu, soDienThoai string) (*
 	}, nil
 }
 
+// DaXoa kiểm tra nhân viên đã bị xóa mềm chưa
+func (n *NhanVien) DaXoa() bool {
+	return n.NgayXoa != nil
+}
+
 // LaDauBep kiểm tra nhân viên có phải đầu bếp không
 func (n *NhanVien) LaDauBep() bool {
 	return n.ChucVu == ChucVuBep
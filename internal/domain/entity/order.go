@@ -33,7 +33,8 @@ type OrderItem struct {
 	MonAnID   string // ID của món ăn
 	TenMon    string // Tên món (snapshot tại thời điểm đặt)
 	SoLuong   int    // Số lượng
-	DonGia    int64  // Đơn giá tại thời điểm đặt (đã tính giảm giá)
+	DonGia    int64  // Đơn giá tại thời điểm đặt (đã tính giảm giá, đã cộng giá tùy chọn)
+	TuyChon   []TuyChonDaChon // Các tùy chọn đã chọn (snapshot)
 	GhiChu    string // Ghi chú (ít cay, không hành,...)
 	ThanhTien int64  // Thành tiền = SoLuong * DonGia
 }
@@ -55,6 +56,9 @@ type Order struct {
 	Items          []OrderItem    // Danh sách món
 	TongTien       int64          // Tổng tiền trước giảm giá
 	GiamGia        int64          // Số tiền giảm giá
+	KhuyenMai      []KhuyenMaiApDung // Các khuyến mãi đã áp dụng (snapshot)
+	MaGiamGia      string         // Mã giảm giá khách đã nhập (rỗng = không dùng)
+	TienGiamMa     int64          // Số tiền giảm từ mã (đã tính trong GiamGia)
 	TienThanhToan  int64          // Tiền thực thanh toán
 	GhiChu         string         // Ghi chú chung
 	DiaChiGiao     string         // Địa chỉ giao hàng (cho delivery)
@@ -81,9 +85,18 @@ func NewOrder(id string, loaiOrder LoaiOrder) (*Order, error) {
 
 // ThemMon thêm món vào đơn hàng
 func (o *Order) ThemMon(monAnID, tenMon string, soLuong int, donGia int64, ghiChu string) error {
+	return o.ThemMonCoTuyChon(monAnID, tenMon, soLuong, donGia, nil, ghiChu)
+}
+
+// ThemMonCoTuyChon thêm món kèm các tùy chọn đã chọn vào đơn hàng
+// donGia là giá món (chưa gồm tùy chọn); đơn giá dòng = donGia + tổng giá cộng thêm của tùy chọn
+func (o *Order) ThemMonCoTuyChon(monAnID, tenMon string, soLuong int, donGia int64, tuyChon []TuyChonDaChon, ghiChu string) error {
 	if soLuong <= 0 {
 		return errors.New("số lượng phải lớn hơn 0")
 	}
+	for _, tc := range tuyChon {
+		donGia += tc.GiaThem
+	}
 	if donGia < 0 {
 		return errors.New("đơn giá không được âm")
 	}
@@ -93,6 +106,7 @@ func (o *Order) ThemMon(monAnID, tenMon string, soLuong int, donGia int64, ghiCh
 		TenMon:    tenMon,
 		SoLuong:   soLuong,
 		DonGia:    donGia,
+		TuyChon:   tuyChon,
 		GhiChu:    ghiChu,
 		ThanhTien: int64(soLuong) * donGia,
 	}
@@ -143,6 +157,28 @@ func (o *Order) ApDungGiamGia(soTien int64) error {
 	return nil
 }
 
+// ApDungKhuyenMai áp dụng kết quả đánh giá khuyến mãi vào đơn hàng
+// Ghi lại các khuyến mãi đã áp dụng để đối soát về sau
+func (o *Order) ApDungKhuyenMai(kq KetQuaKhuyenMai) error {
+	if err := o.ApDungGiamGia(kq.TongGiam); err != nil {
+		return err
+	}
+
+	o.KhuyenMai = kq.ApDung
+	return nil
+}
+
+// ApDungMaGiamGia cộng thêm khoản giảm từ mã giảm giá (sau khuyến mãi)
+func (o *Order) ApDungMaGiamGia(ma string, soTien int64) error {
+	if err := o.ApDungGiamGia(o.GiamGia + soTien); err != nil {
+		return err
+	}
+
+	o.MaGiamGia = ma
+	o.TienGiamMa = soTien
+	return nil
+}
+
 // ChuyenTrangThai chuyển trạng thái đơn hàng
 func (o *Order) ChuyenTrangThai(trangThaiMoi TrangThaiOrder) error {
 	// Validate state transitions
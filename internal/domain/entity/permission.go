@@ -0,0 +1,44 @@
+// Package entity chứa các Domain Entity
+package entity
+
+// Permission là một quyền có tên dạng resource:action (vd: menu:write)
+// Danh mục permission và quyền của từng role lưu trong MySQL, admin sửa được qua API
+type Permission struct {
+	Ma   string // Tên quyền
+	MoTa string // Mô tả hiển thị cho admin
+}
+
+// Các permission được kiểm tra trong code (khớp danh mục seed ở các migration 000007 - 000013)
+const (
+	PermissionMenuWrite             = "menu:write"
+	PermissionMenuAvailabilityWrite = "menu:availability:write"
+	PermissionOrderRead             = "order:read"
+	PermissionOrderCancel           = "order:cancel"
+	PermissionOrderRefund           = "order:refund"
+	PermissionOrderStatusWrite      = "order:status:write"
+	PermissionOrderStatusAssigned   = "order:status:write:assigned"
+	PermissionOrderAssign           = "order:assign"
+	PermissionInventoryManage       = "inventory:manage"
+	PermissionPurchasingManage      = "purchasing:manage"
+	PermissionPromotionManage       = "promotion:manage"
+	PermissionReportRead            = "report:read"
+	PermissionStaffSalaryWrite      = "staff:salary:write"
+	PermissionUserRead              = "user:read"
+	PermissionUserWrite             = "user:write"
+	PermissionUserDeactivate        = "user:deactivate"
+	PermissionUserSessionManage     = "user:session:manage"
+	PermissionJobManage             = "job:manage"
+	PermissionSchedulerManage       = "scheduler:manage"
+	PermissionPermissionManage      = "permission:manage"
+	PermissionAuditRead             = "audit:read"
+	PermissionDeletedRestore        = "deleted:restore"
+	PermissionWebhookManage         = "webhook:manage"
+
+	// permissionAssignRolePrefix + role: được tạo/quản lý user thuộc role đó
+	permissionAssignRolePrefix = "user:assign:"
+)
+
+// PermissionAssignRole trả về permission cần có để tạo hoặc quản lý user thuộc role
+func PermissionAssignRole(role UserRole) string {
+	return permissionAssignRolePrefix + string(role)
+}
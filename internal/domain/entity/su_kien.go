@@ -0,0 +1,91 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// LoaiSuKien định nghĩa loại domain event
+type LoaiSuKien string
+
+const (
+	SuKienOrderCreated             LoaiSuKien = "order.created"               // Đơn hàng mới được tạo
+	SuKienOrderStatusChanged       LoaiSuKien = "order.status_changed"        // Đơn hàng đổi trạng thái (kể cả hủy)
+	SuKienUserDeactivated          LoaiSuKien = "user.deactivated"            // Tài khoản bị vô hiệu hóa
+	SuKienMonAnAvailabilityChanged LoaiSuKien = "mon_an.availability_changed" // Món đổi trạng thái còn hàng / hết hàng
+)
+
+// SuKien là domain event ghi vào outbox cùng transaction với thay đổi trạng thái
+// Relay đọc outbox và phát tới dispatcher nội bộ: ít nhất một lần (at-least-once),
+// nên consumer phải idempotent theo ID sự kiện
+type SuKien struct {
+	ID         string     // UUID, cũng là khóa idempotency của consumer
+	Loai       LoaiSuKien // Loại sự kiện, dùng để chọn consumer
+	DoiTuongID string     // ID của aggregate phát sinh sự kiện (order, user...)
+	DuLieu     []byte     // Payload JSON
+	TaoLuc     time.Time  // Thời điểm phát sinh
+	SoLanThu   int        // Số lần relay đã phát thất bại
+	LoiCuoi    string     // Lỗi của lần phát thất bại gần nhất
+	ThuLaiLuc  time.Time  // Relay chỉ nhận sự kiện khi tới thời điểm này (backoff / đang được giữ chỗ)
+	DaPhatLuc  *time.Time // Thời điểm phát thành công (nil = chưa phát)
+}
+
+// NewSuKien tạo sự kiện mới với payload encode JSON
+func NewSuKien(id string, loai LoaiSuKien, doiTuongID string, payload any) (*SuKien, error) {
+	duLieu, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("không thể encode sự kiện %s: %w", loai, err)
+	}
+
+	now := time.Now()
+	return &SuKien{
+		ID:         id,
+		Loai:       loai,
+		DoiTuongID: doiTuongID,
+		DuLieu:     duLieu,
+		TaoLuc:     now,
+		ThuLaiLuc:  now,
+	}, nil
+}
+
+// DocDuLieu decode payload JSON vào v
+func (s *SuKien) DocDuLieu(v any) error {
+	if err := json.Unmarshal(s.DuLieu, v); err != nil {
+		return fmt.Errorf("payload sự kiện %s không hợp lệ: %w", s.Loai, err)
+	}
+	return nil
+}
+
+// OrderCreatedPayload là payload của sự kiện order.created
+type OrderCreatedPayload struct {
+	OrderID       string    `json:"order_id"`
+	KhachHangID   string    `json:"khach_hang_id,omitempty"`
+	LoaiOrder     LoaiOrder `json:"loai_order"`
+	TienThanhToan int64     `json:"tien_thanh_toan"`
+}
+
+// OrderStatusChangedPayload là payload của sự kiện order.status_changed
+type OrderStatusChangedPayload struct {
+	OrderID       string         `json:"order_id"`
+	KhachHangID   string         `json:"khach_hang_id,omitempty"`
+	DauBepID      string         `json:"dau_bep_id,omitempty"`
+	TrangThaiCu   TrangThaiOrder `json:"trang_thai_cu"`
+	TrangThaiMoi  TrangThaiOrder `json:"trang_thai_moi"`
+	TienThanhToan int64          `json:"tien_thanh_toan"`
+}
+
+// UserDeactivatedPayload là payload của sự kiện user.deactivated
+type UserDeactivatedPayload struct {
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Role     UserRole `json:"role"`
+}
+
+// MonAnAvailabilityChangedPayload là payload của sự kiện mon_an.availability_changed
+type MonAnAvailabilityChangedPayload struct {
+	MonAnID string `json:"mon_an_id"`
+	Ten     string `json:"ten"`
+	ConHang bool   `json:"con_hang"`
+}
@@ -0,0 +1,321 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// KhuyenMaiApDung ghi nhận một khuyến mãi đã áp dụng cho đơn hàng (snapshot)
+type KhuyenMaiApDung struct {
+	KhuyenMaiID string // ID khuyến mãi
+	Ten         string // Tên khuyến mãi tại thời điểm áp dụng
+	SoTienGiam  int64  // Số tiền được giảm bởi khuyến mãi này
+}
+
+// KetQuaKhuyenMai là kết quả đánh giá khuyến mãi cho một đơn hàng
+type KetQuaKhuyenMai struct {
+	ApDung   []KhuyenMaiApDung // Các khuyến mãi được chọn
+	TongGiam int64             // Tổng số tiền giảm (không vượt quá tổng đơn)
+}
+
+// phanMon là số phần còn lại của một món trong đơn, chưa bị khuyến mãi nào "chiếm"
+type phanMon struct {
+	monAnID string
+	donGia  int64
+	conLai  int
+}
+
+// soCongDonToiDaHoanVi là số khuyến mãi cộng dồn tối đa được xét mọi thứ tự áp dụng
+// (7! = 5040 phương án); các khuyến mãi cộng dồn còn lại được áp dụng sau theo thứ tự ưu tiên
+const soCongDonToiDaHoanVi = 7
+
+// ChonKhuyenMaiTotNhat đánh giá các khuyến mãi cho danh sách món và chọn phương án giảm nhiều nhất
+//
+// Quy tắc (xác định - cùng input luôn cho cùng kết quả):
+//  1. Chỉ xét khuyến mãi có hiệu lực tại t, sắp theo UuTien giảm dần rồi ID tăng dần
+//  2. Mỗi phần món chỉ được hưởng MỘT khuyến mãi theo món (giảm món, mua X tặng Y, combo)
+//  3. Khuyến mãi theo đơn tính trên số tiền còn lại sau khuyến mãi theo món
+//  4. Phương án "độc quyền": mỗi khuyến mãi không CongDon được xét riêng lẻ
+//  5. Phương án "cộng dồn": các khuyến mãi CongDon, xét mọi thứ tự áp dụng (khuyến mãi theo món
+//     trước, theo đơn sau) vì khuyến mãi áp dụng trước chiếm phần món của khuyến mãi sau.
+//     Xét mọi thứ tự đã bao gồm mọi tập con: khuyến mãi xếp cuối chỉ nhận phần món còn thừa
+//     nên thêm nó không bao giờ làm tổng giảm ít đi
+//  6. Chọn phương án giảm nhiều nhất; hòa thì ưu tiên phương án cộng dồn, rồi theo thứ tự sắp xếp
+//
+// t phải ở múi giờ của nhà hàng
+func ChonKhuyenMaiTotNhat(items []OrderItem, dsKhuyenMai []*KhuyenMai, t time.Time) KetQuaKhuyenMai {
+	var tongTien int64
+	for _, item := range items {
+		tongTien += item.ThanhTien
+	}
+
+	var hieuLuc []*KhuyenMai
+	for _, km := range dsKhuyenMai {
+		if km.DangHieuLuc(t) && tongTien >= km.DonToiThieu {
+			hieuLuc = append(hieuLuc, km)
+		}
+	}
+	sort.SliceStable(hieuLuc, func(i, j int) bool {
+		if hieuLuc[i].UuTien != hieuLuc[j].UuTien {
+			return hieuLuc[i].UuTien > hieuLuc[j].UuTien
+		}
+		return hieuLuc[i].ID < hieuLuc[j].ID
+	})
+
+	var congDonMon, congDonDon, docQuyen []*KhuyenMai
+	for _, km := range hieuLuc {
+		switch {
+		case !km.CongDon:
+			docQuyen = append(docQuyen, km)
+		case km.laKhuyenMaiDon():
+			congDonDon = append(congDonDon, km)
+		default:
+			congDonMon = append(congDonMon, km)
+		}
+	}
+
+	best := chonThuTuCongDon(items, tongTien, congDonMon, congDonDon)
+	for _, km := range docQuyen {
+		kq := danhGiaPhuongAn(items, tongTien, []*KhuyenMai{km})
+		if kq.TongGiam > best.TongGiam {
+			best = kq
+		}
+	}
+
+	return best
+}
+
+// chonThuTuCongDon thử mọi thứ tự áp dụng của các khuyến mãi cộng dồn và trả về phương án giảm nhiều nhất
+// Thứ tự đầu tiên được thử là thứ tự ưu tiên, nên khi hòa phương án theo ưu tiên được giữ
+func chonThuTuCongDon(items []OrderItem, tongTien int64, theoMon, theoDon []*KhuyenMai) KetQuaKhuyenMai {
+	// Khuyến mãi vượt giới hạn hoán vị: cố định ở cuối mỗi nhóm theo thứ tự ưu tiên
+	var monCoDinh, donCoDinh []*KhuyenMai
+	if len(theoMon) > soCongDonToiDaHoanVi {
+		theoMon, monCoDinh = theoMon[:soCongDonToiDaHoanVi], theoMon[soCongDonToiDaHoanVi:]
+	}
+	if du := soCongDonToiDaHoanVi - len(theoMon); len(theoDon) > du {
+		theoDon, donCoDinh = theoDon[:du], theoDon[du:]
+	}
+
+	var best KetQuaKhuyenMai
+	daCo := false
+	hoanVi(theoMon, func(mon []*KhuyenMai) {
+		hoanVi(theoDon, func(don []*KhuyenMai) {
+			ds := make([]*KhuyenMai, 0, len(mon)+len(monCoDinh)+len(don)+len(donCoDinh))
+			ds = append(ds, mon...)
+			ds = append(ds, monCoDinh...)
+			ds = append(ds, don...)
+			ds = append(ds, donCoDinh...)
+
+			kq := danhGiaPhuongAn(items, tongTien, ds)
+			if !daCo || kq.TongGiam > best.TongGiam {
+				best = kq
+				daCo = true
+			}
+		})
+	})
+	return best
+}
+
+// hoanVi gọi fn với mọi hoán vị của ds, hoán vị đầu tiên là chính ds
+// fn không được giữ lại slice nhận được (được dùng lại giữa các lần gọi)
+func hoanVi(ds []*KhuyenMai, fn func([]*KhuyenMai)) {
+	a := append([]*KhuyenMai(nil), ds...)
+	var sinh func(k int)
+	sinh = func(k int) {
+		if k >= len(a)-1 {
+			fn(a)
+			return
+		}
+		for i := k; i < len(a); i++ {
+			// Đưa phần tử i lên vị trí k, giữ nguyên thứ tự tương đối của phần còn lại
+			x := a[i]
+			copy(a[k+1:i+1], a[k:i])
+			a[k] = x
+			sinh(k + 1)
+			copy(a[k:i], a[k+1:i+1])
+			a[i] = x
+		}
+	}
+	sinh(0)
+}
+
+// danhGiaPhuongAn áp dụng lần lượt các khuyến mãi (theo món trước, theo đơn sau) trên cùng một đơn
+func danhGiaPhuongAn(items []OrderItem, tongTien int64, dsKhuyenMai []*KhuyenMai) KetQuaKhuyenMai {
+	phan := gomPhanMon(items)
+	kq := KetQuaKhuyenMai{}
+
+	apDung := func(km *KhuyenMai) {
+		conLai := tongTien - kq.TongGiam
+		giam := km.tinhGiam(phan, conLai)
+		if giam > conLai {
+			giam = conLai
+		}
+		if giam <= 0 {
+			return
+		}
+		kq.ApDung = append(kq.ApDung, KhuyenMaiApDung{
+			KhuyenMaiID: km.ID,
+			Ten:         km.Ten,
+			SoTienGiam:  giam,
+		})
+		kq.TongGiam += giam
+	}
+
+	for _, km := range dsKhuyenMai {
+		if !km.laKhuyenMaiDon() {
+			apDung(km)
+		}
+	}
+	for _, km := range dsKhuyenMai {
+		if km.laKhuyenMaiDon() {
+			apDung(km)
+		}
+	}
+
+	return kq
+}
+
+// gomPhanMon gom các OrderItem cùng món và cùng đơn giá (khác ghi chú) thành một nhóm phần món
+// Cùng món nhưng khác tùy chọn (size, topping) có thể khác đơn giá nên được tách nhóm riêng
+func gomPhanMon(items []OrderItem) []*phanMon {
+	type khoa struct {
+		monAnID string
+		donGia  int64
+	}
+
+	var result []*phanMon
+	index := make(map[khoa]*phanMon)
+	for _, item := range items {
+		k := khoa{item.MonAnID, item.DonGia}
+		if p, ok := index[k]; ok {
+			p.conLai += item.SoLuong
+			continue
+		}
+		p := &phanMon{monAnID: item.MonAnID, donGia: item.DonGia, conLai: item.SoLuong}
+		index[k] = p
+		result = append(result, p)
+	}
+	return result
+}
+
+// phanReNhat trả về nhóm phần món rẻ nhất của món còn phần chưa bị chiếm (nil nếu hết)
+func phanReNhat(phan []*phanMon, monAnID string) *phanMon {
+	var result *phanMon
+	for _, p := range phan {
+		if p.monAnID != monAnID || p.conLai <= 0 {
+			continue
+		}
+		if result == nil || p.donGia < result.donGia {
+			result = p
+		}
+	}
+	return result
+}
+
+// tinhGiam tính số tiền giảm của khuyến mãi và "chiếm" các phần món đã dùng
+// conLai là số tiền đơn còn lại sau các khuyến mãi trước đó
+func (k *KhuyenMai) tinhGiam(phan []*phanMon, conLai int64) int64 {
+	var giam int64
+
+	switch k.Loai {
+	case KMGiamPhanTramMon:
+		for _, p := range phan {
+			if p.conLai > 0 && k.apDungChoMon(p.monAnID) {
+				giam += p.donGia * k.GiaTri / 100 * int64(p.conLai)
+				p.conLai = 0
+			}
+		}
+	case KMGiamTienMon:
+		for _, p := range phan {
+			if p.conLai > 0 && k.apDungChoMon(p.monAnID) {
+				giam += min(k.GiaTri, p.donGia) * int64(p.conLai)
+				p.conLai = 0
+			}
+		}
+	case KMMuaXTangY:
+		daXet := make(map[string]bool)
+		for _, p := range phan {
+			if daXet[p.monAnID] || !k.apDungChoMon(p.monAnID) {
+				continue
+			}
+			daXet[p.monAnID] = true
+			giam += k.tinhGiamMuaXTangY(phan, p.monAnID)
+		}
+	case KMCombo:
+		giam = k.tinhGiamCombo(phan)
+	case KMGiamPhanTramDon:
+		giam = conLai * k.GiaTri / 100
+	case KMGiamTienDon:
+		giam = k.GiaTri
+	}
+
+	// Trần số tiền giảm chỉ dành cho khuyến mãi theo %
+	if k.laKhuyenMaiPhanTram() && k.GiamToiDa > 0 && giam > k.GiamToiDa {
+		giam = k.GiamToiDa
+	}
+	return giam
+}
+
+// tinhGiamMuaXTangY tính giảm mua X tặng Y cho một món, gộp mọi mức giá của món đó
+// Phần được tặng là các phần rẻ nhất; các phần đã tính vào nhóm (mua + tặng) bị chiếm
+func (k *KhuyenMai) tinhGiamMuaXTangY(phan []*phanMon, monAnID string) int64 {
+	moiNhom := k.SoLuongMua + k.SoLuongTang
+	if moiNhom <= 0 {
+		return 0
+	}
+
+	tongPhan := 0
+	for _, p := range phan {
+		if p.monAnID == monAnID {
+			tongPhan += p.conLai
+		}
+	}
+	soNhom := tongPhan / moiNhom
+	if soNhom == 0 {
+		return 0
+	}
+
+	var giam int64
+	soTang := soNhom * k.SoLuongTang
+	for i := 0; i < soNhom*moiNhom; i++ {
+		p := phanReNhat(phan, monAnID)
+		if i < soTang {
+			giam += p.donGia
+		}
+		p.conLai--
+	}
+	return giam
+}
+
+// tinhGiamCombo ghép lần lượt từng combo từ các phần món rẻ nhất còn lại
+// Dừng khi không đủ món hoặc giá lẻ của combo kế tiếp không cao hơn giá combo
+func (k *KhuyenMai) tinhGiamCombo(phan []*phanMon) int64 {
+	if len(k.MonAnIDs) == 0 {
+		return 0
+	}
+
+	var giam int64
+	for {
+		var daLay []*phanMon
+		var giaLe int64
+		for _, id := range k.MonAnIDs {
+			p := phanReNhat(phan, id)
+			if p == nil {
+				break
+			}
+			p.conLai--
+			daLay = append(daLay, p)
+			giaLe += p.donGia
+		}
+
+		if len(daLay) < len(k.MonAnIDs) || giaLe <= k.GiaCombo {
+			for _, p := range daLay {
+				p.conLai++
+			}
+			return giam
+		}
+		giam += giaLe - k.GiaCombo
+	}
+}
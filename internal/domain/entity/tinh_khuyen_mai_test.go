@@ -0,0 +1,182 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChonKhuyenMaiTotNhat(t *testing.T) {
+	now := time.Date(2026, 1, 24, 12, 0, 0, 0, time.UTC)
+
+	// Đơn mẫu: 2 phở (50.000) + 1 trà (20.000) = 120.000
+	items := []OrderItem{
+		{MonAnID: "pho", SoLuong: 2, DonGia: 50000, ThanhTien: 100000},
+		{MonAnID: "tra", SoLuong: 1, DonGia: 20000, ThanhTien: 20000},
+	}
+
+	km := func(id string, loai LoaiKhuyenMai, giaTri int64, congDon bool, uuTien int, monAnIDs ...string) *KhuyenMai {
+		return &KhuyenMai{
+			ID:           id,
+			Ten:          id,
+			Loai:         loai,
+			GiaTri:       giaTri,
+			MonAnIDs:     monAnIDs,
+			CongDon:      congDon,
+			UuTien:       uuTien,
+			DangHoatDong: true,
+		}
+	}
+	voiGiamToiDa := func(k *KhuyenMai, giamToiDa int64) *KhuyenMai {
+		k.GiamToiDa = giamToiDa
+		return k
+	}
+	muaXTangY := km("mua1tang1", KMMuaXTangY, 0, false, 0, "pho")
+	muaXTangY.SoLuongMua, muaXTangY.SoLuongTang = 1, 1
+	donToiThieu := km("don-lon", KMGiamTienDon, 50000, false, 0)
+	donToiThieu.DonToiThieu = 200000
+	ngungHoatDong := km("tat", KMGiamPhanTramDon, 90, false, 0)
+	ngungHoatDong.DangHoatDong = false
+
+	tests := []struct {
+		name         string
+		dsKhuyenMai  []*KhuyenMai
+		wantTongGiam int64
+		wantApDung   []string
+	}{
+		{
+			name:         "không có khuyến mãi",
+			wantTongGiam: 0,
+		},
+		{
+			name: "độc quyền giảm nhiều hơn bộ cộng dồn",
+			dsKhuyenMai: []*KhuyenMai{
+				km("doc-quyen-30", KMGiamPhanTramDon, 30, false, 0),
+				km("tien-mon", KMGiamTienMon, 10000, true, 0),
+				km("tien-don", KMGiamTienDon, 5000, true, 0),
+			},
+			wantTongGiam: 36000,
+			wantApDung:   []string{"doc-quyen-30"},
+		},
+		{
+			name: "bộ cộng dồn giảm nhiều hơn độc quyền",
+			dsKhuyenMai: []*KhuyenMai{
+				km("doc-quyen-30", KMGiamPhanTramDon, 30, false, 0),
+				km("tien-mon", KMGiamTienMon, 10000, true, 0),
+				km("tien-don", KMGiamTienDon, 10000, true, 0),
+			},
+			wantTongGiam: 40000,
+			wantApDung:   []string{"tien-mon", "tien-don"},
+		},
+		{
+			name: "hòa thì ưu tiên cộng dồn",
+			dsKhuyenMai: []*KhuyenMai{
+				km("doc-quyen-30", KMGiamPhanTramDon, 30, false, 0),
+				km("tien-mon", KMGiamTienMon, 10000, true, 0),
+				km("tien-don", KMGiamTienDon, 6000, true, 0),
+			},
+			wantTongGiam: 36000,
+			wantApDung:   []string{"tien-mon", "tien-don"},
+		},
+		{
+			// Theo ưu tiên: 10% chiếm mọi phần món (12.000), 50% phở không còn phần nào.
+			// Tốt nhất: 50% phở trước (50.000) rồi 10% cho trà (2.000)
+			name: "không áp dụng tham lam theo ưu tiên",
+			dsKhuyenMai: []*KhuyenMai{
+				km("10-moi-mon", KMGiamPhanTramMon, 10, true, 10),
+				km("50-pho", KMGiamPhanTramMon, 50, true, 1, "pho"),
+			},
+			wantTongGiam: 52000,
+			wantApDung:   []string{"50-pho", "10-moi-mon"},
+		},
+		{
+			name: "giảm % trên đơn bị giới hạn bởi GiamToiDa",
+			dsKhuyenMai: []*KhuyenMai{
+				voiGiamToiDa(km("50-don", KMGiamPhanTramDon, 50, false, 0), 10000),
+			},
+			wantTongGiam: 10000,
+			wantApDung:   []string{"50-don"},
+		},
+		{
+			name: "giảm % trên món bị giới hạn bởi GiamToiDa",
+			dsKhuyenMai: []*KhuyenMai{
+				voiGiamToiDa(km("50-pho", KMGiamPhanTramMon, 50, false, 0, "pho"), 15000),
+			},
+			wantTongGiam: 15000,
+			wantApDung:   []string{"50-pho"},
+		},
+		{
+			name: "giảm tiền trên đơn không bị GiamToiDa giới hạn",
+			dsKhuyenMai: []*KhuyenMai{
+				voiGiamToiDa(km("30k-don", KMGiamTienDon, 30000, false, 0), 10000),
+			},
+			wantTongGiam: 30000,
+			wantApDung:   []string{"30k-don"},
+		},
+		{
+			name:         "mua X tặng Y không bị GiamToiDa giới hạn",
+			dsKhuyenMai:  []*KhuyenMai{voiGiamToiDa(muaXTangY, 10000)},
+			wantTongGiam: 50000,
+			wantApDung:   []string{"mua1tang1"},
+		},
+		{
+			name:         "bỏ qua khuyến mãi chưa đạt đơn tối thiểu hoặc ngừng hoạt động",
+			dsKhuyenMai:  []*KhuyenMai{donToiThieu, ngungHoatDong},
+			wantTongGiam: 0,
+		},
+		{
+			name: "tổng giảm không vượt quá tổng đơn",
+			dsKhuyenMai: []*KhuyenMai{
+				km("100k-don", KMGiamTienDon, 100000, true, 0),
+				km("50k-don", KMGiamTienDon, 50000, true, 0),
+			},
+			wantTongGiam: 120000,
+			wantApDung:   []string{"100k-don", "50k-don"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ChonKhuyenMaiTotNhat(items, tt.dsKhuyenMai, now)
+
+			if got.TongGiam != tt.wantTongGiam {
+				t.Errorf("TongGiam = %d, want %d", got.TongGiam, tt.wantTongGiam)
+			}
+
+			var tong int64
+			ids := make([]string, len(got.ApDung))
+			for i, ap := range got.ApDung {
+				ids[i] = ap.KhuyenMaiID
+				tong += ap.SoTienGiam
+			}
+			if tong != got.TongGiam {
+				t.Errorf("sum of ApDung = %d, TongGiam = %d", tong, got.TongGiam)
+			}
+			if len(ids) != len(tt.wantApDung) {
+				t.Fatalf("ApDung = %v, want %v", ids, tt.wantApDung)
+			}
+			for i := range ids {
+				if ids[i] != tt.wantApDung[i] {
+					t.Fatalf("ApDung = %v, want %v", ids, tt.wantApDung)
+				}
+			}
+		})
+	}
+}
+
+func TestChonKhuyenMaiTotNhat_XacDinh(t *testing.T) {
+	now := time.Date(2026, 1, 24, 12, 0, 0, 0, time.UTC)
+	items := []OrderItem{{MonAnID: "pho", SoLuong: 3, DonGia: 40000, ThanhTien: 120000}}
+
+	// Hai khuyến mãi giảm bằng nhau: luôn chọn theo thứ tự ưu tiên rồi ID
+	ds := []*KhuyenMai{
+		{ID: "b", Ten: "b", Loai: KMGiamTienDon, GiaTri: 10000, UuTien: 1, DangHoatDong: true},
+		{ID: "a", Ten: "a", Loai: KMGiamTienDon, GiaTri: 10000, UuTien: 1, DangHoatDong: true},
+	}
+
+	for i := 0; i < 10; i++ {
+		got := ChonKhuyenMaiTotNhat(items, ds, now)
+		if len(got.ApDung) != 1 || got.ApDung[0].KhuyenMaiID != "a" {
+			t.Fatalf("run %d: ApDung = %+v, want [a]", i, got.ApDung)
+		}
+	}
+}
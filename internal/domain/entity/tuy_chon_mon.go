@@ -0,0 +1,114 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LuaChonTuyChon là một lựa chọn trong nhóm tùy chọn (Value Object)
+// VD: size "L" (+10.000đ), "Thêm bò" (+25.000đ), "Không hành" (0đ)
+type LuaChonTuyChon struct {
+	ID      string // Mã lựa chọn, duy nhất trong nhóm (VD: "l", "them-bo")
+	Ten     string // Tên hiển thị
+	GiaThem int64  // Giá cộng thêm mỗi phần (có thể âm, VD size nhỏ)
+}
+
+// NhomTuyChon là một nhóm tùy chọn của món (Value Object)
+// VD: "Size" (bắt buộc chọn đúng 1), "Topping" (tùy chọn, tối đa 3)
+//
+// Quy ước:
+//   - ChonToiThieu > 0 nghĩa là nhóm bắt buộc
+//   - ChonToiDa là số lựa chọn tối đa trong nhóm (>= 1)
+type NhomTuyChon struct {
+	ID           string           // Mã nhóm, duy nhất trong món (VD: "size")
+	Ten          string           // Tên hiển thị
+	ChonToiThieu int              // Số lựa chọn tối thiểu (0 = không bắt buộc)
+	ChonToiDa    int              // Số lựa chọn tối đa
+	LuaChon      []LuaChonTuyChon // Các lựa chọn
+}
+
+// TuyChonDaChon là snapshot một lựa chọn khách đã chọn trên OrderItem
+// Giữ cả tên và giá để đơn cũ không đổi khi menu thay đổi
+type TuyChonDaChon struct {
+	NhomID     string // Mã nhóm
+	TenNhom    string // Tên nhóm tại thời điểm đặt
+	LuaChonID  string // Mã lựa chọn
+	TenLuaChon string // Tên lựa chọn tại thời điểm đặt
+	GiaThem    int64  // Giá cộng thêm mỗi phần tại thời điểm đặt
+}
+
+// NewNhomTuyChon tạo NhomTuyChon mới với validation
+func NewNhomTuyChon(id, ten string, chonToiThieu, chonToiDa int, luaChon []LuaChonTuyChon) (NhomTuyChon, error) {
+	if id == "" || ten == "" {
+		return NhomTuyChon{}, errors.New("mã và tên nhóm tùy chọn không được để trống")
+	}
+	if len(luaChon) == 0 {
+		return NhomTuyChon{}, fmt.Errorf("nhóm %q phải có ít nhất một lựa chọn", ten)
+	}
+	if chonToiThieu < 0 || chonToiDa < 1 || chonToiThieu > chonToiDa || chonToiDa > len(luaChon) {
+		return NhomTuyChon{}, fmt.Errorf("số lựa chọn tối thiểu/tối đa của nhóm %q không hợp lệ", ten)
+	}
+
+	daCo := make(map[string]bool, len(luaChon))
+	for _, lc := range luaChon {
+		if lc.ID == "" || lc.Ten == "" {
+			return NhomTuyChon{}, fmt.Errorf("lựa chọn trong nhóm %q phải có mã và tên", ten)
+		}
+		if daCo[lc.ID] {
+			return NhomTuyChon{}, fmt.Errorf("lựa chọn %q bị trùng trong nhóm %q", lc.ID, ten)
+		}
+		daCo[lc.ID] = true
+	}
+
+	return NhomTuyChon{
+		ID:           id,
+		Ten:          ten,
+		ChonToiThieu: chonToiThieu,
+		ChonToiDa:    chonToiDa,
+		LuaChon:      luaChon,
+	}, nil
+}
+
+// BatBuoc kiểm tra nhóm có bắt buộc chọn không
+func (n NhomTuyChon) BatBuoc() bool {
+	return n.ChonToiThieu > 0
+}
+
+// chon kiểm tra các lựa chọn của khách trong nhóm và trả về snapshot
+// Kết quả theo thứ tự lựa chọn trong nhóm (không phụ thuộc thứ tự khách gửi)
+func (n NhomTuyChon) chon(ids []string) ([]TuyChonDaChon, error) {
+	daChon := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if daChon[id] {
+			return nil, fmt.Errorf("lựa chọn %q bị chọn trùng trong nhóm %q", id, n.Ten)
+		}
+		daChon[id] = true
+	}
+	if len(daChon) < n.ChonToiThieu {
+		return nil, fmt.Errorf("nhóm %q phải chọn ít nhất %d lựa chọn", n.Ten, n.ChonToiThieu)
+	}
+	if len(daChon) > n.ChonToiDa {
+		return nil, fmt.Errorf("nhóm %q chỉ được chọn tối đa %d lựa chọn", n.Ten, n.ChonToiDa)
+	}
+
+	var result []TuyChonDaChon
+	for _, lc := range n.LuaChon {
+		if !daChon[lc.ID] {
+			continue
+		}
+		delete(daChon, lc.ID)
+		result = append(result, TuyChonDaChon{
+			NhomID:     n.ID,
+			TenNhom:    n.Ten,
+			LuaChonID:  lc.ID,
+			TenLuaChon: lc.Ten,
+			GiaThem:    lc.GiaThem,
+		})
+	}
+	for id := range daChon {
+		return nil, fmt.Errorf("lựa chọn %q không có trong nhóm %q", id, n.Ten)
+	}
+
+	return result, nil
+}
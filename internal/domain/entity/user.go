@@ -12,10 +12,25 @@ type UserRole string
 const (
 	RoleAdmin    UserRole = "admin"     // Quản trị viên
 	RoleManager  UserRole = "manager"   // Quản lý
-	RoleStaff    UserRole = "staff"     // Nhân viên
+	RoleStaff    UserRole = "staff"     // Nhân viên phục vụ
+	RoleCashier  UserRole = "cashier"   // Thu ngân
+	RoleChef     UserRole = "chef"      // Đầu bếp
 	RoleCustomer UserRole = "customer"  // Khách hàng
 )
 
+// AllRoles là danh sách role hợp lệ (khớp ENUM users.role)
+var AllRoles = []UserRole{RoleAdmin, RoleManager, RoleStaff, RoleCashier, RoleChef, RoleCustomer}
+
+// IsValid kiểm tra role có hợp lệ không
+func (r UserRole) IsValid() bool {
+	for _, role := range AllRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 // User là Entity đại diện cho tài khoản người dùng
 // Lưu trong MySQL vì:
 // - Dữ liệu ổn định, ít thay đổi schema
@@ -31,6 +46,7 @@ type User struct {
 	IsEmailVerified bool      // Email đã được xác thực chưa
 	NgayTao         time.Time // Ngày tạo tài khoản
 	NgayCapNhat     time.Time // Ngày cập nhật cuối
+	Version         int64     // Phiên bản để kiểm soát ghi đồng thời (0 = chưa lưu, tăng mỗi lần lưu)
 }
 
 // NewUser tạo một User mới với validation
@@ -59,6 +75,35 @@ func NewUser(id, username, email, passwordHash string, role UserRole) (*User, er
 	}, nil
 }
 
+// NewExternalUser tạo User đăng ký bằng tài khoản bên ngoài (OpenID Connect)
+// Email đã được provider xác thực; chưa có mật khẩu (có thể đặt sau qua quên mật khẩu)
+func NewExternalUser(id, username, email string, role UserRole) (*User, error) {
+	if username == "" {
+		return nil, errors.New("username không được để trống")
+	}
+	if email == "" {
+		return nil, errors.New("email không được để trống")
+	}
+
+	now := time.Now()
+	return &User{
+		ID:              id,
+		Username:        username,
+		Email:           email,
+		Role:            role,
+		IsActive:        true,
+		IsEmailVerified: true,
+		NgayTao:         now,
+		NgayCapNhat:     now,
+	}, nil
+}
+
+// HasPassword kiểm tra user có đăng nhập được bằng mật khẩu không
+// User tạo từ tài khoản bên ngoài chưa có mật khẩu cho tới khi tự đặt
+func (u *User) HasPassword() bool {
+	return u.PasswordHash != ""
+}
+
 // IsAdmin kiểm tra user có phải admin không
 func (u *User) IsAdmin() bool {
 	return u.Role == RoleAdmin
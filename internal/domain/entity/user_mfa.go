@@ -0,0 +1,34 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import "time"
+
+// UserMFA là cấu hình xác thực hai lớp (TOTP) của một user
+// Tạo khi user bắt đầu đăng ký, chỉ có hiệu lực khi đăng nhập sau khi DaKichHoat = true
+type UserMFA struct {
+	UserID       string     // User sở hữu
+	Secret       string     // TOTP secret (base32)
+	DaKichHoat   bool       // false = đang đăng ký, chưa xác nhận mã đầu tiên
+	MaKhoiPhuc   []string   // SHA-256 (hex) của các mã khôi phục chưa dùng
+	BuocCuoi     int64      // Bước TOTP đã dùng gần nhất, mã cùng bước hoặc cũ hơn bị từ chối
+	NgayTao      time.Time  // Ngày bắt đầu đăng ký
+	NgayKichHoat *time.Time // Ngày kích hoạt (nil = chưa kích hoạt)
+}
+
+// NewUserMFA tạo cấu hình TOTP mới ở trạng thái chờ xác nhận
+func NewUserMFA(userID, secret string, maKhoiPhuc []string) *UserMFA {
+	return &UserMFA{
+		UserID:     userID,
+		Secret:     secret,
+		MaKhoiPhuc: maKhoiPhuc,
+		NgayTao:    time.Now(),
+	}
+}
+
+// KichHoat đánh dấu đã xác nhận mã đầu tiên, buoc là bước TOTP của mã đó
+func (m *UserMFA) KichHoat(buoc int64) {
+	now := time.Now()
+	m.DaKichHoat = true
+	m.BuocCuoi = buoc
+	m.NgayKichHoat = &now
+}
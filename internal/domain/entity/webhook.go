@@ -0,0 +1,239 @@
+// Package entity chứa các Domain Entity
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// DoDaiSecretToiThieu là độ dài tối thiểu của secret ký webhook
+const DoDaiSecretToiThieu = 16
+
+// SuKienWebhook là các loại sự kiện được phép gửi ra ngoài qua webhook
+// Sự kiện nội bộ (user.deactivated...) không nằm trong danh sách này
+var SuKienWebhook = []LoaiSuKien{
+	SuKienOrderCreated,
+	SuKienOrderStatusChanged,
+	SuKienMonAnAvailabilityChanged,
+}
+
+// Webhook là endpoint của bên thứ ba (đối tác giao hàng, phần mềm kế toán...) nhận sự kiện qua HTTP POST
+// Lưu trong MySQL; Secret dùng để ký HMAC-SHA256 nên phải lưu dạng gốc (không hash được)
+type Webhook struct {
+	ID           string       // UUID
+	Ten          string       // Tên hiển thị (vd: "Đối tác giao hàng A")
+	URL          string       // Endpoint nhận sự kiện (http/https)
+	SuKien       []LoaiSuKien // Bộ lọc: chỉ gửi các loại sự kiện này
+	Secret       string       // Secret ký HMAC-SHA256, không bao giờ trả về qua API
+	DangHoatDong bool         // false = tạm dừng gửi
+	NgayTao      time.Time
+	NgayCapNhat  time.Time
+}
+
+// NewWebhook tạo webhook mới (đang hoạt động)
+func NewWebhook(id, ten, diaChi string, suKien []LoaiSuKien, secret string) (*Webhook, error) {
+	if ten == "" {
+		return nil, errors.New("tên webhook không được để trống")
+	}
+
+	now := time.Now()
+	w := &Webhook{
+		ID:           id,
+		Ten:          ten,
+		DangHoatDong: true,
+		NgayTao:      now,
+		NgayCapNhat:  now,
+	}
+	if err := w.DatURL(diaChi); err != nil {
+		return nil, err
+	}
+	if err := w.DatSuKien(suKien); err != nil {
+		return nil, err
+	}
+	if err := w.DatSecret(secret); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// DatURL đổi endpoint nhận sự kiện
+func (w *Webhook) DatURL(diaChi string) error {
+	u, err := url.Parse(diaChi)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("URL webhook không hợp lệ: %q (cần http:// hoặc https://)", diaChi)
+	}
+
+	w.URL = diaChi
+	w.NgayCapNhat = time.Now()
+	return nil
+}
+
+// DatSuKien đổi bộ lọc sự kiện (bỏ trùng, giữ thứ tự)
+func (w *Webhook) DatSuKien(suKien []LoaiSuKien) error {
+	if len(suKien) == 0 {
+		return errors.New("webhook phải đăng ký ít nhất một loại sự kiện")
+	}
+
+	list := make([]LoaiSuKien, 0, len(suKien))
+	for _, loai := range suKien {
+		if !slices.Contains(SuKienWebhook, loai) {
+			return fmt.Errorf("loại sự kiện không hỗ trợ webhook: %s", loai)
+		}
+		if !slices.Contains(list, loai) {
+			list = append(list, loai)
+		}
+	}
+
+	w.SuKien = list
+	w.NgayCapNhat = time.Now()
+	return nil
+}
+
+// DatSecret đổi secret ký webhook
+func (w *Webhook) DatSecret(secret string) error {
+	if len(secret) < DoDaiSecretToiThieu {
+		return fmt.Errorf("secret webhook phải có ít nhất %d ký tự", DoDaiSecretToiThieu)
+	}
+
+	w.Secret = secret
+	w.NgayCapNhat = time.Now()
+	return nil
+}
+
+// TamDung tạm dừng gửi sự kiện tới webhook
+func (w *Webhook) TamDung() {
+	w.DangHoatDong = false
+	w.NgayCapNhat = time.Now()
+}
+
+// KichHoat gửi sự kiện tới webhook trở lại
+func (w *Webhook) KichHoat() {
+	w.DangHoatDong = true
+	w.NgayCapNhat = time.Now()
+}
+
+// NhanSuKien kiểm tra webhook có nhận loại sự kiện này không
+func (w *Webhook) NhanSuKien(loai LoaiSuKien) bool {
+	return w.DangHoatDong && slices.Contains(w.SuKien, loai)
+}
+
+// KyWebhook tính chữ ký gửi kèm header X-Webhook-Signature: "sha256=" + hex(HMAC-SHA256(secret, "<timestamp>.<body>"))
+// Bên nhận tính lại với cùng secret, so sánh hằng thời gian và từ chối timestamp quá cũ để chống replay
+func KyWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ============================================
+// WEBHOOK DELIVERY - nhật ký gửi
+// ============================================
+
+// TrangThaiDelivery định nghĩa trạng thái của một lần gửi sự kiện tới webhook
+type TrangThaiDelivery string
+
+const (
+	DeliveryDangCho   TrangThaiDelivery = "dang_cho"   // Chờ gửi hoặc đang chờ thử lại
+	DeliveryThanhCong TrangThaiDelivery = "thanh_cong" // Endpoint trả về 2xx
+	DeliveryThatBai   TrangThaiDelivery = "that_bai"   // Hết lượt thử lại (gửi lại thủ công được)
+)
+
+// IsValid kiểm tra trạng thái delivery hợp lệ
+func (t TrangThaiDelivery) IsValid() bool {
+	switch t {
+	case DeliveryDangCho, DeliveryThanhCong, DeliveryThatBai:
+		return true
+	}
+	return false
+}
+
+// WebhookEnvelope là body JSON gửi tới webhook
+type WebhookEnvelope struct {
+	ID     string          `json:"id"` // ID sự kiện: giống nhau giữa các webhook và các lần gửi lại
+	Loai   LoaiSuKien      `json:"loai"`
+	TaoLuc time.Time       `json:"tao_luc"`
+	DuLieu json.RawMessage `json:"du_lieu"`
+}
+
+// WebhookDelivery là một lần gửi sự kiện tới một webhook (kèm kết quả các lần thử)
+// Lưu trong MongoDB (nhật ký chỉ ghi thêm và cập nhật trạng thái, tra cứu theo webhook)
+type WebhookDelivery struct {
+	ID           string            // Xác định từ webhook + sự kiện: phát lại sự kiện không tạo delivery trùng
+	WebhookID    string            // Webhook nhận
+	SuKienID     string            // Sự kiện gốc trong outbox
+	Loai         LoaiSuKien        // Loại sự kiện
+	DuLieu       []byte            // Body JSON đã gửi (WebhookEnvelope), giữ nguyên khi gửi lại
+	TrangThai    TrangThaiDelivery // Trạng thái hiện tại
+	SoLanThu     int               // Tổng số lần đã gửi (kể cả gửi lại thủ công)
+	MaPhanHoi    int               // HTTP status của lần gửi gần nhất (0 = không kết nối được)
+	LoiCuoi      string            // Lỗi của lần gửi thất bại gần nhất
+	TaoLuc       time.Time
+	CapNhatLuc   time.Time
+	ThanhCongLuc *time.Time // Thời điểm gửi thành công (nil = chưa)
+}
+
+// NewWebhookDelivery tạo delivery chờ gửi cho sự kiện
+func NewWebhookDelivery(id, webhookID string, suKien *SuKien) (*WebhookDelivery, error) {
+	body, err := json.Marshal(WebhookEnvelope{
+		ID:     suKien.ID,
+		Loai:   suKien.Loai,
+		TaoLuc: suKien.TaoLuc,
+		DuLieu: suKien.DuLieu,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("không thể encode webhook %s: %w", suKien.Loai, err)
+	}
+
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:         id,
+		WebhookID:  webhookID,
+		SuKienID:   suKien.ID,
+		Loai:       suKien.Loai,
+		DuLieu:     body,
+		TrangThai:  DeliveryDangCho,
+		TaoLuc:     now,
+		CapNhatLuc: now,
+	}, nil
+}
+
+// GhiThanhCong ghi nhận lần gửi thành công
+func (d *WebhookDelivery) GhiThanhCong(maPhanHoi int) {
+	now := time.Now()
+	d.SoLanThu++
+	d.MaPhanHoi = maPhanHoi
+	d.LoiCuoi = ""
+	d.TrangThai = DeliveryThanhCong
+	d.ThanhCongLuc = &now
+	d.CapNhatLuc = now
+}
+
+// GhiThatBai ghi nhận lần gửi thất bại
+// hetLuot = true: không còn lần thử lại tự động, chuyển sang thất bại
+func (d *WebhookDelivery) GhiThatBai(maPhanHoi int, loi string, hetLuot bool) {
+	d.SoLanThu++
+	d.MaPhanHoi = maPhanHoi
+	d.LoiCuoi = loi
+	if hetLuot {
+		d.TrangThai = DeliveryThatBai
+	}
+	d.CapNhatLuc = time.Now()
+}
+
+// GuiLai đưa delivery về trạng thái chờ gửi (gửi lại thủ công, kể cả đã thành công)
+// SoLanThu giữ nguyên: là tổng số lần gửi, không phải số lượt thử lại còn lại
+func (d *WebhookDelivery) GuiLai() {
+	d.TrangThai = DeliveryDangCho
+	d.CapNhatLuc = time.Now()
+}
@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"testing"
+)
+
+func TestKyWebhook(t *testing.T) {
+	// Giá trị mong đợi tính độc lập bằng HMAC-SHA256(secret, "<timestamp>.<body>")
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{
+			name:      "body JSON",
+			secret:    "whsec_0123456789abcdef",
+			timestamp: 1700000000,
+			body:      `{"id":"sk-1"}`,
+			want:      "sha256=69f4b3581814c0f2442283ea8fdbb222e7e2a33381dcdaca38ac24a17c63061b",
+		},
+		{
+			name:      "body rỗng",
+			secret:    "whsec_0123456789abcdef",
+			timestamp: 1700000000,
+			body:      "",
+			want:      "sha256=0e1fd9afd303fb6f213cc511093ec9783e6286d777cfee89f917aebd54d9a963",
+		},
+		{
+			name:      "timestamp nằm trong nội dung ký",
+			secret:    "key",
+			timestamp: 1,
+			body:      "The quick brown fox jumps over the lazy dog",
+			want:      "sha256=3ff4d3cc115b639a16dc5b217aa5c89be41d1e4b54efc356d63d0cd2a65b30f1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KyWebhook(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("KyWebhook() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKyWebhook_ThayDoiNoiDung(t *testing.T) {
+	goc := KyWebhook("whsec_0123456789abcdef", 1700000000, []byte(`{"id":"sk-1"}`))
+
+	// Bên nhận phải thấy chữ ký khác khi bất kỳ phần nào bị sửa (secret, timestamp replay, body)
+	khac := map[string]string{
+		"secret khác":    KyWebhook("whsec_fedcba9876543210", 1700000000, []byte(`{"id":"sk-1"}`)),
+		"timestamp khác": KyWebhook("whsec_0123456789abcdef", 1700000001, []byte(`{"id":"sk-1"}`)),
+		"body khác":      KyWebhook("whsec_0123456789abcdef", 1700000000, []byte(`{"id":"sk-2"}`)),
+	}
+	for name, sig := range khac {
+		if hmac.Equal([]byte(sig), []byte(goc)) {
+			t.Errorf("%s: signature unchanged", name)
+		}
+	}
+}
+
+func TestWebhookDelivery_GuiLaiGiuSoLanThu(t *testing.T) {
+	sk, err := NewSuKien("sk-1", SuKienOrderCreated, "order-1", OrderCreatedPayload{OrderID: "order-1"})
+	if err != nil {
+		t.Fatalf("NewSuKien() error = %v", err)
+	}
+	d, err := NewWebhookDelivery("d-1", "wh-1", sk)
+	if err != nil {
+		t.Fatalf("NewWebhookDelivery() error = %v", err)
+	}
+
+	d.GhiThatBai(500, "endpoint trả về HTTP 500", true)
+	d.GuiLai()
+	if d.TrangThai != DeliveryDangCho || d.SoLanThu != 1 {
+		t.Errorf("after GuiLai: TrangThai = %s, SoLanThu = %d, want %s, 1", d.TrangThai, d.SoLanThu, DeliveryDangCho)
+	}
+
+	d.GhiThanhCong(200)
+	if d.TrangThai != DeliveryThanhCong || d.SoLanThu != 2 || d.LoiCuoi != "" {
+		t.Errorf("after success: %+v", d)
+	}
+}
@@ -0,0 +1,30 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// AuditLogFilter là điều kiện lọc audit log, trường rỗng/nil thì bỏ qua
+type AuditLogFilter struct {
+	ActorID    string
+	HanhDong   string
+	DoiTuong   string
+	DoiTuongID string
+	Tu         *time.Time
+	Den        *time.Time
+}
+
+// IAuditLogRepository là interface định nghĩa các thao tác với audit log
+// Implementation: MongoDB (append-only, snapshot trước/sau có schema linh hoạt)
+// Không có thao tác sửa/xóa: audit log chỉ được ghi thêm
+type IAuditLogRepository interface {
+	// Append ghi thêm một bản ghi audit log
+	Append(ctx context.Context, log *entity.AuditLog) error
+
+	// Find lấy audit log theo điều kiện lọc có phân trang, mới nhất trước
+	Find(ctx context.Context, filter AuditLogFilter, offset, limit int) ([]*entity.AuditLog, int64, error)
+}
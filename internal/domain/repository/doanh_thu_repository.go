@@ -0,0 +1,19 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IDoanhThuRepository là interface định nghĩa các thao tác với snapshot doanh thu theo ngày
+// Implementation: MongoDB (cùng database với Order)
+type IDoanhThuRepository interface {
+	// Save lưu snapshot, ghi đè snapshot cũ cùng ngày
+	Save(ctx context.Context, dt *entity.DoanhThuNgay) error
+
+	// FindByKhoang lấy snapshot từ ngày tuNgay đến denNgay (YYYY-MM-DD, bao gồm cả hai đầu)
+	// Sắp xếp theo ngày tăng dần
+	FindByKhoang(ctx context.Context, tuNgay, denNgay string) ([]*entity.DoanhThuNgay, error)
+}
@@ -0,0 +1,31 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IDonDatHangRepository là interface định nghĩa các thao tác với dữ liệu DonDatHang
+// Implementation: MySQL (header + items cần lưu trong cùng transaction)
+type IDonDatHangRepository interface {
+	// FindByID tìm đơn đặt hàng theo ID (kèm items)
+	FindByID(ctx context.Context, id string) (*entity.DonDatHang, error)
+
+	// FindByIDForUpdate tìm đơn đặt hàng (kèm items) và khóa dòng header tới hết transaction
+	// Phải gọi trong IUnitOfWork để khóa có tác dụng
+	FindByIDForUpdate(ctx context.Context, id string) (*entity.DonDatHang, error)
+
+	// FindAll lấy tất cả đơn đặt hàng
+	FindAll(ctx context.Context) ([]*entity.DonDatHang, error)
+
+	// FindByTrangThai lấy đơn đặt hàng theo trạng thái
+	FindByTrangThai(ctx context.Context, trangThai entity.TrangThaiDonDatHang) ([]*entity.DonDatHang, error)
+
+	// FindDangCho lấy các đơn đã gửi nhưng chưa nhận đủ hàng
+	FindDangCho(ctx context.Context) ([]*entity.DonDatHang, error)
+
+	// Save lưu đơn đặt hàng mới hoặc cập nhật (kèm items)
+	Save(ctx context.Context, donDatHang *entity.DonDatHang) error
+}
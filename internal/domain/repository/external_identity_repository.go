@@ -0,0 +1,29 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IExternalIdentityRepository là interface lưu liên kết tài khoản bên ngoài của user
+// Implementation: MySQL (cùng database với users, xóa theo user)
+type IExternalIdentityRepository interface {
+	// FindByProviderSubject tìm liên kết theo provider và subject (nil nếu chưa liên kết)
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.ExternalIdentity, error)
+
+	// FindByUserID lấy các tài khoản bên ngoài đã liên kết với user
+	FindByUserID(ctx context.Context, userID string) ([]*entity.ExternalIdentity, error)
+
+	// Create tạo liên kết mới
+	// Trả về ErrDuplicateEntry nếu tài khoản bên ngoài đã liên kết hoặc user đã liên kết provider này
+	Create(ctx context.Context, identity *entity.ExternalIdentity) error
+
+	// Delete hủy liên kết provider của user, trả về false nếu chưa liên kết
+	Delete(ctx context.Context, userID, provider string) (bool, error)
+
+	// GhiNhanDangNhap cập nhật thời điểm đăng nhập gần nhất bằng tài khoản bên ngoài
+	GhiNhanDangNhap(ctx context.Context, id string, thoiDiem time.Time) error
+}
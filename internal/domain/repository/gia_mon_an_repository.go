@@ -0,0 +1,41 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ILichSuGiaRepository là interface định nghĩa các thao tác với lịch sử giá món ăn
+// Implementation: MongoDB (cùng database với MonAn)
+// Chỉ ghi thêm: lịch sử giá không được sửa/xóa
+type ILichSuGiaRepository interface {
+	// Append ghi thêm một lần đổi giá
+	Append(ctx context.Context, lichSu *entity.LichSuGia) error
+
+	// FindByMonAnID lấy lịch sử giá của món theo thứ tự thời gian
+	// tu/den giới hạn theo HieuLucTu (nil = không giới hạn)
+	FindByMonAnID(ctx context.Context, monAnID string, tu, den *time.Time) ([]*entity.LichSuGia, error)
+}
+
+// ILichDoiGiaRepository là interface định nghĩa các thao tác với lịch đổi giá món ăn
+// Implementation: MongoDB (cùng database với MonAn)
+type ILichDoiGiaRepository interface {
+	// FindByID tìm lịch đổi giá theo ID
+	FindByID(ctx context.Context, id string) (*entity.LichDoiGia, error)
+
+	// FindByMonAnID lấy các lịch đổi giá của món, theo thời điểm áp dụng
+	FindByMonAnID(ctx context.Context, monAnID string) ([]*entity.LichDoiGia, error)
+
+	// FindDenHan lấy các lịch đang chờ có thời điểm áp dụng <= now, theo thời điểm áp dụng
+	FindDenHan(ctx context.Context, now time.Time) ([]*entity.LichDoiGia, error)
+
+	// Save lưu lịch đổi giá mới hoặc cập nhật
+	Save(ctx context.Context, lich *entity.LichDoiGia) error
+
+	// ChuyenTrangThai lưu trạng thái mới của lịch (đã áp dụng / đã hủy) chỉ khi lịch còn chờ áp dụng
+	// Trả ErrConcurrentModification nếu lịch đã được áp dụng hoặc hủy bởi request/scheduler khác
+	ChuyenTrangThai(ctx context.Context, lich *entity.LichDoiGia) error
+}
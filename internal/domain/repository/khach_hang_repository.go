@@ -3,12 +3,14 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"restaurant_project/internal/domain/entity"
 )
 
 // IKhachHangRepository là interface định nghĩa các thao tác với dữ liệu KhachHang
 // Implementation: MySQL (cần ACID cho loyalty points, foreign key với User)
+// Các truy vấn mặc định bỏ qua khách hàng đã xóa mềm (ngay_xoa khác NULL)
 type IKhachHangRepository interface {
 	// FindByID tìm khách hàng theo ID
 	FindByID(ctx context.Context, id string) (*entity.KhachHang, error)
@@ -25,15 +27,37 @@ type IKhachHangRepository interface {
 	// FindByCapThanhVien lấy khách hàng theo cấp thành viên
 	FindByCapThanhVien(ctx context.Context, cap string) ([]*entity.KhachHang, error)
 
-	// Save lưu khách hàng mới hoặc cập nhật
+	// Create thêm khách hàng mới
+	// Trả về ErrDuplicateEntry nếu số điện thoại đã thuộc khách hàng chưa xóa mềm khác
+	Create(ctx context.Context, khachHang *entity.KhachHang) error
+
+	// Save cập nhật khách hàng đã tồn tại (không tạo mới)
+	// Trả về ErrDuplicateEntry nếu số điện thoại đã thuộc khách hàng chưa xóa mềm khác
 	Save(ctx context.Context, khachHang *entity.KhachHang) error
 
-	// Delete xóa khách hàng theo ID
+	// Delete xóa mềm khách hàng theo ID (đặt NgayXoa)
 	Delete(ctx context.Context, id string) error
 
+	// FindDeleted lấy các khách hàng đã xóa mềm, mới xóa trước
+	FindDeleted(ctx context.Context) ([]*entity.KhachHang, error)
+
+	// Restore khôi phục khách hàng đã xóa mềm
+	// Trả về false nếu không có khách hàng đã xóa với ID này,
+	// ErrDuplicateEntry nếu số điện thoại đã được khách hàng khác dùng
+	Restore(ctx context.Context, id string) (bool, error)
+
+	// PurgeDeleted xóa vĩnh viễn các khách hàng đã xóa mềm trước thời điểm truoc
+	// Trả về số bản ghi đã xóa
+	PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error)
+
 	// UpdateDiemTichLuy cập nhật điểm tích lũy (atomic operation)
 	UpdateDiemTichLuy(ctx context.Context, id string, diemMoi int64) error
 
+	// CongDiemTichLuy cộng thêm diem vào điểm tích lũy bằng một câu UPDATE (không đọc-sửa-ghi)
+	// rồi cập nhật cấp thành viên theo tổng mới; gọi với ctx của IUnitOfWork.Do để hai bước cùng transaction.
+	// Trả về khách hàng sau khi cộng, nil nếu không có khách hàng (chưa xóa) với ID này
+	CongDiemTichLuy(ctx context.Context, id string, diem int64) (*entity.KhachHang, error)
+
 	// Count đếm tổng số khách hàng
 	Count(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,26 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IKhuyenMaiRepository là interface định nghĩa các thao tác với dữ liệu KhuyenMai
+// Implementation: MongoDB (cấu hình mỗi loại khuyến mãi khác nhau)
+type IKhuyenMaiRepository interface {
+	// FindByID tìm khuyến mãi theo ID
+	// Trả về nil nếu không tìm thấy
+	FindByID(ctx context.Context, id string) (*entity.KhuyenMai, error)
+
+	// FindAll lấy tất cả khuyến mãi
+	FindAll(ctx context.Context) ([]*entity.KhuyenMai, error)
+
+	// FindDangHoatDong lấy các khuyến mãi đang bật
+	// Việc lọc theo ngày/khung giờ do domain thực hiện (theo múi giờ nhà hàng)
+	FindDangHoatDong(ctx context.Context) ([]*entity.KhuyenMai, error)
+
+	// Save lưu khuyến mãi mới hoặc cập nhật
+	Save(ctx context.Context, km *entity.KhuyenMai) error
+}
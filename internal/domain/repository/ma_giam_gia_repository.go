@@ -0,0 +1,39 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// KiemTraDungMaFunc kiểm tra và tạo lượt dùng mã trong lúc mã đang bị khóa
+// Trả về error để hủy lượt dùng (transaction được rollback)
+type KiemTraDungMaFunc func(ma *entity.MaGiamGia, soLanKhachDaDung int) (*entity.SuDungMaGiamGia, error)
+
+// IMaGiamGiaRepository là interface định nghĩa các thao tác với dữ liệu MaGiamGia
+// Implementation: MySQL (trừ lượt dùng cần khóa dòng để an toàn khi nhiều đơn dùng cùng lúc)
+type IMaGiamGiaRepository interface {
+	// SaveBatch lưu nhiều mã mới trong một transaction
+	// Trả về ErrDuplicateEntry nếu có mã bị trùng
+	SaveBatch(ctx context.Context, list []*entity.MaGiamGia) error
+
+	// FindByMa tìm mã giảm giá theo mã (đã chuẩn hóa)
+	// Trả về nil nếu không tìm thấy
+	FindByMa(ctx context.Context, ma string) (*entity.MaGiamGia, error)
+
+	// FindByChienDich lấy các mã của một chiến dịch
+	FindByChienDich(ctx context.Context, chienDich string) ([]*entity.MaGiamGia, error)
+
+	// DungMa khóa mã (SELECT ... FOR UPDATE), đếm lượt khách đã dùng rồi gọi kiemTra
+	// Nếu kiemTra thành công: ghi lượt dùng và tăng số lượt đã dùng trong cùng transaction
+	// Trả về nil nếu không tìm thấy mã
+	DungMa(ctx context.Context, ma, khachHangID string, kiemTra KiemTraDungMaFunc) (*entity.SuDungMaGiamGia, error)
+
+	// HoanMaTheoOrder hoàn các lượt dùng mã của một đơn hàng (khi đơn bị hủy)
+	// Trả về số lượt đã hoàn (0 nếu đơn không dùng mã hoặc đã hoàn trước đó)
+	HoanMaTheoOrder(ctx context.Context, orderID string) (int, error)
+
+	// ThongKe tính số liệu sử dụng mã của một chiến dịch
+	ThongKe(ctx context.Context, chienDich string) (*entity.ThongKeMaGiamGia, error)
+}
@@ -4,6 +4,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"restaurant_project/internal/domain/entity"
 )
@@ -20,6 +21,8 @@ import (
 // - Domain layer định nghĩa interface
 // - Infrastructure layer implement interface
 // - UseCase phụ thuộc vào interface, KHÔNG phụ thuộc vào implementation cụ thể
+//
+// Các truy vấn mặc định (FindByID, FindAll, FindByConHang, Count) bỏ qua món đã xóa mềm
 type IMonAnRepository interface {
 	// FindByID tìm món ăn theo ID
 	// Trả về nil nếu không tìm thấy
@@ -34,14 +37,28 @@ type IMonAnRepository interface {
 	FindByConHang(ctx context.Context, conHang bool) ([]*entity.MonAn, error)
 
 	// Save lưu món ăn mới hoặc cập nhật món đã có
-	// Nếu ID đã tồn tại → update
+	// Nếu ID đã tồn tại → update (chỉ khi Version khớp với bản đã lưu)
 	// Nếu ID chưa tồn tại → insert
+	// Thành công thì tăng Version; trả ErrConcurrentModification nếu món
+	// đã bị thay đổi sau khi đọc (hoặc Version = 0 mà ID đã tồn tại)
 	Save(ctx context.Context, mon *entity.MonAn) error
 
-	// Delete xóa món ăn theo ID
-	// Trả về error nếu không tìm thấy món
+	// Delete xóa mềm món ăn theo ID (đặt NgayXoa, tăng Version)
+	// Món vẫn được giữ lại để báo cáo cũ tham chiếu MonAnID
+	// Trả về error nếu không tìm thấy món (hoặc món đã bị xóa)
 	Delete(ctx context.Context, id string) error
 
+	// FindDeleted lấy các món đã xóa mềm, mới xóa trước
+	FindDeleted(ctx context.Context) ([]*entity.MonAn, error)
+
+	// Restore khôi phục món đã xóa mềm
+	// Trả về false nếu không có món đã xóa với ID này
+	Restore(ctx context.Context, id string) (bool, error)
+
+	// PurgeDeleted xóa vĩnh viễn các món đã xóa mềm trước thời điểm truoc
+	// Trả về số món đã xóa
+	PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error)
+
 	// Count đếm tổng số món ăn
 	Count(ctx context.Context) (int64, error)
 }
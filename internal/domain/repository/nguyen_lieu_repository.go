@@ -0,0 +1,36 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// INguyenLieuRepository là interface định nghĩa các thao tác với dữ liệu NguyenLieu
+// Implementation: MySQL (tồn kho cần ACID)
+type INguyenLieuRepository interface {
+	// FindByID tìm nguyên liệu theo ID
+	FindByID(ctx context.Context, id string) (*entity.NguyenLieu, error)
+
+	// FindByIDForUpdate tìm nguyên liệu và khóa dòng (SELECT ... FOR UPDATE) tới hết transaction
+	// Phải gọi trong IUnitOfWork để khóa có tác dụng
+	FindByIDForUpdate(ctx context.Context, id string) (*entity.NguyenLieu, error)
+
+	// FindAll lấy tất cả nguyên liệu
+	FindAll(ctx context.Context) ([]*entity.NguyenLieu, error)
+
+	// Save lưu nguyên liệu mới hoặc cập nhật
+	Save(ctx context.Context, nguyenLieu *entity.NguyenLieu) error
+
+	// Delete xóa nguyên liệu theo ID
+	Delete(ctx context.Context, id string) error
+
+	// ThemBienDong ghi lại một biến động kho
+	ThemBienDong(ctx context.Context, bienDong *entity.BienDongKho) error
+
+	// TongTieuHao tính tổng tiêu hao theo từng nguyên liệu trong khoảng thời gian
+	// Trả về map[nguyenLieuID]tổng số lượng tiêu hao (giá trị dương)
+	TongTieuHao(ctx context.Context, from, to time.Time) (map[string]float64, error)
+}
@@ -0,0 +1,24 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// INhaCungCapRepository là interface định nghĩa các thao tác với dữ liệu NhaCungCap
+// Implementation: MySQL (foreign key với đơn đặt hàng)
+type INhaCungCapRepository interface {
+	// FindByID tìm nhà cung cấp theo ID
+	FindByID(ctx context.Context, id string) (*entity.NhaCungCap, error)
+
+	// FindAll lấy tất cả nhà cung cấp
+	FindAll(ctx context.Context) ([]*entity.NhaCungCap, error)
+
+	// Save lưu nhà cung cấp mới hoặc cập nhật
+	Save(ctx context.Context, nhaCungCap *entity.NhaCungCap) error
+
+	// Delete xóa nhà cung cấp theo ID
+	Delete(ctx context.Context, id string) error
+}
@@ -3,12 +3,14 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"restaurant_project/internal/domain/entity"
 )
 
 // INhanVienRepository là interface định nghĩa các thao tác với dữ liệu NhanVien
 // Implementation: MySQL (dữ liệu ổn định, foreign key với User)
+// Các truy vấn mặc định bỏ qua nhân viên đã xóa mềm (ngay_xoa khác NULL)
 type INhanVienRepository interface {
 	// FindByID tìm nhân viên theo ID
 	FindByID(ctx context.Context, id string) (*entity.NhanVien, error)
@@ -28,12 +30,31 @@ type INhanVienRepository interface {
 	// FindDauBepRanh tìm đầu bếp đang rảnh (để phân công order)
 	FindDauBepRanh(ctx context.Context) ([]*entity.NhanVien, error)
 
+	// Create thêm nhân viên mới
+	// Trả ErrDuplicateEntry nếu số điện thoại đã thuộc nhân viên khác (chưa xóa mềm)
+	Create(ctx context.Context, nhanVien *entity.NhanVien) error
+
+	// ExistsBySoDienThoai kiểm tra số điện thoại đã thuộc nhân viên nào chưa (bỏ qua nhân viên đã xóa mềm)
+	ExistsBySoDienThoai(ctx context.Context, soDienThoai string) (bool, error)
+
 	// Save lưu nhân viên mới hoặc cập nhật
 	Save(ctx context.Context, nhanVien *entity.NhanVien) error
 
-	// Delete xóa nhân viên theo ID
+	// Delete xóa mềm nhân viên theo ID (đặt NgayXoa)
 	Delete(ctx context.Context, id string) error
 
+	// FindDeleted lấy các nhân viên đã xóa mềm, mới xóa trước
+	FindDeleted(ctx context.Context) ([]*entity.NhanVien, error)
+
+	// Restore khôi phục nhân viên đã xóa mềm
+	// Trả về false nếu không có nhân viên đã xóa với ID này,
+	// ErrDuplicateEntry nếu số điện thoại đã được nhân viên khác dùng
+	Restore(ctx context.Context, id string) (bool, error)
+
+	// PurgeDeleted xóa vĩnh viễn các nhân viên đã xóa mềm trước thời điểm truoc
+	// Trả về số bản ghi đã xóa
+	PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error)
+
 	// UpdateTrangThai cập nhật trạng thái làm việc
 	UpdateTrangThai(ctx context.Context, id string, trangThai entity.TrangThaiLamViec) error
 
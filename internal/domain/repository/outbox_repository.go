@@ -0,0 +1,35 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IOutboxRepository là interface định nghĩa các thao tác với outbox sự kiện
+// Mỗi store có outbox riêng để sự kiện được ghi cùng transaction với thay đổi trạng thái:
+// MySQL (user, nhân viên...), MongoDB (order, món ăn...)
+type IOutboxRepository interface {
+	// Add ghi sự kiện vào outbox
+	// Gọi với ctx của IUnitOfWork.Do để ghi cùng transaction với thay đổi trạng thái
+	Add(ctx context.Context, suKien *entity.SuKien) error
+
+	// FindDenHan lấy tối đa limit sự kiện chưa phát đã tới ThuLaiLuc, cũ nhất trước
+	FindDenHan(ctx context.Context, now time.Time, limit int) ([]*entity.SuKien, error)
+
+	// GiuCho dời ThuLaiLuc của sự kiện tới den nếu sự kiện vẫn chưa phát và đã tới hạn
+	// Trả về false nếu relay khác đã giữ chỗ hoặc đã phát (tránh hai instance cùng phát)
+	GiuCho(ctx context.Context, id string, now, den time.Time) (bool, error)
+
+	// DanhDauDaPhat đánh dấu sự kiện đã phát thành công
+	DanhDauDaPhat(ctx context.Context, id string, luc time.Time) error
+
+	// DanhDauThatBai tăng số lần thử, ghi lỗi và hẹn lần phát tiếp theo
+	DanhDauThatBai(ctx context.Context, id string, loi string, thuLaiLuc time.Time) error
+
+	// PurgeDaPhat xóa các sự kiện đã phát trước thời điểm truoc
+	// Trả về số sự kiện đã xóa
+	PurgeDaPhat(ctx context.Context, truoc time.Time) (int64, error)
+}
@@ -0,0 +1,24 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IPermissionRepository là interface lưu danh mục permission và quyền của từng role
+// Implementation: MySQL
+type IPermissionRepository interface {
+	// FindAll lấy danh mục permission, sắp xếp theo tên
+	FindAll(ctx context.Context) ([]*entity.Permission, error)
+
+	// FindByRole lấy tên các permission của role (slice rỗng nếu role không có quyền nào)
+	FindByRole(ctx context.Context, role entity.UserRole) ([]string, error)
+
+	// FindAllRolePermissions lấy quyền của mọi role có ít nhất một permission
+	FindAllRolePermissions(ctx context.Context) (map[entity.UserRole][]string, error)
+
+	// SetRolePermissions thay toàn bộ quyền của role (trong một transaction)
+	SetRolePermissions(ctx context.Context, role entity.UserRole, permissions []string) error
+}
@@ -0,0 +1,20 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// IProcessedEventRepository ghi nhận consumer đã xử lý sự kiện (khóa idempotency)
+// Implementation: MySQL, ghi cùng transaction với tác dụng phụ của consumer
+type IProcessedEventRepository interface {
+	// DanhDauDaXuLy ghi khóa (consumer, ID sự kiện); trả về false nếu khóa đã tồn tại
+	// Gọi với ctx của IUnitOfWork.Do: consumer lỗi thì khóa bị rollback cùng tác dụng phụ.
+	// Lần phát đồng thời cùng khóa chờ transaction đang giữ khóa kết thúc rồi mới nhận kết quả
+	DanhDauDaXuLy(ctx context.Context, consumer, suKienID string) (bool, error)
+
+	// PurgeTruoc xóa các khóa ghi trước thời điểm truoc
+	// Trả về số bản ghi đã xóa
+	PurgeTruoc(ctx context.Context, truoc time.Time) (int64, error)
+}
@@ -0,0 +1,16 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+)
+
+// IUnitOfWork chạy nhiều thao tác repository như một khối nguyên tử (transaction)
+// Transaction đi theo context.Context: repository nhận ctx trong fn sẽ tự tham gia transaction
+// Implementation: MySQL (sql.Tx), MongoDB (session, cần replica set)
+type IUnitOfWork interface {
+	// Do chạy fn trong transaction: fn trả lỗi (hoặc panic) thì rollback, ngược lại commit
+	// fn phải dùng ctx được truyền vào cho mọi lời gọi repository
+	// Gọi lồng nhau thì tham gia transaction bên ngoài thay vì mở transaction mới
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,28 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IUserMFARepository là interface lưu cấu hình xác thực hai lớp của user
+// Implementation: MySQL (cùng database với users, xóa theo user)
+type IUserMFARepository interface {
+	// FindByUserID lấy cấu hình TOTP của user (nil nếu chưa đăng ký)
+	FindByUserID(ctx context.Context, userID string) (*entity.UserMFA, error)
+
+	// Save tạo mới hoặc ghi đè cấu hình TOTP của user
+	Save(ctx context.Context, mfa *entity.UserMFA) error
+
+	// Delete xóa cấu hình TOTP của user (tắt 2FA)
+	Delete(ctx context.Context, userID string) error
+
+	// GhiNhanBuoc cập nhật bước TOTP đã dùng nếu buoc mới hơn bước cuối
+	// Trả về false nếu mã thuộc bước đã dùng (request đồng thời với cùng mã chỉ một request thành công)
+	GhiNhanBuoc(ctx context.Context, userID string, buoc int64) (bool, error)
+
+	// DungMaKhoiPhuc xóa mã khôi phục (SHA-256) khỏi danh sách, trả về false nếu không có
+	DungMaKhoiPhuc(ctx context.Context, userID, maHash string) (bool, error)
+}
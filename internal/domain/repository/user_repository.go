@@ -12,6 +12,10 @@ import (
 var (
 	// ErrDuplicateEntry là lỗi khi INSERT vi phạm UNIQUE constraint
 	ErrDuplicateEntry = errors.New("duplicate entry")
+
+	// ErrConcurrentModification là lỗi khi cập nhật có điều kiện thất bại:
+	// bản ghi đã bị request khác thay đổi (hoặc xóa) kể từ khi được đọc
+	ErrConcurrentModification = errors.New("concurrent modification")
 )
 
 // IUserRepository là interface định nghĩa các thao tác với dữ liệu User
@@ -41,7 +45,8 @@ type IUserRepository interface {
 	// Create tạo user mới (trả lỗi nếu trùng unique constraint)
 	Create(ctx context.Context, user *entity.User) error
 
-	// Save cập nhật user đã tồn tại
+	// Save cập nhật user đã tồn tại nếu Version khớp với DB, tăng Version khi thành công
+	// Trả ErrConcurrentModification nếu user đã bị thay đổi hoặc xóa sau khi đọc
 	Save(ctx context.Context, user *entity.User) error
 
 	// Delete xóa user theo ID
@@ -0,0 +1,50 @@
+// Package repository định nghĩa các Interface cho việc lưu trữ dữ liệu
+package repository
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// IWebhookRepository là interface định nghĩa các thao tác với webhook đã đăng ký
+// Implementation: MySQL (cấu hình ít thay đổi, admin quản lý)
+type IWebhookRepository interface {
+	// FindByID tìm webhook theo ID
+	FindByID(ctx context.Context, id string) (*entity.Webhook, error)
+
+	// FindAll lấy tất cả webhook
+	FindAll(ctx context.Context) ([]*entity.Webhook, error)
+
+	// FindDangHoatDong lấy các webhook đang hoạt động (lọc theo loại sự kiện ở use case)
+	FindDangHoatDong(ctx context.Context) ([]*entity.Webhook, error)
+
+	// Save lưu webhook mới hoặc cập nhật
+	Save(ctx context.Context, webhook *entity.Webhook) error
+
+	// Delete xóa webhook theo ID
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookDeliveryFilter là điều kiện lọc nhật ký gửi webhook, trường rỗng thì bỏ qua
+type WebhookDeliveryFilter struct {
+	WebhookID string
+	SuKienID  string
+	TrangThai entity.TrangThaiDelivery
+}
+
+// IWebhookDeliveryRepository là interface định nghĩa các thao tác với nhật ký gửi webhook
+// Implementation: MongoDB (nhật ký nhiều bản ghi, tra cứu theo webhook)
+type IWebhookDeliveryRepository interface {
+	// TaoNeuChuaCo ghi delivery mới; trả về false nếu ID đã tồn tại (sự kiện được phát lại)
+	TaoNeuChuaCo(ctx context.Context, delivery *entity.WebhookDelivery) (bool, error)
+
+	// FindByID tìm delivery theo ID
+	FindByID(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+
+	// Find lấy delivery theo điều kiện lọc có phân trang, mới nhất trước
+	Find(ctx context.Context, filter WebhookDeliveryFilter, offset, limit int) ([]*entity.WebhookDelivery, int64, error)
+
+	// Save cập nhật trạng thái và kết quả gửi của delivery
+	Save(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
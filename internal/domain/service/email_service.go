@@ -3,16 +3,42 @@ package service
 
 import (
 	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
 )
 
+// ThongTinDatBan là dữ liệu cho email xác nhận đặt bàn
+type ThongTinDatBan struct {
+	TenKhach string    // Tên khách đặt bàn
+	ThoiGian time.Time // Giờ hẹn
+	SoNguoi  int       // Số người
+	SoBan    int       // Số bàn (0 = chưa xếp bàn)
+	GhiChu   string    // Ghi chú của khách
+}
+
 // EmailService interface cho việc gửi email
 // Có 2 implementation:
 // - ConsoleEmailService: Log ra console (development)
-// - SMTPEmailService: Gửi email thật (production) - future
+// - SMTPEmailService: Gửi email thật qua SMTP với template HTML + text (production)
 type EmailService interface {
 	// SendVerificationEmail gửi email xác thực đến user
 	// toEmail: địa chỉ email nhận
 	// token: verification token
 	// Trong development mode, chỉ log link ra console
 	SendVerificationEmail(ctx context.Context, toEmail, token string) error
+
+	// SendPasswordResetEmail gửi email chứa link đặt lại mật khẩu
+	// toEmail: địa chỉ email nhận
+	// token: password reset token (chỉ dùng một lần)
+	SendPasswordResetEmail(ctx context.Context, toEmail, token string) error
+
+	// SendOrderReceipt gửi hóa đơn của đơn hàng cho khách
+	SendOrderReceipt(ctx context.Context, toEmail string, order *entity.Order) error
+
+	// SendReservationConfirmation gửi email xác nhận đặt bàn cho khách
+	SendReservationConfirmation(ctx context.Context, toEmail string, datBan ThongTinDatBan) error
+
+	// SendLowStockAlert gửi cảnh báo các nguyên liệu dưới mức tồn tối thiểu cho quản lý
+	SendLowStockAlert(ctx context.Context, toEmail string, nguyenLieu []*entity.NguyenLieu) error
 }
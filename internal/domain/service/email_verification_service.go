@@ -28,4 +28,8 @@ type EmailVerificationService interface {
 
 	// SetResendCooldown đặt cooldown sau khi gửi email
 	SetResendCooldown(ctx context.Context, userID string) error
+
+	// CleanupExpiredTokens dọn tokens đã hết hạn còn sót trong danh sách token của user
+	// Trả về số token đã dọn (chạy định kỳ bởi scheduler)
+	CleanupExpiredTokens(ctx context.Context) (int64, error)
 }
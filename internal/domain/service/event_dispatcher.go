@@ -0,0 +1,26 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import (
+	"context"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// EventHandler là consumer xử lý một domain event; trả về error để sự kiện được phát lại
+// Sự kiện có thể được phát nhiều lần (at-least-once): dispatcher bỏ qua consumer đã xử lý thành công.
+// Ghi MySQL qua ctx được commit cùng khóa idempotency (exactly-once);
+// tác dụng phụ khác (Redis, MongoDB, HTTP) vẫn nên an toàn khi chạy lại
+type EventHandler func(ctx context.Context, suKien *entity.SuKien) error
+
+// EventDispatcher phát domain event từ outbox tới các consumer trong process
+type EventDispatcher interface {
+	// Subscribe đăng ký consumer cho một loại sự kiện
+	// Tên consumer phải duy nhất và cố định: là một phần của khóa idempotency
+	Subscribe(loai entity.LoaiSuKien, consumer string, handler EventHandler)
+
+	// Dispatch gọi lần lượt các consumer của loại sự kiện
+	// Consumer đã xử lý thành công sự kiện này (theo khóa consumer + ID sự kiện) được bỏ qua
+	// Trả về lỗi nếu có consumer thất bại: relay sẽ phát lại, chỉ consumer thất bại chạy lại
+	Dispatch(ctx context.Context, suKien *entity.SuKien) error
+}
@@ -0,0 +1,34 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// IdempotentResponse là bản ghi của một Idempotency-Key: request đang xử lý hoặc response đã lưu để phát lại
+type IdempotentResponse struct {
+	Fingerprint string // Dấu vân tay của request đầu tiên (method, path, body)
+	InProgress  bool   // Request đầu tiên chưa xử lý xong
+	Token       string // Token ngẫu nhiên của request đang giữ khóa (rỗng khi đã lưu response)
+	StatusCode  int
+	Headers     map[string]string // Header được phát lại (Content-Type, ETag, Location)
+	Body        []byte
+}
+
+// IdempotencyStore lưu response đầu tiên theo Idempotency-Key để phát lại cho request gửi lại
+type IdempotencyStore interface {
+	// Reserve giữ khóa cho request đầu tiên (đánh dấu đang xử lý trong ttl)
+	// Trả về token của lần giữ khóa, hoặc false nếu khóa đã tồn tại (request trùng)
+	Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (string, bool, error)
+
+	// Get lấy bản ghi của khóa, nil nếu không có hoặc đã hết hạn
+	Get(ctx context.Context, key string) (*IdempotentResponse, error)
+
+	// Complete lưu response của request đầu tiên với TTL, chỉ khi khóa vẫn do token giữ
+	// Trả về false nếu khóa đã hết hạn hoặc đã thuộc request khác (không ghi đè)
+	Complete(ctx context.Context, key, token string, resp *IdempotentResponse, ttl time.Duration) (bool, error)
+
+	// Release xóa khóa để client gửi lại được (request đầu tiên lỗi server), chỉ khi khóa vẫn do token giữ
+	Release(ctx context.Context, key, token string) error
+}
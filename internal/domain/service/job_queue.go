@@ -0,0 +1,50 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrJobNotFound được trả về khi không tìm thấy job trong dead-letter list
+var ErrJobNotFound = errors.New("job not found")
+
+// Job là một công việc nền trong hàng đợi
+type Job struct {
+	ID         string    // ID duy nhất của job
+	Loai       string    // Loại job, dùng để chọn handler (VD: "email.verification")
+	DuLieu     []byte    // Payload JSON
+	SoLanThu   int       // Số lần đã chạy (kể cả lần thất bại)
+	LoiCuoi    string    // Lỗi của lần chạy gần nhất
+	TaoLuc     time.Time // Thời điểm đưa vào hàng đợi
+	ThatBaiLuc time.Time // Thời điểm chuyển vào dead-letter (zero nếu chưa)
+}
+
+// JobQueueStats là số liệu hiện tại của hàng đợi
+type JobQueueStats struct {
+	DangCho   int64 // Job đang chờ hoặc đang được xử lý
+	ChoThuLai int64 // Job thất bại đang chờ đến lượt thử lại
+	ThatBai   int64 // Job trong dead-letter list
+}
+
+// JobHandler xử lý một job; trả về error để job được thử lại
+type JobHandler func(ctx context.Context, job *Job) error
+
+// JobQueue interface cho hàng đợi công việc nền bền vững
+// Job thất bại được thử lại với exponential backoff, quá số lần cho phép thì vào dead-letter list
+type JobQueue interface {
+	// Enqueue đưa job vào hàng đợi, payload được encode JSON
+	// Trả về ID của job
+	Enqueue(ctx context.Context, loai string, payload any) (string, error)
+
+	// ListDeadLetter liệt kê các job thất bại, mới nhất trước
+	// Trả về danh sách job và tổng số job trong dead-letter list
+	ListDeadLetter(ctx context.Context, offset, limit int64) ([]Job, int64, error)
+
+	// ReplayDeadLetter đưa một job thất bại trở lại hàng đợi với số lần thử reset về 0
+	ReplayDeadLetter(ctx context.Context, id string) error
+
+	// Stats trả về số job theo từng trạng thái
+	Stats(ctx context.Context) (JobQueueStats, error)
+}
@@ -0,0 +1,34 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrMFAChallengeNotFound là lỗi khi challenge không tồn tại, đã hết hạn hoặc đã hết lượt thử
+var ErrMFAChallengeNotFound = errors.New("mfa challenge not found")
+
+// MFAChallenge là trạng thái đăng nhập dở dang: đã qua bước mật khẩu, chờ mã TOTP
+type MFAChallenge struct {
+	UserID       string
+	YeuCauDangKy bool // true = user bắt buộc 2FA nhưng chưa đăng ký, phải đăng ký trước khi nhập mã
+}
+
+// MFAChallengeService interface quản lý MFA challenge token giữa hai bước đăng nhập
+// Sử dụng Redis với TTL ngắn (giống PasswordResetService, chỉ lưu hash của token)
+type MFAChallengeService interface {
+	// CreateChallenge tạo challenge token cho user vừa nhập đúng mật khẩu
+	CreateChallenge(ctx context.Context, challenge MFAChallenge) (string, error)
+
+	// GetChallenge lấy challenge theo token (ErrMFAChallengeNotFound nếu không còn hiệu lực)
+	GetChallenge(ctx context.Context, token string) (*MFAChallenge, error)
+
+	// RecordFailure ghi nhận một lần nhập sai mã, xóa challenge khi hết lượt
+	// Trả về số lượt còn lại
+	RecordFailure(ctx context.Context, token string) (int, error)
+
+	// DeleteChallenge xóa challenge sau khi xác thực thành công
+	// Trả về false nếu challenge đã bị request khác dùng (chống dùng một challenge hai lần)
+	DeleteChallenge(ctx context.Context, token string) (bool, error)
+}
@@ -0,0 +1,58 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+// OIDC errors
+var (
+	// ErrOIDCStateNotFound khi state không tồn tại, đã hết hạn hoặc đã được dùng
+	ErrOIDCStateNotFound = errors.New("oidc state not found")
+	// ErrOIDCExchangeFailed khi provider từ chối authorization code hoặc ID token không hợp lệ
+	ErrOIDCExchangeFailed = errors.New("oidc code exchange failed")
+)
+
+// OIDCIdentity là danh tính đã được xác thực từ ID token của provider
+type OIDCIdentity struct {
+	Subject       string // Claim sub: định danh ổn định của tài khoản tại provider
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string // Phải khớp nonce gửi đi lúc bắt đầu đăng nhập
+}
+
+// OIDCProvider là nhà cung cấp danh tính OpenID Connect (Google, ...)
+// Dùng authorization code flow kèm PKCE (S256)
+type OIDCProvider interface {
+	// Name trả về tên provider dùng trong URL và bảng external_identities (vd: google)
+	Name() string
+
+	// AuthorizationURL tạo URL chuyển người dùng tới trang đăng nhập của provider
+	AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+
+	// Exchange đổi authorization code (kèm PKCE verifier) lấy ID token,
+	// kiểm tra chữ ký, issuer, audience, thời hạn và trả về danh tính
+	// Trả về lỗi bọc ErrOIDCExchangeFailed nếu provider từ chối hoặc token không hợp lệ
+	Exchange(ctx context.Context, code, codeVerifier string) (*OIDCIdentity, error)
+}
+
+// OIDCAuthRequest là trạng thái của một lần đăng nhập/liên kết đang chờ provider redirect về
+type OIDCAuthRequest struct {
+	Provider     string
+	CodeVerifier string // PKCE verifier, chỉ lưu phía server
+	Nonce        string
+	LinkUserID   string // Khác rỗng: liên kết tài khoản cho user đang đăng nhập thay vì đăng nhập
+}
+
+// OIDCStateService lưu trạng thái OIDC theo state (chống CSRF) với TTL ngắn
+// Sử dụng Redis, chỉ lưu hash của state (giống MFAChallengeService)
+type OIDCStateService interface {
+	// CreateState lưu trạng thái và trả về state gửi kèm URL đăng nhập
+	CreateState(ctx context.Context, request OIDCAuthRequest) (string, error)
+
+	// ConsumeState lấy và xóa trạng thái (mỗi state chỉ dùng một lần)
+	// Trả về ErrOIDCStateNotFound nếu không còn hiệu lực
+	ConsumeState(ctx context.Context, state string) (*OIDCAuthRequest, error)
+}
@@ -0,0 +1,32 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import (
+	"context"
+)
+
+// PasswordResetService interface cho việc quản lý password reset tokens
+// Sử dụng Redis để lưu trữ tokens với TTL (giống EmailVerificationService)
+type PasswordResetService interface {
+	// GenerateToken tạo token đặt lại mật khẩu mới cho user
+	// Token được lưu trong Redis với TTL (mặc định 30 phút)
+	GenerateToken(ctx context.Context, userID string) (string, error)
+
+	// ConsumeToken kiểm tra token, xóa nó và trả về userID nếu hợp lệ
+	// Token chỉ dùng được MỘT lần: hai request đồng thời với cùng token chỉ một request thành công
+	ConsumeToken(ctx context.Context, token string) (string, error)
+
+	// InvalidateAllUserTokens xóa tất cả reset tokens của một user
+	InvalidateAllUserTokens(ctx context.Context, userID string) error
+
+	// CanResend kiểm tra xem có thể gửi lại email đặt lại mật khẩu không
+	// Trả về true nếu có thể gửi, remainingSeconds là số giây còn lại phải chờ
+	CanResend(ctx context.Context, userID string) (canResend bool, remainingSeconds int64, err error)
+
+	// SetResendCooldown đặt cooldown sau khi gửi email
+	SetResendCooldown(ctx context.Context, userID string) error
+
+	// CleanupExpiredTokens dọn reset tokens đã hết hạn còn sót trong danh sách token của user
+	// Trả về số token đã dọn (chạy định kỳ bởi scheduler)
+	CleanupExpiredTokens(ctx context.Context) (int64, error)
+}
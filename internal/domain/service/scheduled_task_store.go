@@ -0,0 +1,49 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// TaskRun là kết quả một lần chạy tác vụ định kỳ
+type TaskRun struct {
+	TacVu    string    // Tên tác vụ
+	LichChay time.Time // Thời điểm theo lịch của lần chạy (slot)
+	BatDau   time.Time // Thời điểm bắt đầu chạy thực tế
+	KetThuc  time.Time // Thời điểm kết thúc
+	Loi      string    // Lỗi (rỗng = thành công)
+	KetQua   string    // Tóm tắt kết quả (VD: "dọn 12 token")
+	Instance string    // Instance đã chạy tác vụ
+}
+
+// TaskStatus là trạng thái hiện tại của một tác vụ định kỳ
+type TaskStatus struct {
+	TacVu        string     // Tên tác vụ
+	Lich         string     // Mô tả lịch chạy (VD: "hằng ngày 00:05", "mỗi 1h0m0s")
+	ChayTiepTheo time.Time  // Lần chạy kế tiếp theo lịch
+	LanChayCuoi  *TaskRun   // Lần chạy gần nhất (nil = chưa chạy)
+	LoiCuoi      string     // Lỗi gần nhất, giữ lại kể cả khi các lần sau thành công
+	LoiCuoiLuc   *time.Time // Thời điểm xảy ra lỗi gần nhất
+}
+
+// ScheduledTaskStore interface lưu khóa phân tán và lịch sử chạy của scheduler
+// Dùng Redis để nhiều instance cùng chạy scheduler nhưng mỗi slot chỉ một instance thực thi
+type ScheduledTaskStore interface {
+	// RegisterTask ghi nhận tác vụ và lần chạy kế tiếp (hiển thị trên trang admin)
+	RegisterTask(ctx context.Context, tacVu, lich string, chayTiepTheo time.Time) error
+
+	// TryLock giành quyền chạy slot lichChay của tác vụ
+	// Trả về false nếu instance khác đang chạy hoặc slot này đã được chạy
+	// unlock phải được gọi sau khi chạy xong
+	TryLock(ctx context.Context, tacVu string, lichChay time.Time, ttl time.Duration) (unlock func(), acquired bool, err error)
+
+	// RecordRun lưu kết quả một lần chạy vào lịch sử và cập nhật trạng thái tác vụ
+	RecordRun(ctx context.Context, run TaskRun) error
+
+	// ListTasks lấy trạng thái của tất cả tác vụ đã đăng ký
+	ListTasks(ctx context.Context) ([]TaskStatus, error)
+
+	// ListRuns lấy lịch sử chạy của một tác vụ, mới nhất trước
+	ListRuns(ctx context.Context, tacVu string, limit int64) ([]TaskRun, error)
+}
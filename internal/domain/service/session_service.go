@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Session errors
+var (
+	// ErrSessionNotFound khi session không tồn tại hoặc đã hết hạn/bị thu hồi
+	ErrSessionNotFound = errors.New("session not found")
+	// ErrRefreshTokenReused khi refresh token đã được xoay vòng trước đó lại được dùng
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// Session là một phiên đăng nhập trên một thiết bị
+// Mỗi lần login tạo một session; refresh token xoay vòng vẫn thuộc session đó
+type Session struct {
+	ID            string
+	UserID        string
+	UserAgent     string
+	IP            string
+	CreatedAt     time.Time
+	LastRefreshAt time.Time // Bằng CreatedAt nếu chưa refresh lần nào
+	ExpiresAt     time.Time // Session hết hạn nếu không refresh trước thời điểm này
+	RefreshJTI    string    // jti của refresh token hiện hành, chỉ token này được dùng để refresh
+}
+
+// SessionRotation là thay đổi của session khi refresh token
+type SessionRotation struct {
+	SessionID    string
+	UserID       string
+	IP           string
+	UserAgent    string
+	PresentedJTI string        // jti của refresh token được gửi lên, phải là refresh token hiện hành của session
+	RefreshJTI   string        // jti của refresh token mới, thay refresh token hiện hành
+	AddJTIs      []string      // jti của cặp token mới
+	RemoveJTIs   []string      // jti cũ đã bị thu hồi
+	TTL          time.Duration // Gia hạn session
+}
+
+// SessionService lưu metadata của các phiên đăng nhập và token thuộc từng phiên
+// Việc thu hồi token vẫn do TokenBlacklistService đảm nhận
+type SessionService interface {
+	// CreateSession lưu session mới cùng các jti được cấp lúc login
+	// session.RefreshJTI là refresh token hiện hành, jtis gồm mọi token (để thu hồi khi xóa session)
+	// ttl: thời gian sống của session (= refresh token TTL)
+	CreateSession(ctx context.Context, session Session, jtis []string, ttl time.Duration) error
+
+	// RotateSession cập nhật session khi refresh (atomic): ghi nhận IP/User-Agent và thời điểm refresh,
+	// thay refresh token hiện hành, thêm jti mới, bỏ jti cũ đã bị thu hồi, gia hạn ttl
+	// Trả về ErrSessionNotFound nếu session đã hết hạn, bị thu hồi hoặc không thuộc userID;
+	// ErrRefreshTokenReused nếu PresentedJTI không phải refresh token hiện hành (đã được xoay vòng)
+	RotateSession(ctx context.Context, rotation SessionRotation) error
+
+	// GetSession lấy session theo ID, trả về ErrSessionNotFound nếu không có
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
+
+	// ListUserSessions lấy các session còn hiệu lực của user, mới hoạt động gần nhất trước
+	ListUserSessions(ctx context.Context, userID string) ([]Session, error)
+
+	// DeleteSession xóa session, trả về các jti còn gắn với session để caller blacklist
+	DeleteSession(ctx context.Context, sessionID string) ([]string, error)
+}
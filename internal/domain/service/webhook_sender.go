@@ -0,0 +1,12 @@
+// Package service chứa các Domain Service interfaces
+package service
+
+import "context"
+
+// WebhookSender gửi webhook delivery tới endpoint ở nền
+// Lần gửi thất bại (lỗi kết nối, HTTP khác 2xx) được thử lại với exponential backoff;
+// kết quả từng lần gửi được ghi vào nhật ký delivery
+type WebhookSender interface {
+	// GuiNen đưa delivery vào hàng đợi gửi
+	GuiNen(ctx context.Context, deliveryID string) error
+}
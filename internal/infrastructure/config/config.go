@@ -11,32 +11,91 @@ import (
 // Config chứa tất cả cấu hình của ứng dụng
 type Config struct {
 	Server     ServerConfig
+	Restaurant RestaurantConfig
 	Log        LogConfig
 	MySQL      MySQLConfig
 	MongoDB    MongoDBConfig
 	Redis      RedisConfig
 	Migration  MigrationConfig
+	JobQueue   JobQueueConfig
+	Outbox     OutboxConfig
+	Webhook    WebhookConfig
+	Scheduler  SchedulerConfig
 	Middleware MiddlewareConfig
 }
 
+// RestaurantConfig cấu hình nghiệp vụ của nhà hàng
+type RestaurantConfig struct {
+	Timezone string // Múi giờ nhà hàng, dùng cho lịch phục vụ món (mặc định Asia/Ho_Chi_Minh)
+}
+
 // MigrationConfig cấu hình cho hệ thống migration tự động
 type MigrationConfig struct {
 	AutoMigrate bool // Tự động chạy migration khi startup
 }
 
+// JobQueueConfig cấu hình hàng đợi công việc nền (Redis Streams)
+type JobQueueConfig struct {
+	Workers           int           // Số worker xử lý job đồng thời (mặc định 4)
+	MaxAttempts       int           // Số lần chạy tối đa trước khi chuyển vào dead-letter, tính cả lần bị gián đoạn (mặc định 5)
+	BackoffBase       time.Duration // Thời gian chờ trước lần thử lại đầu tiên, nhân đôi sau mỗi lần (mặc định 5s)
+	BackoffMax        time.Duration // Thời gian chờ tối đa giữa các lần thử (mặc định 10 phút)
+	JobTimeout        time.Duration // Timeout cho một lần chạy job (mặc định 30s)
+	VisibilityTimeout time.Duration // Job đang xử lý quá thời gian này bị worker khác nhận lại (mặc định 2 phút, phải lớn hơn JobTimeout)
+	DeadLetterMaxLen  int           // Số job tối đa giữ trong dead-letter list (mặc định 1000)
+}
+
+// OutboxConfig cấu hình relay phát domain event từ outbox (MySQL + MongoDB)
+type OutboxConfig struct {
+	PollInterval   time.Duration // Chu kỳ quét outbox tìm sự kiện tới hạn (mặc định 1s)
+	BatchSize      int           // Số sự kiện tối đa mỗi lần quét mỗi store (mặc định 100)
+	HandlerTimeout time.Duration // Timeout phát một sự kiện tới các consumer (mặc định 30s)
+	BackoffBase    time.Duration // Chờ trước lần phát lại đầu tiên, nhân đôi sau mỗi lần (mặc định 5s)
+	BackoffMax     time.Duration // Thời gian chờ tối đa giữa các lần phát lại (mặc định 10 phút)
+	ProcessedTTL   time.Duration // Thời gian giữ khóa idempotency của consumer trong MySQL (mặc định 7 ngày)
+	Retention      time.Duration // Thời gian giữ sự kiện đã phát trước khi xóa (mặc định 7 ngày)
+}
+
+// WebhookConfig cấu hình gửi webhook tới bên thứ ba
+// Số lần thử lại và backoff dùng cấu hình của job queue (JOB_QUEUE_*)
+type WebhookConfig struct {
+	Timeout         time.Duration // Timeout của một request gửi webhook (mặc định 10s, phải nhỏ hơn JobQueue.JobTimeout)
+	AllowedNetworks []string      // Dải IP nội bộ (CIDR hoặc IP) vẫn được gửi tới, chỉ dùng cho dev (mặc định rỗng = chặn hết)
+}
+
+// SchedulerConfig cấu hình các tác vụ định kỳ
+// Giờ chạy (HH:MM) tính theo múi giờ nhà hàng
+type SchedulerConfig struct {
+	Enabled              bool          // Bật/tắt scheduler trên instance này (mặc định true)
+	RevenueSnapshotAt    string        // Giờ chụp snapshot doanh thu ngày hôm trước (mặc định 00:05)
+	TokenCleanupInterval time.Duration // Chu kỳ dọn token xác thực/đặt lại mật khẩu hết hạn (mặc định 1h)
+	LowStockCheckAt      string        // Giờ kiểm tra tồn kho (mặc định 07:00)
+	LowStockAlertEmail   string        // Email nhận cảnh báo tồn kho (rỗng = chỉ ghi log)
+	PriceChangeInterval  time.Duration // Chu kỳ áp dụng lịch đổi giá món đã tới hạn (mặc định 1m)
+	PurgeDeletedAt       string        // Giờ xóa vĩnh viễn bản ghi đã xóa mềm quá hạn lưu giữ (mặc định 03:00)
+	SoftDeleteRetention  time.Duration // Thời gian giữ bản ghi đã xóa mềm trước khi xóa vĩnh viễn (mặc định 90 ngày)
+	OutboxCleanupAt      string        // Giờ xóa sự kiện outbox quá Outbox.Retention và khóa idempotency quá Outbox.ProcessedTTL (mặc định 03:30)
+	HistorySize          int           // Số lần chạy gần nhất giữ lại cho mỗi tác vụ (mặc định 50)
+}
+
 // MiddlewareConfig chứa cấu hình cho tất cả middleware
 type MiddlewareConfig struct {
 	CORS              CORSConfig
 	RateLimit         RateLimitConfig
 	AuthRateLimit     AuthRateLimitConfig
 	JWT               JWTConfig
+	AuthCookie        AuthCookieConfig
 	Timeout           TimeoutConfig
 	Gzip              GzipConfig
 	Security          SecurityConfig
 	BodyLimit         BodyLimitConfig
+	Idempotency       IdempotencyConfig
 	AccountLockout    AccountLockoutConfig
 	TokenBlacklist    TokenBlacklistConfig
 	EmailVerification EmailVerificationConfig
+	PasswordReset     PasswordResetConfig
+	MFA               MFAConfig
+	OIDC              OIDCConfig
 	Email             EmailConfig
 }
 
@@ -67,9 +126,23 @@ type AuthRateLimitConfig struct {
 // JWTConfig cấu hình JWT Authentication
 type JWTConfig struct {
 	Enabled         bool   // Bật/tắt JWT auth
-	SecretKey       string // Secret key để sign token
+	SecretKey       string // Secret key để sign token (chỉ dùng với HS256)
 	AccessTokenTTL  string // Thời gian sống access token (ví dụ: 15m)
 	RefreshTokenTTL string // Thời gian sống refresh token (ví dụ: 168h)
+
+	// Ký bất đối xứng: service khác verify token qua /.well-known/jwks.json mà không cần secret
+	Algorithm      string   // HS256 (mặc định), RS256 hoặc EdDSA
+	PrivateKeyFile string   // File PEM private key đang dùng để ký (RS256/EdDSA)
+	PublicKeyFiles []string // File PEM public key cũ vẫn được chấp nhận trong lúc xoay vòng key
+}
+
+// AuthCookieConfig cấu hình chế độ xác thực bằng HttpOnly cookie cho web frontend
+// Client chọn chế độ cookie bằng header X-Auth-Mode: cookie, không có header thì vẫn dùng Bearer token
+type AuthCookieConfig struct {
+	Enabled  bool   // Bật/tắt chế độ cookie
+	Domain   string // Domain của cookie (rỗng = chỉ host của API)
+	Secure   bool   // Chỉ gửi cookie qua HTTPS (mặc định true, tắt khi dev bằng http://localhost)
+	SameSite string // strict, lax (mặc định) hoặc none (frontend khác site, bắt buộc Secure)
 }
 
 // TimeoutConfig cấu hình request timeout
@@ -96,6 +169,14 @@ type BodyLimitConfig struct {
 	MaxSize int64 // Kích thước tối đa (bytes)
 }
 
+// IdempotencyConfig cấu hình Idempotency-Key cho POST endpoints
+// Response đầu tiên theo (user, Idempotency-Key) được lưu trong Redis và phát lại cho request gửi lại
+type IdempotencyConfig struct {
+	Enabled bool          // Bật/tắt idempotency
+	TTL     time.Duration // Thời gian giữ response đã lưu (mặc định 24h)
+	LockTTL time.Duration // Thời gian giữ khóa khi request đầu tiên đang xử lý (mặc định 1 phút)
+}
+
 // AccountLockoutConfig cấu hình khóa tài khoản sau nhiều lần login sai
 type AccountLockoutConfig struct {
 	Enabled         bool          // Bật/tắt account lockout
@@ -115,10 +196,49 @@ type EmailVerificationConfig struct {
 	CooldownTTL time.Duration // Thời gian chờ giữa các lần gửi lại (mặc định 60s)
 }
 
+// PasswordResetConfig cấu hình đặt lại mật khẩu qua email
+type PasswordResetConfig struct {
+	TokenTTL    time.Duration // Thời gian sống của reset token (mặc định 30 phút)
+	CooldownTTL time.Duration // Thời gian chờ giữa các lần gửi email (mặc định 60s)
+}
+
+// MFAConfig cấu hình xác thực hai lớp (TOTP)
+type MFAConfig struct {
+	Issuer          string        // Tên hiển thị trong app authenticator (mặc định Restaurant)
+	RequiredMinRole string        // Bắt buộc 2FA cho role từ mức này trở lên (vd: manager; rỗng = không bắt buộc)
+	ChallengeTTL    time.Duration // Thời gian sống của MFA challenge sau bước mật khẩu (mặc định 5 phút)
+	MaxAttempts     int           // Số lần nhập sai mã tối đa cho một challenge (mặc định 5)
+	RecoveryCodes   int           // Số mã khôi phục cấp khi đăng ký (mặc định 10)
+}
+
+// OIDCConfig cấu hình đăng nhập bằng tài khoản bên ngoài (OpenID Connect, authorization code + PKCE)
+type OIDCConfig struct {
+	RedirectURL string        // Trang frontend nhận code/state từ provider (phải đăng ký với provider)
+	StateTTL    time.Duration // Thời gian tối đa từ lúc bắt đầu tới lúc đổi code (mặc định 10 phút)
+	Google      OIDCProviderConfig
+}
+
+// OIDCProviderConfig cấu hình một OIDC provider
+type OIDCProviderConfig struct {
+	Enabled      bool   // Bật/tắt provider
+	IssuerURL    string // Issuer, endpoint lấy từ {issuer}/.well-known/openid-configuration
+	ClientID     string // OAuth client ID
+	ClientSecret string // OAuth client secret
+}
+
 // EmailConfig cấu hình gửi email
 type EmailConfig struct {
-	Enabled             bool   // Bật/tắt gửi email thật (false = console log)
-	VerificationBaseURL string // Base URL cho link xác thực (vd: http://localhost:3000)
+	Enabled             bool          // Bật/tắt gửi email thật qua SMTP (false = console log)
+	VerificationBaseURL string        // Base URL cho link xác thực và đặt lại mật khẩu (vd: http://localhost:3000)
+	Language            string        // Ngôn ngữ template email: vi hoặc en (mặc định vi)
+	SMTPHost            string        // SMTP server host
+	SMTPPort            int           // SMTP server port (mặc định 587 - submission + STARTTLS)
+	SMTPUsername        string        // SMTP username (rỗng = không AUTH)
+	SMTPPassword        string        // SMTP password
+	FromAddress         string        // Địa chỉ gửi (vd: no-reply@nhahang.vn)
+	FromName            string        // Tên hiển thị người gửi
+	RequireTLS          bool          // Bắt buộc STARTTLS, từ chối gửi nếu server không hỗ trợ (mặc định true)
+	Timeout             time.Duration // Timeout cho cả phiên SMTP (mặc định 10s)
 }
 
 // LogConfig cấu hình cho structured logger
@@ -179,6 +299,9 @@ func Load() *Config {
 			WriteTimeout:    getEnvAsDuration("SERVER_WRITE_TIMEOUT", 15*time.Second),
 			ShutdownTimeout: getEnvAsDuration("SERVER_SHUTDOWN_TIMEOUT", 30*time.Second),
 		},
+		Restaurant: RestaurantConfig{
+			Timezone: getEnv("RESTAURANT_TIMEZONE", "Asia/Ho_Chi_Minh"),
+		},
 		Log: LogConfig{
 			Level:         getEnv("LOG_LEVEL", "info"),
 			Environment:   getEnv("ENVIRONMENT", "development"),
@@ -208,12 +331,46 @@ func Load() *Config {
 		Migration: MigrationConfig{
 			AutoMigrate: getEnvAsBool("MIGRATION_AUTO_MIGRATE", true),
 		},
+		JobQueue: JobQueueConfig{
+			Workers:           getEnvAsInt("JOB_QUEUE_WORKERS", 4),
+			MaxAttempts:       getEnvAsInt("JOB_QUEUE_MAX_ATTEMPTS", 5),
+			BackoffBase:       getEnvAsDuration("JOB_QUEUE_BACKOFF_BASE", 5*time.Second),
+			BackoffMax:        getEnvAsDuration("JOB_QUEUE_BACKOFF_MAX", 10*time.Minute),
+			JobTimeout:        getEnvAsDuration("JOB_QUEUE_JOB_TIMEOUT", 30*time.Second),
+			VisibilityTimeout: getEnvAsDuration("JOB_QUEUE_VISIBILITY_TIMEOUT", 2*time.Minute),
+			DeadLetterMaxLen:  getEnvAsInt("JOB_QUEUE_DEAD_LETTER_MAX_LEN", 1000),
+		},
+		Outbox: OutboxConfig{
+			PollInterval:   getEnvAsDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			BatchSize:      getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			HandlerTimeout: getEnvAsDuration("OUTBOX_HANDLER_TIMEOUT", 30*time.Second),
+			BackoffBase:    getEnvAsDuration("OUTBOX_BACKOFF_BASE", 5*time.Second),
+			BackoffMax:     getEnvAsDuration("OUTBOX_BACKOFF_MAX", 10*time.Minute),
+			ProcessedTTL:   getEnvAsDuration("OUTBOX_PROCESSED_TTL", 7*24*time.Hour),
+			Retention:      getEnvAsDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+		},
+		Webhook: WebhookConfig{
+			Timeout:         getEnvAsDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+			AllowedNetworks: getEnvAsStringSlice("WEBHOOK_ALLOWED_NETWORKS", nil),
+		},
+		Scheduler: SchedulerConfig{
+			Enabled:              getEnvAsBool("SCHEDULER_ENABLED", true),
+			RevenueSnapshotAt:    getEnv("SCHEDULER_REVENUE_SNAPSHOT_AT", "00:05"),
+			TokenCleanupInterval: getEnvAsDuration("SCHEDULER_TOKEN_CLEANUP_INTERVAL", time.Hour),
+			LowStockCheckAt:      getEnv("SCHEDULER_LOW_STOCK_CHECK_AT", "07:00"),
+			LowStockAlertEmail:   getEnv("SCHEDULER_LOW_STOCK_ALERT_EMAIL", ""),
+			PriceChangeInterval:  getEnvAsDuration("SCHEDULER_PRICE_CHANGE_INTERVAL", time.Minute),
+			PurgeDeletedAt:       getEnv("SCHEDULER_PURGE_DELETED_AT", "03:00"),
+			SoftDeleteRetention:  getEnvAsDuration("SCHEDULER_SOFT_DELETE_RETENTION", 90*24*time.Hour),
+			OutboxCleanupAt:      getEnv("SCHEDULER_OUTBOX_CLEANUP_AT", "03:30"),
+			HistorySize:          getEnvAsInt("SCHEDULER_HISTORY_SIZE", 50),
+		},
 		Middleware: MiddlewareConfig{
 			CORS: CORSConfig{
 				Enabled:      getEnvAsBool("CORS_ENABLED", true),
 				AllowOrigins: getEnvAsStringSlice("CORS_ALLOW_ORIGINS", []string{"http://localhost:3000", "http://localhost:5173"}),
 				AllowMethods: getEnvAsStringSlice("CORS_ALLOW_METHODS", []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}),
-				AllowHeaders: getEnvAsStringSlice("CORS_ALLOW_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"}),
+				AllowHeaders: getEnvAsStringSlice("CORS_ALLOW_HEADERS", []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "X-CSRF-Token", "X-Auth-Mode", "If-Match", "Idempotency-Key"}),
 				MaxAge:       getEnvAsInt("CORS_MAX_AGE", 86400),
 			},
 			RateLimit: RateLimitConfig{
@@ -226,6 +383,15 @@ func Load() *Config {
 				SecretKey:       getEnv("JWT_SECRET_KEY", "change-this-in-production"),
 				AccessTokenTTL:  getEnv("JWT_ACCESS_TOKEN_TTL", "15m"),
 				RefreshTokenTTL: getEnv("JWT_REFRESH_TOKEN_TTL", "2h"),
+				Algorithm:       getEnv("JWT_ALGORITHM", "HS256"),
+				PrivateKeyFile:  getEnv("JWT_PRIVATE_KEY_FILE", ""),
+				PublicKeyFiles:  getEnvAsStringSlice("JWT_PUBLIC_KEY_FILES", nil),
+			},
+			AuthCookie: AuthCookieConfig{
+				Enabled:  getEnvAsBool("AUTH_COOKIE_ENABLED", false),
+				Domain:   getEnv("AUTH_COOKIE_DOMAIN", ""),
+				Secure:   getEnvAsBool("AUTH_COOKIE_SECURE", true),
+				SameSite: getEnv("AUTH_COOKIE_SAMESITE", "lax"),
 			},
 			Timeout: TimeoutConfig{
 				Enabled:  getEnvAsBool("TIMEOUT_ENABLED", true),
@@ -243,6 +409,11 @@ func Load() *Config {
 				Enabled: getEnvAsBool("BODY_LIMIT_ENABLED", true),
 				MaxSize: getEnvAsInt64("BODY_LIMIT_MAX_SIZE", 1048576), // 1MB
 			},
+			Idempotency: IdempotencyConfig{
+				Enabled: getEnvAsBool("IDEMPOTENCY_ENABLED", true),
+				TTL:     getEnvAsDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+				LockTTL: getEnvAsDuration("IDEMPOTENCY_LOCK_TTL", time.Minute),
+			},
 			AuthRateLimit: AuthRateLimitConfig{
 				Enabled: getEnvAsBool("AUTH_RATE_LIMIT_ENABLED", true),
 				RPS:     getEnvAsFloat("AUTH_RATE_LIMIT_RPS", 5),
@@ -261,9 +432,39 @@ func Load() *Config {
 				TokenTTL:    getEnvAsDuration("EMAIL_VERIFICATION_TOKEN_TTL", 24*time.Hour),
 				CooldownTTL: getEnvAsDuration("EMAIL_VERIFICATION_COOLDOWN_TTL", 60*time.Second),
 			},
+			PasswordReset: PasswordResetConfig{
+				TokenTTL:    getEnvAsDuration("PASSWORD_RESET_TOKEN_TTL", 30*time.Minute),
+				CooldownTTL: getEnvAsDuration("PASSWORD_RESET_COOLDOWN_TTL", 60*time.Second),
+			},
+			MFA: MFAConfig{
+				Issuer:          getEnv("MFA_ISSUER", "Restaurant"),
+				RequiredMinRole: getEnv("MFA_REQUIRED_MIN_ROLE", ""),
+				ChallengeTTL:    getEnvAsDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+				MaxAttempts:     getEnvAsInt("MFA_MAX_ATTEMPTS", 5),
+				RecoveryCodes:   getEnvAsInt("MFA_RECOVERY_CODES", 10),
+			},
+			OIDC: OIDCConfig{
+				RedirectURL: getEnv("OIDC_REDIRECT_URL", "http://localhost:3000/auth/callback"),
+				StateTTL:    getEnvAsDuration("OIDC_STATE_TTL", 10*time.Minute),
+				Google: OIDCProviderConfig{
+					Enabled:      getEnvAsBool("OIDC_GOOGLE_ENABLED", false),
+					IssuerURL:    getEnv("OIDC_GOOGLE_ISSUER_URL", "https://accounts.google.com"),
+					ClientID:     getEnv("OIDC_GOOGLE_CLIENT_ID", ""),
+					ClientSecret: getEnv("OIDC_GOOGLE_CLIENT_SECRET", ""),
+				},
+			},
 			Email: EmailConfig{
 				Enabled:             getEnvAsBool("EMAIL_ENABLED", false),
 				VerificationBaseURL: getEnv("EMAIL_VERIFICATION_BASE_URL", "http://localhost:3000"),
+				Language:            getEnv("EMAIL_LANGUAGE", "vi"),
+				SMTPHost:            getEnv("SMTP_HOST", ""),
+				SMTPPort:            getEnvAsInt("SMTP_PORT", 587),
+				SMTPUsername:        getEnv("SMTP_USERNAME", ""),
+				SMTPPassword:        getEnv("SMTP_PASSWORD", ""),
+				FromAddress:         getEnv("EMAIL_FROM_ADDRESS", "no-reply@localhost"),
+				FromName:            getEnv("EMAIL_FROM_NAME", "Restaurant"),
+				RequireTLS:          getEnvAsBool("SMTP_REQUIRE_TLS", true),
+				Timeout:             getEnvAsDuration("SMTP_TIMEOUT", 10*time.Second),
 			},
 		},
 	}
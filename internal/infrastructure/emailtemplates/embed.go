@@ -0,0 +1,10 @@
+// Package emailtemplates chứa embedded email templates (HTML + text) theo ngôn ngữ
+package emailtemplates
+
+import "embed"
+
+// Files chứa tất cả email templates
+// Cấu trúc: {ngôn ngữ}/{tên}.txt (định nghĩa "subject" + nội dung text) và {ngôn ngữ}/{tên}.html
+//
+//go:embed vi/* en/*
+var Files embed.FS
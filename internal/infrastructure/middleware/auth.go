@@ -27,11 +27,23 @@ const (
 // UserClaims chứa thông tin user trong JWT
 type UserClaims struct {
 	jwt.RegisteredClaims
-	UserID string `json:"user_id"`
-	Role   string `json:"role"`
-	Email  string `json:"email,omitempty"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`
+	Email     string `json:"email,omitempty"`
+	SessionID string `json:"sid,omitempty"` // Phiên đăng nhập sinh ra token (giữ nguyên qua các lần refresh)
+	TokenType string `json:"typ,omitempty"` // access | refresh (rỗng = token cấp trước khi có claim này)
+
+	// Permissions: quyền của role tại thời điểm cấp access token (luôn có, [] nếu không có quyền nào)
+	// nil = token cấp trước khi có phân quyền theo permission
+	Permissions []string `json:"perms"`
 }
 
+// Giá trị claim typ
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
 // TokenInfo chứa thông tin token được tạo (dùng để track)
 type TokenInfo struct {
 	Token string        // JWT token string
@@ -41,15 +53,27 @@ type TokenInfo struct {
 
 // JWTAuthMiddleware quản lý JWT authentication
 type JWTAuthMiddleware struct {
-	secretKey        []byte
+	keys             *jwtKeySet
 	accessTokenTTL   time.Duration
 	refreshTokenTTL  time.Duration
 	enabled          bool
+	cookies          *cookieAuth
 	blacklistService service.TokenBlacklistService
 }
 
-// NewJWTAuth tạo JWTAuthMiddleware mới
-func NewJWTAuth(cfg config.JWTConfig, blacklistService service.TokenBlacklistService) *JWTAuthMiddleware {
+// NewJWTAuth tạo JWTAuthMiddleware mới, trả về lỗi nếu không nạp được signing key
+// hoặc cấu hình cookie không hợp lệ
+func NewJWTAuth(cfg config.JWTConfig, cookieCfg config.AuthCookieConfig, blacklistService service.TokenBlacklistService) (*JWTAuthMiddleware, error) {
+	keys, err := loadKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cookies, err := newCookieAuth(cookieCfg)
+	if err != nil {
+		return nil, err
+	}
+
 	accessTTL, _ := time.ParseDuration(cfg.AccessTokenTTL)
 	if accessTTL == 0 {
 		accessTTL = 15 * time.Minute
@@ -61,17 +85,18 @@ func NewJWTAuth(cfg config.JWTConfig, blacklistService service.TokenBlacklistSer
 	}
 
 	return &JWTAuthMiddleware{
-		secretKey:        []byte(cfg.SecretKey),
+		keys:             keys,
 		accessTokenTTL:   accessTTL,
 		refreshTokenTTL:  refreshTTL,
 		enabled:          cfg.Enabled,
+		cookies:          cookies,
 		blacklistService: blacklistService,
-	}
+	}, nil
 }
 
 // GenerateAccessToken tạo access token mới với JTI để support blacklist
 func (j *JWTAuthMiddleware) GenerateAccessToken(userID, role, email string) (string, error) {
-	tokenInfo, err := j.GenerateAccessTokenWithInfo(userID, role, email)
+	tokenInfo, err := j.GenerateAccessTokenWithInfo(userID, role, email, "", nil)
 	if err != nil {
 		return "", err
 	}
@@ -79,8 +104,13 @@ func (j *JWTAuthMiddleware) GenerateAccessToken(userID, role, email string) (str
 }
 
 // GenerateAccessTokenWithInfo tạo access token và trả về đầy đủ thông tin (bao gồm JTI)
-func (j *JWTAuthMiddleware) GenerateAccessTokenWithInfo(userID, role, email string) (*TokenInfo, error) {
+// sessionID rỗng = token không gắn với phiên đăng nhập nào
+// permissions: quyền của role, ghi vào claim perms để RequirePermission kiểm tra mà không cần truy vấn DB
+func (j *JWTAuthMiddleware) GenerateAccessTokenWithInfo(userID, role, email, sessionID string, permissions []string) (*TokenInfo, error) {
 	jti := uuid.New().String()
+	if permissions == nil {
+		permissions = []string{}
+	}
 
 	claims := UserClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -89,13 +119,15 @@ func (j *JWTAuthMiddleware) GenerateAccessTokenWithInfo(userID, role, email stri
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "restaurant-api",
 		},
-		UserID: userID,
-		Role:   role,
-		Email:  email,
+		UserID:      userID,
+		Role:        role,
+		Email:       email,
+		SessionID:   sessionID,
+		TokenType:   TokenTypeAccess,
+		Permissions: permissions,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secretKey)
+	tokenString, err := j.keys.sign(claims)
 	if err != nil {
 		return nil, err
 	}
@@ -109,7 +141,7 @@ func (j *JWTAuthMiddleware) GenerateAccessTokenWithInfo(userID, role, email stri
 
 // GenerateRefreshToken tạo refresh token mới với JTI
 func (j *JWTAuthMiddleware) GenerateRefreshToken(userID string) (string, error) {
-	tokenInfo, err := j.GenerateRefreshTokenWithInfo(userID)
+	tokenInfo, err := j.GenerateRefreshTokenWithInfo(userID, "")
 	if err != nil {
 		return "", err
 	}
@@ -117,19 +149,23 @@ func (j *JWTAuthMiddleware) GenerateRefreshToken(userID string) (string, error)
 }
 
 // GenerateRefreshTokenWithInfo tạo refresh token và trả về đầy đủ thông tin (bao gồm JTI)
-func (j *JWTAuthMiddleware) GenerateRefreshTokenWithInfo(userID string) (*TokenInfo, error) {
+// Refresh token chỉ mang Subject (userID) và sid, không mang role/email
+func (j *JWTAuthMiddleware) GenerateRefreshTokenWithInfo(userID, sessionID string) (*TokenInfo, error) {
 	jti := uuid.New().String()
 
-	claims := jwt.RegisteredClaims{
-		ID:        jti, // JTI cho token blacklist
-		ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.refreshTokenTTL)),
-		IssuedAt:  jwt.NewNumericDate(time.Now()),
-		Subject:   userID,
-		Issuer:    "restaurant-api",
+	claims := UserClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti, // JTI cho token blacklist
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(j.refreshTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Subject:   userID,
+			Issuer:    "restaurant-api",
+		},
+		SessionID: sessionID,
+		TokenType: TokenTypeRefresh,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(j.secretKey)
+	tokenString, err := j.keys.sign(claims)
 	if err != nil {
 		return nil, err
 	}
@@ -143,12 +179,7 @@ func (j *JWTAuthMiddleware) GenerateRefreshTokenWithInfo(userID string) (*TokenI
 
 // ValidateToken xác thực và parse JWT token
 func (j *JWTAuthMiddleware) ValidateToken(tokenString string) (*UserClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return j.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, j.keys.keyFunc)
 
 	if err != nil {
 		return nil, err
@@ -165,12 +196,7 @@ func (j *JWTAuthMiddleware) ValidateToken(tokenString string) (*UserClaims, erro
 // ParseTokenUnverifiedExpiry parse JWT token mà không check expiry
 // Dùng khi cần lấy JTI từ access token đã hết hạn (ví dụ: khi refresh)
 func (j *JWTAuthMiddleware) ParseTokenIgnoreExpiry(tokenString string) (*UserClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return j.secretKey, nil
-	}, jwt.WithoutClaimsValidation())
+	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, j.keys.keyFunc, jwt.WithoutClaimsValidation())
 
 	if err != nil {
 		return nil, err
@@ -193,9 +219,33 @@ func (j *JWTAuthMiddleware) Middleware() gin.HandlerFunc {
 			return
 		}
 
-		// Lấy token từ Authorization header
+		// Lấy token từ Authorization header, nếu không có thì từ cookie (web frontend)
+		var tokenString string
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		if authHeader != "" {
+			// Kiểm tra format "Bearer <token>"
+			parts := strings.SplitN(authHeader, " ", 2)
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error":      "Invalid authorization format. Use: Bearer <token>",
+					"code":       "AUTH_FORMAT_INVALID",
+					"request_id": logger.GetRequestID(c),
+				})
+				return
+			}
+			tokenString = parts[1]
+		} else if cookieToken, ok := j.AccessTokenFromCookie(c); ok {
+			// Cookie tự gửi kèm cả request từ site khác → bắt buộc CSRF token
+			if !j.VerifyCSRF(c) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error":      "CSRF token missing or invalid",
+					"code":       "CSRF_TOKEN_INVALID",
+					"request_id": logger.GetRequestID(c),
+				})
+				return
+			}
+			tokenString = cookieToken
+		} else {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":      "Authorization header required",
 				"code":       "AUTH_HEADER_MISSING",
@@ -204,19 +254,8 @@ func (j *JWTAuthMiddleware) Middleware() gin.HandlerFunc {
 			return
 		}
 
-		// Kiểm tra format "Bearer <token>"
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":      "Invalid authorization format. Use: Bearer <token>",
-				"code":       "AUTH_FORMAT_INVALID",
-				"request_id": logger.GetRequestID(c),
-			})
-			return
-		}
-
 		// Validate token
-		claims, err := j.ValidateToken(parts[1])
+		claims, err := j.ValidateToken(tokenString)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":      "Invalid or expired token",
@@ -253,6 +292,7 @@ func (j *JWTAuthMiddleware) Middleware() gin.HandlerFunc {
 		c.Set(ContextKeyUserID, claims.UserID)
 		c.Set(ContextKeyUserRole, claims.Role)
 		c.Set(ContextKeyClaims, claims)
+		attachRequestActor(c, claims)
 
 		c.Next()
 	}
@@ -267,19 +307,19 @@ func (j *JWTAuthMiddleware) OptionalMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		tokenString, fromCookie, ok := j.ExtractToken(c)
+		if !ok {
 			c.Next()
 			return
 		}
 
-		parts := strings.SplitN(authHeader, " ", 2)
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		// Cookie không kèm CSRF token hợp lệ → coi như request ẩn danh
+		if fromCookie && !j.VerifyCSRF(c) {
 			c.Next()
 			return
 		}
 
-		claims, err := j.ValidateToken(parts[1])
+		claims, err := j.ValidateToken(tokenString)
 		if err != nil {
 			c.Next()
 			return
@@ -288,6 +328,7 @@ func (j *JWTAuthMiddleware) OptionalMiddleware() gin.HandlerFunc {
 		c.Set(ContextKeyUserID, claims.UserID)
 		c.Set(ContextKeyUserRole, claims.Role)
 		c.Set(ContextKeyClaims, claims)
+		attachRequestActor(c, claims)
 
 		c.Next()
 	}
@@ -335,6 +376,11 @@ func ExtractTokenFromHeader(c *gin.Context) (string, bool) {
 	return parts[1], true
 }
 
+// RefreshTokenTTL trả về thời gian sống của refresh token (cũng là thời gian sống tối đa của session không hoạt động)
+func (j *JWTAuthMiddleware) RefreshTokenTTL() time.Duration {
+	return j.refreshTokenTTL
+}
+
 // GetTokenRemainingTime tính thời gian còn lại của token (cho blacklist TTL)
 func (j *JWTAuthMiddleware) GetTokenRemainingTime(claims *UserClaims) time.Duration {
 	if claims.ExpiresAt == nil {
@@ -383,3 +429,13 @@ func (j *JWTAuthMiddleware) BlacklistToken(c *gin.Context, claims *UserClaims) e
 func (j *JWTAuthMiddleware) GetBlacklistService() service.TokenBlacklistService {
 	return j.blacklistService
 }
+
+// JWKS trả về các public key đang được chấp nhận (rỗng khi dùng HS256)
+func (j *JWTAuthMiddleware) JWKS() JWKS {
+	return j.keys.jwks
+}
+
+// Algorithm trả về thuật toán đang dùng để ký token
+func (j *JWTAuthMiddleware) Algorithm() string {
+	return j.keys.signing.method.Alg()
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Cookie auth cho web frontend: token nằm trong HttpOnly cookie nên JavaScript (và mã XSS) không đọc được.
+// Cookie tự gửi kèm mọi request nên cần chống CSRF bằng double-submit:
+// cookie csrf_token (JavaScript đọc được) phải được gửi lại trong header X-CSRF-Token.
+// Cookie của site khác không đọc được nên trang giả mạo không thể tạo header hợp lệ
+const (
+	AccessTokenCookie  = "access_token"
+	RefreshTokenCookie = "refresh_token"
+	CSRFTokenCookie    = "csrf_token"
+	CSRFTokenHeader    = "X-CSRF-Token"
+
+	// AuthModeHeader: web frontend gửi "X-Auth-Mode: cookie" khi login/refresh để nhận token qua cookie
+	AuthModeHeader = "X-Auth-Mode"
+	AuthModeCookie = "cookie"
+
+	// refreshTokenCookiePath giới hạn refresh token chỉ gửi kèm /api/auth/* (refresh, logout)
+	refreshTokenCookiePath = "/api/auth"
+	csrfTokenBytes         = 32
+)
+
+// ErrCSRFTokenInvalid khi request xác thực bằng cookie thiếu hoặc sai CSRF token
+var ErrCSRFTokenInvalid = errors.New("CSRF token không hợp lệ")
+
+// cookieAuth là cấu hình cookie đã được kiểm tra
+type cookieAuth struct {
+	enabled  bool
+	domain   string
+	secure   bool
+	sameSite http.SameSite
+}
+
+// newCookieAuth kiểm tra cấu hình cookie auth
+func newCookieAuth(cfg config.AuthCookieConfig) (*cookieAuth, error) {
+	var sameSite http.SameSite
+	switch strings.ToLower(cfg.SameSite) {
+	case "", "lax":
+		sameSite = http.SameSiteLaxMode
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		// Trình duyệt bỏ qua cookie SameSite=None không có Secure
+		if !cfg.Secure {
+			return nil, errors.New("AUTH_COOKIE_SAMESITE=none requires AUTH_COOKIE_SECURE=true")
+		}
+		sameSite = http.SameSiteNoneMode
+	default:
+		return nil, fmt.Errorf("unsupported AUTH_COOKIE_SAMESITE %q (use strict, lax or none)", cfg.SameSite)
+	}
+
+	return &cookieAuth{
+		enabled:  cfg.Enabled,
+		domain:   cfg.Domain,
+		secure:   cfg.Secure,
+		sameSite: sameSite,
+	}, nil
+}
+
+// setCookie ghi cookie với thuộc tính chung (Secure, SameSite, Domain)
+func (a *cookieAuth) setCookie(c *gin.Context, name, value, path string, maxAge int, httpOnly bool) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   a.domain,
+		MaxAge:   maxAge,
+		Secure:   a.secure,
+		HttpOnly: httpOnly,
+		SameSite: a.sameSite,
+	})
+}
+
+// CookieAuthEnabled cho biết server có hỗ trợ chế độ cookie không
+func (j *JWTAuthMiddleware) CookieAuthEnabled() bool {
+	return j.cookies.enabled
+}
+
+// UseCookieAuth cho biết request dùng chế độ cookie:
+// client yêu cầu qua header X-Auth-Mode, hoặc không có Authorization header nhưng có cookie auth.
+// App mobile gửi Bearer token và không có cookie nên luôn ở chế độ bearer
+func (j *JWTAuthMiddleware) UseCookieAuth(c *gin.Context) bool {
+	if !j.cookies.enabled {
+		return false
+	}
+	if strings.EqualFold(c.GetHeader(AuthModeHeader), AuthModeCookie) {
+		return true
+	}
+	if c.GetHeader("Authorization") != "" {
+		return false
+	}
+	_, hasAccess := j.AccessTokenFromCookie(c)
+	_, hasRefresh := j.RefreshTokenFromCookie(c)
+	return hasAccess || hasRefresh
+}
+
+// SetAuthCookies ghi access/refresh token vào HttpOnly cookie và cấp CSRF token mới
+// Trả về CSRF token để frontend khác origin (không đọc được cookie của API) vẫn lấy được
+func (j *JWTAuthMiddleware) SetAuthCookies(c *gin.Context, accessToken, refreshToken string) (string, error) {
+	buf := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate CSRF token: %w", err)
+	}
+	csrfToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	refreshMaxAge := int(j.refreshTokenTTL.Seconds())
+	j.cookies.setCookie(c, AccessTokenCookie, accessToken, "/", int(j.accessTokenTTL.Seconds()), true)
+	j.cookies.setCookie(c, RefreshTokenCookie, refreshToken, refreshTokenCookiePath, refreshMaxAge, true)
+	// CSRF cookie sống bằng refresh token để vẫn gọi được /auth/refresh khi access token đã hết hạn
+	j.cookies.setCookie(c, CSRFTokenCookie, csrfToken, "/", refreshMaxAge, false)
+
+	return csrfToken, nil
+}
+
+// ClearAuthCookies xóa cookie auth (logout)
+func (j *JWTAuthMiddleware) ClearAuthCookies(c *gin.Context) {
+	j.cookies.setCookie(c, AccessTokenCookie, "", "/", -1, true)
+	j.cookies.setCookie(c, RefreshTokenCookie, "", refreshTokenCookiePath, -1, true)
+	j.cookies.setCookie(c, CSRFTokenCookie, "", "/", -1, false)
+}
+
+// AccessTokenFromCookie lấy access token từ cookie (chỉ khi bật cookie auth)
+func (j *JWTAuthMiddleware) AccessTokenFromCookie(c *gin.Context) (string, bool) {
+	return j.cookieValue(c, AccessTokenCookie)
+}
+
+// RefreshTokenFromCookie lấy refresh token từ cookie (chỉ khi bật cookie auth)
+func (j *JWTAuthMiddleware) RefreshTokenFromCookie(c *gin.Context) (string, bool) {
+	return j.cookieValue(c, RefreshTokenCookie)
+}
+
+// cookieValue đọc cookie khác rỗng
+func (j *JWTAuthMiddleware) cookieValue(c *gin.Context, name string) (string, bool) {
+	if !j.cookies.enabled {
+		return "", false
+	}
+	value, err := c.Cookie(name)
+	if err != nil || value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+// VerifyCSRF kiểm tra double-submit CSRF token cho request xác thực bằng cookie
+// Method an toàn (GET, HEAD, OPTIONS) không làm thay đổi dữ liệu nên không cần kiểm tra
+func (j *JWTAuthMiddleware) VerifyCSRF(c *gin.Context) bool {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+
+	cookieToken, err := c.Cookie(CSRFTokenCookie)
+	if err != nil || cookieToken == "" {
+		return false
+	}
+	headerToken := c.GetHeader(CSRFTokenHeader)
+	if headerToken == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(cookieToken), []byte(headerToken)) == 1
+}
+
+// ExtractToken lấy access token từ Authorization header, nếu không có thì từ cookie
+// fromCookie = true khi token lấy từ cookie (request cần CSRF token)
+func (j *JWTAuthMiddleware) ExtractToken(c *gin.Context) (token string, fromCookie bool, ok bool) {
+	if token, ok := ExtractTokenFromHeader(c); ok {
+		return token, false, true
+	}
+	if c.GetHeader("Authorization") != "" {
+		return "", false, false
+	}
+	if token, ok := j.AccessTokenFromCookie(c); ok {
+		return token, true, true
+	}
+	return "", false, false
+}
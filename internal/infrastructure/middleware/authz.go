@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 
@@ -13,14 +14,18 @@ const (
 	RoleAdmin    = "admin"
 	RoleManager  = "manager"
 	RoleStaff    = "staff"
+	RoleCashier  = "cashier"
+	RoleChef     = "chef"
 	RoleCustomer = "customer"
 )
 
-// roleHierarchy định nghĩa thứ tự quyền hạn
-// Số càng cao = quyền càng lớn
+// roleHierarchy định nghĩa cấp bậc role cho các chính sách theo cấp (MFA_REQUIRED_MIN_ROLE)
+// Phân quyền API không dùng cấp bậc mà dùng permission (RequirePermission)
 var roleHierarchy = map[string]int{
 	RoleCustomer: 1,
 	RoleStaff:    2,
+	RoleCashier:  2,
+	RoleChef:     2,
 	RoleManager:  3,
 	RoleAdmin:    4,
 }
@@ -74,11 +79,13 @@ func RequireRole(roles ...string) gin.HandlerFunc {
 	}
 }
 
-// RequireMinRole middleware yêu cầu user phải có role tối thiểu
-// Sử dụng: router.Use(RequireMinRole("manager")) - manager và admin đều được phép
-func RequireMinRole(minRole string) gin.HandlerFunc {
+// RequirePermission middleware yêu cầu access token có đủ các permission
+// Permission lấy từ claim perms (quyền của role lúc cấp token), không truy vấn DB mỗi request;
+// admin đổi quyền của role thì có hiệu lực từ lần refresh token tiếp theo
+// Sử dụng: router.Use(RequirePermission(entity.PermissionMenuWrite))
+func RequirePermission(permissions ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userRole, exists := GetUserRole(c)
+		claims, exists := GetClaims(c)
 		if !exists {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":      "Authentication required",
@@ -88,27 +95,26 @@ func RequireMinRole(minRole string) gin.HandlerFunc {
 			return
 		}
 
-		userLevel, userOK := roleHierarchy[userRole]
-		minLevel, minOK := roleHierarchy[minRole]
-
-		if !userOK || !minOK {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error":      "Invalid role configuration",
-				"code":       "INVALID_ROLE",
+		// Token cấp trước khi có claim perms: client refresh để nhận token mới
+		if claims.Permissions == nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error":      "Token does not carry permissions, please refresh",
+				"code":       "TOKEN_REFRESH_REQUIRED",
 				"request_id": logger.GetRequestID(c),
 			})
 			return
 		}
 
-		if userLevel < minLevel {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error":        "Insufficient permissions",
-				"code":         "FORBIDDEN",
-				"min_required": minRole,
-				"your_role":    userRole,
-				"request_id":   logger.GetRequestID(c),
-			})
-			return
+		for _, permission := range permissions {
+			if !slices.Contains(claims.Permissions, permission) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+					"error":                "Insufficient permissions",
+					"code":                 "FORBIDDEN",
+					"required_permissions": permissions,
+					"request_id":           logger.GetRequestID(c),
+				})
+				return
+			}
 		}
 
 		c.Next()
@@ -117,6 +123,8 @@ func RequireMinRole(minRole string) gin.HandlerFunc {
 
 // RequireOwnerOrRole middleware cho phép nếu là chủ sở hữu hoặc có role đủ quyền
 // ownerIDParam là tên param chứa owner ID (ví dụ: "user_id", "id")
+// Chỉ so sánh param với user ID; tài nguyên sở hữu gián tiếp (đơn hàng của khách hàng, đơn gán cho đầu bếp)
+// được kiểm tra trên entity đã load trong use case (xem usecase/order_policy.go)
 func RequireOwnerOrRole(ownerIDParam string, roles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID, exists := GetUserID(c)
@@ -159,22 +167,29 @@ func IsAdmin(c *gin.Context) bool {
 	return exists && role == RoleAdmin
 }
 
-// IsManager helper kiểm tra user có phải manager trở lên không
-func IsManager(c *gin.Context) bool {
-	role, exists := GetUserRole(c)
+// GetPermissions lấy permission của access token từ context
+func GetPermissions(c *gin.Context) []string {
+	claims, exists := GetClaims(c)
 	if !exists {
-		return false
+		return nil
 	}
-	level, ok := roleHierarchy[role]
-	return ok && level >= roleHierarchy[RoleManager]
+	return claims.Permissions
 }
 
-// IsStaff helper kiểm tra user có phải staff trở lên không
-func IsStaff(c *gin.Context) bool {
-	role, exists := GetUserRole(c)
-	if !exists {
-		return false
-	}
+// HasPermission helper kiểm tra access token có permission không
+func HasPermission(c *gin.Context, permission string) bool {
+	return slices.Contains(GetPermissions(c), permission)
+}
+
+// IsValidRole kiểm tra role có nằm trong phân cấp quyền không
+func IsValidRole(role string) bool {
+	_, ok := roleHierarchy[role]
+	return ok
+}
+
+// HasMinRole kiểm tra role có đạt mức tối thiểu minRole không (dùng ngoài HTTP context)
+func HasMinRole(role, minRole string) bool {
 	level, ok := roleHierarchy[role]
-	return ok && level >= roleHierarchy[RoleStaff]
+	minLevel, minOK := roleHierarchy[minRole]
+	return ok && minOK && level >= minLevel
 }
@@ -43,7 +43,7 @@ func CORS(cfg config.CORSConfig) gin.HandlerFunc {
 		AllowOrigins:     origins,
 		AllowMethods:     cfg.AllowMethods,
 		AllowHeaders:     cfg.AllowHeaders,
-		ExposeHeaders:    []string{"Content-Length", "Content-Type", "X-Request-ID"},
+		ExposeHeaders:    []string{"Content-Length", "Content-Type", "X-Request-ID", "ETag", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           time.Duration(cfg.MaxAge) * time.Second,
 	}
@@ -0,0 +1,255 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/pkg/logger"
+)
+
+// Header Idempotency-Key
+const (
+	HeaderIdempotencyKey     = "Idempotency-Key"
+	HeaderIdempotentReplayed = "Idempotent-Replayed" // "true" khi response được phát lại từ lần gửi đầu tiên
+
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotentHeaders là các header của response được lưu và phát lại cùng body
+var idempotentHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// IdempotencyMiddleware chống gửi trùng POST bằng header Idempotency-Key
+// Response đầu tiên theo (user, key) được lưu lại và phát lại cho các request gửi lại,
+// cùng key nhưng khác payload trả về 409
+type IdempotencyMiddleware struct {
+	store   service.IdempotencyStore
+	enabled bool
+	ttl     time.Duration
+	lockTTL time.Duration
+}
+
+// NewIdempotency tạo IdempotencyMiddleware mới
+func NewIdempotency(cfg config.IdempotencyConfig, store service.IdempotencyStore) *IdempotencyMiddleware {
+	return &IdempotencyMiddleware{
+		store:   store,
+		enabled: cfg.Enabled,
+		ttl:     cfg.TTL,
+		lockTTL: cfg.LockTTL,
+	}
+}
+
+// IdempotencyOption tùy chỉnh idempotency cho từng route group
+type IdempotencyOption func(*idempotencyOptions)
+
+type idempotencyOptions struct {
+	ttl      time.Duration
+	required bool
+}
+
+// IdempotencyTTL đặt thời gian giữ response đã lưu cho route group (thay cho IDEMPOTENCY_TTL)
+func IdempotencyTTL(ttl time.Duration) IdempotencyOption {
+	return func(o *idempotencyOptions) {
+		o.ttl = ttl
+	}
+}
+
+// IdempotencyKeyRequired bắt buộc POST phải có header Idempotency-Key (400 nếu thiếu)
+func IdempotencyKeyRequired() IdempotencyOption {
+	return func(o *idempotencyOptions) {
+		o.required = true
+	}
+}
+
+// Middleware trả về gin middleware cho một route group
+// Sử dụng sau JWTAuth middleware: khóa được tách theo user, request chưa xác thực được bỏ qua
+// Chỉ áp dụng cho POST, request không có Idempotency-Key xử lý như bình thường (trừ khi bắt buộc)
+func (m *IdempotencyMiddleware) Middleware(opts ...IdempotencyOption) gin.HandlerFunc {
+	o := idempotencyOptions{ttl: m.ttl}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return func(c *gin.Context) {
+		if !m.enabled || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		key := strings.TrimSpace(c.GetHeader(HeaderIdempotencyKey))
+		if key == "" {
+			if o.required {
+				abortIdempotency(c, http.StatusBadRequest, "Idempotency-Key header is required", "IDEMPOTENCY_KEY_REQUIRED")
+				return
+			}
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			abortIdempotency(c, http.StatusBadRequest, "Idempotency-Key is too long", "INVALID_IDEMPOTENCY_KEY")
+			return
+		}
+
+		userID, ok := GetUserID(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				abortIdempotency(c, http.StatusRequestEntityTooLarge, "Request body too large", "BODY_TOO_LARGE")
+				return
+			}
+			abortIdempotency(c, http.StatusBadRequest, "Unable to read request body", "INVALID_REQUEST_BODY")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		storeKey := userID + ":" + key
+		fingerprint := requestFingerprint(c.Request, body)
+		ctx := c.Request.Context()
+
+		token, reserved, err := m.store.Reserve(ctx, storeKey, fingerprint, m.lockTTL)
+		if err != nil {
+			// Fail-closed: client gửi key để không bị xử lý trùng, không kiểm tra được thì không xử lý
+			logger.CtxError(ctx, "Failed to reserve idempotency key", zap.Error(err))
+			abortIdempotency(c, http.StatusServiceUnavailable, "Unable to verify idempotency key", "IDEMPOTENCY_CHECK_FAILED")
+			return
+		}
+		if !reserved {
+			m.replay(c, storeKey, fingerprint)
+			return
+		}
+
+		writer := &idempotencyResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		m.save(c, storeKey, token, fingerprint, writer, o.ttl)
+	}
+}
+
+// replay trả về response đã lưu của request đầu tiên, hoặc 409 nếu key bị dùng lại / đang xử lý
+func (m *IdempotencyMiddleware) replay(c *gin.Context, storeKey, fingerprint string) {
+	rec, err := m.store.Get(c.Request.Context(), storeKey)
+	if err != nil {
+		logger.CtxError(c.Request.Context(), "Failed to get idempotent response", zap.Error(err))
+		abortIdempotency(c, http.StatusServiceUnavailable, "Unable to verify idempotency key", "IDEMPOTENCY_CHECK_FAILED")
+		return
+	}
+
+	if rec != nil && rec.Fingerprint != fingerprint {
+		abortIdempotency(c, http.StatusConflict, "Idempotency-Key was already used with a different request", "IDEMPOTENCY_KEY_REUSED")
+		return
+	}
+	// rec == nil: khóa vừa hết hạn hoặc vừa được nhả giữa hai lần đọc, client gửi lại sau
+	if rec == nil || rec.InProgress {
+		c.Header("Retry-After", "1")
+		abortIdempotency(c, http.StatusConflict, "A request with this Idempotency-Key is still being processed", "IDEMPOTENCY_REQUEST_IN_PROGRESS")
+		return
+	}
+
+	for name, value := range rec.Headers {
+		c.Header(name, value)
+	}
+	c.Header(HeaderIdempotentReplayed, "true")
+	c.Data(rec.StatusCode, rec.Headers["Content-Type"], rec.Body)
+	c.Abort()
+}
+
+// save lưu response của request đầu tiên
+// Lỗi server và lỗi không do payload (401, 403, 408, 429) không được lưu: nhả khóa để client gửi lại được.
+// Chỉ tác động lên khóa còn do token giữ: xử lý lâu hơn lockTTL thì khóa có thể đã thuộc request khác
+func (m *IdempotencyMiddleware) save(c *gin.Context, storeKey, token, fingerprint string, writer *idempotencyResponseWriter, ttl time.Duration) {
+	// Context mới: request context có thể đã bị hủy (timeout, client ngắt kết nối)
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(c.Request.Context()), 5*time.Second)
+	defer cancel()
+
+	status := writer.Status()
+	switch {
+	case status >= http.StatusInternalServerError,
+		status == http.StatusUnauthorized,
+		status == http.StatusForbidden,
+		status == http.StatusRequestTimeout,
+		status == http.StatusTooManyRequests:
+		if err := m.store.Release(ctx, storeKey, token); err != nil {
+			logger.CtxError(ctx, "Failed to release idempotency key", zap.Error(err))
+		}
+		return
+	}
+
+	headers := make(map[string]string, len(idempotentHeaders))
+	for _, name := range idempotentHeaders {
+		if value := writer.Header().Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	saved, err := m.store.Complete(ctx, storeKey, token, &service.IdempotentResponse{
+		Fingerprint: fingerprint,
+		StatusCode:  status,
+		Headers:     headers,
+		Body:        writer.body.Bytes(),
+	}, ttl)
+	if err != nil {
+		// Khóa đang xử lý sẽ hết hạn sau lockTTL, trong lúc đó request gửi lại nhận 409
+		logger.CtxError(ctx, "Failed to save idempotent response",
+			zap.String("request_id", logger.GetRequestID(c)),
+			zap.Error(err),
+		)
+		return
+	}
+	if !saved {
+		logger.CtxWarn(ctx, "Idempotency key expired before response was saved",
+			zap.String("request_id", logger.GetRequestID(c)),
+			zap.Duration("lock_ttl", m.lockTTL),
+		)
+	}
+}
+
+// requestFingerprint băm method, path (kèm query) và body để nhận ra key bị dùng lại cho request khác
+func requestFingerprint(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// abortIdempotency trả về lỗi theo format chung của middleware
+func abortIdempotency(c *gin.Context, status int, message, code string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error":      message,
+		"code":       code,
+		"request_id": logger.GetRequestID(c),
+	})
+}
+
+// idempotencyResponseWriter ghi response xuống client đồng thời giữ lại body để lưu
+type idempotencyResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyResponseWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyResponseWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
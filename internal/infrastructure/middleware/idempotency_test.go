@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// fakeIdempotencyStore lưu bản ghi trong bộ nhớ, cùng ngữ nghĩa token CAS với RedisIdempotencyStore
+// (không mô phỏng TTL: test hết hạn khóa bằng hetHan)
+type fakeIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]service.IdempotentResponse
+	soToken int
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{records: make(map[string]service.IdempotentResponse)}
+}
+
+func (s *fakeIdempotencyStore) Reserve(_ context.Context, key, fingerprint string, _ time.Duration) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[key]; ok {
+		return "", false, nil
+	}
+	s.soToken++
+	token := fmt.Sprintf("token-%d", s.soToken)
+	s.records[key] = service.IdempotentResponse{Fingerprint: fingerprint, InProgress: true, Token: token}
+	return token, true, nil
+}
+
+func (s *fakeIdempotencyStore) Get(_ context.Context, key string) (*service.IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rec, ok := s.records[key]
+	if !ok {
+		return nil, nil
+	}
+	return &rec, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(_ context.Context, key, token string, resp *service.IdempotentResponse, _ time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records[key].Token != token {
+		return false, nil
+	}
+	luu := *resp
+	luu.InProgress, luu.Token = false, ""
+	s.records[key] = luu
+	return true, nil
+}
+
+func (s *fakeIdempotencyStore) Release(_ context.Context, key, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.records[key].Token == token {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+// hetHan xóa khóa như khi lockTTL trôi qua
+func (s *fakeIdempotencyStore) hetHan(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+}
+
+// idempotencyTestServer dựng router: user lấy từ header X-Test-User (thay cho JWTAuth),
+// POST /items trả về status theo header X-Test-Status và đếm số lần handler chạy
+type idempotencyTestServer struct {
+	srv      *httptest.Server
+	store    *fakeIdempotencyStore
+	mu       sync.Mutex
+	soLan    int
+	trongKhi func() // Chạy bên trong handler (mô phỏng việc xảy ra khi request đầu đang xử lý)
+}
+
+func newIdempotencyTestServer(t *testing.T, opts ...IdempotencyOption) *idempotencyTestServer {
+	t.Helper()
+	ts := &idempotencyTestServer{store: newFakeIdempotencyStore()}
+	m := NewIdempotency(config.IdempotencyConfig{Enabled: true, TTL: time.Hour, LockTTL: time.Minute}, ts.store)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if user := c.GetHeader("X-Test-User"); user != "" {
+			c.Set(ContextKeyUserID, user)
+		}
+		c.Next()
+	})
+	r.Use(m.Middleware(opts...))
+	r.POST("/items", func(c *gin.Context) {
+		ts.mu.Lock()
+		ts.soLan++
+		n := ts.soLan
+		ts.mu.Unlock()
+		if ts.trongKhi != nil {
+			ts.trongKhi()
+		}
+
+		status := http.StatusCreated
+		if s := c.GetHeader("X-Test-Status"); s != "" {
+			fmt.Sscanf(s, "%d", &status)
+		}
+		c.Header("Location", fmt.Sprintf("/items/%d", n))
+		c.JSON(status, gin.H{"lan": n})
+	})
+
+	ts.srv = httptest.NewServer(r)
+	t.Cleanup(ts.srv.Close)
+	return ts
+}
+
+func (ts *idempotencyTestServer) post(t *testing.T, user, key, body string, status int) (*http.Response, string) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, ts.srv.URL+"/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+	}
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	if status != 0 {
+		req.Header.Set("X-Test-Status", fmt.Sprint(status))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("POST /items error = %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body error = %v", err)
+	}
+	return resp, string(respBody)
+}
+
+func (ts *idempotencyTestServer) soLanChay() int {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.soLan
+}
+
+func TestIdempotency_PhatLai(t *testing.T) {
+	ts := newIdempotencyTestServer(t)
+
+	resp1, body1 := ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 0)
+	resp2, body2 := ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 0)
+
+	if resp1.StatusCode != http.StatusCreated || resp2.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, %d, want 201, 201", resp1.StatusCode, resp2.StatusCode)
+	}
+	if body1 != body2 {
+		t.Errorf("replayed body = %s, want %s", body2, body1)
+	}
+	if resp2.Header.Get("Location") != resp1.Header.Get("Location") {
+		t.Errorf("replayed Location = %q, want %q", resp2.Header.Get("Location"), resp1.Header.Get("Location"))
+	}
+	if resp1.Header.Get(HeaderIdempotentReplayed) != "" || resp2.Header.Get(HeaderIdempotentReplayed) != "true" {
+		t.Errorf("Idempotent-Replayed = %q, %q, want \"\", \"true\"",
+			resp1.Header.Get(HeaderIdempotentReplayed), resp2.Header.Get(HeaderIdempotentReplayed))
+	}
+	if n := ts.soLanChay(); n != 1 {
+		t.Errorf("handler ran %d times, want 1", n)
+	}
+}
+
+func TestIdempotency_CacTruongHop(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []IdempotencyOption
+		chuanBi   func(t *testing.T, ts *idempotencyTestServer)
+		user      string
+		key       string
+		body      string
+		status    int
+		want      int
+		wantCode  string
+		wantSoLan int
+	}{
+		{
+			name:    "cùng key khác payload",
+			chuanBi: func(t *testing.T, ts *idempotencyTestServer) { ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 0) },
+			user:    "u1", key: "key-1", body: `{"ten":"bun"}`,
+			want: http.StatusConflict, wantCode: "IDEMPOTENCY_KEY_REUSED", wantSoLan: 1,
+		},
+		{
+			name: "request đầu đang xử lý",
+			chuanBi: func(t *testing.T, ts *idempotencyTestServer) {
+				req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"ten":"pho"}`))
+				_, _, _ = ts.store.Reserve(context.Background(), "u1:key-1", requestFingerprint(req, []byte(`{"ten":"pho"}`)), time.Minute)
+			},
+			user: "u1", key: "key-1", body: `{"ten":"pho"}`,
+			want: http.StatusConflict, wantCode: "IDEMPOTENCY_REQUEST_IN_PROGRESS", wantSoLan: 0,
+		},
+		{
+			name:    "khóa tách theo user",
+			chuanBi: func(t *testing.T, ts *idempotencyTestServer) { ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 0) },
+			user:    "u2", key: "key-1", body: `{"ten":"pho"}`,
+			want: http.StatusCreated, wantSoLan: 2,
+		},
+		{
+			name:    "lỗi server nhả khóa để gửi lại",
+			chuanBi: func(t *testing.T, ts *idempotencyTestServer) { ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 500) },
+			user:    "u1", key: "key-1", body: `{"ten":"pho"}`,
+			want: http.StatusCreated, wantSoLan: 2,
+		},
+		{
+			name:    "lỗi payload được lưu và phát lại",
+			chuanBi: func(t *testing.T, ts *idempotencyTestServer) { ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 400) },
+			user:    "u1", key: "key-1", body: `{"ten":"pho"}`,
+			want: http.StatusBadRequest, wantSoLan: 1,
+		},
+		{
+			name: "không có key", user: "u1", body: `{"ten":"pho"}`,
+			chuanBi: func(t *testing.T, ts *idempotencyTestServer) { ts.post(t, "u1", "", `{"ten":"pho"}`, 0) },
+			want:    http.StatusCreated, wantSoLan: 2,
+		},
+		{
+			name: "bắt buộc key nhưng thiếu", opts: []IdempotencyOption{IdempotencyKeyRequired()},
+			user: "u1", body: `{"ten":"pho"}`,
+			want: http.StatusBadRequest, wantCode: "IDEMPOTENCY_KEY_REQUIRED", wantSoLan: 0,
+		},
+		{
+			name: "key quá dài", user: "u1", key: strings.Repeat("k", maxIdempotencyKeyLength+1), body: `{}`,
+			want: http.StatusBadRequest, wantCode: "INVALID_IDEMPOTENCY_KEY", wantSoLan: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newIdempotencyTestServer(t, tt.opts...)
+			if tt.chuanBi != nil {
+				tt.chuanBi(t, ts)
+			}
+
+			resp, body := ts.post(t, tt.user, tt.key, tt.body, tt.status)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", resp.StatusCode, tt.want, body)
+			}
+			if tt.wantCode != "" && !strings.Contains(body, tt.wantCode) {
+				t.Errorf("body = %s, want code %s", body, tt.wantCode)
+			}
+			if n := ts.soLanChay(); n != tt.wantSoLan {
+				t.Errorf("handler ran %d times, want %d", n, tt.wantSoLan)
+			}
+		})
+	}
+}
+
+func TestIdempotency_KhoaHetHanKhongGhiDe(t *testing.T) {
+	ts := newIdempotencyTestServer(t)
+
+	// Request đầu xử lý quá lockTTL: khóa hết hạn và request gửi lại giữ khóa mới trong lúc đó
+	var resp2 *http.Response
+	var body2 string
+	ts.trongKhi = func() {
+		ts.trongKhi = nil
+		ts.store.hetHan("u1:key-1")
+		resp2, body2 = ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 0)
+	}
+	resp1, body1 := ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 0)
+	if resp1.StatusCode != http.StatusCreated || resp2.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, %d, want 201, 201", resp1.StatusCode, resp2.StatusCode)
+	}
+
+	// Response được lưu là của request giữ khóa hiện tại, request đầu không ghi đè
+	resp3, body3 := ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 0)
+	if resp3.Header.Get(HeaderIdempotentReplayed) != "true" || body3 != body2 {
+		t.Errorf("replayed body = %s, want %s (first request body %s)", body3, body2, body1)
+	}
+}
+
+func TestIdempotency_NhaKhoaCuKhongXoaKhoaMoi(t *testing.T) {
+	ts := newIdempotencyTestServer(t)
+
+	// Request đầu lỗi server sau khi khóa đã hết hạn và thuộc request khác đang xử lý
+	ts.trongKhi = func() {
+		ts.trongKhi = nil
+		ts.store.hetHan("u1:key-1")
+		_, _, _ = ts.store.Reserve(context.Background(), "u1:key-1", "fingerprint-khac", time.Minute)
+	}
+	ts.post(t, "u1", "key-1", `{"ten":"pho"}`, 500)
+
+	rec, _ := ts.store.Get(context.Background(), "u1:key-1")
+	if rec == nil || !rec.InProgress || rec.Fingerprint != "fingerprint-khac" {
+		t.Errorf("record = %+v, want the other request's in-progress record", rec)
+	}
+}
@@ -0,0 +1,278 @@
+package middleware
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Thuật toán ký JWT được hỗ trợ
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// minRSAKeyBits là độ dài tối thiểu của RSA key
+const minRSAKeyBits = 2048
+
+// JWK là public key theo định dạng JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 (OKP)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS là tập public key công bố tại /.well-known/jwks.json
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// jwtKey là một key ký/verify JWT
+type jwtKey struct {
+	kid       string
+	method    jwt.SigningMethod
+	signKey   any // nil nếu key chỉ dùng để verify
+	verifyKey any
+	jwk       *JWK // nil với HS256 (không công bố secret)
+}
+
+// jwtKeySet chứa key đang ký và mọi key còn được chấp nhận khi verify
+type jwtKeySet struct {
+	signing *jwtKey
+	verify  map[string]*jwtKey // theo kid
+	jwks    JWKS
+}
+
+// loadKeySet tạo key set từ config
+// HS256 dùng SecretKey, RS256/EdDSA đọc private key và các public key cũ từ file PEM
+func loadKeySet(cfg config.JWTConfig) (*jwtKeySet, error) {
+	alg := cfg.Algorithm
+	if alg == "" {
+		alg = AlgorithmHS256
+	}
+
+	if alg == AlgorithmHS256 {
+		if cfg.SecretKey == "" {
+			return nil, errors.New("jwt: JWT_SECRET_KEY is required for HS256")
+		}
+		secret := []byte(cfg.SecretKey)
+		key := &jwtKey{method: jwt.SigningMethodHS256, signKey: secret, verifyKey: secret}
+		return &jwtKeySet{
+			signing: key,
+			verify:  map[string]*jwtKey{"": key},
+			jwks:    JWKS{Keys: []JWK{}},
+		}, nil
+	}
+
+	if alg != AlgorithmRS256 && alg != AlgorithmEdDSA {
+		return nil, fmt.Errorf("jwt: unsupported algorithm %q (use HS256, RS256 or EdDSA)", alg)
+	}
+	if cfg.PrivateKeyFile == "" {
+		return nil, fmt.Errorf("jwt: JWT_PRIVATE_KEY_FILE is required for %s", alg)
+	}
+
+	signing, err := loadPrivateKey(cfg.PrivateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if signing.method.Alg() != alg {
+		return nil, fmt.Errorf("jwt: %s is a %s key but JWT_ALGORITHM is %s", cfg.PrivateKeyFile, signing.method.Alg(), alg)
+	}
+
+	ks := &jwtKeySet{
+		signing: signing,
+		verify:  map[string]*jwtKey{signing.kid: signing},
+		jwks:    JWKS{Keys: []JWK{*signing.jwk}},
+	}
+
+	// Public key cũ có thể khác thuật toán (ví dụ đang chuyển từ RS256 sang EdDSA)
+	for _, path := range cfg.PublicKeyFiles {
+		key, err := loadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := ks.verify[key.kid]; exists {
+			continue
+		}
+		ks.verify[key.kid] = key
+		ks.jwks.Keys = append(ks.jwks.Keys, *key.jwk)
+	}
+
+	return ks, nil
+}
+
+// keyFunc chọn key verify theo kid và chặn token ký bằng thuật toán khác với key (alg confusion)
+func (ks *jwtKeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := ks.verify[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New("unexpected signing method")
+	}
+	return key.verifyKey, nil
+}
+
+// sign ký claims bằng key hiện tại, gắn kid vào header với key bất đối xứng
+func (ks *jwtKeySet) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signing.method, claims)
+	if ks.signing.kid != "" {
+		token.Header["kid"] = ks.signing.kid
+	}
+	return token.SignedString(ks.signing.signKey)
+}
+
+// loadPrivateKey đọc private key PEM (PKCS#8 hoặc PKCS#1 với RSA)
+func loadPrivateKey(path string) (*jwtKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var priv any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwt: parse private key %s: %w", path, err)
+	}
+
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("jwt: unsupported private key type in %s", path)
+	}
+	key, err := newAsymmetricKey(signer.Public(), path)
+	if err != nil {
+		return nil, err
+	}
+	key.signKey = priv
+	return key, nil
+}
+
+// loadPublicKey đọc public key PEM (PKIX, PKCS#1 hoặc certificate)
+func loadPublicKey(path string) (*jwtKey, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pub any
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			pub = cert.PublicKey
+		}
+	default:
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwt: parse public key %s: %w", path, err)
+	}
+
+	return newAsymmetricKey(pub, path)
+}
+
+// newAsymmetricKey tạo key verify, JWK và kid (RFC 7638 thumbprint) từ public key
+func newAsymmetricKey(pub any, path string) (*jwtKey, error) {
+	b64 := base64.RawURLEncoding
+
+	var (
+		key        = &jwtKey{verifyKey: pub}
+		jwk        JWK
+		thumbprint any
+	)
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("jwt: RSA key in %s must be at least %d bits", path, minRSAKeyBits)
+		}
+		key.method = jwt.SigningMethodRS256
+		jwk = JWK{
+			Kty: "RSA",
+			Alg: AlgorithmRS256,
+			N:   b64.EncodeToString(k.N.Bytes()),
+			E:   b64.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+		// Thứ tự field theo RFC 7638: các member bắt buộc, sắp xếp theo tên
+		thumbprint = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{jwk.E, jwk.Kty, jwk.N}
+	case ed25519.PublicKey:
+		key.method = jwt.SigningMethodEdDSA
+		jwk = JWK{
+			Kty: "OKP",
+			Alg: AlgorithmEdDSA,
+			Crv: "Ed25519",
+			X:   b64.EncodeToString(k),
+		}
+		thumbprint = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{jwk.Crv, jwk.Kty, jwk.X}
+	default:
+		return nil, fmt.Errorf("jwt: unsupported key type %T in %s (use RSA or Ed25519)", pub, path)
+	}
+
+	raw, err := json.Marshal(thumbprint)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: compute key id: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+
+	key.kid = b64.EncodeToString(sum[:])
+	jwk.Kid = key.kid
+	jwk.Use = "sig"
+	key.jwk = &jwk
+	return key, nil
+}
+
+// readPEM đọc block PEM đầu tiên của file
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: %s is not a PEM file", path)
+	}
+	if strings.Contains(block.Type, "ENCRYPTED") {
+		return nil, fmt.Errorf("jwt: encrypted key %s is not supported", path)
+	}
+	return block, nil
+}
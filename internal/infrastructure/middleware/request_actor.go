@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/pkg/logger"
+)
+
+// RequestActor là người gửi request đã xác thực cùng thông tin của request
+// JWT middleware gắn vào request context để use case ghi audit log mà không phải truyền qua từng tham số
+type RequestActor struct {
+	UserID    string
+	Role      string
+	RequestID string
+	IP        string
+}
+
+// requestActorKey là key lưu RequestActor trong context.Context
+type requestActorKey struct{}
+
+// WithRequestActor gắn RequestActor vào context
+func WithRequestActor(ctx context.Context, actor RequestActor) context.Context {
+	return context.WithValue(ctx, requestActorKey{}, actor)
+}
+
+// RequestActorFromContext lấy RequestActor từ context
+// false nếu context không đến từ request đã xác thực (scheduler, background job,...)
+func RequestActorFromContext(ctx context.Context) (RequestActor, bool) {
+	actor, ok := ctx.Value(requestActorKey{}).(RequestActor)
+	return actor, ok
+}
+
+// attachRequestActor gắn người gửi request (từ claims đã xác thực) vào request context
+func attachRequestActor(c *gin.Context, claims *UserClaims) {
+	c.Request = c.Request.WithContext(WithRequestActor(c.Request.Context(), RequestActor{
+		UserID:    claims.UserID,
+		Role:      claims.Role,
+		RequestID: logger.GetRequestID(c),
+		IP:        c.ClientIP(),
+	}))
+}
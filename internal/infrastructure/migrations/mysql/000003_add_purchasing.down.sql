@@ -0,0 +1,6 @@
+-- Rollback: Drop purchasing tables in reverse order (respect foreign keys)
+DROP TABLE IF EXISTS don_dat_hang_item;
+DROP TABLE IF EXISTS don_dat_hang;
+DROP TABLE IF EXISTS nha_cung_cap;
+DROP TABLE IF EXISTS bien_dong_kho;
+DROP TABLE IF EXISTS nguyen_lieu;
@@ -0,0 +1,86 @@
+-- Migration: Thêm kho nguyên liệu, nhà cung cấp và đơn đặt hàng
+-- Description: Hỗ trợ quản lý nhập hàng (purchase orders) và tính giá vốn (COGS)
+
+-- ===========================================
+-- BẢNG NGUYEN_LIEU - Nguyên liệu trong kho
+-- ===========================================
+CREATE TABLE IF NOT EXISTS nguyen_lieu (
+    id VARCHAR(36) PRIMARY KEY,                     -- UUID
+    ten VARCHAR(100) NOT NULL UNIQUE,               -- Tên nguyên liệu
+    don_vi_tinh VARCHAR(20) NOT NULL,               -- Đơn vị tính (kg, lít, cái,...)
+    so_luong_ton DECIMAL(14,3) NOT NULL DEFAULT 0,  -- Tồn kho hiện tại
+    muc_toi_thieu DECIMAL(14,3) NOT NULL DEFAULT 0, -- Mức tồn tối thiểu
+    gia_von_binh_quan BIGINT NOT NULL DEFAULT 0,    -- Giá vốn bình quân (VND/đơn vị)
+    ngay_tao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ngay_cap_nhat DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ===========================================
+-- BẢNG BIEN_DONG_KHO - Lịch sử biến động tồn kho
+-- ===========================================
+CREATE TABLE IF NOT EXISTS bien_dong_kho (
+    id VARCHAR(36) PRIMARY KEY,                     -- UUID
+    nguyen_lieu_id VARCHAR(36) NOT NULL,            -- FK -> nguyen_lieu
+    loai ENUM('nhap_hang', 'tieu_hao', 'dieu_chinh') NOT NULL,
+    so_luong DECIMAL(14,3) NOT NULL,                -- Dương = tăng, âm = giảm
+    don_gia BIGINT NOT NULL DEFAULT 0,              -- Đơn giá tại thời điểm biến động
+    tham_chieu VARCHAR(36),                         -- Mã tham chiếu (VD: đơn đặt hàng)
+    ngay_tao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (nguyen_lieu_id) REFERENCES nguyen_lieu(id) ON DELETE CASCADE,
+    INDEX idx_nguyen_lieu_ngay (nguyen_lieu_id, ngay_tao),
+    INDEX idx_loai_ngay (loai, ngay_tao)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ===========================================
+-- BẢNG NHA_CUNG_CAP - Nhà cung cấp
+-- ===========================================
+CREATE TABLE IF NOT EXISTS nha_cung_cap (
+    id VARCHAR(36) PRIMARY KEY,                -- UUID
+    ten VARCHAR(150) NOT NULL,                 -- Tên nhà cung cấp
+    nguoi_lien_he VARCHAR(100),                -- Người liên hệ
+    so_dien_thoai VARCHAR(15) NOT NULL,        -- Số điện thoại
+    email VARCHAR(100),                        -- Email
+    dia_chi TEXT,                              -- Địa chỉ
+    so_ngay_giao INT NOT NULL DEFAULT 1,       -- Thời gian giao hàng dự kiến (ngày)
+    dang_hop_tac BOOLEAN NOT NULL DEFAULT TRUE,
+    ngay_tao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ngay_cap_nhat DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_dang_hop_tac (dang_hop_tac)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ===========================================
+-- BẢNG DON_DAT_HANG - Đơn đặt hàng (purchase order)
+-- ===========================================
+CREATE TABLE IF NOT EXISTS don_dat_hang (
+    id VARCHAR(36) PRIMARY KEY,                -- UUID
+    nha_cung_cap_id VARCHAR(36) NOT NULL,      -- FK -> nha_cung_cap
+    nguoi_tao_id VARCHAR(36),                  -- User tạo đơn
+    trang_thai ENUM('nhap', 'da_gui', 'nhan_mot_phan', 'da_nhan', 'da_huy') NOT NULL DEFAULT 'nhap',
+    ghi_chu TEXT,
+    ngay_tao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ngay_gui DATETIME NULL,
+    ngay_nhan DATETIME NULL,
+    ngay_cap_nhat DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (nha_cung_cap_id) REFERENCES nha_cung_cap(id),
+    INDEX idx_trang_thai (trang_thai),
+    INDEX idx_nha_cung_cap_id (nha_cung_cap_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ===========================================
+-- BẢNG DON_DAT_HANG_ITEM - Dòng nguyên liệu trong đơn đặt hàng
+-- ===========================================
+CREATE TABLE IF NOT EXISTS don_dat_hang_item (
+    don_dat_hang_id VARCHAR(36) NOT NULL,          -- FK -> don_dat_hang
+    nguyen_lieu_id VARCHAR(36) NOT NULL,           -- FK -> nguyen_lieu
+    ten_nguyen_lieu VARCHAR(100) NOT NULL,         -- Snapshot tên
+    so_luong_dat DECIMAL(14,3) NOT NULL,
+    so_luong_da_nhan DECIMAL(14,3) NOT NULL DEFAULT 0,
+    don_gia BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (don_dat_hang_id, nguyen_lieu_id),
+    FOREIGN KEY (don_dat_hang_id) REFERENCES don_dat_hang(id) ON DELETE CASCADE,
+    FOREIGN KEY (nguyen_lieu_id) REFERENCES nguyen_lieu(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- Rollback: Drop voucher tables in reverse order (respect foreign keys)
+DROP TABLE IF EXISTS su_dung_ma_giam_gia;
+DROP TABLE IF EXISTS ma_giam_gia;
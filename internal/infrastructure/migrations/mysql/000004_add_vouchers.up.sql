@@ -0,0 +1,43 @@
+-- Migration: Thêm mã giảm giá (voucher / coupon)
+-- Description: Mã dùng một lần hoặc nhiều lần, có hạn dùng, giới hạn lượt và giới hạn mỗi khách
+
+-- ===========================================
+-- BẢNG MA_GIAM_GIA - Mã giảm giá
+-- ===========================================
+CREATE TABLE IF NOT EXISTS ma_giam_gia (
+    id VARCHAR(36) PRIMARY KEY,                     -- UUID
+    ma VARCHAR(32) NOT NULL UNIQUE,                 -- Mã khách nhập (viết hoa)
+    chien_dich VARCHAR(100) NOT NULL,               -- Chiến dịch / đợt phát hành (dùng cho thống kê)
+    loai ENUM('phan_tram', 'so_tien') NOT NULL,     -- Giảm theo % hoặc số tiền
+    gia_tri BIGINT NOT NULL,                        -- % (1-100) hoặc số tiền (VND)
+    giam_toi_da BIGINT NOT NULL DEFAULT 0,          -- Trần số tiền giảm cho loại % (0 = không giới hạn)
+    don_toi_thieu BIGINT NOT NULL DEFAULT 0,        -- Giá trị đơn tối thiểu
+    so_lan_toi_da INT NOT NULL DEFAULT 1,           -- Tổng lượt dùng tối đa (0 = không giới hạn)
+    so_lan_moi_khach INT NOT NULL DEFAULT 0,        -- Lượt dùng tối đa mỗi khách (0 = không giới hạn)
+    so_lan_da_dung INT NOT NULL DEFAULT 0,          -- Lượt đã dùng (không tính lượt đã hoàn)
+    het_han DATETIME NULL,                          -- Hạn dùng (NULL = không hết hạn)
+    dang_hoat_dong BOOLEAN NOT NULL DEFAULT TRUE,
+    ngay_tao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ngay_cap_nhat DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+    INDEX idx_chien_dich (chien_dich)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ===========================================
+-- BẢNG SU_DUNG_MA_GIAM_GIA - Lịch sử dùng mã
+-- ===========================================
+CREATE TABLE IF NOT EXISTS su_dung_ma_giam_gia (
+    id VARCHAR(36) PRIMARY KEY,                     -- UUID
+    ma_giam_gia_id VARCHAR(36) NOT NULL,            -- FK -> ma_giam_gia
+    order_id VARCHAR(64) NOT NULL,                  -- Đơn hàng (MongoDB)
+    khach_hang_id VARCHAR(36),                      -- Khách dùng mã (NULL = khách vãng lai)
+    so_tien_giam BIGINT NOT NULL,                   -- Số tiền đã giảm
+    trang_thai ENUM('da_dung', 'da_hoan') NOT NULL DEFAULT 'da_dung',
+    ngay_dung DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ngay_hoan DATETIME NULL,                        -- Thời điểm hoàn lượt (đơn bị hủy)
+
+    FOREIGN KEY (ma_giam_gia_id) REFERENCES ma_giam_gia(id) ON DELETE CASCADE,
+    UNIQUE KEY uk_ma_order (ma_giam_gia_id, order_id),
+    INDEX idx_order_id (order_id),
+    INDEX idx_ma_khach_hang (ma_giam_gia_id, khach_hang_id, trang_thai)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Rollback: Drop user_mfa table
+DROP TABLE IF EXISTS user_mfa;
@@ -0,0 +1,17 @@
+-- Migration: Thêm xác thực hai lớp (TOTP) cho tài khoản
+-- Description: Mỗi user có tối đa một cấu hình TOTP; chưa kích hoạt cho tới khi xác nhận mã đầu tiên
+
+-- ===========================================
+-- BẢNG USER_MFA - Cấu hình TOTP của user
+-- ===========================================
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id VARCHAR(36) PRIMARY KEY,                -- FK -> users
+    secret VARCHAR(64) NOT NULL,                    -- TOTP secret (base32)
+    da_kich_hoat BOOLEAN NOT NULL DEFAULT FALSE,    -- FALSE = đang đăng ký, chưa xác nhận mã
+    ma_khoi_phuc JSON NOT NULL,                     -- SHA-256 của các mã khôi phục chưa dùng
+    buoc_cuoi BIGINT NOT NULL DEFAULT 0,            -- Bước TOTP đã dùng gần nhất (chống dùng lại mã)
+    ngay_tao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ngay_kich_hoat DATETIME NULL,
+
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,2 @@
+-- Rollback: Drop external_identities table
+DROP TABLE IF EXISTS external_identities;
@@ -0,0 +1,20 @@
+-- Migration: Thêm liên kết tài khoản bên ngoài (OpenID Connect: Google, ...)
+-- Description: Một user có thể liên kết nhiều provider, mỗi provider tối đa một tài khoản;
+-- một tài khoản bên ngoài chỉ liên kết với một user
+
+-- ===========================================
+-- BẢNG EXTERNAL_IDENTITIES - Tài khoản bên ngoài đã liên kết
+-- ===========================================
+CREATE TABLE IF NOT EXISTS external_identities (
+    id VARCHAR(36) PRIMARY KEY,                     -- UUID
+    user_id VARCHAR(36) NOT NULL,                   -- FK -> users
+    provider VARCHAR(32) NOT NULL,                  -- Tên provider (google)
+    subject VARCHAR(255) NOT NULL,                  -- Claim sub của provider (ổn định, không đổi theo email)
+    email VARCHAR(100) NOT NULL,                    -- Email của tài khoản bên ngoài lúc liên kết (chỉ để hiển thị)
+    ngay_lien_ket DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    lan_dang_nhap_cuoi DATETIME NULL,               -- Lần đăng nhập gần nhất bằng tài khoản này
+
+    UNIQUE KEY uk_provider_subject (provider, subject),
+    UNIQUE KEY uk_user_provider (user_id, provider),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,7 @@
+-- Rollback: Drop permission tables, gộp cashier/chef về staff
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+
+UPDATE users SET role = 'staff' WHERE role IN ('cashier', 'chef');
+ALTER TABLE users
+    MODIFY role ENUM('admin', 'manager', 'staff', 'customer') NOT NULL DEFAULT 'customer';
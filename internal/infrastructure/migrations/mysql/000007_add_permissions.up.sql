@@ -0,0 +1,79 @@
+-- Migration: Phân quyền theo permission thay cho phân cấp role cố định
+-- Description: Danh mục permission, bảng gán permission cho role (admin sửa được qua API)
+--              và thêm role cashier (thu ngân), chef (đầu bếp) tách khỏi staff
+
+ALTER TABLE users
+    MODIFY role ENUM('admin', 'manager', 'staff', 'cashier', 'chef', 'customer') NOT NULL DEFAULT 'customer';
+
+-- ===========================================
+-- BẢNG PERMISSIONS - Danh mục quyền
+-- ===========================================
+CREATE TABLE IF NOT EXISTS permissions (
+    ma VARCHAR(64) PRIMARY KEY,                -- Tên quyền dạng resource:action (vd: menu:write)
+    mo_ta VARCHAR(255) NOT NULL                -- Mô tả hiển thị cho admin
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- ===========================================
+-- BẢNG ROLE_PERMISSIONS - Quyền của từng role
+-- ===========================================
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role VARCHAR(32) NOT NULL,                 -- Role (khớp users.role)
+    permission VARCHAR(64) NOT NULL,           -- FK -> permissions
+
+    PRIMARY KEY (role, permission),
+    FOREIGN KEY (permission) REFERENCES permissions(ma) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+INSERT INTO permissions (ma, mo_ta) VALUES
+    ('menu:write', 'Thêm, xóa món, đổi giá, giảm giá, lịch bán, tùy chọn món'),
+    ('menu:availability:write', 'Đánh dấu món hết hàng / còn hàng'),
+    ('order:read', 'Xem đơn hàng, đơn đang chờ và phiếu bếp'),
+    ('order:cancel', 'Hủy đơn hàng'),
+    ('order:refund', 'Hoàn tiền đơn hàng'),
+    ('inventory:manage', 'Quản lý nguyên liệu và tồn kho'),
+    ('purchasing:manage', 'Quản lý nhà cung cấp và đơn đặt hàng'),
+    ('promotion:manage', 'Quản lý khuyến mãi và mã giảm giá'),
+    ('report:read', 'Xem báo cáo doanh thu'),
+    ('staff:salary:write', 'Cập nhật lương nhân viên'),
+    ('user:read', 'Xem danh sách và thông tin user'),
+    ('user:write', 'Tạo và cập nhật user (chỉ với role được gán)'),
+    ('user:deactivate', 'Vô hiệu hóa user'),
+    ('user:session:manage', 'Xem và thu hồi phiên đăng nhập của user khác'),
+    ('user:assign:admin', 'Tạo/quản lý user role admin'),
+    ('user:assign:manager', 'Tạo/quản lý user role manager'),
+    ('user:assign:staff', 'Tạo/quản lý user role staff'),
+    ('user:assign:cashier', 'Tạo/quản lý user role cashier'),
+    ('user:assign:chef', 'Tạo/quản lý user role chef'),
+    ('user:assign:customer', 'Tạo/quản lý user role customer'),
+    ('job:manage', 'Xem và chạy lại background job'),
+    ('scheduler:manage', 'Quản lý tác vụ định kỳ'),
+    ('permission:manage', 'Sửa quyền của các role');
+
+-- Admin có mọi quyền
+INSERT INTO role_permissions (role, permission)
+SELECT 'admin', ma FROM permissions;
+
+-- Quyền mặc định tương đương phân cấp role trước đây
+INSERT INTO role_permissions (role, permission) VALUES
+    ('manager', 'menu:write'),
+    ('manager', 'menu:availability:write'),
+    ('manager', 'order:read'),
+    ('manager', 'order:cancel'),
+    ('manager', 'order:refund'),
+    ('manager', 'inventory:manage'),
+    ('manager', 'purchasing:manage'),
+    ('manager', 'promotion:manage'),
+    ('manager', 'report:read'),
+    ('manager', 'user:read'),
+    ('manager', 'user:write'),
+    ('manager', 'user:assign:staff'),
+    ('manager', 'user:assign:cashier'),
+    ('manager', 'user:assign:chef'),
+    ('manager', 'user:assign:customer'),
+    ('staff', 'order:read'),
+    ('staff', 'order:cancel'),
+    ('cashier', 'order:read'),
+    ('cashier', 'order:cancel'),
+    ('cashier', 'order:refund'),
+    ('chef', 'order:read'),
+    ('chef', 'menu:availability:write');
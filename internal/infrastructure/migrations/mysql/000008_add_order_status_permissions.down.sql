@@ -0,0 +1,2 @@
+-- Rollback: Xóa quyền đổi trạng thái và phân công đơn hàng (role_permissions xóa theo ON DELETE CASCADE)
+DELETE FROM permissions WHERE ma IN ('order:status:write', 'order:status:write:assigned', 'order:assign');
@@ -0,0 +1,18 @@
+-- Migration: Quyền đổi trạng thái và phân công đơn hàng
+-- Description: Nhân viên có order:status:write đổi trạng thái mọi đơn; đầu bếp chỉ có
+--              order:status:write:assigned - đổi trạng thái đơn được gán cho mình (orders.dau_bep_id)
+
+INSERT INTO permissions (ma, mo_ta) VALUES
+    ('order:status:write', 'Đổi trạng thái mọi đơn hàng'),
+    ('order:status:write:assigned', 'Đổi trạng thái đơn hàng được gán cho mình (đầu bếp)'),
+    ('order:assign', 'Gán đầu bếp cho đơn hàng')
+ON DUPLICATE KEY UPDATE mo_ta = VALUES(mo_ta);
+
+INSERT IGNORE INTO role_permissions (role, permission) VALUES
+    ('admin', 'order:status:write'),
+    ('admin', 'order:status:write:assigned'),
+    ('admin', 'order:assign'),
+    ('manager', 'order:status:write'),
+    ('manager', 'order:assign'),
+    ('staff', 'order:status:write'),
+    ('chef', 'order:status:write:assigned');
@@ -0,0 +1,2 @@
+-- Rollback: Xóa quyền xem audit log (role_permissions xóa theo ON DELETE CASCADE)
+DELETE FROM permissions WHERE ma = 'audit:read';
@@ -0,0 +1,9 @@
+-- Migration: Quyền xem audit log
+-- Description: Audit log lưu trong MongoDB (collection audit_logs), chỉ admin được tra cứu
+
+INSERT INTO permissions (ma, mo_ta) VALUES
+    ('audit:read', 'Xem audit log các thao tác đặc quyền')
+ON DUPLICATE KEY UPDATE mo_ta = VALUES(mo_ta);
+
+INSERT IGNORE INTO role_permissions (role, permission) VALUES
+    ('admin', 'audit:read');
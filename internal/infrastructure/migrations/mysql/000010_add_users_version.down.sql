@@ -0,0 +1,2 @@
+-- Rollback: Remove version column
+ALTER TABLE users DROP COLUMN version;
@@ -0,0 +1,7 @@
+-- Migration: Add version column to users table
+-- Description: Thêm cột version cho optimistic locking - UPDATE chỉ thành công khi version khớp
+
+-- User đã có bắt đầu từ version 1 (0 = chưa lưu ở tầng ứng dụng)
+ALTER TABLE users
+ADD COLUMN version BIGINT NOT NULL DEFAULT 1
+AFTER ngay_cap_nhat;
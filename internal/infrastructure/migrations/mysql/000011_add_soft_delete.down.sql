@@ -0,0 +1,8 @@
+-- Rollback: Xóa quyền khôi phục và cột ngay_xoa (bản ghi đã xóa mềm bị xóa vĩnh viễn)
+DELETE FROM permissions WHERE ma = 'deleted:restore';
+
+DELETE FROM khach_hang WHERE ngay_xoa IS NOT NULL;
+ALTER TABLE khach_hang DROP INDEX idx_ngay_xoa, DROP COLUMN ngay_xoa;
+
+DELETE FROM nhan_vien WHERE ngay_xoa IS NOT NULL;
+ALTER TABLE nhan_vien DROP INDEX idx_ngay_xoa, DROP COLUMN ngay_xoa;
@@ -0,0 +1,19 @@
+-- Migration: Xóa mềm khách hàng và nhân viên
+-- Description: Thêm cột ngay_xoa (NULL = chưa xóa); bản ghi đã xóa mềm bị loại khỏi truy vấn mặc định
+--              và được xóa vĩnh viễn sau thời gian lưu giữ (SCHEDULER_SOFT_DELETE_RETENTION)
+
+ALTER TABLE khach_hang
+ADD COLUMN ngay_xoa DATETIME NULL DEFAULT NULL AFTER ngay_cap_nhat,
+ADD INDEX idx_ngay_xoa (ngay_xoa);
+
+ALTER TABLE nhan_vien
+ADD COLUMN ngay_xoa DATETIME NULL DEFAULT NULL AFTER ngay_cap_nhat,
+ADD INDEX idx_ngay_xoa (ngay_xoa);
+
+-- Quyền xem và khôi phục bản ghi đã xóa mềm (món ăn, khách hàng, nhân viên)
+INSERT INTO permissions (ma, mo_ta) VALUES
+    ('deleted:restore', 'Xem và khôi phục món ăn, khách hàng, nhân viên đã xóa')
+ON DUPLICATE KEY UPDATE mo_ta = VALUES(mo_ta);
+
+INSERT IGNORE INTO role_permissions (role, permission) VALUES
+    ('admin', 'deleted:restore');
@@ -0,0 +1,2 @@
+-- Rollback: Drop outbox table
+DROP TABLE IF EXISTS outbox;
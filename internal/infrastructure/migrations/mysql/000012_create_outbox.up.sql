@@ -0,0 +1,20 @@
+-- Migration: Thêm outbox sự kiện cho dữ liệu MySQL
+-- Description: Domain event (user.deactivated...) ghi cùng transaction với thay đổi trạng thái,
+-- relay đọc bảng này và phát tới dispatcher nội bộ (at-least-once)
+
+-- ===========================================
+-- BẢNG OUTBOX - Sự kiện chờ phát
+-- ===========================================
+CREATE TABLE IF NOT EXISTS outbox (
+    id VARCHAR(36) PRIMARY KEY,                     -- ID sự kiện (khóa idempotency của consumer)
+    loai VARCHAR(100) NOT NULL,                     -- Loại sự kiện (vd: user.deactivated)
+    doi_tuong_id VARCHAR(100) NOT NULL,             -- ID aggregate phát sinh sự kiện
+    du_lieu JSON NOT NULL,                          -- Payload
+    so_lan_thu INT NOT NULL DEFAULT 0,              -- Số lần phát thất bại
+    loi_cuoi TEXT NULL,                             -- Lỗi lần phát thất bại gần nhất
+    tao_luc DATETIME(3) NOT NULL,
+    thu_lai_luc DATETIME(3) NOT NULL,               -- Relay chỉ nhận sự kiện khi tới thời điểm này
+    da_phat_luc DATETIME(3) NULL,                   -- NULL = chưa phát
+
+    INDEX idx_den_han (da_phat_luc, thu_lai_luc)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,4 @@
+-- Rollback: Xóa quyền quản lý webhook và bảng webhooks
+DELETE FROM permissions WHERE ma = 'webhook:manage';
+
+DROP TABLE IF EXISTS webhooks;
@@ -0,0 +1,27 @@
+-- Migration: Webhook cho tích hợp bên thứ ba
+-- Description: Admin đăng ký endpoint nhận sự kiện (đổi trạng thái đơn, món hết hàng...) kèm bộ lọc sự kiện
+--              và secret ký HMAC-SHA256; nhật ký gửi lưu trong MongoDB (collection webhook_deliveries)
+
+-- ===========================================
+-- BẢNG WEBHOOKS - Endpoint đã đăng ký
+-- ===========================================
+CREATE TABLE IF NOT EXISTS webhooks (
+    id VARCHAR(36) PRIMARY KEY,
+    ten VARCHAR(100) NOT NULL,                      -- Tên hiển thị
+    url VARCHAR(2048) NOT NULL,                     -- Endpoint nhận sự kiện
+    su_kien JSON NOT NULL,                          -- Bộ lọc: danh sách loại sự kiện
+    secret VARCHAR(255) NOT NULL,                   -- Secret ký HMAC-SHA256 (lưu dạng gốc để ký)
+    dang_hoat_dong BOOLEAN NOT NULL DEFAULT TRUE,
+    ngay_tao DATETIME NOT NULL,
+    ngay_cap_nhat DATETIME NOT NULL,
+
+    INDEX idx_dang_hoat_dong (dang_hoat_dong)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Quyền quản lý webhook, xem nhật ký gửi và gửi lại
+INSERT INTO permissions (ma, mo_ta) VALUES
+    ('webhook:manage', 'Quản lý webhook, xem nhật ký gửi và gửi lại sự kiện')
+ON DUPLICATE KEY UPDATE mo_ta = VALUES(mo_ta);
+
+INSERT IGNORE INTO role_permissions (role, permission) VALUES
+    ('admin', 'webhook:manage');
@@ -0,0 +1,2 @@
+-- Rollback: Bỏ ràng buộc số điện thoại nhân viên duy nhất
+ALTER TABLE nhan_vien DROP INDEX uq_nhan_vien_so_dien_thoai, DROP COLUMN so_dien_thoai_hoat_dong;
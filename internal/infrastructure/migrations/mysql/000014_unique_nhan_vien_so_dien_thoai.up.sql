@@ -0,0 +1,8 @@
+-- Migration: Số điện thoại nhân viên là duy nhất
+-- Description: Chỉ tính nhân viên chưa xóa mềm (cột sinh ra NULL khi đã xóa, UNIQUE cho phép nhiều NULL)
+--              để tuyển lại người cũ với cùng số điện thoại. Lỗi nếu dữ liệu hiện có đã trùng số
+
+ALTER TABLE nhan_vien
+ADD COLUMN so_dien_thoai_hoat_dong VARCHAR(15)
+    GENERATED ALWAYS AS (IF(ngay_xoa IS NULL, so_dien_thoai, NULL)) VIRTUAL,
+ADD UNIQUE INDEX uq_nhan_vien_so_dien_thoai (so_dien_thoai_hoat_dong);
@@ -0,0 +1,2 @@
+-- Rollback: Drop processed_events table
+DROP TABLE IF EXISTS processed_events;
@@ -0,0 +1,15 @@
+-- Migration: Khóa idempotency của consumer domain event
+-- Description: Dispatcher ghi (consumer, event_id) cùng transaction MySQL với tác dụng phụ của consumer
+--              (vd: cộng điểm tích lũy), nên sự kiện phát lại không được áp dụng hai lần
+
+-- ===========================================
+-- BẢNG PROCESSED_EVENTS - Sự kiện consumer đã xử lý
+-- ===========================================
+CREATE TABLE IF NOT EXISTS processed_events (
+    consumer VARCHAR(100) NOT NULL,                 -- Tên consumer đăng ký với dispatcher
+    event_id VARCHAR(36) NOT NULL,                  -- ID sự kiện trong outbox
+    xu_ly_luc DATETIME(3) NOT NULL,
+
+    UNIQUE KEY uq_processed_events (consumer, event_id),
+    INDEX idx_xu_ly_luc (xu_ly_luc)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
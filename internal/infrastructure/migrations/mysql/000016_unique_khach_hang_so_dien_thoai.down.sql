@@ -0,0 +1,5 @@
+-- Rollback: Trả lại UNIQUE trên mọi khách hàng (lỗi nếu khách đã xóa và khách mới trùng số)
+ALTER TABLE khach_hang
+DROP INDEX uq_khach_hang_so_dien_thoai,
+DROP COLUMN so_dien_thoai_hoat_dong,
+ADD UNIQUE INDEX so_dien_thoai (so_dien_thoai);
@@ -0,0 +1,9 @@
+-- Migration: Số điện thoại khách hàng chỉ duy nhất trong các khách hàng chưa xóa mềm
+-- Description: UNIQUE cũ (000001) tính cả khách hàng đã xóa mềm nên số của khách đã xóa không dùng lại được.
+--              Giống nhan_vien (000014): cột sinh ra NULL khi đã xóa (UNIQUE cho phép nhiều NULL)
+
+ALTER TABLE khach_hang
+DROP INDEX so_dien_thoai,
+ADD COLUMN so_dien_thoai_hoat_dong VARCHAR(15)
+    GENERATED ALWAYS AS (IF(ngay_xoa IS NULL, so_dien_thoai, NULL)) VIRTUAL,
+ADD UNIQUE INDEX uq_khach_hang_so_dien_thoai (so_dien_thoai_hoat_dong);
@@ -117,6 +117,10 @@ func (r *CachedMonAnRepository) FindAll(ctx context.Context) ([]*entity.MonAn, e
 }
 
 // FindByConHang lấy các món theo trạng thái còn hàng với caching
+// Lưu ý: danh sách cache chỉ phụ thuộc ConHang, KHÔNG lọc theo lịch phục vụ (LichBan).
+// Việc lọc theo giờ hiện tại do UseCase thực hiện sau khi đọc cache, nên kết quả
+// không bị stale khi chuyển khung giờ (VD: hết giờ bữa sáng lúc 10:00).
+// Đừng cache danh sách đã lọc theo thời gian ở đây.
 func (r *CachedMonAnRepository) FindByConHang(ctx context.Context, conHang bool) ([]*entity.MonAn, error) {
 	cacheKey := fmt.Sprintf(keyMonAnConHang, conHang)
 
@@ -157,7 +161,7 @@ func (r *CachedMonAnRepository) Save(ctx context.Context, mon *entity.MonAn) err
 	return nil
 }
 
-// Delete xóa món ăn và invalidate cache
+// Delete xóa mềm món ăn và invalidate cache
 func (r *CachedMonAnRepository) Delete(ctx context.Context, id string) error {
 	// Delete from DB first
 	if err := r.repo.Delete(ctx, id); err != nil {
@@ -170,6 +174,29 @@ func (r *CachedMonAnRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// FindDeleted lấy các món đã xóa mềm (không cache - chỉ admin dùng)
+func (r *CachedMonAnRepository) FindDeleted(ctx context.Context) ([]*entity.MonAn, error) {
+	return r.repo.FindDeleted(ctx)
+}
+
+// Restore khôi phục món đã xóa mềm và invalidate cache
+func (r *CachedMonAnRepository) Restore(ctx context.Context, id string) (bool, error) {
+	ok, err := r.repo.Restore(ctx, id)
+	if err != nil || !ok {
+		return ok, err
+	}
+
+	r.invalidateCache(ctx, id)
+
+	return true, nil
+}
+
+// PurgeDeleted xóa vĩnh viễn các món đã xóa mềm
+// Món đã xóa mềm không nằm trong cache (bị invalidate khi xóa) nên không cần invalidate
+func (r *CachedMonAnRepository) PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error) {
+	return r.repo.PurgeDeleted(ctx, truoc)
+}
+
 // Count đếm tổng số món ăn với caching
 func (r *CachedMonAnRepository) Count(ctx context.Context) (int64, error) {
 	data, err := r.cache.Get(ctx, keyMonAnCount)
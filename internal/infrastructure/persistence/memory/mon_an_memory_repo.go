@@ -5,7 +5,9 @@ package memory
 import (
 	"context"
 	"errors"
+	"sort"
 	"sync"
+	"time"
 
 	"restaurant_project/internal/domain/entity"
 	"restaurant_project/internal/domain/repository"
@@ -44,7 +46,7 @@ func (r *MonAnMemoryRepo) FindByID(ctx context.Context, id string) (*entity.MonA
 	defer r.mutex.RUnlock()
 
 	mon, exists := r.data[id]
-	if !exists {
+	if !exists || mon.DaXoa() {
 		return nil, nil // Không tìm thấy, trả về nil (không phải error)
 	}
 
@@ -59,7 +61,9 @@ func (r *MonAnMemoryRepo) FindAll(ctx context.Context) ([]*entity.MonAn, error)
 
 	result := make([]*entity.MonAn, 0, len(r.data))
 	for _, mon := range r.data {
-		result = append(result, r.copyMonAn(mon))
+		if !mon.DaXoa() {
+			result = append(result, r.copyMonAn(mon))
+		}
 	}
 
 	return result, nil
@@ -72,7 +76,7 @@ func (r *MonAnMemoryRepo) FindByConHang(ctx context.Context, conHang bool) ([]*e
 
 	result := make([]*entity.MonAn, 0)
 	for _, mon := range r.data {
-		if mon.ConHang == conHang {
+		if mon.ConHang == conHang && !mon.DaXoa() {
 			result = append(result, r.copyMonAn(mon))
 		}
 	}
@@ -93,31 +97,100 @@ func (r *MonAnMemoryRepo) Save(ctx context.Context, mon *entity.MonAn) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	// Optimistic locking: chỉ ghi đè khi version khớp với bản đang lưu
+	var versionHienTai int64
+	if cu, exists := r.data[mon.ID]; exists {
+		versionHienTai = cu.Version
+	}
+	if mon.Version != versionHienTai {
+		return repository.ErrConcurrentModification
+	}
+	mon.Version++
+
 	// Lưu copy để tránh modification từ bên ngoài
 	r.data[mon.ID] = r.copyMonAn(mon)
 
 	return nil
 }
 
-// Delete xóa món ăn theo ID
+// Delete xóa mềm món ăn theo ID
 func (r *MonAnMemoryRepo) Delete(ctx context.Context, id string) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	if _, exists := r.data[id]; !exists {
+	mon, exists := r.data[id]
+	if !exists || mon.DaXoa() {
 		return errors.New("không tìm thấy món ăn để xóa")
 	}
 
-	delete(r.data, id)
+	now := time.Now()
+	mon.NgayXoa = &now
+	mon.NgayCapNhat = now
+	mon.Version++
 	return nil
 }
 
-// Count đếm tổng số món ăn
+// FindDeleted lấy các món đã xóa mềm, mới xóa trước
+func (r *MonAnMemoryRepo) FindDeleted(ctx context.Context) ([]*entity.MonAn, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]*entity.MonAn, 0)
+	for _, mon := range r.data {
+		if mon.DaXoa() {
+			result = append(result, r.copyMonAn(mon))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NgayXoa.After(*result[j].NgayXoa)
+	})
+
+	return result, nil
+}
+
+// Restore khôi phục món đã xóa mềm
+func (r *MonAnMemoryRepo) Restore(ctx context.Context, id string) (bool, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	mon, exists := r.data[id]
+	if !exists || !mon.DaXoa() {
+		return false, nil
+	}
+
+	mon.NgayXoa = nil
+	mon.NgayCapNhat = time.Now()
+	mon.Version++
+	return true, nil
+}
+
+// PurgeDeleted xóa vĩnh viễn các món đã xóa mềm trước thời điểm truoc
+func (r *MonAnMemoryRepo) PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var n int64
+	for id, mon := range r.data {
+		if mon.DaXoa() && mon.NgayXoa.Before(truoc) {
+			delete(r.data, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// Count đếm tổng số món ăn (không gồm món đã xóa mềm)
 func (r *MonAnMemoryRepo) Count(ctx context.Context) (int64, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
-	return int64(len(r.data)), nil
+	var n int64
+	for _, mon := range r.data {
+		if !mon.DaXoa() {
+			n++
+		}
+	}
+	return n, nil
 }
 
 // ============================================
@@ -135,9 +208,26 @@ func (r *MonAnMemoryRepo) copyMonAn(mon *entity.MonAn) *entity.MonAn {
 		MoTa:        mon.MoTa,
 		ConHang:     mon.ConHang,
 		GiamGia:     mon.GiamGia,
+		LichBan:     append([]entity.KhungGioBan(nil), mon.LichBan...),
+		NhomTuyChon: copyNhomTuyChon(mon.NhomTuyChon),
 		NgayTao:     mon.NgayTao,
 		NgayCapNhat: mon.NgayCapNhat,
+		Version:     mon.Version,
+		NgayXoa:     mon.NgayXoa,
+	}
+}
+
+// copyNhomTuyChon deep copy các nhóm tùy chọn (slice lựa chọn lồng bên trong)
+func copyNhomTuyChon(list []entity.NhomTuyChon) []entity.NhomTuyChon {
+	if list == nil {
+		return nil
+	}
+	result := make([]entity.NhomTuyChon, len(list))
+	for i, n := range list {
+		result[i] = n
+		result[i].LuaChon = append([]entity.LuaChonTuyChon(nil), n.LuaChon...)
 	}
+	return result
 }
 
 // ============================================
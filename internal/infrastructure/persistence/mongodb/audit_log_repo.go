@@ -0,0 +1,137 @@
+// Package mongodb chứa các MongoDB repository implementations
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// auditLogDocument là struct mapping với MongoDB document
+type auditLogDocument struct {
+	ID         string         `bson:"_id"`
+	ActorID    string         `bson:"actor_id"`
+	ActorRole  string         `bson:"actor_role,omitempty"`
+	HanhDong   string         `bson:"hanh_dong"`
+	DoiTuong   string         `bson:"doi_tuong"`
+	DoiTuongID string         `bson:"doi_tuong_id"`
+	TruocKhi   map[string]any `bson:"truoc_khi,omitempty"`
+	SauKhi     map[string]any `bson:"sau_khi,omitempty"`
+	RequestID  string         `bson:"request_id,omitempty"`
+	IP         string         `bson:"ip,omitempty"`
+	ThoiGian   time.Time      `bson:"thoi_gian"`
+}
+
+// toEntity chuyển từ document sang entity
+func (d *auditLogDocument) toEntity() *entity.AuditLog {
+	return &entity.AuditLog{
+		ID:         d.ID,
+		ActorID:    d.ActorID,
+		ActorRole:  d.ActorRole,
+		HanhDong:   d.HanhDong,
+		DoiTuong:   d.DoiTuong,
+		DoiTuongID: d.DoiTuongID,
+		TruocKhi:   d.TruocKhi,
+		SauKhi:     d.SauKhi,
+		RequestID:  d.RequestID,
+		IP:         d.IP,
+		ThoiGian:   d.ThoiGian,
+	}
+}
+
+// toAuditLogDocument chuyển từ entity sang document
+func toAuditLogDocument(l *entity.AuditLog) *auditLogDocument {
+	return &auditLogDocument{
+		ID:         l.ID,
+		ActorID:    l.ActorID,
+		ActorRole:  l.ActorRole,
+		HanhDong:   l.HanhDong,
+		DoiTuong:   l.DoiTuong,
+		DoiTuongID: l.DoiTuongID,
+		TruocKhi:   l.TruocKhi,
+		SauKhi:     l.SauKhi,
+		RequestID:  l.RequestID,
+		IP:         l.IP,
+		ThoiGian:   l.ThoiGian,
+	}
+}
+
+// AuditLogMongoRepo là implementation của IAuditLogRepository sử dụng MongoDB
+type AuditLogMongoRepo struct {
+	collection *mongo.Collection
+}
+
+// NewAuditLogMongoRepo tạo mới AuditLogMongoRepo
+func NewAuditLogMongoRepo(db *mongo.Database) *AuditLogMongoRepo {
+	return &AuditLogMongoRepo{
+		collection: db.Collection("audit_logs"),
+	}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IAuditLogRepository = (*AuditLogMongoRepo)(nil)
+
+// Append ghi thêm một bản ghi (InsertOne: trùng _id thì lỗi, không ghi đè)
+func (r *AuditLogMongoRepo) Append(ctx context.Context, log *entity.AuditLog) error {
+	_, err := r.collection.InsertOne(ctx, toAuditLogDocument(log))
+	return err
+}
+
+// Find lấy audit log theo điều kiện lọc có phân trang, mới nhất trước
+func (r *AuditLogMongoRepo) Find(ctx context.Context, filter repository.AuditLogFilter, offset, limit int) ([]*entity.AuditLog, int64, error) {
+	query := bson.M{}
+	if filter.ActorID != "" {
+		query["actor_id"] = filter.ActorID
+	}
+	if filter.HanhDong != "" {
+		query["hanh_dong"] = filter.HanhDong
+	}
+	if filter.DoiTuong != "" {
+		query["doi_tuong"] = filter.DoiTuong
+	}
+	if filter.DoiTuongID != "" {
+		query["doi_tuong_id"] = filter.DoiTuongID
+	}
+	if filter.Tu != nil || filter.Den != nil {
+		khoang := bson.M{}
+		if filter.Tu != nil {
+			khoang["$gte"] = *filter.Tu
+		}
+		if filter.Den != nil {
+			khoang["$lte"] = *filter.Den
+		}
+		query["thoi_gian"] = khoang
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "thoi_gian", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.AuditLog
+	for cursor.Next(ctx) {
+		var doc auditLogDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, total, cursor.Err()
+}
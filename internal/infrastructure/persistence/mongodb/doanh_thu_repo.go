@@ -0,0 +1,107 @@
+// Package mongodb chứa các MongoDB repository implementations
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// doanhThuNgayDocument là struct mapping với MongoDB document
+// _id là ngày (YYYY-MM-DD) nên mỗi ngày chỉ có một snapshot
+type doanhThuNgayDocument struct {
+	Ngay           string    `bson:"_id"`
+	SoDon          int       `bson:"so_don"`
+	SoDonHoanThanh int       `bson:"so_don_hoan_thanh"`
+	SoDonHuy       int       `bson:"so_don_huy"`
+	TongTien       int64     `bson:"tong_tien"`
+	GiamGia        int64     `bson:"giam_gia"`
+	DoanhThu       int64     `bson:"doanh_thu"`
+	ChupLuc        time.Time `bson:"chup_luc"`
+}
+
+// toEntity chuyển từ document sang entity
+func (d *doanhThuNgayDocument) toEntity() *entity.DoanhThuNgay {
+	return &entity.DoanhThuNgay{
+		Ngay:           d.Ngay,
+		SoDon:          d.SoDon,
+		SoDonHoanThanh: d.SoDonHoanThanh,
+		SoDonHuy:       d.SoDonHuy,
+		TongTien:       d.TongTien,
+		GiamGia:        d.GiamGia,
+		DoanhThu:       d.DoanhThu,
+		ChupLuc:        d.ChupLuc,
+	}
+}
+
+// toDoanhThuNgayDocument chuyển từ entity sang document
+func toDoanhThuNgayDocument(dt *entity.DoanhThuNgay) *doanhThuNgayDocument {
+	return &doanhThuNgayDocument{
+		Ngay:           dt.Ngay,
+		SoDon:          dt.SoDon,
+		SoDonHoanThanh: dt.SoDonHoanThanh,
+		SoDonHuy:       dt.SoDonHuy,
+		TongTien:       dt.TongTien,
+		GiamGia:        dt.GiamGia,
+		DoanhThu:       dt.DoanhThu,
+		ChupLuc:        dt.ChupLuc,
+	}
+}
+
+// DoanhThuMongoRepo là implementation của IDoanhThuRepository sử dụng MongoDB
+type DoanhThuMongoRepo struct {
+	collection *mongo.Collection
+}
+
+// NewDoanhThuMongoRepo tạo mới DoanhThuMongoRepo
+func NewDoanhThuMongoRepo(db *mongo.Database) *DoanhThuMongoRepo {
+	return &DoanhThuMongoRepo{
+		collection: db.Collection("doanh_thu_ngay"),
+	}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IDoanhThuRepository = (*DoanhThuMongoRepo)(nil)
+
+// Save lưu snapshot, ghi đè snapshot cũ cùng ngày
+func (r *DoanhThuMongoRepo) Save(ctx context.Context, dt *entity.DoanhThuNgay) error {
+	doc := toDoanhThuNgayDocument(dt)
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": dt.Ngay}, doc, opts)
+
+	return err
+}
+
+// FindByKhoang lấy snapshot trong khoảng ngày (so sánh chuỗi YYYY-MM-DD)
+func (r *DoanhThuMongoRepo) FindByKhoang(ctx context.Context, tuNgay, denNgay string) ([]*entity.DoanhThuNgay, error) {
+	filter := bson.M{
+		"_id": bson.M{
+			"$gte": tuNgay,
+			"$lte": denNgay,
+		},
+	}
+	opts := options.Find().SetSort(bson.M{"_id": 1})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.DoanhThuNgay
+	for cursor.Next(ctx) {
+		var doc doanhThuNgayDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, cursor.Err()
+}
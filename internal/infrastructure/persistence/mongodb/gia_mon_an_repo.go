@@ -0,0 +1,248 @@
+// Package mongodb chứa các MongoDB repository implementations
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ============================================================
+// Lịch sử giá
+// ============================================================
+
+// lichSuGiaDocument là struct mapping với MongoDB document
+type lichSuGiaDocument struct {
+	ID           string    `bson:"_id"`
+	MonAnID      string    `bson:"mon_an_id"`
+	GiaCu        int64     `bson:"gia_cu"`
+	GiaMoi       int64     `bson:"gia_moi"`
+	HieuLucTu    time.Time `bson:"hieu_luc_tu"`
+	NguoiThayDoi string    `bson:"nguoi_thay_doi,omitempty"`
+	LichDoiGiaID string    `bson:"lich_doi_gia_id,omitempty"`
+}
+
+// toEntity chuyển từ document sang entity
+func (d *lichSuGiaDocument) toEntity() *entity.LichSuGia {
+	return &entity.LichSuGia{
+		ID:           d.ID,
+		MonAnID:      d.MonAnID,
+		GiaCu:        d.GiaCu,
+		GiaMoi:       d.GiaMoi,
+		HieuLucTu:    d.HieuLucTu,
+		NguoiThayDoi: d.NguoiThayDoi,
+		LichDoiGiaID: d.LichDoiGiaID,
+	}
+}
+
+// toLichSuGiaDocument chuyển từ entity sang document
+func toLichSuGiaDocument(l *entity.LichSuGia) *lichSuGiaDocument {
+	return &lichSuGiaDocument{
+		ID:           l.ID,
+		MonAnID:      l.MonAnID,
+		GiaCu:        l.GiaCu,
+		GiaMoi:       l.GiaMoi,
+		HieuLucTu:    l.HieuLucTu,
+		NguoiThayDoi: l.NguoiThayDoi,
+		LichDoiGiaID: l.LichDoiGiaID,
+	}
+}
+
+// LichSuGiaMongoRepo là implementation của ILichSuGiaRepository sử dụng MongoDB
+type LichSuGiaMongoRepo struct {
+	collection *mongo.Collection
+}
+
+// NewLichSuGiaMongoRepo tạo mới LichSuGiaMongoRepo
+func NewLichSuGiaMongoRepo(db *mongo.Database) *LichSuGiaMongoRepo {
+	return &LichSuGiaMongoRepo{
+		collection: db.Collection("lich_su_gia"),
+	}
+}
+
+// Verify interface implementation at compile time
+var _ repository.ILichSuGiaRepository = (*LichSuGiaMongoRepo)(nil)
+
+// Append ghi thêm một lần đổi giá
+func (r *LichSuGiaMongoRepo) Append(ctx context.Context, lichSu *entity.LichSuGia) error {
+	_, err := r.collection.InsertOne(ctx, toLichSuGiaDocument(lichSu))
+	return err
+}
+
+// FindByMonAnID lấy lịch sử giá của món theo thứ tự thời gian
+func (r *LichSuGiaMongoRepo) FindByMonAnID(ctx context.Context, monAnID string, tu, den *time.Time) ([]*entity.LichSuGia, error) {
+	filter := bson.M{"mon_an_id": monAnID}
+	if tu != nil || den != nil {
+		khoang := bson.M{}
+		if tu != nil {
+			khoang["$gte"] = *tu
+		}
+		if den != nil {
+			khoang["$lte"] = *den
+		}
+		filter["hieu_luc_tu"] = khoang
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "hieu_luc_tu", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.LichSuGia
+	for cursor.Next(ctx) {
+		var doc lichSuGiaDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, cursor.Err()
+}
+
+// ============================================================
+// Lịch đổi giá
+// ============================================================
+
+// lichDoiGiaDocument là struct mapping với MongoDB document
+type lichDoiGiaDocument struct {
+	ID          string     `bson:"_id"`
+	MonAnID     string     `bson:"mon_an_id"`
+	GiaMoi      int64      `bson:"gia_moi"`
+	ApDungLuc   time.Time  `bson:"ap_dung_luc"`
+	TrangThai   string     `bson:"trang_thai"`
+	NguoiTao    string     `bson:"nguoi_tao,omitempty"`
+	NgayTao     time.Time  `bson:"ngay_tao"`
+	DaApDungLuc *time.Time `bson:"da_ap_dung_luc,omitempty"`
+}
+
+// toEntity chuyển từ document sang entity
+func (d *lichDoiGiaDocument) toEntity() *entity.LichDoiGia {
+	return &entity.LichDoiGia{
+		ID:          d.ID,
+		MonAnID:     d.MonAnID,
+		GiaMoi:      d.GiaMoi,
+		ApDungLuc:   d.ApDungLuc,
+		TrangThai:   entity.TrangThaiLichDoiGia(d.TrangThai),
+		NguoiTao:    d.NguoiTao,
+		NgayTao:     d.NgayTao,
+		DaApDungLuc: d.DaApDungLuc,
+	}
+}
+
+// toLichDoiGiaDocument chuyển từ entity sang document
+func toLichDoiGiaDocument(l *entity.LichDoiGia) *lichDoiGiaDocument {
+	return &lichDoiGiaDocument{
+		ID:          l.ID,
+		MonAnID:     l.MonAnID,
+		GiaMoi:      l.GiaMoi,
+		ApDungLuc:   l.ApDungLuc,
+		TrangThai:   string(l.TrangThai),
+		NguoiTao:    l.NguoiTao,
+		NgayTao:     l.NgayTao,
+		DaApDungLuc: l.DaApDungLuc,
+	}
+}
+
+// LichDoiGiaMongoRepo là implementation của ILichDoiGiaRepository sử dụng MongoDB
+type LichDoiGiaMongoRepo struct {
+	collection *mongo.Collection
+}
+
+// NewLichDoiGiaMongoRepo tạo mới LichDoiGiaMongoRepo
+func NewLichDoiGiaMongoRepo(db *mongo.Database) *LichDoiGiaMongoRepo {
+	return &LichDoiGiaMongoRepo{
+		collection: db.Collection("lich_doi_gia"),
+	}
+}
+
+// Verify interface implementation at compile time
+var _ repository.ILichDoiGiaRepository = (*LichDoiGiaMongoRepo)(nil)
+
+// FindByID tìm lịch đổi giá theo ID
+func (r *LichDoiGiaMongoRepo) FindByID(ctx context.Context, id string) (*entity.LichDoiGia, error) {
+	var doc lichDoiGiaDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.toEntity(), nil
+}
+
+// FindByMonAnID lấy các lịch đổi giá của món, theo thời điểm áp dụng
+func (r *LichDoiGiaMongoRepo) FindByMonAnID(ctx context.Context, monAnID string) ([]*entity.LichDoiGia, error) {
+	return r.find(ctx, bson.M{"mon_an_id": monAnID})
+}
+
+// FindDenHan lấy các lịch đang chờ có thời điểm áp dụng <= now
+func (r *LichDoiGiaMongoRepo) FindDenHan(ctx context.Context, now time.Time) ([]*entity.LichDoiGia, error) {
+	return r.find(ctx, bson.M{
+		"trang_thai":  string(entity.LichDoiGiaChoApDung),
+		"ap_dung_luc": bson.M{"$lte": now},
+	})
+}
+
+// find chạy query và decode danh sách lịch đổi giá
+func (r *LichDoiGiaMongoRepo) find(ctx context.Context, filter bson.M) ([]*entity.LichDoiGia, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "ap_dung_luc", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.LichDoiGia
+	for cursor.Next(ctx) {
+		var doc lichDoiGiaDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, cursor.Err()
+}
+
+// Save lưu lịch đổi giá mới hoặc cập nhật
+func (r *LichDoiGiaMongoRepo) Save(ctx context.Context, lich *entity.LichDoiGia) error {
+	doc := toLichDoiGiaDocument(lich)
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": lich.ID}, doc, opts)
+
+	return err
+}
+
+// ChuyenTrangThai cập nhật trạng thái có điều kiện: chỉ document còn cho_ap_dung mới khớp,
+// nên hủy và áp dụng đồng thời cùng một lịch chỉ một bên thành công
+func (r *LichDoiGiaMongoRepo) ChuyenTrangThai(ctx context.Context, lich *entity.LichDoiGia) error {
+	filter := bson.M{"_id": lich.ID, "trang_thai": string(entity.LichDoiGiaChoApDung)}
+	update := bson.M{"$set": bson.M{
+		"trang_thai":     string(lich.TrangThai),
+		"da_ap_dung_luc": lich.DaApDungLuc,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrConcurrentModification
+	}
+
+	return nil
+}
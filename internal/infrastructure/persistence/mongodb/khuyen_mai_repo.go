@@ -0,0 +1,159 @@
+// Package mongodb chứa các MongoDB repository implementations
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// khuyenMaiDocument là struct mapping với MongoDB document
+type khuyenMaiDocument struct {
+	ID           string                `bson:"_id"`
+	Ten          string                `bson:"ten"`
+	MoTa         string                `bson:"mo_ta,omitempty"`
+	Loai         string                `bson:"loai"`
+	GiaTri       int64                 `bson:"gia_tri"`
+	GiamToiDa    int64                 `bson:"giam_toi_da,omitempty"`
+	MonAnIDs     []string              `bson:"mon_an_ids,omitempty"`
+	SoLuongMua   int                   `bson:"so_luong_mua,omitempty"`
+	SoLuongTang  int                   `bson:"so_luong_tang,omitempty"`
+	GiaCombo     int64                 `bson:"gia_combo,omitempty"`
+	DonToiThieu  int64                 `bson:"don_toi_thieu,omitempty"`
+	TuNgay       *time.Time            `bson:"tu_ngay,omitempty"`
+	DenNgay      *time.Time            `bson:"den_ngay,omitempty"`
+	KhungGio     []khungGioBanDocument `bson:"khung_gio,omitempty"`
+	CongDon      bool                  `bson:"cong_don"`
+	UuTien       int                   `bson:"uu_tien"`
+	DangHoatDong bool                  `bson:"dang_hoat_dong"`
+	NgayTao      time.Time             `bson:"ngay_tao"`
+	NgayCapNhat  time.Time             `bson:"ngay_cap_nhat"`
+}
+
+// toEntity chuyển từ document sang entity
+func (d *khuyenMaiDocument) toEntity() *entity.KhuyenMai {
+	return &entity.KhuyenMai{
+		ID:           d.ID,
+		Ten:          d.Ten,
+		MoTa:         d.MoTa,
+		Loai:         entity.LoaiKhuyenMai(d.Loai),
+		GiaTri:       d.GiaTri,
+		GiamToiDa:    d.GiamToiDa,
+		MonAnIDs:     d.MonAnIDs,
+		SoLuongMua:   d.SoLuongMua,
+		SoLuongTang:  d.SoLuongTang,
+		GiaCombo:     d.GiaCombo,
+		DonToiThieu:  d.DonToiThieu,
+		TuNgay:       d.TuNgay,
+		DenNgay:      d.DenNgay,
+		KhungGio:     toKhungGioBanList(d.KhungGio),
+		CongDon:      d.CongDon,
+		UuTien:       d.UuTien,
+		DangHoatDong: d.DangHoatDong,
+		NgayTao:      d.NgayTao,
+		NgayCapNhat:  d.NgayCapNhat,
+	}
+}
+
+// toKhuyenMaiDocument chuyển từ entity sang document
+func toKhuyenMaiDocument(k *entity.KhuyenMai) *khuyenMaiDocument {
+	return &khuyenMaiDocument{
+		ID:           k.ID,
+		Ten:          k.Ten,
+		MoTa:         k.MoTa,
+		Loai:         string(k.Loai),
+		GiaTri:       k.GiaTri,
+		GiamToiDa:    k.GiamToiDa,
+		MonAnIDs:     k.MonAnIDs,
+		SoLuongMua:   k.SoLuongMua,
+		SoLuongTang:  k.SoLuongTang,
+		GiaCombo:     k.GiaCombo,
+		DonToiThieu:  k.DonToiThieu,
+		TuNgay:       k.TuNgay,
+		DenNgay:      k.DenNgay,
+		KhungGio:     toKhungGioBanDocuments(k.KhungGio),
+		CongDon:      k.CongDon,
+		UuTien:       k.UuTien,
+		DangHoatDong: k.DangHoatDong,
+		NgayTao:      k.NgayTao,
+		NgayCapNhat:  k.NgayCapNhat,
+	}
+}
+
+// KhuyenMaiMongoRepo là implementation của IKhuyenMaiRepository sử dụng MongoDB
+type KhuyenMaiMongoRepo struct {
+	collection *mongo.Collection
+}
+
+// NewKhuyenMaiMongoRepo tạo mới KhuyenMaiMongoRepo
+func NewKhuyenMaiMongoRepo(db *mongo.Database) *KhuyenMaiMongoRepo {
+	return &KhuyenMaiMongoRepo{
+		collection: db.Collection("khuyen_mai"),
+	}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IKhuyenMaiRepository = (*KhuyenMaiMongoRepo)(nil)
+
+// FindByID tìm khuyến mãi theo ID
+func (r *KhuyenMaiMongoRepo) FindByID(ctx context.Context, id string) (*entity.KhuyenMai, error) {
+	var doc khuyenMaiDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.toEntity(), nil
+}
+
+// FindAll lấy tất cả khuyến mãi
+func (r *KhuyenMaiMongoRepo) FindAll(ctx context.Context) ([]*entity.KhuyenMai, error) {
+	return r.find(ctx, bson.M{})
+}
+
+// FindDangHoatDong lấy các khuyến mãi đang bật
+func (r *KhuyenMaiMongoRepo) FindDangHoatDong(ctx context.Context) ([]*entity.KhuyenMai, error) {
+	return r.find(ctx, bson.M{"dang_hoat_dong": true})
+}
+
+// find chạy query và decode danh sách khuyến mãi
+func (r *KhuyenMaiMongoRepo) find(ctx context.Context, filter bson.M) ([]*entity.KhuyenMai, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "uu_tien", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.KhuyenMai
+	for cursor.Next(ctx) {
+		var doc khuyenMaiDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, cursor.Err()
+}
+
+// Save lưu khuyến mãi mới hoặc cập nhật
+func (r *KhuyenMaiMongoRepo) Save(ctx context.Context, km *entity.KhuyenMai) error {
+	doc := toKhuyenMaiDocument(km)
+
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": km.ID}, doc, opts)
+
+	return err
+}
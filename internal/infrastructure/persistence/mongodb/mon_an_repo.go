@@ -14,16 +14,115 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// khungGioBanDocument là struct mapping cho KhungGioBan trong MongoDB
+type khungGioBanDocument struct {
+	CacNgay []int `bson:"cac_ngay,omitempty"`
+	TuPhut  int   `bson:"tu_phut"`
+	DenPhut int   `bson:"den_phut"`
+}
+
+// toKhungGioBanList chuyển danh sách khung giờ từ document sang entity
+func toKhungGioBanList(docs []khungGioBanDocument) []entity.KhungGioBan {
+	var result []entity.KhungGioBan
+	for _, k := range docs {
+		cacNgay := make([]time.Weekday, len(k.CacNgay))
+		for i, n := range k.CacNgay {
+			cacNgay[i] = time.Weekday(n)
+		}
+		result = append(result, entity.KhungGioBan{
+			CacNgay: cacNgay,
+			TuPhut:  k.TuPhut,
+			DenPhut: k.DenPhut,
+		})
+	}
+	return result
+}
+
+// toKhungGioBanDocuments chuyển danh sách khung giờ từ entity sang document
+func toKhungGioBanDocuments(list []entity.KhungGioBan) []khungGioBanDocument {
+	var result []khungGioBanDocument
+	for _, k := range list {
+		cacNgay := make([]int, len(k.CacNgay))
+		for i, n := range k.CacNgay {
+			cacNgay[i] = int(n)
+		}
+		result = append(result, khungGioBanDocument{
+			CacNgay: cacNgay,
+			TuPhut:  k.TuPhut,
+			DenPhut: k.DenPhut,
+		})
+	}
+	return result
+}
+
+// luaChonTuyChonDocument là struct mapping cho LuaChonTuyChon trong MongoDB
+type luaChonTuyChonDocument struct {
+	ID      string `bson:"id"`
+	Ten     string `bson:"ten"`
+	GiaThem int64  `bson:"gia_them"`
+}
+
+// nhomTuyChonDocument là struct mapping cho NhomTuyChon trong MongoDB
+type nhomTuyChonDocument struct {
+	ID           string                   `bson:"id"`
+	Ten          string                   `bson:"ten"`
+	ChonToiThieu int                      `bson:"chon_toi_thieu"`
+	ChonToiDa    int                      `bson:"chon_toi_da"`
+	LuaChon      []luaChonTuyChonDocument `bson:"lua_chon"`
+}
+
+// toNhomTuyChonList chuyển danh sách nhóm tùy chọn từ document sang entity
+func toNhomTuyChonList(docs []nhomTuyChonDocument) []entity.NhomTuyChon {
+	var result []entity.NhomTuyChon
+	for _, n := range docs {
+		luaChon := make([]entity.LuaChonTuyChon, len(n.LuaChon))
+		for i, lc := range n.LuaChon {
+			luaChon[i] = entity.LuaChonTuyChon{ID: lc.ID, Ten: lc.Ten, GiaThem: lc.GiaThem}
+		}
+		result = append(result, entity.NhomTuyChon{
+			ID:           n.ID,
+			Ten:          n.Ten,
+			ChonToiThieu: n.ChonToiThieu,
+			ChonToiDa:    n.ChonToiDa,
+			LuaChon:      luaChon,
+		})
+	}
+	return result
+}
+
+// toNhomTuyChonDocuments chuyển danh sách nhóm tùy chọn từ entity sang document
+func toNhomTuyChonDocuments(list []entity.NhomTuyChon) []nhomTuyChonDocument {
+	var result []nhomTuyChonDocument
+	for _, n := range list {
+		luaChon := make([]luaChonTuyChonDocument, len(n.LuaChon))
+		for i, lc := range n.LuaChon {
+			luaChon[i] = luaChonTuyChonDocument{ID: lc.ID, Ten: lc.Ten, GiaThem: lc.GiaThem}
+		}
+		result = append(result, nhomTuyChonDocument{
+			ID:           n.ID,
+			Ten:          n.Ten,
+			ChonToiThieu: n.ChonToiThieu,
+			ChonToiDa:    n.ChonToiDa,
+			LuaChon:      luaChon,
+		})
+	}
+	return result
+}
+
 // monAnDocument là struct mapping với MongoDB document
 type monAnDocument struct {
-	ID          string    `bson:"_id"`
-	Ten         string    `bson:"ten"`
-	Gia         int64     `bson:"gia"`
-	MoTa        string    `bson:"mo_ta"`
-	ConHang     bool      `bson:"con_hang"`
-	GiamGia     int       `bson:"giam_gia"`
-	NgayTao     time.Time `bson:"ngay_tao"`
-	NgayCapNhat time.Time `bson:"ngay_cap_nhat"`
+	ID          string                `bson:"_id"`
+	Ten         string                `bson:"ten"`
+	Gia         int64                 `bson:"gia"`
+	MoTa        string                `bson:"mo_ta"`
+	ConHang     bool                  `bson:"con_hang"`
+	GiamGia     int                   `bson:"giam_gia"`
+	LichBan     []khungGioBanDocument `bson:"lich_ban,omitempty"`
+	NhomTuyChon []nhomTuyChonDocument `bson:"nhom_tuy_chon,omitempty"`
+	NgayTao     time.Time             `bson:"ngay_tao"`
+	NgayCapNhat time.Time             `bson:"ngay_cap_nhat"`
+	Version     int64                 `bson:"version,omitempty"`
+	NgayXoa     *time.Time            `bson:"ngay_xoa,omitempty"`
 }
 
 // toEntity chuyển từ document sang entity
@@ -35,8 +134,12 @@ func (d *monAnDocument) toEntity() *entity.MonAn {
 		MoTa:        d.MoTa,
 		ConHang:     d.ConHang,
 		GiamGia:     d.GiamGia,
+		LichBan:     toKhungGioBanList(d.LichBan),
+		NhomTuyChon: toNhomTuyChonList(d.NhomTuyChon),
 		NgayTao:     d.NgayTao,
 		NgayCapNhat: d.NgayCapNhat,
+		Version:     d.Version,
+		NgayXoa:     d.NgayXoa,
 	}
 }
 
@@ -49,11 +152,22 @@ func toMonAnDocument(m *entity.MonAn) *monAnDocument {
 		MoTa:        m.MoTa,
 		ConHang:     m.ConHang,
 		GiamGia:     m.GiamGia,
+		LichBan:     toKhungGioBanDocuments(m.LichBan),
+		NhomTuyChon: toNhomTuyChonDocuments(m.NhomTuyChon),
 		NgayTao:     m.NgayTao,
 		NgayCapNhat: m.NgayCapNhat,
+		Version:     m.Version,
+		NgayXoa:     m.NgayXoa,
 	}
 }
 
+// chuaXoa là điều kiện lọc món chưa bị xóa mềm
+// ngay_xoa: nil khớp cả document không có field (tạo trước khi có xóa mềm)
+func chuaXoa(filter bson.M) bson.M {
+	filter["ngay_xoa"] = nil
+	return filter
+}
+
 // MonAnMongoRepo là implementation của IMonAnRepository sử dụng MongoDB
 type MonAnMongoRepo struct {
 	collection *mongo.Collection
@@ -72,7 +186,7 @@ var _ repository.IMonAnRepository = (*MonAnMongoRepo)(nil)
 // FindByID tìm món ăn theo ID
 func (r *MonAnMongoRepo) FindByID(ctx context.Context, id string) (*entity.MonAn, error) {
 	var doc monAnDocument
-	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+	err := r.collection.FindOne(ctx, chuaXoa(bson.M{"_id": id})).Decode(&doc)
 
 	if errors.Is(err, mongo.ErrNoDocuments) {
 		return nil, nil
@@ -87,7 +201,7 @@ func (r *MonAnMongoRepo) FindByID(ctx context.Context, id string) (*entity.MonAn
 // FindAll lấy tất cả món ăn
 func (r *MonAnMongoRepo) FindAll(ctx context.Context) ([]*entity.MonAn, error) {
 	opts := options.Find().SetSort(bson.M{"ngay_tao": -1})
-	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	cursor, err := r.collection.Find(ctx, chuaXoa(bson.M{}), opts)
 	if err != nil {
 		return nil, err
 	}
@@ -107,7 +221,7 @@ func (r *MonAnMongoRepo) FindAll(ctx context.Context) ([]*entity.MonAn, error) {
 
 // FindByConHang lấy các món theo trạng thái còn hàng
 func (r *MonAnMongoRepo) FindByConHang(ctx context.Context, conHang bool) ([]*entity.MonAn, error) {
-	cursor, err := r.collection.Find(ctx, bson.M{"con_hang": conHang})
+	cursor, err := r.collection.Find(ctx, chuaXoa(bson.M{"con_hang": conHang}))
 	if err != nil {
 		return nil, err
 	}
@@ -125,31 +239,106 @@ func (r *MonAnMongoRepo) FindByConHang(ctx context.Context, conHang bool) ([]*en
 	return list, cursor.Err()
 }
 
-// Save lưu món ăn mới hoặc cập nhật
+// Save lưu món ăn mới hoặc cập nhật có điều kiện theo Version
+//   - Version > 0: chỉ replace document có cùng version, không khớp → ErrConcurrentModification
+//   - Version = 0: insert món mới; document tạo trước khi có version (không có field) cũng khớp.
+//     ID đã tồn tại với version khác → upsert insert trùng _id → ErrConcurrentModification
 func (r *MonAnMongoRepo) Save(ctx context.Context, mon *entity.MonAn) error {
 	doc := toMonAnDocument(mon)
+	doc.Version = mon.Version + 1
 
-	opts := options.Replace().SetUpsert(true)
-	_, err := r.collection.ReplaceOne(ctx, bson.M{"_id": mon.ID}, doc, opts)
+	if mon.Version == 0 {
+		filter := bson.M{"_id": mon.ID, "version": bson.M{"$exists": false}}
+		_, err := r.collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+		if mongo.IsDuplicateKeyError(err) {
+			return repository.ErrConcurrentModification
+		}
+		if err != nil {
+			return err
+		}
+		mon.Version = doc.Version
+		return nil
+	}
+
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": mon.ID, "version": mon.Version}, doc)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return repository.ErrConcurrentModification
+	}
 
-	return err
+	mon.Version = doc.Version
+	return nil
 }
 
-// Delete xóa món ăn theo ID
+// Delete xóa mềm món ăn theo ID
+// Tăng version để request đang giữ bản đọc trước đó không ghi đè được món đã xóa
 func (r *MonAnMongoRepo) Delete(ctx context.Context, id string) error {
-	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"ngay_xoa": now, "ngay_cap_nhat": now},
+		"$inc": bson.M{"version": 1},
+	}
+	result, err := r.collection.UpdateOne(ctx, chuaXoa(bson.M{"_id": id}), update)
 	if err != nil {
 		return err
 	}
 
-	if result.DeletedCount == 0 {
+	if result.MatchedCount == 0 {
 		return errors.New("không tìm thấy món ăn để xóa")
 	}
 
 	return nil
 }
 
+// FindDeleted lấy các món đã xóa mềm, mới xóa trước
+func (r *MonAnMongoRepo) FindDeleted(ctx context.Context) ([]*entity.MonAn, error) {
+	opts := options.Find().SetSort(bson.M{"ngay_xoa": -1})
+	cursor, err := r.collection.Find(ctx, bson.M{"ngay_xoa": bson.M{"$ne": nil}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.MonAn
+	for cursor.Next(ctx) {
+		var doc monAnDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, cursor.Err()
+}
+
+// Restore khôi phục món đã xóa mềm
+func (r *MonAnMongoRepo) Restore(ctx context.Context, id string) (bool, error) {
+	update := bson.M{
+		"$unset": bson.M{"ngay_xoa": ""},
+		"$set":   bson.M{"ngay_cap_nhat": time.Now()},
+		"$inc":   bson.M{"version": 1},
+	}
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": id, "ngay_xoa": bson.M{"$ne": nil}}, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.MatchedCount > 0, nil
+}
+
+// PurgeDeleted xóa vĩnh viễn các món đã xóa mềm trước thời điểm truoc
+func (r *MonAnMongoRepo) PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{"ngay_xoa": bson.M{"$lt": truoc}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
 // Count đếm tổng số món ăn
 func (r *MonAnMongoRepo) Count(ctx context.Context) (int64, error) {
-	return r.collection.CountDocuments(ctx, bson.M{})
+	return r.collection.CountDocuments(ctx, chuaXoa(bson.M{}))
 }
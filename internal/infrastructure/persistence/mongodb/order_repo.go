@@ -14,14 +14,31 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// tuyChonDaChonDocument là struct mapping cho TuyChonDaChon trong MongoDB
+type tuyChonDaChonDocument struct {
+	NhomID     string `bson:"nhom_id"`
+	TenNhom    string `bson:"ten_nhom"`
+	LuaChonID  string `bson:"lua_chon_id"`
+	TenLuaChon string `bson:"ten_lua_chon"`
+	GiaThem    int64  `bson:"gia_them"`
+}
+
 // orderItemDocument là struct mapping cho OrderItem trong MongoDB
 type orderItemDocument struct {
-	MonAnID   string `bson:"mon_an_id"`
-	TenMon    string `bson:"ten_mon"`
-	SoLuong   int    `bson:"so_luong"`
-	DonGia    int64  `bson:"don_gia"`
-	GhiChu    string `bson:"ghi_chu,omitempty"`
-	ThanhTien int64  `bson:"thanh_tien"`
+	MonAnID   string                  `bson:"mon_an_id"`
+	TenMon    string                  `bson:"ten_mon"`
+	SoLuong   int                     `bson:"so_luong"`
+	DonGia    int64                   `bson:"don_gia"`
+	TuyChon   []tuyChonDaChonDocument `bson:"tuy_chon,omitempty"`
+	GhiChu    string                  `bson:"ghi_chu,omitempty"`
+	ThanhTien int64                   `bson:"thanh_tien"`
+}
+
+// khuyenMaiApDungDocument là struct mapping cho KhuyenMaiApDung trong MongoDB
+type khuyenMaiApDungDocument struct {
+	KhuyenMaiID string `bson:"khuyen_mai_id"`
+	Ten         string `bson:"ten"`
+	SoTienGiam  int64  `bson:"so_tien_giam"`
 }
 
 // orderDocument là struct mapping với MongoDB document
@@ -36,6 +53,9 @@ type orderDocument struct {
 	Items             []orderItemDocument `bson:"items"`
 	TongTien          int64               `bson:"tong_tien"`
 	GiamGia           int64               `bson:"giam_gia"`
+	KhuyenMai         []khuyenMaiApDungDocument `bson:"khuyen_mai,omitempty"`
+	MaGiamGia         string              `bson:"ma_giam_gia,omitempty"`
+	TienGiamMa        int64               `bson:"tien_giam_ma,omitempty"`
 	TienThanhToan     int64               `bson:"tien_thanh_toan"`
 	GhiChu            string              `bson:"ghi_chu,omitempty"`
 	DiaChiGiao        string              `bson:"dia_chi_giao,omitempty"`
@@ -48,16 +68,36 @@ type orderDocument struct {
 func (d *orderDocument) toEntity() *entity.Order {
 	items := make([]entity.OrderItem, len(d.Items))
 	for i, item := range d.Items {
+		var tuyChon []entity.TuyChonDaChon
+		for _, tc := range item.TuyChon {
+			tuyChon = append(tuyChon, entity.TuyChonDaChon{
+				NhomID:     tc.NhomID,
+				TenNhom:    tc.TenNhom,
+				LuaChonID:  tc.LuaChonID,
+				TenLuaChon: tc.TenLuaChon,
+				GiaThem:    tc.GiaThem,
+			})
+		}
 		items[i] = entity.OrderItem{
 			MonAnID:   item.MonAnID,
 			TenMon:    item.TenMon,
 			SoLuong:   item.SoLuong,
 			DonGia:    item.DonGia,
+			TuyChon:   tuyChon,
 			GhiChu:    item.GhiChu,
 			ThanhTien: item.ThanhTien,
 		}
 	}
 
+	var khuyenMai []entity.KhuyenMaiApDung
+	for _, km := range d.KhuyenMai {
+		khuyenMai = append(khuyenMai, entity.KhuyenMaiApDung{
+			KhuyenMaiID: km.KhuyenMaiID,
+			Ten:         km.Ten,
+			SoTienGiam:  km.SoTienGiam,
+		})
+	}
+
 	return &entity.Order{
 		ID:                d.ID,
 		KhachHangID:       d.KhachHangID,
@@ -69,6 +109,9 @@ func (d *orderDocument) toEntity() *entity.Order {
 		Items:             items,
 		TongTien:          d.TongTien,
 		GiamGia:           d.GiamGia,
+		KhuyenMai:         khuyenMai,
+		MaGiamGia:         d.MaGiamGia,
+		TienGiamMa:        d.TienGiamMa,
 		TienThanhToan:     d.TienThanhToan,
 		GhiChu:            d.GhiChu,
 		DiaChiGiao:        d.DiaChiGiao,
@@ -82,16 +125,36 @@ func (d *orderDocument) toEntity() *entity.Order {
 func toOrderDocument(o *entity.Order) *orderDocument {
 	items := make([]orderItemDocument, len(o.Items))
 	for i, item := range o.Items {
+		var tuyChon []tuyChonDaChonDocument
+		for _, tc := range item.TuyChon {
+			tuyChon = append(tuyChon, tuyChonDaChonDocument{
+				NhomID:     tc.NhomID,
+				TenNhom:    tc.TenNhom,
+				LuaChonID:  tc.LuaChonID,
+				TenLuaChon: tc.TenLuaChon,
+				GiaThem:    tc.GiaThem,
+			})
+		}
 		items[i] = orderItemDocument{
 			MonAnID:   item.MonAnID,
 			TenMon:    item.TenMon,
 			SoLuong:   item.SoLuong,
 			DonGia:    item.DonGia,
+			TuyChon:   tuyChon,
 			GhiChu:    item.GhiChu,
 			ThanhTien: item.ThanhTien,
 		}
 	}
 
+	var khuyenMai []khuyenMaiApDungDocument
+	for _, km := range o.KhuyenMai {
+		khuyenMai = append(khuyenMai, khuyenMaiApDungDocument{
+			KhuyenMaiID: km.KhuyenMaiID,
+			Ten:         km.Ten,
+			SoTienGiam:  km.SoTienGiam,
+		})
+	}
+
 	return &orderDocument{
 		ID:                o.ID,
 		KhachHangID:       o.KhachHangID,
@@ -103,6 +166,9 @@ func toOrderDocument(o *entity.Order) *orderDocument {
 		Items:             items,
 		TongTien:          o.TongTien,
 		GiamGia:           o.GiamGia,
+		KhuyenMai:         khuyenMai,
+		MaGiamGia:         o.MaGiamGia,
+		TienGiamMa:        o.TienGiamMa,
 		TienThanhToan:     o.TienThanhToan,
 		GhiChu:            o.GhiChu,
 		DiaChiGiao:        o.DiaChiGiao,
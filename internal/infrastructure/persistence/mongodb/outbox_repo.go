@@ -0,0 +1,152 @@
+// Package mongodb chứa các MongoDB repository implementations
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// outboxDocument là struct mapping với MongoDB document
+type outboxDocument struct {
+	ID         string     `bson:"_id"`
+	Loai       string     `bson:"loai"`
+	DoiTuongID string     `bson:"doi_tuong_id"`
+	DuLieu     []byte     `bson:"du_lieu"`
+	SoLanThu   int        `bson:"so_lan_thu"`
+	LoiCuoi    string     `bson:"loi_cuoi,omitempty"`
+	TaoLuc     time.Time  `bson:"tao_luc"`
+	ThuLaiLuc  time.Time  `bson:"thu_lai_luc"`
+	DaPhatLuc  *time.Time `bson:"da_phat_luc"`
+}
+
+// toEntity chuyển từ document sang entity
+func (d *outboxDocument) toEntity() *entity.SuKien {
+	return &entity.SuKien{
+		ID:         d.ID,
+		Loai:       entity.LoaiSuKien(d.Loai),
+		DoiTuongID: d.DoiTuongID,
+		DuLieu:     d.DuLieu,
+		SoLanThu:   d.SoLanThu,
+		LoiCuoi:    d.LoiCuoi,
+		TaoLuc:     d.TaoLuc,
+		ThuLaiLuc:  d.ThuLaiLuc,
+		DaPhatLuc:  d.DaPhatLuc,
+	}
+}
+
+// OutboxMongoRepo là implementation của IOutboxRepository sử dụng MongoDB
+// Add tham gia transaction của MongoUnitOfWork qua session trong ctx
+type OutboxMongoRepo struct {
+	collection *mongo.Collection
+}
+
+// NewOutboxMongoRepo tạo mới OutboxMongoRepo
+func NewOutboxMongoRepo(db *mongo.Database) *OutboxMongoRepo {
+	return &OutboxMongoRepo{
+		collection: db.Collection("outbox"),
+	}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IOutboxRepository = (*OutboxMongoRepo)(nil)
+
+// Add ghi sự kiện vào outbox (InsertOne: trùng _id thì lỗi)
+func (r *OutboxMongoRepo) Add(ctx context.Context, sk *entity.SuKien) error {
+	_, err := r.collection.InsertOne(ctx, &outboxDocument{
+		ID:         sk.ID,
+		Loai:       string(sk.Loai),
+		DoiTuongID: sk.DoiTuongID,
+		DuLieu:     sk.DuLieu,
+		TaoLuc:     sk.TaoLuc,
+		ThuLaiLuc:  sk.ThuLaiLuc,
+	})
+	return err
+}
+
+// FindDenHan lấy các sự kiện chưa phát đã tới hạn, cũ nhất trước
+func (r *OutboxMongoRepo) FindDenHan(ctx context.Context, now time.Time, limit int) ([]*entity.SuKien, error) {
+	filter := bson.M{
+		"da_phat_luc": nil,
+		"thu_lai_luc": bson.M{"$lte": now},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "tao_luc", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.SuKien
+	for cursor.Next(ctx) {
+		var doc outboxDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, cursor.Err()
+}
+
+// GiuCho dời thu_lai_luc nếu sự kiện vẫn chưa phát và đã tới hạn (UpdateOne có điều kiện)
+func (r *OutboxMongoRepo) GiuCho(ctx context.Context, id string, now, den time.Time) (bool, error) {
+	filter := bson.M{
+		"_id":         id,
+		"da_phat_luc": nil,
+		"thu_lai_luc": bson.M{"$lte": now},
+	}
+	result, err := r.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"thu_lai_luc": den}})
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// DanhDauDaPhat đánh dấu sự kiện đã phát thành công
+func (r *OutboxMongoRepo) DanhDauDaPhat(ctx context.Context, id string, luc time.Time) error {
+	_, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"da_phat_luc": luc}},
+	)
+	return err
+}
+
+// DanhDauThatBai tăng số lần thử, ghi lỗi và hẹn lần phát tiếp theo
+func (r *OutboxMongoRepo) DanhDauThatBai(ctx context.Context, id string, loi string, thuLaiLuc time.Time) error {
+	result, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "da_phat_luc": nil},
+		bson.M{
+			"$inc": bson.M{"so_lan_thu": 1},
+			"$set": bson.M{"loi_cuoi": loi, "thu_lai_luc": thuLaiLuc},
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("không tìm thấy sự kiện chưa phát với ID: %s", id)
+	}
+	return nil
+}
+
+// PurgeDaPhat xóa các sự kiện đã phát trước thời điểm truoc
+func (r *OutboxMongoRepo) PurgeDaPhat(ctx context.Context, truoc time.Time) (int64, error) {
+	result, err := r.collection.DeleteMany(ctx, bson.M{
+		"da_phat_luc": bson.M{"$ne": nil, "$lt": truoc},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
@@ -0,0 +1,49 @@
+// Package mongodb chứa các MongoDB repository implementations
+package mongodb
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/infrastructure/database"
+)
+
+// MongoUnitOfWork là implementation của IUnitOfWork dùng multi-document transaction của MongoDB
+// Session nằm trong mongo.SessionContext nên mọi MongoDB repository nhận ctx từ Do
+// đều chạy trong transaction mà không cần sửa repository
+// Lưu ý: MongoDB chỉ hỗ trợ transaction trên replica set hoặc sharded cluster
+type MongoUnitOfWork struct {
+	conn *database.MongoDBConnection
+}
+
+// NewMongoUnitOfWork tạo mới MongoUnitOfWork
+func NewMongoUnitOfWork(conn *database.MongoDBConnection) *MongoUnitOfWork {
+	return &MongoUnitOfWork{conn: conn}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IUnitOfWork = (*MongoUnitOfWork)(nil)
+
+// Do chạy fn trong một transaction MongoDB
+// Driver tự thử lại cả fn khi gặp lỗi tạm thời (TransientTransactionError),
+// nên fn không được có tác dụng phụ ngoài database (gửi email, publish...)
+func (u *MongoUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Gọi lồng nhau: tham gia transaction bên ngoài
+	if mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := u.conn.StartSession()
+	if err != nil {
+		return fmt.Errorf("không thể bắt đầu session: %w", err)
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (any, error) {
+		return nil, fn(sc)
+	})
+	return err
+}
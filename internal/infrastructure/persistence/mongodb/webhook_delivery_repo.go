@@ -0,0 +1,162 @@
+// Package mongodb chứa các MongoDB repository implementations
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// webhookDeliveryDocument là struct mapping với MongoDB document
+type webhookDeliveryDocument struct {
+	ID           string     `bson:"_id"`
+	WebhookID    string     `bson:"webhook_id"`
+	SuKienID     string     `bson:"su_kien_id"`
+	Loai         string     `bson:"loai"`
+	DuLieu       []byte     `bson:"du_lieu"`
+	TrangThai    string     `bson:"trang_thai"`
+	SoLanThu     int        `bson:"so_lan_thu"`
+	MaPhanHoi    int        `bson:"ma_phan_hoi"`
+	LoiCuoi      string     `bson:"loi_cuoi,omitempty"`
+	TaoLuc       time.Time  `bson:"tao_luc"`
+	CapNhatLuc   time.Time  `bson:"cap_nhat_luc"`
+	ThanhCongLuc *time.Time `bson:"thanh_cong_luc,omitempty"`
+}
+
+// toEntity chuyển từ document sang entity
+func (d *webhookDeliveryDocument) toEntity() *entity.WebhookDelivery {
+	return &entity.WebhookDelivery{
+		ID:           d.ID,
+		WebhookID:    d.WebhookID,
+		SuKienID:     d.SuKienID,
+		Loai:         entity.LoaiSuKien(d.Loai),
+		DuLieu:       d.DuLieu,
+		TrangThai:    entity.TrangThaiDelivery(d.TrangThai),
+		SoLanThu:     d.SoLanThu,
+		MaPhanHoi:    d.MaPhanHoi,
+		LoiCuoi:      d.LoiCuoi,
+		TaoLuc:       d.TaoLuc,
+		CapNhatLuc:   d.CapNhatLuc,
+		ThanhCongLuc: d.ThanhCongLuc,
+	}
+}
+
+// toWebhookDeliveryDocument chuyển từ entity sang document
+func toWebhookDeliveryDocument(d *entity.WebhookDelivery) *webhookDeliveryDocument {
+	return &webhookDeliveryDocument{
+		ID:           d.ID,
+		WebhookID:    d.WebhookID,
+		SuKienID:     d.SuKienID,
+		Loai:         string(d.Loai),
+		DuLieu:       d.DuLieu,
+		TrangThai:    string(d.TrangThai),
+		SoLanThu:     d.SoLanThu,
+		MaPhanHoi:    d.MaPhanHoi,
+		LoiCuoi:      d.LoiCuoi,
+		TaoLuc:       d.TaoLuc,
+		CapNhatLuc:   d.CapNhatLuc,
+		ThanhCongLuc: d.ThanhCongLuc,
+	}
+}
+
+// WebhookDeliveryMongoRepo là implementation của IWebhookDeliveryRepository sử dụng MongoDB
+type WebhookDeliveryMongoRepo struct {
+	collection *mongo.Collection
+}
+
+// NewWebhookDeliveryMongoRepo tạo mới WebhookDeliveryMongoRepo
+func NewWebhookDeliveryMongoRepo(db *mongo.Database) *WebhookDeliveryMongoRepo {
+	return &WebhookDeliveryMongoRepo{
+		collection: db.Collection("webhook_deliveries"),
+	}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IWebhookDeliveryRepository = (*WebhookDeliveryMongoRepo)(nil)
+
+// TaoNeuChuaCo ghi delivery mới (InsertOne: trùng _id nghĩa là đã tạo ở lần phát trước)
+func (r *WebhookDeliveryMongoRepo) TaoNeuChuaCo(ctx context.Context, d *entity.WebhookDelivery) (bool, error) {
+	_, err := r.collection.InsertOne(ctx, toWebhookDeliveryDocument(d))
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// FindByID tìm delivery theo ID
+func (r *WebhookDeliveryMongoRepo) FindByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	var doc webhookDeliveryDocument
+	err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&doc)
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.toEntity(), nil
+}
+
+// Find lấy delivery theo điều kiện lọc có phân trang, mới nhất trước
+func (r *WebhookDeliveryMongoRepo) Find(ctx context.Context, filter repository.WebhookDeliveryFilter, offset, limit int) ([]*entity.WebhookDelivery, int64, error) {
+	query := bson.M{}
+	if filter.WebhookID != "" {
+		query["webhook_id"] = filter.WebhookID
+	}
+	if filter.SuKienID != "" {
+		query["su_kien_id"] = filter.SuKienID
+	}
+	if filter.TrangThai != "" {
+		query["trang_thai"] = string(filter.TrangThai)
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "tao_luc", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var list []*entity.WebhookDelivery
+	for cursor.Next(ctx) {
+		var doc webhookDeliveryDocument
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, 0, err
+		}
+		list = append(list, doc.toEntity())
+	}
+
+	return list, total, cursor.Err()
+}
+
+// Save cập nhật delivery đã tồn tại (ReplaceOne, không upsert)
+func (r *WebhookDeliveryMongoRepo) Save(ctx context.Context, d *entity.WebhookDelivery) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": d.ID}, toWebhookDeliveryDocument(d))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("không tìm thấy webhook delivery với ID: %s", d.ID)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// DonDatHangMySQLRepo là implementation của IDonDatHangRepository sử dụng MySQL
+type DonDatHangMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewDonDatHangMySQLRepo tạo mới DonDatHangMySQLRepo
+func NewDonDatHangMySQLRepo(db *sql.DB) *DonDatHangMySQLRepo {
+	return &DonDatHangMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IDonDatHangRepository = (*DonDatHangMySQLRepo)(nil)
+
+const selectDonDatHang = `SELECT id, nha_cung_cap_id, nguoi_tao_id, trang_thai, ghi_chu,
+			  ngay_tao, ngay_gui, ngay_nhan, ngay_cap_nhat
+			  FROM don_dat_hang`
+
+// scanDonDatHang đọc một dòng don_dat_hang (xử lý các cột nullable)
+func scanDonDatHang(scanner interface{ Scan(...any) error }) (*entity.DonDatHang, error) {
+	d := &entity.DonDatHang{}
+	var nguoiTaoID, ghiChu sql.NullString
+	var ngayGui, ngayNhan sql.NullTime
+
+	err := scanner.Scan(
+		&d.ID, &d.NhaCungCapID, &nguoiTaoID, &d.TrangThai, &ghiChu,
+		&d.NgayTao, &ngayGui, &ngayNhan, &d.NgayCapNhat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	d.NguoiTaoID = nguoiTaoID.String
+	d.GhiChu = ghiChu.String
+	if ngayGui.Valid {
+		d.NgayGui = &ngayGui.Time
+	}
+	if ngayNhan.Valid {
+		d.NgayNhan = &ngayNhan.Time
+	}
+	d.Items = make([]entity.DonDatHangItem, 0)
+
+	return d, nil
+}
+
+// FindByID tìm đơn đặt hàng theo ID (kèm items)
+func (r *DonDatHangMySQLRepo) FindByID(ctx context.Context, id string) (*entity.DonDatHang, error) {
+	return r.findOne(ctx, selectDonDatHang+` WHERE id = ?`, id)
+}
+
+// FindByIDForUpdate tìm đơn đặt hàng và khóa dòng header tới hết transaction của UnitOfWork
+// Hai lần nhận hàng cho cùng đơn được xử lý tuần tự, không nhận vượt số lượng đặt
+func (r *DonDatHangMySQLRepo) FindByIDForUpdate(ctx context.Context, id string) (*entity.DonDatHang, error) {
+	return r.findOne(ctx, selectDonDatHang+` WHERE id = ? FOR UPDATE`, id)
+}
+
+// findOne chạy câu truy vấn header một đơn và nạp items
+func (r *DonDatHangMySQLRepo) findOne(ctx context.Context, query string, id string) (*entity.DonDatHang, error) {
+	d, err := scanDonDatHang(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.loadItems(ctx, []*entity.DonDatHang{d}); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// FindAll lấy tất cả đơn đặt hàng
+func (r *DonDatHangMySQLRepo) FindAll(ctx context.Context) ([]*entity.DonDatHang, error) {
+	return r.query(ctx, selectDonDatHang+` ORDER BY ngay_tao DESC`)
+}
+
+// FindByTrangThai lấy đơn đặt hàng theo trạng thái
+func (r *DonDatHangMySQLRepo) FindByTrangThai(ctx context.Context, trangThai entity.TrangThaiDonDatHang) ([]*entity.DonDatHang, error) {
+	return r.query(ctx, selectDonDatHang+` WHERE trang_thai = ? ORDER BY ngay_tao DESC`, trangThai)
+}
+
+// FindDangCho lấy các đơn đã gửi nhưng chưa nhận đủ hàng
+func (r *DonDatHangMySQLRepo) FindDangCho(ctx context.Context) ([]*entity.DonDatHang, error) {
+	return r.query(ctx, selectDonDatHang+` WHERE trang_thai IN (?, ?) ORDER BY ngay_tao DESC`,
+		entity.DonDatHangDaGui, entity.DonDatHangNhanMotPhan)
+}
+
+// query chạy câu truy vấn header và nạp items cho tất cả đơn
+func (r *DonDatHangMySQLRepo) query(ctx context.Context, query string, args ...interface{}) ([]*entity.DonDatHang, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.DonDatHang
+	for rows.Next() {
+		d, err := scanDonDatHang(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadItems(ctx, list); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// loadItems nạp items cho danh sách đơn bằng một câu truy vấn duy nhất
+func (r *DonDatHangMySQLRepo) loadItems(ctx context.Context, list []*entity.DonDatHang) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*entity.DonDatHang, len(list))
+	args := make([]interface{}, 0, len(list))
+	for _, d := range list {
+		byID[d.ID] = d
+		args = append(args, d.ID)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(list)), ",")
+	query := fmt.Sprintf(`SELECT don_dat_hang_id, nguyen_lieu_id, ten_nguyen_lieu, so_luong_dat, so_luong_da_nhan, don_gia
+			  FROM don_dat_hang_item WHERE don_dat_hang_id IN (%s)
+			  ORDER BY ten_nguyen_lieu`, placeholders)
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var donID string
+		var item entity.DonDatHangItem
+		err := rows.Scan(&donID, &item.NguyenLieuID, &item.TenNguyenLieu,
+			&item.SoLuongDat, &item.SoLuongDaNhan, &item.DonGia)
+		if err != nil {
+			return err
+		}
+		if d, ok := byID[donID]; ok {
+			d.Items = append(d.Items, item)
+		}
+	}
+
+	return rows.Err()
+}
+
+// Save lưu đơn đặt hàng mới hoặc cập nhật (kèm items)
+// Header và items được ghi trong cùng một transaction
+func (r *DonDatHangMySQLRepo) Save(ctx context.Context, d *entity.DonDatHang) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO don_dat_hang (id, nha_cung_cap_id, nguoi_tao_id, trang_thai, ghi_chu,
+			  ngay_tao, ngay_gui, ngay_nhan, ngay_cap_nhat)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE
+			  nha_cung_cap_id = VALUES(nha_cung_cap_id),
+			  trang_thai = VALUES(trang_thai),
+			  ghi_chu = VALUES(ghi_chu),
+			  ngay_gui = VALUES(ngay_gui),
+			  ngay_nhan = VALUES(ngay_nhan),
+			  ngay_cap_nhat = VALUES(ngay_cap_nhat)`
+
+	var nguoiTaoID, ghiChu interface{}
+	if d.NguoiTaoID != "" {
+		nguoiTaoID = d.NguoiTaoID
+	}
+	if d.GhiChu != "" {
+		ghiChu = d.GhiChu
+	}
+
+	_, err = tx.ExecContext(ctx, query,
+		d.ID, d.NhaCungCapID, nguoiTaoID, d.TrangThai, ghiChu,
+		d.NgayTao, d.NgayGui, d.NgayNhan, d.NgayCapNhat,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Thay toàn bộ items (số dòng nhỏ, đơn giản hơn so với diff)
+	if _, err := tx.ExecContext(ctx, `DELETE FROM don_dat_hang_item WHERE don_dat_hang_id = ?`, d.ID); err != nil {
+		return err
+	}
+
+	itemQuery := `INSERT INTO don_dat_hang_item (don_dat_hang_id, nguyen_lieu_id, ten_nguyen_lieu, so_luong_dat, so_luong_da_nhan, don_gia)
+			  VALUES (?, ?, ?, ?, ?, ?)`
+	for _, item := range d.Items {
+		_, err := tx.ExecContext(ctx, itemQuery,
+			d.ID, item.NguyenLieuID, item.TenNguyenLieu, item.SoLuongDat, item.SoLuongDaNhan, item.DonGia,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
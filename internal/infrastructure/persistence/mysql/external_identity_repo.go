@@ -0,0 +1,126 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// ExternalIdentityMySQLRepo là implementation của IExternalIdentityRepository sử dụng MySQL
+type ExternalIdentityMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewExternalIdentityMySQLRepo tạo mới ExternalIdentityMySQLRepo
+func NewExternalIdentityMySQLRepo(db *sql.DB) *ExternalIdentityMySQLRepo {
+	return &ExternalIdentityMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IExternalIdentityRepository = (*ExternalIdentityMySQLRepo)(nil)
+
+const externalIdentityColumns = `id, user_id, provider, subject, email, ngay_lien_ket, lan_dang_nhap_cuoi`
+
+// FindByProviderSubject tìm liên kết theo provider và subject
+func (r *ExternalIdentityMySQLRepo) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.ExternalIdentity, error) {
+	query := `SELECT ` + externalIdentityColumns + `
+			  FROM external_identities WHERE provider = ? AND subject = ?`
+
+	identity, err := scanExternalIdentity(executor(ctx, r.db).QueryRowContext(ctx, query, provider, subject))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	return identity, err
+}
+
+// FindByUserID lấy các tài khoản bên ngoài đã liên kết với user
+func (r *ExternalIdentityMySQLRepo) FindByUserID(ctx context.Context, userID string) ([]*entity.ExternalIdentity, error) {
+	query := `SELECT ` + externalIdentityColumns + `
+			  FROM external_identities WHERE user_id = ? ORDER BY ngay_lien_ket`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	identities := []*entity.ExternalIdentity{}
+	for rows.Next() {
+		identity, err := scanExternalIdentity(rows)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+
+	return identities, rows.Err()
+}
+
+// Create tạo liên kết mới, trả lỗi nếu trùng unique constraint
+func (r *ExternalIdentityMySQLRepo) Create(ctx context.Context, identity *entity.ExternalIdentity) error {
+	query := `INSERT INTO external_identities (` + externalIdentityColumns + `)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		identity.ID, identity.UserID, identity.Provider, identity.Subject, identity.Email,
+		identity.NgayLienKet, identity.LanDangNhapCuoi,
+	)
+	if err != nil {
+		var mysqlErr *mysqldriver.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return repository.ErrDuplicateEntry
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete hủy liên kết provider của user
+func (r *ExternalIdentityMySQLRepo) Delete(ctx context.Context, userID, provider string) (bool, error) {
+	result, err := executor(ctx, r.db).ExecContext(ctx,
+		`DELETE FROM external_identities WHERE user_id = ? AND provider = ?`, userID, provider,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// GhiNhanDangNhap cập nhật thời điểm đăng nhập gần nhất
+func (r *ExternalIdentityMySQLRepo) GhiNhanDangNhap(ctx context.Context, id string, thoiDiem time.Time) error {
+	_, err := executor(ctx, r.db).ExecContext(ctx,
+		`UPDATE external_identities SET lan_dang_nhap_cuoi = ? WHERE id = ?`, thoiDiem, id,
+	)
+	return err
+}
+
+// scanExternalIdentity đọc một dòng external_identities
+func scanExternalIdentity(row interface{ Scan(dest ...any) error }) (*entity.ExternalIdentity, error) {
+	identity := &entity.ExternalIdentity{}
+	var lanDangNhapCuoi sql.NullTime
+
+	err := row.Scan(
+		&identity.ID, &identity.UserID, &identity.Provider, &identity.Subject, &identity.Email,
+		&identity.NgayLienKet, &lanDangNhapCuoi,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lanDangNhapCuoi.Valid {
+		identity.LanDangNhapCuoi = &lanDangNhapCuoi.Time
+	}
+	return identity, nil
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
 
 	"restaurant_project/internal/domain/entity"
 	"restaurant_project/internal/domain/repository"
@@ -27,13 +30,13 @@ var _ repository.IKhachHangRepository = (*KhachHangMySQLRepo)(nil)
 func (r *KhachHangMySQLRepo) FindByID(ctx context.Context, id string) (*entity.KhachHang, error) {
 	query := `SELECT id, user_id, ho_ten, so_dien_thoai, email, dia_chi,
 			  diem_tich_luy, cap_thanh_vien, ngay_tao, ngay_cap_nhat
-			  FROM khach_hang WHERE id = ?`
+			  FROM khach_hang WHERE id = ? AND ngay_xoa IS NULL`
 
 	kh := &entity.KhachHang{}
 	var userID sql.NullString
 	var email, diaChi sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&kh.ID, &userID, &kh.HoTen, &kh.SoDienThoai, &email, &diaChi,
 		&kh.DiemTichLuy, &kh.CapThanhVien, &kh.NgayTao, &kh.NgayCapNhat,
 	)
@@ -62,13 +65,13 @@ func (r *KhachHangMySQLRepo) FindByID(ctx context.Context, id string) (*entity.K
 func (r *KhachHangMySQLRepo) FindByUserID(ctx context.Context, userID string) (*entity.KhachHang, error) {
 	query := `SELECT id, user_id, ho_ten, so_dien_thoai, email, dia_chi,
 			  diem_tich_luy, cap_thanh_vien, ngay_tao, ngay_cap_nhat
-			  FROM khach_hang WHERE user_id = ?`
+			  FROM khach_hang WHERE user_id = ? AND ngay_xoa IS NULL`
 
 	kh := &entity.KhachHang{}
 	var uid sql.NullString
 	var email, diaChi sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
 		&kh.ID, &uid, &kh.HoTen, &kh.SoDienThoai, &email, &diaChi,
 		&kh.DiemTichLuy, &kh.CapThanhVien, &kh.NgayTao, &kh.NgayCapNhat,
 	)
@@ -97,13 +100,13 @@ func (r *KhachHangMySQLRepo) FindByUserID(ctx context.Context, userID string) (*
 func (r *KhachHangMySQLRepo) FindBySoDienThoai(ctx context.Context, soDienThoai string) (*entity.KhachHang, error) {
 	query := `SELECT id, user_id, ho_ten, so_dien_thoai, email, dia_chi,
 			  diem_tich_luy, cap_thanh_vien, ngay_tao, ngay_cap_nhat
-			  FROM khach_hang WHERE so_dien_thoai = ?`
+			  FROM khach_hang WHERE so_dien_thoai = ? AND ngay_xoa IS NULL`
 
 	kh := &entity.KhachHang{}
 	var userID sql.NullString
 	var email, diaChi sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, soDienThoai).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, soDienThoai).Scan(
 		&kh.ID, &userID, &kh.HoTen, &kh.SoDienThoai, &email, &diaChi,
 		&kh.DiemTichLuy, &kh.CapThanhVien, &kh.NgayTao, &kh.NgayCapNhat,
 	)
@@ -132,9 +135,9 @@ func (r *KhachHangMySQLRepo) FindBySoDienThoai(ctx context.Context, soDienThoai
 func (r *KhachHangMySQLRepo) FindAll(ctx context.Context) ([]*entity.KhachHang, error) {
 	query := `SELECT id, user_id, ho_ten, so_dien_thoai, email, dia_chi,
 			  diem_tich_luy, cap_thanh_vien, ngay_tao, ngay_cap_nhat
-			  FROM khach_hang ORDER BY ngay_tao DESC`
+			  FROM khach_hang WHERE ngay_xoa IS NULL ORDER BY ngay_tao DESC`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -174,9 +177,9 @@ func (r *KhachHangMySQLRepo) FindAll(ctx context.Context) ([]*entity.KhachHang,
 func (r *KhachHangMySQLRepo) FindByCapThanhVien(ctx context.Context, cap string) ([]*entity.KhachHang, error) {
 	query := `SELECT id, user_id, ho_ten, so_dien_thoai, email, dia_chi,
 			  diem_tich_luy, cap_thanh_vien, ngay_tao, ngay_cap_nhat
-			  FROM khach_hang WHERE cap_thanh_vien = ? ORDER BY diem_tich_luy DESC`
+			  FROM khach_hang WHERE cap_thanh_vien = ? AND ngay_xoa IS NULL ORDER BY diem_tich_luy DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, cap)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, cap)
 	if err != nil {
 		return nil, err
 	}
@@ -212,22 +215,47 @@ func (r *KhachHangMySQLRepo) FindByCapThanhVien(ctx context.Context, cap string)
 	return list, rows.Err()
 }
 
-// Save lưu khách hàng mới hoặc cập nhật
-func (r *KhachHangMySQLRepo) Save(ctx context.Context, kh *entity.KhachHang) error {
+// Create thêm khách hàng mới, ErrDuplicateEntry nếu số điện thoại đã thuộc khách hàng chưa xóa khác
+// INSERT thuần: không ON DUPLICATE KEY UPDATE để trùng số điện thoại không ghi đè khách hàng khác
+func (r *KhachHangMySQLRepo) Create(ctx context.Context, kh *entity.KhachHang) error {
 	query := `INSERT INTO khach_hang (id, user_id, ho_ten, so_dien_thoai, email, dia_chi,
 			  diem_tich_luy, cap_thanh_vien, ngay_tao, ngay_cap_nhat)
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-			  ON DUPLICATE KEY UPDATE
-			  user_id = VALUES(user_id),
-			  ho_ten = VALUES(ho_ten),
-			  so_dien_thoai = VALUES(so_dien_thoai),
-			  email = VALUES(email),
-			  dia_chi = VALUES(dia_chi),
-			  diem_tich_luy = VALUES(diem_tich_luy),
-			  cap_thanh_vien = VALUES(cap_thanh_vien),
-			  ngay_cap_nhat = VALUES(ngay_cap_nhat)`
-
-	var userID, email, diaChi interface{}
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	userID, email, diaChi := khachHangNullable(kh)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		kh.ID, userID, kh.HoTen, kh.SoDienThoai, email, diaChi,
+		kh.DiemTichLuy, kh.CapThanhVien, kh.NgayTao, kh.NgayCapNhat,
+	)
+
+	return loiTrungKhachHang(err)
+}
+
+// Save cập nhật khách hàng đã tồn tại (chưa xóa mềm) theo ID
+// ErrDuplicateEntry nếu số điện thoại mới đã thuộc khách hàng chưa xóa khác
+func (r *KhachHangMySQLRepo) Save(ctx context.Context, kh *entity.KhachHang) error {
+	query := `UPDATE khach_hang SET
+			  user_id = ?,
+			  ho_ten = ?,
+			  so_dien_thoai = ?,
+			  email = ?,
+			  dia_chi = ?,
+			  diem_tich_luy = ?,
+			  cap_thanh_vien = ?,
+			  ngay_cap_nhat = ?
+			  WHERE id = ? AND ngay_xoa IS NULL`
+
+	userID, email, diaChi := khachHangNullable(kh)
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		userID, kh.HoTen, kh.SoDienThoai, email, diaChi,
+		kh.DiemTichLuy, kh.CapThanhVien, kh.NgayCapNhat, kh.ID,
+	)
+
+	return loiTrungKhachHang(err)
+}
+
+// khachHangNullable chuyển các field tùy chọn rỗng thành NULL
+func khachHangNullable(kh *entity.KhachHang) (userID, email, diaChi interface{}) {
 	if kh.UserID != "" {
 		userID = kh.UserID
 	}
@@ -237,19 +265,23 @@ func (r *KhachHangMySQLRepo) Save(ctx context.Context, kh *entity.KhachHang) err
 	if kh.DiaChi != "" {
 		diaChi = kh.DiaChi
 	}
+	return userID, email, diaChi
+}
 
-	_, err := r.db.ExecContext(ctx, query,
-		kh.ID, userID, kh.HoTen, kh.SoDienThoai, email, diaChi,
-		kh.DiemTichLuy, kh.CapThanhVien, kh.NgayTao, kh.NgayCapNhat,
-	)
-
+// loiTrungKhachHang chuyển lỗi trùng unique key của MySQL thành ErrDuplicateEntry
+func loiTrungKhachHang(err error) error {
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		return repository.ErrDuplicateEntry
+	}
 	return err
 }
 
-// Delete xóa khách hàng theo ID
+// Delete xóa mềm khách hàng theo ID
 func (r *KhachHangMySQLRepo) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM khach_hang WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, id)
+	// ngay_xoa lấy giờ ứng dụng (không dùng NOW()) để so sánh nhất quán với mốc của PurgeDeleted
+	query := `UPDATE khach_hang SET ngay_xoa = ?, ngay_cap_nhat = NOW() WHERE id = ? AND ngay_xoa IS NULL`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
 		return err
 	}
@@ -266,10 +298,84 @@ func (r *KhachHangMySQLRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// FindDeleted lấy các khách hàng đã xóa mềm, mới xóa trước
+func (r *KhachHangMySQLRepo) FindDeleted(ctx context.Context) ([]*entity.KhachHang, error) {
+	query := `SELECT id, user_id, ho_ten, so_dien_thoai, email, dia_chi,
+			  diem_tich_luy, cap_thanh_vien, ngay_tao, ngay_cap_nhat, ngay_xoa
+			  FROM khach_hang WHERE ngay_xoa IS NOT NULL ORDER BY ngay_xoa DESC`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.KhachHang
+	for rows.Next() {
+		kh := &entity.KhachHang{}
+		var userID sql.NullString
+		var email, diaChi sql.NullString
+		var ngayXoa sql.NullTime
+
+		err := rows.Scan(
+			&kh.ID, &userID, &kh.HoTen, &kh.SoDienThoai, &email, &diaChi,
+			&kh.DiemTichLuy, &kh.CapThanhVien, &kh.NgayTao, &kh.NgayCapNhat, &ngayXoa,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if userID.Valid {
+			kh.UserID = userID.String
+		}
+		if email.Valid {
+			kh.Email = email.String
+		}
+		if diaChi.Valid {
+			kh.DiaChi = diaChi.String
+		}
+		if ngayXoa.Valid {
+			kh.NgayXoa = &ngayXoa.Time
+		}
+
+		list = append(list, kh)
+	}
+
+	return list, rows.Err()
+}
+
+// Restore khôi phục khách hàng đã xóa mềm
+// ErrDuplicateEntry nếu số điện thoại đã được khách hàng khác dùng sau khi xóa
+func (r *KhachHangMySQLRepo) Restore(ctx context.Context, id string) (bool, error) {
+	query := `UPDATE khach_hang SET ngay_xoa = NULL, ngay_cap_nhat = NOW() WHERE id = ? AND ngay_xoa IS NOT NULL`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return false, loiTrungKhachHang(err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// PurgeDeleted xóa vĩnh viễn các khách hàng đã xóa mềm trước thời điểm truoc
+func (r *KhachHangMySQLRepo) PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error) {
+	query := `DELETE FROM khach_hang WHERE ngay_xoa IS NOT NULL AND ngay_xoa < ?`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, truoc)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 // UpdateDiemTichLuy cập nhật điểm tích lũy (atomic operation)
 func (r *KhachHangMySQLRepo) UpdateDiemTichLuy(ctx context.Context, id string, diemMoi int64) error {
-	query := `UPDATE khach_hang SET diem_tich_luy = ?, ngay_cap_nhat = NOW() WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, diemMoi, id)
+	query := `UPDATE khach_hang SET diem_tich_luy = ?, ngay_cap_nhat = NOW() WHERE id = ? AND ngay_xoa IS NULL`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, diemMoi, id)
 	if err != nil {
 		return err
 	}
@@ -286,10 +392,45 @@ func (r *KhachHangMySQLRepo) UpdateDiemTichLuy(ctx context.Context, id string, d
 	return nil
 }
 
+// CongDiemTichLuy cộng dồn điểm tích lũy và cập nhật cấp thành viên theo tổng mới
+// UPDATE cộng dồn giữ khóa dòng tới hết transaction nên hai lần cộng đồng thời không ghi đè nhau
+func (r *KhachHangMySQLRepo) CongDiemTichLuy(ctx context.Context, id string, diem int64) (*entity.KhachHang, error) {
+	query := `UPDATE khach_hang SET diem_tich_luy = diem_tich_luy + ?, ngay_cap_nhat = NOW()
+			  WHERE id = ? AND ngay_xoa IS NULL`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, diem, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if rowsAffected == 0 {
+		return nil, nil
+	}
+
+	kh, err := r.FindByID(ctx, id)
+	if err != nil || kh == nil {
+		return kh, err
+	}
+
+	capMoi := entity.CapThanhVienTheoDiem(kh.DiemTichLuy)
+	if capMoi != kh.CapThanhVien {
+		query = `UPDATE khach_hang SET cap_thanh_vien = ? WHERE id = ?`
+		if _, err := executor(ctx, r.db).ExecContext(ctx, query, capMoi, id); err != nil {
+			return nil, err
+		}
+		kh.CapThanhVien = capMoi
+	}
+
+	return kh, nil
+}
+
 // Count đếm tổng số khách hàng
 func (r *KhachHangMySQLRepo) Count(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM khach_hang`
+	query := `SELECT COUNT(*) FROM khach_hang WHERE ngay_xoa IS NULL`
 	var count int64
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query).Scan(&count)
 	return count, err
 }
@@ -0,0 +1,274 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// MaGiamGiaMySQLRepo là implementation của IMaGiamGiaRepository sử dụng MySQL
+type MaGiamGiaMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewMaGiamGiaMySQLRepo tạo mới MaGiamGiaMySQLRepo
+func NewMaGiamGiaMySQLRepo(db *sql.DB) *MaGiamGiaMySQLRepo {
+	return &MaGiamGiaMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IMaGiamGiaRepository = (*MaGiamGiaMySQLRepo)(nil)
+
+const selectMaGiamGia = `SELECT id, ma, chien_dich, loai, gia_tri, giam_toi_da, don_toi_thieu,
+			  so_lan_toi_da, so_lan_moi_khach, so_lan_da_dung, het_han, dang_hoat_dong,
+			  ngay_tao, ngay_cap_nhat
+			  FROM ma_giam_gia`
+
+// scanMaGiamGia đọc một dòng ma_giam_gia (xử lý các cột nullable)
+func scanMaGiamGia(scanner interface{ Scan(...any) error }) (*entity.MaGiamGia, error) {
+	m := &entity.MaGiamGia{}
+	var hetHan sql.NullTime
+
+	err := scanner.Scan(
+		&m.ID, &m.Ma, &m.ChienDich, &m.Loai, &m.GiaTri, &m.GiamToiDa, &m.DonToiThieu,
+		&m.SoLanToiDa, &m.SoLanMoiKhach, &m.SoLanDaDung, &hetHan, &m.DangHoatDong,
+		&m.NgayTao, &m.NgayCapNhat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if hetHan.Valid {
+		m.HetHan = &hetHan.Time
+	}
+
+	return m, nil
+}
+
+// SaveBatch lưu nhiều mã mới trong một transaction
+func (r *MaGiamGiaMySQLRepo) SaveBatch(ctx context.Context, list []*entity.MaGiamGia) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO ma_giam_gia (id, ma, chien_dich, loai, gia_tri, giam_toi_da, don_toi_thieu,
+			  so_lan_toi_da, so_lan_moi_khach, so_lan_da_dung, het_han, dang_hoat_dong, ngay_tao, ngay_cap_nhat)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, m := range list {
+		_, err := stmt.ExecContext(ctx,
+			m.ID, m.Ma, m.ChienDich, m.Loai, m.GiaTri, m.GiamToiDa, m.DonToiThieu,
+			m.SoLanToiDa, m.SoLanMoiKhach, m.SoLanDaDung, m.HetHan, m.DangHoatDong, m.NgayTao, m.NgayCapNhat,
+		)
+		if err != nil {
+			var mysqlErr *mysqldriver.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+				return repository.ErrDuplicateEntry
+			}
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// FindByMa tìm mã giảm giá theo mã
+func (r *MaGiamGiaMySQLRepo) FindByMa(ctx context.Context, ma string) (*entity.MaGiamGia, error) {
+	m, err := scanMaGiamGia(executor(ctx, r.db).QueryRowContext(ctx, selectMaGiamGia+` WHERE ma = ?`, ma))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// FindByChienDich lấy các mã của một chiến dịch
+func (r *MaGiamGiaMySQLRepo) FindByChienDich(ctx context.Context, chienDich string) ([]*entity.MaGiamGia, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, selectMaGiamGia+` WHERE chien_dich = ? ORDER BY ma`, chienDich)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.MaGiamGia
+	for rows.Next() {
+		m, err := scanMaGiamGia(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, m)
+	}
+
+	return list, rows.Err()
+}
+
+// DungMa khóa mã, kiểm tra và ghi lượt dùng trong cùng một transaction
+// SELECT ... FOR UPDATE đảm bảo hai đơn dùng cùng mã được xử lý tuần tự,
+// nên không thể vượt quá giới hạn lượt dù có nhiều request đồng thời
+func (r *MaGiamGiaMySQLRepo) DungMa(
+	ctx context.Context,
+	ma, khachHangID string,
+	kiemTra repository.KiemTraDungMaFunc,
+) (*entity.SuDungMaGiamGia, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	m, err := scanMaGiamGia(tx.QueryRowContext(ctx, selectMaGiamGia+` WHERE ma = ? FOR UPDATE`, ma))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var soLanKhachDaDung int
+	if khachHangID != "" {
+		err := tx.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM su_dung_ma_giam_gia
+			 WHERE ma_giam_gia_id = ? AND khach_hang_id = ? AND trang_thai = ?`,
+			m.ID, khachHangID, entity.SuDungMaDaDung,
+		).Scan(&soLanKhachDaDung)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	suDung, err := kiemTra(m, soLanKhachDaDung)
+	if err != nil {
+		return nil, err
+	}
+
+	var khachHang interface{}
+	if suDung.KhachHangID != "" {
+		khachHang = suDung.KhachHangID
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO su_dung_ma_giam_gia (id, ma_giam_gia_id, order_id, khach_hang_id, so_tien_giam, trang_thai, ngay_dung)
+		 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		suDung.ID, m.ID, suDung.OrderID, khachHang, suDung.SoTienGiam, suDung.TrangThai, suDung.NgayDung,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE ma_giam_gia SET so_lan_da_dung = so_lan_da_dung + 1 WHERE id = ?`, m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return suDung, nil
+}
+
+// HoanMaTheoOrder hoàn các lượt dùng mã của một đơn hàng
+func (r *MaGiamGiaMySQLRepo) HoanMaTheoOrder(ctx context.Context, orderID string) (int, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, ma_giam_gia_id FROM su_dung_ma_giam_gia
+		 WHERE order_id = ? AND trang_thai = ? FOR UPDATE`,
+		orderID, entity.SuDungMaDaDung,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	type luotDung struct{ id, maGiamGiaID string }
+	var dsLuot []luotDung
+	for rows.Next() {
+		var l luotDung
+		if err := rows.Scan(&l.id, &l.maGiamGiaID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		dsLuot = append(dsLuot, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	for _, l := range dsLuot {
+		_, err := tx.ExecContext(ctx,
+			`UPDATE su_dung_ma_giam_gia SET trang_thai = ?, ngay_hoan = ? WHERE id = ?`,
+			entity.SuDungMaDaHoan, now, l.id,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			`UPDATE ma_giam_gia SET so_lan_da_dung = GREATEST(so_lan_da_dung - 1, 0) WHERE id = ?`,
+			l.maGiamGiaID,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(dsLuot), nil
+}
+
+// ThongKe tính số liệu sử dụng mã của một chiến dịch
+func (r *MaGiamGiaMySQLRepo) ThongKe(ctx context.Context, chienDich string) (*entity.ThongKeMaGiamGia, error) {
+	tk := &entity.ThongKeMaGiamGia{ChienDich: chienDich}
+
+	err := executor(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COUNT(*), COALESCE(SUM(so_lan_da_dung > 0), 0)
+		 FROM ma_giam_gia WHERE chien_dich = ?`,
+		chienDich,
+	).Scan(&tk.SoMa, &tk.SoMaDaDung)
+	if err != nil {
+		return nil, err
+	}
+
+	err = executor(ctx, r.db).QueryRowContext(ctx,
+		`SELECT COALESCE(SUM(s.trang_thai = ?), 0),
+		        COALESCE(SUM(s.trang_thai = ?), 0),
+		        COALESCE(SUM(CASE WHEN s.trang_thai = ? THEN s.so_tien_giam ELSE 0 END), 0)
+		 FROM su_dung_ma_giam_gia s
+		 JOIN ma_giam_gia m ON m.id = s.ma_giam_gia_id
+		 WHERE m.chien_dich = ?`,
+		entity.SuDungMaDaDung, entity.SuDungMaDaHoan, entity.SuDungMaDaDung, chienDich,
+	).Scan(&tk.SoLuotDung, &tk.SoLuotHoan, &tk.TongTienGiam)
+	if err != nil {
+		return nil, err
+	}
+
+	return tk, nil
+}
@@ -0,0 +1,166 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// NguyenLieuMySQLRepo là implementation của INguyenLieuRepository sử dụng MySQL
+type NguyenLieuMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewNguyenLieuMySQLRepo tạo mới NguyenLieuMySQLRepo
+func NewNguyenLieuMySQLRepo(db *sql.DB) *NguyenLieuMySQLRepo {
+	return &NguyenLieuMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.INguyenLieuRepository = (*NguyenLieuMySQLRepo)(nil)
+
+// FindByID tìm nguyên liệu theo ID
+func (r *NguyenLieuMySQLRepo) FindByID(ctx context.Context, id string) (*entity.NguyenLieu, error) {
+	return r.findOne(ctx, `SELECT id, ten, don_vi_tinh, so_luong_ton, muc_toi_thieu, gia_von_binh_quan, ngay_tao, ngay_cap_nhat
+			  FROM nguyen_lieu WHERE id = ?`, id)
+}
+
+// FindByIDForUpdate tìm nguyên liệu và khóa dòng tới hết transaction của UnitOfWork
+// Nhập kho đồng thời vào cùng nguyên liệu được xử lý tuần tự, không mất tồn kho / giá vốn
+func (r *NguyenLieuMySQLRepo) FindByIDForUpdate(ctx context.Context, id string) (*entity.NguyenLieu, error) {
+	return r.findOne(ctx, `SELECT id, ten, don_vi_tinh, so_luong_ton, muc_toi_thieu, gia_von_binh_quan, ngay_tao, ngay_cap_nhat
+			  FROM nguyen_lieu WHERE id = ? FOR UPDATE`, id)
+}
+
+// findOne chạy câu truy vấn một nguyên liệu
+func (r *NguyenLieuMySQLRepo) findOne(ctx context.Context, query string, id string) (*entity.NguyenLieu, error) {
+	nl := &entity.NguyenLieu{}
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
+		&nl.ID, &nl.Ten, &nl.DonViTinh, &nl.SoLuongTon, &nl.MucToiThieu,
+		&nl.GiaVonBinhQuan, &nl.NgayTao, &nl.NgayCapNhat,
+	)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nl, nil
+}
+
+// FindAll lấy tất cả nguyên liệu
+func (r *NguyenLieuMySQLRepo) FindAll(ctx context.Context) ([]*entity.NguyenLieu, error) {
+	query := `SELECT id, ten, don_vi_tinh, so_luong_ton, muc_toi_thieu, gia_von_binh_quan, ngay_tao, ngay_cap_nhat
+			  FROM nguyen_lieu ORDER BY ten`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.NguyenLieu
+	for rows.Next() {
+		nl := &entity.NguyenLieu{}
+		err := rows.Scan(
+			&nl.ID, &nl.Ten, &nl.DonViTinh, &nl.SoLuongTon, &nl.MucToiThieu,
+			&nl.GiaVonBinhQuan, &nl.NgayTao, &nl.NgayCapNhat,
+		)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, nl)
+	}
+
+	return list, rows.Err()
+}
+
+// Save lưu nguyên liệu mới hoặc cập nhật
+func (r *NguyenLieuMySQLRepo) Save(ctx context.Context, nl *entity.NguyenLieu) error {
+	query := `INSERT INTO nguyen_lieu (id, ten, don_vi_tinh, so_luong_ton, muc_toi_thieu, gia_von_binh_quan, ngay_tao, ngay_cap_nhat)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE
+			  ten = VALUES(ten),
+			  don_vi_tinh = VALUES(don_vi_tinh),
+			  so_luong_ton = VALUES(so_luong_ton),
+			  muc_toi_thieu = VALUES(muc_toi_thieu),
+			  gia_von_binh_quan = VALUES(gia_von_binh_quan),
+			  ngay_cap_nhat = VALUES(ngay_cap_nhat)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		nl.ID, nl.Ten, nl.DonViTinh, nl.SoLuongTon, nl.MucToiThieu,
+		nl.GiaVonBinhQuan, nl.NgayTao, nl.NgayCapNhat,
+	)
+
+	return err
+}
+
+// Delete xóa nguyên liệu theo ID
+func (r *NguyenLieuMySQLRepo) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM nguyen_lieu WHERE id = ?`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("không tìm thấy nguyên liệu để xóa")
+	}
+
+	return nil
+}
+
+// ThemBienDong ghi lại một biến động kho
+func (r *NguyenLieuMySQLRepo) ThemBienDong(ctx context.Context, bd *entity.BienDongKho) error {
+	query := `INSERT INTO bien_dong_kho (id, nguyen_lieu_id, loai, so_luong, don_gia, tham_chieu, ngay_tao)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)`
+
+	var thamChieu interface{}
+	if bd.ThamChieu != "" {
+		thamChieu = bd.ThamChieu
+	}
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		bd.ID, bd.NguyenLieuID, bd.Loai, bd.SoLuong, bd.DonGia, thamChieu, bd.NgayTao,
+	)
+
+	return err
+}
+
+// TongTieuHao tính tổng tiêu hao theo từng nguyên liệu trong khoảng thời gian
+func (r *NguyenLieuMySQLRepo) TongTieuHao(ctx context.Context, from, to time.Time) (map[string]float64, error) {
+	query := `SELECT nguyen_lieu_id, -SUM(so_luong)
+			  FROM bien_dong_kho
+			  WHERE loai = ? AND ngay_tao BETWEEN ? AND ?
+			  GROUP BY nguyen_lieu_id`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, entity.BienDongTieuHao, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]float64)
+	for rows.Next() {
+		var id string
+		var tong float64
+		if err := rows.Scan(&id, &tong); err != nil {
+			return nil, err
+		}
+		result[id] = tong
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,139 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// NhaCungCapMySQLRepo là implementation của INhaCungCapRepository sử dụng MySQL
+type NhaCungCapMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewNhaCungCapMySQLRepo tạo mới NhaCungCapMySQLRepo
+func NewNhaCungCapMySQLRepo(db *sql.DB) *NhaCungCapMySQLRepo {
+	return &NhaCungCapMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.INhaCungCapRepository = (*NhaCungCapMySQLRepo)(nil)
+
+// scanNhaCungCap đọc một dòng nha_cung_cap (xử lý các cột nullable)
+func scanNhaCungCap(scanner interface{ Scan(...any) error }) (*entity.NhaCungCap, error) {
+	ncc := &entity.NhaCungCap{}
+	var nguoiLienHe, email, diaChi sql.NullString
+
+	err := scanner.Scan(
+		&ncc.ID, &ncc.Ten, &nguoiLienHe, &ncc.SoDienThoai, &email, &diaChi,
+		&ncc.SoNgayGiao, &ncc.DangHopTac, &ncc.NgayTao, &ncc.NgayCapNhat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ncc.NguoiLienHe = nguoiLienHe.String
+	ncc.Email = email.String
+	ncc.DiaChi = diaChi.String
+
+	return ncc, nil
+}
+
+// FindByID tìm nhà cung cấp theo ID
+func (r *NhaCungCapMySQLRepo) FindByID(ctx context.Context, id string) (*entity.NhaCungCap, error) {
+	query := `SELECT id, ten, nguoi_lien_he, so_dien_thoai, email, dia_chi,
+			  so_ngay_giao, dang_hop_tac, ngay_tao, ngay_cap_nhat
+			  FROM nha_cung_cap WHERE id = ?`
+
+	ncc, err := scanNhaCungCap(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ncc, nil
+}
+
+// FindAll lấy tất cả nhà cung cấp
+func (r *NhaCungCapMySQLRepo) FindAll(ctx context.Context) ([]*entity.NhaCungCap, error) {
+	query := `SELECT id, ten, nguoi_lien_he, so_dien_thoai, email, dia_chi,
+			  so_ngay_giao, dang_hop_tac, ngay_tao, ngay_cap_nhat
+			  FROM nha_cung_cap ORDER BY ten`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.NhaCungCap
+	for rows.Next() {
+		ncc, err := scanNhaCungCap(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ncc)
+	}
+
+	return list, rows.Err()
+}
+
+// Save lưu nhà cung cấp mới hoặc cập nhật
+func (r *NhaCungCapMySQLRepo) Save(ctx context.Context, ncc *entity.NhaCungCap) error {
+	query := `INSERT INTO nha_cung_cap (id, ten, nguoi_lien_he, so_dien_thoai, email, dia_chi,
+			  so_ngay_giao, dang_hop_tac, ngay_tao, ngay_cap_nhat)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE
+			  ten = VALUES(ten),
+			  nguoi_lien_he = VALUES(nguoi_lien_he),
+			  so_dien_thoai = VALUES(so_dien_thoai),
+			  email = VALUES(email),
+			  dia_chi = VALUES(dia_chi),
+			  so_ngay_giao = VALUES(so_ngay_giao),
+			  dang_hop_tac = VALUES(dang_hop_tac),
+			  ngay_cap_nhat = VALUES(ngay_cap_nhat)`
+
+	var nguoiLienHe, email, diaChi interface{}
+	if ncc.NguoiLienHe != "" {
+		nguoiLienHe = ncc.NguoiLienHe
+	}
+	if ncc.Email != "" {
+		email = ncc.Email
+	}
+	if ncc.DiaChi != "" {
+		diaChi = ncc.DiaChi
+	}
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		ncc.ID, ncc.Ten, nguoiLienHe, ncc.SoDienThoai, email, diaChi,
+		ncc.SoNgayGiao, ncc.DangHopTac, ncc.NgayTao, ncc.NgayCapNhat,
+	)
+
+	return err
+}
+
+// Delete xóa nhà cung cấp theo ID
+func (r *NhaCungCapMySQLRepo) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM nha_cung_cap WHERE id = ?`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("không tìm thấy nhà cung cấp để xóa")
+	}
+
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
 
 	"restaurant_project/internal/domain/entity"
 	"restaurant_project/internal/domain/repository"
@@ -27,12 +30,12 @@ var _ repository.INhanVienRepository = (*NhanVienMySQLRepo)(nil)
 func (r *NhanVienMySQLRepo) FindByID(ctx context.Context, id string) (*entity.NhanVien, error) {
 	query := `SELECT id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
 			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat
-			  FROM nhan_vien WHERE id = ?`
+			  FROM nhan_vien WHERE id = ? AND ngay_xoa IS NULL`
 
 	nv := &entity.NhanVien{}
 	var email sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&nv.ID, &nv.UserID, &nv.HoTen, &nv.ChucVu, &nv.SoDienThoai, &email,
 		&nv.TrangThai, &nv.LuongCoBan, &nv.NgayVaoLam, &nv.NgayTao, &nv.NgayCapNhat,
 	)
@@ -55,12 +58,12 @@ func (r *NhanVienMySQLRepo) FindByID(ctx context.Context, id string) (*entity.Nh
 func (r *NhanVienMySQLRepo) FindByUserID(ctx context.Context, userID string) (*entity.NhanVien, error) {
 	query := `SELECT id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
 			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat
-			  FROM nhan_vien WHERE user_id = ?`
+			  FROM nhan_vien WHERE user_id = ? AND ngay_xoa IS NULL`
 
 	nv := &entity.NhanVien{}
 	var email sql.NullString
 
-	err := r.db.QueryRowContext(ctx, query, userID).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
 		&nv.ID, &nv.UserID, &nv.HoTen, &nv.ChucVu, &nv.SoDienThoai, &email,
 		&nv.TrangThai, &nv.LuongCoBan, &nv.NgayVaoLam, &nv.NgayTao, &nv.NgayCapNhat,
 	)
@@ -83,9 +86,9 @@ func (r *NhanVienMySQLRepo) FindByUserID(ctx context.Context, userID string) (*e
 func (r *NhanVienMySQLRepo) FindAll(ctx context.Context) ([]*entity.NhanVien, error) {
 	query := `SELECT id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
 			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat
-			  FROM nhan_vien ORDER BY ngay_vao_lam DESC`
+			  FROM nhan_vien WHERE ngay_xoa IS NULL ORDER BY ngay_vao_lam DESC`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -118,9 +121,9 @@ func (r *NhanVienMySQLRepo) FindAll(ctx context.Context) ([]*entity.NhanVien, er
 func (r *NhanVienMySQLRepo) FindByChucVu(ctx context.Context, chucVu entity.ChucVu) ([]*entity.NhanVien, error) {
 	query := `SELECT id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
 			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat
-			  FROM nhan_vien WHERE chuc_vu = ? ORDER BY ho_ten`
+			  FROM nhan_vien WHERE chuc_vu = ? AND ngay_xoa IS NULL ORDER BY ho_ten`
 
-	rows, err := r.db.QueryContext(ctx, query, chucVu)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, chucVu)
 	if err != nil {
 		return nil, err
 	}
@@ -153,9 +156,9 @@ func (r *NhanVienMySQLRepo) FindByChucVu(ctx context.Context, chucVu entity.Chuc
 func (r *NhanVienMySQLRepo) FindByTrangThai(ctx context.Context, trangThai entity.TrangThaiLamViec) ([]*entity.NhanVien, error) {
 	query := `SELECT id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
 			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat
-			  FROM nhan_vien WHERE trang_thai = ? ORDER BY ho_ten`
+			  FROM nhan_vien WHERE trang_thai = ? AND ngay_xoa IS NULL ORDER BY ho_ten`
 
-	rows, err := r.db.QueryContext(ctx, query, trangThai)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, trangThai)
 	if err != nil {
 		return nil, err
 	}
@@ -188,9 +191,9 @@ func (r *NhanVienMySQLRepo) FindByTrangThai(ctx context.Context, trangThai entit
 func (r *NhanVienMySQLRepo) FindDauBepRanh(ctx context.Context) ([]*entity.NhanVien, error) {
 	query := `SELECT id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
 			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat
-			  FROM nhan_vien WHERE chuc_vu = ? AND trang_thai = ? ORDER BY ho_ten`
+			  FROM nhan_vien WHERE chuc_vu = ? AND trang_thai = ? AND ngay_xoa IS NULL ORDER BY ho_ten`
 
-	rows, err := r.db.QueryContext(ctx, query, entity.ChucVuBep, entity.TrangThaiRanh)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, entity.ChucVuBep, entity.TrangThaiRanh)
 	if err != nil {
 		return nil, err
 	}
@@ -219,6 +222,41 @@ func (r *NhanVienMySQLRepo) FindDauBepRanh(ctx context.Context) ([]*entity.NhanV
 	return list, rows.Err()
 }
 
+// Create thêm nhân viên mới
+// INSERT thuần (không ON DUPLICATE KEY UPDATE): trùng số điện thoại phải báo lỗi chứ không ghi đè nhân viên khác
+func (r *NhanVienMySQLRepo) Create(ctx context.Context, nv *entity.NhanVien) error {
+	query := `INSERT INTO nhan_vien (id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
+			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	var email interface{}
+	if nv.Email != "" {
+		email = nv.Email
+	}
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		nv.ID, nv.UserID, nv.HoTen, nv.ChucVu, nv.SoDienThoai, email,
+		nv.TrangThai, nv.LuongCoBan, nv.NgayVaoLam, nv.NgayTao, nv.NgayCapNhat,
+	)
+	if err != nil {
+		var mysqlErr *mysqldriver.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return repository.ErrDuplicateEntry
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ExistsBySoDienThoai kiểm tra số điện thoại đã thuộc nhân viên chưa xóa mềm nào chưa
+func (r *NhanVienMySQLRepo) ExistsBySoDienThoai(ctx context.Context, soDienThoai string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM nhan_vien WHERE so_dien_thoai = ? AND ngay_xoa IS NULL)`
+	var exists bool
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, soDienThoai).Scan(&exists)
+	return exists, err
+}
+
 // Save lưu nhân viên mới hoặc cập nhật
 func (r *NhanVienMySQLRepo) Save(ctx context.Context, nv *entity.NhanVien) error {
 	query := `INSERT INTO nhan_vien (id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
@@ -239,7 +277,7 @@ func (r *NhanVienMySQLRepo) Save(ctx context.Context, nv *entity.NhanVien) error
 		email = nv.Email
 	}
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
 		nv.ID, nv.UserID, nv.HoTen, nv.ChucVu, nv.SoDienThoai, email,
 		nv.TrangThai, nv.LuongCoBan, nv.NgayVaoLam, nv.NgayTao, nv.NgayCapNhat,
 	)
@@ -247,10 +285,11 @@ func (r *NhanVienMySQLRepo) Save(ctx context.Context, nv *entity.NhanVien) error
 	return err
 }
 
-// Delete xóa nhân viên theo ID
+// Delete xóa mềm nhân viên theo ID
 func (r *NhanVienMySQLRepo) Delete(ctx context.Context, id string) error {
-	query := `DELETE FROM nhan_vien WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, id)
+	// ngay_xoa lấy giờ ứng dụng (không dùng NOW()) để so sánh nhất quán với mốc của PurgeDeleted
+	query := `UPDATE nhan_vien SET ngay_xoa = ?, ngay_cap_nhat = NOW() WHERE id = ? AND ngay_xoa IS NULL`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, time.Now(), id)
 	if err != nil {
 		return err
 	}
@@ -267,10 +306,80 @@ func (r *NhanVienMySQLRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+// FindDeleted lấy các nhân viên đã xóa mềm, mới xóa trước
+func (r *NhanVienMySQLRepo) FindDeleted(ctx context.Context) ([]*entity.NhanVien, error) {
+	query := `SELECT id, user_id, ho_ten, chuc_vu, so_dien_thoai, email,
+			  trang_thai, luong_co_ban, ngay_vao_lam, ngay_tao, ngay_cap_nhat, ngay_xoa
+			  FROM nhan_vien WHERE ngay_xoa IS NOT NULL ORDER BY ngay_xoa DESC`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.NhanVien
+	for rows.Next() {
+		nv := &entity.NhanVien{}
+		var email sql.NullString
+		var ngayXoa sql.NullTime
+
+		err := rows.Scan(
+			&nv.ID, &nv.UserID, &nv.HoTen, &nv.ChucVu, &nv.SoDienThoai, &email,
+			&nv.TrangThai, &nv.LuongCoBan, &nv.NgayVaoLam, &nv.NgayTao, &nv.NgayCapNhat, &ngayXoa,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if email.Valid {
+			nv.Email = email.String
+		}
+		if ngayXoa.Valid {
+			nv.NgayXoa = &ngayXoa.Time
+		}
+
+		list = append(list, nv)
+	}
+
+	return list, rows.Err()
+}
+
+// Restore khôi phục nhân viên đã xóa mềm
+func (r *NhanVienMySQLRepo) Restore(ctx context.Context, id string) (bool, error) {
+	query := `UPDATE nhan_vien SET ngay_xoa = NULL, ngay_cap_nhat = NOW() WHERE id = ? AND ngay_xoa IS NOT NULL`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		var mysqlErr *mysqldriver.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return false, repository.ErrDuplicateEntry
+		}
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// PurgeDeleted xóa vĩnh viễn các nhân viên đã xóa mềm trước thời điểm truoc
+func (r *NhanVienMySQLRepo) PurgeDeleted(ctx context.Context, truoc time.Time) (int64, error) {
+	query := `DELETE FROM nhan_vien WHERE ngay_xoa IS NOT NULL AND ngay_xoa < ?`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, truoc)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
 // UpdateTrangThai cập nhật trạng thái làm việc
 func (r *NhanVienMySQLRepo) UpdateTrangThai(ctx context.Context, id string, trangThai entity.TrangThaiLamViec) error {
-	query := `UPDATE nhan_vien SET trang_thai = ?, ngay_cap_nhat = NOW() WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, trangThai, id)
+	query := `UPDATE nhan_vien SET trang_thai = ?, ngay_cap_nhat = NOW() WHERE id = ? AND ngay_xoa IS NULL`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, trangThai, id)
 	if err != nil {
 		return err
 	}
@@ -289,8 +398,8 @@ func (r *NhanVienMySQLRepo) UpdateTrangThai(ctx context.Context, id string, tran
 
 // Count đếm tổng số nhân viên
 func (r *NhanVienMySQLRepo) Count(ctx context.Context) (int64, error) {
-	query := `SELECT COUNT(*) FROM nhan_vien`
+	query := `SELECT COUNT(*) FROM nhan_vien WHERE ngay_xoa IS NULL`
 	var count int64
-	err := r.db.QueryRowContext(ctx, query).Scan(&count)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query).Scan(&count)
 	return count, err
 }
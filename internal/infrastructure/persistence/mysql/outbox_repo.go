@@ -0,0 +1,123 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// OutboxMySQLRepo là implementation của IOutboxRepository sử dụng MySQL
+// Add tham gia transaction của MySQLUnitOfWork qua ctx
+type OutboxMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewOutboxMySQLRepo tạo mới OutboxMySQLRepo
+func NewOutboxMySQLRepo(db *sql.DB) *OutboxMySQLRepo {
+	return &OutboxMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IOutboxRepository = (*OutboxMySQLRepo)(nil)
+
+// Add ghi sự kiện vào outbox
+func (r *OutboxMySQLRepo) Add(ctx context.Context, sk *entity.SuKien) error {
+	query := `INSERT INTO outbox (id, loai, doi_tuong_id, du_lieu, so_lan_thu, tao_luc, thu_lai_luc)
+			  VALUES (?, ?, ?, ?, 0, ?, ?)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
+		sk.ID, sk.Loai, sk.DoiTuongID, sk.DuLieu, sk.TaoLuc, sk.ThuLaiLuc,
+	)
+	return err
+}
+
+// FindDenHan lấy các sự kiện chưa phát đã tới hạn, cũ nhất trước
+func (r *OutboxMySQLRepo) FindDenHan(ctx context.Context, now time.Time, limit int) ([]*entity.SuKien, error) {
+	query := `SELECT id, loai, doi_tuong_id, du_lieu, so_lan_thu, loi_cuoi, tao_luc, thu_lai_luc
+			  FROM outbox
+			  WHERE da_phat_luc IS NULL AND thu_lai_luc <= ?
+			  ORDER BY tao_luc
+			  LIMIT ?`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.SuKien
+	for rows.Next() {
+		sk := &entity.SuKien{}
+		var loiCuoi sql.NullString
+		if err := rows.Scan(
+			&sk.ID, &sk.Loai, &sk.DoiTuongID, &sk.DuLieu, &sk.SoLanThu, &loiCuoi, &sk.TaoLuc, &sk.ThuLaiLuc,
+		); err != nil {
+			return nil, err
+		}
+		sk.LoiCuoi = loiCuoi.String
+		list = append(list, sk)
+	}
+
+	return list, rows.Err()
+}
+
+// GiuCho dời thu_lai_luc nếu sự kiện vẫn chưa phát và đã tới hạn (UPDATE có điều kiện)
+func (r *OutboxMySQLRepo) GiuCho(ctx context.Context, id string, now, den time.Time) (bool, error) {
+	query := `UPDATE outbox SET thu_lai_luc = ?
+			  WHERE id = ? AND da_phat_luc IS NULL AND thu_lai_luc <= ?`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, den, id, now)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+// DanhDauDaPhat đánh dấu sự kiện đã phát thành công
+func (r *OutboxMySQLRepo) DanhDauDaPhat(ctx context.Context, id string, luc time.Time) error {
+	query := `UPDATE outbox SET da_phat_luc = ? WHERE id = ?`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, luc, id)
+	return err
+}
+
+// DanhDauThatBai tăng số lần thử, ghi lỗi và hẹn lần phát tiếp theo
+func (r *OutboxMySQLRepo) DanhDauThatBai(ctx context.Context, id string, loi string, thuLaiLuc time.Time) error {
+	query := `UPDATE outbox SET so_lan_thu = so_lan_thu + 1, loi_cuoi = ?, thu_lai_luc = ?
+			  WHERE id = ? AND da_phat_luc IS NULL`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, loi, thuLaiLuc, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("không tìm thấy sự kiện chưa phát với ID: %s", id)
+	}
+	return nil
+}
+
+// PurgeDaPhat xóa các sự kiện đã phát trước thời điểm truoc
+func (r *OutboxMySQLRepo) PurgeDaPhat(ctx context.Context, truoc time.Time) (int64, error) {
+	query := `DELETE FROM outbox WHERE da_phat_luc IS NOT NULL AND da_phat_luc < ?`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, truoc)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
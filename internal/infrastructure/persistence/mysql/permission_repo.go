@@ -0,0 +1,113 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// PermissionMySQLRepo là implementation của IPermissionRepository sử dụng MySQL
+type PermissionMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewPermissionMySQLRepo tạo mới PermissionMySQLRepo
+func NewPermissionMySQLRepo(db *sql.DB) *PermissionMySQLRepo {
+	return &PermissionMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IPermissionRepository = (*PermissionMySQLRepo)(nil)
+
+// FindAll lấy danh mục permission
+func (r *PermissionMySQLRepo) FindAll(ctx context.Context) ([]*entity.Permission, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, `SELECT ma, mo_ta FROM permissions ORDER BY ma`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []*entity.Permission{}
+	for rows.Next() {
+		p := &entity.Permission{}
+		if err := rows.Scan(&p.Ma, &p.MoTa); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, p)
+	}
+
+	return permissions, rows.Err()
+}
+
+// FindByRole lấy các permission của role
+func (r *PermissionMySQLRepo) FindByRole(ctx context.Context, role entity.UserRole) ([]string, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx,
+		`SELECT permission FROM role_permissions WHERE role = ? ORDER BY permission`, string(role))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := []string{}
+	for rows.Next() {
+		var permission string
+		if err := rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+
+	return permissions, rows.Err()
+}
+
+// FindAllRolePermissions lấy quyền của mọi role
+func (r *PermissionMySQLRepo) FindAllRolePermissions(ctx context.Context) (map[entity.UserRole][]string, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, `SELECT role, permission FROM role_permissions ORDER BY role, permission`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[entity.UserRole][]string)
+	for rows.Next() {
+		var role, permission string
+		if err := rows.Scan(&role, &permission); err != nil {
+			return nil, err
+		}
+		result[entity.UserRole(role)] = append(result[entity.UserRole(role)], permission)
+	}
+
+	return result, rows.Err()
+}
+
+// SetRolePermissions xóa quyền cũ và ghi quyền mới của role trong một transaction
+func (r *PermissionMySQLRepo) SetRolePermissions(ctx context.Context, role entity.UserRole, permissions []string) error {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM role_permissions WHERE role = ?`, string(role)); err != nil {
+		return err
+	}
+
+	if len(permissions) > 0 {
+		stmt, err := tx.PrepareContext(ctx, `INSERT INTO role_permissions (role, permission) VALUES (?, ?)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, permission := range permissions {
+			if _, err := stmt.ExecContext(ctx, string(role), permission); err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
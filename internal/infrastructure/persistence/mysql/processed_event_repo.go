@@ -0,0 +1,53 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"restaurant_project/internal/domain/repository"
+)
+
+// ProcessedEventMySQLRepo là implementation của IProcessedEventRepository sử dụng MySQL
+// DanhDauDaXuLy tham gia transaction của MySQLUnitOfWork qua ctx
+type ProcessedEventMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewProcessedEventMySQLRepo tạo mới ProcessedEventMySQLRepo
+func NewProcessedEventMySQLRepo(db *sql.DB) *ProcessedEventMySQLRepo {
+	return &ProcessedEventMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IProcessedEventRepository = (*ProcessedEventMySQLRepo)(nil)
+
+// DanhDauDaXuLy ghi khóa (consumer, ID sự kiện); trùng UNIQUE key nghĩa là đã xử lý
+func (r *ProcessedEventMySQLRepo) DanhDauDaXuLy(ctx context.Context, consumer, suKienID string) (bool, error) {
+	query := `INSERT INTO processed_events (consumer, event_id, xu_ly_luc) VALUES (?, ?, ?)`
+
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, consumer, suKienID, time.Now())
+	if err != nil {
+		var mysqlErr *mysqldriver.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// PurgeTruoc xóa các khóa ghi trước thời điểm truoc
+func (r *ProcessedEventMySQLRepo) PurgeTruoc(ctx context.Context, truoc time.Time) (int64, error) {
+	query := `DELETE FROM processed_events WHERE xu_ly_luc < ?`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, truoc)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
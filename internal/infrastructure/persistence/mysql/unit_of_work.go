@@ -0,0 +1,111 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/infrastructure/database"
+)
+
+// txKey là key của *sql.Tx trong context.Context
+type txKey struct{}
+
+// sqlExecutor là phần chung của *sql.DB và *sql.Tx mà repository dùng để chạy query
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// executor trả về transaction của UnitOfWork nếu ctx đang mang một transaction, ngược lại trả về db
+func executor(ctx context.Context, db *sql.DB) sqlExecutor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// localTx là transaction repository tự mở cho thao tác nhiều câu lệnh
+// Nếu ctx đã mang transaction của UnitOfWork thì tham gia transaction đó:
+// Commit/Rollback khi ấy không làm gì, UnitOfWork quyết định cho cả khối
+type localTx struct {
+	*sql.Tx
+	owned bool
+}
+
+// beginTx mở transaction riêng hoặc tham gia transaction của UnitOfWork trong ctx
+func beginTx(ctx context.Context, db *sql.DB) (*localTx, error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return &localTx{Tx: tx}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &localTx{Tx: tx, owned: true}, nil
+}
+
+// Commit commit transaction nếu do repository tự mở
+func (t *localTx) Commit() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Commit()
+}
+
+// Rollback rollback transaction nếu do repository tự mở
+func (t *localTx) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+	return t.Tx.Rollback()
+}
+
+// MySQLUnitOfWork là implementation của IUnitOfWork dùng transaction MySQL
+// Mọi MySQL repository nhận ctx từ Do đều chạy trong cùng một *sql.Tx
+type MySQLUnitOfWork struct {
+	conn *database.MySQLConnection
+}
+
+// NewMySQLUnitOfWork tạo mới MySQLUnitOfWork
+func NewMySQLUnitOfWork(conn *database.MySQLConnection) *MySQLUnitOfWork {
+	return &MySQLUnitOfWork{conn: conn}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IUnitOfWork = (*MySQLUnitOfWork)(nil)
+
+// Do chạy fn trong một transaction MySQL
+func (u *MySQLUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Gọi lồng nhau: tham gia transaction bên ngoài
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := u.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("không thể bắt đầu transaction: %w", err)
+	}
+
+	committed := false
+	defer func() {
+		// fn lỗi hoặc panic: trả lại kết nối, không commit phần đã ghi
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("không thể commit transaction: %w", err)
+	}
+	committed = true
+	return nil
+}
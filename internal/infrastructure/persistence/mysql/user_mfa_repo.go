@@ -0,0 +1,148 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// UserMFAMySQLRepo là implementation của IUserMFARepository sử dụng MySQL
+type UserMFAMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewUserMFAMySQLRepo tạo mới UserMFAMySQLRepo
+func NewUserMFAMySQLRepo(db *sql.DB) *UserMFAMySQLRepo {
+	return &UserMFAMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IUserMFARepository = (*UserMFAMySQLRepo)(nil)
+
+// FindByUserID lấy cấu hình TOTP của user
+func (r *UserMFAMySQLRepo) FindByUserID(ctx context.Context, userID string) (*entity.UserMFA, error) {
+	query := `SELECT user_id, secret, da_kich_hoat, ma_khoi_phuc, buoc_cuoi, ngay_tao, ngay_kich_hoat
+			  FROM user_mfa WHERE user_id = ?`
+
+	m := &entity.UserMFA{}
+	var maKhoiPhuc []byte
+	var ngayKichHoat sql.NullTime
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, userID).Scan(
+		&m.UserID, &m.Secret, &m.DaKichHoat, &maKhoiPhuc, &m.BuocCuoi, &m.NgayTao, &ngayKichHoat,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(maKhoiPhuc, &m.MaKhoiPhuc); err != nil {
+		return nil, fmt.Errorf("invalid recovery codes: %w", err)
+	}
+	if ngayKichHoat.Valid {
+		m.NgayKichHoat = &ngayKichHoat.Time
+	}
+
+	return m, nil
+}
+
+// Save tạo mới hoặc ghi đè cấu hình TOTP của user
+func (r *UserMFAMySQLRepo) Save(ctx context.Context, m *entity.UserMFA) error {
+	maKhoiPhuc, err := json.Marshal(m.MaKhoiPhuc)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO user_mfa (user_id, secret, da_kich_hoat, ma_khoi_phuc, buoc_cuoi, ngay_tao, ngay_kich_hoat)
+			  VALUES (?, ?, ?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE
+			  secret = VALUES(secret),
+			  da_kich_hoat = VALUES(da_kich_hoat),
+			  ma_khoi_phuc = VALUES(ma_khoi_phuc),
+			  buoc_cuoi = VALUES(buoc_cuoi),
+			  ngay_tao = VALUES(ngay_tao),
+			  ngay_kich_hoat = VALUES(ngay_kich_hoat)`
+
+	_, err = executor(ctx, r.db).ExecContext(ctx, query,
+		m.UserID, m.Secret, m.DaKichHoat, maKhoiPhuc, m.BuocCuoi, m.NgayTao, m.NgayKichHoat,
+	)
+	return err
+}
+
+// Delete xóa cấu hình TOTP của user
+func (r *UserMFAMySQLRepo) Delete(ctx context.Context, userID string) error {
+	_, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM user_mfa WHERE user_id = ?`, userID)
+	return err
+}
+
+// GhiNhanBuoc cập nhật bước TOTP đã dùng bằng một UPDATE có điều kiện (atomic)
+func (r *UserMFAMySQLRepo) GhiNhanBuoc(ctx context.Context, userID string, buoc int64) (bool, error) {
+	result, err := executor(ctx, r.db).ExecContext(ctx,
+		`UPDATE user_mfa SET buoc_cuoi = ? WHERE user_id = ? AND buoc_cuoi < ?`,
+		buoc, userID, buoc,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+// DungMaKhoiPhuc xóa mã khôi phục khỏi danh sách trong transaction (khóa dòng bằng FOR UPDATE)
+func (r *UserMFAMySQLRepo) DungMaKhoiPhuc(ctx context.Context, userID, maHash string) (bool, error) {
+	tx, err := beginTx(ctx, r.db)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var raw []byte
+	err = tx.QueryRowContext(ctx,
+		`SELECT ma_khoi_phuc FROM user_mfa WHERE user_id = ? FOR UPDATE`, userID,
+	).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return false, fmt.Errorf("invalid recovery codes: %w", err)
+	}
+
+	i := slices.Index(list, maHash)
+	if i < 0 {
+		return false, nil
+	}
+	list = slices.Delete(list, i, i+1)
+
+	updated, err := json.Marshal(list)
+	if err != nil {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE user_mfa SET ma_khoi_phuc = ? WHERE user_id = ?`, updated, userID,
+	); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -27,13 +27,13 @@ var _ repository.IUserRepository = (*UserMySQLRepo)(nil)
 
 // FindByID tìm user theo ID
 func (r *UserMySQLRepo) FindByID(ctx context.Context, id string) (*entity.User, error) {
-	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat
+	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version
 			  FROM users WHERE id = ?`
 
 	user := &entity.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(
 		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
-		&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat,
+		&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat, &user.Version,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -48,13 +48,13 @@ func (r *UserMySQLRepo) FindByID(ctx context.Context, id string) (*entity.User,
 
 // FindByUsername tìm user theo username
 func (r *UserMySQLRepo) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
-	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat
+	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version
 			  FROM users WHERE username = ?`
 
 	user := &entity.User{}
-	err := r.db.QueryRowContext(ctx, query, username).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, username).Scan(
 		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
-		&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat,
+		&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat, &user.Version,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -69,13 +69,13 @@ func (r *UserMySQLRepo) FindByUsername(ctx context.Context, username string) (*e
 
 // FindByEmail tìm user theo email
 func (r *UserMySQLRepo) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
-	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat
+	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version
 			  FROM users WHERE email = ?`
 
 	user := &entity.User{}
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, email).Scan(
 		&user.ID, &user.Username, &user.Email, &user.PasswordHash,
-		&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat,
+		&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat, &user.Version,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
@@ -90,10 +90,10 @@ func (r *UserMySQLRepo) FindByEmail(ctx context.Context, email string) (*entity.
 
 // FindAll lấy tất cả users
 func (r *UserMySQLRepo) FindAll(ctx context.Context) ([]*entity.User, error) {
-	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat
+	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version
 			  FROM users ORDER BY ngay_tao DESC`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +104,7 @@ func (r *UserMySQLRepo) FindAll(ctx context.Context) ([]*entity.User, error) {
 		user := &entity.User{}
 		err := rows.Scan(
 			&user.ID, &user.Username, &user.Email, &user.PasswordHash,
-			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat,
+			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat, &user.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -120,15 +120,15 @@ func (r *UserMySQLRepo) FindAllPaginated(ctx context.Context, offset, limit int)
 	// Count total
 	var total int64
 	countQuery := `SELECT COUNT(*) FROM users`
-	if err := r.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+	if err := executor(ctx, r.db).QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	// Fetch paginated
-	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat
+	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version
 			  FROM users ORDER BY ngay_tao DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, limit, offset)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -139,7 +139,7 @@ func (r *UserMySQLRepo) FindAllPaginated(ctx context.Context, offset, limit int)
 		user := &entity.User{}
 		err := rows.Scan(
 			&user.ID, &user.Username, &user.Email, &user.PasswordHash,
-			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat,
+			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat, &user.Version,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -155,15 +155,15 @@ func (r *UserMySQLRepo) FindByRolePaginated(ctx context.Context, role entity.Use
 	// Count total
 	var total int64
 	countQuery := `SELECT COUNT(*) FROM users WHERE role = ?`
-	if err := r.db.QueryRowContext(ctx, countQuery, role).Scan(&total); err != nil {
+	if err := executor(ctx, r.db).QueryRowContext(ctx, countQuery, role).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
 	// Fetch paginated
-	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat
+	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version
 			  FROM users WHERE role = ? ORDER BY ngay_tao DESC LIMIT ? OFFSET ?`
 
-	rows, err := r.db.QueryContext(ctx, query, role, limit, offset)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, role, limit, offset)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -174,7 +174,7 @@ func (r *UserMySQLRepo) FindByRolePaginated(ctx context.Context, role entity.Use
 		user := &entity.User{}
 		err := rows.Scan(
 			&user.ID, &user.Username, &user.Email, &user.PasswordHash,
-			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat,
+			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat, &user.Version,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -187,10 +187,10 @@ func (r *UserMySQLRepo) FindByRolePaginated(ctx context.Context, role entity.Use
 
 // FindByRole lấy users theo role
 func (r *UserMySQLRepo) FindByRole(ctx context.Context, role entity.UserRole) ([]*entity.User, error) {
-	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat
+	query := `SELECT id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version
 			  FROM users WHERE role = ? ORDER BY ngay_tao DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, role)
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, role)
 	if err != nil {
 		return nil, err
 	}
@@ -201,7 +201,7 @@ func (r *UserMySQLRepo) FindByRole(ctx context.Context, role entity.UserRole) ([
 		user := &entity.User{}
 		err := rows.Scan(
 			&user.ID, &user.Username, &user.Email, &user.PasswordHash,
-			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat,
+			&user.Role, &user.IsActive, &user.IsEmailVerified, &user.NgayTao, &user.NgayCapNhat, &user.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -214,10 +214,10 @@ func (r *UserMySQLRepo) FindByRole(ctx context.Context, role entity.UserRole) ([
 
 // Create tạo user mới, trả lỗi nếu trùng unique constraint
 func (r *UserMySQLRepo) Create(ctx context.Context, user *entity.User) error {
-	query := `INSERT INTO users (id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat)
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	query := `INSERT INTO users (id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat, version)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, 1)`
 
-	_, err := r.db.ExecContext(ctx, query,
+	_, err := executor(ctx, r.db).ExecContext(ctx, query,
 		user.ID, user.Username, user.Email, user.PasswordHash,
 		user.Role, user.IsActive, user.IsEmailVerified, user.NgayTao, user.NgayCapNhat,
 	)
@@ -228,34 +228,55 @@ func (r *UserMySQLRepo) Create(ctx context.Context, user *entity.User) error {
 		}
 		return err
 	}
+
+	user.Version = 1
 	return nil
 }
 
-// Save cập nhật user đã tồn tại
+// Save cập nhật user đã tồn tại với optimistic locking
+// Chỉ UPDATE khi version trong DB còn bằng user.Version (version luôn tăng nên
+// RowsAffected = 0 nghĩa là user đã bị thay đổi hoặc xóa bởi request khác)
 func (r *UserMySQLRepo) Save(ctx context.Context, user *entity.User) error {
-	query := `INSERT INTO users (id, username, email, password_hash, role, is_active, is_email_verified, ngay_tao, ngay_cap_nhat)
-			  VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
-			  ON DUPLICATE KEY UPDATE
-			  username = VALUES(username),
-			  email = VALUES(email),
-			  password_hash = VALUES(password_hash),
-			  role = VALUES(role),
-			  is_active = VALUES(is_active),
-			  is_email_verified = VALUES(is_email_verified),
-			  ngay_cap_nhat = VALUES(ngay_cap_nhat)`
-
-	_, err := r.db.ExecContext(ctx, query,
-		user.ID, user.Username, user.Email, user.PasswordHash,
-		user.Role, user.IsActive, user.IsEmailVerified, user.NgayTao, user.NgayCapNhat,
+	query := `UPDATE users SET
+			  username = ?,
+			  email = ?,
+			  password_hash = ?,
+			  role = ?,
+			  is_active = ?,
+			  is_email_verified = ?,
+			  ngay_cap_nhat = ?,
+			  version = version + 1
+			  WHERE id = ? AND version = ?`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		user.Username, user.Email, user.PasswordHash, user.Role,
+		user.IsActive, user.IsEmailVerified, user.NgayCapNhat,
+		user.ID, user.Version,
 	)
+	if err != nil {
+		var mysqlErr *mysqldriver.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return repository.ErrDuplicateEntry
+		}
+		return err
+	}
 
-	return err
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return repository.ErrConcurrentModification
+	}
+
+	user.Version++
+	return nil
 }
 
 // Delete xóa user theo ID
 func (r *UserMySQLRepo) Delete(ctx context.Context, id string) error {
 	query := `DELETE FROM users WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
@@ -276,7 +297,7 @@ func (r *UserMySQLRepo) Delete(ctx context.Context, id string) error {
 func (r *UserMySQLRepo) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE username = ?)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, username).Scan(&exists)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, username).Scan(&exists)
 	return exists, err
 }
 
@@ -284,6 +305,6 @@ func (r *UserMySQLRepo) ExistsByUsername(ctx context.Context, username string) (
 func (r *UserMySQLRepo) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM users WHERE email = ?)`
 	var exists bool
-	err := r.db.QueryRowContext(ctx, query, email).Scan(&exists)
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, email).Scan(&exists)
 	return exists, err
 }
@@ -0,0 +1,140 @@
+// Package mysql chứa các MySQL repository implementations
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// WebhookMySQLRepo là implementation của IWebhookRepository sử dụng MySQL
+type WebhookMySQLRepo struct {
+	db *sql.DB
+}
+
+// NewWebhookMySQLRepo tạo mới WebhookMySQLRepo
+func NewWebhookMySQLRepo(db *sql.DB) *WebhookMySQLRepo {
+	return &WebhookMySQLRepo{db: db}
+}
+
+// Verify interface implementation at compile time
+var _ repository.IWebhookRepository = (*WebhookMySQLRepo)(nil)
+
+// scanWebhook đọc một dòng webhooks (su_kien lưu dạng JSON array)
+func scanWebhook(scanner interface{ Scan(...any) error }) (*entity.Webhook, error) {
+	w := &entity.Webhook{}
+	var suKien []byte
+
+	err := scanner.Scan(
+		&w.ID, &w.Ten, &w.URL, &suKien, &w.Secret,
+		&w.DangHoatDong, &w.NgayTao, &w.NgayCapNhat,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(suKien, &w.SuKien); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// findMany chạy truy vấn trả về nhiều webhook
+func (r *WebhookMySQLRepo) findMany(ctx context.Context, query string, args ...any) ([]*entity.Webhook, error) {
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, w)
+	}
+
+	return list, rows.Err()
+}
+
+// FindByID tìm webhook theo ID
+func (r *WebhookMySQLRepo) FindByID(ctx context.Context, id string) (*entity.Webhook, error) {
+	query := `SELECT id, ten, url, su_kien, secret, dang_hoat_dong, ngay_tao, ngay_cap_nhat
+			  FROM webhooks WHERE id = ?`
+
+	w, err := scanWebhook(executor(ctx, r.db).QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// FindAll lấy tất cả webhook
+func (r *WebhookMySQLRepo) FindAll(ctx context.Context) ([]*entity.Webhook, error) {
+	query := `SELECT id, ten, url, su_kien, secret, dang_hoat_dong, ngay_tao, ngay_cap_nhat
+			  FROM webhooks ORDER BY ngay_tao`
+
+	return r.findMany(ctx, query)
+}
+
+// FindDangHoatDong lấy các webhook đang hoạt động
+func (r *WebhookMySQLRepo) FindDangHoatDong(ctx context.Context) ([]*entity.Webhook, error) {
+	query := `SELECT id, ten, url, su_kien, secret, dang_hoat_dong, ngay_tao, ngay_cap_nhat
+			  FROM webhooks WHERE dang_hoat_dong = TRUE ORDER BY ngay_tao`
+
+	return r.findMany(ctx, query)
+}
+
+// Save lưu webhook mới hoặc cập nhật
+func (r *WebhookMySQLRepo) Save(ctx context.Context, w *entity.Webhook) error {
+	query := `INSERT INTO webhooks (id, ten, url, su_kien, secret, dang_hoat_dong, ngay_tao, ngay_cap_nhat)
+			  VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			  ON DUPLICATE KEY UPDATE
+			  ten = VALUES(ten),
+			  url = VALUES(url),
+			  su_kien = VALUES(su_kien),
+			  secret = VALUES(secret),
+			  dang_hoat_dong = VALUES(dang_hoat_dong),
+			  ngay_cap_nhat = VALUES(ngay_cap_nhat)`
+
+	suKien, err := json.Marshal(w.SuKien)
+	if err != nil {
+		return err
+	}
+
+	_, err = executor(ctx, r.db).ExecContext(ctx, query,
+		w.ID, w.Ten, w.URL, suKien, w.Secret, w.DangHoatDong, w.NgayTao, w.NgayCapNhat,
+	)
+	return err
+}
+
+// Delete xóa webhook theo ID
+func (r *WebhookMySQLRepo) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM webhooks WHERE id = ?`
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("không tìm thấy webhook để xóa")
+	}
+
+	return nil
+}
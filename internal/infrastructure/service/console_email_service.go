@@ -7,6 +7,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"restaurant_project/internal/domain/entity"
 	"restaurant_project/internal/domain/service"
 	"restaurant_project/internal/infrastructure/config"
 	"restaurant_project/pkg/logger"
@@ -66,3 +67,84 @@ func (s *ConsoleEmailService) SendVerificationEmail(ctx context.Context, toEmail
 func (s *ConsoleEmailService) IsEnabled() bool {
 	return s.enabled
 }
+
+// SendPasswordResetEmail log password reset link ra console
+func (s *ConsoleEmailService) SendPasswordResetEmail(ctx context.Context, toEmail, token string) error {
+	if !s.enabled {
+		return nil
+	}
+
+	// Build reset URL
+	resetURL := fmt.Sprintf("%s/reset-password?token=%s", s.baseURL, token)
+
+	logger.Info("[EMAIL] Password reset email would be sent",
+		zap.String("to", toEmail),
+		zap.String("reset_url", resetURL),
+	)
+
+	fmt.Println("")
+	fmt.Println("╔════════════════════════════════════════════════════════════════════╗")
+	fmt.Println("║                     PASSWORD RESET (DEV MODE)                      ║")
+	fmt.Println("╠════════════════════════════════════════════════════════════════════╣")
+	fmt.Printf("║ To: %s\n", toEmail)
+	fmt.Println("║────────────────────────────────────────────────────────────────────║")
+	fmt.Println("║ Reset Link:                                                        ║")
+	fmt.Printf("║ %s\n", resetURL)
+	fmt.Println("║────────────────────────────────────────────────────────────────────║")
+	fmt.Printf("║ Token: %s\n", token)
+	fmt.Println("╚════════════════════════════════════════════════════════════════════╝")
+	fmt.Println("")
+
+	return nil
+}
+
+// SendOrderReceipt log hóa đơn đơn hàng ra console
+func (s *ConsoleEmailService) SendOrderReceipt(ctx context.Context, toEmail string, order *entity.Order) error {
+	if !s.enabled {
+		return nil
+	}
+
+	logger.Info("[EMAIL] Order receipt would be sent",
+		zap.String("to", toEmail),
+		zap.String("order_id", order.ID),
+		zap.Int("items", len(order.Items)),
+		zap.Int64("tien_thanh_toan", order.TienThanhToan),
+	)
+
+	return nil
+}
+
+// SendReservationConfirmation log xác nhận đặt bàn ra console
+func (s *ConsoleEmailService) SendReservationConfirmation(ctx context.Context, toEmail string, datBan service.ThongTinDatBan) error {
+	if !s.enabled {
+		return nil
+	}
+
+	logger.Info("[EMAIL] Reservation confirmation would be sent",
+		zap.String("to", toEmail),
+		zap.String("ten_khach", datBan.TenKhach),
+		zap.Time("thoi_gian", datBan.ThoiGian),
+		zap.Int("so_nguoi", datBan.SoNguoi),
+	)
+
+	return nil
+}
+
+// SendLowStockAlert log cảnh báo tồn kho ra console
+func (s *ConsoleEmailService) SendLowStockAlert(ctx context.Context, toEmail string, nguyenLieu []*entity.NguyenLieu) error {
+	if !s.enabled {
+		return nil
+	}
+
+	ten := make([]string, len(nguyenLieu))
+	for i, nl := range nguyenLieu {
+		ten[i] = nl.Ten
+	}
+
+	logger.Info("[EMAIL] Low stock alert would be sent",
+		zap.String("to", toEmail),
+		zap.Strings("nguyen_lieu", ten),
+	)
+
+	return nil
+}
@@ -0,0 +1,132 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+
+	"restaurant_project/internal/infrastructure/emailtemplates"
+)
+
+// Tên các email template (file {tên}.txt và {tên}.html trong emailtemplates)
+const (
+	templateVerification  = "verification"
+	templatePasswordReset = "password_reset"
+	templateOrderReceipt  = "order_receipt"
+	templateReservation   = "reservation"
+	templateLowStock      = "low_stock"
+)
+
+// Các ngôn ngữ email được hỗ trợ
+const (
+	EmailLangVI = "vi"
+	EmailLangEN = "en"
+)
+
+// renderedEmail là email đã render, sẵn sàng đóng gói multipart
+type renderedEmail struct {
+	subject string
+	text    string
+	html    string
+}
+
+// EmailRenderer render email từ embedded templates theo một ngôn ngữ
+// Template được parse một lần khi khởi tạo: lỗi template phát hiện ngay lúc startup
+type EmailRenderer struct {
+	text map[string]*texttemplate.Template
+	html map[string]*htmltemplate.Template
+}
+
+// NewEmailRenderer parse toàn bộ templates của ngôn ngữ lang (vi hoặc en)
+func NewEmailRenderer(lang string) (*EmailRenderer, error) {
+	if lang != EmailLangVI && lang != EmailLangEN {
+		return nil, fmt.Errorf("unsupported email language %q", lang)
+	}
+
+	funcs := map[string]any{"tien": dinhDangTien(lang)}
+	r := &EmailRenderer{
+		text: make(map[string]*texttemplate.Template),
+		html: make(map[string]*htmltemplate.Template),
+	}
+
+	for _, name := range []string{templateVerification, templatePasswordReset, templateOrderReceipt, templateReservation, templateLowStock} {
+		txt, err := texttemplate.New(name+".txt").Funcs(funcs).
+			ParseFS(emailtemplates.Files, lang+"/"+name+".txt")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s/%s.txt: %w", lang, name, err)
+		}
+		if txt.Lookup("subject") == nil {
+			return nil, fmt.Errorf("email template %s/%s.txt must define \"subject\"", lang, name)
+		}
+
+		html, err := htmltemplate.New(name+".html").Funcs(funcs).
+			ParseFS(emailtemplates.Files, lang+"/layout.html", lang+"/"+name+".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse email template %s/%s.html: %w", lang, name, err)
+		}
+
+		r.text[name] = txt
+		r.html[name] = html
+	}
+
+	return r, nil
+}
+
+// Render render subject, phần text và phần HTML của một template
+func (r *EmailRenderer) Render(name string, data any) (*renderedEmail, error) {
+	txt, ok := r.text[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := txt.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("failed to render subject of %s: %w", name, err)
+	}
+	if err := txt.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("failed to render text of %s: %w", name, err)
+	}
+	if err := r.html[name].Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("failed to render html of %s: %w", name, err)
+	}
+
+	return &renderedEmail{
+		subject: strings.TrimSpace(subject.String()),
+		text:    text.String(),
+		html:    html.String(),
+	}, nil
+}
+
+// dinhDangTien trả về hàm format số tiền VND theo ngôn ngữ
+// vi: 1.250.000đ, en: 1,250,000 VND
+func dinhDangTien(lang string) func(int64) string {
+	sep, suffix := ".", "đ"
+	if lang == EmailLangEN {
+		sep, suffix = ",", " VND"
+	}
+
+	return func(soTien int64) string {
+		am := soTien < 0
+		if am {
+			soTien = -soTien
+		}
+
+		s := strconv.FormatInt(soTien, 10)
+		var b strings.Builder
+		if am {
+			b.WriteByte('-')
+		}
+		for i, ch := range s {
+			if i > 0 && (len(s)-i)%3 == 0 {
+				b.WriteString(sep)
+			}
+			b.WriteRune(ch)
+		}
+		b.WriteString(suffix)
+		return b.String()
+	}
+}
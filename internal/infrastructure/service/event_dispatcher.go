@@ -0,0 +1,100 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+)
+
+// Đảm bảo LocalEventDispatcher implement EventDispatcher
+var _ service.EventDispatcher = (*LocalEventDispatcher)(nil)
+
+// eventSubscriber là một consumer đã đăng ký
+type eventSubscriber struct {
+	consumer string
+	handler  service.EventHandler
+}
+
+// LocalEventDispatcher implementation của EventDispatcher gọi consumer ngay trong process
+// Mỗi consumer chạy trong một transaction MySQL bắt đầu bằng việc ghi khóa idempotency (consumer + ID sự kiện):
+// tác dụng phụ MySQL của consumer commit cùng khóa; tác dụng phụ ngoài MySQL vẫn là at-least-once
+type LocalEventDispatcher struct {
+	processed repository.IProcessedEventRepository
+	uow       repository.IUnitOfWork
+
+	mu          sync.RWMutex
+	subscribers map[entity.LoaiSuKien][]eventSubscriber
+}
+
+// NewLocalEventDispatcher tạo mới LocalEventDispatcher
+// Consumer phải được đăng ký bằng Subscribe trước khi relay bắt đầu
+func NewLocalEventDispatcher(processed repository.IProcessedEventRepository, uow repository.IUnitOfWork) *LocalEventDispatcher {
+	return &LocalEventDispatcher{
+		processed:   processed,
+		uow:         uow,
+		subscribers: make(map[entity.LoaiSuKien][]eventSubscriber),
+	}
+}
+
+// Subscribe đăng ký consumer cho một loại sự kiện
+func (d *LocalEventDispatcher) Subscribe(loai entity.LoaiSuKien, consumer string, handler service.EventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers[loai] = append(d.subscribers[loai], eventSubscriber{consumer: consumer, handler: handler})
+}
+
+// Dispatch gọi lần lượt các consumer chưa xử lý sự kiện
+// Consumer lỗi không chặn các consumer còn lại; lỗi được gộp để relay phát lại
+func (d *LocalEventDispatcher) Dispatch(ctx context.Context, suKien *entity.SuKien) error {
+	d.mu.RLock()
+	subscribers := d.subscribers[suKien.Loai]
+	d.mu.RUnlock()
+
+	var errs []error
+	for _, sub := range subscribers {
+		if err := d.dispatchOne(ctx, sub, suKien); err != nil {
+			logger.CtxWarn(ctx, "Event consumer failed",
+				zap.String("event_id", suKien.ID),
+				zap.String("event_type", string(suKien.Loai)),
+				zap.String("consumer", sub.consumer),
+				zap.Error(err),
+			)
+			errs = append(errs, fmt.Errorf("%s: %w", sub.consumer, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// dispatchOne chạy consumer nếu chưa xử lý sự kiện
+// Khóa được ghi trước trong cùng transaction: lần phát đồng thời chờ khóa dòng rồi thấy đã xử lý,
+// consumer lỗi (hoặc panic) thì khóa rollback cùng tác dụng phụ để lần phát lại chạy lại consumer
+func (d *LocalEventDispatcher) dispatchOne(ctx context.Context, sub eventSubscriber, suKien *entity.SuKien) error {
+	return d.uow.Do(ctx, func(ctx context.Context) (err error) {
+		moi, err := d.processed.DanhDauDaXuLy(ctx, sub.consumer, suKien.ID)
+		if err != nil {
+			return fmt.Errorf("không thể ghi khóa idempotency: %w", err)
+		}
+		if !moi {
+			return nil
+		}
+
+		// Consumer panic không được làm chết relay
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("consumer panic: %v", r)
+			}
+		}()
+
+		return sub.handler(ctx, suKien)
+	})
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+)
+
+// fakeTxKey là key lưu transaction giả trong ctx
+type fakeTxKey struct{}
+
+// fakeTx gom các thay đổi trong transaction, chỉ áp dụng khi commit
+type fakeTx struct {
+	ghi []func()
+}
+
+// fakeDB là "database" trong bộ nhớ có transaction: ghi trong tx chỉ hiện ra sau commit,
+// các transaction chạy tuần tự như khi lần phát đồng thời chờ khóa dòng processed_events
+type fakeDB struct {
+	txMu sync.Mutex
+
+	mu   sync.Mutex
+	khoa map[string]bool  // processed_events đã commit
+	diem map[string]int64 // khach_hang.diem_tich_luy đã commit
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{khoa: make(map[string]bool), diem: make(map[string]int64)}
+}
+
+func (db *fakeDB) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	db.txMu.Lock()
+	defer db.txMu.Unlock()
+
+	tx := &fakeTx{}
+	if err := fn(context.WithValue(ctx, fakeTxKey{}, tx)); err != nil {
+		return err // Rollback: bỏ các thay đổi của tx
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, ghi := range tx.ghi {
+		ghi()
+	}
+	return nil
+}
+
+// ghiTrongTx ghi nhận thay đổi vào tx của ctx (bắt buộc chạy trong Do)
+func (db *fakeDB) ghiTrongTx(ctx context.Context, ghi func()) error {
+	tx, ok := ctx.Value(fakeTxKey{}).(*fakeTx)
+	if !ok {
+		return errors.New("write outside transaction")
+	}
+	tx.ghi = append(tx.ghi, ghi)
+	return nil
+}
+
+// fakeProcessedEventRepo ghi khóa idempotency vào fakeDB
+type fakeProcessedEventRepo struct {
+	repository.IProcessedEventRepository
+	db *fakeDB
+}
+
+func (r *fakeProcessedEventRepo) DanhDauDaXuLy(ctx context.Context, consumer, suKienID string) (bool, error) {
+	key := consumer + "/" + suKienID
+	r.db.mu.Lock()
+	daCo := r.db.khoa[key]
+	r.db.mu.Unlock()
+	if daCo {
+		return false, nil
+	}
+	return true, r.db.ghiTrongTx(ctx, func() { r.db.khoa[key] = true })
+}
+
+// fakeKhachHangRepo cộng điểm vào fakeDB
+type fakeKhachHangRepo struct {
+	repository.IKhachHangRepository
+	db *fakeDB
+}
+
+func (r *fakeKhachHangRepo) CongDiemTichLuy(ctx context.Context, id string, diem int64) (*entity.KhachHang, error) {
+	r.db.mu.Lock()
+	hienTai, ok := r.db.diem[id]
+	r.db.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	if err := r.db.ghiTrongTx(ctx, func() { r.db.diem[id] += diem }); err != nil {
+		return nil, err
+	}
+	return &entity.KhachHang{ID: id, DiemTichLuy: hienTai + diem}, nil
+}
+
+// newDonHoanThanh tạo sự kiện đơn hoàn thành của khách kh-1 (được cộng 10 điểm)
+func newDonHoanThanh(t *testing.T) *entity.SuKien {
+	t.Helper()
+	sk, err := entity.NewSuKien("sk-1", entity.SuKienOrderStatusChanged, "order-1", entity.OrderStatusChangedPayload{
+		OrderID:       "order-1",
+		KhachHangID:   "kh-1",
+		TrangThaiCu:   entity.OrderDangGiao,
+		TrangThaiMoi:  entity.OrderHoanThanh,
+		TienThanhToan: 10 * entity.SoTienMoiDiem,
+	})
+	if err != nil {
+		t.Fatalf("NewSuKien() error = %v", err)
+	}
+	return sk
+}
+
+func TestLocalEventDispatcher_CongDiemMotLan(t *testing.T) {
+	tests := []struct {
+		name      string
+		soLanLoi  int  // Số lần đầu consumer lỗi sau khi đã cộng điểm
+		panic     bool // Lỗi bằng panic thay vì trả về error
+		soLanPhat int
+		dongThoi  bool
+	}{
+		{name: "phát lại sau khi thành công", soLanPhat: 3},
+		{name: "consumer lỗi sau khi cộng điểm", soLanLoi: 2, soLanPhat: 4},
+		{name: "consumer panic sau khi cộng điểm", soLanLoi: 1, panic: true, soLanPhat: 3},
+		{name: "phát đồng thời", soLanPhat: 10, dongThoi: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			db := newFakeDB()
+			db.diem["kh-1"] = 0
+			consumer := usecase.NewSuKienConsumer(nil, &fakeKhachHangRepo{db: db}, nil, nil)
+
+			var mu sync.Mutex
+			soLanChay := 0
+			d := NewLocalEventDispatcher(&fakeProcessedEventRepo{db: db}, db)
+			d.Subscribe(entity.SuKienOrderStatusChanged, "loyalty.cong_diem", func(ctx context.Context, sk *entity.SuKien) error {
+				if err := consumer.CongDiemTichLuy(ctx, sk); err != nil {
+					return err
+				}
+				mu.Lock()
+				soLanChay++
+				loi := soLanChay <= tt.soLanLoi
+				mu.Unlock()
+				if loi && tt.panic {
+					panic("consumer crashed")
+				}
+				if loi {
+					return errors.New("consumer failed")
+				}
+				return nil
+			})
+
+			sk := newDonHoanThanh(t)
+			var wg sync.WaitGroup
+			for i := 0; i < tt.soLanPhat; i++ {
+				if !tt.dongThoi {
+					_ = d.Dispatch(ctx, sk)
+					continue
+				}
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_ = d.Dispatch(ctx, sk)
+				}()
+			}
+			wg.Wait()
+
+			if got := db.diem["kh-1"]; got != 10 {
+				t.Errorf("diem_tich_luy = %d, want 10", got)
+			}
+			if got, want := soLanChay, tt.soLanLoi+1; got != want {
+				t.Errorf("consumer ran %d times, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestLocalEventDispatcher_ConsumerLoiKhongChayLaiConsumerKhac(t *testing.T) {
+	ctx := context.Background()
+	db := newFakeDB()
+	d := NewLocalEventDispatcher(&fakeProcessedEventRepo{db: db}, db)
+
+	soLanChay := map[string]int{}
+	loiConLai := 1
+	d.Subscribe(entity.SuKienOrderStatusChanged, "a", func(ctx context.Context, sk *entity.SuKien) error {
+		soLanChay["a"]++
+		return nil
+	})
+	d.Subscribe(entity.SuKienOrderStatusChanged, "b", func(ctx context.Context, sk *entity.SuKien) error {
+		soLanChay["b"]++
+		if loiConLai > 0 {
+			loiConLai--
+			return errors.New("consumer failed")
+		}
+		return nil
+	})
+
+	sk := newDonHoanThanh(t)
+	if err := d.Dispatch(ctx, sk); err == nil {
+		t.Fatal("first Dispatch() error = nil, want consumer b error")
+	}
+	if err := d.Dispatch(ctx, sk); err != nil {
+		t.Fatalf("second Dispatch() error = %v", err)
+	}
+	if err := d.Dispatch(ctx, sk); err != nil {
+		t.Fatalf("third Dispatch() error = %v", err)
+	}
+
+	if soLanChay["a"] != 1 || soLanChay["b"] != 2 {
+		t.Errorf("runs = %v, want a=1 b=2", soLanChay)
+	}
+}
@@ -0,0 +1,337 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Đảm bảo OIDCClient implement OIDCProvider
+var _ service.OIDCProvider = (*OIDCClient)(nil)
+
+const (
+	oidcHTTPTimeout     = 10 * time.Second
+	oidcMaxResponseSize = 1 << 20
+	oidcScopes          = "openid email profile"
+	oidcKeysMaxAge      = time.Hour   // Tải lại JWKS định kỳ để nhận key mới của provider
+	oidcKeysMinRefresh  = time.Minute // Gặp kid lạ cũng không tải lại JWKS quá thường xuyên
+	oidcClockSkew       = time.Minute
+)
+
+// oidcMetadata là các endpoint lấy từ {issuer}/.well-known/openid-configuration
+type oidcMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// OIDCClient implementation của OIDCProvider cho provider chuẩn OpenID Connect (Google, ...)
+// Endpoint lấy qua discovery lần đầu dùng, nên provider tạm lỗi không chặn app khởi động
+type OIDCClient struct {
+	name         string
+	issuer       string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	httpClient   *http.Client
+
+	mu       sync.Mutex
+	metadata *oidcMetadata
+	keys     map[string]*rsa.PublicKey
+	keysAt   time.Time
+}
+
+// NewOIDCClient tạo OIDC client cho một provider
+func NewOIDCClient(name string, cfg config.OIDCProviderConfig, redirectURL string) *OIDCClient {
+	return &OIDCClient{
+		name:         name,
+		issuer:       strings.TrimSuffix(cfg.IssuerURL, "/"),
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		redirectURL:  redirectURL,
+		httpClient:   &http.Client{Timeout: oidcHTTPTimeout},
+	}
+}
+
+// Name trả về tên provider
+func (c *OIDCClient) Name() string {
+	return c.name
+}
+
+// AuthorizationURL tạo URL đăng nhập tại provider (authorization code + PKCE S256)
+func (c *OIDCClient) AuthorizationURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := c.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	authURL, err := url.Parse(metadata.AuthorizationEndpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid authorization endpoint: %w", err)
+	}
+
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", c.clientID)
+	query.Set("redirect_uri", c.redirectURL)
+	query.Set("scope", oidcScopes)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	return authURL.String(), nil
+}
+
+// Exchange đổi authorization code lấy ID token và xác thực ID token
+func (c *OIDCClient) Exchange(ctx context.Context, code, codeVerifier string) (*service.OIDCIdentity, error) {
+	metadata, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {c.redirectURL},
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+		"code_verifier": {codeVerifier},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call token endpoint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var tokenResp struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, oidcMaxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token response: %w", err)
+	}
+	if err := json.Unmarshal(body, &tokenResp); err != nil && resp.StatusCode == http.StatusOK {
+		return nil, fmt.Errorf("invalid token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// 4xx: code sai/hết hạn/verifier sai; 5xx: lỗi phía provider
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return nil, fmt.Errorf("token endpoint returned status %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("%w: %s %s", service.ErrOIDCExchangeFailed, tokenResp.Error, tokenResp.ErrorDescription)
+	}
+	if tokenResp.IDToken == "" {
+		return nil, fmt.Errorf("%w: token response has no id_token", service.ErrOIDCExchangeFailed)
+	}
+
+	return c.verifyIDToken(ctx, metadata, tokenResp.IDToken)
+}
+
+// idTokenClaims là các claim cần dùng trong ID token
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Email         string   `json:"email"`
+	EmailVerified flexBool `json:"email_verified"`
+	Name          string   `json:"name"`
+	Nonce         string   `json:"nonce"`
+}
+
+// verifyIDToken kiểm tra chữ ký (JWKS của provider), audience, issuer, thời hạn của ID token
+func (c *OIDCClient) verifyIDToken(ctx context.Context, metadata *oidcMetadata, rawToken string) (*service.OIDCIdentity, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string)
+			return c.publicKey(ctx, metadata.JWKSURI, kid)
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(c.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid id token: %v", service.ErrOIDCExchangeFailed, err)
+	}
+
+	// Google có thể trả iss không kèm scheme ("accounts.google.com")
+	if claims.Issuer != metadata.Issuer && "https://"+claims.Issuer != metadata.Issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", service.ErrOIDCExchangeFailed, claims.Issuer)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: id token has no subject", service.ErrOIDCExchangeFailed)
+	}
+
+	return &service.OIDCIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+		Nonce:         claims.Nonce,
+	}, nil
+}
+
+// discover lấy metadata của provider, cache sau lần đầu thành công
+func (c *OIDCClient) discover(ctx context.Context) (*oidcMetadata, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.metadata != nil {
+		return c.metadata, nil
+	}
+
+	metadata := &oidcMetadata{}
+	if err := c.getJSON(ctx, c.issuer+"/.well-known/openid-configuration", metadata); err != nil {
+		return nil, fmt.Errorf("failed to discover oidc provider %s: %w", c.name, err)
+	}
+	if metadata.Issuer != c.issuer {
+		return nil, fmt.Errorf("oidc provider %s: discovery issuer %q does not match %q", c.name, metadata.Issuer, c.issuer)
+	}
+	if metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, fmt.Errorf("oidc provider %s: discovery document is missing endpoints", c.name)
+	}
+
+	c.metadata = metadata
+	return metadata, nil
+}
+
+// publicKey lấy public key theo kid, tải lại JWKS khi cache cũ hoặc gặp kid chưa biết
+func (c *OIDCClient) publicKey(ctx context.Context, jwksURI, kid string) (*rsa.PublicKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	age := time.Since(c.keysAt)
+	if key := c.lookupKey(kid); key != nil && age < oidcKeysMaxAge {
+		return key, nil
+	}
+	if c.keys != nil && age < oidcKeysMinRefresh {
+		if key := c.lookupKey(kid); key != nil {
+			return key, nil
+		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keys, err := c.fetchKeys(ctx, jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	c.keys = keys
+	c.keysAt = time.Now()
+
+	if key := c.lookupKey(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// lookupKey tìm key theo kid; token không có kid chỉ chấp nhận khi JWKS có đúng một key
+func (c *OIDCClient) lookupKey(kid string) *rsa.PublicKey {
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key
+		}
+	}
+	return c.keys[kid]
+}
+
+// fetchKeys tải JWKS và lấy các RSA key dùng để ký
+func (c *OIDCClient) fetchKeys(ctx context.Context, jwksURI string) (map[string]*rsa.PublicKey, error) {
+	var jwks struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := c.getJSON(ctx, jwksURI, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(k.N)
+		e, errE := base64.RawURLEncoding.DecodeString(k.E)
+		if errN != nil || errE != nil || len(e) == 0 || len(e) > 4 {
+			continue
+		}
+		exponent := int(new(big.Int).SetBytes(e).Int64())
+		keys[k.Kid] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks has no usable RSA signing key")
+	}
+
+	return keys, nil
+}
+
+// getJSON gọi GET và decode JSON response
+func (c *OIDCClient) getJSON(ctx context.Context, endpoint string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", endpoint, resp.StatusCode)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, oidcMaxResponseSize)).Decode(out)
+}
+
+// flexBool nhận cả true và "true" (một số provider trả email_verified dạng chuỗi)
+type flexBool bool
+
+// UnmarshalJSON implement json.Unmarshaler
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "true":
+		*b = true
+	case "false", "null", "":
+		*b = false
+	default:
+		return fmt.Errorf("invalid boolean %s", data)
+	}
+	return nil
+}
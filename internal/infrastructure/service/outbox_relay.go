@@ -0,0 +1,209 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/pkg/logger"
+)
+
+// OutboxStore là một outbox relay cần quét (mỗi database một outbox)
+type OutboxStore struct {
+	Ten  string // Tên store, dùng cho log (vd: "mysql", "mongodb")
+	Repo repository.IOutboxRepository
+}
+
+// OutboxRelay quét outbox và phát sự kiện tới dispatcher (at-least-once)
+//
+// Luồng xử lý mỗi store:
+//   - FindDenHan: lấy sự kiện chưa phát đã tới ThuLaiLuc, cũ nhất trước
+//   - GiuCho: dời ThuLaiLuc qua khỏi thời gian xử lý để instance khác không phát trùng
+//   - Dispatch thành công → DanhDauDaPhat; lỗi → DanhDauThatBai với exponential backoff (không bỏ sự kiện)
+//   - Instance chết sau GiuCho: sự kiện tới hạn lại và được phát lại, consumer bỏ qua nhờ khóa idempotency
+type OutboxRelay struct {
+	stores     []OutboxStore
+	dispatcher service.EventDispatcher
+	cfg        config.OutboxConfig
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewOutboxRelay tạo mới OutboxRelay
+func NewOutboxRelay(dispatcher service.EventDispatcher, cfg config.OutboxConfig, stores ...OutboxStore) *OutboxRelay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.HandlerTimeout <= 0 {
+		cfg.HandlerTimeout = 30 * time.Second
+	}
+	if cfg.BatchSize < 1 {
+		cfg.BatchSize = 1
+	}
+	if cfg.BackoffMax < cfg.BackoffBase {
+		cfg.BackoffMax = cfg.BackoffBase
+	}
+
+	return &OutboxRelay{
+		stores:     stores,
+		dispatcher: dispatcher,
+		cfg:        cfg,
+	}
+}
+
+// Start khởi chạy một vòng quét cho mỗi store
+func (r *OutboxRelay) Start() {
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	for _, store := range r.stores {
+		r.wg.Add(1)
+		go r.run(runCtx, store)
+	}
+
+	logger.Info("Outbox relay started",
+		zap.Int("stores", len(r.stores)),
+		zap.Duration("poll_interval", r.cfg.PollInterval),
+	)
+}
+
+// Stop dừng quét và chờ sự kiện đang phát kết thúc
+func (r *OutboxRelay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("outbox relay stop: %w", ctx.Err())
+	}
+
+	logger.Info("Outbox relay stopped")
+	return nil
+}
+
+// run là vòng quét của một store
+// Lô đầy thì quét tiếp ngay (còn sự kiện tồn), ngược lại chờ PollInterval
+func (r *OutboxRelay) run(ctx context.Context, store OutboxStore) {
+	defer r.wg.Done()
+
+	for ctx.Err() == nil {
+		soSuKien, err := r.relayBatch(ctx, store)
+		if err != nil && ctx.Err() == nil {
+			logger.Error("Outbox poll failed", zap.String("store", store.Ten), zap.Error(err))
+		}
+		if err != nil || soSuKien < r.cfg.BatchSize {
+			sleepCtx(ctx, r.cfg.PollInterval)
+		}
+	}
+}
+
+// relayBatch phát một lô sự kiện tới hạn, trả về số sự kiện đã lấy
+func (r *OutboxRelay) relayBatch(ctx context.Context, store OutboxStore) (int, error) {
+	list, err := store.Repo.FindDenHan(ctx, time.Now(), r.cfg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, suKien := range list {
+		if ctx.Err() != nil {
+			break
+		}
+		r.relayOne(store, suKien)
+	}
+	return len(list), nil
+}
+
+// relayOne giữ chỗ, phát một sự kiện và ghi kết quả vào outbox
+// Dùng context riêng: sự kiện đã giữ chỗ được phát hết dù relay đang dừng
+func (r *OutboxRelay) relayOne(store OutboxStore, suKien *entity.SuKien) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.cfg.HandlerTimeout)
+	defer cancel()
+
+	now := time.Now()
+	// Giữ chỗ lâu hơn timeout xử lý để không bị instance khác nhận lại khi đang phát
+	ok, err := store.Repo.GiuCho(ctx, suKien.ID, now, now.Add(2*r.cfg.HandlerTimeout))
+	if err != nil {
+		logger.Error("Failed to claim outbox event",
+			zap.String("store", store.Ten),
+			zap.String("event_id", suKien.ID),
+			zap.Error(err),
+		)
+		return
+	}
+	if !ok {
+		return
+	}
+
+	dispatchErr := r.dispatcher.Dispatch(ctx, suKien)
+
+	// Ghi kết quả bằng context mới: ctx phát có thể đã hết hạn
+	ghiCtx, ghiCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer ghiCancel()
+
+	if dispatchErr == nil {
+		if err := store.Repo.DanhDauDaPhat(ghiCtx, suKien.ID, time.Now()); err != nil {
+			// Sự kiện sẽ được phát lại khi hết giữ chỗ, consumer bỏ qua nhờ khóa idempotency
+			logger.Error("Failed to mark outbox event as published",
+				zap.String("store", store.Ten),
+				zap.String("event_id", suKien.ID),
+				zap.Error(err),
+			)
+		}
+		return
+	}
+
+	soLanThu := suKien.SoLanThu + 1
+	delay := expBackoff(r.cfg.BackoffBase, r.cfg.BackoffMax, soLanThu)
+	if err := store.Repo.DanhDauThatBai(ghiCtx, suKien.ID, dispatchErr.Error(), time.Now().Add(delay)); err != nil {
+		logger.Error("Failed to record outbox event failure",
+			zap.String("store", store.Ten),
+			zap.String("event_id", suKien.ID),
+			zap.Error(err),
+		)
+		return
+	}
+
+	logger.Warn("Outbox event dispatch failed, will retry",
+		zap.String("store", store.Ten),
+		zap.String("event_id", suKien.ID),
+		zap.String("event_type", string(suKien.Loai)),
+		zap.Int("so_lan_thu", soLanThu),
+		zap.Duration("retry_in", delay),
+		zap.Error(dispatchErr),
+	)
+}
+
+// PurgeDaPhat xóa các sự kiện đã phát trước thời điểm truoc ở mọi store (scheduler gọi)
+// Lỗi một store không chặn các store còn lại: trả về tổng số đã xóa kèm lỗi của các store thất bại
+func (r *OutboxRelay) PurgeDaPhat(ctx context.Context, truoc time.Time) (int64, error) {
+	var tong int64
+	var errs []error
+	for _, store := range r.stores {
+		n, err := store.Repo.PurgeDaPhat(ctx, truoc)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", store.Ten, err))
+			continue
+		}
+		tong += n
+	}
+	return tong, errors.Join(errs...)
+}
@@ -0,0 +1,218 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/pkg/logger"
+)
+
+// Đảm bảo QueuedEmailService implement EmailService
+var _ service.EmailService = (*QueuedEmailService)(nil)
+
+// Loại job gửi email
+const (
+	jobEmailVerification  = "email.verification"
+	jobEmailPasswordReset = "email.password_reset"
+	jobEmailOrderReceipt  = "email.order_receipt"
+	jobEmailReservation   = "email.reservation"
+	jobEmailLowStock      = "email.low_stock"
+)
+
+// Key pattern cho token chờ gửi email: email:token:<ref>
+// Job chỉ mang ref, token thật không nằm trong stream, retry set hay dead-letter list
+const emailTokenKeyPrefix = "email:token:"
+
+// Payload của các job gửi email
+type tokenEmailJob struct {
+	To       string `json:"to"`
+	TokenRef string `json:"token_ref"`
+}
+
+type orderReceiptJob struct {
+	To    string        `json:"to"`
+	Order *entity.Order `json:"order"`
+}
+
+type reservationJob struct {
+	To     string                 `json:"to"`
+	DatBan service.ThongTinDatBan `json:"dat_ban"`
+}
+
+type lowStockJob struct {
+	To         string               `json:"to"`
+	NguyenLieu []*entity.NguyenLieu `json:"nguyen_lieu"`
+}
+
+// QueuedEmailService bọc một EmailService thật và chuyển việc gửi sang job queue
+// Request chỉ tốn một lệnh XADD; SMTP chậm hoặc lỗi được worker thử lại ở nền
+type QueuedEmailService struct {
+	client *redis.Client
+	queue  *RedisJobQueue
+	sender service.EmailService
+
+	verificationTTL time.Duration // Thời gian giữ verification token chờ gửi (bằng thời gian sống của token)
+	resetTTL        time.Duration // Thời gian giữ reset token chờ gửi (bằng thời gian sống của token)
+}
+
+// NewQueuedEmailService tạo mới QueuedEmailService và đăng ký handler gửi email với queue
+func NewQueuedEmailService(
+	client *redis.Client,
+	queue *RedisJobQueue,
+	sender service.EmailService,
+	verificationTTL, resetTTL time.Duration,
+) *QueuedEmailService {
+	s := &QueuedEmailService{
+		client:          client,
+		queue:           queue,
+		sender:          sender,
+		verificationTTL: verificationTTL,
+		resetTTL:        resetTTL,
+	}
+
+	queue.Register(jobEmailVerification, s.handleVerification)
+	queue.Register(jobEmailPasswordReset, s.handlePasswordReset)
+	queue.Register(jobEmailOrderReceipt, s.handleOrderReceipt)
+	queue.Register(jobEmailReservation, s.handleReservation)
+	queue.Register(jobEmailLowStock, s.handleLowStock)
+
+	return s
+}
+
+// SendVerificationEmail đưa email xác thực vào hàng đợi
+func (s *QueuedEmailService) SendVerificationEmail(ctx context.Context, toEmail, token string) error {
+	return s.enqueueToken(ctx, jobEmailVerification, toEmail, token, s.verificationTTL)
+}
+
+// SendPasswordResetEmail đưa email đặt lại mật khẩu vào hàng đợi
+func (s *QueuedEmailService) SendPasswordResetEmail(ctx context.Context, toEmail, token string) error {
+	return s.enqueueToken(ctx, jobEmailPasswordReset, toEmail, token, s.resetTTL)
+}
+
+// SendOrderReceipt đưa email hóa đơn vào hàng đợi (kèm snapshot đơn hàng tại thời điểm đặt)
+func (s *QueuedEmailService) SendOrderReceipt(ctx context.Context, toEmail string, order *entity.Order) error {
+	return s.enqueue(ctx, jobEmailOrderReceipt, toEmail, orderReceiptJob{To: toEmail, Order: order})
+}
+
+// SendReservationConfirmation đưa email xác nhận đặt bàn vào hàng đợi
+func (s *QueuedEmailService) SendReservationConfirmation(ctx context.Context, toEmail string, datBan service.ThongTinDatBan) error {
+	return s.enqueue(ctx, jobEmailReservation, toEmail, reservationJob{To: toEmail, DatBan: datBan})
+}
+
+// SendLowStockAlert đưa email cảnh báo tồn kho vào hàng đợi
+func (s *QueuedEmailService) SendLowStockAlert(ctx context.Context, toEmail string, nguyenLieu []*entity.NguyenLieu) error {
+	return s.enqueue(ctx, jobEmailLowStock, toEmail, lowStockJob{To: toEmail, NguyenLieu: nguyenLieu})
+}
+
+// enqueueToken lưu token vào Redis dưới một ref ngẫu nhiên rồi đưa job chỉ mang ref vào hàng đợi
+// Ref hết hạn cùng token: job chạy trễ hơn thời gian sống của token thì không còn gì để gửi
+func (s *QueuedEmailService) enqueueToken(ctx context.Context, loai, toEmail, token string, ttl time.Duration) error {
+	ref := uuid.NewString()
+	if err := s.client.Set(ctx, emailTokenKeyPrefix+ref, token, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store email token: %w", err)
+	}
+
+	if err := s.enqueue(ctx, loai, toEmail, tokenEmailJob{To: toEmail, TokenRef: ref}); err != nil {
+		s.client.Del(ctx, emailTokenKeyPrefix+ref)
+		return err
+	}
+	return nil
+}
+
+// enqueue đưa job gửi email vào hàng đợi
+func (s *QueuedEmailService) enqueue(ctx context.Context, loai, toEmail string, payload any) error {
+	jobID, err := s.queue.Enqueue(ctx, loai, payload)
+	if err != nil {
+		return fmt.Errorf("failed to queue email: %w", err)
+	}
+
+	logger.CtxDebug(ctx, "Email queued",
+		zap.String("job_id", jobID),
+		zap.String("loai", loai),
+		zap.String("to", toEmail),
+	)
+	return nil
+}
+
+// ============================================================
+// Job handlers (chạy trong worker)
+// ============================================================
+
+func (s *QueuedEmailService) handleVerification(ctx context.Context, job *service.Job) error {
+	var p tokenEmailJob
+	if err := json.Unmarshal(job.DuLieu, &p); err != nil {
+		return fmt.Errorf("invalid verification email payload: %w", err)
+	}
+	return s.sendWithToken(ctx, job, p, s.sender.SendVerificationEmail)
+}
+
+func (s *QueuedEmailService) handlePasswordReset(ctx context.Context, job *service.Job) error {
+	var p tokenEmailJob
+	if err := json.Unmarshal(job.DuLieu, &p); err != nil {
+		return fmt.Errorf("invalid password reset email payload: %w", err)
+	}
+	return s.sendWithToken(ctx, job, p, s.sender.SendPasswordResetEmail)
+}
+
+// sendWithToken lấy token theo ref rồi gửi email, xóa token sau khi gửi thành công
+// Token đã hết hạn thì bỏ qua job: link trong email cũng đã hết hiệu lực, người dùng phải yêu cầu lại
+func (s *QueuedEmailService) sendWithToken(ctx context.Context, job *service.Job, p tokenEmailJob, send func(ctx context.Context, toEmail, token string) error) error {
+	key := emailTokenKeyPrefix + p.TokenRef
+	token, err := s.client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		logger.CtxWarn(ctx, "Email token expired before sending, job skipped",
+			zap.String("job_id", job.ID),
+			zap.String("loai", job.Loai),
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load email token: %w", err)
+	}
+
+	if err := send(ctx, p.To, token); err != nil {
+		return err
+	}
+
+	if err := s.client.Del(ctx, key).Err(); err != nil {
+		logger.CtxWarn(ctx, "Failed to delete sent email token", zap.Error(err))
+	}
+	return nil
+}
+
+func (s *QueuedEmailService) handleOrderReceipt(ctx context.Context, job *service.Job) error {
+	var p orderReceiptJob
+	if err := json.Unmarshal(job.DuLieu, &p); err != nil {
+		return fmt.Errorf("invalid order receipt payload: %w", err)
+	}
+	if p.Order == nil {
+		return errors.New("order receipt payload has no order")
+	}
+	return s.sender.SendOrderReceipt(ctx, p.To, p.Order)
+}
+
+func (s *QueuedEmailService) handleReservation(ctx context.Context, job *service.Job) error {
+	var p reservationJob
+	if err := json.Unmarshal(job.DuLieu, &p); err != nil {
+		return fmt.Errorf("invalid reservation email payload: %w", err)
+	}
+	return s.sender.SendReservationConfirmation(ctx, p.To, p.DatBan)
+}
+
+func (s *QueuedEmailService) handleLowStock(ctx context.Context, job *service.Job) error {
+	var p lowStockJob
+	if err := json.Unmarshal(job.DuLieu, &p); err != nil {
+		return fmt.Errorf("invalid low stock alert payload: %w", err)
+	}
+	return s.sender.SendLowStockAlert(ctx, p.To, p.NguyenLieu)
+}
@@ -0,0 +1,193 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/repository"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/pkg/logger"
+	"restaurant_project/pkg/netguard"
+)
+
+// Đảm bảo QueuedWebhookSender implement WebhookSender
+var _ service.WebhookSender = (*QueuedWebhookSender)(nil)
+
+// Loại job gửi webhook
+const jobWebhookDeliver = "webhook.deliver"
+
+// Header của request gửi webhook
+const (
+	headerWebhookEvent     = "X-Webhook-Event"     // Loại sự kiện
+	headerWebhookDelivery  = "X-Webhook-Delivery"  // ID delivery: bên nhận dùng để bỏ qua bản gửi trùng
+	headerWebhookTimestamp = "X-Webhook-Timestamp" // Unix giây, là một phần nội dung được ký
+	headerWebhookSignature = "X-Webhook-Signature" // entity.KyWebhook(secret, timestamp, body)
+
+	webhookUserAgent     = "restaurant-webhook/1.0"
+	webhookMaxDocPhanHoi = 64 << 10 // Số byte body phản hồi tối đa đọc bỏ (để tái dùng kết nối)
+)
+
+// webhookJob là payload của job gửi webhook
+type webhookJob struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+// QueuedWebhookSender gửi webhook qua job queue
+// Job thất bại được queue thử lại với exponential backoff; mỗi lần gửi ghi kết quả vào nhật ký delivery,
+// hết lượt thử thì delivery chuyển sang thất bại (admin gửi lại thủ công được)
+type QueuedWebhookSender struct {
+	queue        *RedisJobQueue
+	webhookRepo  repository.IWebhookRepository
+	deliveryRepo repository.IWebhookDeliveryRepository
+	client       *http.Client
+}
+
+// NewQueuedWebhookSender tạo mới QueuedWebhookSender và đăng ký handler gửi webhook với queue
+// Kết nối đi qua guard: endpoint phân giải ra địa chỉ nội bộ bị từ chối lúc connect (chống SSRF)
+func NewQueuedWebhookSender(
+	queue *RedisJobQueue,
+	webhookRepo repository.IWebhookRepository,
+	deliveryRepo repository.IWebhookDeliveryRepository,
+	guard *netguard.Guard,
+	cfg config.WebhookConfig,
+) *QueuedWebhookSender {
+	s := &QueuedWebhookSender{
+		queue:        queue,
+		webhookRepo:  webhookRepo,
+		deliveryRepo: deliveryRepo,
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: guard.Transport(),
+			// Không theo redirect: endpoint phải trả 2xx trực tiếp, chữ ký không bị gửi sang host khác
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+
+	queue.Register(jobWebhookDeliver, s.handleDeliver)
+
+	return s
+}
+
+// GuiNen đưa delivery vào hàng đợi gửi
+func (s *QueuedWebhookSender) GuiNen(ctx context.Context, deliveryID string) error {
+	jobID, err := s.queue.Enqueue(ctx, jobWebhookDeliver, webhookJob{DeliveryID: deliveryID})
+	if err != nil {
+		return fmt.Errorf("failed to queue webhook: %w", err)
+	}
+
+	logger.CtxDebug(ctx, "Webhook queued",
+		zap.String("job_id", jobID),
+		zap.String("delivery_id", deliveryID),
+	)
+	return nil
+}
+
+// ============================================================
+// Job handler (chạy trong worker)
+// ============================================================
+
+func (s *QueuedWebhookSender) handleDeliver(ctx context.Context, job *service.Job) error {
+	var p webhookJob
+	if err := json.Unmarshal(job.DuLieu, &p); err != nil {
+		return fmt.Errorf("invalid webhook payload: %w", err)
+	}
+
+	d, err := s.deliveryRepo.FindByID(ctx, p.DeliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook delivery: %w", err)
+	}
+	if d == nil {
+		logger.CtxWarn(ctx, "Webhook delivery not found, skipping", zap.String("delivery_id", p.DeliveryID))
+		return nil
+	}
+	// Job trùng (sự kiện phát lại, replay dead-letter) cho delivery đã gửi xong
+	if d.TrangThai == entity.DeliveryThanhCong {
+		return nil
+	}
+
+	wh, err := s.webhookRepo.FindByID(ctx, d.WebhookID)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook: %w", err)
+	}
+	if wh == nil || !wh.DangHoatDong {
+		d.GhiThatBai(0, "webhook đã bị xóa hoặc tạm dừng", true)
+		s.luuKetQua(ctx, d)
+		return nil
+	}
+
+	maPhanHoi, guiErr := s.gui(ctx, wh, d)
+	if guiErr == nil {
+		d.GhiThanhCong(maPhanHoi)
+	} else {
+		d.GhiThatBai(maPhanHoi, guiErr.Error(), job.SoLanThu >= s.queue.cfg.MaxAttempts)
+	}
+	s.luuKetQua(ctx, d)
+
+	if guiErr == nil {
+		logger.CtxInfo(ctx, "Webhook delivered",
+			zap.String("delivery_id", d.ID),
+			zap.String("webhook_id", wh.ID),
+			zap.String("loai", string(d.Loai)),
+			zap.Int("status", maPhanHoi),
+		)
+	}
+	return guiErr
+}
+
+// gui POST body của delivery tới endpoint kèm chữ ký, trả về HTTP status (0 nếu không nhận được phản hồi)
+func (s *QueuedWebhookSender) gui(ctx context.Context, wh *entity.Webhook, d *entity.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(d.DuLieu))
+	if err != nil {
+		return 0, fmt.Errorf("không thể tạo request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(headerWebhookEvent, string(d.Loai))
+	req.Header.Set(headerWebhookDelivery, d.ID)
+	req.Header.Set(headerWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(headerWebhookSignature, entity.KyWebhook(wh.Secret, timestamp, d.DuLieu))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("không thể gửi webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Body phản hồi không được ghi vào nhật ký (có thể chứa dữ liệu của hệ thống bên nhận), chỉ giữ status
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookMaxDocPhanHoi))
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+	return resp.StatusCode, fmt.Errorf("endpoint trả về HTTP %d", resp.StatusCode)
+}
+
+// luuKetQua ghi kết quả lần gửi vào nhật ký
+// Lỗi chỉ được log: trả lỗi sẽ làm job chạy lại và gửi trùng một lần đã thành công
+func (s *QueuedWebhookSender) luuKetQua(ctx context.Context, d *entity.WebhookDelivery) {
+	// Context mới: ctx của job có thể đã hết hạn do endpoint phản hồi chậm
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if err := s.deliveryRepo.Save(saveCtx, d); err != nil {
+		logger.CtxError(ctx, "failed to save webhook delivery result",
+			zap.String("delivery_id", d.ID),
+			zap.String("trang_thai", string(d.TrangThai)),
+			zap.Error(err),
+		)
+	}
+}
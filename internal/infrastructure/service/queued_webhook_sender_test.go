@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/pkg/netguard"
+)
+
+// newTestWebhookSender tạo sender chỉ dùng để gọi gui (không cần queue)
+func newTestWebhookSender(t *testing.T, choPhep ...string) *QueuedWebhookSender {
+	t.Helper()
+	guard, err := netguard.New(choPhep)
+	if err != nil {
+		t.Fatalf("netguard.New() error = %v", err)
+	}
+	return &QueuedWebhookSender{client: &http.Client{Transport: guard.Transport()}}
+}
+
+func newTestDelivery(t *testing.T) *entity.WebhookDelivery {
+	t.Helper()
+	sk, err := entity.NewSuKien("sk-1", entity.SuKienOrderCreated, "order-1", entity.OrderCreatedPayload{OrderID: "order-1"})
+	if err != nil {
+		t.Fatalf("NewSuKien() error = %v", err)
+	}
+	d, err := entity.NewWebhookDelivery("d-1", "wh-1", sk)
+	if err != nil {
+		t.Fatalf("NewWebhookDelivery() error = %v", err)
+	}
+	return d
+}
+
+func TestQueuedWebhookSender_GuiKyChuKy(t *testing.T) {
+	const secret = "whsec_0123456789abcdef"
+	d := newTestDelivery(t)
+
+	var loiKy error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		ts, err := strconv.ParseInt(r.Header.Get(headerWebhookTimestamp), 10, 64)
+		switch {
+		case err != nil:
+			loiKy = err
+		case r.Header.Get(headerWebhookSignature) != entity.KyWebhook(secret, ts, body):
+			loiKy = errors.New("signature mismatch")
+		case r.Header.Get(headerWebhookDelivery) != d.ID || r.Header.Get(headerWebhookEvent) != string(d.Loai):
+			loiKy = errors.New("delivery headers mismatch")
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s := newTestWebhookSender(t, "127.0.0.0/8", "::1")
+	wh := &entity.Webhook{ID: "wh-1", URL: srv.URL, Secret: secret, DangHoatDong: true}
+
+	maPhanHoi, err := s.gui(context.Background(), wh, d)
+	if err != nil || maPhanHoi != http.StatusAccepted {
+		t.Fatalf("gui() = %d, %v, want %d, nil", maPhanHoi, err, http.StatusAccepted)
+	}
+	if loiKy != nil {
+		t.Errorf("receiver verification failed: %v", loiKy)
+	}
+}
+
+func TestQueuedWebhookSender_GuiKhongGhiBodyPhanHoi(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = io.WriteString(w, "db password=hunter2")
+	}))
+	defer srv.Close()
+
+	s := newTestWebhookSender(t, "127.0.0.0/8", "::1")
+	wh := &entity.Webhook{ID: "wh-1", URL: srv.URL, Secret: "whsec_0123456789abcdef", DangHoatDong: true}
+
+	maPhanHoi, err := s.gui(context.Background(), wh, newTestDelivery(t))
+	if maPhanHoi != http.StatusInternalServerError || err == nil {
+		t.Fatalf("gui() = %d, %v, want %d and error", maPhanHoi, err, http.StatusInternalServerError)
+	}
+	if strings.Contains(err.Error(), "hunter2") {
+		t.Errorf("error %q contains response body", err)
+	}
+}
+
+func TestQueuedWebhookSender_GuiChanDiaChiNoiBo(t *testing.T) {
+	goi := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		goi = true
+	}))
+	defer srv.Close()
+
+	s := newTestWebhookSender(t)
+	wh := &entity.Webhook{ID: "wh-1", URL: srv.URL, Secret: "whsec_0123456789abcdef", DangHoatDong: true}
+
+	maPhanHoi, err := s.gui(context.Background(), wh, newTestDelivery(t))
+	if maPhanHoi != 0 || !errors.Is(err, netguard.ErrDiaChiNoiBo) {
+		t.Errorf("gui() = %d, %v, want 0 and ErrDiaChiNoiBo", maPhanHoi, err)
+	}
+	if goi {
+		t.Error("request reached loopback endpoint")
+	}
+}
@@ -163,6 +163,15 @@ func (s *RedisEmailVerificationService) InvalidateAllUserTokens(ctx context.Cont
 	return nil
 }
 
+// CleanupExpiredTokens xóa các token đã hết hạn khỏi set token của user
+func (s *RedisEmailVerificationService) CleanupExpiredTokens(ctx context.Context) (int64, error) {
+	if !s.enabled {
+		return 0, nil
+	}
+
+	return cleanupExpiredTokenSets(ctx, s.client, emailVerifyUserKeyPrefix, emailVerifyTokenKeyPrefix)
+}
+
 // CanResend kiểm tra xem có thể gửi lại email verification không
 func (s *RedisEmailVerificationService) CanResend(ctx context.Context, userID string) (bool, int64, error) {
 	if !s.enabled {
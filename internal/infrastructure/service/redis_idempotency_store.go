@@ -0,0 +1,122 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"restaurant_project/internal/domain/service"
+)
+
+// Đảm bảo RedisIdempotencyStore implement IdempotencyStore
+var _ service.IdempotencyStore = (*RedisIdempotencyStore)(nil)
+
+const (
+	// Key pattern cho Idempotency-Key: idempotency:<key do middleware ghép (user + key của client)>
+	idempotencyKeyPrefix = "idempotency:"
+)
+
+// completeIdempotencyScript ghi response chỉ khi bản ghi vẫn là bản ghi đang xử lý của token
+// (khóa có thể đã hết lockTTL và được request khác giữ lại)
+var completeIdempotencyScript = redis.NewScript(`
+local cur = redis.call('GET', KEYS[1])
+if not cur or cjson.decode(cur)['Token'] ~= ARGV[1] then
+	return 0
+end
+redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+return 1
+`)
+
+// releaseIdempotencyScript chỉ xóa khóa nếu vẫn do token giữ
+var releaseIdempotencyScript = redis.NewScript(`
+local cur = redis.call('GET', KEYS[1])
+if not cur or cjson.decode(cur)['Token'] ~= ARGV[1] then
+	return 0
+end
+return redis.call('DEL', KEYS[1])
+`)
+
+// RedisIdempotencyStore implementation của IdempotencyStore sử dụng Redis
+// Mỗi khóa là một JSON IdempotentResponse, hết hạn theo TTL
+type RedisIdempotencyStore struct {
+	client *redis.Client
+}
+
+// NewRedisIdempotencyStore tạo mới RedisIdempotencyStore
+func NewRedisIdempotencyStore(client *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client}
+}
+
+// Reserve giữ khóa bằng SET NX kèm token ngẫu nhiên, trả về false nếu khóa đã tồn tại
+func (s *RedisIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string, ttl time.Duration) (string, bool, error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", false, fmt.Errorf("failed to generate idempotency token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	data, err := json.Marshal(service.IdempotentResponse{Fingerprint: fingerprint, InProgress: true, Token: token})
+	if err != nil {
+		return "", false, err
+	}
+
+	ok, err := s.client.SetNX(ctx, idempotencyKeyPrefix+key, data, ttl).Result()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if !ok {
+		return "", false, nil
+	}
+	return token, true, nil
+}
+
+// Get lấy bản ghi của khóa
+func (s *RedisIdempotencyStore) Get(ctx context.Context, key string) (*service.IdempotentResponse, error) {
+	data, err := s.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	var resp service.IdempotentResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("invalid idempotency record: %w", err)
+	}
+	return &resp, nil
+}
+
+// Complete thay bản ghi đang xử lý của token bằng response đã hoàn tất
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, key, token string, resp *service.IdempotentResponse, ttl time.Duration) (bool, error) {
+	luu := *resp
+	luu.InProgress = false
+	luu.Token = ""
+	data, err := json.Marshal(luu)
+	if err != nil {
+		return false, err
+	}
+
+	saved, err := completeIdempotencyScript.Run(ctx, s.client,
+		[]string{idempotencyKeyPrefix + key}, token, data, ttl.Milliseconds(),
+	).Int()
+	if err != nil {
+		return false, fmt.Errorf("failed to save idempotent response: %w", err)
+	}
+	return saved == 1, nil
+}
+
+// Release xóa khóa nếu vẫn do token giữ
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key, token string) error {
+	if err := releaseIdempotencyScript.Run(ctx, s.client, []string{idempotencyKeyPrefix + key}, token).Err(); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
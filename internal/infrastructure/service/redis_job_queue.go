@@ -0,0 +1,601 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/pkg/logger"
+)
+
+// Đảm bảo RedisJobQueue implement JobQueue
+var _ service.JobQueue = (*RedisJobQueue)(nil)
+
+const (
+	// Keys của job queue
+	jobStreamKey     = "jobs:stream" // Stream chứa job sẵn sàng chạy (consumer group jobs:workers)
+	jobRetryKey      = "jobs:retry"  // Sorted set job chờ thử lại, score = thời điểm chạy (unix ms)
+	jobDeadLetterKey = "jobs:dead"   // List job thất bại vĩnh viễn, mới nhất ở đầu
+	jobGroupName     = "jobs:workers"
+
+	jobMessageField = "job" // Field chứa job JSON trong stream entry
+
+	jobReadBlock       = 2 * time.Second // Thời gian block mỗi lần XREADGROUP
+	jobRetryPollPeriod = time.Second     // Chu kỳ chuyển job đến hạn thử lại vào stream
+	jobRetryBatchSize  = 100
+)
+
+// promoteRetryScript chuyển các job đến hạn từ sorted set retry sang stream
+// Chạy atomic để nhiều instance cùng poll không đẩy trùng một job
+var promoteRetryScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+for _, job in ipairs(due) do
+	redis.call('ZREM', KEYS[1], job)
+	redis.call('XADD', KEYS[2], '*', ARGV[3], job)
+end
+return #due
+`)
+
+// jobMessage là dạng JSON của job lưu trong Redis
+type jobMessage struct {
+	ID         string          `json:"id"`
+	Loai       string          `json:"loai"`
+	DuLieu     json.RawMessage `json:"du_lieu"`
+	SoLanThu   int             `json:"so_lan_thu"`
+	LoiCuoi    string          `json:"loi_cuoi,omitempty"`
+	TaoLuc     time.Time       `json:"tao_luc"`
+	ThatBaiLuc time.Time       `json:"that_bai_luc,omitzero"`
+}
+
+func (m *jobMessage) toJob() service.Job {
+	return service.Job{
+		ID:         m.ID,
+		Loai:       m.Loai,
+		DuLieu:     []byte(m.DuLieu),
+		SoLanThu:   m.SoLanThu,
+		LoiCuoi:    m.LoiCuoi,
+		TaoLuc:     m.TaoLuc,
+		ThatBaiLuc: m.ThatBaiLuc,
+	}
+}
+
+// RedisJobQueue implementation của JobQueue sử dụng Redis Streams
+//
+// Luồng xử lý:
+//   - Enqueue: XADD vào stream
+//   - Worker: XREADGROUP → chạy handler → XACK + XDEL
+//   - Thất bại: đưa vào sorted set retry với exponential backoff, hết lượt thì LPUSH vào dead-letter list
+//   - Worker chết giữa chừng: entry nằm trong pending list, sau VisibilityTimeout được XAUTOCLAIM nhận lại
+type RedisJobQueue struct {
+	client   *redis.Client
+	cfg      config.JobQueueConfig
+	consumer string
+
+	mu       sync.RWMutex
+	handlers map[string]service.JobHandler
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewRedisJobQueue tạo mới RedisJobQueue
+// Handler phải được đăng ký bằng Register trước khi gọi Start.
+// cfg.JobTimeout phải nhỏ hơn cfg.VisibilityTimeout (provider kiểm tra lúc khởi động)
+func NewRedisJobQueue(client *redis.Client, cfg config.JobQueueConfig) *RedisJobQueue {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.BackoffMax < cfg.BackoffBase {
+		cfg.BackoffMax = cfg.BackoffBase
+	}
+
+	hostname, _ := os.Hostname()
+	return &RedisJobQueue{
+		client:   client,
+		cfg:      cfg,
+		consumer: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		handlers: make(map[string]service.JobHandler),
+	}
+}
+
+// Register đăng ký handler cho một loại job
+func (q *RedisJobQueue) Register(loai string, handler service.JobHandler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[loai] = handler
+}
+
+// Enqueue đưa job mới vào stream
+func (q *RedisJobQueue) Enqueue(ctx context.Context, loai string, payload any) (string, error) {
+	duLieu, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	msg := jobMessage{
+		ID:     uuid.New().String(),
+		Loai:   loai,
+		DuLieu: duLieu,
+		TaoLuc: time.Now(),
+	}
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job: %w", err)
+	}
+
+	if err := q.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: jobStreamKey,
+		Values: map[string]interface{}{jobMessageField: raw},
+	}).Err(); err != nil {
+		return "", fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	return msg.ID, nil
+}
+
+// ListDeadLetter liệt kê job trong dead-letter list, mới nhất trước
+func (q *RedisJobQueue) ListDeadLetter(ctx context.Context, offset, limit int64) ([]service.Job, int64, error) {
+	total, err := q.client.LLen(ctx, jobDeadLetterKey).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count dead-letter jobs: %w", err)
+	}
+	if limit <= 0 || offset >= total {
+		return []service.Job{}, total, nil
+	}
+
+	raws, err := q.client.LRange(ctx, jobDeadLetterKey, offset, offset+limit-1).Result()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list dead-letter jobs: %w", err)
+	}
+
+	jobs := make([]service.Job, 0, len(raws))
+	for _, raw := range raws {
+		var msg jobMessage
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			// Entry hỏng vẫn hiển thị để admin biết, không làm hỏng cả danh sách
+			jobs = append(jobs, service.Job{LoiCuoi: "invalid job encoding: " + err.Error()})
+			continue
+		}
+		jobs = append(jobs, msg.toJob())
+	}
+
+	return jobs, total, nil
+}
+
+// ReplayDeadLetter đưa job thất bại trở lại stream với số lần thử reset về 0
+func (q *RedisJobQueue) ReplayDeadLetter(ctx context.Context, id string) error {
+	raws, err := q.client.LRange(ctx, jobDeadLetterKey, 0, -1).Result()
+	if err != nil {
+		return fmt.Errorf("failed to read dead-letter jobs: %w", err)
+	}
+
+	for _, raw := range raws {
+		var msg jobMessage
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil || msg.ID != id {
+			continue
+		}
+
+		msg.SoLanThu = 0
+		msg.LoiCuoi = ""
+		msg.ThatBaiLuc = time.Time{}
+		replay, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to encode job: %w", err)
+		}
+
+		// LREM trước: admin bấm replay hai lần cùng lúc thì chỉ một lần đẩy job vào stream
+		removed, err := q.client.LRem(ctx, jobDeadLetterKey, 1, raw).Result()
+		if err != nil {
+			return fmt.Errorf("failed to remove dead-letter job: %w", err)
+		}
+		if removed == 0 {
+			return service.ErrJobNotFound
+		}
+
+		if err := q.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: jobStreamKey,
+			Values: map[string]interface{}{jobMessageField: replay},
+		}).Err(); err != nil {
+			// Trả job về dead-letter để không bị mất
+			q.client.LPush(ctx, jobDeadLetterKey, raw)
+			return fmt.Errorf("failed to enqueue replayed job: %w", err)
+		}
+
+		logger.CtxInfo(ctx, "Dead-letter job replayed",
+			zap.String("job_id", msg.ID),
+			zap.String("loai", msg.Loai),
+		)
+		return nil
+	}
+
+	return service.ErrJobNotFound
+}
+
+// Stats trả về số job theo từng trạng thái
+func (q *RedisJobQueue) Stats(ctx context.Context) (service.JobQueueStats, error) {
+	pipe := q.client.Pipeline()
+	streamLen := pipe.XLen(ctx, jobStreamKey)
+	retryLen := pipe.ZCard(ctx, jobRetryKey)
+	deadLen := pipe.LLen(ctx, jobDeadLetterKey)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return service.JobQueueStats{}, fmt.Errorf("failed to get job queue stats: %w", err)
+	}
+
+	return service.JobQueueStats{
+		DangCho:   streamLen.Val(),
+		ChoThuLai: retryLen.Val(),
+		ThatBai:   deadLen.Val(),
+	}, nil
+}
+
+// ============================================================
+// Worker pool
+// ============================================================
+
+// Start tạo consumer group (nếu chưa có) và khởi chạy worker pool
+func (q *RedisJobQueue) Start(ctx context.Context) error {
+	err := q.client.XGroupCreateMkStream(ctx, jobStreamKey, jobGroupName, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create job consumer group: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	q.cancel = cancel
+
+	for i := 0; i < q.cfg.Workers; i++ {
+		q.wg.Add(1)
+		go q.work(runCtx, fmt.Sprintf("%s-%d", q.consumer, i))
+	}
+
+	q.wg.Add(2)
+	go q.promoteRetries(runCtx)
+	go q.reclaimStale(runCtx)
+
+	logger.Info("Job queue started",
+		zap.Int("workers", q.cfg.Workers),
+		zap.Int("max_attempts", q.cfg.MaxAttempts),
+	)
+	return nil
+}
+
+// Stop dừng nhận job mới và chờ các job đang chạy kết thúc
+func (q *RedisJobQueue) Stop(ctx context.Context) error {
+	if q.cancel == nil {
+		return nil
+	}
+	q.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return fmt.Errorf("job queue stop: %w", ctx.Err())
+	}
+
+	// Xóa consumer không còn job pending để consumer group không phình theo mỗi lần restart
+	for i := 0; i < q.cfg.Workers; i++ {
+		name := fmt.Sprintf("%s-%d", q.consumer, i)
+		pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream:   jobStreamKey,
+			Group:    jobGroupName,
+			Consumer: name,
+			Start:    "-",
+			End:      "+",
+			Count:    1,
+		}).Result()
+		if err == nil && len(pending) == 0 {
+			q.client.XGroupDelConsumer(ctx, jobStreamKey, jobGroupName, name)
+		}
+	}
+
+	logger.Info("Job queue stopped")
+	return nil
+}
+
+// work là vòng lặp của một worker
+func (q *RedisJobQueue) work(ctx context.Context, consumer string) {
+	defer q.wg.Done()
+
+	for ctx.Err() == nil {
+		streams, err := q.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    jobGroupName,
+			Consumer: consumer,
+			Streams:  []string{jobStreamKey, ">"},
+			Count:    1,
+			Block:    jobReadBlock,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, redis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			logger.Error("Job queue read failed", zap.String("consumer", consumer), zap.Error(err))
+			sleepCtx(ctx, time.Second)
+			continue
+		}
+
+		for _, stream := range streams {
+			for _, entry := range stream.Messages {
+				q.process(entry, 1)
+			}
+		}
+	}
+}
+
+// promoteRetries định kỳ chuyển job đến hạn thử lại vào stream
+func (q *RedisJobQueue) promoteRetries(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(jobRetryPollPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err := promoteRetryScript.Run(ctx, q.client,
+				[]string{jobRetryKey, jobStreamKey},
+				time.Now().UnixMilli(), jobRetryBatchSize, jobMessageField,
+			).Err()
+			if err != nil && ctx.Err() == nil {
+				logger.Error("Job queue retry promotion failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// reclaimStale nhận lại các job pending quá VisibilityTimeout (worker xử lý đã chết)
+func (q *RedisJobQueue) reclaimStale(ctx context.Context) {
+	defer q.wg.Done()
+
+	ticker := time.NewTicker(q.cfg.VisibilityTimeout / 2)
+	defer ticker.Stop()
+
+	consumer := q.consumer + "-reclaim"
+	for {
+		select {
+		case <-ctx.Done():
+			q.client.XGroupDelConsumer(context.Background(), jobStreamKey, jobGroupName, consumer)
+			return
+		case <-ticker.C:
+			start := "0-0"
+			for ctx.Err() == nil {
+				entries, next, err := q.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+					Stream:   jobStreamKey,
+					Group:    jobGroupName,
+					Consumer: consumer,
+					MinIdle:  q.cfg.VisibilityTimeout,
+					Start:    start,
+					Count:    int64(q.cfg.Workers),
+				}).Result()
+				if err != nil {
+					if ctx.Err() == nil {
+						logger.Error("Job queue reclaim failed", zap.Error(err))
+					}
+					break
+				}
+				for _, entry := range entries {
+					daGiao := q.soLanGiao(ctx, consumer, entry.ID)
+					logger.Warn("Reclaimed stale job",
+						zap.String("entry_id", entry.ID),
+						zap.Int64("so_lan_giao", daGiao),
+					)
+					q.process(entry, daGiao)
+				}
+				if next == "0-0" {
+					break
+				}
+				start = next
+			}
+		}
+	}
+}
+
+// soLanGiao lấy số lần entry đã được giao cho worker (times-delivered trong pending list)
+// Không đọc được thì coi như đã giao 2 lần: entry bị nhận lại nghĩa là lần giao trước đã bị gián đoạn
+func (q *RedisJobQueue) soLanGiao(ctx context.Context, consumer, entryID string) int64 {
+	pending, err := q.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream:   jobStreamKey,
+		Group:    jobGroupName,
+		Consumer: consumer,
+		Start:    entryID,
+		End:      entryID,
+		Count:    1,
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		return 2
+	}
+	return max(pending[0].RetryCount, 1)
+}
+
+// process chạy handler cho một stream entry và ghi nhận kết quả
+// daGiao: số lần entry đã được giao (1 = lần đầu). Các lần giao trước bị gián đoạn (worker chết,
+// OOM, chạy quá VisibilityTimeout) không kịp ghi kết quả nhưng vẫn tính là một lần thử,
+// để job làm sập worker cũng tới MaxAttempts và vào dead-letter
+// Dùng context riêng: job đang chạy được hoàn thành kể cả khi queue đang dừng
+func (q *RedisJobQueue) process(entry redis.XMessage, daGiao int64) {
+	raw, ok := entry.Values[jobMessageField].(string)
+	if !ok && len(entry.Values) == 0 {
+		// Entry đã bị XDEL trong lúc còn pending (XAUTOCLAIM trả về entry rỗng): chỉ cần ack
+		q.client.XAck(context.Background(), jobStreamKey, jobGroupName, entry.ID)
+		return
+	}
+
+	var msg jobMessage
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		msg = jobMessage{ID: entry.ID, DuLieu: json.RawMessage("null"), TaoLuc: time.Now()}
+		q.deadLetter(entry.ID, &msg, fmt.Errorf("invalid job encoding: %w", err))
+		return
+	}
+
+	if biGianDoan := int(daGiao - 1); biGianDoan > 0 {
+		msg.SoLanThu += biGianDoan
+		if msg.SoLanThu >= q.cfg.MaxAttempts {
+			q.deadLetter(entry.ID, &msg, fmt.Errorf("job interrupted %d times without completing (worker crashed or exceeded visibility timeout)", biGianDoan))
+			return
+		}
+	}
+
+	jobLogger := logger.With(zap.String("job_id", msg.ID), zap.String("loai", msg.Loai))
+	ctx, cancel := context.WithTimeout(logger.WithContext(context.Background(), jobLogger), q.cfg.JobTimeout)
+	defer cancel()
+
+	job := msg.toJob()
+	job.SoLanThu++
+	err := q.runHandler(ctx, &job)
+	msg.SoLanThu = job.SoLanThu
+
+	if err == nil {
+		// Context mới: ctx của job có thể đã hết hạn ngay khi handler vừa xong,
+		// ack bằng ctx đó sẽ lỗi và job đã chạy thành công bị worker khác nhận lại
+		ackCtx, ackCancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer ackCancel()
+
+		pipe := q.client.TxPipeline()
+		pipe.XAck(ackCtx, jobStreamKey, jobGroupName, entry.ID)
+		pipe.XDel(ackCtx, jobStreamKey, entry.ID)
+		if _, err := pipe.Exec(ackCtx); err != nil {
+			jobLogger.Error("Failed to ack job", zap.Error(err))
+		}
+		return
+	}
+
+	if msg.SoLanThu >= q.cfg.MaxAttempts {
+		q.deadLetter(entry.ID, &msg, err)
+		return
+	}
+
+	msg.LoiCuoi = err.Error()
+	delay := q.backoff(msg.SoLanThu)
+	retry, encErr := json.Marshal(msg)
+	if encErr != nil {
+		jobLogger.Error("Failed to encode job for retry", zap.Error(encErr))
+		return
+	}
+
+	// Context mới: ctx của job có thể đã hết hạn do handler chạy quá lâu
+	saveCtx, saveCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer saveCancel()
+
+	pipe := q.client.TxPipeline()
+	pipe.ZAdd(saveCtx, jobRetryKey, redis.Z{
+		Score:  float64(time.Now().Add(delay).UnixMilli()),
+		Member: retry,
+	})
+	pipe.XAck(saveCtx, jobStreamKey, jobGroupName, entry.ID)
+	pipe.XDel(saveCtx, jobStreamKey, entry.ID)
+	if _, err := pipe.Exec(saveCtx); err != nil {
+		jobLogger.Error("Failed to schedule job retry", zap.Error(err))
+		return
+	}
+
+	jobLogger.Warn("Job failed, will retry",
+		zap.Int("so_lan_thu", msg.SoLanThu),
+		zap.Duration("retry_in", delay),
+		zap.Error(err),
+	)
+}
+
+// runHandler gọi handler của job, panic được chuyển thành error để job được thử lại
+func (q *RedisJobQueue) runHandler(ctx context.Context, job *service.Job) (err error) {
+	q.mu.RLock()
+	handler, ok := q.handlers[job.Loai]
+	q.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("no handler registered for job type %q", job.Loai)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, job)
+}
+
+// deadLetter chuyển job vào dead-letter list và xóa khỏi stream
+func (q *RedisJobQueue) deadLetter(entryID string, msg *jobMessage, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msg.LoiCuoi = cause.Error()
+	msg.ThatBaiLuc = time.Now()
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		logger.Error("Failed to encode dead-letter job", zap.String("job_id", msg.ID), zap.Error(err))
+		return
+	}
+
+	pipe := q.client.TxPipeline()
+	pipe.LPush(ctx, jobDeadLetterKey, raw)
+	if q.cfg.DeadLetterMaxLen > 0 {
+		pipe.LTrim(ctx, jobDeadLetterKey, 0, int64(q.cfg.DeadLetterMaxLen)-1)
+	}
+	pipe.XAck(ctx, jobStreamKey, jobGroupName, entryID)
+	pipe.XDel(ctx, jobStreamKey, entryID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error("Failed to move job to dead-letter", zap.String("job_id", msg.ID), zap.Error(err))
+		return
+	}
+
+	logger.Error("Job moved to dead-letter",
+		zap.String("job_id", msg.ID),
+		zap.String("loai", msg.Loai),
+		zap.Int("so_lan_thu", msg.SoLanThu),
+		zap.Error(cause),
+	)
+}
+
+// backoff tính thời gian chờ trước lần thử tiếp theo của job
+func (q *RedisJobQueue) backoff(soLanThu int) time.Duration {
+	return expBackoff(q.cfg.BackoffBase, q.cfg.BackoffMax, soLanThu)
+}
+
+// expBackoff tính thời gian chờ: base * 2^(soLanThu-1), tối đa maxDelay
+// Thêm jitter (50%-100%) để các lần lỗi cùng lúc không dồn vào cùng một thời điểm
+func expBackoff(base, maxDelay time.Duration, soLanThu int) time.Duration {
+	delay := base
+	for i := 1; i < soLanThu && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxDelay)
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(half)+1))
+}
+
+// sleepCtx ngủ trong khoảng d hoặc đến khi ctx bị hủy
+func sleepCtx(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
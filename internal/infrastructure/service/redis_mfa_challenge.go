@@ -0,0 +1,123 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Đảm bảo RedisMFAChallengeService implement MFAChallengeService
+var _ service.MFAChallengeService = (*RedisMFAChallengeService)(nil)
+
+const (
+	// Key pattern cho MFA challenge
+	mfaChallengeKeyPrefix = "mfa_challenge:" // mfa_challenge:{sha256(token)} -> Hash {user_id, dang_ky, that_bai}
+
+	mfaFieldUserID  = "user_id"
+	mfaFieldDangKy  = "dang_ky"
+	mfaFieldThatBai = "that_bai"
+)
+
+// recordFailureScript tăng số lần sai chỉ khi challenge còn tồn tại (tránh tạo lại key không TTL),
+// xóa challenge khi đạt giới hạn. Trả về số lần sai, -1 nếu challenge không còn
+var recordFailureScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+local n = redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+if n >= tonumber(ARGV[2]) then
+	redis.call('DEL', KEYS[1])
+end
+return n
+`)
+
+// RedisMFAChallengeService implementation của MFAChallengeService sử dụng Redis
+type RedisMFAChallengeService struct {
+	client      *redis.Client
+	ttl         time.Duration
+	maxAttempts int
+}
+
+// NewRedisMFAChallengeService tạo mới RedisMFAChallengeService
+func NewRedisMFAChallengeService(client *redis.Client, cfg config.MFAConfig) *RedisMFAChallengeService {
+	return &RedisMFAChallengeService{
+		client:      client,
+		ttl:         cfg.ChallengeTTL,
+		maxAttempts: cfg.MaxAttempts,
+	}
+}
+
+// CreateChallenge tạo challenge token mới
+func (s *RedisMFAChallengeService) CreateChallenge(ctx context.Context, challenge service.MFAChallenge) (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("failed to generate mfa challenge: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	key := mfaChallengeKeyPrefix + hashResetToken(token)
+
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, key,
+		mfaFieldUserID, challenge.UserID,
+		mfaFieldDangKy, strconv.FormatBool(challenge.YeuCauDangKy),
+		mfaFieldThatBai, 0,
+	)
+	pipe.Expire(ctx, key, s.ttl)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to store mfa challenge: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetChallenge lấy challenge theo token
+func (s *RedisMFAChallengeService) GetChallenge(ctx context.Context, token string) (*service.MFAChallenge, error) {
+	fields, err := s.client.HGetAll(ctx, mfaChallengeKeyPrefix+hashResetToken(token)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mfa challenge: %w", err)
+	}
+	if fields[mfaFieldUserID] == "" {
+		return nil, service.ErrMFAChallengeNotFound
+	}
+
+	dangKy, _ := strconv.ParseBool(fields[mfaFieldDangKy])
+	return &service.MFAChallenge{
+		UserID:       fields[mfaFieldUserID],
+		YeuCauDangKy: dangKy,
+	}, nil
+}
+
+// RecordFailure tăng số lần nhập sai, xóa challenge khi đạt giới hạn
+func (s *RedisMFAChallengeService) RecordFailure(ctx context.Context, token string) (int, error) {
+	thatBai, err := recordFailureScript.Run(ctx, s.client,
+		[]string{mfaChallengeKeyPrefix + hashResetToken(token)},
+		mfaFieldThatBai, s.maxAttempts,
+	).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to record mfa failure: %w", err)
+	}
+	if thatBai < 0 {
+		return 0, service.ErrMFAChallengeNotFound
+	}
+
+	return max(s.maxAttempts-thatBai, 0), nil
+}
+
+// DeleteChallenge xóa challenge, chỉ một request xóa thành công
+func (s *RedisMFAChallengeService) DeleteChallenge(ctx context.Context, token string) (bool, error) {
+	n, err := s.client.Del(ctx, mfaChallengeKeyPrefix+hashResetToken(token)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete mfa challenge: %w", err)
+	}
+	return n == 1, nil
+}
@@ -0,0 +1,91 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Đảm bảo RedisOIDCStateService implement OIDCStateService
+var _ service.OIDCStateService = (*RedisOIDCStateService)(nil)
+
+const (
+	// Key pattern cho OIDC state
+	oidcStateKeyPrefix = "oidc_state:" // oidc_state:{sha256(state)} -> Hash {provider, code_verifier, nonce, link_user_id}
+
+	oidcFieldProvider     = "provider"
+	oidcFieldCodeVerifier = "code_verifier"
+	oidcFieldNonce        = "nonce"
+	oidcFieldLinkUserID   = "link_user_id"
+)
+
+// RedisOIDCStateService implementation của OIDCStateService sử dụng Redis
+type RedisOIDCStateService struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewRedisOIDCStateService tạo mới RedisOIDCStateService
+func NewRedisOIDCStateService(client *redis.Client, cfg config.OIDCConfig) *RedisOIDCStateService {
+	return &RedisOIDCStateService{
+		client: client,
+		ttl:    cfg.StateTTL,
+	}
+}
+
+// CreateState tạo state ngẫu nhiên và lưu trạng thái đăng nhập
+func (s *RedisOIDCStateService) CreateState(ctx context.Context, request service.OIDCAuthRequest) (string, error) {
+	stateBytes := make([]byte, 32)
+	if _, err := rand.Read(stateBytes); err != nil {
+		return "", fmt.Errorf("failed to generate oidc state: %w", err)
+	}
+	state := hex.EncodeToString(stateBytes)
+	key := oidcStateKeyPrefix + hashResetToken(state)
+
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, key,
+		oidcFieldProvider, request.Provider,
+		oidcFieldCodeVerifier, request.CodeVerifier,
+		oidcFieldNonce, request.Nonce,
+		oidcFieldLinkUserID, request.LinkUserID,
+	)
+	pipe.Expire(ctx, key, s.ttl)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to store oidc state: %w", err)
+	}
+
+	return state, nil
+}
+
+// ConsumeState lấy và xóa trạng thái trong cùng transaction, chỉ một request nhận được
+func (s *RedisOIDCStateService) ConsumeState(ctx context.Context, state string) (*service.OIDCAuthRequest, error) {
+	key := oidcStateKeyPrefix + hashResetToken(state)
+
+	pipe := s.client.TxPipeline()
+	getCmd := pipe.HGetAll(ctx, key)
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to consume oidc state: %w", err)
+	}
+
+	fields := getCmd.Val()
+	if fields[oidcFieldProvider] == "" {
+		return nil, service.ErrOIDCStateNotFound
+	}
+
+	return &service.OIDCAuthRequest{
+		Provider:     fields[oidcFieldProvider],
+		CodeVerifier: fields[oidcFieldCodeVerifier],
+		Nonce:        fields[oidcFieldNonce],
+		LinkUserID:   fields[oidcFieldLinkUserID],
+	}, nil
+}
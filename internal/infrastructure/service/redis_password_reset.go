@@ -0,0 +1,150 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Đảm bảo RedisPasswordResetService implement PasswordResetService
+var _ service.PasswordResetService = (*RedisPasswordResetService)(nil)
+
+const (
+	// Key patterns for password reset
+	passwordResetTokenKeyPrefix    = "pwd_reset:"      // pwd_reset:{sha256(token)} -> userID
+	passwordResetUserKeyPrefix     = "pwd_reset_user:" // pwd_reset_user:{userID} -> Set of sha256(token)
+	passwordResetCooldownKeyPrefix = "pwd_reset_cd:"   // pwd_reset_cd:{userID} -> "1"
+)
+
+// RedisPasswordResetService implementation của PasswordResetService sử dụng Redis
+// Chỉ lưu SHA-256 của token: lộ dữ liệu Redis cũng không dùng được để đặt lại mật khẩu
+type RedisPasswordResetService struct {
+	client      *redis.Client
+	tokenTTL    time.Duration
+	cooldownTTL time.Duration
+}
+
+// NewRedisPasswordResetService tạo mới RedisPasswordResetService
+func NewRedisPasswordResetService(
+	client *redis.Client,
+	cfg config.PasswordResetConfig,
+) *RedisPasswordResetService {
+	return &RedisPasswordResetService{
+		client:      client,
+		tokenTTL:    cfg.TokenTTL,
+		cooldownTTL: cfg.CooldownTTL,
+	}
+}
+
+// hashResetToken băm token trước khi dùng làm key
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateToken tạo token mới cho user
+func (s *RedisPasswordResetService) GenerateToken(ctx context.Context, userID string) (string, error) {
+	// Generate random token (32 bytes = 64 hex characters)
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	tokenHash := hashResetToken(token)
+
+	userKey := passwordResetUserKeyPrefix + userID
+
+	pipe := s.client.Pipeline()
+
+	// Set hash(token) -> userID với TTL
+	pipe.Set(ctx, passwordResetTokenKeyPrefix+tokenHash, userID, s.tokenTTL)
+
+	// Add hash to user's token set (để có thể invalidate all tokens của user)
+	pipe.SAdd(ctx, userKey, tokenHash)
+	pipe.Expire(ctx, userKey, s.tokenTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to store password reset token: %w", err)
+	}
+
+	return token, nil
+}
+
+// ConsumeToken lấy userID và xóa token trong cùng một lệnh (GETDEL) nên token chỉ dùng được một lần
+func (s *RedisPasswordResetService) ConsumeToken(ctx context.Context, token string) (string, error) {
+	tokenHash := hashResetToken(token)
+
+	userID, err := s.client.GetDel(ctx, passwordResetTokenKeyPrefix+tokenHash).Result()
+	if err == redis.Nil {
+		return "", fmt.Errorf("invalid or expired password reset token")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to consume password reset token: %w", err)
+	}
+
+	// Dọn hash khỏi user's token set (không ảnh hưởng kết quả nếu lỗi)
+	_ = s.client.SRem(ctx, passwordResetUserKeyPrefix+userID, tokenHash).Err()
+
+	return userID, nil
+}
+
+// InvalidateAllUserTokens xóa tất cả reset tokens của một user
+func (s *RedisPasswordResetService) InvalidateAllUserTokens(ctx context.Context, userID string) error {
+	userKey := passwordResetUserKeyPrefix + userID
+
+	hashes, err := s.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get user reset tokens: %w", err)
+	}
+
+	pipe := s.client.Pipeline()
+	for _, h := range hashes {
+		pipe.Del(ctx, passwordResetTokenKeyPrefix+h)
+	}
+	pipe.Del(ctx, userKey)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to invalidate user reset tokens: %w", err)
+	}
+
+	return nil
+}
+
+// CleanupExpiredTokens xóa hash của các reset token đã hết hạn khỏi set token của user
+func (s *RedisPasswordResetService) CleanupExpiredTokens(ctx context.Context) (int64, error) {
+	return cleanupExpiredTokenSets(ctx, s.client, passwordResetUserKeyPrefix, passwordResetTokenKeyPrefix)
+}
+
+// CanResend kiểm tra xem có thể gửi lại email đặt lại mật khẩu không
+func (s *RedisPasswordResetService) CanResend(ctx context.Context, userID string) (bool, int64, error) {
+	ttl, err := s.client.TTL(ctx, passwordResetCooldownKeyPrefix+userID).Result()
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to check resend cooldown: %w", err)
+	}
+
+	// Key doesn't exist or has expired
+	if ttl < 0 {
+		return true, 0, nil
+	}
+
+	return false, int64(ttl.Seconds()), nil
+}
+
+// SetResendCooldown đặt cooldown sau khi gửi email
+func (s *RedisPasswordResetService) SetResendCooldown(ctx context.Context, userID string) error {
+	err := s.client.Set(ctx, passwordResetCooldownKeyPrefix+userID, "1", s.cooldownTTL).Err()
+	if err != nil {
+		return fmt.Errorf("failed to set resend cooldown: %w", err)
+	}
+
+	return nil
+}
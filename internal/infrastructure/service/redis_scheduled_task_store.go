@@ -0,0 +1,233 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Đảm bảo RedisScheduledTaskStore implement ScheduledTaskStore
+var _ service.ScheduledTaskStore = (*RedisScheduledTaskStore)(nil)
+
+const (
+	// Key patterns cho scheduler
+	schedulerTasksKey        = "scheduler:tasks" // Set tên các tác vụ đã đăng ký
+	schedulerTaskKeyPrefix   = "scheduler:task:" // scheduler:task:{tên} -> Hash trạng thái
+	schedulerLockKeyPrefix   = "scheduler:lock:" // scheduler:lock:{tên} -> token của instance đang chạy
+	schedulerSlotKeyPrefix   = "scheduler:slot:" // scheduler:slot:{tên} -> slot gần nhất đã giành (unix ms)
+	schedulerRunsKeyPrefix   = "scheduler:runs:" // scheduler:runs:{tên} -> List lịch sử chạy, mới nhất ở đầu
+	schedulerFieldLich       = "lich"
+	schedulerFieldTiepTheo   = "chay_tiep_theo"
+	schedulerFieldLanCuoi    = "lan_chay_cuoi"
+	schedulerFieldLoiCuoi    = "loi_cuoi"
+	schedulerFieldLoiCuoiLuc = "loi_cuoi_luc"
+)
+
+// tryLockScript giành khóa cho một slot: chỉ thành công khi slot mới hơn slot đã chạy và không ai giữ khóa
+// Slot được ghi ngay khi giành khóa để instance có đồng hồ lệch vài giây không chạy lại cùng slot
+var tryLockScript = redis.NewScript(`
+local last = tonumber(redis.call('GET', KEYS[2]) or '0')
+if last >= tonumber(ARGV[3]) then
+	return 0
+end
+if not redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 0
+end
+redis.call('SET', KEYS[2], ARGV[3])
+return 1
+`)
+
+// unlockScript chỉ xóa khóa nếu vẫn là khóa của mình (khóa có thể đã hết hạn và bị instance khác giành)
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// taskRunRecord là dạng JSON của TaskRun lưu trong Redis
+type taskRunRecord struct {
+	TacVu    string    `json:"tac_vu"`
+	LichChay time.Time `json:"lich_chay"`
+	BatDau   time.Time `json:"bat_dau"`
+	KetThuc  time.Time `json:"ket_thuc"`
+	Loi      string    `json:"loi,omitempty"`
+	KetQua   string    `json:"ket_qua,omitempty"`
+	Instance string    `json:"instance"`
+}
+
+func toTaskRunRecord(run service.TaskRun) taskRunRecord {
+	return taskRunRecord(run)
+}
+
+func (r taskRunRecord) toTaskRun() service.TaskRun {
+	return service.TaskRun(r)
+}
+
+// RedisScheduledTaskStore implementation của ScheduledTaskStore sử dụng Redis
+type RedisScheduledTaskStore struct {
+	client      *redis.Client
+	historySize int64
+}
+
+// NewRedisScheduledTaskStore tạo mới RedisScheduledTaskStore
+func NewRedisScheduledTaskStore(client *redis.Client, cfg config.SchedulerConfig) *RedisScheduledTaskStore {
+	historySize := int64(cfg.HistorySize)
+	if historySize < 1 {
+		historySize = 1
+	}
+	return &RedisScheduledTaskStore{
+		client:      client,
+		historySize: historySize,
+	}
+}
+
+// RegisterTask ghi nhận tác vụ và lần chạy kế tiếp
+func (s *RedisScheduledTaskStore) RegisterTask(ctx context.Context, tacVu, lich string, chayTiepTheo time.Time) error {
+	pipe := s.client.Pipeline()
+	pipe.SAdd(ctx, schedulerTasksKey, tacVu)
+	pipe.HSet(ctx, schedulerTaskKeyPrefix+tacVu,
+		schedulerFieldLich, lich,
+		schedulerFieldTiepTheo, chayTiepTheo.Format(time.RFC3339),
+	)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to register scheduled task: %w", err)
+	}
+	return nil
+}
+
+// TryLock giành quyền chạy một slot của tác vụ
+func (s *RedisScheduledTaskStore) TryLock(ctx context.Context, tacVu string, lichChay time.Time, ttl time.Duration) (func(), bool, error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, false, fmt.Errorf("failed to generate lock token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	lockKey := schedulerLockKeyPrefix + tacVu
+
+	acquired, err := tryLockScript.Run(ctx, s.client,
+		[]string{lockKey, schedulerSlotKeyPrefix + tacVu},
+		token, ttl.Milliseconds(), lichChay.UnixMilli(),
+	).Int()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to acquire scheduler lock: %w", err)
+	}
+	if acquired == 0 {
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		// Context riêng: vẫn mở khóa được khi context của tác vụ đã hết hạn
+		unlockCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		unlockScript.Run(unlockCtx, s.client, []string{lockKey}, token)
+	}
+	return unlock, true, nil
+}
+
+// RecordRun lưu kết quả một lần chạy
+func (s *RedisScheduledTaskStore) RecordRun(ctx context.Context, run service.TaskRun) error {
+	raw, err := json.Marshal(toTaskRunRecord(run))
+	if err != nil {
+		return fmt.Errorf("failed to encode task run: %w", err)
+	}
+
+	runsKey := schedulerRunsKeyPrefix + run.TacVu
+	taskKey := schedulerTaskKeyPrefix + run.TacVu
+
+	pipe := s.client.TxPipeline()
+	pipe.LPush(ctx, runsKey, raw)
+	pipe.LTrim(ctx, runsKey, 0, s.historySize-1)
+	pipe.HSet(ctx, taskKey, schedulerFieldLanCuoi, raw)
+	if run.Loi != "" {
+		pipe.HSet(ctx, taskKey,
+			schedulerFieldLoiCuoi, run.Loi,
+			schedulerFieldLoiCuoiLuc, run.KetThuc.Format(time.RFC3339),
+		)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to record task run: %w", err)
+	}
+	return nil
+}
+
+// ListTasks lấy trạng thái của tất cả tác vụ, sắp xếp theo tên
+func (s *RedisScheduledTaskStore) ListTasks(ctx context.Context) ([]service.TaskStatus, error) {
+	names, err := s.client.SMembers(ctx, schedulerTasksKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled tasks: %w", err)
+	}
+	sort.Strings(names)
+
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(names))
+	for i, name := range names {
+		cmds[i] = pipe.HGetAll(ctx, schedulerTaskKeyPrefix+name)
+	}
+	if len(names) > 0 {
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, fmt.Errorf("failed to read scheduled tasks: %w", err)
+		}
+	}
+
+	result := make([]service.TaskStatus, 0, len(names))
+	for i, name := range names {
+		fields := cmds[i].Val()
+		status := service.TaskStatus{
+			TacVu:   name,
+			Lich:    fields[schedulerFieldLich],
+			LoiCuoi: fields[schedulerFieldLoiCuoi],
+		}
+		if t, err := time.Parse(time.RFC3339, fields[schedulerFieldTiepTheo]); err == nil {
+			status.ChayTiepTheo = t
+		}
+		if t, err := time.Parse(time.RFC3339, fields[schedulerFieldLoiCuoiLuc]); err == nil {
+			status.LoiCuoiLuc = &t
+		}
+		if raw := fields[schedulerFieldLanCuoi]; raw != "" {
+			var rec taskRunRecord
+			if err := json.Unmarshal([]byte(raw), &rec); err == nil {
+				run := rec.toTaskRun()
+				status.LanChayCuoi = &run
+			}
+		}
+		result = append(result, status)
+	}
+
+	return result, nil
+}
+
+// ListRuns lấy lịch sử chạy của một tác vụ, mới nhất trước
+func (s *RedisScheduledTaskStore) ListRuns(ctx context.Context, tacVu string, limit int64) ([]service.TaskRun, error) {
+	if limit <= 0 || limit > s.historySize {
+		limit = s.historySize
+	}
+
+	raws, err := s.client.LRange(ctx, schedulerRunsKeyPrefix+tacVu, 0, limit-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list task runs: %w", err)
+	}
+
+	runs := make([]service.TaskRun, 0, len(raws))
+	for _, raw := range raws {
+		var rec taskRunRecord
+		if err := json.Unmarshal([]byte(raw), &rec); err != nil {
+			continue
+		}
+		runs = append(runs, rec.toTaskRun())
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// Đảm bảo RedisSessionService implement SessionService
+var _ service.SessionService = (*RedisSessionService)(nil)
+
+const (
+	// Key pattern cho session
+	sessionKeyPrefix       = "session:"        // session:{sid} -> Hash metadata
+	sessionTokensKeyPrefix = "session_tokens:" // session_tokens:{sid} -> SET of jti
+	userSessionsKeyPrefix  = "user_sessions:"  // user_sessions:{userID} -> SET of sid
+
+	sessionFieldUserID        = "user_id"
+	sessionFieldUserAgent     = "user_agent"
+	sessionFieldIP            = "ip"
+	sessionFieldCreatedAt     = "created_at"
+	sessionFieldLastRefreshAt = "last_refresh_at"
+	sessionFieldExpiresAt     = "expires_at"
+	sessionFieldRefreshJTI    = "refresh_jti"
+)
+
+// rotateSessionScript cập nhật session chỉ khi session còn tồn tại, thuộc đúng user
+// và refresh token được gửi lên là refresh token hiện hành của session (refresh_jti).
+// Chỉ so với refresh_jti, không so với session_tokens vì tập đó chứa cả jti của access token.
+// Session tạo trước khi có refresh_jti: chấp nhận jti còn trong session_tokens cho lần xoay đầu tiên
+// (usecase đã loại access token theo Subject/typ), sau đó refresh_jti được ghi và áp dụng.
+// Kiểm tra và xoay vòng trong cùng script nên hai request dùng chung một refresh token
+// không thể cùng thành công.
+// Trả về 1 nếu thành công, 0 nếu không có session, -1 nếu refresh token đã bị dùng
+// ARGV: user_id, presented jti, ip, user_agent, last_refresh_at, expires_at, ttl giây, refresh jti mới,
+// số jti thêm, jti thêm..., jti bỏ...
+var rotateSessionScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'user_id') ~= ARGV[1] then
+	return 0
+end
+local current = redis.call('HGET', KEYS[1], 'refresh_jti')
+if current then
+	if current ~= ARGV[2] then
+		return -1
+	end
+elseif redis.call('SISMEMBER', KEYS[2], ARGV[2]) == 0 then
+	return -1
+end
+redis.call('HSET', KEYS[1], 'ip', ARGV[3], 'user_agent', ARGV[4], 'last_refresh_at', ARGV[5], 'expires_at', ARGV[6], 'refresh_jti', ARGV[8])
+local n = tonumber(ARGV[9])
+for i = 10, 9 + n do
+	redis.call('SADD', KEYS[2], ARGV[i])
+end
+for i = 10 + n, #ARGV do
+	redis.call('SREM', KEYS[2], ARGV[i])
+end
+redis.call('EXPIRE', KEYS[1], ARGV[7])
+redis.call('EXPIRE', KEYS[2], ARGV[7])
+redis.call('EXPIRE', KEYS[3], ARGV[7])
+return 1
+`)
+
+// RedisSessionService implementation của SessionService sử dụng Redis
+// Bật/tắt theo token blacklist vì thu hồi session dựa vào blacklist
+type RedisSessionService struct {
+	client  *redis.Client
+	enabled bool
+}
+
+// NewRedisSessionService tạo mới RedisSessionService
+func NewRedisSessionService(client *redis.Client, cfg config.TokenBlacklistConfig) *RedisSessionService {
+	return &RedisSessionService{
+		client:  client,
+		enabled: cfg.Enabled,
+	}
+}
+
+// CreateSession lưu session mới
+// Redis structure:
+//   - session:{sid} = Hash {user_id, user_agent, ip, created_at, last_refresh_at, expires_at, refresh_jti}
+//   - session_tokens:{sid} = SET of jti
+//   - user_sessions:{userID} = SET of sid
+func (s *RedisSessionService) CreateSession(ctx context.Context, session service.Session, jtis []string, ttl time.Duration) error {
+	if !s.enabled {
+		return nil
+	}
+
+	if session.ID == "" || session.UserID == "" {
+		return fmt.Errorf("session id and user id cannot be empty")
+	}
+
+	sessionKey := sessionKeyPrefix + session.ID
+	tokensKey := sessionTokensKeyPrefix + session.ID
+	userSessionsKey := userSessionsKeyPrefix + session.UserID
+
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, sessionKey,
+		sessionFieldUserID, session.UserID,
+		sessionFieldUserAgent, session.UserAgent,
+		sessionFieldIP, session.IP,
+		sessionFieldCreatedAt, session.CreatedAt.Unix(),
+		sessionFieldLastRefreshAt, session.LastRefreshAt.Unix(),
+		sessionFieldExpiresAt, session.ExpiresAt.Unix(),
+		sessionFieldRefreshJTI, session.RefreshJTI,
+	)
+	pipe.Expire(ctx, sessionKey, ttl)
+	if len(jtis) > 0 {
+		pipe.SAdd(ctx, tokensKey, toAnySlice(jtis)...)
+		pipe.Expire(ctx, tokensKey, ttl)
+	}
+	// Mọi session cùng TTL nên session mới nhất luôn hết hạn muộn nhất
+	pipe.SAdd(ctx, userSessionsKey, session.ID)
+	pipe.Expire(ctx, userSessionsKey, ttl)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	return nil
+}
+
+// RotateSession cập nhật session sau khi refresh token
+func (s *RedisSessionService) RotateSession(ctx context.Context, rotation service.SessionRotation) error {
+	if !s.enabled {
+		return nil
+	}
+
+	now := time.Now()
+	args := make([]any, 0, 9+len(rotation.AddJTIs)+len(rotation.RemoveJTIs))
+	args = append(args,
+		rotation.UserID, rotation.PresentedJTI, rotation.IP, rotation.UserAgent,
+		now.Unix(), now.Add(rotation.TTL).Unix(), max(int64(rotation.TTL.Seconds()), 1),
+		rotation.RefreshJTI, len(rotation.AddJTIs),
+	)
+	args = append(args, toAnySlice(rotation.AddJTIs)...)
+	args = append(args, toAnySlice(rotation.RemoveJTIs)...)
+
+	result, err := rotateSessionScript.Run(ctx, s.client,
+		[]string{
+			sessionKeyPrefix + rotation.SessionID,
+			sessionTokensKeyPrefix + rotation.SessionID,
+			userSessionsKeyPrefix + rotation.UserID,
+		},
+		args...,
+	).Int()
+	if err != nil {
+		return fmt.Errorf("failed to rotate session: %w", err)
+	}
+
+	switch result {
+	case 0:
+		return service.ErrSessionNotFound
+	case -1:
+		return service.ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// GetSession lấy session theo ID
+func (s *RedisSessionService) GetSession(ctx context.Context, sessionID string) (*service.Session, error) {
+	if !s.enabled {
+		return nil, service.ErrSessionNotFound
+	}
+
+	fields, err := s.client.HGetAll(ctx, sessionKeyPrefix+sessionID).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if fields[sessionFieldUserID] == "" {
+		return nil, service.ErrSessionNotFound
+	}
+
+	return toSession(sessionID, fields), nil
+}
+
+// ListUserSessions lấy các session còn hiệu lực của user
+// sid của session đã hết hạn được dọn khỏi user_sessions:{userID}
+func (s *RedisSessionService) ListUserSessions(ctx context.Context, userID string) ([]service.Session, error) {
+	if !s.enabled || userID == "" {
+		return []service.Session{}, nil
+	}
+
+	userSessionsKey := userSessionsKeyPrefix + userID
+	sessionIDs, err := s.client.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+	if len(sessionIDs) == 0 {
+		return []service.Session{}, nil
+	}
+
+	pipe := s.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(sessionIDs))
+	for i, sid := range sessionIDs {
+		cmds[i] = pipe.HGetAll(ctx, sessionKeyPrefix+sid)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get user sessions: %w", err)
+	}
+
+	sessions := make([]service.Session, 0, len(sessionIDs))
+	var stale []any
+	for i, cmd := range cmds {
+		fields := cmd.Val()
+		if fields[sessionFieldUserID] != userID {
+			stale = append(stale, sessionIDs[i])
+			continue
+		}
+		sessions = append(sessions, *toSession(sessionIDs[i], fields))
+	}
+
+	if len(stale) > 0 {
+		_ = s.client.SRem(ctx, userSessionsKey, stale...).Err()
+	}
+
+	slices.SortFunc(sessions, func(a, b service.Session) int {
+		return b.LastRefreshAt.Compare(a.LastRefreshAt)
+	})
+
+	return sessions, nil
+}
+
+// DeleteSession xóa session và trả về các jti còn gắn với session
+func (s *RedisSessionService) DeleteSession(ctx context.Context, sessionID string) ([]string, error) {
+	if !s.enabled {
+		return nil, nil
+	}
+
+	sessionKey := sessionKeyPrefix + sessionID
+	tokensKey := sessionTokensKeyPrefix + sessionID
+
+	userID, err := s.client.HGet(ctx, sessionKey, sessionFieldUserID).Result()
+	if err == redis.Nil {
+		return nil, service.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	jtis, err := s.client.SMembers(ctx, tokensKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session tokens: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, sessionKey, tokensKey)
+	pipe.SRem(ctx, userSessionsKeyPrefix+userID, sessionID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return jtis, nil
+}
+
+// toSession chuyển Hash Redis sang Session
+func toSession(sessionID string, fields map[string]string) *service.Session {
+	return &service.Session{
+		ID:            sessionID,
+		UserID:        fields[sessionFieldUserID],
+		UserAgent:     fields[sessionFieldUserAgent],
+		IP:            fields[sessionFieldIP],
+		CreatedAt:     parseUnix(fields[sessionFieldCreatedAt]),
+		LastRefreshAt: parseUnix(fields[sessionFieldLastRefreshAt]),
+		ExpiresAt:     parseUnix(fields[sessionFieldExpiresAt]),
+		RefreshJTI:    fields[sessionFieldRefreshJTI],
+	}
+}
+
+// parseUnix parse unix timestamp dạng chuỗi, trả về zero time nếu lỗi
+func parseUnix(value string) time.Time {
+	sec, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0)
+}
+
+// toAnySlice chuyển []string sang []any cho các lệnh Redis variadic
+func toAnySlice(values []string) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
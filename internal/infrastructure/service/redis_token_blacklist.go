@@ -189,9 +189,9 @@ func (s *RedisTokenBlacklistService) GetUserActiveTokens(ctx context.Context, us
 
 // RevokeAllUserTokens thu hồi tất cả token của user
 // Flow:
-//  1. Lấy tất cả jti từ user_tokens:{userID}
+//  1. Lấy tất cả jti từ user_tokens:{userID} và sid từ user_sessions:{userID}
 //  2. Blacklist từng jti
-//  3. Xóa user_tokens:{userID} set
+//  3. Xóa user_tokens:{userID} set và toàn bộ session của user
 func (s *RedisTokenBlacklistService) RevokeAllUserTokens(ctx context.Context, userID string) error {
 	if !s.enabled {
 		return nil
@@ -202,6 +202,7 @@ func (s *RedisTokenBlacklistService) RevokeAllUserTokens(ctx context.Context, us
 	}
 
 	userTokensKey := userTokensKeyPrefix + userID
+	userSessionsKey := userSessionsKeyPrefix + userID
 
 	// Lấy tất cả token của user
 	tokens, err := s.client.SMembers(ctx, userTokensKey).Result()
@@ -209,12 +210,23 @@ func (s *RedisTokenBlacklistService) RevokeAllUserTokens(ctx context.Context, us
 		return fmt.Errorf("failed to get user tokens: %w", err)
 	}
 
-	if len(tokens) == 0 {
+	sessionIDs, err := s.client.SMembers(ctx, userSessionsKey).Result()
+	if err != nil {
+		return fmt.Errorf("failed to get user sessions: %w", err)
+	}
+
+	if len(tokens) == 0 && len(sessionIDs) == 0 {
 		return nil // Không có token nào để revoke
 	}
 
 	pipe := s.client.Pipeline()
 
+	// Xóa metadata session (token của session đều nằm trong user_tokens SET)
+	for _, sid := range sessionIDs {
+		pipe.Del(ctx, sessionKeyPrefix+sid, sessionTokensKeyPrefix+sid)
+	}
+	pipe.Del(ctx, userSessionsKey)
+
 	// Blacklist tất cả token
 	// TTL mặc định 24h (đủ cho access token, refresh token sẽ tự expire)
 	defaultBlacklistTTL := 24 * time.Hour
@@ -0,0 +1,64 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenSetScanBatch là số key mỗi lần SCAN khi dọn token hết hạn
+const tokenSetScanBatch = 200
+
+// cleanupExpiredTokenSets dọn các set "token của user" (userKeyPrefix{userID})
+// Token key ({tokenKeyPrefix}{member}) tự hết hạn theo TTL, nhưng member trong set của user
+// vẫn còn cho tới khi set hết hạn; set bị gia hạn mỗi lần tạo token mới nên có thể tồn tại rất lâu.
+// Trả về số member đã xóa.
+func cleanupExpiredTokenSets(ctx context.Context, client *redis.Client, userKeyPrefix, tokenKeyPrefix string) (int64, error) {
+	var removed int64
+
+	iter := client.Scan(ctx, 0, userKeyPrefix+"*", tokenSetScanBatch).Iterator()
+	for iter.Next(ctx) {
+		userKey := iter.Val()
+
+		members, err := client.SMembers(ctx, userKey).Result()
+		if err != nil {
+			return removed, fmt.Errorf("failed to read token set %s: %w", userKey, err)
+		}
+		if len(members) == 0 {
+			continue
+		}
+
+		pipe := client.Pipeline()
+		exists := make([]*redis.IntCmd, len(members))
+		for i, m := range members {
+			exists[i] = pipe.Exists(ctx, tokenKeyPrefix+m)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return removed, fmt.Errorf("failed to check tokens of %s: %w", userKey, err)
+		}
+
+		var expired []interface{}
+		for i, m := range members {
+			if exists[i].Val() == 0 {
+				expired = append(expired, m)
+			}
+		}
+		if len(expired) == 0 {
+			continue
+		}
+
+		// SREM xóa member cuối cùng thì Redis tự xóa luôn set rỗng
+		n, err := client.SRem(ctx, userKey, expired...).Result()
+		if err != nil {
+			return removed, fmt.Errorf("failed to remove expired tokens of %s: %w", userKey, err)
+		}
+		removed += n
+	}
+	if err := iter.Err(); err != nil {
+		return removed, fmt.Errorf("failed to scan token sets: %w", err)
+	}
+
+	return removed, nil
+}
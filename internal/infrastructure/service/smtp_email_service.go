@@ -0,0 +1,385 @@
+// Package service chứa các Infrastructure Service implementations
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/domain/service"
+	"restaurant_project/internal/infrastructure/config"
+	"restaurant_project/pkg/logger"
+)
+
+// Đảm bảo SMTPEmailService implement EmailService
+var _ service.EmailService = (*SMTPEmailService)(nil)
+
+// SMTPEmailService implementation của EmailService gửi email thật qua SMTP
+// - STARTTLS khi server hỗ trợ (bắt buộc nếu RequireTLS)
+// - AUTH PLAIN khi có username
+// - Email multipart/alternative gồm phần text và HTML, render từ embedded templates
+type SMTPEmailService struct {
+	host       string
+	port       int
+	username   string
+	password   string
+	from       mail.Address
+	requireTLS bool
+	timeout    time.Duration
+	baseURL    string
+	resetTTL   time.Duration
+	loc        *time.Location
+	renderer   *EmailRenderer
+}
+
+// NewSMTPEmailService tạo mới SMTPEmailService
+// resetTTL dùng để ghi thời hạn link đặt lại mật khẩu trong email, loc là múi giờ nhà hàng
+func NewSMTPEmailService(cfg config.EmailConfig, resetTTL time.Duration, loc *time.Location) (*SMTPEmailService, error) {
+	if cfg.SMTPHost == "" {
+		return nil, errors.New("SMTP_HOST is required when EMAIL_ENABLED=true")
+	}
+	from, err := mail.ParseAddress(cfg.FromAddress)
+	if err != nil {
+		return nil, fmt.Errorf("invalid EMAIL_FROM_ADDRESS %q: %w", cfg.FromAddress, err)
+	}
+	from.Name = cfg.FromName
+
+	renderer, err := NewEmailRenderer(cfg.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SMTPEmailService{
+		host:       cfg.SMTPHost,
+		port:       cfg.SMTPPort,
+		username:   cfg.SMTPUsername,
+		password:   cfg.SMTPPassword,
+		from:       *from,
+		requireTLS: cfg.RequireTLS,
+		timeout:    cfg.Timeout,
+		baseURL:    cfg.VerificationBaseURL,
+		resetTTL:   resetTTL,
+		loc:        loc,
+		renderer:   renderer,
+	}, nil
+}
+
+// ==================== Template data ====================
+
+// linkEmailData là dữ liệu cho email chứa link (xác thực, đặt lại mật khẩu)
+type linkEmailData struct {
+	Email      string
+	Link       string
+	HetHanPhut int
+}
+
+// orderReceiptItemData là một dòng món trên hóa đơn
+type orderReceiptItemData struct {
+	TenMon    string
+	SoLuong   int
+	TuyChon   []string
+	ThanhTien int64
+}
+
+// orderReceiptData là dữ liệu cho email hóa đơn
+type orderReceiptData struct {
+	MaDon         string
+	ThoiGian      string
+	SoBan         int
+	DiaChiGiao    string
+	Items         []orderReceiptItemData
+	TongTien      int64
+	GiamGia       int64
+	TienThanhToan int64
+}
+
+// reservationData là dữ liệu cho email xác nhận đặt bàn
+type reservationData struct {
+	TenKhach string
+	ThoiGian string
+	SoNguoi  int
+	SoBan    int
+	GhiChu   string
+}
+
+// lowStockItemData là một nguyên liệu trong email cảnh báo tồn kho
+type lowStockItemData struct {
+	Ten         string
+	DonViTinh   string
+	SoLuongTon  string
+	MucToiThieu string
+}
+
+// lowStockData là dữ liệu cho email cảnh báo tồn kho
+type lowStockData struct {
+	ThoiGian string
+	Items    []lowStockItemData
+}
+
+// ==================== EmailService ====================
+
+// SendVerificationEmail gửi email xác thực
+func (s *SMTPEmailService) SendVerificationEmail(ctx context.Context, toEmail, token string) error {
+	return s.send(ctx, toEmail, templateVerification, linkEmailData{
+		Email: toEmail,
+		Link:  fmt.Sprintf("%s/verify-email?token=%s", s.baseURL, token),
+	})
+}
+
+// SendPasswordResetEmail gửi email đặt lại mật khẩu
+func (s *SMTPEmailService) SendPasswordResetEmail(ctx context.Context, toEmail, token string) error {
+	return s.send(ctx, toEmail, templatePasswordReset, linkEmailData{
+		Email:      toEmail,
+		Link:       fmt.Sprintf("%s/reset-password?token=%s", s.baseURL, token),
+		HetHanPhut: int(s.resetTTL.Minutes()),
+	})
+}
+
+// SendOrderReceipt gửi hóa đơn đơn hàng
+func (s *SMTPEmailService) SendOrderReceipt(ctx context.Context, toEmail string, order *entity.Order) error {
+	items := make([]orderReceiptItemData, len(order.Items))
+	for i, item := range order.Items {
+		tuyChon := make([]string, len(item.TuyChon))
+		for j, tc := range item.TuyChon {
+			tuyChon[j] = tc.TenNhom + ": " + tc.TenLuaChon
+		}
+		items[i] = orderReceiptItemData{
+			TenMon:    item.TenMon,
+			SoLuong:   item.SoLuong,
+			TuyChon:   tuyChon,
+			ThanhTien: item.ThanhTien,
+		}
+	}
+
+	return s.send(ctx, toEmail, templateOrderReceipt, orderReceiptData{
+		MaDon:         order.ID,
+		ThoiGian:      order.ThoiGianDat.In(s.loc).Format("02/01/2006 15:04"),
+		SoBan:         order.SoBan,
+		DiaChiGiao:    order.DiaChiGiao,
+		Items:         items,
+		TongTien:      order.TongTien,
+		GiamGia:       order.GiamGia,
+		TienThanhToan: order.TienThanhToan,
+	})
+}
+
+// SendReservationConfirmation gửi email xác nhận đặt bàn
+func (s *SMTPEmailService) SendReservationConfirmation(ctx context.Context, toEmail string, datBan service.ThongTinDatBan) error {
+	return s.send(ctx, toEmail, templateReservation, reservationData{
+		TenKhach: datBan.TenKhach,
+		ThoiGian: datBan.ThoiGian.In(s.loc).Format("02/01/2006 15:04"),
+		SoNguoi:  datBan.SoNguoi,
+		SoBan:    datBan.SoBan,
+		GhiChu:   datBan.GhiChu,
+	})
+}
+
+// SendLowStockAlert gửi cảnh báo tồn kho cho quản lý
+func (s *SMTPEmailService) SendLowStockAlert(ctx context.Context, toEmail string, nguyenLieu []*entity.NguyenLieu) error {
+	items := make([]lowStockItemData, len(nguyenLieu))
+	for i, nl := range nguyenLieu {
+		items[i] = lowStockItemData{
+			Ten:         nl.Ten,
+			DonViTinh:   nl.DonViTinh,
+			SoLuongTon:  strconv.FormatFloat(nl.SoLuongTon, 'f', -1, 64),
+			MucToiThieu: strconv.FormatFloat(nl.MucToiThieu, 'f', -1, 64),
+		}
+	}
+
+	return s.send(ctx, toEmail, templateLowStock, lowStockData{
+		ThoiGian: time.Now().In(s.loc).Format("02/01/2006 15:04"),
+		Items:    items,
+	})
+}
+
+// IsEnabled luôn true: SMTPEmailService chỉ được tạo khi Email.Enabled = true
+func (s *SMTPEmailService) IsEnabled() bool {
+	return true
+}
+
+// ==================== SMTP ====================
+
+// send render template, đóng gói MIME và gửi qua SMTP
+func (s *SMTPEmailService) send(ctx context.Context, toEmail, templateName string, data any) error {
+	to, err := mail.ParseAddress(toEmail)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", toEmail, err)
+	}
+
+	rendered, err := s.renderer.Render(templateName, data)
+	if err != nil {
+		return err
+	}
+
+	msg, err := s.buildMessage(to, rendered, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := s.deliver(ctx, to.Address, msg); err != nil {
+		logger.CtxError(ctx, "Failed to send email",
+			zap.String("template", templateName),
+			zap.String("to", to.Address),
+			zap.Error(err),
+		)
+		return err
+	}
+
+	logger.CtxInfo(ctx, "Email sent",
+		zap.String("template", templateName),
+		zap.String("to", to.Address),
+	)
+	return nil
+}
+
+// buildMessage tạo email multipart/alternative (text trước, HTML sau theo RFC 2046)
+func (s *SMTPEmailService) buildMessage(to *mail.Address, e *renderedEmail, now time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", e.text},
+		{"text/html; charset=UTF-8", e.html},
+	}
+	for _, p := range parts {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(p.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	messageID, err := s.newMessageID()
+	if err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	headers := [][2]string{
+		{"From", s.from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("UTF-8", e.subject)},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", messageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + mw.Boundary()},
+	}
+	for _, h := range headers {
+		fmt.Fprintf(&msg, "%s: %s\r\n", h[0], h[1])
+	}
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+// newMessageID sinh Message-ID duy nhất theo domain của địa chỉ gửi
+func (s *SMTPEmailService) newMessageID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate message id: %w", err)
+	}
+
+	domain := s.host
+	if at := strings.LastIndex(s.from.Address, "@"); at >= 0 {
+		domain = s.from.Address[at+1:]
+	}
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">", nil
+}
+
+// deliver mở kết nối SMTP, nâng cấp STARTTLS, xác thực và gửi email
+func (s *SMTPEmailService) deliver(ctx context.Context, to string, msg []byte) error {
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+
+	dialer := net.Dialer{Timeout: s.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+
+	// Giới hạn tổng thời gian của cả phiên SMTP (không chỉ lúc dial)
+	deadline := time.Now().Add(s.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("SMTP STARTTLS failed: %w", err)
+		}
+	} else if s.requireTLS {
+		return errors.New("SMTP server does not support STARTTLS")
+	}
+
+	if s.username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("SMTP server does not support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return fmt.Errorf("SMTP auth failed: %w", err)
+		}
+	}
+
+	if err := c.Mail(s.from.Address); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	if err := c.Rcpt(to); err != nil {
+		return fmt.Errorf("SMTP RCPT TO failed: %w", err)
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected email: %w", err)
+	}
+
+	return c.Quit()
+}
@@ -0,0 +1,324 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"restaurant_project/internal/infrastructure/config"
+)
+
+// fakeSMTPServer là SMTP server tối giản trong test: ghi lại phiên gửi và trả lời theo cấu hình
+type fakeSMTPServer struct {
+	ln         net.Listener
+	extensions []string          // Extension quảng bá sau EHLO (vd: "AUTH PLAIN")
+	replies    map[string]string // Trả lời thay thế theo lệnh (vd: "RCPT": "550 no such user")
+
+	mu       sync.Mutex
+	auth     string // Chuỗi credentials AUTH PLAIN đã giải mã
+	mailFrom string
+	rcptTo   []string
+	data     string
+}
+
+func newFakeSMTPServer(t *testing.T, extensions []string, replies map[string]string) *fakeSMTPServer {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTPServer{ln: ln, extensions: extensions, replies: replies}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) reply(cmd, def string) string {
+	if r, ok := s.replies[cmd]; ok {
+		return r
+	}
+	return def
+}
+
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	send := func(line string) {
+		w.WriteString(line + "\r\n")
+		w.Flush()
+	}
+
+	send("220 localhost fake SMTP")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch cmd {
+		case "EHLO", "HELO":
+			if len(s.extensions) == 0 {
+				send("250 localhost")
+				continue
+			}
+			send("250-localhost")
+			for i, ext := range s.extensions {
+				if i == len(s.extensions)-1 {
+					send("250 " + ext)
+				} else {
+					send("250-" + ext)
+				}
+			}
+		case "AUTH":
+			parts := strings.Fields(line)
+			if len(parts) == 3 {
+				decoded, _ := base64.StdEncoding.DecodeString(parts[2])
+				s.mu.Lock()
+				s.auth = string(decoded)
+				s.mu.Unlock()
+			}
+			send(s.reply("AUTH", "235 2.7.0 Authentication successful"))
+		case "MAIL":
+			s.mu.Lock()
+			s.mailFrom = line
+			s.mu.Unlock()
+			send(s.reply("MAIL", "250 OK"))
+		case "RCPT":
+			s.mu.Lock()
+			s.rcptTo = append(s.rcptTo, line)
+			s.mu.Unlock()
+			send(s.reply("RCPT", "250 OK"))
+		case "DATA":
+			send("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+			send(s.reply("DATA", "250 OK queued"))
+		case "QUIT":
+			send("221 Bye")
+			return
+		default:
+			send("502 Command not implemented")
+		}
+	}
+}
+
+func newTestSMTPEmailService(t *testing.T, port int, username string, requireTLS bool) *SMTPEmailService {
+	t.Helper()
+
+	s, err := NewSMTPEmailService(config.EmailConfig{
+		Enabled:             true,
+		VerificationBaseURL: "https://app.example.com",
+		Language:            EmailLangEN,
+		SMTPHost:            "127.0.0.1",
+		SMTPPort:            port,
+		SMTPUsername:        username,
+		SMTPPassword:        "secret",
+		FromAddress:         "no-reply@nhahang.vn",
+		FromName:            "Nhà hàng",
+		RequireTLS:          requireTLS,
+		Timeout:             5 * time.Second,
+	}, 30*time.Minute, time.UTC)
+	if err != nil {
+		t.Fatalf("NewSMTPEmailService: %v", err)
+	}
+	return s
+}
+
+// parseParts đọc email multipart/alternative, trả về nội dung đã giải mã theo Content-Type
+func parseParts(t *testing.T, msg *mail.Message) map[string]string {
+	t.Helper()
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q, want multipart/alternative", msg.Header.Get("Content-Type"))
+	}
+
+	parts := make(map[string]string)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		p, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("NextPart: %v", err)
+		}
+		body, err := io.ReadAll(p) // multipart.Reader tự giải mã quoted-printable
+		if err != nil {
+			t.Fatalf("read part: %v", err)
+		}
+		ct, _, _ := mime.ParseMediaType(p.Header.Get("Content-Type"))
+		parts[ct] = string(body)
+	}
+	return parts
+}
+
+func TestSMTPEmailService_SendPasswordResetEmail(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions []string
+		username   string
+		wantAuth   string
+	}{
+		{
+			name: "không xác thực",
+		},
+		{
+			name:       "AUTH PLAIN",
+			extensions: []string{"AUTH PLAIN"},
+			username:   "mailer",
+			wantAuth:   "\x00mailer\x00secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t, tt.extensions, nil)
+			svc := newTestSMTPEmailService(t, server.port(), tt.username, false)
+
+			if err := svc.SendPasswordResetEmail(context.Background(), "khach@example.com", "tok-123"); err != nil {
+				t.Fatalf("SendPasswordResetEmail: %v", err)
+			}
+
+			server.mu.Lock()
+			defer server.mu.Unlock()
+
+			if server.auth != tt.wantAuth {
+				t.Errorf("AUTH = %q, want %q", server.auth, tt.wantAuth)
+			}
+			if server.mailFrom != "MAIL FROM:<no-reply@nhahang.vn>" && !strings.HasPrefix(server.mailFrom, "MAIL FROM:<no-reply@nhahang.vn> ") {
+				t.Errorf("MAIL = %q", server.mailFrom)
+			}
+			if len(server.rcptTo) != 1 || server.rcptTo[0] != "RCPT TO:<khach@example.com>" {
+				t.Errorf("RCPT = %v", server.rcptTo)
+			}
+
+			msg, err := mail.ReadMessage(strings.NewReader(server.data))
+			if err != nil {
+				t.Fatalf("ReadMessage: %v", err)
+			}
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			if err != nil || subject != "Reset your password" {
+				t.Errorf("Subject = %q (%v)", subject, err)
+			}
+			if from := msg.Header.Get("From"); !strings.Contains(from, "<no-reply@nhahang.vn>") {
+				t.Errorf("From = %q", from)
+			}
+			if id := msg.Header.Get("Message-ID"); !strings.HasSuffix(id, "@nhahang.vn>") {
+				t.Errorf("Message-ID = %q", id)
+			}
+
+			parts := parseParts(t, msg)
+			link := "https://app.example.com/reset-password?token=tok-123"
+			for _, ct := range []string{"text/plain", "text/html"} {
+				body, ok := parts[ct]
+				if !ok {
+					t.Fatalf("missing %s part", ct)
+				}
+				if !strings.Contains(body, link) {
+					t.Errorf("%s part does not contain reset link", ct)
+				}
+				if !strings.Contains(body, "30") {
+					t.Errorf("%s part does not contain expiry minutes", ct)
+				}
+			}
+		})
+	}
+}
+
+func TestSMTPEmailService_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions []string
+		replies    map[string]string
+		username   string
+		requireTLS bool
+		wantErr    string
+	}{
+		{
+			name:       "bắt buộc TLS nhưng server không có STARTTLS",
+			requireTLS: true,
+			wantErr:    "does not support STARTTLS",
+		},
+		{
+			name:     "có username nhưng server không hỗ trợ AUTH",
+			username: "mailer",
+			wantErr:  "does not support AUTH",
+		},
+		{
+			name:       "sai mật khẩu",
+			extensions: []string{"AUTH PLAIN"},
+			replies:    map[string]string{"AUTH": "535 5.7.8 Authentication failed"},
+			username:   "mailer",
+			wantErr:    "SMTP auth failed",
+		},
+		{
+			name:    "người nhận bị từ chối",
+			replies: map[string]string{"RCPT": "550 5.1.1 No such user"},
+			wantErr: "SMTP RCPT TO failed",
+		},
+		{
+			name:    "server từ chối nội dung",
+			replies: map[string]string{"DATA": "554 5.7.1 Message rejected"},
+			wantErr: "SMTP server rejected email",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newFakeSMTPServer(t, tt.extensions, tt.replies)
+			svc := newTestSMTPEmailService(t, server.port(), tt.username, tt.requireTLS)
+
+			err := svc.SendVerificationEmail(context.Background(), "khach@example.com", "tok")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSMTPEmailService_InvalidRecipient(t *testing.T) {
+	svc := newTestSMTPEmailService(t, 1, "", false)
+
+	if err := svc.SendVerificationEmail(context.Background(), "not-an-email", "tok"); err == nil {
+		t.Fatal("expected error for invalid recipient")
+	}
+}
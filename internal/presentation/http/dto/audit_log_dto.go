@@ -0,0 +1,59 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// AUDIT LOG REQUEST/RESPONSE DTOs
+// ============================================
+
+// AuditLogQueryRequest là tham số lọc audit log (kèm phân trang)
+type AuditLogQueryRequest struct {
+	PaginationRequest
+	ActorID    string `form:"actor_id"`
+	HanhDong   string `form:"hanh_dong"`
+	DoiTuong   string `form:"doi_tuong"`
+	DoiTuongID string `form:"doi_tuong_id"`
+	Tu         string `form:"tu"`  // RFC3339
+	Den        string `form:"den"` // RFC3339
+}
+
+// AuditLogResponse là một bản ghi audit log
+type AuditLogResponse struct {
+	ID         string         `json:"id"`
+	ActorID    string         `json:"actor_id" example:"a1b2c3d4-..."`
+	ActorRole  string         `json:"actor_role,omitempty" example:"admin"`
+	HanhDong   string         `json:"hanh_dong" example:"mon_an.cap_nhat_gia"`
+	DoiTuong   string         `json:"doi_tuong" example:"mon_an"`
+	DoiTuongID string         `json:"doi_tuong_id" example:"pho-bo"`
+	TruocKhi   map[string]any `json:"truoc_khi,omitempty"`
+	SauKhi     map[string]any `json:"sau_khi,omitempty"`
+	RequestID  string         `json:"request_id,omitempty"`
+	IP         string         `json:"ip,omitempty" example:"203.0.113.10"`
+	ThoiGian   time.Time      `json:"thoi_gian"`
+}
+
+// ToAuditLogResponseList chuyển đổi danh sách AuditLog sang Response DTOs
+func ToAuditLogResponseList(list []*entity.AuditLog) []AuditLogResponse {
+	result := make([]AuditLogResponse, len(list))
+	for i, l := range list {
+		result[i] = AuditLogResponse{
+			ID:         l.ID,
+			ActorID:    l.ActorID,
+			ActorRole:  l.ActorRole,
+			HanhDong:   l.HanhDong,
+			DoiTuong:   l.DoiTuong,
+			DoiTuongID: l.DoiTuongID,
+			TruocKhi:   l.TruocKhi,
+			SauKhi:     l.SauKhi,
+			RequestID:  l.RequestID,
+			IP:         l.IP,
+			ThoiGian:   l.ThoiGian,
+		}
+	}
+	return result
+}
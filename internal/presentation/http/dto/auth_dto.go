@@ -1,6 +1,8 @@
 // Package dto chứa Data Transfer Objects
 package dto
 
+import "time"
+
 // ============================================
 // AUTH REQUEST DTOs
 // ============================================
@@ -19,14 +21,16 @@ type LoginRequest struct {
 }
 
 // LogoutRequest là dữ liệu để đăng xuất (revoke cả access + refresh token)
+// Chế độ cookie: refresh token lấy từ cookie nếu không gửi trong body
 type LogoutRequest struct {
-	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIs..."` // Refresh token cần revoke
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIs..."` // Refresh token cần revoke (bắt buộc với Bearer)
 }
 
 // RefreshTokenRequest là dữ liệu để refresh access token
+// Chế độ cookie: bỏ trống body, refresh token lấy từ cookie (cần header X-CSRF-Token)
 type RefreshTokenRequest struct {
-	RefreshToken string `json:"refresh_token" binding:"required" example:"eyJhbGciOiJIUzI1NiIs..."`
-	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIs..."` // Optional: gửi kèm access token cũ để cleanup
+	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIs..."` // Bắt buộc với Bearer
+	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIs..."`  // Optional: gửi kèm access token cũ để cleanup
 }
 
 // ============================================
@@ -34,20 +38,23 @@ type RefreshTokenRequest struct {
 // ============================================
 
 // AuthResponse là dữ liệu trả về sau khi đăng nhập/đăng ký thành công
+// Chế độ cookie: không có access_token/refresh_token (nằm trong HttpOnly cookie), token_type = Cookie
 type AuthResponse struct {
-	AccessToken  string       `json:"access_token" example:"eyJhbGciOiJIUzI1NiIs..."`
-	RefreshToken string       `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIs..."`
+	AccessToken  string       `json:"access_token,omitempty" example:"eyJhbGciOiJIUzI1NiIs..."`
+	RefreshToken string       `json:"refresh_token,omitempty" example:"eyJhbGciOiJIUzI1NiIs..."`
 	TokenType    string       `json:"token_type" example:"Bearer"`
-	ExpiresIn    int64        `json:"expires_in" example:"900"` // seconds
+	ExpiresIn    int64        `json:"expires_in" example:"900"`                       // seconds
+	CSRFToken    string       `json:"csrf_token,omitempty" example:"2l7Qm9dZ0c1v..."` // Chỉ có ở chế độ cookie, gửi lại qua header X-CSRF-Token
 	User         UserResponse `json:"user"`
 }
 
 // RefreshTokenResponse là dữ liệu trả về sau khi refresh token thành công
 type RefreshTokenResponse struct {
-	AccessToken  string `json:"access_token" example:"eyJhbGciOiJIUzI1NiIs..."`
-	RefreshToken string `json:"refresh_token" example:"eyJhbGciOiJIUzI1NiIs..."`
+	AccessToken  string `json:"access_token,omitempty" example:"eyJhbGciOiJIUzI1NiIs..."`
+	RefreshToken string `json:"refresh_token,omitempty" example:"eyJhbGciOiJIUzI1NiIs..."`
 	TokenType    string `json:"token_type" example:"Bearer"`
-	ExpiresIn    int64  `json:"expires_in" example:"900"` // seconds
+	ExpiresIn    int64  `json:"expires_in" example:"900"`                       // seconds
+	CSRFToken    string `json:"csrf_token,omitempty" example:"2l7Qm9dZ0c1v..."` // Chỉ có ở chế độ cookie
 }
 
 // LogoutAllResponse là dữ liệu trả về sau khi logout tất cả thiết bị
@@ -56,9 +63,10 @@ type LogoutAllResponse struct {
 	Message         string `json:"message" example:"Đã đăng xuất khỏi tất cả thiết bị"`
 }
 
-// ActiveSessionsResponse là dữ liệu trả về số session active
+// ActiveSessionsResponse là danh sách session active
 type ActiveSessionsResponse struct {
-	ActiveSessions int64 `json:"active_sessions" example:"3"`
+	ActiveSessions int64             `json:"active_sessions" example:"3"`
+	Sessions       []SessionResponse `json:"sessions"`
 }
 
 // ============================================
@@ -80,8 +88,78 @@ type ResendVerificationResponse struct {
 	Message string `json:"message" example:"Email xác thực đã được gửi lại"`
 }
 
+// ============================================
+// PASSWORD RESET DTOs
+// ============================================
+
+// ForgotPasswordRequest là dữ liệu để yêu cầu đặt lại mật khẩu
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"john@example.com"`
+}
+
+// ResetPasswordRequest là dữ liệu để đặt lại mật khẩu bằng token trong email
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required" example:"abc123def456..."`
+	NewPassword string `json:"new_password" binding:"required,min=6,max=100" example:"newpass123"`
+}
+
+// ForgotPasswordResponse là dữ liệu trả về sau khi yêu cầu đặt lại mật khẩu
+// Nội dung luôn giống nhau dù email có tồn tại hay không
+type ForgotPasswordResponse struct {
+	Message string `json:"message" example:"Nếu email đã đăng ký, link đặt lại mật khẩu sẽ được gửi tới hộp thư"`
+}
+
 // ResendCooldownResponse là dữ liệu trả về khi đang trong thời gian chờ
 type ResendCooldownResponse struct {
 	Message          string `json:"message" example:"Vui lòng đợi trước khi gửi lại"`
 	RemainingSeconds int64  `json:"remaining_seconds" example:"45"`
 }
+
+// ============================================
+// TWO-FACTOR AUTHENTICATION (TOTP) DTOs
+// ============================================
+
+// MFAChallengeResponse là dữ liệu trả về khi đăng nhập cần bước nhập mã TOTP
+type MFAChallengeResponse struct {
+	MFARequired        bool   `json:"mfa_required" example:"true"`
+	ChallengeToken     string `json:"challenge_token" example:"9f2c4e..."`
+	EnrollmentRequired bool   `json:"enrollment_required" example:"false"` // true = gọi /auth/mfa/setup để đăng ký trước
+	ExpiresIn          int64  `json:"expires_in" example:"300"`            // seconds
+}
+
+// MFAVerifyRequest là dữ liệu để hoàn tất đăng nhập bằng mã TOTP hoặc mã khôi phục
+type MFAVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required" example:"9f2c4e..."`
+	Code           string `json:"code" binding:"required,max=32" example:"123456"`
+}
+
+// MFASetupRequest là dữ liệu để đăng ký TOTP trong lúc đăng nhập (bị bắt buộc 2FA)
+type MFASetupRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required" example:"9f2c4e..."`
+}
+
+// MFACodeRequest là dữ liệu để xác nhận đăng ký TOTP
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required,max=32" example:"123456"`
+}
+
+// MFADisableRequest là dữ liệu để tắt 2FA
+type MFADisableRequest struct {
+	Password string `json:"password" binding:"required" example:"password123"`
+	Code     string `json:"code" binding:"required,max=32" example:"123456"` // Mã TOTP hoặc mã khôi phục
+}
+
+// MFAEnrollmentResponse là thông tin đăng ký TOTP (chỉ hiển thị một lần)
+type MFAEnrollmentResponse struct {
+	Secret        string   `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`
+	OtpauthURI    string   `json:"otpauth_uri" example:"otpauth://totp/Restaurant:admin?secret=...&issuer=Restaurant"`
+	RecoveryCodes []string `json:"recovery_codes" example:"abcde-fghij"`
+}
+
+// MFAStatusResponse là trạng thái 2FA của user
+type MFAStatusResponse struct {
+	Enabled                bool       `json:"enabled" example:"true"`
+	Required               bool       `json:"required" example:"true"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining" example:"10"`
+	EnabledAt              *time.Time `json:"enabled_at,omitempty"`
+}
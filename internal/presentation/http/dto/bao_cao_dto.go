@@ -0,0 +1,48 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// BÁO CÁO REQUEST/RESPONSE DTOs
+// ============================================
+
+// DoanhThuNgayRequest là tham số xem báo cáo doanh thu theo ngày
+type DoanhThuNgayRequest struct {
+	TuNgay  string `form:"tu_ngay" binding:"required"`
+	DenNgay string `form:"den_ngay" binding:"required"`
+}
+
+// DoanhThuNgayResponse là snapshot doanh thu của một ngày
+type DoanhThuNgayResponse struct {
+	Ngay           string    `json:"ngay" example:"2025-01-15"`
+	SoDon          int       `json:"so_don" example:"42"`
+	SoDonHoanThanh int       `json:"so_don_hoan_thanh" example:"38"`
+	SoDonHuy       int       `json:"so_don_huy" example:"3"`
+	TongTien       int64     `json:"tong_tien" example:"5200000"`
+	GiamGia        int64     `json:"giam_gia" example:"200000"`
+	DoanhThu       int64     `json:"doanh_thu" example:"5000000"`
+	ChupLuc        time.Time `json:"chup_luc"`
+}
+
+// ToDoanhThuNgayResponseList chuyển đổi danh sách DoanhThuNgay sang Response DTOs
+func ToDoanhThuNgayResponseList(list []*entity.DoanhThuNgay) []DoanhThuNgayResponse {
+	result := make([]DoanhThuNgayResponse, len(list))
+	for i, dt := range list {
+		result[i] = DoanhThuNgayResponse{
+			Ngay:           dt.Ngay,
+			SoDon:          dt.SoDon,
+			SoDonHoanThanh: dt.SoDonHoanThanh,
+			SoDonHuy:       dt.SoDonHuy,
+			TongTien:       dt.TongTien,
+			GiamGia:        dt.GiamGia,
+			DoanhThu:       dt.DoanhThu,
+			ChupLuc:        dt.ChupLuc,
+		}
+	}
+	return result
+}
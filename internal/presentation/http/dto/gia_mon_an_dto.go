@@ -0,0 +1,87 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// GIÁ MÓN ĂN REQUEST/RESPONSE DTOs
+// ============================================
+
+// LichSuGiaQueryRequest là tham số lọc lịch sử giá theo thời điểm hiệu lực
+type LichSuGiaQueryRequest struct {
+	Tu  string `form:"tu"`  // RFC3339
+	Den string `form:"den"` // RFC3339
+}
+
+// LichSuGiaResponse là một lần đổi giá của món
+type LichSuGiaResponse struct {
+	ID           string    `json:"id"`
+	MonAnID      string    `json:"mon_an_id" example:"pho-bo"`
+	GiaCu        int64     `json:"gia_cu" example:"50000"`
+	GiaMoi       int64     `json:"gia_moi" example:"55000"`
+	HieuLucTu    time.Time `json:"hieu_luc_tu"`
+	NguoiThayDoi string    `json:"nguoi_thay_doi,omitempty"`
+	LichDoiGiaID string    `json:"lich_doi_gia_id,omitempty"`
+}
+
+// ToLichSuGiaResponseList chuyển đổi danh sách LichSuGia sang Response DTOs
+func ToLichSuGiaResponseList(list []*entity.LichSuGia) []LichSuGiaResponse {
+	result := make([]LichSuGiaResponse, len(list))
+	for i, l := range list {
+		result[i] = LichSuGiaResponse{
+			ID:           l.ID,
+			MonAnID:      l.MonAnID,
+			GiaCu:        l.GiaCu,
+			GiaMoi:       l.GiaMoi,
+			HieuLucTu:    l.HieuLucTu,
+			NguoiThayDoi: l.NguoiThayDoi,
+			LichDoiGiaID: l.LichDoiGiaID,
+		}
+	}
+	return result
+}
+
+// DatLichDoiGiaRequest là request body để đặt lịch đổi giá
+type DatLichDoiGiaRequest struct {
+	Gia       int64     `json:"gia" binding:"required,gt=0" example:"55000"`
+	ApDungLuc time.Time `json:"ap_dung_luc" binding:"required" example:"2026-11-01T00:00:00+07:00"`
+}
+
+// LichDoiGiaResponse là một lịch đổi giá của món
+type LichDoiGiaResponse struct {
+	ID          string     `json:"id"`
+	MonAnID     string     `json:"mon_an_id" example:"pho-bo"`
+	GiaMoi      int64      `json:"gia_moi" example:"55000"`
+	ApDungLuc   time.Time  `json:"ap_dung_luc"`
+	TrangThai   string     `json:"trang_thai" example:"cho_ap_dung"`
+	NguoiTao    string     `json:"nguoi_tao,omitempty"`
+	NgayTao     time.Time  `json:"ngay_tao"`
+	DaApDungLuc *time.Time `json:"da_ap_dung_luc,omitempty"`
+}
+
+// ToLichDoiGiaResponse chuyển đổi LichDoiGia sang Response DTO
+func ToLichDoiGiaResponse(l *entity.LichDoiGia) LichDoiGiaResponse {
+	return LichDoiGiaResponse{
+		ID:          l.ID,
+		MonAnID:     l.MonAnID,
+		GiaMoi:      l.GiaMoi,
+		ApDungLuc:   l.ApDungLuc,
+		TrangThai:   string(l.TrangThai),
+		NguoiTao:    l.NguoiTao,
+		NgayTao:     l.NgayTao,
+		DaApDungLuc: l.DaApDungLuc,
+	}
+}
+
+// ToLichDoiGiaResponseList chuyển đổi danh sách LichDoiGia sang Response DTOs
+func ToLichDoiGiaResponseList(list []*entity.LichDoiGia) []LichDoiGiaResponse {
+	result := make([]LichDoiGiaResponse, len(list))
+	for i, l := range list {
+		result[i] = ToLichDoiGiaResponse(l)
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/service"
+)
+
+// ============================================
+// JOB QUEUE RESPONSE DTOs
+// ============================================
+
+// JobResponse là dữ liệu trả về cho một job thất bại
+// Không trả payload vì có thể chứa token nhạy cảm (link xác thực, đặt lại mật khẩu)
+type JobResponse struct {
+	ID         string     `json:"id" example:"7f1c2a9e-4b3d-4e8a-9c1f-2d6b5a4e3f21"`
+	Loai       string     `json:"loai" example:"email.verification"`
+	SoLanThu   int        `json:"so_lan_thu" example:"5"`
+	LoiCuoi    string     `json:"loi_cuoi" example:"smtp: dial tcp: i/o timeout"`
+	TaoLuc     time.Time  `json:"tao_luc"`
+	ThatBaiLuc *time.Time `json:"that_bai_luc,omitempty"`
+}
+
+// ThongKeJobResponse là số job theo trạng thái của hàng đợi
+type ThongKeJobResponse struct {
+	DangCho   int64 `json:"dang_cho" example:"3"`
+	ChoThuLai int64 `json:"cho_thu_lai" example:"1"`
+	ThatBai   int64 `json:"that_bai" example:"2"`
+}
+
+// ToJobResponse chuyển đổi Job sang Response DTO
+func ToJobResponse(job service.Job) JobResponse {
+	resp := JobResponse{
+		ID:       job.ID,
+		Loai:     job.Loai,
+		SoLanThu: job.SoLanThu,
+		LoiCuoi:  job.LoiCuoi,
+		TaoLuc:   job.TaoLuc,
+	}
+	if !job.ThatBaiLuc.IsZero() {
+		thatBaiLuc := job.ThatBaiLuc
+		resp.ThatBaiLuc = &thatBaiLuc
+	}
+	return resp
+}
+
+// ToJobResponseList chuyển đổi danh sách Job sang Response DTOs
+func ToJobResponseList(jobs []service.Job) []JobResponse {
+	result := make([]JobResponse, len(jobs))
+	for i, job := range jobs {
+		result[i] = ToJobResponse(job)
+	}
+	return result
+}
+
+// ToThongKeJobResponse chuyển đổi thống kê hàng đợi sang Response DTO
+func ToThongKeJobResponse(stats service.JobQueueStats) ThongKeJobResponse {
+	return ThongKeJobResponse{
+		DangCho:   stats.DangCho,
+		ChoThuLai: stats.ChoThuLai,
+		ThatBai:   stats.ThatBai,
+	}
+}
@@ -0,0 +1,123 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// KHUYẾN MÃI REQUEST DTOs
+// ============================================
+
+// TaoKhuyenMaiRequest là dữ liệu để tạo khuyến mãi
+// Các trường dùng theo loại:
+//   - giam_phan_tram_mon / giam_tien_mon: gia_tri, mon_an_ids (rỗng = mọi món)
+//   - mua_x_tang_y: so_luong_mua, so_luong_tang, mon_an_ids
+//   - combo: mon_an_ids (lặp ID = nhiều phần), gia_combo
+//   - giam_phan_tram_don / giam_tien_don: gia_tri, giam_toi_da, don_toi_thieu
+type TaoKhuyenMaiRequest struct {
+	Ten         string           `json:"ten" binding:"required,max=200" example:"Happy hour bia"`
+	MoTa        string           `json:"mo_ta,omitempty" binding:"max=1000" example:"Giảm 30% đồ uống 17h-19h"`
+	Loai        string           `json:"loai" binding:"required,oneof=giam_phan_tram_mon giam_tien_mon mua_x_tang_y combo giam_phan_tram_don giam_tien_don" example:"giam_phan_tram_mon"`
+	GiaTri      int64            `json:"gia_tri,omitempty" binding:"min=0" example:"30"`
+	GiamToiDa   int64            `json:"giam_toi_da,omitempty" binding:"min=0" example:"0"`
+	MonAnIDs    []string         `json:"mon_an_ids,omitempty" example:"1_mon,2_mon"`
+	SoLuongMua  int              `json:"so_luong_mua,omitempty" binding:"min=0" example:"0"`
+	SoLuongTang int              `json:"so_luong_tang,omitempty" binding:"min=0" example:"0"`
+	GiaCombo    int64            `json:"gia_combo,omitempty" binding:"min=0" example:"0"`
+	DonToiThieu int64            `json:"don_toi_thieu,omitempty" binding:"min=0" example:"0"`
+	TuNgay      *time.Time       `json:"tu_ngay,omitempty" example:"2026-06-01T00:00:00+07:00"`
+	DenNgay     *time.Time       `json:"den_ngay,omitempty" example:"2026-07-01T00:00:00+07:00"`
+	KhungGio    []KhungGioBanDTO `json:"khung_gio,omitempty" binding:"dive"`
+	CongDon     bool             `json:"cong_don" example:"false"`
+	UuTien      int              `json:"uu_tien" example:"0"`
+}
+
+// ToKhungGioBanList chuyển khung giờ áp dụng sang Value Object (kèm validation)
+func (r TaoKhuyenMaiRequest) ToKhungGioBanList() ([]entity.KhungGioBan, error) {
+	return toKhungGioBanList(r.KhungGio)
+}
+
+// ============================================
+// KHUYẾN MÃI RESPONSE DTOs
+// ============================================
+
+// KhuyenMaiResponse là dữ liệu trả về cho khuyến mãi
+type KhuyenMaiResponse struct {
+	ID           string           `json:"id" example:"uuid-123"`
+	Ten          string           `json:"ten" example:"Happy hour bia"`
+	MoTa         string           `json:"mo_ta,omitempty" example:"Giảm 30% đồ uống 17h-19h"`
+	Loai         string           `json:"loai" example:"giam_phan_tram_mon"`
+	GiaTri       int64            `json:"gia_tri,omitempty" example:"30"`
+	GiamToiDa    int64            `json:"giam_toi_da,omitempty" example:"0"`
+	MonAnIDs     []string         `json:"mon_an_ids,omitempty"`
+	SoLuongMua   int              `json:"so_luong_mua,omitempty" example:"0"`
+	SoLuongTang  int              `json:"so_luong_tang,omitempty" example:"0"`
+	GiaCombo     int64            `json:"gia_combo,omitempty" example:"0"`
+	DonToiThieu  int64            `json:"don_toi_thieu,omitempty" example:"0"`
+	TuNgay       *time.Time       `json:"tu_ngay,omitempty"`
+	DenNgay      *time.Time       `json:"den_ngay,omitempty"`
+	KhungGio     []KhungGioBanDTO `json:"khung_gio,omitempty"`
+	CongDon      bool             `json:"cong_don" example:"false"`
+	UuTien       int              `json:"uu_tien" example:"0"`
+	DangHoatDong bool             `json:"dang_hoat_dong" example:"true"`
+	NgayTao      string           `json:"ngay_tao" example:"24/01/2026 10:30"`
+}
+
+// KhuyenMaiApDungResponse là khuyến mãi đã áp dụng trên đơn hàng
+type KhuyenMaiApDungResponse struct {
+	KhuyenMaiID string `json:"khuyen_mai_id" example:"uuid-123"`
+	Ten         string `json:"ten" example:"Happy hour bia"`
+	SoTienGiam  int64  `json:"so_tien_giam" example:"15000"`
+}
+
+// ToKhuyenMaiResponse chuyển đổi Entity sang Response DTO
+func ToKhuyenMaiResponse(k *entity.KhuyenMai) KhuyenMaiResponse {
+	return KhuyenMaiResponse{
+		ID:           k.ID,
+		Ten:          k.Ten,
+		MoTa:         k.MoTa,
+		Loai:         string(k.Loai),
+		GiaTri:       k.GiaTri,
+		GiamToiDa:    k.GiamToiDa,
+		MonAnIDs:     k.MonAnIDs,
+		SoLuongMua:   k.SoLuongMua,
+		SoLuongTang:  k.SoLuongTang,
+		GiaCombo:     k.GiaCombo,
+		DonToiThieu:  k.DonToiThieu,
+		TuNgay:       k.TuNgay,
+		DenNgay:      k.DenNgay,
+		KhungGio:     toKhungGioBanDTOList(k.KhungGio),
+		CongDon:      k.CongDon,
+		UuTien:       k.UuTien,
+		DangHoatDong: k.DangHoatDong,
+		NgayTao:      k.NgayTao.Format("02/01/2006 15:04"),
+	}
+}
+
+// ToKhuyenMaiResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToKhuyenMaiResponseList(list []*entity.KhuyenMai) []KhuyenMaiResponse {
+	result := make([]KhuyenMaiResponse, len(list))
+	for i, k := range list {
+		result[i] = ToKhuyenMaiResponse(k)
+	}
+	return result
+}
+
+// toKhuyenMaiApDungResponseList chuyển đổi các khuyến mãi đã áp dụng sang DTO
+func toKhuyenMaiApDungResponseList(list []entity.KhuyenMaiApDung) []KhuyenMaiApDungResponse {
+	if len(list) == 0 {
+		return nil
+	}
+	result := make([]KhuyenMaiApDungResponse, len(list))
+	for i, k := range list {
+		result[i] = KhuyenMaiApDungResponse{
+			KhuyenMaiID: k.KhuyenMaiID,
+			Ten:         k.Ten,
+			SoTienGiam:  k.SoTienGiam,
+		}
+	}
+	return result
+}
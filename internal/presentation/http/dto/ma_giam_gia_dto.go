@@ -0,0 +1,98 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// MÃ GIẢM GIÁ REQUEST DTOs
+// ============================================
+
+// PhatHanhMaRequest là dữ liệu để phát hành mã giảm giá hàng loạt
+// Truyền "ma" để tạo một mã cụ thể dùng nhiều lần (VD: SUMMER10),
+// bỏ trống để sinh "so_luong" mã ngẫu nhiên với "tien_to"
+type PhatHanhMaRequest struct {
+	ChienDich     string     `json:"chien_dich" binding:"required,max=100" example:"TET2026"`
+	Ma            string     `json:"ma,omitempty" binding:"omitempty,alphanum,max=32" example:""`
+	TienTo        string     `json:"tien_to,omitempty" binding:"omitempty,alphanum,max=16" example:"TET"`
+	SoLuong       int        `json:"so_luong" binding:"required,min=1,max=1000" example:"100"`
+	Loai          string     `json:"loai" binding:"required,oneof=phan_tram so_tien" example:"so_tien"`
+	GiaTri        int64      `json:"gia_tri" binding:"required,min=1" example:"20000"`
+	GiamToiDa     int64      `json:"giam_toi_da,omitempty" binding:"min=0" example:"0"`
+	DonToiThieu   int64      `json:"don_toi_thieu,omitempty" binding:"min=0" example:"100000"`
+	SoLanToiDa    int        `json:"so_lan_toi_da" binding:"min=0" example:"1"`
+	SoLanMoiKhach int        `json:"so_lan_moi_khach,omitempty" binding:"min=0" example:"1"`
+	HetHan        *time.Time `json:"het_han,omitempty" example:"2026-02-28T23:59:59+07:00"`
+}
+
+// ============================================
+// MÃ GIẢM GIÁ RESPONSE DTOs
+// ============================================
+
+// MaGiamGiaResponse là dữ liệu trả về cho mã giảm giá
+type MaGiamGiaResponse struct {
+	Ma            string     `json:"ma" example:"TETAB12CD34"`
+	ChienDich     string     `json:"chien_dich" example:"TET2026"`
+	Loai          string     `json:"loai" example:"so_tien"`
+	GiaTri        int64      `json:"gia_tri" example:"20000"`
+	GiamToiDa     int64      `json:"giam_toi_da,omitempty" example:"0"`
+	DonToiThieu   int64      `json:"don_toi_thieu,omitempty" example:"100000"`
+	SoLanToiDa    int        `json:"so_lan_toi_da" example:"1"`
+	SoLanMoiKhach int        `json:"so_lan_moi_khach,omitempty" example:"1"`
+	SoLanDaDung   int        `json:"so_lan_da_dung" example:"0"`
+	HetHan        *time.Time `json:"het_han,omitempty"`
+	DangHoatDong  bool       `json:"dang_hoat_dong" example:"true"`
+	NgayTao       string     `json:"ngay_tao" example:"24/01/2026 10:30"`
+}
+
+// ThongKeMaGiamGiaResponse là số liệu sử dụng mã của một chiến dịch
+type ThongKeMaGiamGiaResponse struct {
+	ChienDich    string `json:"chien_dich" example:"TET2026"`
+	SoMa         int    `json:"so_ma" example:"100"`
+	SoMaDaDung   int    `json:"so_ma_da_dung" example:"37"`
+	SoLuotDung   int    `json:"so_luot_dung" example:"37"`
+	SoLuotHoan   int    `json:"so_luot_hoan" example:"2"`
+	TongTienGiam int64  `json:"tong_tien_giam" example:"740000"`
+}
+
+// ToMaGiamGiaResponse chuyển đổi Entity sang Response DTO
+func ToMaGiamGiaResponse(m *entity.MaGiamGia) MaGiamGiaResponse {
+	return MaGiamGiaResponse{
+		Ma:            m.Ma,
+		ChienDich:     m.ChienDich,
+		Loai:          string(m.Loai),
+		GiaTri:        m.GiaTri,
+		GiamToiDa:     m.GiamToiDa,
+		DonToiThieu:   m.DonToiThieu,
+		SoLanToiDa:    m.SoLanToiDa,
+		SoLanMoiKhach: m.SoLanMoiKhach,
+		SoLanDaDung:   m.SoLanDaDung,
+		HetHan:        m.HetHan,
+		DangHoatDong:  m.DangHoatDong,
+		NgayTao:       m.NgayTao.Format("02/01/2006 15:04"),
+	}
+}
+
+// ToMaGiamGiaResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToMaGiamGiaResponseList(list []*entity.MaGiamGia) []MaGiamGiaResponse {
+	result := make([]MaGiamGiaResponse, len(list))
+	for i, m := range list {
+		result[i] = ToMaGiamGiaResponse(m)
+	}
+	return result
+}
+
+// ToThongKeMaGiamGiaResponse chuyển đổi thống kê sang Response DTO
+func ToThongKeMaGiamGiaResponse(tk *entity.ThongKeMaGiamGia) ThongKeMaGiamGiaResponse {
+	return ThongKeMaGiamGiaResponse{
+		ChienDich:    tk.ChienDich,
+		SoMa:         tk.SoMa,
+		SoMaDaDung:   tk.SoMaDaDung,
+		SoLuotDung:   tk.SoLuotDung,
+		SoLuotHoan:   tk.SoLuotHoan,
+		TongTienGiam: tk.TongTienGiam,
+	}
+}
@@ -3,6 +3,9 @@
 package dto
 
 import (
+	"fmt"
+	"time"
+
 	"restaurant_project/internal/domain/entity"
 )
 
@@ -28,6 +31,142 @@ type ApDungGiamGiaRequest struct {
 	PhanTram int `json:"phan_tram" example:"10"` // Phần trăm giảm giá (0-100)
 }
 
+// KhungGioBanDTO là một khung giờ phục vụ món (dùng cho cả request và response)
+type KhungGioBanDTO struct {
+	CacNgay []int  `json:"cac_ngay,omitempty" example:"0,6"`     // Ngày trong tuần (0=CN, 1=T2,... 6=T7), rỗng = mọi ngày
+	Tu      string `json:"tu" binding:"required" example:"06:00"`  // Giờ bắt đầu (HH:MM, giờ nhà hàng)
+	Den     string `json:"den" binding:"required" example:"10:00"` // Giờ kết thúc (HH:MM, không bao gồm)
+}
+
+// DatLichBanRequest là dữ liệu client gửi khi đặt lịch phục vụ món
+// Gửi danh sách rỗng để món được phục vụ mọi lúc
+type DatLichBanRequest struct {
+	LichBan []KhungGioBanDTO `json:"lich_ban" binding:"dive"`
+}
+
+// ToKhungGioBanList chuyển đổi request sang danh sách Value Object (kèm validation)
+func (r DatLichBanRequest) ToKhungGioBanList() ([]entity.KhungGioBan, error) {
+	return toKhungGioBanList(r.LichBan)
+}
+
+// toKhungGioBanList chuyển danh sách DTO sang Value Object (kèm validation)
+func toKhungGioBanList(dtos []KhungGioBanDTO) ([]entity.KhungGioBan, error) {
+	result := make([]entity.KhungGioBan, 0, len(dtos))
+	for _, k := range dtos {
+		tuPhut, err := parseGioPhut(k.Tu)
+		if err != nil {
+			return nil, err
+		}
+		denPhut, err := parseGioPhut(k.Den)
+		if err != nil {
+			return nil, err
+		}
+
+		cacNgay := make([]time.Weekday, len(k.CacNgay))
+		for i, n := range k.CacNgay {
+			cacNgay[i] = time.Weekday(n)
+		}
+
+		khung, err := entity.NewKhungGioBan(cacNgay, tuPhut, denPhut)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, khung)
+	}
+	return result, nil
+}
+
+// parseGioPhut chuyển "HH:MM" sang số phút trong ngày
+func parseGioPhut(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("giờ không hợp lệ %q (định dạng HH:MM)", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// toKhungGioBanDTOList chuyển đổi lịch phục vụ sang DTO
+func toKhungGioBanDTOList(lichBan []entity.KhungGioBan) []KhungGioBanDTO {
+	if len(lichBan) == 0 {
+		return nil
+	}
+	result := make([]KhungGioBanDTO, len(lichBan))
+	for i, k := range lichBan {
+		cacNgay := make([]int, len(k.CacNgay))
+		for j, n := range k.CacNgay {
+			cacNgay[j] = int(n)
+		}
+		result[i] = KhungGioBanDTO{
+			CacNgay: cacNgay,
+			Tu:      fmt.Sprintf("%02d:%02d", k.TuPhut/60, k.TuPhut%60),
+			Den:     fmt.Sprintf("%02d:%02d", k.DenPhut/60, k.DenPhut%60),
+		}
+	}
+	return result
+}
+
+// LuaChonTuyChonDTO là một lựa chọn trong nhóm tùy chọn (dùng cho cả request và response)
+type LuaChonTuyChonDTO struct {
+	ID      string `json:"id" binding:"required,max=50" example:"l"`    // Mã lựa chọn, duy nhất trong nhóm
+	Ten     string `json:"ten" binding:"required,max=100" example:"L"`  // Tên hiển thị
+	GiaThem int64  `json:"gia_them" example:"10000"`                   // Giá cộng thêm mỗi phần (VND, có thể âm)
+}
+
+// NhomTuyChonDTO là một nhóm tùy chọn của món (dùng cho cả request và response)
+type NhomTuyChonDTO struct {
+	ID           string              `json:"id" binding:"required,max=50" example:"size"`    // Mã nhóm, duy nhất trong món
+	Ten          string              `json:"ten" binding:"required,max=100" example:"Size"`  // Tên hiển thị
+	ChonToiThieu int                 `json:"chon_toi_thieu" binding:"min=0" example:"1"`       // Số lựa chọn tối thiểu (0 = không bắt buộc)
+	ChonToiDa    int                 `json:"chon_toi_da" binding:"required,min=1" example:"1"` // Số lựa chọn tối đa
+	LuaChon      []LuaChonTuyChonDTO `json:"lua_chon" binding:"required,min=1,dive"`           // Các lựa chọn
+}
+
+// DatTuyChonRequest là dữ liệu client gửi khi đặt các nhóm tùy chọn của món
+// Gửi danh sách rỗng để bỏ toàn bộ tùy chọn
+type DatTuyChonRequest struct {
+	NhomTuyChon []NhomTuyChonDTO `json:"nhom_tuy_chon" binding:"dive"`
+}
+
+// ToNhomTuyChonList chuyển đổi request sang danh sách Value Object (kèm validation)
+func (r DatTuyChonRequest) ToNhomTuyChonList() ([]entity.NhomTuyChon, error) {
+	result := make([]entity.NhomTuyChon, 0, len(r.NhomTuyChon))
+	for _, n := range r.NhomTuyChon {
+		luaChon := make([]entity.LuaChonTuyChon, len(n.LuaChon))
+		for i, lc := range n.LuaChon {
+			luaChon[i] = entity.LuaChonTuyChon{ID: lc.ID, Ten: lc.Ten, GiaThem: lc.GiaThem}
+		}
+
+		nhom, err := entity.NewNhomTuyChon(n.ID, n.Ten, n.ChonToiThieu, n.ChonToiDa, luaChon)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, nhom)
+	}
+	return result, nil
+}
+
+// toNhomTuyChonDTOList chuyển đổi các nhóm tùy chọn sang DTO
+func toNhomTuyChonDTOList(list []entity.NhomTuyChon) []NhomTuyChonDTO {
+	if len(list) == 0 {
+		return nil
+	}
+	result := make([]NhomTuyChonDTO, len(list))
+	for i, n := range list {
+		luaChon := make([]LuaChonTuyChonDTO, len(n.LuaChon))
+		for j, lc := range n.LuaChon {
+			luaChon[j] = LuaChonTuyChonDTO{ID: lc.ID, Ten: lc.Ten, GiaThem: lc.GiaThem}
+		}
+		result[i] = NhomTuyChonDTO{
+			ID:           n.ID,
+			Ten:          n.Ten,
+			ChonToiThieu: n.ChonToiThieu,
+			ChonToiDa:    n.ChonToiDa,
+			LuaChon:      luaChon,
+		}
+	}
+	return result
+}
+
 // ============================================
 // RESPONSE DTOs - Dữ liệu trả về cho client
 // ============================================
@@ -46,8 +185,11 @@ type MonAnResponse struct {
 	ConHang     bool   `json:"con_hang" example:"true"`               // Còn bán không
 	GiamGia     int    `json:"giam_gia" example:"10"`                 // % giảm giá
 	CoTheBan    bool   `json:"co_the_ban" example:"true"`             // Có thể bán không (business logic)
+	LichBan     []KhungGioBanDTO `json:"lich_ban,omitempty"`          // Lịch phục vụ (rỗng = mọi lúc)
+	NhomTuyChon []NhomTuyChonDTO `json:"nhom_tuy_chon,omitempty"`     // Các nhóm tùy chọn (size, topping,...)
 	NgayTao     string `json:"ngay_tao" example:"24/01/2026 10:00"`   // Ngày tạo (format đẹp)
 	NgayCapNhat string `json:"ngay_cap_nhat" example:"24/01/2026 10:30"` // Ngày cập nhật
+	Version     int64  `json:"version" example:"3"`                   // Phiên bản (giá trị ETag, gửi lại qua If-Match)
 }
 
 // ToMonAnResponse chuyển đổi Entity sang Response DTO
@@ -62,8 +204,11 @@ func ToMonAnResponse(mon *entity.MonAn) MonAnResponse {
 		ConHang:     mon.ConHang,
 		GiamGia:     mon.GiamGia,
 		CoTheBan:    mon.CoTheBan(),  // Gọi business logic của Entity
+		LichBan:     toKhungGioBanDTOList(mon.LichBan),
+		NhomTuyChon: toNhomTuyChonDTOList(mon.NhomTuyChon),
 		NgayTao:     mon.NgayTao.Format("02/01/2006 15:04"),
 		NgayCapNhat: mon.NgayCapNhat.Format("02/01/2006 15:04"),
+		Version:     mon.Version,
 	}
 }
 
@@ -0,0 +1,254 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// NGUYÊN LIỆU DTOs
+// ============================================
+
+// ThemNguyenLieuRequest là dữ liệu để thêm nguyên liệu mới
+type ThemNguyenLieuRequest struct {
+	Ten         string  `json:"ten" binding:"required,max=100" example:"Thịt bò"`
+	DonViTinh   string  `json:"don_vi_tinh" binding:"required,max=20" example:"kg"`
+	MucToiThieu float64 `json:"muc_toi_thieu" binding:"min=0" example:"5"`
+}
+
+// GhiTieuHaoRequest là dữ liệu để ghi nhận tiêu hao nguyên liệu
+type GhiTieuHaoRequest struct {
+	SoLuong   float64 `json:"so_luong" binding:"required,gt=0" example:"1.5"`
+	ThamChieu string  `json:"tham_chieu,omitempty" binding:"max=36" example:""`
+}
+
+// NguyenLieuResponse là dữ liệu trả về cho nguyên liệu
+type NguyenLieuResponse struct {
+	ID              string  `json:"id" example:"uuid-123"`
+	Ten             string  `json:"ten" example:"Thịt bò"`
+	DonViTinh       string  `json:"don_vi_tinh" example:"kg"`
+	SoLuongTon      float64 `json:"so_luong_ton" example:"12.5"`
+	MucToiThieu     float64 `json:"muc_toi_thieu" example:"5"`
+	GiaVonBinhQuan  int64   `json:"gia_von_binh_quan" example:"250000"`
+	DuoiMucToiThieu bool    `json:"duoi_muc_toi_thieu" example:"false"`
+	NgayCapNhat     string  `json:"ngay_cap_nhat" example:"24/01/2026 10:30"`
+}
+
+// ToNguyenLieuResponse chuyển đổi Entity sang Response DTO
+func ToNguyenLieuResponse(nl *entity.NguyenLieu) NguyenLieuResponse {
+	return NguyenLieuResponse{
+		ID:              nl.ID,
+		Ten:             nl.Ten,
+		DonViTinh:       nl.DonViTinh,
+		SoLuongTon:      nl.SoLuongTon,
+		MucToiThieu:     nl.MucToiThieu,
+		GiaVonBinhQuan:  nl.GiaVonBinhQuan,
+		DuoiMucToiThieu: nl.DuoiMucToiThieu(),
+		NgayCapNhat:     nl.NgayCapNhat.Format("02/01/2006 15:04"),
+	}
+}
+
+// ToNguyenLieuResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToNguyenLieuResponseList(list []*entity.NguyenLieu) []NguyenLieuResponse {
+	result := make([]NguyenLieuResponse, len(list))
+	for i, nl := range list {
+		result[i] = ToNguyenLieuResponse(nl)
+	}
+	return result
+}
+
+// ============================================
+// NHÀ CUNG CẤP DTOs
+// ============================================
+
+// TaoNhaCungCapRequest là dữ liệu để tạo nhà cung cấp
+type TaoNhaCungCapRequest struct {
+	Ten         string `json:"ten" binding:"required,max=150" example:"Công ty Thực phẩm Sạch"`
+	NguoiLienHe string `json:"nguoi_lien_he,omitempty" binding:"max=100" example:"Nguyễn Văn A"`
+	SoDienThoai string `json:"so_dien_thoai" binding:"required,max=15" example:"0901234567"`
+	Email       string `json:"email,omitempty" binding:"omitempty,email" example:"lienhe@thucphamsach.vn"`
+	DiaChi      string `json:"dia_chi,omitempty" example:"123 Lê Lợi, Q.1, TP.HCM"`
+	SoNgayGiao  int    `json:"so_ngay_giao" binding:"min=0" example:"2"`
+}
+
+// CapNhatNhaCungCapRequest là dữ liệu để cập nhật nhà cung cấp
+type CapNhatNhaCungCapRequest struct {
+	Ten         *string `json:"ten,omitempty" binding:"omitempty,max=150" example:"Công ty Thực phẩm Sạch"`
+	NguoiLienHe *string `json:"nguoi_lien_he,omitempty" binding:"omitempty,max=100" example:"Nguyễn Văn B"`
+	SoDienThoai *string `json:"so_dien_thoai,omitempty" binding:"omitempty,max=15" example:"0907654321"`
+	Email       *string `json:"email,omitempty" binding:"omitempty,email" example:"lienhe@thucphamsach.vn"`
+	DiaChi      *string `json:"dia_chi,omitempty" example:"456 Hai Bà Trưng, Q.3, TP.HCM"`
+	SoNgayGiao  *int    `json:"so_ngay_giao,omitempty" binding:"omitempty,min=0" example:"3"`
+	DangHopTac  *bool   `json:"dang_hop_tac,omitempty" example:"true"`
+}
+
+// NhaCungCapResponse là dữ liệu trả về cho nhà cung cấp
+type NhaCungCapResponse struct {
+	ID          string `json:"id" example:"uuid-123"`
+	Ten         string `json:"ten" example:"Công ty Thực phẩm Sạch"`
+	NguoiLienHe string `json:"nguoi_lien_he,omitempty" example:"Nguyễn Văn A"`
+	SoDienThoai string `json:"so_dien_thoai" example:"0901234567"`
+	Email       string `json:"email,omitempty" example:"lienhe@thucphamsach.vn"`
+	DiaChi      string `json:"dia_chi,omitempty" example:"123 Lê Lợi, Q.1, TP.HCM"`
+	SoNgayGiao  int    `json:"so_ngay_giao" example:"2"`
+	DangHopTac  bool   `json:"dang_hop_tac" example:"true"`
+	NgayTao     string `json:"ngay_tao" example:"24/01/2026 10:00"`
+}
+
+// ToNhaCungCapResponse chuyển đổi Entity sang Response DTO
+func ToNhaCungCapResponse(ncc *entity.NhaCungCap) NhaCungCapResponse {
+	return NhaCungCapResponse{
+		ID:          ncc.ID,
+		Ten:         ncc.Ten,
+		NguoiLienHe: ncc.NguoiLienHe,
+		SoDienThoai: ncc.SoDienThoai,
+		Email:       ncc.Email,
+		DiaChi:      ncc.DiaChi,
+		SoNgayGiao:  ncc.SoNgayGiao,
+		DangHopTac:  ncc.DangHopTac,
+		NgayTao:     ncc.NgayTao.Format("02/01/2006 15:04"),
+	}
+}
+
+// ToNhaCungCapResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToNhaCungCapResponseList(list []*entity.NhaCungCap) []NhaCungCapResponse {
+	result := make([]NhaCungCapResponse, len(list))
+	for i, ncc := range list {
+		result[i] = ToNhaCungCapResponse(ncc)
+	}
+	return result
+}
+
+// ============================================
+// ĐƠN ĐẶT HÀNG DTOs
+// ============================================
+
+// DonDatHangItemRequest là một dòng nguyên liệu trong đơn đặt hàng
+type DonDatHangItemRequest struct {
+	NguyenLieuID string  `json:"nguyen_lieu_id" binding:"required" example:"uuid-nguyen-lieu"`
+	SoLuong      float64 `json:"so_luong" binding:"required,gt=0" example:"10"`
+	DonGia       int64   `json:"don_gia" binding:"min=0" example:"250000"`
+}
+
+// TaoDonDatHangRequest là dữ liệu để tạo đơn đặt hàng (bản nháp)
+type TaoDonDatHangRequest struct {
+	NhaCungCapID string                  `json:"nha_cung_cap_id" binding:"required" example:"uuid-nha-cung-cap"`
+	GhiChu       string                  `json:"ghi_chu,omitempty" example:"Giao trước 8h sáng"`
+	Items        []DonDatHangItemRequest `json:"items" binding:"dive"`
+}
+
+// NhanHangItemRequest là một dòng nguyên liệu nhận được
+type NhanHangItemRequest struct {
+	NguyenLieuID string  `json:"nguyen_lieu_id" binding:"required" example:"uuid-nguyen-lieu"`
+	SoLuong      float64 `json:"so_luong" binding:"required,gt=0" example:"8"`
+	DonGia       *int64  `json:"don_gia,omitempty" binding:"omitempty,min=0" example:"245000"` // Đơn giá thực tế (bỏ trống = theo đơn)
+}
+
+// NhanHangRequest là dữ liệu ghi nhận hàng về
+type NhanHangRequest struct {
+	Items []NhanHangItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// DonDatHangItemResponse là dữ liệu trả về cho một dòng trong đơn
+type DonDatHangItemResponse struct {
+	NguyenLieuID  string  `json:"nguyen_lieu_id" example:"uuid-nguyen-lieu"`
+	TenNguyenLieu string  `json:"ten_nguyen_lieu" example:"Thịt bò"`
+	SoLuongDat    float64 `json:"so_luong_dat" example:"10"`
+	SoLuongDaNhan float64 `json:"so_luong_da_nhan" example:"8"`
+	ConThieu      float64 `json:"con_thieu" example:"2"`
+	DonGia        int64   `json:"don_gia" example:"250000"`
+}
+
+// DonDatHangResponse là dữ liệu trả về cho đơn đặt hàng
+type DonDatHangResponse struct {
+	ID           string                   `json:"id" example:"uuid-123"`
+	NhaCungCapID string                   `json:"nha_cung_cap_id" example:"uuid-nha-cung-cap"`
+	NguoiTaoID   string                   `json:"nguoi_tao_id,omitempty" example:"uuid-user"`
+	TrangThai    string                   `json:"trang_thai" example:"da_gui"`
+	Items        []DonDatHangItemResponse `json:"items"`
+	TongTien     int64                    `json:"tong_tien" example:"2500000"`
+	GhiChu       string                   `json:"ghi_chu,omitempty" example:"Giao trước 8h sáng"`
+	NgayTao      string                   `json:"ngay_tao" example:"24/01/2026 10:00"`
+	NgayGui      string                   `json:"ngay_gui,omitempty" example:"24/01/2026 10:30"`
+	NgayNhan     string                   `json:"ngay_nhan,omitempty" example:"26/01/2026 07:45"`
+}
+
+// ToDonDatHangResponse chuyển đổi Entity sang Response DTO
+func ToDonDatHangResponse(don *entity.DonDatHang) DonDatHangResponse {
+	items := make([]DonDatHangItemResponse, len(don.Items))
+	for i := range don.Items {
+		item := &don.Items[i]
+		items[i] = DonDatHangItemResponse{
+			NguyenLieuID:  item.NguyenLieuID,
+			TenNguyenLieu: item.TenNguyenLieu,
+			SoLuongDat:    item.SoLuongDat,
+			SoLuongDaNhan: item.SoLuongDaNhan,
+			ConThieu:      item.ConThieu(),
+			DonGia:        item.DonGia,
+		}
+	}
+
+	resp := DonDatHangResponse{
+		ID:           don.ID,
+		NhaCungCapID: don.NhaCungCapID,
+		NguoiTaoID:   don.NguoiTaoID,
+		TrangThai:    string(don.TrangThai),
+		Items:        items,
+		TongTien:     don.TinhTongTien(),
+		GhiChu:       don.GhiChu,
+		NgayTao:      don.NgayTao.Format("02/01/2006 15:04"),
+	}
+	if don.NgayGui != nil {
+		resp.NgayGui = don.NgayGui.Format("02/01/2006 15:04")
+	}
+	if don.NgayNhan != nil {
+		resp.NgayNhan = don.NgayNhan.Format("02/01/2006 15:04")
+	}
+	return resp
+}
+
+// ToDonDatHangResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToDonDatHangResponseList(list []*entity.DonDatHang) []DonDatHangResponse {
+	result := make([]DonDatHangResponse, len(list))
+	for i, don := range list {
+		result[i] = ToDonDatHangResponse(don)
+	}
+	return result
+}
+
+// ============================================
+// GỢI Ý ĐẶT HÀNG DTOs
+// ============================================
+
+// GoiYDatHangRequest là query params cho báo cáo gợi ý đặt hàng
+type GoiYDatHangRequest struct {
+	Days         int    `form:"days,default=14" binding:"min=1,max=90"`
+	CoverDays    int    `form:"cover_days,default=7" binding:"min=0,max=60"`
+	NhaCungCapID string `form:"supplier_id"`
+}
+
+// GoiYDatHangResponse là một dòng trong báo cáo gợi ý đặt hàng
+type GoiYDatHangResponse struct {
+	NguyenLieuID         string  `json:"nguyen_lieu_id" example:"uuid-nguyen-lieu"`
+	TenNguyenLieu        string  `json:"ten_nguyen_lieu" example:"Thịt bò"`
+	DonViTinh            string  `json:"don_vi_tinh" example:"kg"`
+	SoLuongTon           float64 `json:"so_luong_ton" example:"3"`
+	MucToiThieu          float64 `json:"muc_toi_thieu" example:"5"`
+	TieuHaoTrungBinhNgay float64 `json:"tieu_hao_trung_binh_ngay" example:"2.5"`
+	DangVe               float64 `json:"dang_ve" example:"0"`
+	SoLuongGoiY          float64 `json:"so_luong_goi_y" example:"24.5"`
+}
+
+// ToGoiYDatHangResponse tạo một dòng gợi ý đặt hàng từ nguyên liệu và số liệu tính toán
+func ToGoiYDatHangResponse(nl *entity.NguyenLieu, tieuHaoTrungBinhNgay, dangVe, soLuongGoiY float64) GoiYDatHangResponse {
+	return GoiYDatHangResponse{
+		NguyenLieuID:         nl.ID,
+		TenNguyenLieu:        nl.Ten,
+		DonViTinh:            nl.DonViTinh,
+		SoLuongTon:           nl.SoLuongTon,
+		MucToiThieu:          nl.MucToiThieu,
+		TieuHaoTrungBinhNgay: tieuHaoTrungBinhNgay,
+		DangVe:               dangVe,
+		SoLuongGoiY:          soLuongGoiY,
+	}
+}
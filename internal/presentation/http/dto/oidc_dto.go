@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// OIDCProvidersResponse là danh sách provider đăng nhập bên ngoài đang bật
+type OIDCProvidersResponse struct {
+	Providers []string `json:"providers" example:"google"`
+}
+
+// OIDCAuthorizeResponse là URL chuyển người dùng tới provider
+// Frontend lưu state và so khớp với state trong redirect trước khi gọi callback
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string `json:"authorization_url" example:"https://accounts.google.com/o/oauth2/v2/auth?client_id=..."`
+	State            string `json:"state" example:"Jf3k9sQ2..."`
+}
+
+// OIDCCallbackRequest là code và state provider trả về trang redirect của frontend
+type OIDCCallbackRequest struct {
+	Code  string `json:"code" binding:"required" example:"4/0AX4XfWh..."`
+	State string `json:"state" binding:"required" example:"Jf3k9sQ2..."`
+}
+
+// ExternalIdentityResponse là một tài khoản bên ngoài đã liên kết
+type ExternalIdentityResponse struct {
+	Provider        string     `json:"provider" example:"google"`
+	Email           string     `json:"email" example:"john@gmail.com"`
+	NgayLienKet     time.Time  `json:"ngay_lien_ket"`
+	LanDangNhapCuoi *time.Time `json:"lan_dang_nhap_cuoi,omitempty"`
+}
+
+// ExternalIdentitiesResponse là các phương thức đăng nhập của user
+type ExternalIdentitiesResponse struct {
+	HasPassword bool                       `json:"has_password" example:"true"` // false: không thể hủy liên kết cuối cùng
+	Identities  []ExternalIdentityResponse `json:"identities"`
+}
+
+// ToExternalIdentityResponse chuyển đổi ExternalIdentity entity sang Response DTO
+// Không trả subject của provider ra ngoài
+func ToExternalIdentityResponse(identity *entity.ExternalIdentity) ExternalIdentityResponse {
+	return ExternalIdentityResponse{
+		Provider:        identity.Provider,
+		Email:           identity.Email,
+		NgayLienKet:     identity.NgayLienKet,
+		LanDangNhapCuoi: identity.LanDangNhapCuoi,
+	}
+}
+
+// ToExternalIdentityResponseList chuyển đổi danh sách ExternalIdentity sang Response DTO
+func ToExternalIdentityResponseList(identities []*entity.ExternalIdentity) []ExternalIdentityResponse {
+	result := make([]ExternalIdentityResponse, 0, len(identities))
+	for _, identity := range identities {
+		result = append(result, ToExternalIdentityResponse(identity))
+	}
+	return result
+}
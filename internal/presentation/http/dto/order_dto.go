@@ -0,0 +1,216 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"fmt"
+	"strings"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// ORDER REQUEST DTOs
+// ============================================
+
+// OrderItemRequest là một món trong đơn hàng
+// TuyChon: mã nhóm tùy chọn → các mã lựa chọn (VD: {"size": ["l"], "topping": ["them-bo"]})
+type OrderItemRequest struct {
+	MonAnID string              `json:"mon_an_id" binding:"required" example:"1_mon"`
+	SoLuong int                 `json:"so_luong" binding:"required,min=1,max=100" example:"2"`
+	TuyChon map[string][]string `json:"tuy_chon,omitempty"`
+	GhiChu  string              `json:"ghi_chu,omitempty" binding:"max=255" example:"Ít cay"`
+}
+
+// TaoOrderRequest là dữ liệu để tạo đơn hàng
+type TaoOrderRequest struct {
+	LoaiOrder  string             `json:"loai_order" binding:"required,oneof=tai_cho mang_ve giao_hang" example:"tai_cho"`
+	SoBan      int                `json:"so_ban,omitempty" binding:"min=0" example:"5"`
+	GhiChu     string             `json:"ghi_chu,omitempty" binding:"max=500" example:"Khách dị ứng đậu phộng"`
+	DiaChiGiao string             `json:"dia_chi_giao,omitempty" binding:"max=500" example:""`
+	MaGiamGia  string             `json:"ma_giam_gia,omitempty" binding:"max=32" example:"TET2026AB12CD34"`
+	Items      []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+}
+
+// CapNhatTrangThaiOrderRequest là request chuyển trạng thái đơn hàng (hủy đơn dùng PUT /orders/:id/cancel)
+type CapNhatTrangThaiOrderRequest struct {
+	TrangThai string `json:"trang_thai" binding:"required,oneof=da_xac_nhan dang_nau da_nau dang_giao hoan_thanh" example:"dang_nau"`
+}
+
+// PhanCongDauBepRequest là request gán đầu bếp cho đơn hàng
+type PhanCongDauBepRequest struct {
+	DauBepID string `json:"dau_bep_id" binding:"required" example:"660e8400-e29b-41d4-a716-446655440001"`
+}
+
+// ============================================
+// ORDER RESPONSE DTOs
+// ============================================
+
+// TuyChonDaChonResponse là một tùy chọn đã chọn của món trong đơn
+type TuyChonDaChonResponse struct {
+	NhomID     string `json:"nhom_id" example:"size"`
+	TenNhom    string `json:"ten_nhom" example:"Size"`
+	LuaChonID  string `json:"lua_chon_id" example:"l"`
+	TenLuaChon string `json:"ten_lua_chon" example:"L"`
+	GiaThem    int64  `json:"gia_them" example:"10000"`
+}
+
+// OrderItemResponse là dữ liệu trả về cho một món trong đơn
+// DonGia đã gồm giá cộng thêm của các tùy chọn
+type OrderItemResponse struct {
+	MonAnID   string                  `json:"mon_an_id" example:"1_mon"`
+	TenMon    string                  `json:"ten_mon" example:"Phở bò tái"`
+	SoLuong   int                     `json:"so_luong" example:"2"`
+	DonGia    int64                   `json:"don_gia" example:"55000"`
+	TuyChon   []TuyChonDaChonResponse `json:"tuy_chon,omitempty"`
+	GhiChu    string                  `json:"ghi_chu,omitempty" example:"Ít cay"`
+	ThanhTien int64                   `json:"thanh_tien" example:"110000"`
+}
+
+// OrderResponse là dữ liệu trả về cho đơn hàng
+type OrderResponse struct {
+	ID            string                    `json:"id" example:"uuid-123"`
+	LoaiOrder     string                    `json:"loai_order" example:"tai_cho"`
+	TrangThai     string                    `json:"trang_thai" example:"moi"`
+	SoBan         int                       `json:"so_ban,omitempty" example:"5"`
+	Items         []OrderItemResponse       `json:"items"`
+	TongTien      int64                     `json:"tong_tien" example:"90000"`
+	GiamGia       int64                     `json:"giam_gia" example:"0"`
+	KhuyenMai     []KhuyenMaiApDungResponse `json:"khuyen_mai,omitempty"`
+	MaGiamGia     string                    `json:"ma_giam_gia,omitempty" example:"TET2026AB12CD34"`
+	TienGiamMa    int64                     `json:"tien_giam_ma,omitempty" example:"20000"`
+	TienThanhToan int64                     `json:"tien_thanh_toan" example:"90000"`
+	GhiChu        string                    `json:"ghi_chu,omitempty" example:""`
+	DiaChiGiao    string                    `json:"dia_chi_giao,omitempty" example:""`
+	DauBepID      string                    `json:"dau_bep_id,omitempty" example:"660e8400-e29b-41d4-a716-446655440001"`
+	ThoiGianDat   string                    `json:"thoi_gian_dat" example:"24/01/2026 12:05"`
+}
+
+// ToOrderResponse chuyển đổi Entity sang Response DTO
+func ToOrderResponse(o *entity.Order) OrderResponse {
+	items := make([]OrderItemResponse, len(o.Items))
+	for i, item := range o.Items {
+		items[i] = OrderItemResponse{
+			MonAnID:   item.MonAnID,
+			TenMon:    item.TenMon,
+			SoLuong:   item.SoLuong,
+			DonGia:    item.DonGia,
+			TuyChon:   toTuyChonDaChonResponseList(item.TuyChon),
+			GhiChu:    item.GhiChu,
+			ThanhTien: item.ThanhTien,
+		}
+	}
+
+	return OrderResponse{
+		ID:            o.ID,
+		LoaiOrder:     string(o.LoaiOrder),
+		TrangThai:     string(o.TrangThai),
+		SoBan:         o.SoBan,
+		Items:         items,
+		TongTien:      o.TongTien,
+		GiamGia:       o.GiamGia,
+		KhuyenMai:     toKhuyenMaiApDungResponseList(o.KhuyenMai),
+		MaGiamGia:     o.MaGiamGia,
+		TienGiamMa:    o.TienGiamMa,
+		TienThanhToan: o.TienThanhToan,
+		GhiChu:        o.GhiChu,
+		DiaChiGiao:    o.DiaChiGiao,
+		DauBepID:      o.DauBepID,
+		ThoiGianDat:   o.ThoiGianDat.Format("02/01/2006 15:04"),
+	}
+}
+
+// ToOrderResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToOrderResponseList(orders []*entity.Order) []OrderResponse {
+	result := make([]OrderResponse, len(orders))
+	for i, o := range orders {
+		result[i] = ToOrderResponse(o)
+	}
+	return result
+}
+
+// toTuyChonDaChonResponseList chuyển đổi các tùy chọn đã chọn sang DTO
+func toTuyChonDaChonResponseList(list []entity.TuyChonDaChon) []TuyChonDaChonResponse {
+	if len(list) == 0 {
+		return nil
+	}
+	result := make([]TuyChonDaChonResponse, len(list))
+	for i, tc := range list {
+		result[i] = TuyChonDaChonResponse{
+			NhomID:     tc.NhomID,
+			TenNhom:    tc.TenNhom,
+			LuaChonID:  tc.LuaChonID,
+			TenLuaChon: tc.TenLuaChon,
+			GiaThem:    tc.GiaThem,
+		}
+	}
+	return result
+}
+
+// ============================================
+// PHIẾU BẾP (KITCHEN TICKET)
+// ============================================
+
+// DongPhieuBep là một món trên phiếu bếp (không có giá)
+type DongPhieuBep struct {
+	TenMon  string   `json:"ten_mon" example:"Phở bò tái"`
+	SoLuong int      `json:"so_luong" example:"2"`
+	TuyChon []string `json:"tuy_chon,omitempty" example:"Size: L,Topping: Thêm bò"`
+	GhiChu  string   `json:"ghi_chu,omitempty" example:"Ít cay"`
+}
+
+// PhieuBepResponse là phiếu bếp của đơn hàng
+// NoiDung là bản text để in ra máy in bếp, mỗi tùy chọn một dòng
+type PhieuBepResponse struct {
+	OrderID     string         `json:"order_id" example:"uuid-123"`
+	LoaiOrder   string         `json:"loai_order" example:"tai_cho"`
+	SoBan       int            `json:"so_ban,omitempty" example:"5"`
+	ThoiGianDat string         `json:"thoi_gian_dat" example:"24/01/2026 12:05"`
+	Mon         []DongPhieuBep `json:"mon"`
+	GhiChu      string         `json:"ghi_chu,omitempty" example:"Khách dị ứng đậu phộng"`
+	NoiDung     string         `json:"noi_dung" example:"2 x Phở bò tái\n  + Size: L\n  Ghi chú: Ít cay"`
+}
+
+// ToPhieuBepResponse chuyển đổi đơn hàng sang phiếu bếp
+func ToPhieuBepResponse(o *entity.Order) PhieuBepResponse {
+	var b strings.Builder
+	if o.SoBan > 0 {
+		fmt.Fprintf(&b, "Bàn %d - %s\n", o.SoBan, o.LoaiOrder)
+	} else {
+		fmt.Fprintf(&b, "%s\n", o.LoaiOrder)
+	}
+
+	mon := make([]DongPhieuBep, len(o.Items))
+	for i, item := range o.Items {
+		tuyChon := make([]string, len(item.TuyChon))
+		for j, tc := range item.TuyChon {
+			tuyChon[j] = tc.TenNhom + ": " + tc.TenLuaChon
+		}
+		mon[i] = DongPhieuBep{
+			TenMon:  item.TenMon,
+			SoLuong: item.SoLuong,
+			TuyChon: tuyChon,
+			GhiChu:  item.GhiChu,
+		}
+
+		fmt.Fprintf(&b, "%d x %s\n", item.SoLuong, item.TenMon)
+		for _, tc := range tuyChon {
+			fmt.Fprintf(&b, "  + %s\n", tc)
+		}
+		if item.GhiChu != "" {
+			fmt.Fprintf(&b, "  Ghi chú: %s\n", item.GhiChu)
+		}
+	}
+	if o.GhiChu != "" {
+		fmt.Fprintf(&b, "Ghi chú đơn: %s\n", o.GhiChu)
+	}
+
+	return PhieuBepResponse{
+		OrderID:     o.ID,
+		LoaiOrder:   string(o.LoaiOrder),
+		SoBan:       o.SoBan,
+		ThoiGianDat: o.ThoiGianDat.Format("02/01/2006 15:04"),
+		Mon:         mon,
+		GhiChu:      o.GhiChu,
+		NoiDung:     b.String(),
+	}
+}
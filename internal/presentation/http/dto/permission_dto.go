@@ -0,0 +1,48 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// PERMISSION REQUEST DTOs
+// ============================================
+
+// SetRolePermissionsRequest là request thay toàn bộ quyền của một role
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required" example:"order:read,order:cancel,order:refund"`
+}
+
+// ============================================
+// PERMISSION RESPONSE DTOs
+// ============================================
+
+// PermissionResponse là dữ liệu trả về cho một permission
+type PermissionResponse struct {
+	Ma   string `json:"ma" example:"order:refund"`
+	MoTa string `json:"mo_ta" example:"Hoàn tiền đơn hàng"`
+}
+
+// RolePermissionsResponse là quyền hiện tại của một role
+type RolePermissionsResponse struct {
+	Role        string   `json:"role" example:"cashier"`
+	Permissions []string `json:"permissions" example:"order:read,order:cancel,order:refund"`
+}
+
+// ToPermissionResponseList chuyển đổi danh sách Permission sang Response DTOs
+func ToPermissionResponseList(permissions []*entity.Permission) []PermissionResponse {
+	responses := make([]PermissionResponse, len(permissions))
+	for i, p := range permissions {
+		responses[i] = PermissionResponse{Ma: p.Ma, MoTa: p.MoTa}
+	}
+	return responses
+}
+
+// ToRolePermissionsResponse tạo Response DTO cho quyền của một role
+func ToRolePermissionsResponse(role entity.UserRole, permissions []string) RolePermissionsResponse {
+	return RolePermissionsResponse{
+		Role:        string(role),
+		Permissions: permissions,
+	}
+}
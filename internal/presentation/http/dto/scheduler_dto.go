@@ -0,0 +1,76 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/service"
+)
+
+// ============================================
+// SCHEDULER RESPONSE DTOs
+// ============================================
+
+// TaskRunResponse là dữ liệu trả về cho một lần chạy tác vụ định kỳ
+type TaskRunResponse struct {
+	LichChay   time.Time `json:"lich_chay"`
+	BatDau     time.Time `json:"bat_dau"`
+	KetThuc    time.Time `json:"ket_thuc"`
+	ThoiGianMs int64     `json:"thoi_gian_ms" example:"120"`
+	ThanhCong  bool      `json:"thanh_cong" example:"true"`
+	Loi        string    `json:"loi,omitempty" example:""`
+	KetQua     string    `json:"ket_qua,omitempty" example:"dọn 12 token"`
+	Instance   string    `json:"instance" example:"api-1-4821"`
+}
+
+// TaskStatusResponse là trạng thái của một tác vụ định kỳ
+type TaskStatusResponse struct {
+	TacVu        string           `json:"tac_vu" example:"don_token_het_han"`
+	Lich         string           `json:"lich" example:"mỗi 1h0m0s"`
+	ChayTiepTheo time.Time        `json:"chay_tiep_theo"`
+	LanChayCuoi  *TaskRunResponse `json:"lan_chay_cuoi,omitempty"`
+	LoiCuoi      string           `json:"loi_cuoi,omitempty"`
+	LoiCuoiLuc   *time.Time       `json:"loi_cuoi_luc,omitempty"`
+}
+
+// ToTaskRunResponse chuyển đổi TaskRun sang Response DTO
+func ToTaskRunResponse(run service.TaskRun) TaskRunResponse {
+	return TaskRunResponse{
+		LichChay:   run.LichChay,
+		BatDau:     run.BatDau,
+		KetThuc:    run.KetThuc,
+		ThoiGianMs: run.KetThuc.Sub(run.BatDau).Milliseconds(),
+		ThanhCong:  run.Loi == "",
+		Loi:        run.Loi,
+		KetQua:     run.KetQua,
+		Instance:   run.Instance,
+	}
+}
+
+// ToTaskRunResponseList chuyển đổi danh sách TaskRun sang Response DTOs
+func ToTaskRunResponseList(runs []service.TaskRun) []TaskRunResponse {
+	result := make([]TaskRunResponse, len(runs))
+	for i, run := range runs {
+		result[i] = ToTaskRunResponse(run)
+	}
+	return result
+}
+
+// ToTaskStatusResponseList chuyển đổi danh sách TaskStatus sang Response DTOs
+func ToTaskStatusResponseList(list []service.TaskStatus) []TaskStatusResponse {
+	result := make([]TaskStatusResponse, len(list))
+	for i, status := range list {
+		result[i] = TaskStatusResponse{
+			TacVu:        status.TacVu,
+			Lich:         status.Lich,
+			ChayTiepTheo: status.ChayTiepTheo,
+			LoiCuoi:      status.LoiCuoi,
+			LoiCuoiLuc:   status.LoiCuoiLuc,
+		}
+		if status.LanChayCuoi != nil {
+			run := ToTaskRunResponse(*status.LanChayCuoi)
+			result[i].LanChayCuoi = &run
+		}
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"restaurant_project/internal/domain/service"
+)
+
+// SessionResponse là một phiên đăng nhập (một thiết bị)
+type SessionResponse struct {
+	ID            string    `json:"id" example:"3f6c2a9e-8d1b-4c55-9a7e-2b4f0c1d9e8a"`
+	Device        string    `json:"device" example:"Chrome trên Windows"`
+	UserAgent     string    `json:"user_agent" example:"Mozilla/5.0 (Windows NT 10.0; Win64; x64) ..."`
+	IP            string    `json:"ip" example:"203.0.113.10"`
+	CreatedAt     time.Time `json:"created_at"`
+	LastRefreshAt time.Time `json:"last_refresh_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	Current       bool      `json:"current" example:"true"` // Phiên của token đang gửi request
+}
+
+// ToSessionResponseList chuyển danh sách session sang Response DTO
+// currentSessionID: session của token đang gọi API (rỗng nếu admin xem session người khác)
+func ToSessionResponseList(sessions []service.Session, currentSessionID string) []SessionResponse {
+	result := make([]SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, SessionResponse{
+			ID:            s.ID,
+			Device:        describeDevice(s.UserAgent),
+			UserAgent:     s.UserAgent,
+			IP:            s.IP,
+			CreatedAt:     s.CreatedAt,
+			LastRefreshAt: s.LastRefreshAt,
+			ExpiresAt:     s.ExpiresAt,
+			Current:       currentSessionID != "" && s.ID == currentSessionID,
+		})
+	}
+	return result
+}
+
+// describeDevice đoán trình duyệt và hệ điều hành từ User-Agent để hiển thị
+// Chỉ dùng cho hiển thị, không dùng cho bảo mật
+func describeDevice(userAgent string) string {
+	fields := strings.Fields(userAgent)
+	if len(fields) == 0 {
+		return "Không xác định"
+	}
+
+	var browser string
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+
+	var os string
+	switch {
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		os = "iOS"
+	case strings.Contains(userAgent, "Android"):
+		os = "Android"
+	case strings.Contains(userAgent, "Windows"):
+		os = "Windows"
+	case strings.Contains(userAgent, "Mac OS X"):
+		os = "macOS"
+	case strings.Contains(userAgent, "Linux"):
+		os = "Linux"
+	}
+
+	switch {
+	case browser != "" && os != "":
+		return browser + " trên " + os
+	case browser != "":
+		return browser
+	case os != "":
+		return os
+	}
+
+	// App/CLI: lấy tên client đầu tiên, ví dụ "okhttp/4.12.0" -> "okhttp"
+	name, _, _ := strings.Cut(fields[0], "/")
+	return name
+}
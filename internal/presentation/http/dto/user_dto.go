@@ -14,7 +14,17 @@ type CreateUserRequest struct {
 	Username string `json:"username" binding:"required,min=3,max=50" example:"john_doe"`
 	Email    string `json:"email" binding:"required,email" example:"john@example.com"`
 	Password string `json:"password" binding:"required,min=6,max=100" example:"password123"`
-	Role     string `json:"role" binding:"required,oneof=admin manager staff customer" example:"staff"`
+	Role     string `json:"role" binding:"required,oneof=admin manager staff cashier chef customer" example:"staff"`
+}
+
+// CreateStaffRequest là dữ liệu để tạo tài khoản nhân viên (user + hồ sơ nhân viên)
+type CreateStaffRequest struct {
+	CreateUserRequest
+
+	HoTen       string `json:"ho_ten" binding:"required,max=100" example:"Nguyễn Văn Bếp"`
+	ChucVu      string `json:"chuc_vu" binding:"required,oneof=bep phuc_vu thu_ngan quan_ly giao_hang" example:"bep"`
+	SoDienThoai string `json:"so_dien_thoai" binding:"required,max=20" example:"0901234567"`
+	LuongCoBan  int64  `json:"luong_co_ban" binding:"min=0" example:"12000000"` // VND/tháng
 }
 
 // UpdateUserRequest là dữ liệu để cập nhật user
@@ -43,6 +53,13 @@ type UserResponse struct {
 	IsEmailVerified bool   `json:"is_email_verified" example:"true"`
 	CreatedAt       string `json:"created_at" example:"24/01/2026 10:00"`
 	UpdatedAt       string `json:"updated_at" example:"24/01/2026 10:30"`
+	Version         int64  `json:"version" example:"3"` // Phiên bản (giá trị ETag, gửi lại qua If-Match)
+}
+
+// StaffResponse là tài khoản nhân viên vừa tạo
+type StaffResponse struct {
+	User     UserResponse     `json:"user"`
+	NhanVien NhanVienResponse `json:"nhan_vien"`
 }
 
 // ToUserResponse chuyển đổi Entity sang Response DTO
@@ -56,6 +73,7 @@ func ToUserResponse(user *entity.User) UserResponse {
 		IsEmailVerified: user.IsEmailVerified,
 		CreatedAt:       user.NgayTao.Format("02/01/2006 15:04"),
 		UpdatedAt:       user.NgayCapNhat.Format("02/01/2006 15:04"),
+		Version:         user.Version,
 	}
 }
 
@@ -0,0 +1,115 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"time"
+
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// WEBHOOK REQUEST/RESPONSE DTOs
+// ============================================
+
+// TaoWebhookRequest là dữ liệu để đăng ký webhook
+type TaoWebhookRequest struct {
+	Ten    string   `json:"ten" binding:"required,max=100" example:"Đối tác giao hàng A"`
+	URL    string   `json:"url" binding:"required,url,max=2048" example:"https://partner.example.com/hooks/restaurant"`
+	SuKien []string `json:"su_kien" binding:"required,min=1" example:"order.status_changed,mon_an.availability_changed"`
+	Secret string   `json:"secret" binding:"required,min=16,max=255" example:"whsec_0123456789abcdef"`
+}
+
+// CapNhatWebhookRequest là dữ liệu để cập nhật webhook (trường bỏ trống thì giữ nguyên)
+type CapNhatWebhookRequest struct {
+	Ten          *string  `json:"ten,omitempty" binding:"omitempty,max=100" example:"Đối tác giao hàng A"`
+	URL          *string  `json:"url,omitempty" binding:"omitempty,url,max=2048" example:"https://partner.example.com/hooks/v2"`
+	SuKien       []string `json:"su_kien,omitempty" binding:"omitempty,min=1" example:"order.status_changed"`
+	Secret       *string  `json:"secret,omitempty" binding:"omitempty,min=16,max=255" example:"whsec_fedcba9876543210"`
+	DangHoatDong *bool    `json:"dang_hoat_dong,omitempty" example:"false"`
+}
+
+// WebhookResponse là dữ liệu trả về cho webhook (không bao giờ gồm secret)
+type WebhookResponse struct {
+	ID           string   `json:"id" example:"uuid-123"`
+	Ten          string   `json:"ten" example:"Đối tác giao hàng A"`
+	URL          string   `json:"url" example:"https://partner.example.com/hooks/restaurant"`
+	SuKien       []string `json:"su_kien" example:"order.status_changed"`
+	DangHoatDong bool     `json:"dang_hoat_dong" example:"true"`
+	NgayTao      string   `json:"ngay_tao" example:"24/01/2026 10:30"`
+	NgayCapNhat  string   `json:"ngay_cap_nhat" example:"24/01/2026 10:30"`
+}
+
+// ToWebhookResponse chuyển đổi Entity sang Response DTO
+func ToWebhookResponse(wh *entity.Webhook) WebhookResponse {
+	suKien := make([]string, len(wh.SuKien))
+	for i, loai := range wh.SuKien {
+		suKien[i] = string(loai)
+	}
+
+	return WebhookResponse{
+		ID:           wh.ID,
+		Ten:          wh.Ten,
+		URL:          wh.URL,
+		SuKien:       suKien,
+		DangHoatDong: wh.DangHoatDong,
+		NgayTao:      wh.NgayTao.Format("02/01/2006 15:04"),
+		NgayCapNhat:  wh.NgayCapNhat.Format("02/01/2006 15:04"),
+	}
+}
+
+// ToWebhookResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToWebhookResponseList(list []*entity.Webhook) []WebhookResponse {
+	result := make([]WebhookResponse, len(list))
+	for i, wh := range list {
+		result[i] = ToWebhookResponse(wh)
+	}
+	return result
+}
+
+// WebhookDeliveryQueryRequest là tham số lọc nhật ký gửi webhook (kèm phân trang)
+type WebhookDeliveryQueryRequest struct {
+	PaginationRequest
+	SuKienID  string `form:"su_kien_id"`
+	TrangThai string `form:"trang_thai"` // dang_cho | thanh_cong | that_bai
+}
+
+// WebhookDeliveryResponse là một lần gửi sự kiện tới webhook
+type WebhookDeliveryResponse struct {
+	ID           string     `json:"id" example:"uuid-456"`
+	WebhookID    string     `json:"webhook_id" example:"uuid-123"`
+	SuKienID     string     `json:"su_kien_id" example:"uuid-789"`
+	Loai         string     `json:"loai" example:"order.status_changed"`
+	TrangThai    string     `json:"trang_thai" example:"that_bai"`
+	SoLanThu     int        `json:"so_lan_thu" example:"5"`
+	MaPhanHoi    int        `json:"ma_phan_hoi,omitempty" example:"503"`
+	LoiCuoi      string     `json:"loi_cuoi,omitempty" example:"endpoint trả về HTTP 503: Service Unavailable"`
+	TaoLuc       time.Time  `json:"tao_luc"`
+	CapNhatLuc   time.Time  `json:"cap_nhat_luc"`
+	ThanhCongLuc *time.Time `json:"thanh_cong_luc,omitempty"`
+}
+
+// ToWebhookDeliveryResponse chuyển đổi Entity sang Response DTO
+func ToWebhookDeliveryResponse(d *entity.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:           d.ID,
+		WebhookID:    d.WebhookID,
+		SuKienID:     d.SuKienID,
+		Loai:         string(d.Loai),
+		TrangThai:    string(d.TrangThai),
+		SoLanThu:     d.SoLanThu,
+		MaPhanHoi:    d.MaPhanHoi,
+		LoiCuoi:      d.LoiCuoi,
+		TaoLuc:       d.TaoLuc,
+		CapNhatLuc:   d.CapNhatLuc,
+		ThanhCongLuc: d.ThanhCongLuc,
+	}
+}
+
+// ToWebhookDeliveryResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToWebhookDeliveryResponseList(list []*entity.WebhookDelivery) []WebhookDeliveryResponse {
+	result := make([]WebhookDeliveryResponse, len(list))
+	for i, d := range list {
+		result[i] = ToWebhookDeliveryResponse(d)
+	}
+	return result
+}
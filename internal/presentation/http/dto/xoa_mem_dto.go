@@ -0,0 +1,110 @@
+// Package dto chứa Data Transfer Objects
+package dto
+
+import (
+	"restaurant_project/internal/domain/entity"
+)
+
+// ============================================
+// SOFT DELETE RESPONSE DTOs
+// ============================================
+
+// MonAnDaXoaResponse là món ăn đã xóa mềm
+type MonAnDaXoaResponse struct {
+	MonAnResponse
+	NgayXoa string `json:"ngay_xoa,omitempty" example:"24/01/2026 11:00"` // Thời điểm xóa
+}
+
+// KhachHangResponse là dữ liệu trả về cho khách hàng
+type KhachHangResponse struct {
+	ID           string `json:"id" example:"770e8400-e29b-41d4-a716-446655440001"`
+	UserID       string `json:"user_id,omitempty"`
+	HoTen        string `json:"ho_ten" example:"Trần Thị Lan"`
+	SoDienThoai  string `json:"so_dien_thoai" example:"0987654321"`
+	Email        string `json:"email,omitempty" example:"lan.tran@email.com"`
+	DiaChi       string `json:"dia_chi,omitempty"`
+	DiemTichLuy  int64  `json:"diem_tich_luy" example:"5500"`
+	CapThanhVien string `json:"cap_thanh_vien" example:"gold"`
+	NgayTao      string `json:"ngay_tao" example:"24/01/2026 10:00"`
+	NgayXoa      string `json:"ngay_xoa,omitempty" example:"24/01/2026 11:00"` // Thời điểm xóa (rỗng = chưa xóa)
+}
+
+// NhanVienResponse là dữ liệu trả về cho nhân viên (không gồm lương)
+type NhanVienResponse struct {
+	ID          string `json:"id" example:"660e8400-e29b-41d4-a716-446655440001"`
+	UserID      string `json:"user_id" example:"550e8400-e29b-41d4-a716-446655440003"`
+	HoTen       string `json:"ho_ten" example:"Nguyễn Văn Bếp"`
+	ChucVu      string `json:"chuc_vu" example:"bep"`
+	SoDienThoai string `json:"so_dien_thoai" example:"0901234567"`
+	Email       string `json:"email,omitempty" example:"chef01@restaurant.vn"`
+	TrangThai   string `json:"trang_thai" example:"offline"`
+	NgayTao     string `json:"ngay_tao" example:"24/01/2026 10:00"`
+	NgayXoa     string `json:"ngay_xoa,omitempty" example:"24/01/2026 11:00"` // Thời điểm xóa (rỗng = chưa xóa)
+}
+
+// ToMonAnDaXoaResponseList chuyển đổi danh sách món đã xóa sang Response DTO
+func ToMonAnDaXoaResponseList(list []*entity.MonAn) []MonAnDaXoaResponse {
+	result := make([]MonAnDaXoaResponse, len(list))
+	for i, mon := range list {
+		result[i] = MonAnDaXoaResponse{MonAnResponse: ToMonAnResponse(mon)}
+		if mon.NgayXoa != nil {
+			result[i].NgayXoa = mon.NgayXoa.Format("02/01/2006 15:04")
+		}
+	}
+	return result
+}
+
+// ToKhachHangResponse chuyển đổi Entity sang Response DTO
+func ToKhachHangResponse(kh *entity.KhachHang) KhachHangResponse {
+	resp := KhachHangResponse{
+		ID:           kh.ID,
+		UserID:       kh.UserID,
+		HoTen:        kh.HoTen,
+		SoDienThoai:  kh.SoDienThoai,
+		Email:        kh.Email,
+		DiaChi:       kh.DiaChi,
+		DiemTichLuy:  kh.DiemTichLuy,
+		CapThanhVien: kh.CapThanhVien,
+		NgayTao:      kh.NgayTao.Format("02/01/2006 15:04"),
+	}
+	if kh.NgayXoa != nil {
+		resp.NgayXoa = kh.NgayXoa.Format("02/01/2006 15:04")
+	}
+	return resp
+}
+
+// ToKhachHangResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToKhachHangResponseList(list []*entity.KhachHang) []KhachHangResponse {
+	result := make([]KhachHangResponse, len(list))
+	for i, kh := range list {
+		result[i] = ToKhachHangResponse(kh)
+	}
+	return result
+}
+
+// ToNhanVienResponse chuyển đổi Entity sang Response DTO
+func ToNhanVienResponse(nv *entity.NhanVien) NhanVienResponse {
+	resp := NhanVienResponse{
+		ID:          nv.ID,
+		UserID:      nv.UserID,
+		HoTen:       nv.HoTen,
+		ChucVu:      string(nv.ChucVu),
+		SoDienThoai: nv.SoDienThoai,
+		Email:       nv.Email,
+		TrangThai:   string(nv.TrangThai),
+		NgayTao:     nv.NgayTao.Format("02/01/2006 15:04"),
+	}
+	if nv.NgayXoa != nil {
+		resp.NgayXoa = nv.NgayXoa.Format("02/01/2006 15:04")
+	}
+	return resp
+}
+
+// ToNhanVienResponseList chuyển đổi danh sách Entity sang Response DTO
+func ToNhanVienResponseList(list []*entity.NhanVien) []NhanVienResponse {
+	result := make([]NhanVienResponse, len(list))
+	for i, nv := range list {
+		result[i] = ToNhanVienResponse(nv)
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// AuditLogHandler xử lý các HTTP request tra cứu audit log (Admin)
+type AuditLogHandler struct {
+	useCase *usecase.AuditLogUseCase
+}
+
+// NewAuditLogHandler tạo mới AuditLogHandler
+func NewAuditLogHandler(uc *usecase.AuditLogUseCase) *AuditLogHandler {
+	return &AuditLogHandler{
+		useCase: uc,
+	}
+}
+
+// XemAuditLog xử lý GET /api/admin/audit-logs - Tra cứu audit log
+// @Summary Tra cứu audit log
+// @Description Lịch sử thao tác đặc quyền (ai, làm gì, trên đối tượng nào, giá trị trước/sau), mới nhất trước (cần audit:read)
+// @Tags AuditLogs
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query string false "Lọc theo user thực hiện (system = thao tác của hệ thống)"
+// @Param hanh_dong query string false "Lọc theo thao tác" example(mon_an.cap_nhat_gia)
+// @Param doi_tuong query string false "Lọc theo loại đối tượng" example(mon_an)
+// @Param doi_tuong_id query string false "Lọc theo ID đối tượng"
+// @Param tu query string false "Từ thời điểm (RFC3339)" example(2025-01-01T00:00:00+07:00)
+// @Param den query string false "Đến thời điểm (RFC3339)" example(2025-01-31T23:59:59+07:00)
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} dto.APIResponse{data=dto.PaginatedResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/audit-logs [get]
+func (h *AuditLogHandler) XemAuditLog(c *gin.Context) {
+	var req dto.AuditLogQueryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số tra cứu không hợp lệ", err))
+		return
+	}
+
+	tu, err := parseThoiDiem(req.Tu)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số tu phải theo định dạng RFC3339", err))
+		return
+	}
+	den, err := parseThoiDiem(req.Den)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số den phải theo định dạng RFC3339", err))
+		return
+	}
+
+	logs, total, err := h.useCase.XemAuditLog(c.Request.Context(), usecase.XemAuditLogInput{
+		ActorID:    req.ActorID,
+		HanhDong:   req.HanhDong,
+		DoiTuong:   req.DoiTuong,
+		DoiTuongID: req.DoiTuongID,
+		Tu:         tu,
+		Den:        den,
+		Offset:     req.Offset(),
+		Limit:      req.Limit,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrKhoangThoiGianKhongHopLe) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể tra cứu audit log", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Tra cứu audit log thành công",
+			dto.NewPaginatedResponse(dto.ToAuditLogResponseList(logs), total, req.Page, req.Limit)))
+}
+
+// parseThoiDiem parse thời điểm RFC3339 từ query (rỗng = không lọc)
+func parseThoiDiem(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// BasePath trả về base path cho AuditLog module
+func (h *AuditLogHandler) BasePath() string {
+	return "/admin/audit-logs"
+}
+
+// RegisterRoutes đăng ký tất cả routes của AuditLog module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *AuditLogHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionAuditRead))
+
+	rg.GET("", h.XemAuditLog)
+}
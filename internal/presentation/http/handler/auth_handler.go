@@ -3,6 +3,7 @@ package handler
 
 import (
 	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -26,10 +27,11 @@ func NewAuthHandler(uc *usecase.AuthUseCase) *AuthHandler {
 
 // Register xử lý POST /api/auth/register - Đăng ký tài khoản mới
 // @Summary Đăng ký tài khoản
-// @Description Đăng ký tài khoản mới (Customer)
+// @Description Đăng ký tài khoản mới (Customer). Gửi header X-Auth-Mode: cookie để nhận token qua HttpOnly cookie
 // @Tags Auth
 // @Accept json
 // @Produce json
+// @Param X-Auth-Mode header string false "cookie = nhận token qua HttpOnly cookie (web)"
 // @Param request body dto.RegisterRequest true "Thông tin đăng ký"
 // @Success 201 {object} dto.APIResponse{data=dto.AuthResponse}
 // @Failure 400 {object} dto.APIResponse
@@ -46,6 +48,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		Username: req.Username,
 		Email:    req.Email,
 		Password: req.Password,
+		Client:   clientInfo(c),
 	}
 
 	result, err := h.useCase.Register(c.Request.Context(), input)
@@ -62,12 +65,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		return
 	}
 
-	response := dto.AuthResponse{
-		AccessToken:  result.AccessToken,
-		RefreshToken: result.RefreshToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    result.ExpiresIn,
-		User:         dto.ToUserResponse(result.User),
+	response, err := h.newAuthResponse(c, result)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể cấp token", err))
+		return
 	}
 
 	c.JSON(http.StatusCreated,
@@ -76,12 +78,15 @@ func (h *AuthHandler) Register(c *gin.Context) {
 
 // Login xử lý POST /api/auth/login - Đăng nhập
 // @Summary Đăng nhập
-// @Description Đăng nhập và nhận tokens
+// @Description Đăng nhập và nhận tokens. Tài khoản bật 2FA (hoặc bị bắt buộc 2FA) nhận challenge token thay vì tokens.
+// @Description Gửi header X-Auth-Mode: cookie để nhận token qua HttpOnly cookie (web), mặc định trả Bearer token trong body
 // @Tags Auth
 // @Accept json
 // @Produce json
+// @Param X-Auth-Mode header string false "cookie = nhận token qua HttpOnly cookie (web)"
 // @Param request body dto.LoginRequest true "Thông tin đăng nhập"
 // @Success 200 {object} dto.APIResponse{data=dto.AuthResponse}
+// @Success 202 {object} dto.APIResponse{data=dto.MFAChallengeResponse} "Cần nhập mã TOTP (POST /api/auth/mfa/verify)"
 // @Failure 400 {object} dto.APIResponse
 // @Failure 401 {object} dto.APIResponse
 // @Router /api/auth/login [post]
@@ -96,6 +101,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	input := usecase.LoginInput{
 		Username: req.Username,
 		Password: req.Password,
+		Client:   clientInfo(c),
 	}
 
 	result, err := h.useCase.Login(c.Request.Context(), input)
@@ -125,12 +131,23 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	response := dto.AuthResponse{
-		AccessToken:  result.AccessToken,
-		RefreshToken: result.RefreshToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    result.ExpiresIn,
-		User:         dto.ToUserResponse(result.User),
+	// Bước 2: cần mã TOTP, chưa cấp token
+	if result.MFAChallenge != nil {
+		c.JSON(http.StatusAccepted,
+			dto.NewSuccessResponse("Vui lòng nhập mã xác thực hai lớp", dto.MFAChallengeResponse{
+				MFARequired:        true,
+				ChallengeToken:     result.MFAChallenge.Token,
+				EnrollmentRequired: result.MFAChallenge.YeuCauDangKy,
+				ExpiresIn:          result.MFAChallenge.ExpiresIn,
+			}))
+		return
+	}
+
+	response, err := h.newAuthResponse(c, result)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể cấp token", err))
+		return
 	}
 
 	c.JSON(http.StatusOK,
@@ -139,24 +156,51 @@ func (h *AuthHandler) Login(c *gin.Context) {
 
 // RefreshToken xử lý POST /api/auth/refresh - Làm mới access token
 // @Summary Làm mới access token
-// @Description Làm mới access token từ refresh token
+// @Description Làm mới access token từ refresh token. Refresh token chỉ dùng được một lần; dùng lại refresh token đã xoay vòng sẽ thu hồi toàn bộ phiên đăng nhập đó.
+// @Description Chế độ cookie: bỏ trống body, refresh token lấy từ cookie và bắt buộc header X-CSRF-Token
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Param X-CSRF-Token header string false "Bắt buộc khi refresh token lấy từ cookie"
+// @Param request body dto.RefreshTokenRequest false "Refresh token (bắt buộc với Bearer)"
 // @Success 200 {object} dto.APIResponse{data=dto.RefreshTokenResponse}
 // @Failure 400 {object} dto.APIResponse
 // @Failure 401 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
 // @Router /api/auth/refresh [post]
 func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	var req dto.RefreshTokenRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
 		c.JSON(http.StatusBadRequest,
 			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
 		return
 	}
 
-	result, err := h.useCase.RefreshToken(c.Request.Context(), req.RefreshToken, req.AccessToken)
+	jwtAuth := h.useCase.GetJWTAuth()
+	cookieMode := jwtAuth.UseCookieAuth(c)
+
+	refreshToken, accessToken := req.RefreshToken, req.AccessToken
+	if refreshToken == "" {
+		cookieToken, ok := jwtAuth.RefreshTokenFromCookie(c)
+		if !ok {
+			c.JSON(http.StatusBadRequest,
+				dto.NewErrorResponse("Dữ liệu không hợp lệ", errors.New("refresh_token là bắt buộc")))
+			return
+		}
+		// Cookie tự gửi kèm cả request từ site khác → bắt buộc CSRF token
+		if !jwtAuth.VerifyCSRF(c) {
+			c.JSON(http.StatusForbidden,
+				dto.NewErrorResponse("Làm mới token thất bại", middleware.ErrCSRFTokenInvalid))
+			return
+		}
+		refreshToken = cookieToken
+		cookieMode = true
+		if accessToken == "" {
+			accessToken, _ = jwtAuth.AccessTokenFromCookie(c)
+		}
+	}
+
+	result, err := h.useCase.RefreshToken(c.Request.Context(), refreshToken, accessToken, clientInfo(c))
 	if err != nil {
 		statusCode := http.StatusBadRequest
 		if err == usecase.ErrInvalidToken {
@@ -170,11 +214,11 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	response := dto.RefreshTokenResponse{
-		AccessToken:  result.AccessToken,
-		RefreshToken: result.RefreshToken,
-		TokenType:    "Bearer",
-		ExpiresIn:    result.ExpiresIn,
+	response, err := h.deliverTokens(c, result, cookieMode)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể cấp token", err))
+		return
 	}
 
 	c.JSON(http.StatusOK,
@@ -183,12 +227,12 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 
 // Logout xử lý POST /api/auth/logout - Đăng xuất (revoke cả access + refresh token)
 // @Summary Đăng xuất
-// @Description Đăng xuất và revoke cả access token và refresh token
+// @Description Đăng xuất, revoke access token, refresh token và kết thúc session hiện tại. Chế độ cookie: xóa cookie auth
 // @Tags Auth
 // @Accept json
 // @Produce json
 // @Security BearerAuth
-// @Param request body dto.LogoutRequest true "Refresh token cần revoke"
+// @Param request body dto.LogoutRequest false "Refresh token cần revoke (bắt buộc với Bearer)"
 // @Success 200 {object} dto.APIResponse
 // @Failure 400 {object} dto.APIResponse
 // @Failure 401 {object} dto.APIResponse
@@ -196,21 +240,32 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 func (h *AuthHandler) Logout(c *gin.Context) {
 	// Parse request body để lấy refresh token
 	var req dto.LogoutRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
 		c.JSON(http.StatusBadRequest,
 			dto.NewErrorResponse("Vui lòng gửi refresh_token trong body", err))
 		return
 	}
 
-	// Lấy access token từ header
-	tokenString, ok := middleware.ExtractTokenFromHeader(c)
+	jwtAuth := h.useCase.GetJWTAuth()
+
+	// Lấy access token từ header (hoặc cookie ở chế độ cookie)
+	tokenString, fromCookie, ok := jwtAuth.ExtractToken(c)
 	if !ok {
 		c.JSON(http.StatusUnauthorized,
 			dto.NewErrorResponse("Token không tìm thấy", nil))
 		return
 	}
 
-	jwtAuth := h.useCase.GetJWTAuth()
+	cookieMode := fromCookie || jwtAuth.UseCookieAuth(c)
+	refreshToken := req.RefreshToken
+	if refreshToken == "" && cookieMode {
+		// Refresh cookie có thể đã hết hạn, vẫn cho đăng xuất
+		refreshToken, _ = jwtAuth.RefreshTokenFromCookie(c)
+	} else if refreshToken == "" {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Vui lòng gửi refresh_token trong body", errors.New("refresh_token là bắt buộc")))
+		return
+	}
 
 	// 1. Blacklist access token
 	accessClaims, err := jwtAuth.ValidateToken(tokenString)
@@ -227,7 +282,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	}
 
 	// 2. Blacklist refresh token
-	refreshClaims, err := jwtAuth.ParseTokenIgnoreExpiry(req.RefreshToken)
+	refreshClaims, err := jwtAuth.ParseTokenIgnoreExpiry(refreshToken)
 	if err == nil && refreshClaims.ID != "" {
 		remainingTTL := jwtAuth.GetTokenRemainingTime(refreshClaims)
 		if remainingTTL > 0 {
@@ -239,6 +294,15 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		}
 	}
 
+	// 3. Kết thúc session (token phát hành trước khi có session thì không có sid)
+	if accessClaims.SessionID != "" {
+		_ = h.useCase.RevokeSession(c.Request.Context(), accessClaims.UserID, accessClaims.SessionID)
+	}
+
+	if cookieMode {
+		jwtAuth.ClearAuthCookies(c)
+	}
+
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Đăng xuất thành công", nil))
 }
@@ -273,6 +337,10 @@ func (h *AuthHandler) LogoutAllDevices(c *gin.Context) {
 		return
 	}
 
+	if jwtAuth := h.useCase.GetJWTAuth(); jwtAuth.UseCookieAuth(c) {
+		jwtAuth.ClearAuthCookies(c)
+	}
+
 	response := dto.LogoutAllResponse{
 		RevokedSessions: sessionCount,
 		Message:         "Đã đăng xuất khỏi tất cả thiết bị",
@@ -282,9 +350,9 @@ func (h *AuthHandler) LogoutAllDevices(c *gin.Context) {
 		dto.NewSuccessResponse("Đăng xuất tất cả thiết bị thành công", response))
 }
 
-// GetActiveSessions xử lý GET /api/auth/sessions - Lấy số lượng session active
-// @Summary Lấy số session active
-// @Description Lấy số lượng thiết bị đang đăng nhập
+// GetActiveSessions xử lý GET /api/auth/sessions - Danh sách session active
+// @Summary Danh sách session active
+// @Description Lấy các thiết bị đang đăng nhập (User-Agent, IP, thời điểm tạo và refresh gần nhất). Session hiện tại có current=true
 // @Tags Auth
 // @Produce json
 // @Security BearerAuth
@@ -299,21 +367,56 @@ func (h *AuthHandler) GetActiveSessions(c *gin.Context) {
 		return
 	}
 
-	count, err := h.useCase.GetActiveSessionCount(c.Request.Context(), userID)
+	sessions, err := h.useCase.ListSessions(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError,
 			dto.NewErrorResponse("Không thể lấy thông tin session", err))
 		return
 	}
 
+	var currentSessionID string
+	if claims, ok := middleware.GetClaims(c); ok {
+		currentSessionID = claims.SessionID
+	}
+
 	response := dto.ActiveSessionsResponse{
-		ActiveSessions: count,
+		ActiveSessions: int64(len(sessions)),
+		Sessions:       dto.ToSessionResponseList(sessions, currentSessionID),
 	}
 
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Lấy thông tin session thành công", response))
 }
 
+// RevokeSession xử lý DELETE /api/auth/sessions/:id - Đăng xuất một thiết bị
+// @Summary Thu hồi một session
+// @Description Đăng xuất một thiết bị: thu hồi mọi token của session đó
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 401 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	if err := h.useCase.RevokeSession(c.Request.Context(), userID, c.Param("id")); err != nil {
+		c.JSON(sessionErrorStatus(err),
+			dto.NewErrorResponse("Không thể thu hồi session", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã đăng xuất thiết bị", nil))
+}
+
 // VerifyEmail xử lý POST /api/auth/verify-email - Xác thực email
 // @Summary Xác thực email
 // @Description Xác thực email của user với verification token
@@ -406,6 +509,376 @@ func (h *AuthHandler) ResendVerification(c *gin.Context) {
 		dto.NewSuccessResponse("Gửi lại email xác thực thành công", response))
 }
 
+// ForgotPassword xử lý POST /api/auth/forgot-password - Yêu cầu đặt lại mật khẩu
+// @Summary Quên mật khẩu
+// @Description Gửi link đặt lại mật khẩu tới email. Response luôn giống nhau dù email có tồn tại hay không
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Email tài khoản"
+// @Success 200 {object} dto.APIResponse{data=dto.ForgotPasswordResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/auth/forgot-password [post]
+func (h *AuthHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	// Không trả lỗi về client để tránh lộ email nào tồn tại
+	_ = h.useCase.ForgotPassword(c.Request.Context(), req.Email)
+
+	response := dto.ForgotPasswordResponse{
+		Message: "Nếu email đã đăng ký, link đặt lại mật khẩu sẽ được gửi tới hộp thư",
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã tiếp nhận yêu cầu đặt lại mật khẩu", response))
+}
+
+// ResetPassword xử lý POST /api/auth/reset-password - Đặt lại mật khẩu
+// @Summary Đặt lại mật khẩu
+// @Description Đặt mật khẩu mới bằng token trong email. Token chỉ dùng một lần; mọi phiên đăng nhập cũ bị thu hồi
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Token và mật khẩu mới"
+// @Success 200 {object} dto.APIResponse
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/auth/reset-password [post]
+func (h *AuthHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	err := h.useCase.ResetPassword(c.Request.Context(), req.Token, req.NewPassword)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrInvalidResetToken) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Đặt lại mật khẩu thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đặt lại mật khẩu thành công, vui lòng đăng nhập lại", nil))
+}
+
+// VerifyMFA xử lý POST /api/auth/mfa/verify - Bước 2 đăng nhập với mã TOTP
+// @Summary Xác thực hai lớp
+// @Description Hoàn tất đăng nhập bằng challenge token và mã TOTP (hoặc mã khôi phục). Mã sai được tính vào account lockout
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param X-Auth-Mode header string false "cookie = nhận token qua HttpOnly cookie (web)"
+// @Param request body dto.MFAVerifyRequest true "Challenge token và mã"
+// @Success 200 {object} dto.APIResponse{data=dto.AuthResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 401 {object} dto.APIResponse
+// @Failure 423 {object} dto.APIResponse
+// @Router /api/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *gin.Context) {
+	var req dto.MFAVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	result, err := h.useCase.VerifyMFA(c.Request.Context(), req.ChallengeToken, req.Code, clientInfo(c))
+	if err != nil {
+		var lockedErr *usecase.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			c.JSON(http.StatusLocked, gin.H{
+				"success":           false,
+				"message":           "Tài khoản tạm thời bị khóa",
+				"error":             err.Error(),
+				"remaining_seconds": lockedErr.RemainingSeconds,
+			})
+			return
+		}
+
+		c.JSON(mfaErrorStatus(err),
+			dto.NewErrorResponse("Xác thực hai lớp thất bại", err))
+		return
+	}
+
+	response, err := h.newAuthResponse(c, result)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể cấp token", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đăng nhập thành công", response))
+}
+
+// SetupMFA xử lý POST /api/auth/mfa/setup - Đăng ký TOTP trong lúc đăng nhập
+// @Summary Đăng ký 2FA khi đăng nhập
+// @Description Dùng khi login trả enrollment_required = true: nhận secret, URI và mã khôi phục, sau đó gọi /api/auth/mfa/verify với mã đầu tiên
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body dto.MFASetupRequest true "Challenge token"
+// @Success 200 {object} dto.APIResponse{data=dto.MFAEnrollmentResponse}
+// @Failure 401 {object} dto.APIResponse
+// @Router /api/auth/mfa/setup [post]
+func (h *AuthHandler) SetupMFA(c *gin.Context) {
+	var req dto.MFASetupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	result, err := h.useCase.SetupMFAWithChallenge(c.Request.Context(), req.ChallengeToken)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err),
+			dto.NewErrorResponse("Đăng ký xác thực hai lớp thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Quét mã QR rồi nhập mã đầu tiên để hoàn tất", toMFAEnrollmentResponse(result)))
+}
+
+// GetMFAStatus xử lý GET /api/auth/mfa - Trạng thái 2FA
+// @Summary Trạng thái 2FA
+// @Description Xem 2FA đã bật chưa, có bị bắt buộc không và số mã khôi phục còn lại
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=dto.MFAStatusResponse}
+// @Failure 401 {object} dto.APIResponse
+// @Router /api/auth/mfa [get]
+func (h *AuthHandler) GetMFAStatus(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	status, err := h.useCase.GetMFAStatus(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err),
+			dto.NewErrorResponse("Không thể lấy trạng thái xác thực hai lớp", err))
+		return
+	}
+
+	response := dto.MFAStatusResponse{
+		Enabled:                status.DaBat,
+		Required:               status.BatBuoc,
+		RecoveryCodesRemaining: status.SoMaKhoiPhucCon,
+		EnabledAt:              status.NgayKichHoat,
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy trạng thái xác thực hai lớp thành công", response))
+}
+
+// EnrollMFA xử lý POST /api/auth/mfa/enroll - Bắt đầu đăng ký TOTP
+// @Summary Bắt đầu đăng ký 2FA
+// @Description Tạo secret, otpauth URI và mã khôi phục. 2FA chỉ có hiệu lực sau khi xác nhận mã đầu tiên qua /api/auth/mfa/confirm
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=dto.MFAEnrollmentResponse}
+// @Failure 401 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/auth/mfa/enroll [post]
+func (h *AuthHandler) EnrollMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	result, err := h.useCase.EnrollMFA(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(mfaErrorStatus(err),
+			dto.NewErrorResponse("Đăng ký xác thực hai lớp thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Quét mã QR rồi nhập mã đầu tiên để hoàn tất", toMFAEnrollmentResponse(result)))
+}
+
+// ConfirmMFA xử lý POST /api/auth/mfa/confirm - Xác nhận đăng ký TOTP
+// @Summary Xác nhận đăng ký 2FA
+// @Description Bật 2FA bằng mã TOTP đầu tiên từ app authenticator
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFACodeRequest true "Mã TOTP"
+// @Success 200 {object} dto.APIResponse
+// @Failure 400 {object} dto.APIResponse
+// @Failure 401 {object} dto.APIResponse
+// @Router /api/auth/mfa/confirm [post]
+func (h *AuthHandler) ConfirmMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	var req dto.MFACodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	if err := h.useCase.ConfirmMFA(c.Request.Context(), userID, req.Code); err != nil {
+		c.JSON(mfaErrorStatus(err),
+			dto.NewErrorResponse("Xác nhận xác thực hai lớp thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã bật xác thực hai lớp", nil))
+}
+
+// DisableMFA xử lý POST /api/auth/mfa/disable - Tắt 2FA
+// @Summary Tắt 2FA
+// @Description Tắt 2FA, cần mật khẩu và mã TOTP (hoặc mã khôi phục). Không được tắt nếu vai trò bị bắt buộc 2FA
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFADisableRequest true "Mật khẩu và mã"
+// @Success 200 {object} dto.APIResponse
+// @Failure 400 {object} dto.APIResponse
+// @Failure 401 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/auth/mfa/disable [post]
+func (h *AuthHandler) DisableMFA(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	var req dto.MFADisableRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	if err := h.useCase.DisableMFA(c.Request.Context(), userID, req.Password, req.Code); err != nil {
+		c.JSON(mfaErrorStatus(err),
+			dto.NewErrorResponse("Tắt xác thực hai lớp thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã tắt xác thực hai lớp", nil))
+}
+
+// newAuthResponse tạo response đăng nhập/đăng ký, token được giao theo chế độ client chọn
+func (h *AuthHandler) newAuthResponse(c *gin.Context, result *usecase.AuthResult) (dto.AuthResponse, error) {
+	tokens, err := h.deliverTokens(c, result, h.useCase.GetJWTAuth().UseCookieAuth(c))
+	if err != nil {
+		return dto.AuthResponse{}, err
+	}
+
+	return dto.AuthResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    tokens.TokenType,
+		ExpiresIn:    tokens.ExpiresIn,
+		CSRFToken:    tokens.CSRFToken,
+		User:         dto.ToUserResponse(result.User),
+	}, nil
+}
+
+// deliverTokens giao token cho client:
+// Bearer (mặc định, app mobile) trả token trong body; cookie (web) ghi HttpOnly cookie, body chỉ có CSRF token
+func (h *AuthHandler) deliverTokens(c *gin.Context, result *usecase.AuthResult, cookieMode bool) (dto.RefreshTokenResponse, error) {
+	if !cookieMode {
+		return dto.RefreshTokenResponse{
+			AccessToken:  result.AccessToken,
+			RefreshToken: result.RefreshToken,
+			TokenType:    "Bearer",
+			ExpiresIn:    result.ExpiresIn,
+		}, nil
+	}
+
+	csrfToken, err := h.useCase.GetJWTAuth().SetAuthCookies(c, result.AccessToken, result.RefreshToken)
+	if err != nil {
+		return dto.RefreshTokenResponse{}, err
+	}
+
+	return dto.RefreshTokenResponse{
+		TokenType: "Cookie",
+		ExpiresIn: result.ExpiresIn,
+		CSRFToken: csrfToken,
+	}, nil
+}
+
+// clientInfo lấy IP và User-Agent của request để lưu cùng session
+func clientInfo(c *gin.Context) usecase.ClientInfo {
+	return usecase.ClientInfo{
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// sessionErrorStatus map lỗi session sang HTTP status code
+func sessionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrSessionNotFound),
+		errors.Is(err, usecase.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// toMFAEnrollmentResponse chuyển đổi kết quả đăng ký TOTP sang Response DTO
+func toMFAEnrollmentResponse(result *usecase.MFAEnrollmentResult) dto.MFAEnrollmentResponse {
+	return dto.MFAEnrollmentResponse{
+		Secret:        result.Secret,
+		OtpauthURI:    result.URI,
+		RecoveryCodes: result.MaKhoiPhuc,
+	}
+}
+
+// mfaErrorStatus map lỗi 2FA sang HTTP status code
+func mfaErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMFAChallenge),
+		errors.Is(err, usecase.ErrInvalidMFACode),
+		errors.Is(err, usecase.ErrInvalidCredentials):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecase.ErrUserInactive),
+		errors.Is(err, usecase.ErrMFARequired):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrMFAAlreadyEnabled):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrMFANotEnrolled):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrUserNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
 // ============================================================
 // RouteRegistrar Interface Implementation
 // ============================================================
@@ -416,13 +889,20 @@ func (h *AuthHandler) BasePath() string {
 }
 
 // RegisterRoutes đăng ký tất cả routes của Auth module
-// Note: register, login, refresh, verify-email là PUBLIC - không cần JWT
-// logout, resend-verification cần JWT authentication
+// Note: register, login, refresh, verify-email, forgot/reset-password, mfa/verify, mfa/setup, oidc/* là PUBLIC - không cần JWT
+// logout, resend-verification, quản lý 2FA, liên kết tài khoản bên ngoài cần JWT authentication
 func (h *AuthHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.POST("/register", h.Register)
 	rg.POST("/login", h.Login)
 	rg.POST("/refresh", h.RefreshToken)
 	rg.POST("/verify-email", h.VerifyEmail)
+	rg.POST("/forgot-password", h.ForgotPassword)
+	rg.POST("/reset-password", h.ResetPassword)
+	rg.POST("/mfa/verify", h.VerifyMFA)
+	rg.POST("/mfa/setup", h.SetupMFA)
+	rg.GET("/oidc/providers", h.GetOIDCProviders)
+	rg.POST("/oidc/:provider/authorize", h.StartOIDCLogin)
+	rg.POST("/oidc/callback", h.CompleteOIDCLogin)
 }
 
 // RegisterProtectedRoutes đăng ký routes cần JWT authentication
@@ -430,5 +910,14 @@ func (h *AuthHandler) RegisterProtectedRoutes(rg *gin.RouterGroup) {
 	rg.POST("/logout", h.Logout)
 	rg.POST("/logout-all", h.LogoutAllDevices)
 	rg.GET("/sessions", h.GetActiveSessions)
+	rg.DELETE("/sessions/:id", h.RevokeSession)
 	rg.POST("/resend-verification", h.ResendVerification)
+	rg.GET("/mfa", h.GetMFAStatus)
+	rg.POST("/mfa/enroll", h.EnrollMFA)
+	rg.POST("/mfa/confirm", h.ConfirmMFA)
+	rg.POST("/mfa/disable", h.DisableMFA)
+	rg.GET("/identities", h.GetIdentities)
+	rg.POST("/identities/:provider/authorize", h.StartOIDCLink)
+	rg.POST("/identities/link", h.LinkIdentity)
+	rg.DELETE("/identities/:provider", h.UnlinkIdentity)
 }
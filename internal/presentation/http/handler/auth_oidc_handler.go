@@ -0,0 +1,265 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// GetOIDCProviders xử lý GET /api/auth/oidc/providers - Danh sách provider đăng nhập bên ngoài
+// @Summary Danh sách provider đăng nhập bên ngoài
+// @Description Các provider OpenID Connect đang bật (vd: google) để frontend hiển thị nút đăng nhập
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} dto.APIResponse{data=dto.OIDCProvidersResponse}
+// @Router /api/auth/oidc/providers [get]
+func (h *AuthHandler) GetOIDCProviders(c *gin.Context) {
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách provider thành công", dto.OIDCProvidersResponse{
+			Providers: h.useCase.OIDCProviders(),
+		}))
+}
+
+// StartOIDCLogin xử lý POST /api/auth/oidc/:provider/authorize - Bắt đầu đăng nhập bằng provider
+// @Summary Bắt đầu đăng nhập bằng tài khoản bên ngoài
+// @Description Tạo URL đăng nhập tại provider (authorization code + PKCE). Frontend chuyển người dùng tới authorization_url,
+// @Description provider redirect về OIDC_REDIRECT_URL kèm code và state, frontend gửi chúng tới POST /api/auth/oidc/callback
+// @Tags Auth
+// @Produce json
+// @Param provider path string true "Tên provider" example(google)
+// @Success 200 {object} dto.APIResponse{data=dto.OIDCAuthorizeResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/auth/oidc/{provider}/authorize [post]
+func (h *AuthHandler) StartOIDCLogin(c *gin.Context) {
+	authorization, err := h.useCase.StartOIDCLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		c.JSON(oidcErrorStatus(err),
+			dto.NewErrorResponse("Không thể bắt đầu đăng nhập", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Chuyển tới trang đăng nhập của provider", dto.OIDCAuthorizeResponse{
+			AuthorizationURL: authorization.URL,
+			State:            authorization.State,
+		}))
+}
+
+// CompleteOIDCLogin xử lý POST /api/auth/oidc/callback - Hoàn tất đăng nhập bằng provider
+// @Summary Hoàn tất đăng nhập bằng tài khoản bên ngoài
+// @Description Đổi code lấy danh tính tại provider. Tài khoản chưa liên kết được ghép với user cùng email (email phải được provider xác minh),
+// @Description chưa có user thì tạo tài khoản Customer mới. Tài khoản bật 2FA nhận challenge token thay vì tokens
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param X-Auth-Mode header string false "cookie = nhận token qua HttpOnly cookie (web)"
+// @Param request body dto.OIDCCallbackRequest true "Code và state từ provider"
+// @Success 200 {object} dto.APIResponse{data=dto.AuthResponse}
+// @Success 202 {object} dto.APIResponse{data=dto.MFAChallengeResponse} "Cần nhập mã TOTP (POST /api/auth/mfa/verify)"
+// @Failure 400 {object} dto.APIResponse
+// @Failure 401 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/auth/oidc/callback [post]
+func (h *AuthHandler) CompleteOIDCLogin(c *gin.Context) {
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	result, err := h.useCase.CompleteOIDCLogin(c.Request.Context(), req.Code, req.State, clientInfo(c))
+	if err != nil {
+		c.JSON(oidcErrorStatus(err),
+			dto.NewErrorResponse("Đăng nhập thất bại", err))
+		return
+	}
+
+	// Bước 2: cần mã TOTP, chưa cấp token
+	if result.MFAChallenge != nil {
+		c.JSON(http.StatusAccepted,
+			dto.NewSuccessResponse("Vui lòng nhập mã xác thực hai lớp", dto.MFAChallengeResponse{
+				MFARequired:        true,
+				ChallengeToken:     result.MFAChallenge.Token,
+				EnrollmentRequired: result.MFAChallenge.YeuCauDangKy,
+				ExpiresIn:          result.MFAChallenge.ExpiresIn,
+			}))
+		return
+	}
+
+	response, err := h.newAuthResponse(c, result)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể cấp token", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đăng nhập thành công", response))
+}
+
+// GetIdentities xử lý GET /api/auth/identities - Tài khoản bên ngoài đã liên kết
+// @Summary Tài khoản bên ngoài đã liên kết
+// @Description Các tài khoản bên ngoài đã liên kết và user đã có mật khẩu hay chưa
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=dto.ExternalIdentitiesResponse}
+// @Failure 401 {object} dto.APIResponse
+// @Router /api/auth/identities [get]
+func (h *AuthHandler) GetIdentities(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	linked, err := h.useCase.ListIdentities(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(oidcErrorStatus(err),
+			dto.NewErrorResponse("Không thể lấy tài khoản liên kết", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy tài khoản liên kết thành công", dto.ExternalIdentitiesResponse{
+			HasPassword: linked.HasPassword,
+			Identities:  dto.ToExternalIdentityResponseList(linked.Identities),
+		}))
+}
+
+// StartOIDCLink xử lý POST /api/auth/identities/:provider/authorize - Bắt đầu liên kết tài khoản bên ngoài
+// @Summary Bắt đầu liên kết tài khoản bên ngoài
+// @Description Tạo URL đăng nhập tại provider để liên kết. Sau khi provider redirect về, gửi code và state tới POST /api/auth/identities/link
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Tên provider" example(google)
+// @Success 200 {object} dto.APIResponse{data=dto.OIDCAuthorizeResponse}
+// @Failure 401 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/auth/identities/{provider}/authorize [post]
+func (h *AuthHandler) StartOIDCLink(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	authorization, err := h.useCase.StartOIDCLink(c.Request.Context(), userID, c.Param("provider"))
+	if err != nil {
+		c.JSON(oidcErrorStatus(err),
+			dto.NewErrorResponse("Không thể bắt đầu liên kết", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Chuyển tới trang đăng nhập của provider", dto.OIDCAuthorizeResponse{
+			AuthorizationURL: authorization.URL,
+			State:            authorization.State,
+		}))
+}
+
+// LinkIdentity xử lý POST /api/auth/identities/link - Hoàn tất liên kết tài khoản bên ngoài
+// @Summary Liên kết tài khoản bên ngoài
+// @Description Đổi code lấy danh tính tại provider và liên kết với user đang đăng nhập
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.OIDCCallbackRequest true "Code và state từ provider"
+// @Success 200 {object} dto.APIResponse{data=dto.ExternalIdentityResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 401 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/auth/identities/link [post]
+func (h *AuthHandler) LinkIdentity(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	identity, err := h.useCase.LinkIdentity(c.Request.Context(), userID, req.Code, req.State)
+	if err != nil {
+		c.JSON(oidcErrorStatus(err),
+			dto.NewErrorResponse("Liên kết tài khoản thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã liên kết tài khoản", dto.ToExternalIdentityResponse(identity)))
+}
+
+// UnlinkIdentity xử lý DELETE /api/auth/identities/:provider - Hủy liên kết tài khoản bên ngoài
+// @Summary Hủy liên kết tài khoản bên ngoài
+// @Description Hủy liên kết provider. Không cho hủy nếu đây là phương thức đăng nhập duy nhất (user chưa đặt mật khẩu)
+// @Tags Auth
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Tên provider" example(google)
+// @Success 200 {object} dto.APIResponse
+// @Failure 401 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/auth/identities/{provider} [delete]
+func (h *AuthHandler) UnlinkIdentity(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized,
+			dto.NewErrorResponse("Không tìm thấy thông tin user", nil))
+		return
+	}
+
+	if err := h.useCase.UnlinkIdentity(c.Request.Context(), userID, c.Param("provider")); err != nil {
+		c.JSON(oidcErrorStatus(err),
+			dto.NewErrorResponse("Hủy liên kết thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã hủy liên kết tài khoản", nil))
+}
+
+// oidcErrorStatus map lỗi đăng nhập/liên kết tài khoản bên ngoài sang HTTP status code
+func oidcErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrInvalidOIDCState),
+		errors.Is(err, usecase.ErrOIDCEmailNotVerified):
+		return http.StatusBadRequest
+	case errors.Is(err, usecase.ErrOIDCLoginFailed):
+		return http.StatusUnauthorized
+	case errors.Is(err, usecase.ErrUserInactive):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrOIDCProviderNotFound),
+		errors.Is(err, usecase.ErrIdentityNotFound),
+		errors.Is(err, usecase.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrOIDCAccountNotVerified),
+		errors.Is(err, usecase.ErrIdentityAlreadyLinked),
+		errors.Is(err, usecase.ErrLastLoginMethod),
+		errors.Is(err, usecase.ErrEmailExists),
+		errors.Is(err, usecase.ErrUsernameExists):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,78 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// BaoCaoHandler xử lý các HTTP request báo cáo
+type BaoCaoHandler struct {
+	useCase *usecase.BaoCaoUseCase
+}
+
+// NewBaoCaoHandler tạo mới BaoCaoHandler
+func NewBaoCaoHandler(uc *usecase.BaoCaoUseCase) *BaoCaoHandler {
+	return &BaoCaoHandler{
+		useCase: uc,
+	}
+}
+
+// XemDoanhThuNgay xử lý GET /api/reports/daily-revenue - Báo cáo doanh thu theo ngày
+// @Summary Báo cáo doanh thu theo ngày
+// @Description Các snapshot doanh thu được scheduler chụp mỗi đêm, tối đa 366 ngày (cần report:read)
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tu_ngay query string true "Từ ngày (YYYY-MM-DD)"
+// @Param den_ngay query string true "Đến ngày (YYYY-MM-DD)"
+// @Success 200 {object} dto.APIResponse{data=[]dto.DoanhThuNgayResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/reports/daily-revenue [get]
+func (h *BaoCaoHandler) XemDoanhThuNgay(c *gin.Context) {
+	var req dto.DoanhThuNgayRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số không hợp lệ", err))
+		return
+	}
+
+	list, err := h.useCase.XemDoanhThuNgay(c.Request.Context(), req.TuNgay, req.DenNgay)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrKhoangNgayKhongHopLe) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể lấy báo cáo doanh thu", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy báo cáo doanh thu thành công", dto.ToDoanhThuNgayResponseList(list)))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Reports module
+func (h *BaoCaoHandler) BasePath() string {
+	return "/reports"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Reports module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *BaoCaoHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionReportRead))
+
+	rg.GET("/daily-revenue", h.XemDoanhThuNgay)
+}
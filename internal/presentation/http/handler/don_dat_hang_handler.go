@@ -0,0 +1,338 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// DonDatHangHandler xử lý các HTTP request liên quan đến đơn đặt hàng (purchase order)
+type DonDatHangHandler struct {
+	useCase *usecase.MuaHangUseCase
+}
+
+// NewDonDatHangHandler tạo mới DonDatHangHandler
+func NewDonDatHangHandler(uc *usecase.MuaHangUseCase) *DonDatHangHandler {
+	return &DonDatHangHandler{
+		useCase: uc,
+	}
+}
+
+// donDatHangErrorStatus chọn HTTP status phù hợp cho lỗi từ MuaHangUseCase
+func donDatHangErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrDonDatHangNotFound),
+		errors.Is(err, usecase.ErrNhaCungCapNotFound),
+		errors.Is(err, usecase.ErrNguyenLieuNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// XemDonDatHang xử lý GET /api/purchase-orders - Lấy danh sách đơn đặt hàng
+// @Summary Lấy danh sách đơn đặt hàng
+// @Description Lấy danh sách đơn đặt hàng, có thể lọc theo trạng thái (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param trang_thai query string false "Lọc theo trạng thái (nhap, da_gui, nhan_mot_phan, da_nhan, da_huy)"
+// @Success 200 {object} dto.APIResponse{data=[]dto.DonDatHangResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/purchase-orders [get]
+func (h *DonDatHangHandler) XemDonDatHang(c *gin.Context) {
+	trangThai := entity.TrangThaiDonDatHang(c.Query("trang_thai"))
+
+	list, err := h.useCase.XemDonDatHang(c.Request.Context(), trangThai)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách đơn đặt hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách đơn đặt hàng thành công", dto.ToDonDatHangResponseList(list)))
+}
+
+// TaoDonDatHang xử lý POST /api/purchase-orders - Tạo đơn đặt hàng
+// @Summary Tạo đơn đặt hàng
+// @Description Tạo đơn đặt hàng ở trạng thái nháp (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TaoDonDatHangRequest true "Thông tin đơn đặt hàng"
+// @Success 201 {object} dto.APIResponse{data=dto.DonDatHangResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/purchase-orders [post]
+func (h *DonDatHangHandler) TaoDonDatHang(c *gin.Context) {
+	var req dto.TaoDonDatHangRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+
+	items := make([]usecase.TaoDonDatHangItemInput, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = usecase.TaoDonDatHangItemInput{
+			NguyenLieuID: item.NguyenLieuID,
+			SoLuong:      item.SoLuong,
+			DonGia:       item.DonGia,
+		}
+	}
+
+	input := usecase.TaoDonDatHangInput{
+		NhaCungCapID: req.NhaCungCapID,
+		NguoiTaoID:   userID,
+		GhiChu:       req.GhiChu,
+		Items:        items,
+	}
+
+	don, err := h.useCase.TaoDonDatHang(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(donDatHangErrorStatus(err),
+			dto.NewErrorResponse("Không thể tạo đơn đặt hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Tạo đơn đặt hàng thành công", dto.ToDonDatHangResponse(don)))
+}
+
+// TimDonDatHang xử lý GET /api/purchase-orders/:id - Lấy đơn đặt hàng theo ID
+// @Summary Lấy đơn đặt hàng theo ID
+// @Description Lấy chi tiết đơn đặt hàng kèm các dòng nguyên liệu (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Đơn đặt hàng ID"
+// @Success 200 {object} dto.APIResponse{data=dto.DonDatHangResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/purchase-orders/{id} [get]
+func (h *DonDatHangHandler) TimDonDatHang(c *gin.Context) {
+	don, err := h.useCase.TimDonDatHang(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrDonDatHangNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể lấy đơn đặt hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy đơn đặt hàng thành công", dto.ToDonDatHangResponse(don)))
+}
+
+// ThemNguyenLieu xử lý POST /api/purchase-orders/:id/items - Thêm dòng nguyên liệu
+// @Summary Thêm nguyên liệu vào đơn đặt hàng
+// @Description Thêm một dòng nguyên liệu vào đơn đặt hàng nháp (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Đơn đặt hàng ID"
+// @Param request body dto.DonDatHangItemRequest true "Dòng nguyên liệu"
+// @Success 200 {object} dto.APIResponse{data=dto.DonDatHangResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/purchase-orders/{id}/items [post]
+func (h *DonDatHangHandler) ThemNguyenLieu(c *gin.Context) {
+	var req dto.DonDatHangItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	item := usecase.TaoDonDatHangItemInput{
+		NguyenLieuID: req.NguyenLieuID,
+		SoLuong:      req.SoLuong,
+		DonGia:       req.DonGia,
+	}
+
+	don, err := h.useCase.ThemNguyenLieuVaoDon(c.Request.Context(), c.Param("id"), item)
+	if err != nil {
+		c.JSON(donDatHangErrorStatus(err),
+			dto.NewErrorResponse("Không thể thêm nguyên liệu vào đơn", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Thêm nguyên liệu vào đơn thành công", dto.ToDonDatHangResponse(don)))
+}
+
+// GuiDonDatHang xử lý PUT /api/purchase-orders/:id/send - Gửi đơn cho nhà cung cấp
+// @Summary Gửi đơn đặt hàng
+// @Description Chuyển đơn từ nháp sang đã gửi (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Đơn đặt hàng ID"
+// @Success 200 {object} dto.APIResponse{data=dto.DonDatHangResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/purchase-orders/{id}/send [put]
+func (h *DonDatHangHandler) GuiDonDatHang(c *gin.Context) {
+	don, err := h.useCase.GuiDonDatHang(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(donDatHangErrorStatus(err),
+			dto.NewErrorResponse("Không thể gửi đơn đặt hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Gửi đơn đặt hàng thành công", dto.ToDonDatHangResponse(don)))
+}
+
+// NhanHang xử lý POST /api/purchase-orders/:id/receive - Ghi nhận hàng về
+// @Summary Nhận hàng
+// @Description Ghi nhận hàng về (toàn bộ hoặc một phần), tăng tồn kho và cập nhật giá vốn (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Đơn đặt hàng ID"
+// @Param request body dto.NhanHangRequest true "Danh sách nguyên liệu nhận được"
+// @Success 200 {object} dto.APIResponse{data=dto.DonDatHangResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/purchase-orders/{id}/receive [post]
+func (h *DonDatHangHandler) NhanHang(c *gin.Context) {
+	var req dto.NhanHangRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	items := make([]usecase.NhanHangItemInput, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = usecase.NhanHangItemInput{
+			NguyenLieuID: item.NguyenLieuID,
+			SoLuong:      item.SoLuong,
+			DonGia:       item.DonGia,
+		}
+	}
+
+	input := usecase.NhanHangInput{
+		DonDatHangID: c.Param("id"),
+		Items:        items,
+	}
+
+	don, err := h.useCase.NhanHang(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(donDatHangErrorStatus(err),
+			dto.NewErrorResponse("Không thể nhận hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Nhận hàng thành công", dto.ToDonDatHangResponse(don)))
+}
+
+// HuyDonDatHang xử lý PUT /api/purchase-orders/:id/cancel - Hủy đơn đặt hàng
+// @Summary Hủy đơn đặt hàng
+// @Description Hủy đơn đặt hàng chưa nhận hàng (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Đơn đặt hàng ID"
+// @Success 200 {object} dto.APIResponse{data=dto.DonDatHangResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/purchase-orders/{id}/cancel [put]
+func (h *DonDatHangHandler) HuyDonDatHang(c *gin.Context) {
+	don, err := h.useCase.HuyDonDatHang(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(donDatHangErrorStatus(err),
+			dto.NewErrorResponse("Không thể hủy đơn đặt hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Hủy đơn đặt hàng thành công", dto.ToDonDatHangResponse(don)))
+}
+
+// GoiYDatHang xử lý GET /api/purchase-orders/suggestions - Báo cáo gợi ý đặt hàng
+// @Summary Gợi ý đặt hàng
+// @Description Tính số lượng nên đặt thêm dựa trên tiêu hao gần đây, tồn kho và hàng đang về (cần purchasing:manage)
+// @Tags PurchaseOrders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Số ngày tiêu hao gần đây (default: 14)"
+// @Param cover_days query int false "Số ngày dự trữ mong muốn (default: 7)"
+// @Param supplier_id query string false "Nhà cung cấp dự kiến (cộng thêm thời gian giao hàng)"
+// @Success 200 {object} dto.APIResponse{data=[]dto.GoiYDatHangResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/purchase-orders/suggestions [get]
+func (h *DonDatHangHandler) GoiYDatHang(c *gin.Context) {
+	var req dto.GoiYDatHangRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số không hợp lệ", err))
+		return
+	}
+
+	input := usecase.GoiYDatHangInput{
+		SoNgayTieuHao: req.Days,
+		SoNgayDuTru:   req.CoverDays,
+		NhaCungCapID:  req.NhaCungCapID,
+	}
+
+	list, err := h.useCase.GoiYDatHang(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(donDatHangErrorStatus(err),
+			dto.NewErrorResponse("Không thể tính gợi ý đặt hàng", err))
+		return
+	}
+
+	result := make([]dto.GoiYDatHangResponse, len(list))
+	for i, g := range list {
+		result[i] = dto.ToGoiYDatHangResponse(g.NguyenLieu, g.TieuHaoTrungBinhNgay, g.DangVe, g.SoLuongGoiY)
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Tính gợi ý đặt hàng thành công", result))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho PurchaseOrders module
+func (h *DonDatHangHandler) BasePath() string {
+	return "/purchase-orders"
+}
+
+// RegisterRoutes đăng ký tất cả routes của PurchaseOrders module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *DonDatHangHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionPurchasingManage))
+
+	rg.GET("", h.XemDonDatHang)
+	rg.POST("", h.TaoDonDatHang)
+	rg.GET("/suggestions", h.GoiYDatHang)
+	rg.GET("/:id", h.TimDonDatHang)
+	rg.POST("/:id/items", h.ThemNguyenLieu)
+	rg.PUT("/:id/send", h.GuiDonDatHang)
+	rg.POST("/:id/receive", h.NhanHang)
+	rg.PUT("/:id/cancel", h.HuyDonDatHang)
+}
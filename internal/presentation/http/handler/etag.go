@@ -0,0 +1,54 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// ============================================================
+// Optimistic concurrency qua ETag / If-Match
+// ETag là Version của entity: client đọc (GET) nhận ETag, gửi lại trong If-Match
+// khi sửa. Phiên bản đã thay đổi → 412 Precondition Failed, client tải lại rồi thử lại
+// ============================================================
+
+// datETag gắn phiên bản hiện tại của tài nguyên vào header ETag
+func datETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// docIfMatch đọc phiên bản client mong đợi từ header If-Match
+// Không có header hoặc "*" → 0 (không kiểm tra). Chấp nhận weak ETag (W/"3")
+// Giá trị không phải ETag do API cấp thì không thể khớp: trả 412 và ok = false
+func docIfMatch(c *gin.Context) (version int64, ok bool) {
+	v := strings.TrimSpace(c.GetHeader("If-Match"))
+	if v == "" || v == "*" {
+		return 0, true
+	}
+
+	v = strings.TrimPrefix(v, "W/")
+	version, err := strconv.ParseInt(strings.Trim(v, `"`), 10, 64)
+	if err != nil || version <= 0 {
+		traLoiXungDot(c)
+		return 0, false
+	}
+	return version, true
+}
+
+// laXungDot kiểm tra lỗi có phải do dữ liệu đã bị thay đổi đồng thời không
+func laXungDot(err error) bool {
+	return errors.Is(err, usecase.ErrConcurrentModification)
+}
+
+// traLoiXungDot trả 412 khi phiên bản client gửi không còn là phiên bản hiện tại
+func traLoiXungDot(c *gin.Context) {
+	c.JSON(http.StatusPreconditionFailed,
+		dto.NewErrorResponse("Dữ liệu đã bị thay đổi bởi người khác, hãy tải lại và thử lại", usecase.ErrConcurrentModification))
+}
@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/persistence/memory"
+)
+
+// noopUnitOfWork chạy fn trực tiếp (repository bộ nhớ không có transaction)
+type noopUnitOfWork struct{}
+
+func (noopUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// ghiDongThoiRepo giả lập request khác lưu món ngay trước lần Save của request đang xử lý
+type ghiDongThoiRepo struct {
+	*memory.MonAnMemoryRepo
+	ghiDongThoi bool
+}
+
+func (r *ghiDongThoiRepo) Save(ctx context.Context, mon *entity.MonAn) error {
+	if r.ghiDongThoi {
+		r.ghiDongThoi = false
+		khac, _ := r.MonAnMemoryRepo.FindByID(ctx, mon.ID)
+		if err := r.MonAnMemoryRepo.Save(ctx, khac); err != nil {
+			return err
+		}
+	}
+	return r.MonAnMemoryRepo.Save(ctx, mon)
+}
+
+func TestDocIfMatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		wantVersion int64
+		wantOK      bool
+	}{
+		{name: "không có header", wantOK: true},
+		{name: "dấu sao", ifMatch: "*", wantOK: true},
+		{name: "strong ETag", ifMatch: `"3"`, wantVersion: 3, wantOK: true},
+		{name: "weak ETag", ifMatch: `W/"7"`, wantVersion: 7, wantOK: true},
+		{name: "không có ngoặc kép", ifMatch: "5", wantVersion: 5, wantOK: true},
+		{name: "không phải số", ifMatch: `"abc"`},
+		{name: "phiên bản 0", ifMatch: `"0"`},
+		{name: "phiên bản âm", ifMatch: `"-1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+			if tt.ifMatch != "" {
+				c.Request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			version, ok := docIfMatch(c)
+			if ok != tt.wantOK || version != tt.wantVersion {
+				t.Fatalf("docIfMatch = (%d, %v), want (%d, %v)", version, ok, tt.wantVersion, tt.wantOK)
+			}
+			if !ok && w.Code != http.StatusPreconditionFailed {
+				t.Errorf("status = %d, want 412", w.Code)
+			}
+		})
+	}
+}
+
+func TestMonAnHandler_CapNhatGia_OptimisticLocking(t *testing.T) {
+	tests := []struct {
+		name        string
+		ifMatch     string
+		ghiDongThoi bool
+		wantStatus  int
+		wantETag    string
+		wantGia     int64
+	}{
+		{
+			name:       "không gửi If-Match",
+			wantStatus: http.StatusOK,
+			wantETag:   `"2"`,
+			wantGia:    60000,
+		},
+		{
+			name:       "If-Match khớp phiên bản hiện tại",
+			ifMatch:    `"1"`,
+			wantStatus: http.StatusOK,
+			wantETag:   `"2"`,
+			wantGia:    60000,
+		},
+		{
+			name:       "If-Match không phải ETag do API cấp",
+			ifMatch:    `"0"`,
+			wantStatus: http.StatusPreconditionFailed,
+			wantGia:    50000,
+		},
+		{
+			name:       "If-Match là phiên bản khác",
+			ifMatch:    `W/"5"`,
+			wantStatus: http.StatusPreconditionFailed,
+			wantGia:    50000,
+		},
+		{
+			name:        "request khác lưu trước giữa lúc đọc và ghi",
+			ifMatch:     `"1"`,
+			ghiDongThoi: true,
+			wantStatus:  http.StatusPreconditionFailed,
+			wantGia:     50000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := &ghiDongThoiRepo{MonAnMemoryRepo: memory.NewMonAnMemoryRepo()}
+			mon, err := entity.NewMonAn("pho-bo", "Phở bò", 50000, "")
+			if err != nil {
+				t.Fatalf("NewMonAn: %v", err)
+			}
+			if err := repo.Save(ctx, mon); err != nil {
+				t.Fatalf("Save: %v", err)
+			}
+			repo.ghiDongThoi = tt.ghiDongThoi
+
+			h := NewMonAnHandler(usecase.NewMonAnUseCase(repo, nil, nil, nil, noopUnitOfWork{}, nil, nil))
+			router := gin.New()
+			router.GET("/mon-an/:id", h.TimMon)
+			router.PUT("/mon-an/:id/gia", h.CapNhatGia)
+
+			// Client đọc món và nhận ETag của phiên bản đầu tiên
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/mon-an/pho-bo", nil))
+			if etag := w.Header().Get("ETag"); etag != `"1"` {
+				t.Fatalf("GET ETag = %s, want \"1\"", etag)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/mon-an/pho-bo/gia", strings.NewReader(`{"gia": 60000}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if etag := w.Header().Get("ETag"); etag != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", etag, tt.wantETag)
+			}
+			if w.Code == http.StatusPreconditionFailed {
+				var body struct {
+					Success bool `json:"success"`
+				}
+				if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Success {
+					t.Errorf("412 body = %s", w.Body.String())
+				}
+			}
+
+			saved, _ := repo.MonAnMemoryRepo.FindByID(ctx, "pho-bo")
+			if saved.Gia != tt.wantGia {
+				t.Errorf("stored price = %d, want %d", saved.Gia, tt.wantGia)
+			}
+		})
+	}
+}
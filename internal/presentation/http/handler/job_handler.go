@@ -0,0 +1,125 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// JobHandler xử lý các HTTP request quản trị hàng đợi công việc nền
+type JobHandler struct {
+	useCase *usecase.JobUseCase
+}
+
+// NewJobHandler tạo mới JobHandler
+func NewJobHandler(uc *usecase.JobUseCase) *JobHandler {
+	return &JobHandler{
+		useCase: uc,
+	}
+}
+
+// ThongKe xử lý GET /api/admin/jobs/stats - Thống kê hàng đợi
+// @Summary Thống kê hàng đợi job
+// @Description Số job đang chờ, đang chờ thử lại và đã thất bại (cần job:manage)
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=dto.ThongKeJobResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/jobs/stats [get]
+func (h *JobHandler) ThongKe(c *gin.Context) {
+	stats, err := h.useCase.ThongKe(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể thống kê hàng đợi", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Thống kê hàng đợi thành công", dto.ToThongKeJobResponse(stats)))
+}
+
+// XemJobThatBai xử lý GET /api/admin/jobs/dead-letter - Liệt kê job thất bại
+// @Summary Liệt kê job thất bại
+// @Description Liệt kê job trong dead-letter list, mới nhất trước (cần job:manage)
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} dto.APIResponse{data=dto.PaginatedResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/jobs/dead-letter [get]
+func (h *JobHandler) XemJobThatBai(c *gin.Context) {
+	var pagination dto.PaginationRequest
+	if err := c.ShouldBindQuery(&pagination); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số phân trang không hợp lệ", err))
+		return
+	}
+
+	jobs, total, err := h.useCase.XemJobThatBai(c.Request.Context(), pagination.Offset(), pagination.Limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách job thất bại", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách job thất bại thành công",
+			dto.NewPaginatedResponse(dto.ToJobResponseList(jobs), total, pagination.Page, pagination.Limit)))
+}
+
+// ChayLaiJob xử lý POST /api/admin/jobs/dead-letter/:id/replay - Chạy lại job thất bại
+// @Summary Chạy lại job thất bại
+// @Description Đưa job từ dead-letter trở lại hàng đợi với số lần thử reset về 0 (cần job:manage)
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/admin/jobs/dead-letter/{id}/replay [post]
+func (h *JobHandler) ChayLaiJob(c *gin.Context) {
+	if err := h.useCase.ChayLaiJob(c.Request.Context(), c.Param("id")); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrJobNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể chạy lại job", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã đưa job trở lại hàng đợi", nil))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Jobs module
+func (h *JobHandler) BasePath() string {
+	return "/admin/jobs"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Jobs module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *JobHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionJobManage))
+
+	rg.GET("/stats", h.ThongKe)
+	rg.GET("/dead-letter", h.XemJobThatBai)
+	rg.POST("/dead-letter/:id/replay", h.ChayLaiJob)
+}
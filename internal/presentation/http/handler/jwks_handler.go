@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/infrastructure/middleware"
+)
+
+// jwksCacheControl cho phép service khác cache JWKS, đủ ngắn để nhận key mới khi xoay vòng
+const jwksCacheControl = "public, max-age=300"
+
+// JWKSHandler công bố public key để service khác tự verify JWT
+type JWKSHandler struct {
+	jwtAuth *middleware.JWTAuthMiddleware
+}
+
+// NewJWKSHandler tạo instance mới
+func NewJWKSHandler(jwtAuth *middleware.JWTAuthMiddleware) *JWKSHandler {
+	return &JWKSHandler{jwtAuth: jwtAuth}
+}
+
+// GetJWKS xử lý GET /.well-known/jwks.json
+// Trả về JSON Web Key Set chuẩn RFC 7517 (không bọc trong SuccessResponse)
+// @Summary JSON Web Key Set
+// @Description Public key đang được chấp nhận để verify access/refresh token (chọn key theo header kid). Rỗng khi server dùng HS256
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} middleware.JWKS
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", jwksCacheControl)
+	c.JSON(http.StatusOK, h.jwtAuth.JWKS())
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho JWKS (không có prefix /api)
+func (h *JWKSHandler) BasePath() string {
+	return "/.well-known"
+}
+
+// RegisterRoutes đăng ký routes của JWKS
+func (h *JWKSHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.GET("/jwks.json", h.GetJWKS)
+}
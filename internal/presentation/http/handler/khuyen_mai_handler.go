@@ -0,0 +1,182 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// KhuyenMaiHandler xử lý các HTTP request liên quan đến khuyến mãi
+type KhuyenMaiHandler struct {
+	useCase *usecase.KhuyenMaiUseCase
+}
+
+// NewKhuyenMaiHandler tạo mới KhuyenMaiHandler
+func NewKhuyenMaiHandler(uc *usecase.KhuyenMaiUseCase) *KhuyenMaiHandler {
+	return &KhuyenMaiHandler{
+		useCase: uc,
+	}
+}
+
+// XemKhuyenMai xử lý GET /api/promotions - Lấy danh sách khuyến mãi
+// @Summary Lấy danh sách khuyến mãi
+// @Description Lấy tất cả chương trình khuyến mãi, kể cả đã ngừng (cần promotion:manage)
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.KhuyenMaiResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/promotions [get]
+func (h *KhuyenMaiHandler) XemKhuyenMai(c *gin.Context) {
+	list, err := h.useCase.XemKhuyenMai(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách khuyến mãi", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách khuyến mãi thành công", dto.ToKhuyenMaiResponseList(list)))
+}
+
+// TaoKhuyenMai xử lý POST /api/promotions - Tạo khuyến mãi
+// @Summary Tạo khuyến mãi
+// @Description Tạo khuyến mãi theo món, mua X tặng Y, combo hoặc theo đơn; hỗ trợ thời hạn và khung giờ happy hour (cần promotion:manage)
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TaoKhuyenMaiRequest true "Cấu hình khuyến mãi"
+// @Success 201 {object} dto.APIResponse{data=dto.KhuyenMaiResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/promotions [post]
+func (h *KhuyenMaiHandler) TaoKhuyenMai(c *gin.Context) {
+	var req dto.TaoKhuyenMaiRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	khungGio, err := req.ToKhungGioBanList()
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Khung giờ không hợp lệ", err))
+		return
+	}
+
+	input := usecase.TaoKhuyenMaiInput{
+		Ten:         req.Ten,
+		MoTa:        req.MoTa,
+		Loai:        entity.LoaiKhuyenMai(req.Loai),
+		GiaTri:      req.GiaTri,
+		GiamToiDa:   req.GiamToiDa,
+		MonAnIDs:    req.MonAnIDs,
+		SoLuongMua:  req.SoLuongMua,
+		SoLuongTang: req.SoLuongTang,
+		GiaCombo:    req.GiaCombo,
+		DonToiThieu: req.DonToiThieu,
+		TuNgay:      req.TuNgay,
+		DenNgay:     req.DenNgay,
+		KhungGio:    khungGio,
+		CongDon:     req.CongDon,
+		UuTien:      req.UuTien,
+	}
+
+	km, err := h.useCase.TaoKhuyenMai(c.Request.Context(), input)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrMonAnNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể tạo khuyến mãi", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Tạo khuyến mãi thành công", dto.ToKhuyenMaiResponse(km)))
+}
+
+// TimKhuyenMai xử lý GET /api/promotions/:id - Lấy chi tiết khuyến mãi
+// @Summary Lấy chi tiết khuyến mãi
+// @Description Lấy khuyến mãi theo ID (cần promotion:manage)
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Khuyến mãi ID"
+// @Success 200 {object} dto.APIResponse{data=dto.KhuyenMaiResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/promotions/{id} [get]
+func (h *KhuyenMaiHandler) TimKhuyenMai(c *gin.Context) {
+	km, err := h.useCase.TimKhuyenMai(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrKhuyenMaiNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể lấy khuyến mãi", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy khuyến mãi thành công", dto.ToKhuyenMaiResponse(km)))
+}
+
+// NgungKhuyenMai xử lý PUT /api/promotions/:id/deactivate - Ngừng khuyến mãi
+// @Summary Ngừng khuyến mãi
+// @Description Tắt khuyến mãi; các đơn đã áp dụng vẫn giữ nguyên (cần promotion:manage)
+// @Tags Promotions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Khuyến mãi ID"
+// @Success 200 {object} dto.APIResponse{data=dto.KhuyenMaiResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/promotions/{id}/deactivate [put]
+func (h *KhuyenMaiHandler) NgungKhuyenMai(c *gin.Context) {
+	km, err := h.useCase.NgungKhuyenMai(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrKhuyenMaiNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể ngừng khuyến mãi", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Ngừng khuyến mãi thành công", dto.ToKhuyenMaiResponse(km)))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Promotions module
+func (h *KhuyenMaiHandler) BasePath() string {
+	return "/promotions"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Promotions module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *KhuyenMaiHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionPromotionManage))
+
+	rg.GET("", h.XemKhuyenMai)
+	rg.POST("", h.TaoKhuyenMai)
+	rg.GET("/:id", h.TimKhuyenMai)
+	rg.PUT("/:id/deactivate", h.NgungKhuyenMai)
+}
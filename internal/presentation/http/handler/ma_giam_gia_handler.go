@@ -0,0 +1,182 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// MaGiamGiaHandler xử lý các HTTP request quản trị mã giảm giá
+type MaGiamGiaHandler struct {
+	useCase *usecase.MaGiamGiaUseCase
+}
+
+// NewMaGiamGiaHandler tạo mới MaGiamGiaHandler
+func NewMaGiamGiaHandler(uc *usecase.MaGiamGiaUseCase) *MaGiamGiaHandler {
+	return &MaGiamGiaHandler{
+		useCase: uc,
+	}
+}
+
+// PhatHanhMa xử lý POST /api/vouchers/generate - Phát hành mã hàng loạt
+// @Summary Phát hành mã giảm giá hàng loạt
+// @Description Sinh tối đa 1000 mã ngẫu nhiên (hoặc 1 mã cụ thể) cho một chiến dịch (cần promotion:manage)
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.PhatHanhMaRequest true "Cấu hình mã"
+// @Success 201 {object} dto.APIResponse{data=[]dto.MaGiamGiaResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/vouchers/generate [post]
+func (h *MaGiamGiaHandler) PhatHanhMa(c *gin.Context) {
+	var req dto.PhatHanhMaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	input := usecase.PhatHanhMaInput{
+		ChienDich:     req.ChienDich,
+		Ma:            req.Ma,
+		TienTo:        req.TienTo,
+		SoLuong:       req.SoLuong,
+		Loai:          entity.LoaiMaGiamGia(req.Loai),
+		GiaTri:        req.GiaTri,
+		GiamToiDa:     req.GiamToiDa,
+		DonToiThieu:   req.DonToiThieu,
+		SoLanToiDa:    req.SoLanToiDa,
+		SoLanMoiKhach: req.SoLanMoiKhach,
+		HetHan:        req.HetHan,
+	}
+
+	list, err := h.useCase.PhatHanhMa(c.Request.Context(), input)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrMaGiamGiaDaTonTai) {
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể phát hành mã giảm giá", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Phát hành mã giảm giá thành công", dto.ToMaGiamGiaResponseList(list)))
+}
+
+// XemMaTheoChienDich xử lý GET /api/vouchers?chien_dich= - Lấy mã của một chiến dịch
+// @Summary Lấy danh sách mã theo chiến dịch
+// @Description Lấy các mã của một chiến dịch kèm số lượt đã dùng (cần promotion:manage)
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param chien_dich query string true "Chiến dịch"
+// @Success 200 {object} dto.APIResponse{data=[]dto.MaGiamGiaResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/vouchers [get]
+func (h *MaGiamGiaHandler) XemMaTheoChienDich(c *gin.Context) {
+	chienDich := c.Query("chien_dich")
+	if chienDich == "" {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Thiếu tham số chien_dich", nil))
+		return
+	}
+
+	list, err := h.useCase.XemMaTheoChienDich(c.Request.Context(), chienDich)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách mã", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách mã thành công", dto.ToMaGiamGiaResponseList(list)))
+}
+
+// ThongKe xử lý GET /api/vouchers/stats?chien_dich= - Thống kê sử dụng mã
+// @Summary Thống kê sử dụng mã giảm giá
+// @Description Số mã, số lượt dùng, số lượt hoàn và tổng tiền đã giảm của một chiến dịch (cần promotion:manage)
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param chien_dich query string true "Chiến dịch"
+// @Success 200 {object} dto.APIResponse{data=dto.ThongKeMaGiamGiaResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/vouchers/stats [get]
+func (h *MaGiamGiaHandler) ThongKe(c *gin.Context) {
+	chienDich := c.Query("chien_dich")
+	if chienDich == "" {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Thiếu tham số chien_dich", nil))
+		return
+	}
+
+	tk, err := h.useCase.ThongKe(c.Request.Context(), chienDich)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể thống kê mã giảm giá", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Thống kê mã giảm giá thành công", dto.ToThongKeMaGiamGiaResponse(tk)))
+}
+
+// TimMa xử lý GET /api/vouchers/:ma - Lấy chi tiết mã
+// @Summary Lấy chi tiết mã giảm giá
+// @Description Lấy mã giảm giá theo mã (cần promotion:manage)
+// @Tags Vouchers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ma path string true "Mã giảm giá"
+// @Success 200 {object} dto.APIResponse{data=dto.MaGiamGiaResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/vouchers/{ma} [get]
+func (h *MaGiamGiaHandler) TimMa(c *gin.Context) {
+	m, err := h.useCase.TimMa(c.Request.Context(), c.Param("ma"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrMaGiamGiaNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể lấy mã giảm giá", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy mã giảm giá thành công", dto.ToMaGiamGiaResponse(m)))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Vouchers module
+func (h *MaGiamGiaHandler) BasePath() string {
+	return "/vouchers"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Vouchers module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *MaGiamGiaHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionPromotionManage))
+
+	rg.GET("", h.XemMaTheoChienDich)
+	rg.POST("/generate", h.PhatHanhMa)
+	rg.GET("/stats", h.ThongKe)
+	rg.GET("/:ma", h.TimMa)
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"restaurant_project/pkg/logger"
+)
+
+func TestMain(m *testing.M) {
+	// Handler và use case log qua global logger, test không cần output
+	logger.Log = zap.NewNop()
+	gin.SetMode(gin.TestMode)
+	os.Exit(m.Run())
+}
@@ -3,11 +3,14 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
 	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
 	"restaurant_project/internal/presentation/http/dto"
 )
 
@@ -46,8 +49,10 @@ func generateID() string {
 // @Accept json
 // @Produce json
 // @Param request body dto.ThemMonRequest true "Thông tin món ăn mới"
+// @Param Idempotency-Key header string false "Khóa chống gửi trùng: gửi lại cùng khóa nhận lại response đầu tiên"
 // @Success 201 {object} dto.APIResponse{data=dto.MonAnResponse} "Thêm món thành công"
 // @Failure 400 {object} dto.APIResponse "Dữ liệu không hợp lệ"
+// @Failure 409 {object} dto.APIResponse "Idempotency-Key đã dùng cho request khác hoặc đang xử lý"
 // @Router /api/mon-an [post]
 func (h *MonAnHandler) ThemMon(c *gin.Context) {
 	// Bước 1: Parse JSON từ request body
@@ -84,7 +89,7 @@ func (h *MonAnHandler) ThemMon(c *gin.Context) {
 // @Tags MonAn
 // @Accept json
 // @Produce json
-// @Param con_hang query bool false "Chỉ lấy món còn hàng" default(false)
+// @Param con_hang query bool false "Chỉ lấy món đang phục vụ (còn hàng và trong lịch phục vụ hiện tại)" default(false)
 // @Success 200 {object} dto.APIResponse{data=[]dto.MonAnResponse} "Lấy menu thành công"
 // @Failure 500 {object} dto.APIResponse "Lỗi server"
 // @Router /api/mon-an [get]
@@ -128,6 +133,7 @@ func (h *MonAnHandler) XemMenu(c *gin.Context) {
 // @Produce json
 // @Param id path string true "ID món ăn"
 // @Success 200 {object} dto.APIResponse{data=dto.MonAnResponse} "Tìm món thành công"
+// @Header 200 {string} ETag "Phiên bản món ăn, gửi lại qua If-Match khi sửa"
 // @Failure 400 {object} dto.APIResponse "ID không hợp lệ"
 // @Failure 404 {object} dto.APIResponse "Không tìm thấy món"
 // @Router /api/mon-an/{id} [get]
@@ -148,6 +154,7 @@ func (h *MonAnHandler) TimMon(c *gin.Context) {
 		return
 	}
 
+	datETag(c, mon.Version)
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Tìm món thành công", dto.ToMonAnResponse(mon)))
 }
@@ -161,7 +168,10 @@ func (h *MonAnHandler) TimMon(c *gin.Context) {
 // @Param id path string true "ID món ăn"
 // @Param request body dto.CapNhatGiaRequest true "Giá mới"
 // @Success 200 {object} dto.APIResponse{data=dto.MonAnResponse} "Cập nhật giá thành công"
+// @Header 200 {string} ETag "Phiên bản món ăn"
 // @Failure 400 {object} dto.APIResponse "Dữ liệu không hợp lệ"
+// @Param If-Match header string false "ETag đã đọc, sửa khi món chưa bị thay đổi"
+// @Failure 412 {object} dto.APIResponse "Món đã bị thay đổi bởi người khác"
 // @Router /api/mon-an/{id}/gia [put]
 func (h *MonAnHandler) CapNhatGia(c *gin.Context) {
 	// Lấy ID từ URL param
@@ -173,6 +183,11 @@ func (h *MonAnHandler) CapNhatGia(c *gin.Context) {
 		return
 	}
 
+	phienBan, ok := docIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// Parse request
 	var req dto.CapNhatGiaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -183,17 +198,23 @@ func (h *MonAnHandler) CapNhatGia(c *gin.Context) {
 
 	// Gọi UseCase
 	input := usecase.CapNhatGiaInput{
-		ID:     id,
-		GiaMoi: req.Gia,
+		ID:       id,
+		GiaMoi:   req.Gia,
+		PhienBan: phienBan,
 	}
 
 	mon, err := h.useCase.CapNhatGia(c.Request.Context(), input)
 	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
 		c.JSON(http.StatusBadRequest,
 			dto.NewErrorResponse("Không thể cập nhật giá", err))
 		return
 	}
 
+	datETag(c, mon.Version)
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Cập nhật giá thành công", dto.ToMonAnResponse(mon)))
 }
@@ -207,7 +228,10 @@ func (h *MonAnHandler) CapNhatGia(c *gin.Context) {
 // @Param id path string true "ID món ăn"
 // @Param request body dto.ApDungGiamGiaRequest true "Phần trăm giảm giá"
 // @Success 200 {object} dto.APIResponse{data=dto.MonAnResponse} "Áp dụng giảm giá thành công"
+// @Header 200 {string} ETag "Phiên bản món ăn"
 // @Failure 400 {object} dto.APIResponse "Dữ liệu không hợp lệ"
+// @Param If-Match header string false "ETag đã đọc, sửa khi món chưa bị thay đổi"
+// @Failure 412 {object} dto.APIResponse "Món đã bị thay đổi bởi người khác"
 // @Router /api/mon-an/{id}/giam-gia [put]
 func (h *MonAnHandler) ApDungGiamGia(c *gin.Context) {
 	// Lấy ID từ URL param
@@ -219,6 +243,11 @@ func (h *MonAnHandler) ApDungGiamGia(c *gin.Context) {
 		return
 	}
 
+	phienBan, ok := docIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// Parse request
 	var req dto.ApDungGiamGiaRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -231,15 +260,21 @@ func (h *MonAnHandler) ApDungGiamGia(c *gin.Context) {
 	input := usecase.ApDungGiamGiaInput{
 		ID:       id,
 		PhanTram: req.PhanTram,
+		PhienBan: phienBan,
 	}
 
 	mon, err := h.useCase.ApDungGiamGia(c.Request.Context(), input)
 	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
 		c.JSON(http.StatusBadRequest,
 			dto.NewErrorResponse("Không thể áp dụng giảm giá", err))
 		return
 	}
 
+	datETag(c, mon.Version)
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Áp dụng giảm giá thành công", dto.ToMonAnResponse(mon)))
 }
@@ -253,6 +288,8 @@ func (h *MonAnHandler) ApDungGiamGia(c *gin.Context) {
 // @Param id path string true "ID món ăn"
 // @Success 200 {object} dto.APIResponse "Xóa món thành công"
 // @Failure 400 {object} dto.APIResponse "Không thể xóa món"
+// @Param If-Match header string false "ETag đã đọc, sửa khi món chưa bị thay đổi"
+// @Failure 412 {object} dto.APIResponse "Món đã bị thay đổi bởi người khác"
 // @Router /api/mon-an/{id} [delete]
 func (h *MonAnHandler) XoaMon(c *gin.Context) {
 	id := c.Param("id")
@@ -263,8 +300,17 @@ func (h *MonAnHandler) XoaMon(c *gin.Context) {
 		return
 	}
 
-	err := h.useCase.XoaMon(c.Request.Context(), id)
+	phienBan, ok := docIfMatch(c)
+	if !ok {
+		return
+	}
+
+	err := h.useCase.XoaMon(c.Request.Context(), id, phienBan)
 	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
 		c.JSON(http.StatusBadRequest,
 			dto.NewErrorResponse("Không thể xóa món", err))
 		return
@@ -282,7 +328,10 @@ func (h *MonAnHandler) XoaMon(c *gin.Context) {
 // @Produce json
 // @Param id path string true "ID món ăn"
 // @Success 200 {object} dto.APIResponse{data=dto.MonAnResponse} "Đánh dấu hết hàng thành công"
+// @Header 200 {string} ETag "Phiên bản món ăn"
 // @Failure 400 {object} dto.APIResponse "Không thể đánh dấu hết hàng"
+// @Param If-Match header string false "ETag đã đọc, sửa khi món chưa bị thay đổi"
+// @Failure 412 {object} dto.APIResponse "Món đã bị thay đổi bởi người khác"
 // @Router /api/mon-an/{id}/het-hang [put]
 func (h *MonAnHandler) DanhDauHetHang(c *gin.Context) {
 	id := c.Param("id")
@@ -293,17 +342,301 @@ func (h *MonAnHandler) DanhDauHetHang(c *gin.Context) {
 		return
 	}
 
-	mon, err := h.useCase.DanhDauHetHang(c.Request.Context(), id)
+	phienBan, ok := docIfMatch(c)
+	if !ok {
+		return
+	}
+
+	mon, err := h.useCase.DanhDauHetHang(c.Request.Context(), id, phienBan)
 	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
 		c.JSON(http.StatusBadRequest,
 			dto.NewErrorResponse("Không thể đánh dấu hết hàng", err))
 		return
 	}
 
+	datETag(c, mon.Version)
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Đánh dấu hết hàng thành công", dto.ToMonAnResponse(mon)))
 }
 
+// DatLichBan xử lý PUT /api/mon-an/:id/lich-ban - Đặt lịch phục vụ
+// @Summary Đặt lịch phục vụ món ăn
+// @Description Đặt các khung giờ/ngày phục vụ (VD: bữa sáng 06:00-10:00, chỉ cuối tuần). Giờ tính theo múi giờ nhà hàng. Gửi danh sách rỗng để phục vụ mọi lúc
+// @Tags MonAn
+// @Accept json
+// @Produce json
+// @Param id path string true "ID món ăn"
+// @Param request body dto.DatLichBanRequest true "Lịch phục vụ"
+// @Success 200 {object} dto.APIResponse{data=dto.MonAnResponse} "Đặt lịch phục vụ thành công"
+// @Header 200 {string} ETag "Phiên bản món ăn"
+// @Failure 400 {object} dto.APIResponse "Dữ liệu không hợp lệ"
+// @Param If-Match header string false "ETag đã đọc, sửa khi món chưa bị thay đổi"
+// @Failure 412 {object} dto.APIResponse "Món đã bị thay đổi bởi người khác"
+// @Router /api/mon-an/{id}/lich-ban [put]
+func (h *MonAnHandler) DatLichBan(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("ID không được để trống", nil))
+		return
+	}
+
+	phienBan, ok := docIfMatch(c)
+	if !ok {
+		return
+	}
+
+	// Parse request
+	var req dto.DatLichBanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	lichBan, err := req.ToKhungGioBanList()
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Lịch phục vụ không hợp lệ", err))
+		return
+	}
+
+	// Gọi UseCase
+	input := usecase.DatLichBanInput{
+		ID:       id,
+		LichBan:  lichBan,
+		PhienBan: phienBan,
+	}
+
+	mon, err := h.useCase.DatLichBan(c.Request.Context(), input)
+	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Không thể đặt lịch phục vụ", err))
+		return
+	}
+
+	datETag(c, mon.Version)
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đặt lịch phục vụ thành công", dto.ToMonAnResponse(mon)))
+}
+
+// DatTuyChon xử lý PUT /api/mon-an/:id/tuy-chon - Đặt các nhóm tùy chọn
+// @Summary Đặt tùy chọn món ăn
+// @Description Thay toàn bộ nhóm tùy chọn của món (size, topping, độ cay,...), mỗi lựa chọn có giá cộng thêm. Gửi danh sách rỗng để bỏ tùy chọn
+// @Tags MonAn
+// @Accept json
+// @Produce json
+// @Param id path string true "ID món ăn"
+// @Param request body dto.DatTuyChonRequest true "Các nhóm tùy chọn"
+// @Success 200 {object} dto.APIResponse{data=dto.MonAnResponse} "Đặt tùy chọn thành công"
+// @Header 200 {string} ETag "Phiên bản món ăn"
+// @Failure 400 {object} dto.APIResponse "Dữ liệu không hợp lệ"
+// @Param If-Match header string false "ETag đã đọc, sửa khi món chưa bị thay đổi"
+// @Failure 412 {object} dto.APIResponse "Món đã bị thay đổi bởi người khác"
+// @Router /api/mon-an/{id}/tuy-chon [put]
+func (h *MonAnHandler) DatTuyChon(c *gin.Context) {
+	id := c.Param("id")
+
+	if id == "" {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("ID không được để trống", nil))
+		return
+	}
+
+	phienBan, ok := docIfMatch(c)
+	if !ok {
+		return
+	}
+
+	// Parse request
+	var req dto.DatTuyChonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	nhomTuyChon, err := req.ToNhomTuyChonList()
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tùy chọn không hợp lệ", err))
+		return
+	}
+
+	// Gọi UseCase
+	input := usecase.DatTuyChonInput{
+		ID:          id,
+		NhomTuyChon: nhomTuyChon,
+		PhienBan:    phienBan,
+	}
+
+	mon, err := h.useCase.DatTuyChon(c.Request.Context(), input)
+	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Không thể đặt tùy chọn", err))
+		return
+	}
+
+	datETag(c, mon.Version)
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đặt tùy chọn thành công", dto.ToMonAnResponse(mon)))
+}
+
+// XemLichSuGia xử lý GET /api/mon-an/:id/lich-su-gia - Xem lịch sử giá
+// @Summary Xem lịch sử giá món ăn
+// @Description Lấy các lần đổi giá của món theo thứ tự thời gian, lọc theo thời điểm giá có hiệu lực
+// @Tags MonAn
+// @Accept json
+// @Produce json
+// @Param id path string true "ID món ăn"
+// @Param tu query string false "Hiệu lực từ (RFC3339)"
+// @Param den query string false "Hiệu lực đến (RFC3339)"
+// @Success 200 {object} dto.APIResponse{data=[]dto.LichSuGiaResponse} "Lấy lịch sử giá thành công"
+// @Failure 400 {object} dto.APIResponse "Tham số không hợp lệ"
+// @Failure 500 {object} dto.APIResponse "Lỗi server"
+// @Router /api/mon-an/{id}/lich-su-gia [get]
+func (h *MonAnHandler) XemLichSuGia(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.LichSuGiaQueryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số không hợp lệ", err))
+		return
+	}
+
+	tu, err := parseThoiDiem(req.Tu)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số tu phải theo định dạng RFC3339", err))
+		return
+	}
+	den, err := parseThoiDiem(req.Den)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số den phải theo định dạng RFC3339", err))
+		return
+	}
+
+	list, err := h.useCase.XemLichSuGia(c.Request.Context(), id, tu, den)
+	if err != nil {
+		if errors.Is(err, usecase.ErrKhoangThoiGianKhongHopLe) {
+			c.JSON(http.StatusBadRequest,
+				dto.NewErrorResponse("Khoảng thời gian không hợp lệ", err))
+			return
+		}
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy lịch sử giá", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy lịch sử giá thành công", dto.ToLichSuGiaResponseList(list)))
+}
+
+// DatLichDoiGia xử lý POST /api/mon-an/:id/lich-doi-gia - Đặt lịch đổi giá
+// @Summary Đặt lịch đổi giá món ăn
+// @Description Đặt trước giá mới cho món, tự động áp dụng khi tới thời điểm ap_dung_luc
+// @Tags MonAn
+// @Accept json
+// @Produce json
+// @Param id path string true "ID món ăn"
+// @Param request body dto.DatLichDoiGiaRequest true "Giá mới và thời điểm áp dụng"
+// @Success 201 {object} dto.APIResponse{data=dto.LichDoiGiaResponse} "Đặt lịch đổi giá thành công"
+// @Failure 400 {object} dto.APIResponse "Dữ liệu không hợp lệ"
+// @Router /api/mon-an/{id}/lich-doi-gia [post]
+func (h *MonAnHandler) DatLichDoiGia(c *gin.Context) {
+	id := c.Param("id")
+
+	var req dto.DatLichDoiGiaRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	lich, err := h.useCase.DatLichDoiGia(c.Request.Context(), usecase.DatLichDoiGiaInput{
+		MonAnID:   id,
+		GiaMoi:    req.Gia,
+		ApDungLuc: req.ApDungLuc,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Không thể đặt lịch đổi giá", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Đặt lịch đổi giá thành công", dto.ToLichDoiGiaResponse(lich)))
+}
+
+// XemLichDoiGia xử lý GET /api/mon-an/:id/lich-doi-gia - Xem lịch đổi giá
+// @Summary Xem lịch đổi giá món ăn
+// @Description Lấy các lịch đổi giá của món (chờ áp dụng, đã áp dụng, đã hủy)
+// @Tags MonAn
+// @Accept json
+// @Produce json
+// @Param id path string true "ID món ăn"
+// @Success 200 {object} dto.APIResponse{data=[]dto.LichDoiGiaResponse} "Lấy lịch đổi giá thành công"
+// @Failure 500 {object} dto.APIResponse "Lỗi server"
+// @Router /api/mon-an/{id}/lich-doi-gia [get]
+func (h *MonAnHandler) XemLichDoiGia(c *gin.Context) {
+	list, err := h.useCase.XemLichDoiGia(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy lịch đổi giá", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy lịch đổi giá thành công", dto.ToLichDoiGiaResponseList(list)))
+}
+
+// HuyLichDoiGia xử lý PUT /api/mon-an/:id/lich-doi-gia/:lichId/huy - Hủy lịch đổi giá
+// @Summary Hủy lịch đổi giá món ăn
+// @Description Hủy lịch đổi giá đang chờ áp dụng
+// @Tags MonAn
+// @Accept json
+// @Produce json
+// @Param id path string true "ID món ăn"
+// @Param lichId path string true "ID lịch đổi giá"
+// @Success 200 {object} dto.APIResponse{data=dto.LichDoiGiaResponse} "Hủy lịch đổi giá thành công"
+// @Failure 400 {object} dto.APIResponse "Lịch không còn chờ áp dụng"
+// @Failure 404 {object} dto.APIResponse "Không tìm thấy lịch đổi giá"
+// @Failure 409 {object} dto.APIResponse "Lịch vừa được áp dụng hoặc hủy bởi request khác"
+// @Router /api/mon-an/{id}/lich-doi-gia/{lichId}/huy [put]
+func (h *MonAnHandler) HuyLichDoiGia(c *gin.Context) {
+	lich, err := h.useCase.HuyLichDoiGia(c.Request.Context(), c.Param("id"), c.Param("lichId"))
+	if err != nil {
+		status := http.StatusBadRequest
+		switch {
+		case errors.Is(err, usecase.ErrLichDoiGiaNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, usecase.ErrLichDoiGiaDaXuLy):
+			status = http.StatusConflict
+		}
+		c.JSON(status,
+			dto.NewErrorResponse("Không thể hủy lịch đổi giá", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Hủy lịch đổi giá thành công", dto.ToLichDoiGiaResponse(lich)))
+}
+
 // ============================================================
 // RouteRegistrar Interface Implementation
 // ============================================================
@@ -321,9 +654,20 @@ func (h *MonAnHandler) RegisterRoutes(rg *gin.RouterGroup) {
 
 // RegisterProtectedRoutes đăng ký PROTECTED routes (cần JWT)
 func (h *MonAnHandler) RegisterProtectedRoutes(rg *gin.RouterGroup) {
-	rg.POST("", h.ThemMon)
-	rg.DELETE("/:id", h.XoaMon)
-	rg.PUT("/:id/gia", h.CapNhatGia)
-	rg.PUT("/:id/giam-gia", h.ApDungGiamGia)
-	rg.PUT("/:id/het-hang", h.DanhDauHetHang)
+	menuWrite := middleware.RequirePermission(entity.PermissionMenuWrite)
+	rg.POST("", menuWrite, h.ThemMon)
+	rg.DELETE("/:id", menuWrite, h.XoaMon)
+	rg.PUT("/:id/gia", menuWrite, h.CapNhatGia)
+	rg.PUT("/:id/giam-gia", menuWrite, h.ApDungGiamGia)
+	rg.PUT("/:id/lich-ban", menuWrite, h.DatLichBan)
+	rg.PUT("/:id/tuy-chon", menuWrite, h.DatTuyChon)
+	rg.GET("/:id/lich-doi-gia", menuWrite, h.XemLichDoiGia)
+	rg.POST("/:id/lich-doi-gia", menuWrite, h.DatLichDoiGia)
+	rg.PUT("/:id/lich-doi-gia/:lichId/huy", menuWrite, h.HuyLichDoiGia)
+
+	// Lịch sử giá phục vụ đối soát báo cáo
+	rg.GET("/:id/lich-su-gia", middleware.RequirePermission(entity.PermissionReportRead), h.XemLichSuGia)
+
+	// Bếp cũng được báo hết món
+	rg.PUT("/:id/het-hang", middleware.RequirePermission(entity.PermissionMenuAvailabilityWrite), h.DanhDauHetHang)
 }
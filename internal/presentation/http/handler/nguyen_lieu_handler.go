@@ -0,0 +1,145 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// NguyenLieuHandler xử lý các HTTP request liên quan đến kho nguyên liệu
+type NguyenLieuHandler struct {
+	useCase *usecase.KhoUseCase
+}
+
+// NewNguyenLieuHandler tạo mới NguyenLieuHandler
+func NewNguyenLieuHandler(uc *usecase.KhoUseCase) *NguyenLieuHandler {
+	return &NguyenLieuHandler{
+		useCase: uc,
+	}
+}
+
+// XemKho xử lý GET /api/ingredients - Lấy danh sách nguyên liệu
+// @Summary Lấy danh sách nguyên liệu
+// @Description Lấy danh sách nguyên liệu kèm tồn kho và giá vốn bình quân (cần inventory:manage)
+// @Tags Ingredients
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.NguyenLieuResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/ingredients [get]
+func (h *NguyenLieuHandler) XemKho(c *gin.Context) {
+	list, err := h.useCase.XemKho(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách nguyên liệu", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách nguyên liệu thành công", dto.ToNguyenLieuResponseList(list)))
+}
+
+// ThemNguyenLieu xử lý POST /api/ingredients - Thêm nguyên liệu mới
+// @Summary Thêm nguyên liệu mới
+// @Description Thêm nguyên liệu mới vào kho với tồn kho ban đầu bằng 0 (cần inventory:manage)
+// @Tags Ingredients
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ThemNguyenLieuRequest true "Thông tin nguyên liệu"
+// @Success 201 {object} dto.APIResponse{data=dto.NguyenLieuResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/ingredients [post]
+func (h *NguyenLieuHandler) ThemNguyenLieu(c *gin.Context) {
+	var req dto.ThemNguyenLieuRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	input := usecase.ThemNguyenLieuInput{
+		Ten:         req.Ten,
+		DonViTinh:   req.DonViTinh,
+		MucToiThieu: req.MucToiThieu,
+	}
+
+	nl, err := h.useCase.ThemNguyenLieu(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Không thể thêm nguyên liệu", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Thêm nguyên liệu thành công", dto.ToNguyenLieuResponse(nl)))
+}
+
+// GhiTieuHao xử lý POST /api/ingredients/:id/consume - Ghi nhận tiêu hao
+// @Summary Ghi nhận tiêu hao nguyên liệu
+// @Description Trừ tồn kho và ghi lịch sử tiêu hao (dùng cho gợi ý đặt hàng) (cần inventory:manage)
+// @Tags Ingredients
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Nguyên liệu ID"
+// @Param request body dto.GhiTieuHaoRequest true "Số lượng tiêu hao"
+// @Success 200 {object} dto.APIResponse{data=dto.NguyenLieuResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/ingredients/{id}/consume [post]
+func (h *NguyenLieuHandler) GhiTieuHao(c *gin.Context) {
+	var req dto.GhiTieuHaoRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	input := usecase.GhiTieuHaoInput{
+		NguyenLieuID: c.Param("id"),
+		SoLuong:      req.SoLuong,
+		ThamChieu:    req.ThamChieu,
+	}
+
+	nl, err := h.useCase.GhiTieuHao(c.Request.Context(), input)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrNguyenLieuNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể ghi tiêu hao", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Ghi tiêu hao thành công", dto.ToNguyenLieuResponse(nl)))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Ingredients module
+func (h *NguyenLieuHandler) BasePath() string {
+	return "/ingredients"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Ingredients module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *NguyenLieuHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionInventoryManage))
+
+	rg.GET("", h.XemKho)
+	rg.POST("", h.ThemNguyenLieu)
+	rg.POST("/:id/consume", h.GhiTieuHao)
+}
@@ -0,0 +1,181 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// NhaCungCapHandler xử lý các HTTP request liên quan đến nhà cung cấp
+type NhaCungCapHandler struct {
+	useCase *usecase.MuaHangUseCase
+}
+
+// NewNhaCungCapHandler tạo mới NhaCungCapHandler
+func NewNhaCungCapHandler(uc *usecase.MuaHangUseCase) *NhaCungCapHandler {
+	return &NhaCungCapHandler{
+		useCase: uc,
+	}
+}
+
+// XemNhaCungCap xử lý GET /api/suppliers - Lấy danh sách nhà cung cấp
+// @Summary Lấy danh sách nhà cung cấp
+// @Description Lấy danh sách tất cả nhà cung cấp (cần purchasing:manage)
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.NhaCungCapResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/suppliers [get]
+func (h *NhaCungCapHandler) XemNhaCungCap(c *gin.Context) {
+	list, err := h.useCase.XemNhaCungCap(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách nhà cung cấp", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách nhà cung cấp thành công", dto.ToNhaCungCapResponseList(list)))
+}
+
+// TaoNhaCungCap xử lý POST /api/suppliers - Tạo nhà cung cấp
+// @Summary Tạo nhà cung cấp
+// @Description Tạo nhà cung cấp mới (cần purchasing:manage)
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TaoNhaCungCapRequest true "Thông tin nhà cung cấp"
+// @Success 201 {object} dto.APIResponse{data=dto.NhaCungCapResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/suppliers [post]
+func (h *NhaCungCapHandler) TaoNhaCungCap(c *gin.Context) {
+	var req dto.TaoNhaCungCapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	input := usecase.TaoNhaCungCapInput{
+		Ten:         req.Ten,
+		NguoiLienHe: req.NguoiLienHe,
+		SoDienThoai: req.SoDienThoai,
+		Email:       req.Email,
+		DiaChi:      req.DiaChi,
+		SoNgayGiao:  req.SoNgayGiao,
+	}
+
+	ncc, err := h.useCase.TaoNhaCungCap(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Không thể tạo nhà cung cấp", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Tạo nhà cung cấp thành công", dto.ToNhaCungCapResponse(ncc)))
+}
+
+// TimNhaCungCap xử lý GET /api/suppliers/:id - Lấy nhà cung cấp theo ID
+// @Summary Lấy nhà cung cấp theo ID
+// @Description Lấy thông tin nhà cung cấp theo ID (cần purchasing:manage)
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Nhà cung cấp ID"
+// @Success 200 {object} dto.APIResponse{data=dto.NhaCungCapResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/suppliers/{id} [get]
+func (h *NhaCungCapHandler) TimNhaCungCap(c *gin.Context) {
+	ncc, err := h.useCase.TimNhaCungCap(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrNhaCungCapNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể lấy nhà cung cấp", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy nhà cung cấp thành công", dto.ToNhaCungCapResponse(ncc)))
+}
+
+// CapNhatNhaCungCap xử lý PUT /api/suppliers/:id - Cập nhật nhà cung cấp
+// @Summary Cập nhật nhà cung cấp
+// @Description Cập nhật thông tin hoặc ngừng/tiếp tục hợp tác với nhà cung cấp (cần purchasing:manage)
+// @Tags Suppliers
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Nhà cung cấp ID"
+// @Param request body dto.CapNhatNhaCungCapRequest true "Thông tin cập nhật"
+// @Success 200 {object} dto.APIResponse{data=dto.NhaCungCapResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/suppliers/{id} [put]
+func (h *NhaCungCapHandler) CapNhatNhaCungCap(c *gin.Context) {
+	var req dto.CapNhatNhaCungCapRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	input := usecase.CapNhatNhaCungCapInput{
+		ID:          c.Param("id"),
+		Ten:         req.Ten,
+		NguoiLienHe: req.NguoiLienHe,
+		SoDienThoai: req.SoDienThoai,
+		Email:       req.Email,
+		DiaChi:      req.DiaChi,
+		SoNgayGiao:  req.SoNgayGiao,
+		DangHopTac:  req.DangHopTac,
+	}
+
+	ncc, err := h.useCase.CapNhatNhaCungCap(c.Request.Context(), input)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrNhaCungCapNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể cập nhật nhà cung cấp", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Cập nhật nhà cung cấp thành công", dto.ToNhaCungCapResponse(ncc)))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Suppliers module
+func (h *NhaCungCapHandler) BasePath() string {
+	return "/suppliers"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Suppliers module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *NhaCungCapHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionPurchasingManage))
+
+	rg.GET("", h.XemNhaCungCap)
+	rg.POST("", h.TaoNhaCungCap)
+	rg.GET("/:id", h.TimNhaCungCap)
+	rg.PUT("/:id", h.CapNhatNhaCungCap)
+}
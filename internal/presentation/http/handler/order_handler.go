@@ -0,0 +1,334 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// OrderHandler xử lý các HTTP request liên quan đến đơn hàng
+type OrderHandler struct {
+	useCase *usecase.OrderUseCase
+}
+
+// NewOrderHandler tạo mới OrderHandler
+func NewOrderHandler(uc *usecase.OrderUseCase) *OrderHandler {
+	return &OrderHandler{
+		useCase: uc,
+	}
+}
+
+// TaoOrder xử lý POST /api/orders - Tạo đơn hàng
+// @Summary Tạo đơn hàng
+// @Description Tạo đơn hàng mới. Chỉ nhận món còn hàng và đang trong khung giờ phục vụ (theo giờ nhà hàng).
+// @Description Khuyến mãi tốt nhất được áp dụng tự động; mã giảm giá (nếu có) tính trên số tiền còn lại.
+// @Description Món có tùy chọn: gửi tuy_chon (mã nhóm → mã lựa chọn), đơn giá đã cộng giá tùy chọn.
+// @Description Khách tự đặt sẽ nhận hóa đơn qua email
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TaoOrderRequest true "Thông tin đơn hàng"
+// @Param Idempotency-Key header string true "Khóa chống gửi trùng (bắt buộc): gửi lại cùng khóa nhận lại response đầu tiên"
+// @Success 201 {object} dto.APIResponse{data=dto.OrderResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/orders [post]
+func (h *OrderHandler) TaoOrder(c *gin.Context) {
+	var req dto.TaoOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	items := make([]usecase.TaoOrderItemInput, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = usecase.TaoOrderItemInput{
+			MonAnID: item.MonAnID,
+			SoLuong: item.SoLuong,
+			TuyChon: item.TuyChon,
+			GhiChu:  item.GhiChu,
+		}
+	}
+
+	input := usecase.TaoOrderInput{
+		LoaiOrder:  entity.LoaiOrder(req.LoaiOrder),
+		SoBan:      req.SoBan,
+		GhiChu:     req.GhiChu,
+		DiaChiGiao: req.DiaChiGiao,
+		MaGiamGia:  req.MaGiamGia,
+		Items:      items,
+	}
+
+	// Khách tự đặt thì gắn khách hàng, nhân viên đặt hộ thì gắn nhân viên phục vụ
+	userID, _ := middleware.GetUserID(c)
+	if role, _ := middleware.GetUserRole(c); role == middleware.RoleCustomer {
+		input.KhachHangUserID = userID
+		if claims, ok := middleware.GetClaims(c); ok {
+			input.KhachHangEmail = claims.Email
+		}
+	} else {
+		input.NhanVienID = userID
+	}
+
+	order, err := h.useCase.TaoOrder(c.Request.Context(), input)
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		switch {
+		case errors.Is(err, usecase.ErrMonAnNotFound), errors.Is(err, usecase.ErrMaGiamGiaNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, usecase.ErrMonKhongPhucVu):
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể tạo đơn hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Tạo đơn hàng thành công", dto.ToOrderResponse(order)))
+}
+
+// HuyOrder xử lý PUT /api/orders/:id/cancel - Hủy đơn hàng
+// @Summary Hủy đơn hàng
+// @Description Hủy đơn hàng và hoàn lượt mã giảm giá đã dùng (cần order:cancel)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.APIResponse{data=dto.OrderResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/orders/{id}/cancel [put]
+func (h *OrderHandler) HuyOrder(c *gin.Context) {
+	order, err := h.useCase.HuyOrder(c.Request.Context(), currentActor(c), c.Param("id"))
+	if err != nil {
+		c.JSON(orderErrorStatus(err, http.StatusBadRequest),
+			dto.NewErrorResponse("Không thể hủy đơn hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Hủy đơn hàng thành công", dto.ToOrderResponse(order)))
+}
+
+// XemOrderDangCho xử lý GET /api/orders/pending - Lấy các đơn đang chờ xử lý
+// @Summary Lấy các đơn đang chờ xử lý
+// @Description Lấy các đơn mới, đã xác nhận hoặc đang nấu (cần order:read)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.OrderResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/orders/pending [get]
+func (h *OrderHandler) XemOrderDangCho(c *gin.Context) {
+	orders, err := h.useCase.XemOrderDangCho(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy đơn đang chờ", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy đơn đang chờ thành công", dto.ToOrderResponseList(orders)))
+}
+
+// XemOrderCuaToi xử lý GET /api/orders/mine - Đơn hàng của tôi
+// @Summary Đơn hàng của tôi
+// @Description Các đơn khách hàng đang đăng nhập đã tự đặt, mới nhất trước
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.OrderResponse}
+// @Failure 401 {object} dto.APIResponse
+// @Router /api/orders/mine [get]
+func (h *OrderHandler) XemOrderCuaToi(c *gin.Context) {
+	orders, err := h.useCase.XemOrderCuaToi(c.Request.Context(), currentActor(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy đơn hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy đơn hàng thành công", dto.ToOrderResponseList(orders)))
+}
+
+// XemOrder xử lý GET /api/orders/:id - Lấy chi tiết đơn hàng
+// @Summary Lấy chi tiết đơn hàng
+// @Description Lấy chi tiết đơn hàng theo ID. Nhân viên cần order:read; khách hàng chỉ xem được đơn của mình
+// @Description (đơn của người khác trả 404 như đơn không tồn tại)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.APIResponse{data=dto.OrderResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/orders/{id} [get]
+func (h *OrderHandler) XemOrder(c *gin.Context) {
+	order, err := h.useCase.XemOrder(c.Request.Context(), currentActor(c), c.Param("id"))
+	if err != nil {
+		c.JSON(orderErrorStatus(err, http.StatusInternalServerError),
+			dto.NewErrorResponse("Không thể lấy đơn hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy đơn hàng thành công", dto.ToOrderResponse(order)))
+}
+
+// XemPhieuBep xử lý GET /api/orders/:id/kitchen-ticket - Lấy phiếu bếp
+// @Summary Lấy phiếu bếp
+// @Description Phiếu bếp của đơn: từng món kèm các tùy chọn và ghi chú, không có giá (cần order:read)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.APIResponse{data=dto.PhieuBepResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/orders/{id}/kitchen-ticket [get]
+func (h *OrderHandler) XemPhieuBep(c *gin.Context) {
+	order, err := h.useCase.XemOrder(c.Request.Context(), currentActor(c), c.Param("id"))
+	if err != nil {
+		c.JSON(orderErrorStatus(err, http.StatusInternalServerError),
+			dto.NewErrorResponse("Không thể lấy phiếu bếp", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy phiếu bếp thành công", dto.ToPhieuBepResponse(order)))
+}
+
+// CapNhatTrangThai xử lý PUT /api/orders/:id/status - Chuyển trạng thái đơn hàng
+// @Summary Chuyển trạng thái đơn hàng
+// @Description Chuyển đơn sang trạng thái kế tiếp (xác nhận, đang nấu, đã nấu, đang giao, hoàn thành).
+// @Description Cần order:status:write; đầu bếp có order:status:write:assigned chỉ đổi được đơn được gán cho mình
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Param request body dto.CapNhatTrangThaiOrderRequest true "Trạng thái mới"
+// @Success 200 {object} dto.APIResponse{data=dto.OrderResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/orders/{id}/status [put]
+func (h *OrderHandler) CapNhatTrangThai(c *gin.Context) {
+	var req dto.CapNhatTrangThaiOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	order, err := h.useCase.CapNhatTrangThai(c.Request.Context(), currentActor(c), c.Param("id"), entity.TrangThaiOrder(req.TrangThai))
+	if err != nil {
+		c.JSON(orderErrorStatus(err, http.StatusBadRequest),
+			dto.NewErrorResponse("Không thể chuyển trạng thái đơn hàng", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Chuyển trạng thái đơn hàng thành công", dto.ToOrderResponse(order)))
+}
+
+// PhanCongDauBep xử lý PUT /api/orders/:id/chef - Gán đầu bếp cho đơn hàng
+// @Summary Gán đầu bếp cho đơn hàng
+// @Description Gán nhân viên bếp thực hiện đơn; đầu bếp được gán đổi được trạng thái đơn (cần order:assign)
+// @Tags Orders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Order ID"
+// @Param request body dto.PhanCongDauBepRequest true "Nhân viên bếp"
+// @Success 200 {object} dto.APIResponse{data=dto.OrderResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/orders/{id}/chef [put]
+func (h *OrderHandler) PhanCongDauBep(c *gin.Context) {
+	var req dto.PhanCongDauBepRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	order, err := h.useCase.PhanCongDauBep(c.Request.Context(), currentActor(c), c.Param("id"), req.DauBepID)
+	if err != nil {
+		c.JSON(orderErrorStatus(err, http.StatusBadRequest),
+			dto.NewErrorResponse("Không thể gán đầu bếp", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Gán đầu bếp thành công", dto.ToOrderResponse(order)))
+}
+
+// orderErrorStatus map lỗi chính sách truy cập đơn hàng sang HTTP status code
+// Đơn không được xem trả 404 giống đơn không tồn tại; các lỗi khác dùng fallback
+func orderErrorStatus(err error, fallback int) int {
+	switch {
+	case errors.Is(err, usecase.ErrOrderNotFound), errors.Is(err, usecase.ErrNhanVienNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrOrderForbidden):
+		return http.StatusForbidden
+	default:
+		return fallback
+	}
+}
+
+// currentActor lấy người thực hiện thao tác từ access token
+func currentActor(c *gin.Context) usecase.Actor {
+	userID, _ := middleware.GetUserID(c)
+	role, _ := middleware.GetUserRole(c)
+	return usecase.Actor{
+		UserID:      userID,
+		Role:        entity.UserRole(role),
+		Permissions: middleware.GetPermissions(c),
+	}
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Orders module
+func (h *OrderHandler) BasePath() string {
+	return "/orders"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Orders module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *OrderHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	// Mọi user đã đăng nhập đều có thể đặt món
+	rg.POST("", h.TaoOrder)
+
+	// Xem đơn / đổi trạng thái - quyền theo từng đơn được đánh giá trong use case
+	// (khách xem đơn của mình, đầu bếp đổi trạng thái đơn được gán)
+	rg.GET("/mine", h.XemOrderCuaToi)
+	rg.GET("/:id", h.XemOrder)
+	rg.PUT("/:id/status", h.CapNhatTrangThai)
+
+	// Nhân viên có quyền tương ứng
+	rg.GET("/pending", middleware.RequirePermission(entity.PermissionOrderRead), h.XemOrderDangCho)
+	rg.GET("/:id/kitchen-ticket", middleware.RequirePermission(entity.PermissionOrderRead), h.XemPhieuBep)
+	rg.PUT("/:id/cancel", middleware.RequirePermission(entity.PermissionOrderCancel), h.HuyOrder)
+	rg.PUT("/:id/chef", middleware.RequirePermission(entity.PermissionOrderAssign), h.PhanCongDauBep)
+}
@@ -0,0 +1,134 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// PermissionHandler xử lý các HTTP request quản lý permission của role
+type PermissionHandler struct {
+	useCase *usecase.PermissionUseCase
+}
+
+// NewPermissionHandler tạo mới PermissionHandler
+func NewPermissionHandler(uc *usecase.PermissionUseCase) *PermissionHandler {
+	return &PermissionHandler{
+		useCase: uc,
+	}
+}
+
+// GetPermissions xử lý GET /api/admin/permissions - Danh mục permission
+// @Summary Danh mục permission
+// @Description Tất cả permission có thể gán cho role (cần permission:manage)
+// @Tags Permissions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.PermissionResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/permissions [get]
+func (h *PermissionHandler) GetPermissions(c *gin.Context) {
+	permissions, err := h.useCase.ListPermissions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh mục permission", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh mục permission thành công", dto.ToPermissionResponseList(permissions)))
+}
+
+// GetRolePermissions xử lý GET /api/admin/permissions/roles - Quyền của từng role
+// @Summary Quyền của từng role
+// @Description Permission hiện tại của mọi role (cần permission:manage)
+// @Tags Permissions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.RolePermissionsResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/permissions/roles [get]
+func (h *PermissionHandler) GetRolePermissions(c *gin.Context) {
+	list, err := h.useCase.ListRolePermissions(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy quyền của role", err))
+		return
+	}
+
+	responses := make([]dto.RolePermissionsResponse, len(list))
+	for i, rp := range list {
+		responses[i] = dto.ToRolePermissionsResponse(rp.Role, rp.Permissions)
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy quyền của role thành công", responses))
+}
+
+// SetRolePermissions xử lý PUT /api/admin/permissions/roles/:role - Thay quyền của role
+// @Summary Thay quyền của role
+// @Description Thay toàn bộ permission của role. Có hiệu lực với access token cấp sau đó (user cần refresh token hoặc đăng nhập lại).
+// @Description Admin luôn phải giữ permission:manage (cần permission:manage)
+// @Tags Permissions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param role path string true "Role" example(cashier)
+// @Param request body dto.SetRolePermissionsRequest true "Danh sách permission mới"
+// @Success 200 {object} dto.APIResponse{data=dto.RolePermissionsResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/permissions/roles/{role} [put]
+func (h *PermissionHandler) SetRolePermissions(c *gin.Context) {
+	var req dto.SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	actorID, _ := middleware.GetUserID(c)
+
+	result, err := h.useCase.SetRolePermissions(c.Request.Context(), usecase.SetRolePermissionsInput{
+		Role:        entity.UserRole(c.Param("role")),
+		Permissions: req.Permissions,
+		ActorID:     actorID,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrInvalidRole) ||
+			errors.Is(err, usecase.ErrPermissionNotFound) ||
+			errors.Is(err, usecase.ErrCannotRevokeAdminManage) {
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể cập nhật quyền của role", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Cập nhật quyền của role thành công",
+			dto.ToRolePermissionsResponse(result.Role, result.Permissions)))
+}
+
+// BasePath trả về base path cho Permission module
+func (h *PermissionHandler) BasePath() string {
+	return "/admin/permissions"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Permission module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *PermissionHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionPermissionManage))
+
+	rg.GET("", h.GetPermissions)
+	rg.GET("/roles", h.GetRolePermissions)
+	rg.PUT("/roles/:role", h.SetRolePermissions)
+}
@@ -0,0 +1,97 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// SchedulerHandler xử lý các HTTP request xem trạng thái tác vụ định kỳ
+type SchedulerHandler struct {
+	useCase *usecase.SchedulerUseCase
+}
+
+// NewSchedulerHandler tạo mới SchedulerHandler
+func NewSchedulerHandler(uc *usecase.SchedulerUseCase) *SchedulerHandler {
+	return &SchedulerHandler{
+		useCase: uc,
+	}
+}
+
+// XemTacVu xử lý GET /api/admin/scheduler/tasks - Liệt kê tác vụ định kỳ
+// @Summary Liệt kê tác vụ định kỳ
+// @Description Lịch chạy, lần chạy kế tiếp, lần chạy gần nhất và lỗi gần nhất của từng tác vụ (cần scheduler:manage)
+// @Tags Scheduler
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.TaskStatusResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/scheduler/tasks [get]
+func (h *SchedulerHandler) XemTacVu(c *gin.Context) {
+	list, err := h.useCase.XemTacVu(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách tác vụ", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách tác vụ thành công", dto.ToTaskStatusResponseList(list)))
+}
+
+// XemLichSuChay xử lý GET /api/admin/scheduler/tasks/:ten/runs - Lịch sử chạy của tác vụ
+// @Summary Lịch sử chạy của tác vụ
+// @Description Các lần chạy gần nhất của một tác vụ, mới nhất trước (cần scheduler:manage)
+// @Tags Scheduler
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param ten path string true "Tên tác vụ"
+// @Param limit query int false "Số lần chạy (default: 20, tối đa SCHEDULER_HISTORY_SIZE)"
+// @Success 200 {object} dto.APIResponse{data=[]dto.TaskRunResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/admin/scheduler/tasks/{ten}/runs [get]
+func (h *SchedulerHandler) XemLichSuChay(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số limit không hợp lệ", err))
+		return
+	}
+
+	runs, err := h.useCase.XemLichSuChay(c.Request.Context(), c.Param("ten"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy lịch sử chạy", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy lịch sử chạy thành công", dto.ToTaskRunResponseList(runs)))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Scheduler module
+func (h *SchedulerHandler) BasePath() string {
+	return "/admin/scheduler"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Scheduler module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *SchedulerHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionSchedulerManage))
+
+	rg.GET("/tasks", h.XemTacVu)
+	rg.GET("/tasks/:ten/runs", h.XemLichSuChay)
+}
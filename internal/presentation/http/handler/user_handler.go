@@ -2,6 +2,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -14,15 +15,17 @@ import (
 
 // UserHandler xử lý các HTTP request liên quan đến User
 type UserHandler struct {
-	useCase *usecase.UserUseCase
-	jwtAuth *middleware.JWTAuthMiddleware
+	useCase     *usecase.UserUseCase
+	authUseCase *usecase.AuthUseCase // Quản lý session của user (user:session:manage)
+	jwtAuth     *middleware.JWTAuthMiddleware
 }
 
 // NewUserHandler tạo mới UserHandler
-func NewUserHandler(uc *usecase.UserUseCase, jwtAuth *middleware.JWTAuthMiddleware) *UserHandler {
+func NewUserHandler(uc *usecase.UserUseCase, authUC *usecase.AuthUseCase, jwtAuth *middleware.JWTAuthMiddleware) *UserHandler {
 	return &UserHandler{
-		useCase: uc,
-		jwtAuth: jwtAuth,
+		useCase:     uc,
+		authUseCase: authUC,
+		jwtAuth:     jwtAuth,
 	}
 }
 
@@ -98,7 +101,7 @@ func (h *UserHandler) ChangePassword(c *gin.Context) {
 
 // GetUsers xử lý GET /api/users - Lấy danh sách users
 // @Summary Lấy danh sách users
-// @Description Lấy danh sách tất cả users có phân trang (Manager+)
+// @Description Lấy danh sách tất cả users có phân trang (cần user:read)
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -143,7 +146,7 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 
 // CreateUser xử lý POST /api/users - Tạo user mới
 // @Summary Tạo user mới
-// @Description Tạo user mới (Manager+)
+// @Description Tạo user mới (cần user:write và user:assign:<role> của user mới)
 // @Tags Users
 // @Accept json
 // @Produce json
@@ -154,13 +157,6 @@ func (h *UserHandler) GetUsers(c *gin.Context) {
 // @Failure 403 {object} dto.APIResponse
 // @Router /api/users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
-	creatorRole, exists := middleware.GetUserRole(c)
-	if !exists {
-		c.JSON(http.StatusUnauthorized,
-			dto.NewErrorResponse("Unauthorized", nil))
-		return
-	}
-
 	var req dto.CreateUserRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest,
@@ -169,19 +165,18 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	}
 
 	input := usecase.CreateUserInput{
-		Username:    req.Username,
-		Email:       req.Email,
-		Password:    req.Password,
-		Role:        entity.UserRole(req.Role),
-		CreatorRole: entity.UserRole(creatorRole),
+		Username: req.Username,
+		Email:    req.Email,
+		Password: req.Password,
+		Role:     entity.UserRole(req.Role),
+
+		CreatorPermissions: middleware.GetPermissions(c),
 	}
 
 	user, err := h.useCase.CreateUser(c.Request.Context(), input)
 	if err != nil {
 		statusCode := http.StatusBadRequest
-		if err == usecase.ErrPermissionDenied ||
-			err == usecase.ErrCannotCreateAdmin ||
-			err == usecase.ErrCannotCreateManager {
+		if err == usecase.ErrCannotAssignRole {
 			statusCode = http.StatusForbidden
 		}
 		c.JSON(statusCode,
@@ -193,15 +188,88 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		dto.NewSuccessResponse("Tạo user thành công", dto.ToUserResponse(user)))
 }
 
+// staffErrorStatus ánh xạ lỗi tạo tài khoản nhân viên sang HTTP status
+// Lỗi không nhận diện được (repository/DB) là lỗi hệ thống, không phải lỗi của client
+func staffErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrCannotAssignRole),
+		errors.Is(err, usecase.ErrCannotWriteSalary):
+		return http.StatusForbidden
+	case errors.Is(err, usecase.ErrUsernameExists),
+		errors.Is(err, usecase.ErrEmailExists),
+		errors.Is(err, usecase.ErrPhoneExists):
+		return http.StatusConflict
+	case errors.Is(err, usecase.ErrInvalidRole),
+		errors.Is(err, usecase.ErrInvalidChucVu),
+		errors.Is(err, usecase.ErrInvalidUserInfo),
+		errors.Is(err, usecase.ErrInvalidStaffInfo):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CreateStaff xử lý POST /api/users/staff - Tạo tài khoản nhân viên
+// @Summary Tạo tài khoản nhân viên
+// @Description Tạo user và hồ sơ nhân viên trong cùng một transaction (cần user:write và user:assign:<role> của user mới, đặt luong_co_ban > 0 cần thêm staff:salary:write)
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateStaffRequest true "Thông tin tài khoản và hồ sơ nhân viên"
+// @Success 201 {object} dto.APIResponse{data=dto.StaffResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse "Username, email hoặc số điện thoại đã tồn tại"
+// @Failure 500 {object} dto.APIResponse
+// @Router /api/users/staff [post]
+func (h *UserHandler) CreateStaff(c *gin.Context) {
+	var req dto.CreateStaffRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	input := usecase.CreateStaffInput{
+		CreateUserInput: usecase.CreateUserInput{
+			Username: req.Username,
+			Email:    req.Email,
+			Password: req.Password,
+			Role:     entity.UserRole(req.Role),
+
+			CreatorPermissions: middleware.GetPermissions(c),
+		},
+		HoTen:       req.HoTen,
+		ChucVu:      entity.ChucVu(req.ChucVu),
+		SoDienThoai: req.SoDienThoai,
+		LuongCoBan:  req.LuongCoBan,
+	}
+
+	user, nv, err := h.useCase.CreateStaff(c.Request.Context(), input)
+	if err != nil {
+		c.JSON(staffErrorStatus(err),
+			dto.NewErrorResponse("Không thể tạo tài khoản nhân viên", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Tạo tài khoản nhân viên thành công", dto.StaffResponse{
+			User:     dto.ToUserResponse(user),
+			NhanVien: dto.ToNhanVienResponse(nv),
+		}))
+}
+
 // GetUser xử lý GET /api/users/:id - Lấy user theo ID
 // @Summary Lấy user theo ID
-// @Description Lấy thông tin user theo ID (Manager+)
+// @Description Lấy thông tin user theo ID (cần user:read)
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID"
 // @Success 200 {object} dto.APIResponse{data=dto.UserResponse}
+// @Header 200 {string} ETag "Phiên bản user, gửi lại qua If-Match khi sửa"
 // @Failure 404 {object} dto.APIResponse
 // @Router /api/users/{id} [get]
 func (h *UserHandler) GetUser(c *gin.Context) {
@@ -219,21 +287,25 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 		return
 	}
 
+	datETag(c, user.Version)
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Lấy user thành công", dto.ToUserResponse(user)))
 }
 
 // UpdateUser xử lý PUT /api/users/:id - Cập nhật user
 // @Summary Cập nhật user
-// @Description Cập nhật thông tin user (Manager+)
+// @Description Cập nhật thông tin user (cần user:write và user:assign:<role> của user)
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID"
 // @Param request body dto.UpdateUserRequest true "Thông tin cập nhật"
+// @Param If-Match header string false "ETag đã đọc, sửa khi user chưa bị thay đổi"
 // @Success 200 {object} dto.APIResponse{data=dto.UserResponse}
+// @Header 200 {string} ETag "Phiên bản user"
 // @Failure 400 {object} dto.APIResponse
+// @Failure 412 {object} dto.APIResponse "User đã bị thay đổi bởi người khác"
 // @Router /api/users/{id} [put]
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	id := c.Param("id")
@@ -243,10 +315,8 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	callerRole, exists := middleware.GetUserRole(c)
-	if !exists {
-		c.JSON(http.StatusUnauthorized,
-			dto.NewErrorResponse("Unauthorized", nil))
+	phienBan, ok := docIfMatch(c)
+	if !ok {
 		return
 	}
 
@@ -258,16 +328,22 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	}
 
 	input := usecase.UpdateUserInput{
-		ID:         id,
-		Email:      req.Email,
-		IsActive:   req.IsActive,
-		CallerRole: entity.UserRole(callerRole),
+		ID:       id,
+		Email:    req.Email,
+		IsActive: req.IsActive,
+		PhienBan: phienBan,
+
+		CallerPermissions: middleware.GetPermissions(c),
 	}
 
 	user, err := h.useCase.UpdateUser(c.Request.Context(), input)
 	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
 		statusCode := http.StatusBadRequest
-		if err == usecase.ErrPermissionDenied {
+		if err == usecase.ErrCannotAssignRole {
 			statusCode = http.StatusForbidden
 		}
 		c.JSON(statusCode,
@@ -275,21 +351,25 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
+	datETag(c, user.Version)
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Cập nhật user thành công", dto.ToUserResponse(user)))
 }
 
 // DeactivateUser xử lý DELETE /api/users/:id - Vô hiệu hóa user
 // @Summary Vô hiệu hóa user
-// @Description Vô hiệu hóa user (Admin only)
+// @Description Vô hiệu hóa user (cần user:deactivate và user:assign:<role> của user)
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "User ID"
+// @Param If-Match header string false "ETag đã đọc, sửa khi user chưa bị thay đổi"
 // @Success 200 {object} dto.APIResponse{data=dto.UserResponse}
+// @Header 200 {string} ETag "Phiên bản user"
 // @Failure 400 {object} dto.APIResponse
 // @Failure 403 {object} dto.APIResponse
+// @Failure 412 {object} dto.APIResponse "User đã bị thay đổi bởi người khác"
 // @Router /api/users/{id} [delete]
 func (h *UserHandler) DeactivateUser(c *gin.Context) {
 	id := c.Param("id")
@@ -299,12 +379,21 @@ func (h *UserHandler) DeactivateUser(c *gin.Context) {
 		return
 	}
 
+	phienBan, ok := docIfMatch(c)
+	if !ok {
+		return
+	}
+
 	requestorID, _ := middleware.GetUserID(c)
 
-	user, err := h.useCase.DeactivateUser(c.Request.Context(), id, requestorID)
+	user, err := h.useCase.DeactivateUser(c.Request.Context(), id, requestorID, middleware.GetPermissions(c), phienBan)
 	if err != nil {
+		if laXungDot(err) {
+			traLoiXungDot(c)
+			return
+		}
 		statusCode := http.StatusBadRequest
-		if err == usecase.ErrCannotDeactivateSelf {
+		if err == usecase.ErrCannotDeactivateSelf || err == usecase.ErrCannotAssignRole {
 			statusCode = http.StatusForbidden
 		}
 		c.JSON(statusCode,
@@ -312,10 +401,99 @@ func (h *UserHandler) DeactivateUser(c *gin.Context) {
 		return
 	}
 
+	datETag(c, user.Version)
 	c.JSON(http.StatusOK,
 		dto.NewSuccessResponse("Vô hiệu hóa user thành công", dto.ToUserResponse(user)))
 }
 
+// GetUserSessions xử lý GET /api/users/:id/sessions - Xem session của user
+// @Summary Xem session của user
+// @Description Danh sách thiết bị đang đăng nhập của một user (cần user:session:manage)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.APIResponse{data=dto.ActiveSessionsResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/users/{id}/sessions [get]
+func (h *UserHandler) GetUserSessions(c *gin.Context) {
+	sessions, err := h.authUseCase.ListSessions(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(sessionErrorStatus(err),
+			dto.NewErrorResponse("Không thể lấy thông tin session", err))
+		return
+	}
+
+	response := dto.ActiveSessionsResponse{
+		ActiveSessions: int64(len(sessions)),
+		Sessions:       dto.ToSessionResponseList(sessions, ""),
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy thông tin session thành công", response))
+}
+
+// RevokeUserSession xử lý DELETE /api/users/:id/sessions/:sessionId - Thu hồi một session của user
+// @Summary Thu hồi session của user
+// @Description Đăng xuất một thiết bị của user (cần user:session:manage)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Param sessionId path string true "Session ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 403 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/users/{id}/sessions/{sessionId} [delete]
+func (h *UserHandler) RevokeUserSession(c *gin.Context) {
+	if err := h.authUseCase.RevokeSession(c.Request.Context(), c.Param("id"), c.Param("sessionId")); err != nil {
+		c.JSON(sessionErrorStatus(err),
+			dto.NewErrorResponse("Không thể thu hồi session", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Đã thu hồi session", nil))
+}
+
+// RevokeAllUserSessions xử lý DELETE /api/users/:id/sessions - Đăng xuất user khỏi mọi thiết bị
+// @Summary Thu hồi mọi session của user
+// @Description Đăng xuất user khỏi tất cả thiết bị (cần user:session:manage)
+// @Tags Users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.APIResponse{data=dto.LogoutAllResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/users/{id}/sessions [delete]
+func (h *UserHandler) RevokeAllUserSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := c.Param("id")
+
+	sessions, err := h.authUseCase.ListSessions(ctx, userID)
+	if err != nil {
+		c.JSON(sessionErrorStatus(err),
+			dto.NewErrorResponse("Không thể thu hồi session", err))
+		return
+	}
+
+	if err := h.authUseCase.LogoutAllDevices(ctx, userID); err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể thu hồi session", err))
+		return
+	}
+
+	response := dto.LogoutAllResponse{
+		RevokedSessions: int64(len(sessions)),
+		Message:         "Đã đăng xuất user khỏi tất cả thiết bị",
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Thu hồi session thành công", response))
+}
+
 // ============================================================
 // RouteRegistrar Interface Implementation
 // ============================================================
@@ -332,12 +510,16 @@ func (h *UserHandler) RegisterRoutes(rg *gin.RouterGroup) {
 	rg.GET("/me", h.GetMe)
 	rg.PUT("/me/password", h.ChangePassword)
 
-	// Manager+ routes
-	rg.GET("", middleware.RequireMinRole(middleware.RoleManager), h.GetUsers)
-	rg.POST("", middleware.RequireMinRole(middleware.RoleManager), h.CreateUser)
-	rg.GET("/:id", middleware.RequireMinRole(middleware.RoleManager), h.GetUser)
-	rg.PUT("/:id", middleware.RequireMinRole(middleware.RoleManager), h.UpdateUser)
-
-	// Admin only
-	rg.DELETE("/:id", middleware.RequireRole(middleware.RoleAdmin), h.DeactivateUser)
+	// Quản lý user - tạo/sửa chỉ với role mà người gọi có quyền user:assign:<role>
+	rg.GET("", middleware.RequirePermission(entity.PermissionUserRead), h.GetUsers)
+	rg.POST("", middleware.RequirePermission(entity.PermissionUserWrite), h.CreateUser)
+	rg.POST("/staff", middleware.RequirePermission(entity.PermissionUserWrite), h.CreateStaff)
+	rg.GET("/:id", middleware.RequirePermission(entity.PermissionUserRead), h.GetUser)
+	rg.PUT("/:id", middleware.RequirePermission(entity.PermissionUserWrite), h.UpdateUser)
+	rg.DELETE("/:id", middleware.RequirePermission(entity.PermissionUserDeactivate), h.DeactivateUser)
+
+	// Phiên đăng nhập của user khác
+	rg.GET("/:id/sessions", middleware.RequirePermission(entity.PermissionUserSessionManage), h.GetUserSessions)
+	rg.DELETE("/:id/sessions", middleware.RequirePermission(entity.PermissionUserSessionManage), h.RevokeAllUserSessions)
+	rg.DELETE("/:id/sessions/:sessionId", middleware.RequirePermission(entity.PermissionUserSessionManage), h.RevokeUserSession)
 }
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"restaurant_project/internal/application/usecase"
+)
+
+func TestStaffErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"không có quyền gán role", usecase.ErrCannotAssignRole, http.StatusForbidden},
+		{"không có quyền đặt lương", usecase.ErrCannotWriteSalary, http.StatusForbidden},
+		{"trùng username", usecase.ErrUsernameExists, http.StatusConflict},
+		{"trùng email", usecase.ErrEmailExists, http.StatusConflict},
+		{"trùng số điện thoại", usecase.ErrPhoneExists, http.StatusConflict},
+		{"role không hợp lệ", usecase.ErrInvalidRole, http.StatusBadRequest},
+		{"chức vụ không hợp lệ", usecase.ErrInvalidChucVu, http.StatusBadRequest},
+		{"thông tin nhân viên không hợp lệ", fmt.Errorf("%w: họ tên không được để trống", usecase.ErrInvalidStaffInfo), http.StatusBadRequest},
+		{"thông tin user không hợp lệ", fmt.Errorf("%w: username không được để trống", usecase.ErrInvalidUserInfo), http.StatusBadRequest},
+		{"lỗi DB", errors.New("mysql: connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := staffErrorStatus(tt.err); got != tt.want {
+				t.Errorf("staffErrorStatus(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// WebhookHandler xử lý các HTTP request quản lý webhook của bên thứ ba (Admin)
+type WebhookHandler struct {
+	useCase *usecase.WebhookUseCase
+}
+
+// NewWebhookHandler tạo mới WebhookHandler
+func NewWebhookHandler(uc *usecase.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		useCase: uc,
+	}
+}
+
+// XemWebhook xử lý GET /api/admin/webhooks - Lấy danh sách webhook
+// @Summary Lấy danh sách webhook
+// @Description Lấy danh sách webhook đã đăng ký, không gồm secret (cần webhook:manage)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.WebhookResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/webhooks [get]
+func (h *WebhookHandler) XemWebhook(c *gin.Context) {
+	list, err := h.useCase.XemWebhook(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách webhook thành công", dto.ToWebhookResponseList(list)))
+}
+
+// TaoWebhook xử lý POST /api/admin/webhooks - Đăng ký webhook
+// @Summary Đăng ký webhook
+// @Description Đăng ký endpoint nhận sự kiện (order.created, order.status_changed, mon_an.availability_changed).
+// @Description Mỗi request gửi kèm X-Webhook-Timestamp và X-Webhook-Signature = "sha256=" + hex(HMAC-SHA256(secret, "<timestamp>.<body>")),
+// @Description X-Webhook-Delivery để bên nhận bỏ qua bản gửi trùng (cần webhook:manage)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TaoWebhookRequest true "Thông tin webhook"
+// @Success 201 {object} dto.APIResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Router /api/admin/webhooks [post]
+func (h *WebhookHandler) TaoWebhook(c *gin.Context) {
+	var req dto.TaoWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	wh, err := h.useCase.TaoWebhook(c.Request.Context(), usecase.TaoWebhookInput{
+		Ten:    req.Ten,
+		URL:    req.URL,
+		SuKien: req.SuKien,
+		Secret: req.Secret,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Không thể đăng ký webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusCreated,
+		dto.NewSuccessResponse("Đăng ký webhook thành công", dto.ToWebhookResponse(wh)))
+}
+
+// TimWebhook xử lý GET /api/admin/webhooks/:id - Lấy webhook theo ID
+// @Summary Lấy webhook theo ID
+// @Description Lấy thông tin webhook, không gồm secret (cần webhook:manage)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.APIResponse{data=dto.WebhookResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/admin/webhooks/{id} [get]
+func (h *WebhookHandler) TimWebhook(c *gin.Context) {
+	wh, err := h.useCase.TimWebhook(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrWebhookNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể lấy webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy webhook thành công", dto.ToWebhookResponse(wh)))
+}
+
+// CapNhatWebhook xử lý PUT /api/admin/webhooks/:id - Cập nhật webhook
+// @Summary Cập nhật webhook
+// @Description Đổi URL, bộ lọc sự kiện, secret hoặc tạm dừng / kích hoạt webhook (cần webhook:manage)
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param request body dto.CapNhatWebhookRequest true "Thông tin cập nhật"
+// @Success 200 {object} dto.APIResponse{data=dto.WebhookResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/admin/webhooks/{id} [put]
+func (h *WebhookHandler) CapNhatWebhook(c *gin.Context) {
+	var req dto.CapNhatWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Dữ liệu không hợp lệ", err))
+		return
+	}
+
+	wh, err := h.useCase.CapNhatWebhook(c.Request.Context(), usecase.CapNhatWebhookInput{
+		ID:           c.Param("id"),
+		Ten:          req.Ten,
+		URL:          req.URL,
+		SuKien:       req.SuKien,
+		Secret:       req.Secret,
+		DangHoatDong: req.DangHoatDong,
+	})
+	if err != nil {
+		statusCode := http.StatusBadRequest
+		if errors.Is(err, usecase.ErrWebhookNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể cập nhật webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Cập nhật webhook thành công", dto.ToWebhookResponse(wh)))
+}
+
+// XoaWebhook xử lý DELETE /api/admin/webhooks/:id - Xóa webhook
+// @Summary Xóa webhook
+// @Description Xóa webhook; nhật ký gửi được giữ lại (cần webhook:manage)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/admin/webhooks/{id} [delete]
+func (h *WebhookHandler) XoaWebhook(c *gin.Context) {
+	if err := h.useCase.XoaWebhook(c.Request.Context(), c.Param("id")); err != nil {
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, usecase.ErrWebhookNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể xóa webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Xóa webhook thành công", nil))
+}
+
+// XemDelivery xử lý GET /api/admin/webhooks/:id/deliveries - Nhật ký gửi của webhook
+// @Summary Nhật ký gửi webhook
+// @Description Các lần gửi sự kiện tới webhook kèm trạng thái, số lần thử, HTTP status và lỗi gần nhất, mới nhất trước (cần webhook:manage)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param su_kien_id query string false "Lọc theo ID sự kiện"
+// @Param trang_thai query string false "Lọc theo trạng thái" Enums(dang_cho, thanh_cong, that_bai)
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} dto.APIResponse{data=dto.PaginatedResponse}
+// @Failure 400 {object} dto.APIResponse
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) XemDelivery(c *gin.Context) {
+	var req dto.WebhookDeliveryQueryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest,
+			dto.NewErrorResponse("Tham số tra cứu không hợp lệ", err))
+		return
+	}
+
+	list, total, err := h.useCase.XemDelivery(c.Request.Context(), usecase.XemDeliveryInput{
+		WebhookID: c.Param("id"),
+		SuKienID:  req.SuKienID,
+		TrangThai: req.TrangThai,
+		Offset:    req.Offset(),
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, usecase.ErrWebhookNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, usecase.ErrTrangThaiDeliveryKhongHopLe):
+			statusCode = http.StatusBadRequest
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể tra cứu nhật ký gửi webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Tra cứu nhật ký gửi webhook thành công",
+			dto.NewPaginatedResponse(dto.ToWebhookDeliveryResponseList(list), total, req.Page, req.Limit)))
+}
+
+// GuiLaiDelivery xử lý POST /api/admin/webhooks/:id/deliveries/:deliveryId/redeliver - Gửi lại thủ công
+// @Summary Gửi lại webhook
+// @Description Gửi lại một lần gửi (thất bại hoặc đã thành công) với cùng body và X-Webhook-Delivery, lượt thử tự động tính lại từ đầu (cần webhook:manage)
+// @Tags Webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Webhook ID"
+// @Param deliveryId path string true "Delivery ID"
+// @Success 202 {object} dto.APIResponse{data=dto.WebhookDeliveryResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse
+// @Router /api/admin/webhooks/{id}/deliveries/{deliveryId}/redeliver [post]
+func (h *WebhookHandler) GuiLaiDelivery(c *gin.Context) {
+	d, err := h.useCase.GuiLaiDelivery(c.Request.Context(), c.Param("id"), c.Param("deliveryId"))
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, usecase.ErrWebhookNotFound), errors.Is(err, usecase.ErrWebhookDeliveryNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, usecase.ErrWebhookDangTamDung):
+			statusCode = http.StatusConflict
+		}
+		c.JSON(statusCode,
+			dto.NewErrorResponse("Không thể gửi lại webhook", err))
+		return
+	}
+
+	c.JSON(http.StatusAccepted,
+		dto.NewSuccessResponse("Đã đưa lần gửi webhook vào hàng đợi", dto.ToWebhookDeliveryResponse(d)))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho Webhooks module
+func (h *WebhookHandler) BasePath() string {
+	return "/admin/webhooks"
+}
+
+// RegisterRoutes đăng ký tất cả routes của Webhooks module
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *WebhookHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionWebhookManage))
+
+	rg.GET("", h.XemWebhook)
+	rg.POST("", h.TaoWebhook)
+	rg.GET("/:id", h.TimWebhook)
+	rg.PUT("/:id", h.CapNhatWebhook)
+	rg.DELETE("/:id", h.XoaWebhook)
+	rg.GET("/:id/deliveries", h.XemDelivery)
+	rg.POST("/:id/deliveries/:deliveryId/redeliver", h.GuiLaiDelivery)
+}
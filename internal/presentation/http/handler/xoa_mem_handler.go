@@ -0,0 +1,189 @@
+// Package handler chứa HTTP Handlers
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"restaurant_project/internal/application/usecase"
+	"restaurant_project/internal/domain/entity"
+	"restaurant_project/internal/infrastructure/middleware"
+	"restaurant_project/internal/presentation/http/dto"
+)
+
+// XoaMemHandler xử lý các HTTP request xem và khôi phục bản ghi đã xóa mềm (Admin)
+type XoaMemHandler struct {
+	useCase *usecase.XoaMemUseCase
+}
+
+// NewXoaMemHandler tạo mới XoaMemHandler
+func NewXoaMemHandler(uc *usecase.XoaMemUseCase) *XoaMemHandler {
+	return &XoaMemHandler{
+		useCase: uc,
+	}
+}
+
+// XemMonDaXoa xử lý GET /api/admin/deleted/dishes - Liệt kê món đã xóa
+// @Summary Liệt kê món đã xóa
+// @Description Các món đã xóa mềm còn trong thời gian lưu giữ, mới xóa trước (cần deleted:restore)
+// @Tags Deleted
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.MonAnDaXoaResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/deleted/dishes [get]
+func (h *XoaMemHandler) XemMonDaXoa(c *gin.Context) {
+	list, err := h.useCase.XemMonDaXoa(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách món đã xóa", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách món đã xóa thành công", dto.ToMonAnDaXoaResponseList(list)))
+}
+
+// KhoiPhucMon xử lý POST /api/admin/deleted/dishes/:id/restore - Khôi phục món
+// @Summary Khôi phục món đã xóa
+// @Description Đưa món đã xóa mềm trở lại menu (cần deleted:restore)
+// @Tags Deleted
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID món ăn"
+// @Success 200 {object} dto.APIResponse{data=dto.MonAnResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Router /api/admin/deleted/dishes/{id}/restore [post]
+func (h *XoaMemHandler) KhoiPhucMon(c *gin.Context) {
+	mon, err := h.useCase.KhoiPhucMon(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		traLoiLoiKhoiPhuc(c, "Không thể khôi phục món", err)
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Khôi phục món thành công", dto.ToMonAnResponse(mon)))
+}
+
+// XemKhachHangDaXoa xử lý GET /api/admin/deleted/customers - Liệt kê khách hàng đã xóa
+// @Summary Liệt kê khách hàng đã xóa
+// @Description Các khách hàng đã xóa mềm còn trong thời gian lưu giữ, mới xóa trước (cần deleted:restore)
+// @Tags Deleted
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.KhachHangResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/deleted/customers [get]
+func (h *XoaMemHandler) XemKhachHangDaXoa(c *gin.Context) {
+	list, err := h.useCase.XemKhachHangDaXoa(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách khách hàng đã xóa", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách khách hàng đã xóa thành công", dto.ToKhachHangResponseList(list)))
+}
+
+// KhoiPhucKhachHang xử lý POST /api/admin/deleted/customers/:id/restore - Khôi phục khách hàng
+// @Summary Khôi phục khách hàng đã xóa
+// @Description Khôi phục khách hàng đã xóa mềm (giữ nguyên điểm tích lũy) (cần deleted:restore)
+// @Tags Deleted
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID khách hàng"
+// @Success 200 {object} dto.APIResponse{data=dto.KhachHangResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse "Số điện thoại đã được bản ghi khác sử dụng"
+// @Router /api/admin/deleted/customers/{id}/restore [post]
+func (h *XoaMemHandler) KhoiPhucKhachHang(c *gin.Context) {
+	kh, err := h.useCase.KhoiPhucKhachHang(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		traLoiLoiKhoiPhuc(c, "Không thể khôi phục khách hàng", err)
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Khôi phục khách hàng thành công", dto.ToKhachHangResponse(kh)))
+}
+
+// XemNhanVienDaXoa xử lý GET /api/admin/deleted/staff - Liệt kê nhân viên đã xóa
+// @Summary Liệt kê nhân viên đã xóa
+// @Description Các nhân viên đã xóa mềm còn trong thời gian lưu giữ, mới xóa trước (cần deleted:restore)
+// @Tags Deleted
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.APIResponse{data=[]dto.NhanVienResponse}
+// @Failure 403 {object} dto.APIResponse
+// @Router /api/admin/deleted/staff [get]
+func (h *XoaMemHandler) XemNhanVienDaXoa(c *gin.Context) {
+	list, err := h.useCase.XemNhanVienDaXoa(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError,
+			dto.NewErrorResponse("Không thể lấy danh sách nhân viên đã xóa", err))
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Lấy danh sách nhân viên đã xóa thành công", dto.ToNhanVienResponseList(list)))
+}
+
+// KhoiPhucNhanVien xử lý POST /api/admin/deleted/staff/:id/restore - Khôi phục nhân viên
+// @Summary Khôi phục nhân viên đã xóa
+// @Description Khôi phục nhân viên đã xóa mềm (cần deleted:restore)
+// @Tags Deleted
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID nhân viên"
+// @Success 200 {object} dto.APIResponse{data=dto.NhanVienResponse}
+// @Failure 404 {object} dto.APIResponse
+// @Failure 409 {object} dto.APIResponse "Số điện thoại đã được bản ghi khác sử dụng"
+// @Router /api/admin/deleted/staff/{id}/restore [post]
+func (h *XoaMemHandler) KhoiPhucNhanVien(c *gin.Context) {
+	nv, err := h.useCase.KhoiPhucNhanVien(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		traLoiLoiKhoiPhuc(c, "Không thể khôi phục nhân viên", err)
+		return
+	}
+
+	c.JSON(http.StatusOK,
+		dto.NewSuccessResponse("Khôi phục nhân viên thành công", dto.ToNhanVienResponse(nv)))
+}
+
+// traLoiLoiKhoiPhuc trả lỗi khôi phục: 404 nếu không có bản ghi đã xóa,
+// 409 nếu số điện thoại đã được bản ghi khác dùng, còn lại 500
+func traLoiLoiKhoiPhuc(c *gin.Context, message string, err error) {
+	statusCode := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, usecase.ErrBanGhiDaXoaNotFound):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, usecase.ErrKhoiPhucTrungSoDT):
+		statusCode = http.StatusConflict
+	}
+	c.JSON(statusCode, dto.NewErrorResponse(message, err))
+}
+
+// ============================================================
+// RouteRegistrar Interface Implementation
+// ============================================================
+
+// BasePath trả về base path cho module bản ghi đã xóa
+func (h *XoaMemHandler) BasePath() string {
+	return "/admin/deleted"
+}
+
+// RegisterRoutes đăng ký tất cả routes của module bản ghi đã xóa
+// Note: Middleware JWT đã được áp dụng ở cấp group trong app.go
+func (h *XoaMemHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.Use(middleware.RequirePermission(entity.PermissionDeletedRestore))
+
+	rg.GET("/dishes", h.XemMonDaXoa)
+	rg.POST("/dishes/:id/restore", h.KhoiPhucMon)
+	rg.GET("/customers", h.XemKhachHangDaXoa)
+	rg.POST("/customers/:id/restore", h.KhoiPhucKhachHang)
+	rg.GET("/staff", h.XemNhanVienDaXoa)
+	rg.POST("/staff/:id/restore", h.KhoiPhucNhanVien)
+}
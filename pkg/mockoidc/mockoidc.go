@@ -0,0 +1,292 @@
+// Package mockoidc là OpenID Connect provider giả lập dùng khi phát triển và kiểm thử đăng nhập OIDC
+// Hỗ trợ discovery, authorization code + PKCE (S256), token endpoint và JWKS.
+// Không kiểm tra client_secret và không có tài khoản thật: người dùng tự nhập email ở trang đăng nhập
+package mockoidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyID          = "mockoidc"
+	codeTTL        = time.Minute
+	idTokenTTL     = time.Hour
+	rsaKeySize     = 2048
+	codeRandomSize = 32
+)
+
+// authCode là authorization code đã cấp, chờ đổi lấy token
+type authCode struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	email         string
+	emailVerified bool
+	expiresAt     time.Time
+}
+
+// Server là mock OIDC provider, implement http.Handler
+type Server struct {
+	issuer string
+	key    *rsa.PrivateKey
+	mux    *http.ServeMux
+
+	mu    sync.Mutex
+	codes map[string]authCode
+}
+
+// NewServer tạo mock provider với issuer (URL gốc mà server được truy cập, vd http://localhost:9000)
+// Signing key RSA được sinh mới mỗi lần khởi động
+func NewServer(issuer string) (*Server, error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	s := &Server{
+		issuer: strings.TrimSuffix(issuer, "/"),
+		key:    key,
+		mux:    http.NewServeMux(),
+		codes:  make(map[string]authCode),
+	}
+	s.mux.HandleFunc("GET /.well-known/openid-configuration", s.handleDiscovery)
+	s.mux.HandleFunc("GET /authorize", s.handleAuthorize)
+	s.mux.HandleFunc("POST /authorize", s.handleAuthorize)
+	s.mux.HandleFunc("POST /token", s.handleToken)
+	s.mux.HandleFunc("GET /jwks", s.handleJWKS)
+
+	return s, nil
+}
+
+// ServeHTTP implement http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleDiscovery trả về metadata của provider
+func (s *Server) handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                s.issuer,
+		"authorization_endpoint":                s.issuer + "/authorize",
+		"token_endpoint":                        s.issuer + "/token",
+		"jwks_uri":                              s.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// loginPage là trang đăng nhập giả lập: nhập email và chọn email đã xác minh hay chưa
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Mock OIDC Login</title></head>
+<body>
+<h1>Mock OIDC Login</h1>
+<form method="POST">
+{{range $name, $value := .}}<input type="hidden" name="{{$name}}" value="{{$value}}">
+{{end}}<label>Email <input type="email" name="login_hint" required></label><br>
+<label><input type="checkbox" name="email_verified" value="true" checked> Email đã xác minh</label><br>
+<button type="submit">Đăng nhập</button>
+</form>
+</body>
+</html>`))
+
+// handleAuthorize hiển thị trang đăng nhập, hoặc duyệt ngay khi có login_hint
+// (kiểm thử tự động truyền login_hint để bỏ qua bước nhập form)
+func (s *Server) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	redirectURI := r.Form.Get("redirect_uri")
+	redirect, err := url.Parse(redirectURI)
+	if err != nil || redirectURI == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("response_type") != "code" || r.Form.Get("client_id") == "" {
+		http.Error(w, "unsupported response_type or missing client_id", http.StatusBadRequest)
+		return
+	}
+	if r.Form.Get("code_challenge") == "" || r.Form.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE with code_challenge_method=S256 is required", http.StatusBadRequest)
+		return
+	}
+
+	email := r.Form.Get("login_hint")
+	if email == "" {
+		params := map[string]string{}
+		for _, name := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+			params[name] = r.Form.Get(name)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = loginPage.Execute(w, params)
+		return
+	}
+
+	// Form gửi email_verified khi checkbox được chọn; login_hint qua query mặc định là đã xác minh
+	emailVerified := r.Form.Get("email_verified") != "false"
+	if r.Method == http.MethodPost {
+		emailVerified = r.Form.Get("email_verified") == "true"
+	}
+
+	code, err := randomString()
+	if err != nil {
+		http.Error(w, "failed to generate code", http.StatusInternalServerError)
+		return
+	}
+
+	s.mu.Lock()
+	s.codes[code] = authCode{
+		clientID:      r.Form.Get("client_id"),
+		redirectURI:   redirectURI,
+		codeChallenge: r.Form.Get("code_challenge"),
+		nonce:         r.Form.Get("nonce"),
+		email:         email,
+		emailVerified: emailVerified,
+		expiresAt:     time.Now().Add(codeTTL),
+	}
+	s.mu.Unlock()
+
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", r.Form.Get("state"))
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+// handleToken đổi authorization code lấy ID token (mỗi code dùng một lần)
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request", "invalid form")
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		tokenError(w, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	s.mu.Lock()
+	issued, ok := s.codes[code]
+	delete(s.codes, code)
+	s.mu.Unlock()
+
+	if !ok || time.Now().After(issued.expiresAt) {
+		tokenError(w, "invalid_grant", "authorization code is invalid or expired")
+		return
+	}
+	if r.PostForm.Get("client_id") != issued.clientID || r.PostForm.Get("redirect_uri") != issued.redirectURI {
+		tokenError(w, "invalid_grant", "client_id or redirect_uri mismatch")
+		return
+	}
+
+	hash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	challenge := base64.RawURLEncoding.EncodeToString(hash[:])
+	if subtle.ConstantTimeCompare([]byte(challenge), []byte(issued.codeChallenge)) != 1 {
+		tokenError(w, "invalid_grant", "PKCE verification failed")
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            s.issuer,
+		"aud":            issued.clientID,
+		"sub":            Subject(issued.email),
+		"email":          issued.email,
+		"email_verified": issued.emailVerified,
+		"name":           strings.Split(issued.email, "@")[0],
+		"iat":            now.Unix(),
+		"exp":            now.Add(idTokenTTL).Unix(),
+	}
+	if issued.nonce != "" {
+		claims["nonce"] = issued.nonce
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+	idToken, err := token.SignedString(s.key)
+	if err != nil {
+		http.Error(w, "failed to sign id token", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomOrEmpty(),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+// handleJWKS trả về public key để xác thực ID token
+func (s *Server) handleJWKS(w http.ResponseWriter, r *http.Request) {
+	pub := s.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// Subject trả về sub cố định của một email, để cùng email luôn là cùng tài khoản tại provider
+func Subject(email string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(email)))
+	return hex.EncodeToString(hash[:16])
+}
+
+// tokenError trả lỗi theo định dạng OAuth 2.0 (RFC 6749 5.2)
+func tokenError(w http.ResponseWriter, code, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// writeJSON ghi JSON response
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// randomString tạo chuỗi ngẫu nhiên base64url
+func randomString() (string, error) {
+	buf := make([]byte, codeRandomSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// randomOrEmpty tạo access token giả (không dùng tới, chỉ để response đúng định dạng)
+func randomOrEmpty() string {
+	value, _ := randomString()
+	return value
+}
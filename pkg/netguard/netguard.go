@@ -0,0 +1,112 @@
+// Package netguard chặn kết nối ra ngoài tới địa chỉ nội bộ (chống SSRF)
+// khi server gọi URL do người dùng nhập (vd: webhook)
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrDiaChiNoiBo trả về khi địa chỉ đích thuộc mạng nội bộ và không nằm trong allow-list
+var ErrDiaChiNoiBo = errors.New("địa chỉ đích thuộc mạng nội bộ")
+
+// Các dải không có hàm kiểm tra sẵn trong netip
+var daiBiChan = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // "This network"
+	netip.MustParsePrefix("100.64.0.0/10"), // Shared address space (CGNAT)
+	netip.MustParsePrefix("255.255.255.255/32"),
+}
+
+// Guard kiểm tra địa chỉ IP đích của kết nối ra ngoài
+// Chặn loopback, private (RFC 1918, IPv6 ULA), link-local (kể cả metadata 169.254.169.254),
+// unspecified và multicast; trừ các dải trong allow-list (chỉ nên dùng ở môi trường dev)
+type Guard struct {
+	choPhep []netip.Prefix
+}
+
+// New tạo Guard với allow-list gồm CIDR (vd: "127.0.0.0/8") hoặc địa chỉ IP đơn
+func New(choPhep []string) (*Guard, error) {
+	g := &Guard{}
+	for _, s := range choPhep {
+		if p, err := netip.ParsePrefix(s); err == nil {
+			g.choPhep = append(g.choPhep, p.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("dải IP cho phép không hợp lệ: %q", s)
+		}
+		g.choPhep = append(g.choPhep, netip.PrefixFrom(ip.Unmap(), ip.Unmap().BitLen()))
+	}
+	return g, nil
+}
+
+// KiemTraIP trả về ErrDiaChiNoiBo nếu không được kết nối tới ip
+func (g *Guard) KiemTraIP(ip netip.Addr) error {
+	ip = ip.Unmap().WithZone("")
+	for _, p := range g.choPhep {
+		if p.Contains(ip) {
+			return nil
+		}
+	}
+
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return fmt.Errorf("%w: %s", ErrDiaChiNoiBo, ip)
+	}
+	for _, p := range daiBiChan {
+		if p.Contains(ip) {
+			return fmt.Errorf("%w: %s", ErrDiaChiNoiBo, ip)
+		}
+	}
+	return nil
+}
+
+// Control dùng làm net.Dialer.Control: kiểm tra địa chỉ thực sự kết nối (đã phân giải DNS)
+// Chạy ngay trước connect nên DNS rebinding (phân giải lần sau ra IP nội bộ) cũng bị chặn
+func (g *Guard) Control(_, address string, _ syscall.RawConn) error {
+	ap, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("địa chỉ kết nối không hợp lệ %q: %w", address, err)
+	}
+	return g.KiemTraIP(ap.Addr())
+}
+
+// KiemTraHost phân giải host và kiểm tra mọi địa chỉ trả về
+// Dùng để báo lỗi sớm khi người dùng nhập URL; không thay thế Control lúc kết nối
+func (g *Guard) KiemTraHost(ctx context.Context, host string) error {
+	if ip, err := netip.ParseAddr(host); err == nil {
+		return g.KiemTraIP(ip)
+	}
+
+	ips, err := net.DefaultResolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("không phân giải được host %q: %w", host, err)
+	}
+	for _, ip := range ips {
+		if err := g.KiemTraIP(ip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Transport tạo http.Transport chỉ kết nối tới địa chỉ Guard cho phép
+// Không dùng proxy từ biến môi trường: proxy kết nối thay nên sẽ bỏ qua kiểm tra
+func (g *Guard) Transport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = nil
+	t.DialContext = (&net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.Control,
+	}).DialContext
+	return t
+}
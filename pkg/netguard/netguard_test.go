@@ -0,0 +1,93 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+)
+
+func TestGuard_KiemTraIP(t *testing.T) {
+	tests := []struct {
+		name    string
+		choPhep []string
+		ip      string
+		biChan  bool
+	}{
+		{name: "IP công khai", ip: "93.184.216.34"},
+		{name: "IPv6 công khai", ip: "2606:2800:220:1:248:1893:25c8:1946"},
+		{name: "loopback", ip: "127.0.0.1", biChan: true},
+		{name: "loopback IPv6", ip: "::1", biChan: true},
+		{name: "RFC 1918 10/8", ip: "10.1.2.3", biChan: true},
+		{name: "RFC 1918 172.16/12", ip: "172.31.255.1", biChan: true},
+		{name: "RFC 1918 192.168/16", ip: "192.168.1.1", biChan: true},
+		{name: "IPv6 ULA", ip: "fd00::1", biChan: true},
+		{name: "metadata link-local", ip: "169.254.169.254", biChan: true},
+		{name: "link-local IPv6", ip: "fe80::1", biChan: true},
+		{name: "unspecified", ip: "0.0.0.0", biChan: true},
+		{name: "unspecified IPv6", ip: "::", biChan: true},
+		{name: "multicast", ip: "224.0.0.1", biChan: true},
+		{name: "CGNAT", ip: "100.64.0.1", biChan: true},
+		{name: "IPv4-mapped loopback", ip: "::ffff:127.0.0.1", biChan: true},
+		{name: "allow-list CIDR", choPhep: []string{"127.0.0.0/8"}, ip: "127.0.0.1"},
+		{name: "allow-list IP đơn", choPhep: []string{"10.0.0.5"}, ip: "10.0.0.5"},
+		{name: "ngoài allow-list", choPhep: []string{"10.0.0.5"}, ip: "10.0.0.6", biChan: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New(tt.choPhep)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			err = g.KiemTraIP(netip.MustParseAddr(tt.ip))
+			if got := errors.Is(err, ErrDiaChiNoiBo); got != tt.biChan {
+				t.Errorf("KiemTraIP(%s) error = %v, want blocked = %v", tt.ip, err, tt.biChan)
+			}
+		})
+	}
+}
+
+func TestNew_AllowListKhongHopLe(t *testing.T) {
+	if _, err := New([]string{"localhost"}); err == nil {
+		t.Error("New() error = nil, want error for non-IP entry")
+	}
+}
+
+func TestGuard_KiemTraHost(t *testing.T) {
+	g, _ := New(nil)
+	ctx := context.Background()
+
+	if err := g.KiemTraHost(ctx, "localhost"); !errors.Is(err, ErrDiaChiNoiBo) {
+		t.Errorf("KiemTraHost(localhost) error = %v, want ErrDiaChiNoiBo", err)
+	}
+	if err := g.KiemTraHost(ctx, "169.254.169.254"); !errors.Is(err, ErrDiaChiNoiBo) {
+		t.Errorf("KiemTraHost(169.254.169.254) error = %v, want ErrDiaChiNoiBo", err)
+	}
+}
+
+func TestGuard_Transport(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	// Chặn lúc kết nối: không phụ thuộc URL đã được kiểm tra trước đó hay chưa
+	macDinh, _ := New(nil)
+	_, err := (&http.Client{Transport: macDinh.Transport()}).Get(srv.URL)
+	if !errors.Is(err, ErrDiaChiNoiBo) {
+		t.Errorf("Get(%s) error = %v, want ErrDiaChiNoiBo", srv.URL, err)
+	}
+
+	choPhep, _ := New([]string{"127.0.0.0/8", "::1"})
+	resp, err := (&http.Client{Transport: choPhep.Transport()}).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get(%s) with allow-list error = %v", srv.URL, err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
@@ -8,6 +8,9 @@ import (
 // DefaultCost là cost mặc định cho bcrypt (12 được khuyến nghị cho production)
 const DefaultCost = 12
 
+// ErrTooLong trả về khi password dài hơn 72 byte (giới hạn của bcrypt)
+var ErrTooLong = bcrypt.ErrPasswordTooLong
+
 // Hash tạo bcrypt hash từ password plaintext
 func Hash(password string) (string, error) {
 	bytes, err := bcrypt.GenerateFromPassword([]byte(password), DefaultCost)
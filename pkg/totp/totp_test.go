@@ -0,0 +1,138 @@
+package totp
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret là secret ASCII "12345678901234567890" của RFC 6238 phụ lục B, dạng base32
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode_RFC6238(t *testing.T) {
+	// Mã 8 chữ số của RFC lấy 6 chữ số cuối
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Counter(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("Code(%d): %v", tt.unix, err)
+		}
+		if got != tt.want {
+			t.Errorf("Code(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestCode_LowercaseSecret(t *testing.T) {
+	upper, _ := Code(rfcSecret, 1)
+	lower, err := Code(strings.ToLower(rfcSecret), 1)
+	if err != nil || lower != upper {
+		t.Fatalf("Code(lowercase) = %q, %v; want %q", lower, err, upper)
+	}
+}
+
+func TestCode_InvalidSecret(t *testing.T) {
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Fatal("expected error for invalid secret")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	counter := Counter(now)
+	code := func(c int64) string {
+		s, err := Code(rfcSecret, c)
+		if err != nil {
+			t.Fatalf("Code: %v", err)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name        string
+		code        string
+		skew        int64
+		wantOK      bool
+		wantCounter int64
+	}{
+		{name: "mã bước hiện tại", code: code(counter), skew: 1, wantOK: true, wantCounter: counter},
+		{name: "có khoảng trắng thừa", code: " " + code(counter) + " ", skew: 1, wantOK: true, wantCounter: counter},
+		{name: "lệch một bước về trước", code: code(counter - 1), skew: 1, wantOK: true, wantCounter: counter - 1},
+		{name: "lệch một bước về sau", code: code(counter + 1), skew: 1, wantOK: true, wantCounter: counter + 1},
+		{name: "lệch quá skew", code: code(counter - 2), skew: 1},
+		{name: "không cho lệch", code: code(counter - 1), skew: 0},
+		{name: "sai độ dài", code: "12345", skew: 1},
+		{name: "sai mã", code: "000000", skew: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Validate(rfcSecret, tt.code, now, tt.skew)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.wantCounter {
+				t.Errorf("counter = %d, want %d", got, tt.wantCounter)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	b, _ := GenerateSecret()
+
+	if a == b {
+		t.Error("two secrets are equal")
+	}
+	// 20 byte → 32 ký tự base32 không padding
+	if len(a) != 32 {
+		t.Errorf("len(secret) = %d, want 32", len(a))
+	}
+	if _, err := Code(a, 1); err != nil {
+		t.Errorf("generated secret is not usable: %v", err)
+	}
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Nhà hàng ABC", "khach@example.com", rfcSecret)
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Nh%C3%A0%20h%C3%A0ng%20ABC:khach@example.com?") {
+		t.Fatalf("URI = %s", uri)
+	}
+	if strings.Contains(uri, "+") {
+		t.Errorf("URI must encode spaces as %%20: %s", uri)
+	}
+
+	q, err := url.ParseQuery(uri[strings.Index(uri, "?")+1:])
+	if err != nil {
+		t.Fatalf("ParseQuery: %v", err)
+	}
+	want := map[string]string{
+		"secret":    rfcSecret,
+		"issuer":    "Nhà hàng ABC",
+		"algorithm": "SHA1",
+		"digits":    "6",
+		"period":    "30",
+	}
+	for k, v := range want {
+		if q.Get(k) != v {
+			t.Errorf("%s = %q, want %q", k, q.Get(k), v)
+		}
+	}
+}